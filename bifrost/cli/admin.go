@@ -80,7 +80,9 @@ func NewAdminCmd() *AdminCmd {
 	addAdminRealmCommands(admin)
 	addAdminAccountCommands(admin)
 	addAdminPATCommands(admin)
+	addAdminGroupCommands(admin)
 	addAdminRebuildCommands(admin)
+	addAdminAuditCommands(admin)
 	addAdminBootstrapCommands(admin)
 
 	return admin
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -10,8 +13,12 @@ import (
 
 func addAdminAccountCommands(admin *AdminCmd) {
 	admin.Command.AddCommand(newAdminCreateAccountCmd(admin))
+	admin.Command.AddCommand(newAdminCreateServiceAccountCmd(admin))
 	admin.Command.AddCommand(newAdminListAccountsCmd(admin))
 	admin.Command.AddCommand(newAdminSuspendAccountCmd(admin))
+	admin.Command.AddCommand(newAdminReactivateAccountCmd(admin))
+	admin.Command.AddCommand(newAdminRenameAccountCmd(admin))
+	admin.Command.AddCommand(newAdminDeleteAccountCmd(admin))
 	admin.Command.AddCommand(newAdminGrantCmd(admin))
 	admin.Command.AddCommand(newAdminRevokeCmd(admin))
 	admin.Command.AddCommand(newAdminAssignRoleCmd(admin))
@@ -61,15 +68,74 @@ func newAdminCreateAccountCmd(admin *AdminCmd) *cobra.Command {
 	}
 }
 
+func newAdminCreateServiceAccountCmd(admin *AdminCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-service-account <username>",
+		Short: "Create a service account restricted to one realm",
+		Long: `Create a service account for an agent. Service accounts are restricted to a
+single realm, cannot sign in to the UI, and are owned by a human account
+(the caller unless --owner is given). Their claims are labelled as agent work.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			realmID, _ := cmd.Flags().GetString("realm")
+			role, _ := cmd.Flags().GetString("role")
+			owner, _ := cmd.Flags().GetString("owner")
+
+			req := map[string]string{
+				"username": args[0],
+				"realm_id": realmID,
+				"role":     role,
+			}
+			if owner != "" {
+				ownerID, err := resolveUsernameViaAPI(admin.Client, owner)
+				if err != nil {
+					return err
+				}
+				req["owner_id"] = ownerID
+			}
+
+			resp, err := admin.Client.DoPost("/api/create-service-account", req)
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				fmt.Fprintln(cmd.OutOrStdout(), string(resp))
+				return nil
+			}
+
+			var result map[string]string
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Account ID: %s\n", result["account_id"])
+			fmt.Fprintf(cmd.OutOrStdout(), "Token: %s\n", result["pat"])
+			fmt.Fprintln(cmd.OutOrStdout(), "Save this token — it will not be shown again")
+			return nil
+		},
+	}
+	cmd.Flags().String("realm", "", "realm the service account is restricted to (required)")
+	cmd.Flags().String("role", "member", "role in the realm (viewer, member or admin)")
+	cmd.Flags().String("owner", "", "username of the owning human account (default: you)")
+	_ = cmd.MarkFlagRequired("realm")
+	return cmd
+}
+
 func newAdminListAccountsCmd(admin *AdminCmd) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list-accounts",
 		Short: "List all accounts",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonMode, _ := cmd.Flags().GetBool("json")
+			kind, _ := cmd.Flags().GetString("kind")
 
-			resp, err := admin.Client.DoGet("/api/accounts")
+			path := "/api/accounts"
+			if kind != "" {
+				path += "?kind=" + kind
+			}
+			resp, err := admin.Client.DoGet(path)
 			if err != nil {
 				return err
 			}
@@ -82,6 +148,7 @@ func newAdminListAccountsCmd(admin *AdminCmd) *cobra.Command {
 			var entries []struct {
 				AccountID string `json:"account_id"`
 				Username  string `json:"username"`
+				Kind      string `json:"kind"`
 				Status    string `json:"status"`
 				Realms    []string `json:"realms"`
 				PATCount  int    `json:"pat_count"`
@@ -91,16 +158,21 @@ func newAdminListAccountsCmd(admin *AdminCmd) *cobra.Command {
 			}
 
 			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "ID\tUsername\tStatus\tRealms\tPATs")
-			fmt.Fprintln(w, "--\t--------\t------\t------\t----")
+			fmt.Fprintln(w, "ID\tUsername\tKind\tStatus\tRealms\tPATs")
+			fmt.Fprintln(w, "--\t--------\t----\t------\t------\t----")
 			for _, e := range entries {
 				realms := fmt.Sprintf("%d", len(e.Realms))
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", e.AccountID, e.Username, e.Status, realms, e.PATCount)
+				if e.Kind == "" {
+					e.Kind = "human"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n", e.AccountID, e.Username, e.Kind, e.Status, realms, e.PATCount)
 			}
 			w.Flush()
 			return nil
 		},
 	}
+	cmd.Flags().String("kind", "", "only list accounts of this kind (human or service)")
+	return cmd
 }
 
 func newAdminSuspendAccountCmd(admin *AdminCmd) *cobra.Command {
@@ -134,6 +206,120 @@ func newAdminSuspendAccountCmd(admin *AdminCmd) *cobra.Command {
 	}
 }
 
+func newAdminReactivateAccountCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reactivate-account <username>",
+		Short: "Reactivate a suspended account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			accountID, err := resolveUsernameViaAPI(admin.Client, args[0])
+			if err != nil {
+				return err
+			}
+
+			req := map[string]string{"account_id": accountID}
+			_, err = admin.Client.DoPost("/api/reactivate-account", req)
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"status": "active"})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Account %s reactivated\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newAdminRenameAccountCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename-account <username> <new-username>",
+		Short: "Change an account's username",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			accountID, err := resolveUsernameViaAPI(admin.Client, args[0])
+			if err != nil {
+				return err
+			}
+
+			req := map[string]string{
+				"account_id": accountID,
+				"username":   args[1],
+			}
+			_, err = admin.Client.DoPost("/api/rename-account", req)
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"account_id": accountID, "username": args[1]})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Account %s renamed to %s\n", args[0], args[1])
+			return nil
+		},
+	}
+}
+
+func newAdminDeleteAccountCmd(admin *AdminCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete-account <username>",
+		Short: "Permanently delete an account and revoke its PATs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			username := args[0]
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			confirm, _ := cmd.Flags().GetBool("confirm")
+
+			accountID, err := resolveUsernameViaAPI(admin.Client, username)
+			if err != nil {
+				return err
+			}
+
+			if !confirm {
+				fmt.Fprintf(cmd.OutOrStdout(), "This permanently deletes account %s and revokes its PATs.\nType the username to confirm: ", username)
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && err != io.EOF {
+					return fmt.Errorf("failed to read user input: %w", err)
+				}
+				if strings.TrimSpace(line) != username {
+					fmt.Fprintln(cmd.OutOrStdout(), "Aborted")
+					return nil
+				}
+			}
+
+			req := map[string]string{"account_id": accountID}
+			_, err = admin.Client.DoPost("/api/delete-account", req)
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"status": "deleted"})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Account %s deleted\n", username)
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("confirm", false, "skip interactive confirmation prompt")
+
+	return cmd
+}
+
 func newAdminGrantCmd(admin *AdminCmd) *cobra.Command {
 	return &cobra.Command{
 		Use:   "grant <username> <realm-id>",
@@ -3,6 +3,7 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -84,6 +85,89 @@ func TestAdminListAccounts(t *testing.T) {
 	})
 }
 
+func TestAdminCreateServiceAccount(t *testing.T) {
+	t.Run("creates a service account in a realm", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_create_account("acct-bot", "pat-token-xyz")
+
+		// When
+		tc.run_account_cmd("create-service-account", "ci-bot", "--realm", "bf-c3d4")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/create-service-account")
+		tc.posted_body_has("username", "ci-bot")
+		tc.posted_body_has("realm_id", "bf-c3d4")
+		tc.posted_body_has("role", "member")
+		tc.output_contains("Token:")
+	})
+
+	t.Run("resolves the owner username", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-owner")
+		tc.api_returns_create_account("acct-bot", "pat-token-xyz")
+
+		// When
+		tc.run_account_cmd("create-service-account", "ci-bot", "--realm", "bf-c3d4", "--owner", "alice")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_body_has("owner_id", "acct-owner")
+	})
+
+	t.Run("requires a realm", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+
+		// When
+		tc.run_account_cmd("create-service-account", "ci-bot")
+
+		// Then
+		tc.error_occurred()
+	})
+}
+
+func TestAdminListAccountsKind(t *testing.T) {
+	t.Run("shows the kind column", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_accounts_list()
+
+		// When
+		tc.run_list_accounts()
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("Kind")
+		tc.output_contains("human")
+	})
+
+	t.Run("filters by kind", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_accounts_list()
+
+		// When
+		tc.run_account_cmd("list-accounts", "--kind", "service")
+
+		// Then
+		tc.command_has_no_error()
+		tc.requested("/api/accounts?kind=service")
+	})
+}
+
 func TestAdminSuspendAccount(t *testing.T) {
 	t.Run("suspends account and prints confirmation", func(t *testing.T) {
 		tc := newAdminAccountTestContext(t)
@@ -133,6 +217,80 @@ func TestAdminSuspendAccount(t *testing.T) {
 	})
 }
 
+func TestAdminAccountLifecycle(t *testing.T) {
+	t.Run("reactivates an account", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_success()
+
+		// When
+		tc.run_account_cmd("reactivate-account", "alice")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/reactivate-account")
+		tc.output_contains("Account alice reactivated")
+	})
+
+	t.Run("renames an account", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_success()
+
+		// When
+		tc.run_account_cmd("rename-account", "alice", "alicia")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/rename-account")
+		tc.posted_body_has("username", "alicia")
+		tc.output_contains("renamed to alicia")
+	})
+
+	t.Run("deletes an account after the username is typed", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_success()
+		tc.cmd.SetIn(strings.NewReader("alice\n"))
+
+		// When
+		tc.run_account_cmd("delete-account", "alice")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/delete-account")
+		tc.posted_body_has("account_id", "acct-1234")
+		tc.output_contains("Account alice deleted")
+	})
+
+	t.Run("aborts delete when the typed username does not match", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_success()
+		tc.cmd.SetIn(strings.NewReader("y\n"))
+
+		// When
+		tc.run_account_cmd("delete-account", "alice")
+
+		// Then
+		tc.command_has_no_error()
+		assert.Empty(t, tc.mock.postPaths)
+		tc.output_contains("Aborted")
+	})
+}
+
 func TestAdminGrant(t *testing.T) {
 	t.Run("grants realm access as member and prints confirmation", func(t *testing.T) {
 		tc := newAdminAccountTestContext(t)
@@ -475,8 +633,28 @@ func (tc *adminAccountTestContext) run_revoke_role_json(username, realmID string
 	tc.output, tc.err = executeAdminCmd(tc.cmd, "revoke-role", username, realmID, "--json")
 }
 
+func (tc *adminAccountTestContext) run_account_cmd(args ...string) {
+	tc.t.Helper()
+	tc.output, tc.err = executeAdminCmd(tc.cmd, args...)
+}
+
 // --- Then ---
 
+func (tc *adminAccountTestContext) posted_to(path string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, []string{path}, tc.mock.postPaths)
+}
+
+func (tc *adminAccountTestContext) requested(path string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.mock.getPaths, path)
+}
+
+func (tc *adminAccountTestContext) posted_body_has(key, expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.mock.postBody[key])
+}
+
 func (tc *adminAccountTestContext) command_has_no_error() {
 	tc.t.Helper()
 	require.NoError(tc.t, tc.err)
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestAdminAudit(t *testing.T) {
+	t.Run("shows entries as a table", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_audit_entries()
+
+		// When
+		tc.run_account_cmd("audit")
+
+		// Then
+		tc.command_has_no_error()
+		tc.requested("/api/audit")
+		tc.output_contains("PATRevoked")
+		tc.output_contains("root")
+		tc.output_contains("LoginFailed")
+		tc.output_contains("10.0.0.1")
+	})
+
+	t.Run("prints the raw entries with --json", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_audit_entries()
+
+		// When
+		tc.run_account_cmd("audit", "--json")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_is_valid_json_array()
+	})
+
+	t.Run("passes filters as query parameters", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_audit_entries()
+
+		// When
+		tc.run_account_cmd("audit", "--actor", "root", "--target", "acct-1", "--action", "PATRevoked",
+			"--since", "2026-03-01T00:00:00Z", "--limit", "5")
+
+		// Then
+		tc.command_has_no_error()
+		query := tc.audit_query()
+		assert.Equal(t, "root", query.Get("actor"))
+		assert.Equal(t, "acct-1", query.Get("target"))
+		assert.Equal(t, "PATRevoked", query.Get("action"))
+		assert.Equal(t, "2026-03-01T00:00:00Z", query.Get("since"))
+		assert.Equal(t, "5", query.Get("limit"))
+	})
+
+	t.Run("rejects an invalid time", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+
+		// When
+		tc.run_account_cmd("audit", "--until", "soon")
+
+		// Then
+		tc.error_occurred()
+		assert.Empty(t, tc.mock.getPaths)
+	})
+}
+
+// --- Given ---
+
+func (tc *adminAccountTestContext) api_returns_audit_entries() {
+	tc.t.Helper()
+	tc.mock.getResponses = append(tc.mock.getResponses, mustMarshal([]map[string]any{
+		{
+			"id":             "evt-00000000000000000003",
+			"timestamp":      "2026-03-01T14:00:00Z",
+			"action":         "PATRevoked",
+			"actor_id":       "acct-admin",
+			"actor_username": "root",
+			"target_id":      "pat-9",
+			"account_id":     "acct-1",
+		},
+		{
+			"id":        "sec-1",
+			"timestamp": "2026-03-01T13:00:00Z",
+			"action":    "LoginFailed",
+			"ip":        "10.0.0.1",
+			"detail":    `method=pat reason="invalid authentication token"`,
+		},
+	}))
+}
+
+// --- Then ---
+
+func (tc *adminAccountTestContext) audit_query() url.Values {
+	tc.t.Helper()
+	require.Len(tc.t, tc.mock.getPaths, 1)
+	parsed, err := url.Parse(tc.mock.getPaths[0])
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, "/api/audit", parsed.Path)
+	return parsed.Query()
+}
@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func addAdminGroupCommands(admin *AdminCmd) {
+	admin.Command.AddCommand(newAdminGroupCmd(admin))
+}
+
+// newAdminGroupCmd groups the group commands as "bf admin group ...".
+func newAdminGroupCmd(admin *AdminCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Manage groups of accounts and the roles granted to them",
+		Long: `Manage groups. Every member of a group holds the roles granted to the group,
+in addition to their own. Where both grant a role in the same realm, the
+higher role applies.`,
+	}
+
+	cmd.AddCommand(
+		newAdminGroupCreateCmd(admin),
+		newAdminGroupListCmd(admin),
+		newAdminGroupDeleteCmd(admin),
+		newAdminGroupAddMemberCmd(admin),
+		newAdminGroupRemoveMemberCmd(admin),
+		newAdminGroupAssignRoleCmd(admin),
+		newAdminGroupRevokeRoleCmd(admin),
+	)
+	return cmd
+}
+
+type groupListEntry struct {
+	GroupID string `json:"group_id"`
+	Name    string `json:"name"`
+	Members []struct {
+		AccountID string `json:"account_id"`
+		Username  string `json:"username"`
+	} `json:"members"`
+	Roles map[string]string `json:"roles"`
+}
+
+// resolveGroupViaAPI returns the ID of the group with the given name or ID.
+func resolveGroupViaAPI(client *Client, group string) (string, error) {
+	resp, err := client.DoGet("/api/groups")
+	if err != nil {
+		return "", err
+	}
+	var groups []groupListEntry
+	if err := json.Unmarshal(resp, &groups); err != nil {
+		return "", err
+	}
+	for _, g := range groups {
+		if g.GroupID == group || strings.EqualFold(g.Name, group) {
+			return g.GroupID, nil
+		}
+	}
+	return "", fmt.Errorf("group %q not found", group)
+}
+
+func newAdminGroupCreateCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a group",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			resp, err := admin.Client.DoPost("/api/create-group", map[string]string{"name": args[0]})
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				fmt.Fprintln(cmd.OutOrStdout(), string(resp))
+				return nil
+			}
+
+			var result map[string]string
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Group ID: %s\n", result["group_id"])
+			return nil
+		},
+	}
+}
+
+func newAdminGroupListCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List groups with their members and roles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			resp, err := admin.Client.DoGet("/api/groups")
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				fmt.Fprintln(cmd.OutOrStdout(), string(resp))
+				return nil
+			}
+
+			var groups []groupListEntry
+			if err := json.Unmarshal(resp, &groups); err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tName\tMembers\tRoles")
+			fmt.Fprintln(w, "--\t----\t-------\t-----")
+			for _, g := range groups {
+				members := make([]string, 0, len(g.Members))
+				for _, m := range g.Members {
+					if m.Username != "" {
+						members = append(members, m.Username)
+					} else {
+						members = append(members, m.AccountID)
+					}
+				}
+				roles := make([]string, 0, len(g.Roles))
+				for realmID, role := range g.Roles {
+					roles = append(roles, realmID+":"+role)
+				}
+				sort.Strings(roles)
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", g.GroupID, g.Name, strings.Join(members, ", "), strings.Join(roles, ", "))
+			}
+			w.Flush()
+			return nil
+		},
+	}
+}
+
+func newAdminGroupDeleteCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <group>",
+		Short: "Delete a group; its members lose the roles it granted",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			groupID, err := resolveGroupViaAPI(admin.Client, args[0])
+			if err != nil {
+				return err
+			}
+
+			if _, err := admin.Client.DoPost("/api/delete-group", map[string]string{"group_id": groupID}); err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"group_id": groupID, "status": "deleted"})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Group %s deleted\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newAdminGroupAddMemberCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add-member <group> <username>...",
+		Short: "Add accounts to a group",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGroupMembership(cmd, admin, "/api/add-group-member", args, "Added %s to group %s\n")
+		},
+	}
+}
+
+func newAdminGroupRemoveMemberCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-member <group> <username>...",
+		Short: "Remove accounts from a group",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGroupMembership(cmd, admin, "/api/remove-group-member", args, "Removed %s from group %s\n")
+		},
+	}
+}
+
+// runGroupMembership posts one membership change per username in args[1:].
+func runGroupMembership(cmd *cobra.Command, admin *AdminCmd, path string, args []string, format string) error {
+	jsonMode, _ := cmd.Flags().GetBool("json")
+
+	groupID, err := resolveGroupViaAPI(admin.Client, args[0])
+	if err != nil {
+		return err
+	}
+
+	accountIDs := make([]string, 0, len(args)-1)
+	for _, username := range args[1:] {
+		accountID, err := resolveUsernameViaAPI(admin.Client, username)
+		if err != nil {
+			return err
+		}
+		accountIDs = append(accountIDs, accountID)
+	}
+
+	for i, accountID := range accountIDs {
+		req := map[string]string{"group_id": groupID, "account_id": accountID}
+		if _, err := admin.Client.DoPost(path, req); err != nil {
+			return err
+		}
+		if !jsonMode {
+			fmt.Fprintf(cmd.OutOrStdout(), format, args[i+1], args[0])
+		}
+	}
+
+	if jsonMode {
+		out, _ := json.Marshal(map[string]any{"group_id": groupID, "account_ids": accountIDs})
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+	}
+	return nil
+}
+
+func newAdminGroupAssignRoleCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "assign-role <group> <realm-id> <role>",
+		Short: "Grant a role in a realm to every member of a group",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			groupID, err := resolveGroupViaAPI(admin.Client, args[0])
+			if err != nil {
+				return err
+			}
+
+			req := map[string]string{
+				"group_id": groupID,
+				"realm_id": args[1],
+				"role":     args[2],
+			}
+			if _, err := admin.Client.DoPost("/api/assign-group-role", req); err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"status": "assigned"})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Assigned role %s to group %s in realm %s\n", args[2], args[0], args[1])
+			return nil
+		},
+	}
+}
+
+func newAdminGroupRevokeRoleCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-role <group> <realm-id>",
+		Short: "Revoke a group's role in a realm",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			groupID, err := resolveGroupViaAPI(admin.Client, args[0])
+			if err != nil {
+				return err
+			}
+
+			req := map[string]string{
+				"group_id": groupID,
+				"realm_id": args[1],
+			}
+			if _, err := admin.Client.DoPost("/api/revoke-group-role", req); err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"status": "revoked"})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Revoked group %s role in realm %s\n", args[0], args[1])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// --- Tests ---
+
+func TestAdminGroupCommands(t *testing.T) {
+	t.Run("create posts the group name", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.mock.postResponse = mustMarshal(map[string]string{"group_id": "grp-1"})
+
+		// When
+		tc.run_account_cmd("group", "create", "squad")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/create-group")
+		tc.posted_body_has("name", "squad")
+		tc.output_contains("grp-1")
+	})
+
+	t.Run("list shows members and roles", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_groups_list()
+
+		// When
+		tc.run_account_cmd("group", "list")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("squad")
+		tc.output_contains("alice")
+		tc.output_contains("bf-c3d4:member")
+	})
+
+	t.Run("add-member resolves the group and each username", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_groups_list()
+		tc.api_returns_resolve_username("acct-2")
+		tc.api_returns_success()
+
+		// When
+		tc.run_account_cmd("group", "add-member", "squad", "bob")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/add-group-member")
+		tc.posted_body_has("group_id", "grp-1")
+		tc.posted_body_has("account_id", "acct-2")
+		tc.output_contains("Added bob to group squad")
+	})
+
+	t.Run("add-member posts once per username", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_groups_list()
+		tc.api_returns_resolve_username("acct-2")
+		tc.api_returns_resolve_username("acct-3")
+		tc.api_returns_success()
+
+		// When
+		tc.run_account_cmd("group", "add-member", "squad", "bob", "carol")
+
+		// Then
+		tc.command_has_no_error()
+		assert.Equal(t, []string{"/api/add-group-member", "/api/add-group-member"}, tc.mock.postPaths)
+	})
+
+	t.Run("assign-role posts the realm and role", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_groups_list()
+		tc.api_returns_success()
+
+		// When
+		tc.run_account_cmd("group", "assign-role", "squad", "bf-c3d4", "admin")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/assign-group-role")
+		tc.posted_body_has("group_id", "grp-1")
+		tc.posted_body_has("realm_id", "bf-c3d4")
+		tc.posted_body_has("role", "admin")
+	})
+
+	t.Run("fails for an unknown group", func(t *testing.T) {
+		tc := newAdminAccountTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_groups_list()
+
+		// When
+		tc.run_account_cmd("group", "delete", "crew")
+
+		// Then
+		tc.error_occurred()
+		assert.Empty(t, tc.mock.postPaths)
+	})
+}
+
+// --- Given ---
+
+func (tc *adminAccountTestContext) api_returns_groups_list() {
+	tc.t.Helper()
+	tc.mock.getResponses = append(tc.mock.getResponses, mustMarshal([]map[string]any{
+		{
+			"group_id": "grp-1",
+			"name":     "squad",
+			"members":  []map[string]string{{"account_id": "acct-1", "username": "alice"}},
+			"roles":    map[string]string{"bf-c3d4": "member"},
+		},
+	}))
+}
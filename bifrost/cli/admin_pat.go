@@ -3,7 +3,9 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -12,6 +14,25 @@ func addAdminPATCommands(admin *AdminCmd) {
 	admin.Command.AddCommand(newAdminCreatePATCmd(admin))
 	admin.Command.AddCommand(newAdminListPATsCmd(admin))
 	admin.Command.AddCommand(newAdminRevokePATCmd(admin))
+	admin.Command.AddCommand(newAdminPATCmd(admin))
+}
+
+// newAdminPATCmd groups the PAT commands as "bf admin pat create|list|revoke".
+func newAdminPATCmd(admin *AdminCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pat",
+		Short: "Manage personal access tokens",
+	}
+
+	create := newAdminCreatePATCmd(admin)
+	create.Use = "create <username>"
+	list := newAdminListPATsCmd(admin)
+	list.Use = "list <username>"
+	revoke := newAdminRevokePATCmd(admin)
+	revoke.Use = "revoke <username> <pat-id>"
+	cmd.AddCommand(create, list, revoke, newAdminRevokeStalePATsCmd(admin))
+
+	return cmd
 }
 
 func newAdminCreatePATCmd(admin *AdminCmd) *cobra.Command {
@@ -22,16 +43,28 @@ func newAdminCreatePATCmd(admin *AdminCmd) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonMode, _ := cmd.Flags().GetBool("json")
 			label, _ := cmd.Flags().GetString("label")
+			expires, _ := cmd.Flags().GetString("expires")
+
+			req := map[string]any{
+				"label": label,
+			}
+			if expires != "" {
+				expiresAt, err := parseTimeFlag(expires, time.Now())
+				if err != nil {
+					return err
+				}
+				req["expires_at"] = expiresAt.Format(time.RFC3339)
+			}
+			if scopes := patScopesFromFlags(cmd); !scopes.isEmpty() {
+				req["scopes"] = scopes
+			}
 
 			accountID, err := resolveUsernameViaAPI(admin.Client, args[0])
 			if err != nil {
 				return err
 			}
+			req["account_id"] = accountID
 
-			req := map[string]string{
-				"account_id": accountID,
-				"label":      label,
-			}
 			resp, err := admin.Client.DoPost("/api/create-pat", req)
 			if err != nil {
 				return err
@@ -48,23 +81,43 @@ func newAdminCreatePATCmd(admin *AdminCmd) *cobra.Command {
 			}
 			fmt.Fprintf(cmd.OutOrStdout(), "PAT ID: %s\n", result["pat_id"])
 			fmt.Fprintf(cmd.OutOrStdout(), "Token: %s\n", result["pat"])
+			if result["expires_at"] != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Expires: %s\n", result["expires_at"])
+			}
+			if scopes, ok := req["scopes"].(patScopes); ok {
+				fmt.Fprintf(cmd.OutOrStdout(), "Scopes: %s\n", scopes.String())
+			}
 			fmt.Fprintln(cmd.OutOrStdout(), "Save this token — it will not be shown again")
 			return nil
 		},
 	}
 
 	cmd.Flags().String("label", "", "optional label for the PAT")
+	cmd.Flags().String("expires", "", "expiry as a duration from now (30d, 12h) or a date (YYYY-MM-DD, RFC 3339)")
+	cmd.Flags().StringSlice("realm", nil, "realm ID the PAT may access (repeatable, default all of the account's realms)")
+	cmd.Flags().Bool("read-only", false, "cap the PAT at the viewer role")
+	cmd.Flags().StringSlice("scope", nil, "API endpoint the PAT may call, e.g. runes,claim-rune (repeatable, default all)")
 
 	return cmd
 }
 
 func newAdminListPATsCmd(admin *AdminCmd) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list-pats <username>",
 		Short: "List personal access tokens for an account",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonMode, _ := cmd.Flags().GetBool("json")
+			staleFlag, _ := cmd.Flags().GetString("stale")
+
+			var staleBefore time.Time
+			if staleFlag != "" {
+				d, err := parseDuration(staleFlag)
+				if err != nil {
+					return err
+				}
+				staleBefore = time.Now().Add(-d)
+			}
 
 			accountID, err := resolveUsernameViaAPI(admin.Client, args[0])
 			if err != nil {
@@ -76,30 +129,57 @@ func newAdminListPATsCmd(admin *AdminCmd) *cobra.Command {
 				return err
 			}
 
-			if jsonMode {
-				fmt.Fprintln(cmd.OutOrStdout(), string(resp))
-				return nil
+			var raw []json.RawMessage
+			if err := json.Unmarshal(resp, &raw); err != nil {
+				return err
 			}
-
-			var pats []struct {
-				ID        string `json:"id"`
-				Label     string `json:"label"`
-				CreatedAt string `json:"created_at"`
+			kept := make([]json.RawMessage, 0, len(raw))
+			pats := make([]patListEntry, 0, len(raw))
+			for _, r := range raw {
+				var p patListEntry
+				if err := json.Unmarshal(r, &p); err != nil {
+					return err
+				}
+				if !staleBefore.IsZero() && !p.lastActive().Before(staleBefore) {
+					continue
+				}
+				kept = append(kept, r)
+				pats = append(pats, p)
 			}
-			if err := json.Unmarshal(resp, &pats); err != nil {
-				return err
+
+			if jsonMode {
+				out, err := json.Marshal(kept)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
 			}
 
 			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "PAT ID\tLabel\tCreated")
-			fmt.Fprintln(w, "------\t-----\t-------")
+			fmt.Fprintln(w, "PAT ID\tLabel\tCreated\tExpires\tLast Used\tRequests\tScopes")
+			fmt.Fprintln(w, "------\t-----\t-------\t-------\t---------\t--------\t------")
 			for _, p := range pats {
-				fmt.Fprintf(w, "%s\t%s\t%s\n", p.ID, p.Label, p.CreatedAt)
+				expires := p.ExpiresAt
+				if expires == "" {
+					expires = "never"
+				}
+				lastUsed := p.LastUsed
+				if lastUsed == "" {
+					lastUsed = "never"
+				} else if p.LastIP != "" {
+					lastUsed += " from " + p.LastIP
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", p.ID, p.Label, p.CreatedAt, expires, lastUsed, p.RequestCount, p.Scopes.String())
 			}
 			w.Flush()
 			return nil
 		},
 	}
+
+	cmd.Flags().String("stale", "", "only list PATs unused for at least this long, e.g. 30d")
+
+	return cmd
 }
 
 func newAdminRevokePATCmd(admin *AdminCmd) *cobra.Command {
@@ -135,3 +215,122 @@ func newAdminRevokePATCmd(admin *AdminCmd) *cobra.Command {
 		},
 	}
 }
+
+func newAdminRevokeStalePATsCmd(admin *AdminCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-stale <username>",
+		Short: "Revoke an account's PATs that have not been used recently",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			staleFlag, _ := cmd.Flags().GetString("stale")
+
+			d, err := parseDuration(staleFlag)
+			if err != nil {
+				return err
+			}
+
+			accountID, err := resolveUsernameViaAPI(admin.Client, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := admin.Client.DoPost("/api/revoke-stale-pats", map[string]string{
+				"account_id":   accountID,
+				"unused_since": time.Now().Add(-d).UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				fmt.Fprintln(cmd.OutOrStdout(), string(resp))
+				return nil
+			}
+
+			var result struct {
+				Revoked []string `json:"revoked"`
+			}
+			if err := json.Unmarshal(resp, &result); err != nil {
+				return err
+			}
+			if len(result.Revoked) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No stale PATs")
+				return nil
+			}
+			for _, patID := range result.Revoked {
+				fmt.Fprintf(cmd.OutOrStdout(), "PAT %s revoked\n", patID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("stale", "", "revoke PATs unused for at least this long, e.g. 30d (required)")
+	_ = cmd.MarkFlagRequired("stale")
+
+	return cmd
+}
+
+// patListEntry is a PAT as returned by GET /api/pats.
+type patListEntry struct {
+	ID           string    `json:"id"`
+	Label        string    `json:"label"`
+	CreatedAt    string    `json:"created_at"`
+	ExpiresAt    string    `json:"expires_at"`
+	LastUsed     string    `json:"last_used"`
+	LastIP       string    `json:"last_ip"`
+	RequestCount int64     `json:"request_count"`
+	Scopes       patScopes `json:"scopes"`
+}
+
+// lastActive is when the PAT was last used, or its creation time if it never was.
+func (p patListEntry) lastActive() time.Time {
+	value := p.LastUsed
+	if value == "" {
+		value = p.CreatedAt
+	}
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
+
+// patScopes mirrors the server's PAT scopes in create-pat requests and
+// list-pats responses.
+type patScopes struct {
+	Realms   []string `json:"realms,omitempty"`
+	MaxRole  string   `json:"max_role,omitempty"`
+	Commands []string `json:"commands,omitempty"`
+}
+
+func patScopesFromFlags(cmd *cobra.Command) patScopes {
+	realms, _ := cmd.Flags().GetStringSlice("realm")
+	readOnly, _ := cmd.Flags().GetBool("read-only")
+	commands, _ := cmd.Flags().GetStringSlice("scope")
+
+	scopes := patScopes{Realms: realms, Commands: commands}
+	if readOnly {
+		scopes.MaxRole = "viewer"
+	}
+	return scopes
+}
+
+func (s patScopes) isEmpty() bool {
+	return len(s.Realms) == 0 && s.MaxRole == "" && len(s.Commands) == 0
+}
+
+// String summarizes the scopes for table output, or "all" when unscoped.
+func (s patScopes) String() string {
+	var parts []string
+	if len(s.Realms) > 0 {
+		parts = append(parts, "realms="+strings.Join(s.Realms, ","))
+	}
+	if s.MaxRole != "" {
+		parts = append(parts, "max-role="+s.MaxRole)
+	}
+	if len(s.Commands) > 0 {
+		parts = append(parts, "commands="+strings.Join(s.Commands, ","))
+	}
+	if len(parts) == 0 {
+		return "all"
+	}
+	return strings.Join(parts, " ")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -81,6 +82,78 @@ func TestAdminCreatePAT(t *testing.T) {
 	})
 }
 
+func TestAdminCreatePAT_Expires(t *testing.T) {
+	t.Run("sends an expiry computed from a duration", func(t *testing.T) {
+		tc := newAdminPATTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_create_pat("pat-5678", "pat-token-xyz")
+
+		// When
+		tc.output, tc.err = executeAdminCmd(tc.cmd, "pat", "create", "alice", "--expires", "30d")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_expiry_is_about(30 * 24 * time.Hour)
+	})
+
+	t.Run("rejects an invalid expiry before calling the API", func(t *testing.T) {
+		tc := newAdminPATTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+
+		// When
+		tc.output, tc.err = executeAdminCmd(tc.cmd, "create-pat", "alice", "--expires", "soon")
+
+		// Then
+		tc.error_occurred()
+		assert.Empty(t, tc.mock.postPaths)
+	})
+}
+
+func TestAdminCreatePAT_Scopes(t *testing.T) {
+	t.Run("sends realm, read-only and command scopes", func(t *testing.T) {
+		tc := newAdminPATTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_create_pat("pat-5678", "pat-token-xyz")
+
+		// When
+		tc.output, tc.err = executeAdminCmd(tc.cmd, "pat", "create", "alice",
+			"--realm", "realm-1", "--read-only", "--scope", "runes", "--scope", "rune")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_scopes_are(map[string]any{
+			"realms":   []any{"realm-1"},
+			"max_role": "viewer",
+			"commands": []any{"runes", "rune"},
+		})
+		tc.output_contains("Scopes: realms=realm-1 max-role=viewer commands=runes,rune")
+	})
+
+	t.Run("omits scopes when no scope flags are given", func(t *testing.T) {
+		tc := newAdminPATTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_create_pat("pat-5678", "pat-token-xyz")
+
+		// When
+		tc.output, tc.err = executeAdminCmd(tc.cmd, "create-pat", "alice")
+
+		// Then
+		tc.command_has_no_error()
+		assert.NotContains(t, tc.mock.postBody, "scopes")
+	})
+}
+
 func TestAdminListPATs(t *testing.T) {
 	t.Run("lists PATs in human-readable table", func(t *testing.T) {
 		tc := newAdminPATTestContext(t)
@@ -100,6 +173,7 @@ func TestAdminListPATs(t *testing.T) {
 		tc.output_contains("Created")
 		tc.output_contains("pat-5678")
 		tc.output_contains("my-token")
+		tc.output_contains("Scopes")
 	})
 
 	t.Run("lists PATs in json output", func(t *testing.T) {
@@ -133,6 +207,80 @@ func TestAdminListPATs(t *testing.T) {
 	})
 }
 
+func TestAdminListPATs_Stale(t *testing.T) {
+	t.Run("lists only PATs unused for the given duration", func(t *testing.T) {
+		tc := newAdminPATTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_pats_with_usage()
+
+		// When
+		tc.output, tc.err = executeAdminCmd(tc.cmd, "pat", "list", "alice", "--stale", "30d")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("pat-old")
+		tc.output_contains("pat-never")
+		assert.NotContains(t, tc.output, "pat-fresh")
+	})
+
+	t.Run("shows usage in the table", func(t *testing.T) {
+		tc := newAdminPATTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.api_returns_pats_with_usage()
+
+		// When
+		tc.output, tc.err = executeAdminCmd(tc.cmd, "pat", "list", "alice")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("Last Used")
+		tc.output_contains("from 10.0.0.1")
+		tc.output_contains("pat-fresh")
+	})
+}
+
+func TestAdminRevokeStalePATs(t *testing.T) {
+	t.Run("posts the cutoff and prints revoked PATs", func(t *testing.T) {
+		tc := newAdminPATTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_resolve_username("acct-1234")
+		tc.mock.postResponse = mustMarshal(map[string]any{"revoked": []string{"pat-old", "pat-never"}})
+
+		// When
+		tc.output, tc.err = executeAdminCmd(tc.cmd, "pat", "revoke-stale", "alice", "--stale", "30d")
+
+		// Then
+		tc.command_has_no_error()
+		assert.Equal(t, []string{"/api/revoke-stale-pats"}, tc.mock.postPaths)
+		assert.Equal(t, "acct-1234", tc.mock.postBody["account_id"])
+		tc.posted_time_is_about("unused_since", -30*24*time.Hour)
+		tc.output_contains("PAT pat-old revoked")
+		tc.output_contains("PAT pat-never revoked")
+	})
+
+	t.Run("requires --stale", func(t *testing.T) {
+		tc := newAdminPATTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+
+		// When
+		tc.output, tc.err = executeAdminCmd(tc.cmd, "pat", "revoke-stale", "alice")
+
+		// Then
+		tc.error_occurred()
+		assert.Empty(t, tc.mock.postPaths)
+	})
+}
+
 func TestAdminRevokePAT(t *testing.T) {
 	t.Run("revokes PAT and prints confirmation", func(t *testing.T) {
 		tc := newAdminPATTestContext(t)
@@ -233,6 +381,30 @@ func (tc *adminPATTestContext) api_returns_pats_list() {
 	}))
 }
 
+func (tc *adminPATTestContext) api_returns_pats_with_usage() {
+	tc.t.Helper()
+	now := time.Now().UTC()
+	tc.mock.getResponses = append(tc.mock.getResponses, mustMarshal([]map[string]interface{}{
+		{
+			"id":            "pat-fresh",
+			"created_at":    now.Add(-90 * 24 * time.Hour).Format(time.RFC3339),
+			"last_used":     now.Add(-time.Hour).Format(time.RFC3339),
+			"last_ip":       "10.0.0.1",
+			"request_count": 12,
+		},
+		{
+			"id":            "pat-old",
+			"created_at":    now.Add(-90 * 24 * time.Hour).Format(time.RFC3339),
+			"last_used":     now.Add(-60 * 24 * time.Hour).Format(time.RFC3339),
+			"request_count": 3,
+		},
+		{
+			"id":         "pat-never",
+			"created_at": now.Add(-45 * 24 * time.Hour).Format(time.RFC3339),
+		},
+	}))
+}
+
 func (tc *adminPATTestContext) api_returns_success() {
 	tc.t.Helper()
 	tc.mock.postResponse = mustMarshal(map[string]string{"status": "ok"})
@@ -283,6 +455,29 @@ func (tc *adminPATTestContext) run_revoke_pat_json(username, patID string) {
 
 // --- Then ---
 
+func (tc *adminPATTestContext) posted_expiry_is_about(fromNow time.Duration) {
+	tc.t.Helper()
+	raw, ok := tc.mock.postBody["expires_at"].(string)
+	require.True(tc.t, ok, "expected expires_at in request body")
+	expiresAt, err := time.Parse(time.RFC3339, raw)
+	require.NoError(tc.t, err)
+	assert.WithinDuration(tc.t, time.Now().Add(fromNow), expiresAt, time.Minute)
+}
+
+func (tc *adminPATTestContext) posted_scopes_are(expected map[string]any) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.mock.postBody["scopes"])
+}
+
+func (tc *adminPATTestContext) posted_time_is_about(field string, fromNow time.Duration) {
+	tc.t.Helper()
+	raw, ok := tc.mock.postBody[field].(string)
+	require.True(tc.t, ok, "expected %s in request body", field)
+	at, err := time.Parse(time.RFC3339, raw)
+	require.NoError(tc.t, err)
+	assert.WithinDuration(tc.t, time.Now().Add(fromNow), at, time.Minute)
+}
+
 func (tc *adminPATTestContext) command_has_no_error() {
 	tc.t.Helper()
 	require.NoError(tc.t, tc.err)
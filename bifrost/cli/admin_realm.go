@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -12,6 +15,10 @@ func addAdminRealmCommands(admin *AdminCmd) {
 	admin.Command.AddCommand(newAdminCreateRealmCmd(admin))
 	admin.Command.AddCommand(newAdminListRealmsCmd(admin))
 	admin.Command.AddCommand(newAdminSuspendRealmCmd(admin))
+	admin.Command.AddCommand(newAdminRenameRealmCmd(admin))
+	admin.Command.AddCommand(newAdminRealmStatusCmd(admin, "reactivate-realm", "Reactivate a suspended or archived realm", "/api/reactivate-realm", "active", "reactivated"))
+	admin.Command.AddCommand(newAdminRealmStatusCmd(admin, "archive-realm", "Archive a realm, making it read-only", "/api/archive-realm", "archived", "archived"))
+	admin.Command.AddCommand(newAdminDeleteRealmCmd(admin))
 }
 
 func newAdminCreateRealmCmd(admin *AdminCmd) *cobra.Command {
@@ -107,3 +114,100 @@ func newAdminSuspendRealmCmd(admin *AdminCmd) *cobra.Command {
 		},
 	}
 }
+
+func newAdminRenameRealmCmd(admin *AdminCmd) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename-realm <realm-id> <name>",
+		Short: "Rename a realm",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			req := map[string]string{"realm_id": args[0], "name": args[1]}
+			_, err := admin.Client.DoPost("/api/rename-realm", req)
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"realm_id": args[0], "name": args[1]})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Realm %s renamed to %s\n", args[0], args[1])
+			return nil
+		},
+	}
+}
+
+// newAdminRealmStatusCmd builds a command that moves a realm to another status.
+func newAdminRealmStatusCmd(admin *AdminCmd, use, short, path, status, verb string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <realm-id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			req := map[string]string{"realm_id": args[0]}
+			_, err := admin.Client.DoPost(path, req)
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"status": status})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Realm %s %s\n", args[0], verb)
+			return nil
+		},
+	}
+}
+
+func newAdminDeleteRealmCmd(admin *AdminCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete-realm <realm-id>",
+		Short: "Permanently delete a suspended or archived realm and all its data",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			realmID := args[0]
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			confirm, _ := cmd.Flags().GetBool("confirm")
+
+			if !confirm {
+				fmt.Fprintf(cmd.OutOrStdout(), "This permanently deletes realm %s, its runes and its grants.\nType the realm ID to confirm: ", realmID)
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && err != io.EOF {
+					return fmt.Errorf("failed to read user input: %w", err)
+				}
+				if strings.TrimSpace(line) != realmID {
+					fmt.Fprintln(cmd.OutOrStdout(), "Aborted")
+					return nil
+				}
+			}
+
+			req := map[string]string{"realm_id": realmID, "confirm": realmID}
+			_, err := admin.Client.DoPost("/api/delete-realm", req)
+			if err != nil {
+				return err
+			}
+
+			if jsonMode {
+				out, _ := json.Marshal(map[string]string{"status": "deleted"})
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Realm %s deleted\n", realmID)
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("confirm", false, "skip interactive confirmation prompt")
+
+	return cmd
+}
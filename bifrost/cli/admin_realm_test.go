@@ -3,6 +3,7 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -128,6 +129,108 @@ func TestAdminSuspendRealm(t *testing.T) {
 	})
 }
 
+func TestAdminRealmLifecycle(t *testing.T) {
+	t.Run("renames a realm", func(t *testing.T) {
+		tc := newAdminRealmTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_success()
+
+		// When
+		tc.run("rename-realm", "bf-1234", "asgard")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/rename-realm")
+		tc.posted_body_has("name", "asgard")
+		tc.output_contains("renamed to asgard")
+	})
+
+	t.Run("archives a realm", func(t *testing.T) {
+		tc := newAdminRealmTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_success()
+
+		// When
+		tc.run("archive-realm", "bf-1234", "--json")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/archive-realm")
+		tc.output_is_valid_json()
+		tc.json_output_has_value("status", "archived")
+	})
+
+	t.Run("reactivates a realm", func(t *testing.T) {
+		tc := newAdminRealmTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_success()
+
+		// When
+		tc.run("reactivate-realm", "bf-1234")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/reactivate-realm")
+		tc.output_contains("Realm bf-1234 reactivated")
+	})
+
+	t.Run("deletes a realm after the realm ID is typed", func(t *testing.T) {
+		tc := newAdminRealmTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_success()
+		tc.stdin_is("bf-1234\n")
+
+		// When
+		tc.run("delete-realm", "bf-1234")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/delete-realm")
+		tc.posted_body_has("confirm", "bf-1234")
+		tc.output_contains("Realm bf-1234 deleted")
+	})
+
+	t.Run("aborts delete when the typed ID does not match", func(t *testing.T) {
+		tc := newAdminRealmTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_success()
+		tc.stdin_is("yes\n")
+
+		// When
+		tc.run("delete-realm", "bf-1234")
+
+		// Then
+		tc.command_has_no_error()
+		tc.nothing_was_posted()
+		tc.output_contains("Aborted")
+	})
+
+	t.Run("deletes without prompting with --confirm", func(t *testing.T) {
+		tc := newAdminRealmTestContext(t)
+
+		// Given
+		tc.admin_cmd_with_mock_client()
+		tc.api_returns_success()
+
+		// When
+		tc.run("delete-realm", "bf-1234", "--confirm")
+
+		// Then
+		tc.command_has_no_error()
+		tc.posted_to("/api/delete-realm")
+	})
+}
+
 // --- Test Context ---
 
 type adminRealmTestContext struct {
@@ -182,8 +285,18 @@ func (tc *adminRealmTestContext) api_returns_error(msg string) {
 	tc.mock.postError = fmt.Errorf("%s", msg)
 }
 
+func (tc *adminRealmTestContext) stdin_is(input string) {
+	tc.t.Helper()
+	tc.cmd.SetIn(strings.NewReader(input))
+}
+
 // --- When ---
 
+func (tc *adminRealmTestContext) run(args ...string) {
+	tc.t.Helper()
+	tc.output, tc.err = executeAdminCmd(tc.cmd, args...)
+}
+
 func (tc *adminRealmTestContext) run_create_realm(name string) {
 	tc.t.Helper()
 	tc.output, tc.err = executeAdminCmd(tc.cmd, "create-realm", name)
@@ -216,6 +329,21 @@ func (tc *adminRealmTestContext) run_suspend_realm_json(realmID string) {
 
 // --- Then ---
 
+func (tc *adminRealmTestContext) posted_to(path string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, []string{path}, tc.mock.postPaths)
+}
+
+func (tc *adminRealmTestContext) posted_body_has(key, expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.mock.postBody[key])
+}
+
+func (tc *adminRealmTestContext) nothing_was_posted() {
+	tc.t.Helper()
+	assert.Empty(tc.t, tc.mock.postPaths)
+}
+
 func (tc *adminRealmTestContext) command_has_no_error() {
 	tc.t.Helper()
 	require.NoError(tc.t, tc.err)
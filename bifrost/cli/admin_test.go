@@ -59,6 +59,9 @@ func TestNewAdminCmd(t *testing.T) {
 		tc.has_subcommand("create-account")
 		tc.has_subcommand("list-accounts")
 		tc.has_subcommand("suspend-account")
+		tc.has_subcommand("reactivate-account")
+		tc.has_subcommand("rename-account")
+		tc.has_subcommand("delete-account")
 		tc.has_subcommand("grant")
 		tc.has_subcommand("revoke")
 		tc.has_subcommand("assign-role")
@@ -75,6 +78,7 @@ func TestNewAdminCmd(t *testing.T) {
 		tc.has_subcommand("create-pat")
 		tc.has_subcommand("list-pats")
 		tc.has_subcommand("revoke-pat")
+		tc.has_subcommand("pat")
 	})
 
 	t.Run("registers bootstrap subcommand", func(t *testing.T) {
@@ -226,9 +230,12 @@ func (tc *adminTestContext) root_client_is_nil() {
 type mockClient struct {
 	getResponses [][]byte
 	getIndex     int
+	getPaths     []string
 	postResponse []byte
 	getError     error
 	postError    error
+	postPaths    []string
+	postBody     map[string]any
 }
 
 func (m *mockClient) DoGet(path string) ([]byte, error) {
@@ -284,7 +291,9 @@ func newAdminCmdWithMockClient(mock *mockClient) *cobra.Command {
 	addAdminRealmCommands(admin)
 	addAdminAccountCommands(admin)
 	addAdminPATCommands(admin)
+	addAdminGroupCommands(admin)
 	addAdminRebuildCommands(admin)
+	addAdminAuditCommands(admin)
 	addAdminBootstrapCommands(admin)
 
 	return cmd
@@ -321,6 +330,7 @@ func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	var body []byte
 	if req.Method == http.MethodGet {
+		t.mock.getPaths = append(t.mock.getPaths, req.URL.RequestURI())
 		if t.mock.getIndex < len(t.mock.getResponses) {
 			body = t.mock.getResponses[t.mock.getIndex]
 			t.mock.getIndex++
@@ -329,6 +339,11 @@ func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 	} else {
 		body = t.mock.postResponse
+		t.mock.postPaths = append(t.mock.postPaths, req.URL.Path)
+		if req.Body != nil {
+			reqBody, _ := io.ReadAll(req.Body)
+			_ = json.Unmarshal(reqBody, &t.mock.postBody)
+		}
 	}
 
 	return &http.Response{
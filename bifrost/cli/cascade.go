@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// addCascadeFlags registers --recursive and --dry-run on a state-changing command.
+func addCascadeFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("recursive", false, "apply to the rune and all of its descendants")
+	cmd.Flags().Bool("dry-run", false, "with --recursive, list what would change without changing it")
+}
+
+// applyCascadeFlags copies the cascade flags into body and reports whether
+// the request will cascade.
+func applyCascadeFlags(cmd *cobra.Command, body map[string]any) (bool, error) {
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun && !recursive {
+		return false, fmt.Errorf("--dry-run requires --recursive")
+	}
+	if recursive {
+		body["cascade"] = true
+		if dryRun {
+			body["dry_run"] = true
+		}
+	}
+	return recursive, nil
+}
+
+// writeCascadeResults prints the per-rune results of a cascading request and
+// returns an error if any rune failed.
+func writeCascadeResults(out *bytes.Buffer, respBody []byte, humanMode bool) error {
+	var resp struct {
+		DryRun  bool `json:"dry_run"`
+		Results []struct {
+			RuneID string `json:"rune_id"`
+			Result string `json:"result"`
+			Reason string `json:"reason"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return err
+	}
+
+	err := PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, _ []byte) {
+		if resp.DryRun {
+			fmt.Fprintln(w, "Dry run: no changes made")
+		}
+		for _, r := range resp.Results {
+			if r.Reason != "" {
+				fmt.Fprintf(w, "%-16s %s (%s)\n", r.RuneID, r.Result, r.Reason)
+			} else {
+				fmt.Fprintf(w, "%-16s %s\n", r.RuneID, r.Result)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range resp.Results {
+		if r.Result == "failed" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d runes failed", failed, len(resp.Results))
+	}
+	return nil
+}
@@ -2,8 +2,10 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os/user"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -18,9 +20,20 @@ func NewClaimCmd(clientFn func() *Client, out *bytes.Buffer) *ClaimCmd {
 	cmd := &cobra.Command{
 		Use:   "claim [id]",
 		Short: "Claim a rune",
-		Args:  cobra.ExactArgs(1),
+		Long: `Claim a rune by ID, or with --next claim the highest-priority ready rune.
+
+--next selects and claims in one request, so orchestrators running side by
+side never claim the same rune. --tag, --type, --branch and --parent narrow
+the candidates; --wait keeps asking until a rune is ready or the wait
+elapses. It prints the claimed rune's detail, or nothing when no rune is
+ready.
+
+Examples:
+		  bf claim bf-a1b2
+		  bf claim --next --tag backend --type bug --wait 5m`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id := args[0]
+			next, _ := cmd.Flags().GetBool("next")
 			claimant, _ := cmd.Flags().GetString("as")
 			humanMode, _ := cmd.Flags().GetBool("human")
 
@@ -31,6 +44,17 @@ func NewClaimCmd(clientFn func() *Client, out *bytes.Buffer) *ClaimCmd {
 				}
 			}
 
+			if next {
+				if len(args) > 0 {
+					return fmt.Errorf("--next does not take a rune ID")
+				}
+				return c.claimNext(cmd, clientFn(), out, claimant, humanMode)
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("a rune ID or --next is required")
+			}
+			id := args[0]
+
 			body := map[string]string{
 				"id":       id,
 				"claimant": claimant,
@@ -51,7 +75,67 @@ func NewClaimCmd(clientFn func() *Client, out *bytes.Buffer) *ClaimCmd {
 
 	cmd.Flags().String("as", "", "claimant name (defaults to system username)")
 	cmd.Flags().Bool("human", false, "human-readable output")
+	cmd.Flags().Bool("next", false, "claim the highest-priority ready rune")
+	cmd.Flags().StringSlice("tag", nil, "with --next, only claim runes carrying one of these tags")
+	cmd.Flags().String("type", "", "with --next, only claim runes of this type")
+	cmd.Flags().String("branch", "", "with --next, only claim runes on this branch")
+	cmd.Flags().String("parent", "", "with --next, only claim children of this rune")
+	cmd.Flags().Duration("wait", 0, "with --next, wait up to this long for a ready rune")
 
 	c.Command = cmd
 	return c
 }
+
+// claimNextWaitChunk is the longest wait requested from the server at once,
+// keeping each long poll inside the client's request timeout.
+const claimNextWaitChunk = 20 * time.Second
+
+func (c *ClaimCmd) claimNext(cmd *cobra.Command, client *Client, out *bytes.Buffer, claimant string, humanMode bool) error {
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	runeType, _ := cmd.Flags().GetString("type")
+	branch, _ := cmd.Flags().GetString("branch")
+	parent, _ := cmd.Flags().GetString("parent")
+	wait, _ := cmd.Flags().GetDuration("wait")
+
+	body := map[string]any{"claimant": claimant}
+	if len(tags) > 0 {
+		body["tags"] = tags
+	}
+	if runeType != "" {
+		body["type"] = runeType
+	}
+	if branch != "" {
+		body["branch"] = branch
+	}
+	if parent != "" {
+		body["parent_id"] = parent
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		// Round up so a partial second still waits rather than polling in a loop
+		body["wait_seconds"] = int((max(min(time.Until(deadline), claimNextWaitChunk), 0) + time.Second - 1) / time.Second)
+		respBody, err := client.DoPost("/claim-next", body)
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(respBody)) > 0 {
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var detail struct {
+					ID    string `json:"id"`
+					Title string `json:"title"`
+				}
+				if json.Unmarshal(data, &detail) != nil {
+					return
+				}
+				fmt.Fprintf(w, "Rune %s claimed: %s\n", detail.ID, detail.Title)
+			})
+		}
+		if time.Until(deadline) <= 0 {
+			if humanMode {
+				fmt.Fprintln(out, "No ready runes")
+			}
+			return nil
+		}
+	}
+}
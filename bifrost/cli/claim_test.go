@@ -63,6 +63,55 @@ func TestClaimCommand(t *testing.T) {
 		tc.output_contains("Rune bf-abc claimed")
 	})
 
+	t.Run("--next sends POST to /claim-next with filters", func(t *testing.T) {
+		tc := newClaimTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `{"id":"bf-a1b2","title":"Fix login"}`)
+		tc.client_configured()
+
+		// When
+		tc.execute("--next", "--as", "alice", "--tag", "backend", "--type", "bug", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/claim-next")
+		tc.request_body_has_field("claimant", "alice")
+		tc.request_body_has_field("type", "bug")
+		tc.request_body_has_any_field("tags", []any{"backend"})
+		tc.request_body_has_any_field("wait_seconds", float64(0))
+		tc.output_contains("Rune bf-a1b2 claimed: Fix login")
+	})
+
+	t.Run("--next reports when no rune is ready", func(t *testing.T) {
+		tc := newClaimTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute("--next", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("No ready runes")
+	})
+
+	t.Run("requires a rune ID without --next", func(t *testing.T) {
+		tc := newClaimTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute()
+
+		// Then
+		tc.command_has_error()
+	})
+
 	t.Run("returns error when server responds with error", func(t *testing.T) {
 		tc := newClaimTestContext(t)
 
@@ -115,6 +164,19 @@ func (tc *claimTestContext) server_that_captures_request_and_returns_no_content(
 	tc.t.Cleanup(tc.server.Close)
 }
 
+func (tc *claimTestContext) server_that_captures_request_and_returns(status int, body string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedMethod = r.Method
+		tc.receivedPath = r.URL.Path
+		reqBody, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(reqBody, &tc.receivedBody)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
 func (tc *claimTestContext) server_that_returns_error(status int, message string) {
 	tc.t.Helper()
 	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -132,6 +194,14 @@ func (tc *claimTestContext) client_configured() {
 
 // --- When ---
 
+func (tc *claimTestContext) execute(args ...string) {
+	tc.t.Helper()
+	cmd := NewClaimCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
 func (tc *claimTestContext) execute_claim(id string) {
 	tc.t.Helper()
 	cmd := NewClaimCmd(func() *Client { return tc.client }, tc.buf)
@@ -184,6 +254,12 @@ func (tc *claimTestContext) request_body_has_field(key, expected string) {
 	assert.Equal(tc.t, expected, tc.receivedBody[key])
 }
 
+func (tc *claimTestContext) request_body_has_any_field(key string, expected any) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
 func (tc *claimTestContext) request_body_has_non_empty_field(key string) {
 	tc.t.Helper()
 	require.NotNil(tc.t, tc.receivedBody)
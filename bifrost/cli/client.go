@@ -7,16 +7,47 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// tokenExchangeHeader is set by servers that exchange PATs for access tokens.
+const tokenExchangeHeader = "X-Bifrost-Token-Exchange"
+
+// accessTokenRefreshMargin is how long before expiry an access token is
+// replaced, so a request is never sent with one about to lapse.
+const accessTokenRefreshMargin = 30 * time.Second
+
+// Requests rejected with 429 are retried up to rateLimitRetries times,
+// waiting as long as the server's Retry-After asks, or backing off
+// exponentially from rateLimitBackoff when it gives none. No single wait
+// exceeds maxRateLimitWait.
+const (
+	rateLimitRetries = 5
+	rateLimitBackoff = 500 * time.Millisecond
+	maxRateLimitWait = 30 * time.Second
+)
+
 type Client struct {
 	baseURL    string
 	apiKey     string
 	realm      string
 	sync       bool
 	httpClient *http.Client
+
+	// Once the server advertises token exchange, the PAT is traded for a
+	// short-lived access token that is sent instead. noExchange is set if
+	// an exchange fails, after which the PAT is sent directly.
+	tokenMu       sync.Mutex
+	canExchange   bool
+	accessToken   string
+	accessExpires time.Time
+	noExchange    bool
+
+	// sleep waits out rate limits; tests replace it.
+	sleep func(time.Duration)
 }
 
 func NewClient(baseURL, apiKey, realm string) *Client {
@@ -24,6 +55,7 @@ func NewClient(baseURL, apiKey, realm string) *Client {
 		baseURL: baseURL,
 		apiKey:  apiKey,
 		realm:   realm,
+		sleep:   time.Sleep,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -31,11 +63,6 @@ func NewClient(baseURL, apiKey, realm string) *Client {
 }
 
 func (c *Client) DoRequest(method, path string, body []byte) (*http.Response, error) {
-	var bodyReader io.Reader
-	if body != nil {
-		bodyReader = bytes.NewReader(body)
-	}
-
 	// All API paths must be prefixed with /api
 	apiPath := path
 	if len(path) > 0 && path[0] == '/' && !strings.HasPrefix(path, "/api") {
@@ -54,26 +81,154 @@ func (c *Client) DoRequest(method, path string, body []byte) (*http.Response, er
 		debugLog("    body: %s", string(body))
 	}
 
+	bearer := c.bearerToken()
+	resp, err := c.sendWithRetry(method, fullURL, body, bearer)
+	if err != nil {
+		debugLog("<-- error: %v", err)
+		return nil, err
+	}
+	if bearer == c.apiKey && resp.Header.Get(tokenExchangeHeader) != "" {
+		c.tokenMu.Lock()
+		c.canExchange = true
+		c.tokenMu.Unlock()
+	}
+	// The access token may have been revoked or outlived; exchange once more.
+	if resp.StatusCode == http.StatusUnauthorized && bearer != c.apiKey {
+		resp.Body.Close()
+		c.dropAccessToken(bearer)
+		resp, err = c.sendWithRetry(method, fullURL, body, c.bearerToken())
+		if err != nil {
+			debugLog("<-- error: %v", err)
+			return nil, err
+		}
+	}
+
+	debugLog("<-- %d %s", resp.StatusCode, resp.Status)
+	debugLog("    realm: %q, url: %q", c.realm, c.baseURL)
+	return resp, nil
+}
+
+// sendWithRetry sends a request, waiting and retrying while the server
+// answers 429 Too Many Requests. The last response is returned as is once
+// the retries are spent.
+func (c *Client) sendWithRetry(method, fullURL string, body []byte, bearer string) (*http.Response, error) {
+	backoff := rateLimitBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(method, fullURL, body, bearer)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt == rateLimitRetries {
+			return resp, err
+		}
+		wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			wait = backoff
+			backoff *= 2
+		}
+		wait = min(wait, maxRateLimitWait)
+		resp.Body.Close()
+		debugLog("<-- 429, retrying in %s", wait)
+		c.sleep(wait)
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func (c *Client) send(method, fullURL string, body []byte, bearer string) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
 	req, err := http.NewRequest(method, fullURL, bodyReader)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+bearer)
 	req.Header.Set("X-Bifrost-Realm", c.realm)
 	if method == http.MethodPost {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.httpClient.Do(req)
+	return c.httpClient.Do(req)
+}
+
+// bearerToken returns the token to authenticate with: a current access
+// token, exchanging the PAT for a new one when needed, or the PAT itself
+// until the server has advertised token exchange. One-shot commands thus
+// send only their PAT; long-running ones switch to access tokens.
+func (c *Client) bearerToken() string {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if !c.canExchange || c.noExchange || c.apiKey == "" {
+		return c.apiKey
+	}
+	if c.accessToken != "" && time.Until(c.accessExpires) > accessTokenRefreshMargin {
+		return c.accessToken
+	}
+
+	token, expiresAt, err := c.exchangeToken()
 	if err != nil {
-		debugLog("<-- error: %v", err)
-		return nil, err
+		debugLog("    token exchange unavailable, using PAT: %v", err)
+		c.noExchange = true
+		c.accessToken = ""
+		return c.apiKey
 	}
+	c.accessToken, c.accessExpires = token, expiresAt
+	return token
+}
 
+// dropAccessToken forgets token so the next request exchanges the PAT again.
+func (c *Client) dropAccessToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.accessToken == token {
+		c.accessToken = ""
+	}
+}
+
+func (c *Client) exchangeToken() (string, time.Time, error) {
+	debugLog("--> POST %s/api/token", c.baseURL)
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/api/token", nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
 	debugLog("<-- %d %s", resp.StatusCode, resp.Status)
-	debugLog("    realm: %q, url: %q", c.realm, c.baseURL)
-	return resp, nil
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("token exchange failed: %s", resp.Status)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", time.Time{}, fmt.Errorf("decode token exchange response: %w", err)
+	}
+	if result.AccessToken == "" || result.ExpiresIn <= 0 {
+		return "", time.Time{}, fmt.Errorf("token exchange returned no access token")
+	}
+	return result.AccessToken, time.Now().Add(time.Duration(result.ExpiresIn) * time.Second), nil
 }
 
 // DoGet performs a GET request and returns the response body.
@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -118,6 +120,123 @@ func TestDoRequest(t *testing.T) {
 		tc.request_has_no_error()
 		tc.request_header_was("X-Bifrost-Realm", "post-realm")
 	})
+
+	t.Run("sends the PAT until the server advertises token exchange", func(t *testing.T) {
+		tc := newClientTestContext(t)
+
+		// Given
+		tc.server_that_exchanges_tokens()
+		tc.client_with_api_key("my-pat")
+
+		// When
+		tc.do_get("/test", nil)
+
+		// Then
+		tc.request_has_no_error()
+		tc.authorizations_were("Bearer my-pat")
+		tc.exchange_count_is(0)
+	})
+
+	t.Run("exchanges the PAT for an access token once advertised", func(t *testing.T) {
+		tc := newClientTestContext(t)
+
+		// Given
+		tc.server_that_exchanges_tokens()
+		tc.client_with_api_key("my-pat")
+
+		// When
+		tc.do_get("/test", nil)
+		tc.do_get("/test", nil)
+		tc.do_get("/test", nil)
+
+		// Then
+		tc.request_has_no_error()
+		tc.authorizations_were("Bearer my-pat", "Bearer access-1", "Bearer access-1")
+		tc.exchange_count_is(1)
+	})
+
+	t.Run("exchanges again when the access token is rejected", func(t *testing.T) {
+		tc := newClientTestContext(t)
+
+		// Given
+		tc.server_that_exchanges_tokens()
+		tc.client_with_api_key("my-pat")
+		tc.do_get("/test", nil)
+		tc.do_get("/test", nil)
+		tc.server_rejects_token("access-1")
+
+		// When
+		tc.do_post("/test", map[string]string{"foo": "bar"})
+
+		// Then
+		tc.request_has_no_error()
+		tc.authorizations_were("Bearer my-pat", "Bearer access-1", "Bearer access-1", "Bearer access-2")
+		tc.response_body_contains(`"foo":"bar"`)
+	})
+
+	t.Run("falls back to the PAT when the exchange fails", func(t *testing.T) {
+		tc := newClientTestContext(t)
+
+		// Given
+		tc.server_that_exchanges_tokens()
+		tc.exchange_fails()
+		tc.client_with_api_key("my-pat")
+
+		// When
+		tc.do_get("/test", nil)
+		tc.do_get("/test", nil)
+
+		// Then
+		tc.request_has_no_error()
+		tc.authorizations_were("Bearer my-pat", "Bearer my-pat")
+	})
+
+	t.Run("retries after the server's Retry-After when rate limited", func(t *testing.T) {
+		tc := newClientTestContext(t)
+
+		// Given
+		tc.server_that_rate_limits(2, "3")
+		tc.client_with_api_key("my-pat")
+
+		// When
+		tc.do_post("/test", map[string]string{"foo": "bar"})
+
+		// Then
+		tc.request_has_no_error()
+		tc.response_body_contains(`"foo":"bar"`)
+		tc.waits_were(3*time.Second, 3*time.Second)
+	})
+
+	t.Run("backs off exponentially without Retry-After", func(t *testing.T) {
+		tc := newClientTestContext(t)
+
+		// Given
+		tc.server_that_rate_limits(3, "")
+		tc.client_with_api_key("my-pat")
+
+		// When
+		tc.do_get("/test", nil)
+
+		// Then
+		tc.request_has_no_error()
+		tc.waits_were(500*time.Millisecond, time.Second, 2*time.Second)
+	})
+
+	t.Run("gives up when the rate limit persists", func(t *testing.T) {
+		tc := newClientTestContext(t)
+
+		// Given
+		tc.server_that_rate_limits(100, "120")
+		tc.client_with_api_key("my-pat")
+
+		// When
+		tc.do_get("/test", nil)
+
+		// Then
+		tc.request_has_error_containing("rate limit exceeded")
+		assert.Len(t, tc.waits, rateLimitRetries)
+		assert.Equal(t, maxRateLimitWait, tc.waits[0])
+	})
 }
 
 // --- Test Context ---
@@ -129,6 +248,13 @@ type clientTestContext struct {
 	client         *Client
 	receivedHeader http.Header
 
+	authorizations []string
+	exchanges      int
+	rejectedToken  string
+	exchangeBroken bool
+	limited        int
+	waits          []time.Duration
+
 	respBody string
 	err      error
 }
@@ -170,9 +296,65 @@ func (tc *clientTestContext) server_that_echoes_body() {
 	tc.t.Cleanup(tc.server.Close)
 }
 
+func (tc *clientTestContext) server_that_exchanges_tokens() {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if r.URL.Path == "/api/token" {
+			if tc.exchangeBroken {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			tc.exchanges++
+			_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","token_type":"Bearer","expires_in":300}`, tc.exchanges)
+			return
+		}
+		tc.authorizations = append(tc.authorizations, auth)
+		if auth == "Bearer "+tc.rejectedToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if auth == "Bearer my-pat" {
+			w.Header().Set(tokenExchangeHeader, "/api/token")
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *clientTestContext) server_that_rate_limits(times int, retryAfter string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tc.limited < times {
+			tc.limited++
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":"rate limit exceeded"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *clientTestContext) server_rejects_token(token string) {
+	tc.t.Helper()
+	tc.rejectedToken = token
+}
+
+func (tc *clientTestContext) exchange_fails() {
+	tc.t.Helper()
+	tc.exchangeBroken = true
+}
+
 func (tc *clientTestContext) client_with_api_key(apiKey string) {
 	tc.t.Helper()
 	tc.client = NewClient(tc.server.URL, apiKey, "test-realm")
+	tc.client.sleep = func(d time.Duration) { tc.waits = append(tc.waits, d) }
 }
 
 func (tc *clientTestContext) client_with_config(apiKey, realm string) {
@@ -211,11 +393,32 @@ func (tc *clientTestContext) request_has_no_error() {
 	require.NoError(tc.t, tc.err)
 }
 
+func (tc *clientTestContext) request_has_error_containing(substr string) {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+	assert.Contains(tc.t, tc.err.Error(), substr)
+}
+
+func (tc *clientTestContext) waits_were(expected ...time.Duration) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.waits)
+}
+
 func (tc *clientTestContext) request_header_was(key, expected string) {
 	tc.t.Helper()
 	assert.Equal(tc.t, expected, tc.receivedHeader.Get(key))
 }
 
+func (tc *clientTestContext) authorizations_were(expected ...string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.authorizations)
+}
+
+func (tc *clientTestContext) exchange_count_is(expected int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.exchanges)
+}
+
 func (tc *clientTestContext) response_body_contains(substr string) {
 	tc.t.Helper()
 	assert.Contains(tc.t, tc.respBody, substr)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -36,18 +37,17 @@ func NewCreateCmd(clientFn func() *Client, out *bytes.Buffer) *CreateCmd {
 			if branchSet && noBranchSet {
 				return fmt.Errorf("--branch and --no-branch are mutually exclusive")
 			}
-			if parentID == "" && !branchSet && !noBranchSet {
-				return fmt.Errorf("--branch or --no-branch is required when no --parent is set")
-			}
-
-			priority, err := strconv.Atoi(priorityStr)
-			if err != nil {
-				return fmt.Errorf("invalid priority: %s", priorityStr)
-			}
 
 			body := map[string]any{
-				"title":    title,
-				"priority": priority,
+				"title": title,
+			}
+			// Omitted priority and branch fall back to the realm's settings
+			if cmd.Flags().Changed("priority") {
+				priority, err := strconv.Atoi(priorityStr)
+				if err != nil {
+					return fmt.Errorf("invalid priority: %s", priorityStr)
+				}
+				body["priority"] = priority
 			}
 			if description != "" {
 				body["description"] = description
@@ -60,6 +60,22 @@ func NewCreateCmd(clientFn func() *Client, out *bytes.Buffer) *CreateCmd {
 			} else if branchSet {
 				body["branch"] = branch
 			}
+			if cmd.Flags().Changed("due") {
+				due, _ := cmd.Flags().GetString("due")
+				t, err := parseTimeFlag(due, time.Now())
+				if err != nil {
+					return err
+				}
+				body["due_at"] = t.Format(time.RFC3339)
+			}
+			if cmd.Flags().Changed("defer-until") {
+				deferUntil, _ := cmd.Flags().GetString("defer-until")
+				t, err := parseTimeFlag(deferUntil, time.Now())
+				if err != nil {
+					return err
+				}
+				body["defer_until"] = t.Format(time.RFC3339)
+			}
 			if len(tags) > 0 {
 				normalized := make([]string, 0, len(tags))
 				for _, tag := range tags {
@@ -111,13 +127,15 @@ func NewCreateCmd(clientFn func() *Client, out *bytes.Buffer) *CreateCmd {
 		},
 	}
 
-	cmd.Flags().StringP("priority", "p", "0", "rune priority (0-4)")
+	cmd.Flags().StringP("priority", "p", "", "rune priority (0-4, default from realm settings)")
 	cmd.Flags().StringP("description", "d", "", "rune description")
 	cmd.Flags().String("parent", "", "parent rune ID")
 	cmd.Flags().Bool("human", false, "human-readable output")
 	cmd.Flags().StringP("branch", "b", "", "branch name for the rune")
 	cmd.Flags().Bool("no-branch", false, "create rune without a branch")
 	cmd.Flags().StringSlice("tag", nil, "tag to apply (repeatable)")
+	cmd.Flags().String("due", "", "due time (RFC 3339, YYYY-MM-DD, or relative like 3d)")
+	cmd.Flags().String("defer-until", "", "hide from ready until this time (RFC 3339, YYYY-MM-DD, or relative like 3d)")
 	cmd.Flags().StringArray("ac-add", nil, "add acceptance criteria as JSON (repeatable)")
 
 	c.Command = cmd
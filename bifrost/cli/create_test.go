@@ -138,7 +138,7 @@ func TestCreateCommand(t *testing.T) {
 		tc.request_body_has_field("branch", "")
 	})
 
-	t.Run("returns error when neither --branch nor --no-branch provided without parent", func(t *testing.T) {
+	t.Run("omits branch when neither --branch nor --no-branch provided without parent", func(t *testing.T) {
 		tc := newCreateTestContext(t)
 
 		// Given
@@ -149,8 +149,23 @@ func TestCreateCommand(t *testing.T) {
 		tc.execute_create_without_branch_flags("My Rune", "0")
 
 		// Then
-		tc.command_has_error()
-		tc.error_contains("--branch or --no-branch is required")
+		tc.command_has_no_error()
+		tc.request_body_does_not_have_field("branch")
+	})
+
+	t.Run("omits priority when -p is not set", func(t *testing.T) {
+		tc := newCreateTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_created()
+		tc.client_configured()
+
+		// When
+		tc.execute_create_without_priority("My Rune")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_body_does_not_have_field("priority")
 	})
 
 	t.Run("omits branch from request body when --parent is set and no branch flag", func(t *testing.T) {
@@ -183,6 +198,37 @@ func TestCreateCommand(t *testing.T) {
 		tc.error_contains("--branch and --no-branch are mutually exclusive")
 	})
 
+	t.Run("sends due_at and defer_until when --due and --defer-until are set", func(t *testing.T) {
+		tc := newCreateTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_created()
+		tc.client_configured()
+
+		// When
+		tc.execute_create_with_args("My Rune", "--no-branch", "--due", "2026-03-01T12:00:00Z", "--defer-until", "2026-02-01T08:00:00Z")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_body_has_field("due_at", "2026-03-01T12:00:00Z")
+		tc.request_body_has_field("defer_until", "2026-02-01T08:00:00Z")
+	})
+
+	t.Run("returns error for unparseable --due value", func(t *testing.T) {
+		tc := newCreateTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_created()
+		tc.client_configured()
+
+		// When
+		tc.execute_create_with_args("My Rune", "--no-branch", "--due", "next tuesday")
+
+		// Then
+		tc.command_has_error()
+		tc.error_contains("invalid time")
+	})
+
 	t.Run("returns error when server responds with error", func(t *testing.T) {
 		tc := newCreateTestContext(t)
 
@@ -303,6 +349,13 @@ func (tc *createTestContext) execute_create_without_branch_flags(title, priority
 	tc.err = cmd.Command.Execute()
 }
 
+func (tc *createTestContext) execute_create_without_priority(title string) {
+	tc.t.Helper()
+	cmd := NewCreateCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs([]string{title, "--no-branch"})
+	tc.err = cmd.Command.Execute()
+}
+
 func (tc *createTestContext) execute_create_with_branch(title, priority, branch string) {
 	tc.t.Helper()
 	cmd := NewCreateCmd(func() *Client { return tc.client }, tc.buf)
@@ -335,6 +388,14 @@ func (tc *createTestContext) execute_create_with_tags(title, priority string, ta
 	tc.err = cmd.Command.Execute()
 }
 
+func (tc *createTestContext) execute_create_with_args(args ...string) {
+	tc.t.Helper()
+	cmd := NewCreateCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
 // --- Then ---
 
 func (tc *createTestContext) command_has_no_error() {
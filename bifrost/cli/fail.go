@@ -27,12 +27,19 @@ func NewFailCmd(clientFn func() *Client, out *bytes.Buffer) *FailCmd {
 				return fmt.Errorf("--reason is required")
 			}
 
-			body := map[string]string{"id": id, "reason": reason}
+			body := map[string]any{"id": id, "reason": reason}
+			recursive, err := applyCascadeFlags(cmd, body)
+			if err != nil {
+				return err
+			}
 
-			_, err := clientFn().DoPost("/fail-rune", body)
+			respBody, err := clientFn().DoPost("/fail-rune", body)
 			if err != nil {
 				return err
 			}
+			if recursive {
+				return writeCascadeResults(out, respBody, humanMode)
+			}
 
 			if humanMode {
 				fmt.Fprintf(out, "Rune %s marked as failed", id)
@@ -44,6 +51,7 @@ func NewFailCmd(clientFn func() *Client, out *bytes.Buffer) *FailCmd {
 
 	cmd.Flags().String("reason", "", "reason for failure (required)")
 	cmd.Flags().Bool("human", false, "human-readable output")
+	addCascadeFlags(cmd)
 
 	c.Command = cmd
 	return c
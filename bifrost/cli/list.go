@@ -27,6 +27,8 @@ func NewListCmd(clientFn func() *Client, out *bytes.Buffer) *ListCmd {
 			branch, _ := cmd.Flags().GetString("branch")
 			parent, _ := cmd.Flags().GetString("parent")
 			tags, _ := cmd.Flags().GetStringSlice("tag")
+			overdue, _ := cmd.Flags().GetBool("overdue")
+			deferred, _ := cmd.Flags().GetBool("deferred")
 			humanMode, _ := cmd.Flags().GetBool("human")
 
 			params := map[string]string{}
@@ -45,6 +47,12 @@ func NewListCmd(clientFn func() *Client, out *bytes.Buffer) *ListCmd {
 			if parent != "" {
 				params["parent_id"] = parent
 			}
+			if overdue {
+				params["overdue"] = "true"
+			}
+			if deferred {
+				params["deferred"] = "true"
+			}
 			if len(tags) > 0 {
 				normalized := make([]string, 0, len(tags))
 				for _, tag := range tags {
@@ -90,6 +98,9 @@ func NewListCmd(clientFn func() *Client, out *bytes.Buffer) *ListCmd {
 						p = fmt.Sprintf("%d", int(pv))
 					}
 					claimant, _ := r["claimant"].(string)
+					if agent, _ := r["claimed_by_agent"].(bool); agent && claimant != "" {
+						claimant += " (agent)"
+					}
 					br, _ := r["branch"].(string)
 					tags := ""
 					if tagsRaw, ok := r["tags"].([]any); ok && len(tagsRaw) > 0 {
@@ -116,6 +127,8 @@ func NewListCmd(clientFn func() *Client, out *bytes.Buffer) *ListCmd {
 	cmd.Flags().String("branch", "", "filter by branch name")
 	cmd.Flags().String("parent", "", "filter by parent rune ID")
 	cmd.Flags().StringSlice("tag", nil, "filter by tag (repeatable)")
+	cmd.Flags().Bool("overdue", false, "only show runes past their due time")
+	cmd.Flags().Bool("deferred", false, "only show runes deferred until a future time")
 	cmd.Flags().Bool("human", false, "human-readable table output")
 
 	c.Command = cmd
@@ -105,6 +105,38 @@ func TestListCommand(t *testing.T) {
 		tc.request_query_param_was("tags", "backend")
 	})
 
+	t.Run("passes overdue filter when --overdue is set", func(t *testing.T) {
+		tc := newListTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_runes()
+		tc.client_configured()
+
+		// When
+		tc.execute_list_with_args("--overdue")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_query_param_was("overdue", "true")
+		tc.request_query_param_absent("deferred")
+	})
+
+	t.Run("passes deferred filter when --deferred is set", func(t *testing.T) {
+		tc := newListTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_runes()
+		tc.client_configured()
+
+		// When
+		tc.execute_list_with_args("--deferred")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_query_param_was("deferred", "true")
+		tc.request_query_param_absent("overdue")
+	})
+
 	t.Run("omits branch query parameter when flag not set", func(t *testing.T) {
 		tc := newListTestContext(t)
 
@@ -158,6 +190,21 @@ func TestListCommand(t *testing.T) {
 		tc.output_contains("main")
 	})
 
+	t.Run("labels claims by service accounts as agent work", func(t *testing.T) {
+		tc := newListTestContext(t)
+
+		// Given
+		tc.server_that_returns_json(`[{"id":"bf-1","title":"Rune 1","status":"claimed","priority":0,"claimant":"ci-bot","claimed_by_agent":true}]`)
+		tc.client_configured()
+
+		// When
+		tc.execute_list_with_human()
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("ci-bot (agent)")
+	})
+
 	t.Run("returns error when server responds with error", func(t *testing.T) {
 		tc := newListTestContext(t)
 
@@ -297,6 +344,14 @@ func (tc *listTestContext) execute_list_with_tag(tag string) {
 	tc.err = cmd.Command.Execute()
 }
 
+func (tc *listTestContext) execute_list_with_args(args ...string) {
+	tc.t.Helper()
+	cmd := NewListCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
 // --- Then ---
 
 func (tc *listTestContext) command_has_no_error() {
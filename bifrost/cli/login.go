@@ -30,8 +30,19 @@ func NewLoginCmd() *cobra.Command {
 		Short: "Store credentials for a Bifrost server",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			token, _ := cmd.Flags().GetString("token")
-			if token == "" {
-				return fmt.Errorf("required flag \"token\" not set")
+			sso, _ := cmd.Flags().GetBool("sso")
+			web, _ := cmd.Flags().GetBool("web")
+			modes := 0
+			for _, set := range []bool{token != "", sso, web} {
+				if set {
+					modes++
+				}
+			}
+			if modes == 0 {
+				return fmt.Errorf("required flag \"token\" not set (or use --web or --sso)")
+			}
+			if modes > 1 {
+				return fmt.Errorf("--token, --web and --sso cannot be used together")
 			}
 
 			if homeDir == "" {
@@ -55,6 +66,18 @@ func NewLoginCmd() *cobra.Command {
 				url = resolveDefaultURL(workDir)
 			}
 
+			if sso || web {
+				var err error
+				if sso {
+					token, err = loginWithSSO(cmd.Context(), url, cmd.OutOrStdout())
+				} else {
+					token, err = loginWithDevice(cmd.Context(), url, cmd.OutOrStdout())
+				}
+				if err != nil {
+					return err
+				}
+			}
+
 			if err := SaveCredential(homeDir, url, token); err != nil {
 				return err
 			}
@@ -66,6 +89,8 @@ func NewLoginCmd() *cobra.Command {
 
 	cmd.Flags().String("url", "", "Bifrost server URL")
 	cmd.Flags().String("token", "", "Personal access token")
+	cmd.Flags().Bool("web", false, "Approve this login in the admin UI with a one-time code")
+	cmd.Flags().Bool("sso", false, "Sign in through the server's single sign-on provider in a browser")
 	cmd.Flags().StringVar(&homeDir, "home-dir", "", "Home directory (defaults to user home)")
 	cmd.Flags().StringVar(&workDir, "work-dir", "", "Working directory (defaults to cwd)")
 
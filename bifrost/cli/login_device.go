@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// devicePollUnit scales the poll interval the server returns, in seconds.
+// Tests shorten it.
+var devicePollUnit = time.Second
+
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// loginWithDevice runs the device authorization flow: it asks the server for
+// a code, has the user approve it in the admin UI, and polls until the
+// server hands back a PAT.
+func loginWithDevice(ctx context.Context, serverURL string, out io.Writer) (string, error) {
+	base := normalizeURL(serverURL)
+	label := "bf login"
+	if host, err := os.Hostname(); err == nil && host != "" {
+		label = "bf login on " + host
+	}
+
+	var code deviceCodeResponse
+	if status, err := postDeviceJSON(ctx, base+"/api/ui/device/code", map[string]string{"label": label}, &code); err != nil {
+		return "", fmt.Errorf("start device login: %w", err)
+	} else if status != http.StatusOK {
+		return "", fmt.Errorf("start device login: server returned %d", status)
+	}
+
+	fmt.Fprintf(out, "To sign in, visit %s and enter the code %s\n", code.VerificationURI, code.UserCode)
+	_ = openBrowser(code.VerificationURIComplete)
+
+	interval := time.Duration(code.Interval) * devicePollUnit
+	expiresIn := time.Duration(code.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 10 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, expiresIn)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("device login expired; run bf login --web again")
+		case <-time.After(interval):
+		}
+
+		var result struct {
+			Token string `json:"token"`
+			Error string `json:"error"`
+		}
+		status, err := postDeviceJSON(ctx, base+"/api/ui/device/token", map[string]string{"device_code": code.DeviceCode}, &result)
+		if err != nil {
+			return "", fmt.Errorf("poll device login: %w", err)
+		}
+		if status == http.StatusOK && result.Token != "" {
+			return result.Token, nil
+		}
+
+		switch result.Error {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * devicePollUnit
+		case "access_denied":
+			return "", fmt.Errorf("device login was denied")
+		case "expired_token":
+			return "", fmt.Errorf("device login expired; run bf login --web again")
+		default:
+			return "", fmt.Errorf("poll device login: server returned %d: %s", status, result.Error)
+		}
+	}
+}
+
+func postDeviceJSON(ctx context.Context, target string, body, dest any) (int, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return resp.StatusCode, fmt.Errorf("decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
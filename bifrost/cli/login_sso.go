@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// ssoLoginTimeout bounds how long bf login --sso waits for the browser.
+const ssoLoginTimeout = 5 * time.Minute
+
+// openBrowser opens target in the user's browser. Tests replace it.
+var openBrowser = func(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	return cmd.Start()
+}
+
+// loginWithSSO signs in through the server's OIDC provider. It listens on a
+// loopback port, sends the browser to the server's SSO login with that port
+// as the callback, and returns the PAT the server hands back.
+func loginWithSSO(ctx context.Context, serverURL string, out io.Writer) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("listen for SSO callback: %w", err)
+	}
+	defer listener.Close()
+
+	tokens := make(chan string, 1)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if r.URL.Path != "/callback" || token == "" {
+			http.Error(w, "missing token", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, "Signed in to Bifrost. You can close this window.")
+		select {
+		case tokens <- token:
+		default:
+		}
+	})}
+	go func() { _ = srv.Serve(listener) }()
+	defer srv.Close()
+
+	callback := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+	loginURL := normalizeURL(serverURL) + "/api/ui/oidc/login?" + url.Values{"cli_callback": {callback}}.Encode()
+
+	fmt.Fprintln(out, "Opening your browser to sign in. If it does not open, visit:")
+	fmt.Fprintln(out, loginURL)
+	_ = openBrowser(loginURL)
+
+	ctx, cancel := context.WithTimeout(ctx, ssoLoginTimeout)
+	defer cancel()
+	select {
+	case token := <-tokens:
+		return token, nil
+	case <-ctx.Done():
+		return "", errors.New("timed out waiting for SSO sign-in")
+	}
+}
@@ -2,9 +2,13 @@ package cli
 
 import (
 	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -77,16 +81,71 @@ func TestLoginCommand(t *testing.T) {
 		tc.credential_is_stored("https://override.example.com", "my-token")
 		tc.output_contains("Logged in to https://override.example.com")
 	})
+
+	t.Run("--sso stores the token the server hands back", func(t *testing.T) {
+		tc := newLoginTestContext(t)
+
+		// Given
+		tc.sso_server_issuing("sso-token")
+		tc.browser_follows_redirects()
+
+		// When
+		tc.execute_login("--url", tc.serverURL, "--sso")
+
+		// Then
+		tc.no_error_occurred()
+		tc.credential_is_stored(tc.serverURL, "sso-token")
+		tc.output_contains("/api/ui/oidc/login?cli_callback=")
+	})
+
+	t.Run("--web stores the token once the device login is approved", func(t *testing.T) {
+		tc := newLoginTestContext(t)
+
+		// Given
+		tc.device_server_approving_after_polls(2, "device-token")
+
+		// When
+		tc.execute_login("--url", tc.serverURL, "--web")
+
+		// Then
+		tc.no_error_occurred()
+		tc.credential_is_stored(tc.serverURL, "device-token")
+		tc.output_contains("enter the code BCDF-GHJK")
+	})
+
+	t.Run("--web reports a denied device login", func(t *testing.T) {
+		tc := newLoginTestContext(t)
+
+		// Given
+		tc.device_server_denying()
+
+		// When
+		tc.execute_login("--url", tc.serverURL, "--web")
+
+		// Then
+		tc.error_contains("denied")
+	})
+
+	t.Run("errors when --token and --sso are both provided", func(t *testing.T) {
+		tc := newLoginTestContext(t)
+
+		// When
+		tc.execute_login("--token", "my-token", "--sso")
+
+		// Then
+		tc.error_contains("cannot be used together")
+	})
 }
 
 // --- Test Context ---
 
 type loginTestContext struct {
-	t       *testing.T
-	homeDir string
-	workDir string
-	output  string
-	err     error
+	t         *testing.T
+	homeDir   string
+	workDir   string
+	serverURL string
+	output    string
+	err       error
 }
 
 func newLoginTestContext(t *testing.T) *loginTestContext {
@@ -110,6 +169,79 @@ func (tc *loginTestContext) bifrost_yaml_with_url(url string) {
 	require.NoError(tc.t, err)
 }
 
+func (tc *loginTestContext) sso_server_issuing(token string) {
+	tc.t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/ui/oidc/login" {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, r.URL.Query().Get("cli_callback")+"?token="+token, http.StatusFound)
+	}))
+	tc.t.Cleanup(server.Close)
+	tc.serverURL = server.URL
+}
+
+func (tc *loginTestContext) device_server(poll func(w http.ResponseWriter)) {
+	tc.t.Helper()
+	original := devicePollUnit
+	devicePollUnit = time.Millisecond
+	tc.t.Cleanup(func() { devicePollUnit = original })
+	originalBrowser := openBrowser
+	openBrowser = func(string) error { return nil }
+	tc.t.Cleanup(func() { openBrowser = originalBrowser })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/ui/device/code":
+			fmt.Fprint(w, `{"device_code":"dev-1","user_code":"BCDF-GHJK","verification_uri":"http://bifrost.test/ui/device","verification_uri_complete":"http://bifrost.test/ui/device?code=BCDF-GHJK","expires_in":60,"interval":1}`)
+		case "/api/ui/device/token":
+			poll(w)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	tc.t.Cleanup(server.Close)
+	tc.serverURL = server.URL
+}
+
+func (tc *loginTestContext) device_server_approving_after_polls(pending int, token string) {
+	tc.t.Helper()
+	polls := 0
+	tc.device_server(func(w http.ResponseWriter) {
+		polls++
+		if polls <= pending {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"authorization_pending"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"token":%q,"pat_id":"pat-1"}`, token)
+	})
+}
+
+func (tc *loginTestContext) device_server_denying() {
+	tc.t.Helper()
+	tc.device_server(func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"access_denied"}`)
+	})
+}
+
+func (tc *loginTestContext) browser_follows_redirects() {
+	tc.t.Helper()
+	original := openBrowser
+	openBrowser = func(target string) error {
+		go func() {
+			resp, err := http.Get(target)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+	tc.t.Cleanup(func() { openBrowser = original })
+}
+
 // --- When ---
 
 func (tc *loginTestContext) execute_login(args ...string) {
@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// mcpProtocolVersions are the Model Context Protocol revisions the server
+// speaks, newest first. A client asking for another revision gets the newest.
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+const mcpServerVersion = "1.0.0"
+
+// JSON-RPC 2.0 error codes.
+const (
+	jsonRPCParseError     = -32700
+	jsonRPCInvalidRequest = -32600
+	jsonRPCMethodNotFound = -32601
+	jsonRPCInvalidParams  = -32602
+	jsonRPCInternalError  = -32603
+	// mcpResourceNotFound is the MCP error code for an unknown resource URI.
+	mcpResourceNotFound = -32002
+)
+
+type MCPCmd struct {
+	Command *cobra.Command
+}
+
+func NewMCPCmd(clientFn func() *Client, in io.Reader, out io.Writer) *MCPCmd {
+	c := &MCPCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Run a Model Context Protocol server on stdio",
+		Long: `Run a Model Context Protocol (MCP) server over stdin and stdout, so agents
+can drive Bifrost through typed tools instead of shelling out to bf.
+
+The server uses the realm, URL and credentials from .bifrost.yaml, like
+every other command. It exposes tools to create, forge, claim and fulfill
+runes, add notes, retros and acceptance criteria, and patch rune state, and
+resources for ready runes and rune detail.
+
+Example MCP client configuration:
+		  {"mcpServers": {"bifrost": {"command": "bf", "args": ["mcp"]}}}`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+			return NewMCPServer(clientFn()).Serve(ctx, in, out)
+		},
+	}
+
+	c.Command = cmd
+	return c
+}
+
+// MCPServer answers Model Context Protocol requests with calls to the
+// Bifrost API. Messages are newline-delimited JSON-RPC 2.0.
+type MCPServer struct {
+	client    *Client
+	tools     []mcpTool
+	resources []mcpResource
+	templates []mcpResourceTemplate
+
+	// writeMu keeps responses from interleaving on the output.
+	writeMu sync.Mutex
+}
+
+// NewMCPServer creates a server exposing the rune tools and resources.
+func NewMCPServer(client *Client) *MCPServer {
+	return &MCPServer{
+		client:    client,
+		tools:     mcpTools(),
+		resources: mcpResources(),
+		templates: mcpResourceTemplates(),
+	}
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *jsonRPCError) Error() string {
+	return e.Message
+}
+
+// Serve handles requests from in until it is closed or ctx is cancelled.
+func (s *MCPServer) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case line := <-lines:
+			if resp := s.handle(line); resp != nil {
+				if err := s.write(out, resp); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *MCPServer) write(out io.Writer, resp *jsonRPCResponse) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, err = out.Write(append(data, '\n'))
+	return err
+}
+
+// handle answers one message. Notifications, which have no ID, get no
+// response.
+func (s *MCPServer) handle(line []byte) *jsonRPCResponse {
+	var req jsonRPCRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &jsonRPCResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &jsonRPCError{Code: jsonRPCParseError, Message: "parse error"}}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	resp := &jsonRPCResponse{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &jsonRPCError{Code: jsonRPCInvalidRequest, Message: "invalid request"}
+		return resp
+	}
+
+	result, err := s.dispatch(req.Method, req.Params)
+	if err != nil {
+		var rpcErr *jsonRPCError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &jsonRPCError{Code: jsonRPCInvalidParams, Message: err.Error()}
+		}
+		resp.Error = rpcErr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *MCPServer) dispatch(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		return s.initialize(params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.tools}, nil
+	case "tools/call":
+		return s.callTool(params)
+	case "resources/list":
+		return map[string]any{"resources": s.resources}, nil
+	case "resources/templates/list":
+		return map[string]any{"resourceTemplates": s.templates}, nil
+	case "resources/read":
+		return s.readResource(params)
+	}
+	return nil, &jsonRPCError{Code: jsonRPCMethodNotFound, Message: fmt.Sprintf("method not found: %s", method)}
+}
+
+func (s *MCPServer) initialize(params json.RawMessage) (any, error) {
+	var req struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &req); err != nil {
+			return nil, fmt.Errorf("invalid initialize params: %w", err)
+		}
+	}
+	version := mcpProtocolVersions[0]
+	if slices.Contains(mcpProtocolVersions, req.ProtocolVersion) {
+		version = req.ProtocolVersion
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities": map[string]any{
+			"tools":     map[string]any{},
+			"resources": map[string]any{},
+		},
+		"serverInfo": map[string]string{"name": "bifrost", "version": mcpServerVersion},
+	}, nil
+}
+
+// mcpToolResult is the result of tools/call. API failures are reported as
+// results with isError set, so the model can read and react to them.
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (s *MCPServer) callTool(params json.RawMessage) (any, error) {
+	var req struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid tools/call params: %w", err)
+	}
+	i := slices.IndexFunc(s.tools, func(t mcpTool) bool { return t.Name == req.Name })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown tool: %s", req.Name)
+	}
+	if len(req.Arguments) == 0 || string(req.Arguments) == "null" {
+		req.Arguments = json.RawMessage("{}")
+	}
+
+	text, err := s.tools[i].call(s.client, req.Arguments)
+	if err != nil {
+		return mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	return mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}}, nil
+}
+
+func (s *MCPServer) readResource(params json.RawMessage) (any, error) {
+	var req struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid resources/read params: %w", err)
+	}
+	body, err := readMCPResource(s.client, req.URI)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"contents": []map[string]string{{"uri": req.URI, "mimeType": "application/json", "text": string(body)}},
+	}, nil
+}
@@ -0,0 +1,419 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestMCPServer(t *testing.T) {
+	t.Run("initializes with the requested protocol version", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `{}`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.request("initialize", map[string]any{"protocolVersion": "2025-03-26", "capabilities": map[string]any{}})
+
+		// Then
+		result := tc.result()
+		assert.Equal(t, "2025-03-26", result["protocolVersion"])
+		assert.Contains(t, result["capabilities"], "tools")
+		assert.Contains(t, result["capabilities"], "resources")
+	})
+
+	t.Run("does not answer notifications", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `{}`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.notification("notifications/initialized")
+		tc.request("ping", nil)
+
+		// Then
+		tc.response_id_is(1)
+	})
+
+	t.Run("lists tools with input schemas", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `{}`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.request("tools/list", nil)
+
+		// Then
+		tools := tc.result()["tools"].([]any)
+		names := make([]string, 0, len(tools))
+		for _, tool := range tools {
+			names = append(names, tool.(map[string]any)["name"].(string))
+		}
+		assert.ElementsMatch(t, []string{
+			"create_rune", "forge_rune", "claim_rune", "fulfill_rune", "add_note",
+			"add_retro", "add_ac", "update_ac", "patch_rune_state",
+		}, names)
+		schema := tools[0].(map[string]any)["inputSchema"].(map[string]any)
+		assert.Equal(t, "object", schema["type"])
+		assert.Equal(t, []any{"title"}, schema["required"])
+	})
+
+	t.Run("claim_rune posts the claim", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.mcp_server_is_running()
+
+		// When
+		tc.call_tool("claim_rune", map[string]any{"id": "bf-abc", "claimant": "agent-1"})
+
+		// Then
+		tc.tool_succeeded_with("Rune bf-abc claimed")
+		tc.request_path_was("/api/claim-rune")
+		tc.request_body_has_field("id", "bf-abc")
+		tc.request_body_has_field("claimant", "agent-1")
+	})
+
+	t.Run("patch_rune_state sends the patch as a JSON string", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.mcp_server_is_running()
+
+		// When
+		tc.call_tool("patch_rune_state", map[string]any{"rune_id": "bf-abc", "patch": map[string]any{"coverage": 85}})
+
+		// Then
+		tc.tool_succeeded_with("Rune bf-abc state updated")
+		tc.request_path_was("/api/update-rune-state")
+		tc.request_body_has_field("patch", `{"coverage":85}`)
+	})
+
+	t.Run("update_ac posts the whole criterion", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.mcp_server_is_running()
+
+		// When
+		tc.call_tool("update_ac", map[string]any{"rune_id": "bf-abc", "id": "AC-01", "scenario": "login", "description": "redirects home"})
+
+		// Then
+		tc.tool_succeeded_with("Acceptance criterion AC-01 of rune bf-abc updated")
+		tc.request_path_was("/api/update-ac")
+		tc.request_body_has_field("rune_id", "bf-abc")
+		tc.request_body_has_field("id", "AC-01")
+		tc.request_body_has_field("scenario", "login")
+		tc.request_body_has_field("description", "redirects home")
+	})
+
+	t.Run("update_ac requires the description so it is not erased", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.mcp_server_is_running()
+
+		// When
+		tc.call_tool("update_ac", map[string]any{"rune_id": "bf-abc", "id": "AC-01", "scenario": "login"})
+
+		// Then
+		tc.tool_failed_with("description is required")
+		assert.Empty(t, tc.receivedPath)
+	})
+
+	t.Run("reports missing required arguments as tool errors", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.mcp_server_is_running()
+
+		// When
+		tc.call_tool("add_note", map[string]any{"rune_id": "bf-abc"})
+
+		// Then
+		tc.tool_failed_with("text is required")
+		assert.Empty(t, tc.receivedPath)
+	})
+
+	t.Run("reports API errors as tool errors", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNotFound, `{"error":"rune not found"}`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.call_tool("fulfill_rune", map[string]any{"id": "bf-abc"})
+
+		// Then
+		tc.tool_failed_with("rune not found")
+	})
+
+	t.Run("reads the ready runes resource", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `[{"id":"bf-abc"}]`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.request("resources/read", map[string]any{"uri": "bifrost://ready"})
+
+		// Then
+		tc.request_path_was("/api/ready")
+		tc.resource_text_is(`[{"id":"bf-abc"}]`)
+	})
+
+	t.Run("reads a rune through the resource template", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `{"id":"bf-abc"}`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.request("resources/read", map[string]any{"uri": "bifrost://runes/bf-abc"})
+
+		// Then
+		tc.request_path_was("/api/rune")
+		assert.Equal(t, "bf-abc", tc.receivedQuery.Get("id"))
+		tc.resource_text_is(`{"id":"bf-abc"}`)
+	})
+
+	t.Run("rejects an unknown resource", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `{}`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.request("resources/read", map[string]any{"uri": "bifrost://nope"})
+
+		// Then
+		tc.error_code_is(mcpResourceNotFound)
+	})
+
+	t.Run("rejects an unknown method", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `{}`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.request("prompts/list", nil)
+
+		// Then
+		tc.error_code_is(jsonRPCMethodNotFound)
+	})
+
+	t.Run("stops when the input closes", func(t *testing.T) {
+		tc := newMCPTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK, `{}`)
+		tc.mcp_server_is_running()
+
+		// When
+		tc.clientOut.Close()
+
+		// Then
+		tc.server_stopped()
+	})
+}
+
+// --- Test Context ---
+
+type mcpTestContext struct {
+	t *testing.T
+
+	server        *httptest.Server
+	receivedPath  string
+	receivedQuery url.Values
+	receivedBody  map[string]any
+
+	// clientOut writes to the MCP server's input; responses are read from
+	// its output.
+	clientOut *io.PipeWriter
+	responses *bufio.Reader
+	served    chan error
+	nextID    int
+
+	response struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *jsonRPCError   `json:"error"`
+	}
+}
+
+func newMCPTestContext(t *testing.T) *mcpTestContext {
+	t.Helper()
+	return &mcpTestContext{t: t}
+}
+
+// --- Given ---
+
+func (tc *mcpTestContext) server_that_captures_request_and_returns(status int, body string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedPath = r.URL.Path
+		tc.receivedQuery = r.URL.Query()
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &tc.receivedBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *mcpTestContext) mcp_server_is_running() {
+	tc.t.Helper()
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	tc.clientOut = clientOut
+	tc.responses = bufio.NewReader(clientIn)
+	tc.served = make(chan error, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tc.t.Cleanup(cancel)
+	tc.t.Cleanup(func() { clientOut.Close(); clientIn.Close() })
+
+	server := NewMCPServer(NewClient(tc.server.URL, "test-key", "test-realm"))
+	go func() { tc.served <- server.Serve(ctx, serverIn, serverOut) }()
+}
+
+// --- When ---
+
+func (tc *mcpTestContext) request(method string, params any) {
+	tc.t.Helper()
+	tc.nextID++
+	tc.send(map[string]any{"jsonrpc": "2.0", "id": tc.nextID, "method": method, "params": params})
+
+	line, err := tc.responses.ReadBytes('\n')
+	require.NoError(tc.t, err)
+	require.NoError(tc.t, json.Unmarshal(line, &tc.response))
+}
+
+func (tc *mcpTestContext) notification(method string) {
+	tc.t.Helper()
+	tc.send(map[string]any{"jsonrpc": "2.0", "method": method})
+}
+
+func (tc *mcpTestContext) call_tool(name string, args map[string]any) {
+	tc.t.Helper()
+	tc.request("tools/call", map[string]any{"name": name, "arguments": args})
+}
+
+func (tc *mcpTestContext) send(message map[string]any) {
+	tc.t.Helper()
+	data, err := json.Marshal(message)
+	require.NoError(tc.t, err)
+	_, err = fmt.Fprintf(tc.clientOut, "%s\n", data)
+	require.NoError(tc.t, err)
+}
+
+// --- Then ---
+
+func (tc *mcpTestContext) result() map[string]any {
+	tc.t.Helper()
+	require.Nil(tc.t, tc.response.Error)
+	var result map[string]any
+	require.NoError(tc.t, json.Unmarshal(tc.response.Result, &result))
+	return result
+}
+
+func (tc *mcpTestContext) response_id_is(expected int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.response.ID)
+}
+
+func (tc *mcpTestContext) error_code_is(expected int) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.response.Error)
+	assert.Equal(tc.t, expected, tc.response.Error.Code)
+}
+
+func (tc *mcpTestContext) toolResult() mcpToolResult {
+	tc.t.Helper()
+	require.Nil(tc.t, tc.response.Error)
+	var result mcpToolResult
+	require.NoError(tc.t, json.Unmarshal(tc.response.Result, &result))
+	require.Len(tc.t, result.Content, 1)
+	return result
+}
+
+func (tc *mcpTestContext) tool_succeeded_with(text string) {
+	tc.t.Helper()
+	result := tc.toolResult()
+	assert.False(tc.t, result.IsError)
+	assert.Equal(tc.t, text, result.Content[0].Text)
+}
+
+func (tc *mcpTestContext) tool_failed_with(text string) {
+	tc.t.Helper()
+	result := tc.toolResult()
+	assert.True(tc.t, result.IsError)
+	assert.Contains(tc.t, result.Content[0].Text, text)
+}
+
+func (tc *mcpTestContext) resource_text_is(expected string) {
+	tc.t.Helper()
+	var result struct {
+		Contents []struct {
+			URI      string `json:"uri"`
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+		} `json:"contents"`
+	}
+	require.Nil(tc.t, tc.response.Error)
+	require.NoError(tc.t, json.Unmarshal(tc.response.Result, &result))
+	require.Len(tc.t, result.Contents, 1)
+	assert.Equal(tc.t, "application/json", result.Contents[0].MimeType)
+	assert.Equal(tc.t, expected, result.Contents[0].Text)
+}
+
+func (tc *mcpTestContext) request_path_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedPath)
+}
+
+func (tc *mcpTestContext) request_body_has_field(key string, expected any) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
+func (tc *mcpTestContext) server_stopped() {
+	tc.t.Helper()
+	select {
+	case err := <-tc.served:
+		assert.NoError(tc.t, err)
+	case <-time.After(5 * time.Second):
+		tc.t.Fatal("MCP server did not stop")
+	}
+}
@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/user"
+	"strings"
+)
+
+// mcpSchema is the subset of JSON Schema the tool input schemas use.
+type mcpSchema struct {
+	Type        string               `json:"type"`
+	Description string               `json:"description,omitempty"`
+	Properties  map[string]mcpSchema `json:"properties,omitempty"`
+	Required    []string             `json:"required,omitempty"`
+	Items       *mcpSchema           `json:"items,omitempty"`
+	Minimum     *int                 `json:"minimum,omitempty"`
+	Maximum     *int                 `json:"maximum,omitempty"`
+}
+
+type mcpTool struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	InputSchema mcpSchema `json:"inputSchema"`
+
+	// call runs the tool and returns the text handed back to the model.
+	call func(client *Client, args json.RawMessage) (string, error)
+}
+
+// newMCPTool creates a tool whose arguments are decoded into T after the
+// schema's required properties are checked.
+func newMCPTool[T any](name, description string, schema mcpSchema, call func(*Client, T) (string, error)) mcpTool {
+	return mcpTool{
+		Name:        name,
+		Description: description,
+		InputSchema: schema,
+		call: func(client *Client, raw json.RawMessage) (string, error) {
+			var present map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &present); err != nil {
+				return "", fmt.Errorf("arguments must be a JSON object: %w", err)
+			}
+			for _, name := range schema.Required {
+				value := bytes.TrimSpace(present[name])
+				if len(value) == 0 || string(value) == "null" || string(value) == `""` {
+					return "", fmt.Errorf("%s is required", name)
+				}
+			}
+			var args T
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return "", fmt.Errorf("invalid arguments: %w", err)
+			}
+			return call(client, args)
+		},
+	}
+}
+
+func mcpString(description string) mcpSchema {
+	return mcpSchema{Type: "string", Description: description}
+}
+
+func mcpObject(required []string, properties map[string]mcpSchema) mcpSchema {
+	return mcpSchema{Type: "object", Properties: properties, Required: required}
+}
+
+// mcpPost posts body to path and returns the response, or done when the
+// server answers with no content.
+func mcpPost(client *Client, path string, body any, done string) (string, error) {
+	respBody, err := client.DoPost(path, body)
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(respBody)) == 0 {
+		return done, nil
+	}
+	return string(respBody), nil
+}
+
+func mcpTools() []mcpTool {
+	minPriority, maxPriority := 0, 4
+
+	return []mcpTool{
+		newMCPTool("create_rune", "Create a rune. Returns the created rune as JSON.",
+			mcpObject([]string{"title"}, map[string]mcpSchema{
+				"title":       mcpString("Short title of the work"),
+				"description": mcpString("Longer description of the work"),
+				"priority":    {Type: "integer", Description: "Priority from 0 (highest) to 4; defaults to the realm's setting", Minimum: &minPriority, Maximum: &maxPriority},
+				"parent_id":   mcpString("ID of the parent rune"),
+				"branch":      mcpString("Git branch for the work; defaults to the realm's setting"),
+				"tags":        {Type: "array", Description: "Tags to add", Items: &mcpSchema{Type: "string"}},
+				"type":        mcpString("Rune type, such as task, bug or epic"),
+			}),
+			func(client *Client, args struct {
+				Title       string   `json:"title"`
+				Description string   `json:"description,omitempty"`
+				Priority    *int     `json:"priority,omitempty"`
+				ParentID    string   `json:"parent_id,omitempty"`
+				Branch      *string  `json:"branch,omitempty"`
+				Tags        []string `json:"tags,omitempty"`
+				Type        string   `json:"type,omitempty"`
+			}) (string, error) {
+				if args.Priority != nil && (*args.Priority < minPriority || *args.Priority > maxPriority) {
+					return "", fmt.Errorf("priority must be between %d and %d", minPriority, maxPriority)
+				}
+				normalized := make([]string, 0, len(args.Tags))
+				for _, tag := range args.Tags {
+					tag = strings.ToLower(strings.TrimSpace(tag))
+					if tag != "" {
+						normalized = append(normalized, tag)
+					}
+				}
+				args.Tags = normalized
+				return mcpPost(client, "/create-rune", args, "Rune created")
+			}),
+
+		newMCPTool("forge_rune", "Move a draft rune to open so it can be claimed.",
+			mcpObject([]string{"id"}, map[string]mcpSchema{
+				"id": mcpString("ID of the rune to forge"),
+			}),
+			func(client *Client, args struct {
+				ID string `json:"id"`
+			}) (string, error) {
+				return mcpPost(client, "/forge-rune", args, fmt.Sprintf("Rune %s forged", args.ID))
+			}),
+
+		newMCPTool("claim_rune", "Claim an open rune to work on it.",
+			mcpObject([]string{"id"}, map[string]mcpSchema{
+				"id":       mcpString("ID of the rune to claim"),
+				"claimant": mcpString("Who is claiming the rune; defaults to the system username"),
+			}),
+			func(client *Client, args struct {
+				ID       string `json:"id"`
+				Claimant string `json:"claimant"`
+			}) (string, error) {
+				if args.Claimant == "" {
+					if u, err := user.Current(); err == nil {
+						args.Claimant = u.Username
+					}
+				}
+				return mcpPost(client, "/claim-rune", args, fmt.Sprintf("Rune %s claimed", args.ID))
+			}),
+
+		newMCPTool("fulfill_rune", "Mark a claimed rune as fulfilled once its work is done.",
+			mcpObject([]string{"id"}, map[string]mcpSchema{
+				"id": mcpString("ID of the rune to fulfill"),
+			}),
+			func(client *Client, args struct {
+				ID string `json:"id"`
+			}) (string, error) {
+				return mcpPost(client, "/fulfill-rune", args, fmt.Sprintf("Rune %s fulfilled", args.ID))
+			}),
+
+		newMCPTool("add_note", "Add a note to a rune.",
+			mcpObject([]string{"rune_id", "text"}, map[string]mcpSchema{
+				"rune_id": mcpString("ID of the rune"),
+				"text":    mcpString("Text of the note"),
+			}),
+			func(client *Client, args struct {
+				RuneID string `json:"rune_id"`
+				Text   string `json:"text"`
+			}) (string, error) {
+				return mcpPost(client, "/add-note", args, fmt.Sprintf("Note added to rune %s", args.RuneID))
+			}),
+
+		newMCPTool("add_retro", "Add a retrospective item to a rune.",
+			mcpObject([]string{"rune_id", "text"}, map[string]mcpSchema{
+				"rune_id": mcpString("ID of the rune"),
+				"text":    mcpString("What went well, what did not, or what to change"),
+			}),
+			func(client *Client, args struct {
+				RuneID string `json:"rune_id"`
+				Text   string `json:"text"`
+			}) (string, error) {
+				return mcpPost(client, "/add-retro", args, fmt.Sprintf("Retro item added to rune %s", args.RuneID))
+			}),
+
+		newMCPTool("add_ac", "Add an acceptance criterion to a rune.",
+			mcpObject([]string{"rune_id", "scenario", "description"}, map[string]mcpSchema{
+				"rune_id":     mcpString("ID of the rune"),
+				"scenario":    mcpString("Short name of the scenario"),
+				"description": mcpString("What must be true for the scenario to pass"),
+			}),
+			func(client *Client, args struct {
+				RuneID      string `json:"rune_id"`
+				Scenario    string `json:"scenario"`
+				Description string `json:"description"`
+			}) (string, error) {
+				return mcpPost(client, "/add-ac", args, fmt.Sprintf("Acceptance criterion added to rune %s", args.RuneID))
+			}),
+
+		newMCPTool("update_ac", "Replace the scenario and description of a rune's acceptance criterion.",
+			mcpObject([]string{"rune_id", "id", "scenario", "description"}, map[string]mcpSchema{
+				"rune_id":     mcpString("ID of the rune"),
+				"id":          mcpString("ID of the acceptance criterion, such as AC-01"),
+				"scenario":    mcpString("Name of the scenario, repeated if unchanged"),
+				"description": mcpString("Description of the scenario, repeated if unchanged"),
+			}),
+			func(client *Client, args struct {
+				RuneID      string `json:"rune_id"`
+				ID          string `json:"id"`
+				Scenario    string `json:"scenario"`
+				Description string `json:"description"`
+			}) (string, error) {
+				return mcpPost(client, "/update-ac", args, fmt.Sprintf("Acceptance criterion %s of rune %s updated", args.ID, args.RuneID))
+			}),
+
+		newMCPTool("patch_rune_state", "Apply a JSON Merge Patch (RFC 7396) to a rune's state. Set a key to null to remove it.",
+			mcpObject([]string{"rune_id", "patch"}, map[string]mcpSchema{
+				"rune_id": mcpString("ID of the rune"),
+				"patch":   {Type: "object", Description: "JSON Merge Patch to apply to the state"},
+			}),
+			func(client *Client, args struct {
+				RuneID string          `json:"rune_id"`
+				Patch  json.RawMessage `json:"patch"`
+			}) (string, error) {
+				var patch map[string]any
+				if err := json.Unmarshal(args.Patch, &patch); err != nil || patch == nil {
+					return "", fmt.Errorf("patch must be a JSON object")
+				}
+				body := map[string]any{
+					"rune_id": args.RuneID,
+					"patch":   string(args.Patch),
+				}
+				return mcpPost(client, "/update-rune-state", body, fmt.Sprintf("Rune %s state updated", args.RuneID))
+			}),
+	}
+}
+
+type mcpResource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MimeType    string `json:"mimeType"`
+}
+
+type mcpResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MimeType    string `json:"mimeType"`
+}
+
+// mcpRunePrefix is the URI prefix of the rune detail resource template.
+const mcpRunePrefix = "bifrost://runes/"
+
+func mcpResources() []mcpResource {
+	return []mcpResource{
+		{URI: "bifrost://ready", Name: "ready-runes", Description: "Open, unblocked runes ready to be claimed", MimeType: "application/json"},
+		{URI: "bifrost://runes", Name: "runes", Description: "Every rune in the realm", MimeType: "application/json"},
+	}
+}
+
+func mcpResourceTemplates() []mcpResourceTemplate {
+	return []mcpResourceTemplate{
+		{URITemplate: mcpRunePrefix + "{id}", Name: "rune", Description: "A rune with its dependencies, notes, acceptance criteria and state", MimeType: "application/json"},
+	}
+}
+
+// readMCPResource fetches the JSON behind a resource URI.
+func readMCPResource(client *Client, uri string) ([]byte, error) {
+	var body []byte
+	var err error
+	switch {
+	case uri == "bifrost://ready":
+		body, err = client.DoGet("/ready")
+	case uri == "bifrost://runes":
+		body, err = client.DoGet("/runes")
+	case strings.HasPrefix(uri, mcpRunePrefix) && len(uri) > len(mcpRunePrefix):
+		body, err = client.DoGetWithParams("/rune", map[string]string{"id": strings.TrimPrefix(uri, mcpRunePrefix)})
+	default:
+		return nil, &jsonRPCError{Code: mcpResourceNotFound, Message: fmt.Sprintf("resource not found: %s", uri)}
+	}
+	if err != nil {
+		return nil, &jsonRPCError{Code: jsonRPCInternalError, Message: err.Error()}
+	}
+	return body, nil
+}
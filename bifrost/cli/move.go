@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+type MoveCmd struct {
+	Command *cobra.Command
+}
+
+func NewMoveCmd(clientFn func() *Client, out *bytes.Buffer) *MoveCmd {
+	c := &MoveCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "move [id]",
+		Short: "Move a rune under a different parent or to top level",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			parent, _ := cmd.Flags().GetString("parent")
+			topLevel, _ := cmd.Flags().GetBool("top-level")
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			if (parent == "") == !topLevel {
+				return fmt.Errorf("exactly one of --parent or --top-level is required")
+			}
+
+			body := map[string]any{"id": id}
+			if parent != "" {
+				body["parent_id"] = parent
+			}
+
+			respBody, err := clientFn().DoPost("/move-rune", body)
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var result struct {
+					NewParentID string `json:"new_parent_id"`
+					Alias       string `json:"alias"`
+				}
+				if json.Unmarshal(data, &result) != nil {
+					return
+				}
+				if result.NewParentID != "" {
+					fmt.Fprintf(w, "Moved rune %s under %s", id, result.NewParentID)
+				} else {
+					fmt.Fprintf(w, "Moved rune %s to top level", id)
+				}
+				if result.Alias != "" {
+					fmt.Fprintf(w, " (now %s)", result.Alias)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().String("parent", "", "new parent rune ID")
+	cmd.Flags().Bool("top-level", false, "make the rune top-level")
+	cmd.Flags().Bool("human", false, "human-readable output")
+
+	c.Command = cmd
+	return c
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestMoveCommand(t *testing.T) {
+	t.Run("sends POST to /move-rune with id and parent", func(t *testing.T) {
+		tc := newMoveTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`{"id":"bf-abc.1","old_parent_id":"bf-abc","new_parent_id":"bf-def","alias":"bf-def.4","status":"open"}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_move("bf-abc.1", "--parent", "bf-def")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_method_was("POST")
+		tc.request_path_was("/api/move-rune")
+		tc.request_body_has_field("id", "bf-abc.1")
+		tc.request_body_has_field("parent_id", "bf-def")
+		tc.output_contains(`"alias":"bf-def.4"`)
+	})
+
+	t.Run("omits parent_id with --top-level and prints the new alias", func(t *testing.T) {
+		tc := newMoveTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`{"id":"bf-abc.1","old_parent_id":"bf-abc","alias":"bf-9f3e","status":"open"}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_move("bf-abc.1", "--top-level", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_body_lacks_field("parent_id")
+		tc.output_contains("Moved rune bf-abc.1 to top level (now bf-9f3e)")
+	})
+
+	t.Run("requires exactly one of --parent or --top-level", func(t *testing.T) {
+		tc := newMoveTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`{}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_move("bf-abc.1", "--parent", "bf-def", "--top-level")
+
+		// Then
+		tc.command_has_error()
+		tc.no_request_was_sent()
+	})
+}
+
+// --- Test Context ---
+
+type moveTestContext struct {
+	t *testing.T
+
+	server         *httptest.Server
+	client         *Client
+	receivedMethod string
+	receivedPath   string
+	receivedBody   map[string]any
+	buf            *bytes.Buffer
+	err            error
+}
+
+func newMoveTestContext(t *testing.T) *moveTestContext {
+	t.Helper()
+	return &moveTestContext{
+		t:   t,
+		buf: &bytes.Buffer{},
+	}
+}
+
+// --- Given ---
+
+func (tc *moveTestContext) server_that_captures_request_and_returns_json(jsonStr string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedMethod = r.Method
+		tc.receivedPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &tc.receivedBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(jsonStr))
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *moveTestContext) client_configured() {
+	tc.t.Helper()
+	tc.client = NewClient(tc.server.URL, "test-key", "test-realm")
+}
+
+// --- When ---
+
+func (tc *moveTestContext) execute_move(args ...string) {
+	tc.t.Helper()
+	cmd := NewMoveCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
+// --- Then ---
+
+func (tc *moveTestContext) command_has_no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *moveTestContext) command_has_error() {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+}
+
+func (tc *moveTestContext) request_method_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedMethod)
+}
+
+func (tc *moveTestContext) request_path_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedPath)
+}
+
+func (tc *moveTestContext) request_body_has_field(key, expected string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
+func (tc *moveTestContext) request_body_lacks_field(key string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.NotContains(tc.t, tc.receivedBody, key)
+}
+
+func (tc *moveTestContext) output_contains(substr string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.buf.String(), substr)
+}
+
+func (tc *moveTestContext) no_request_was_sent() {
+	tc.t.Helper()
+	assert.Empty(tc.t, tc.receivedMethod)
+}
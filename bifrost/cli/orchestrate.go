@@ -202,8 +202,9 @@ func runOrchestrator(
 
 func fetchReadyRunes(client *Client) ([]map[string]any, error) {
 	params := map[string]string{
-		"status":  "open",
-		"blocked": "false",
+		"status":   "open",
+		"blocked":  "false",
+		"deferred": "false",
 	}
 
 	body, err := client.DoGetWithParams("/runes", params)
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+type PolicyCmd struct {
+	Command *cobra.Command
+}
+
+func NewPolicyCmd(clientFn func() *Client, out *bytes.Buffer) *PolicyCmd {
+	c := &PolicyCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Manage realm policies",
+		Long: `Manage the policies evaluated before rune commands in the current realm.
+
+A policy is an expression over action, role, command and rune that must be
+true for the command to proceed. Setting and removing policies requires the
+admin role.
+
+Subcommands:
+		  list   - List policies
+		  set    - Add or replace a policy
+		  remove - Remove a policy`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(c.newListCmd(clientFn, out))
+	cmd.AddCommand(c.newSetCmd(clientFn, out))
+	cmd.AddCommand(c.newRemoveCmd(clientFn, out))
+
+	c.Command = cmd
+	return c
+}
+
+func (c *PolicyCmd) newListCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List realm policies",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			respBody, err := clientFn().DoGet("/policies")
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var policies []struct {
+					Name       string   `json:"name"`
+					Actions    []string `json:"actions"`
+					Expression string   `json:"expression"`
+				}
+				if json.Unmarshal(data, &policies) != nil {
+					return
+				}
+				if len(policies) == 0 {
+					fmt.Fprintln(w, "No policies")
+					return
+				}
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "NAME\tACTIONS\tEXPRESSION")
+				for _, p := range policies {
+					actions := strings.Join(p.Actions, ",")
+					if actions == "" {
+						actions = "*"
+					}
+					fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Name, actions, p.Expression)
+				}
+				tw.Flush()
+			})
+		},
+	}
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+func (c *PolicyCmd) newSetCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set [name]",
+		Short: "Add or replace a realm policy",
+		Long: `Add a policy, or replace the policy with the same name.
+
+Examples:
+		  bf policy set retro-before-fulfill --on fulfill --expr 'rune.retro_count > 0'
+		  bf policy set claim-needs-ready --on claim --expr '"ready" in rune.tags'
+		  bf policy set p0-needs-description --on create \
+		    --expr 'command.priority != 0 || len(command.description) > 0' \
+		    --message "priority 0 runes need a description"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			expression, _ := cmd.Flags().GetString("expr")
+			actions, _ := cmd.Flags().GetStringSlice("on")
+			message, _ := cmd.Flags().GetString("message")
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			if expression == "" {
+				return fmt.Errorf("--expr is required")
+			}
+
+			body := map[string]any{
+				"name":       name,
+				"expression": expression,
+			}
+			if len(actions) > 0 {
+				body["actions"] = actions
+			}
+			if message != "" {
+				body["message"] = message
+			}
+
+			if _, err := clientFn().DoPost("/set-policy", body); err != nil {
+				return err
+			}
+
+			if humanMode {
+				fmt.Fprintf(out, "Policy %s set", name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String("expr", "", "expression that must be true for the command to proceed")
+	cmd.Flags().StringSlice("on", nil, "actions the policy applies to, e.g. claim,fulfill (default all)")
+	cmd.Flags().String("message", "", "message returned when the policy rejects a command")
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+func (c *PolicyCmd) newRemoveCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove [name]",
+		Short: "Remove a realm policy",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			if _, err := clientFn().DoPost("/remove-policy", map[string]string{"name": name}); err != nil {
+				return err
+			}
+
+			if humanMode {
+				fmt.Fprintf(out, "Policy %s removed", name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
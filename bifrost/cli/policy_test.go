@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestPolicyCommand(t *testing.T) {
+	t.Run("set sends POST to /set-policy with name, actions, expression and message", func(t *testing.T) {
+		tc := newPolicyTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.client_configured()
+
+		// When
+		tc.execute("set", "retro-before-fulfill", "--on", "fulfill,seal", "--expr", "rune.retro_count > 0", "--message", "add a retro")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/set-policy")
+		tc.request_body_has_field("name", "retro-before-fulfill")
+		tc.request_body_has_field("expression", "rune.retro_count > 0")
+		tc.request_body_has_field("message", "add a retro")
+		tc.request_body_has_field("actions", []any{"fulfill", "seal"})
+	})
+
+	t.Run("set requires --expr", func(t *testing.T) {
+		tc := newPolicyTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.client_configured()
+
+		// When
+		tc.execute("set", "empty")
+
+		// Then
+		tc.command_has_error_containing("--expr is required")
+	})
+
+	t.Run("remove sends POST to /remove-policy", func(t *testing.T) {
+		tc := newPolicyTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.client_configured()
+
+		// When
+		tc.execute("remove", "retro-before-fulfill", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/remove-policy")
+		tc.request_body_has_field("name", "retro-before-fulfill")
+		tc.output_contains("Policy retro-before-fulfill removed")
+	})
+
+	t.Run("list prints a table in human mode", func(t *testing.T) {
+		tc := newPolicyTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK,
+			`[{"name":"claim-needs-ready","actions":["claim"],"expression":"\"ready\" in rune.tags"},{"name":"everything","expression":"true"}]`)
+		tc.client_configured()
+
+		// When
+		tc.execute("list", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/policies")
+		tc.output_contains("claim-needs-ready")
+		tc.output_contains(`"ready" in rune.tags`)
+		tc.output_matches(`everything\s+\*\s+true`)
+	})
+}
+
+// --- Test Context ---
+
+type policyTestContext struct {
+	t *testing.T
+
+	server       *httptest.Server
+	client       *Client
+	receivedPath string
+	receivedBody map[string]any
+	buf          *bytes.Buffer
+	err          error
+}
+
+func newPolicyTestContext(t *testing.T) *policyTestContext {
+	t.Helper()
+	return &policyTestContext{
+		t:   t,
+		buf: &bytes.Buffer{},
+	}
+}
+
+// --- Given ---
+
+func (tc *policyTestContext) server_that_captures_request_and_returns(status int, body string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedPath = r.URL.Path
+		reqBody, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(reqBody, &tc.receivedBody)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *policyTestContext) client_configured() {
+	tc.t.Helper()
+	tc.client = NewClient(tc.server.URL, "test-key", "test-realm")
+}
+
+// --- When ---
+
+func (tc *policyTestContext) execute(args ...string) {
+	tc.t.Helper()
+	cmd := NewPolicyCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
+// --- Then ---
+
+func (tc *policyTestContext) command_has_no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *policyTestContext) command_has_error_containing(substr string) {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+	assert.Contains(tc.t, tc.err.Error(), substr)
+}
+
+func (tc *policyTestContext) request_path_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedPath)
+}
+
+func (tc *policyTestContext) request_body_has_field(key string, expected any) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
+func (tc *policyTestContext) output_contains(substr string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.buf.String(), substr)
+}
+
+func (tc *policyTestContext) output_matches(pattern string) {
+	tc.t.Helper()
+	assert.Regexp(tc.t, pattern, tc.buf.String())
+}
@@ -16,6 +16,7 @@ func NewRealmCmd(root *RootCmd) *cobra.Command {
 
 	cmd.AddCommand(newRealmCreateCmd(root))
 	cmd.AddCommand(newRealmListCmd(root))
+	cmd.AddCommand(newRealmSettingsCmd(root))
 
 	return cmd
 }
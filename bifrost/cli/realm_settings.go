@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func newRealmSettingsCmd(root *RootCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "View and change settings for the current realm",
+	}
+
+	cmd.AddCommand(newRealmSettingsGetCmd(root))
+	cmd.AddCommand(newRealmSettingsSetCmd(root))
+
+	return cmd
+}
+
+func newRealmSettingsGetCmd(root *RootCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the current realm's settings",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			respBody, err := root.Client.DoGet("/realm-settings")
+			if err != nil {
+				return fmt.Errorf("getting realm settings: %w", err)
+			}
+
+			if humanMode {
+				var settings map[string]any
+				if err := json.Unmarshal(respBody, &settings); err != nil {
+					return fmt.Errorf("parsing response: %w", err)
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				for _, key := range []string{
+					"saga_auto_complete", "require_ac_verification", "require_branch",
+					"default_priority", "default_rune_type", "max_state_size",
+				} {
+					fmt.Fprintf(w, "%s\t%v\n", key, settings[key])
+				}
+				var wip struct {
+					WIPLimits wipLimits `json:"wip_limits"`
+				}
+				_ = json.Unmarshal(respBody, &wip)
+				fmt.Fprintf(w, "wip_limits\t%s\n", wip.WIPLimits)
+				w.Flush()
+				return nil
+			}
+
+			cmd.Print(string(respBody))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newRealmSettingsSetCmd(root *RootCmd) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Change settings for the current realm",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			body := map[string]any{}
+			if cmd.Flags().Changed("saga-auto-complete") {
+				body["saga_auto_complete"], _ = cmd.Flags().GetString("saga-auto-complete")
+			}
+			if cmd.Flags().Changed("require-ac-verification") {
+				body["require_ac_verification"], _ = cmd.Flags().GetBool("require-ac-verification")
+			}
+			if cmd.Flags().Changed("require-branch") {
+				body["require_branch"], _ = cmd.Flags().GetBool("require-branch")
+			}
+			if cmd.Flags().Changed("default-priority") {
+				body["default_priority"], _ = cmd.Flags().GetInt("default-priority")
+			}
+			if cmd.Flags().Changed("default-rune-type") {
+				body["default_rune_type"], _ = cmd.Flags().GetString("default-rune-type")
+			}
+			if cmd.Flags().Changed("max-state-size") {
+				body["max_state_size"], _ = cmd.Flags().GetInt("max-state-size")
+			}
+			if wipFlagsChanged(cmd) {
+				limits, err := realmWIPLimitsWithFlags(root, cmd)
+				if err != nil {
+					return err
+				}
+				body["wip_limits"] = limits
+			}
+			if len(body) == 0 {
+				return fmt.Errorf("at least one setting flag is required")
+			}
+
+			if _, err := root.Client.DoPost("/realm-settings", body); err != nil {
+				return fmt.Errorf("updating realm settings: %w", err)
+			}
+
+			if humanMode {
+				fmt.Fprintln(cmd.OutOrStdout(), "Realm settings updated")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("saga-auto-complete", "", "saga auto-complete mode (off, fulfill, flag)")
+	cmd.Flags().Bool("require-ac-verification", false, "require verified acceptance criteria before fulfill")
+	cmd.Flags().Bool("require-branch", true, "require --branch on top-level runes")
+	cmd.Flags().Int("default-priority", 0, "priority for runes created without one (0-4)")
+	cmd.Flags().String("default-rune-type", "", "type for runes created without one")
+	cmd.Flags().Int("max-state-size", 0, "maximum rune state size in bytes")
+	cmd.Flags().Int("wip-per-claimant", 0, "maximum runes claimed at once per claimant (0 for unlimited)")
+	cmd.Flags().Int("wip-per-account", 0, "maximum runes claimed at once per account (0 for unlimited)")
+	cmd.Flags().Int("wip-per-realm", 0, "maximum runes claimed at once in the realm (0 for unlimited)")
+	cmd.Flags().StringArray("wip-per-tag", nil, "maximum runes claimed at once per tag as tag=N, repeatable (N=0 removes the limit)")
+
+	return cmd
+}
+
+// wipLimits mirrors the realm's wip_limits setting.
+type wipLimits struct {
+	PerClaimant int            `json:"per_claimant,omitempty"`
+	PerAccount  int            `json:"per_account,omitempty"`
+	PerRealm    int            `json:"per_realm,omitempty"`
+	PerTag      map[string]int `json:"per_tag,omitempty"`
+}
+
+func (l wipLimits) String() string {
+	var parts []string
+	if l.PerClaimant > 0 {
+		parts = append(parts, fmt.Sprintf("per_claimant=%d", l.PerClaimant))
+	}
+	if l.PerAccount > 0 {
+		parts = append(parts, fmt.Sprintf("per_account=%d", l.PerAccount))
+	}
+	if l.PerRealm > 0 {
+		parts = append(parts, fmt.Sprintf("per_realm=%d", l.PerRealm))
+	}
+	tags := make([]string, 0, len(l.PerTag))
+	for tag := range l.PerTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		parts = append(parts, fmt.Sprintf("tag:%s=%d", tag, l.PerTag[tag]))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, " ")
+}
+
+func wipFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{"wip-per-claimant", "wip-per-account", "wip-per-realm", "wip-per-tag"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// realmWIPLimitsWithFlags reads the realm's current WIP limits and applies
+// the changed flags on top, since the server replaces wip_limits wholesale.
+func realmWIPLimitsWithFlags(root *RootCmd, cmd *cobra.Command) (wipLimits, error) {
+	respBody, err := root.Client.DoGet("/realm-settings")
+	if err != nil {
+		return wipLimits{}, fmt.Errorf("getting realm settings: %w", err)
+	}
+	var current struct {
+		WIPLimits wipLimits `json:"wip_limits"`
+	}
+	if err := json.Unmarshal(respBody, &current); err != nil {
+		return wipLimits{}, fmt.Errorf("parsing response: %w", err)
+	}
+	limits := current.WIPLimits
+
+	if cmd.Flags().Changed("wip-per-claimant") {
+		limits.PerClaimant, _ = cmd.Flags().GetInt("wip-per-claimant")
+	}
+	if cmd.Flags().Changed("wip-per-account") {
+		limits.PerAccount, _ = cmd.Flags().GetInt("wip-per-account")
+	}
+	if cmd.Flags().Changed("wip-per-realm") {
+		limits.PerRealm, _ = cmd.Flags().GetInt("wip-per-realm")
+	}
+	perTag, _ := cmd.Flags().GetStringArray("wip-per-tag")
+	for _, entry := range perTag {
+		tag, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(tag) == "" {
+			return wipLimits{}, fmt.Errorf("invalid --wip-per-tag %q: expected tag=N", entry)
+		}
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return wipLimits{}, fmt.Errorf("invalid --wip-per-tag %q: %s is not a number", entry, value)
+		}
+		if limits.PerTag == nil {
+			limits.PerTag = map[string]int{}
+		}
+		if limit == 0 {
+			delete(limits.PerTag, tag)
+		} else {
+			limits.PerTag[tag] = limit
+		}
+	}
+	return limits, nil
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestRealmSettingsGetCommand(t *testing.T) {
+	t.Run("gets realm settings", func(t *testing.T) {
+		tc := newRealmTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(`{"realm_id":"test-realm","require_branch":true,"default_priority":0}`)
+		tc.root_cmd_with_server()
+
+		// When
+		tc.run_realm("settings", "get")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_method_was("GET")
+		tc.request_path_was("/api/realm-settings")
+		tc.output_contains(`"require_branch":true`)
+	})
+
+	t.Run("outputs human-readable settings", func(t *testing.T) {
+		tc := newRealmTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(`{"realm_id":"test-realm","saga_auto_complete":"off","require_branch":false,"default_rune_type":"task","max_state_size":1024}`)
+		tc.root_cmd_with_server()
+
+		// When
+		tc.run_realm("settings", "get", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_matches(`require_branch\s+false`)
+		tc.output_matches(`default_rune_type\s+task`)
+		tc.output_matches(`max_state_size\s+1024`)
+		tc.output_matches(`wip_limits\s+none`)
+	})
+
+	t.Run("outputs WIP limits in human mode", func(t *testing.T) {
+		tc := newRealmTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(`{"realm_id":"test-realm","wip_limits":{"per_claimant":2,"per_tag":{"deploy":1}}}`)
+		tc.root_cmd_with_server()
+
+		// When
+		tc.run_realm("settings", "get", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_matches(`wip_limits\s+per_claimant=2 tag:deploy=1`)
+	})
+}
+
+func TestRealmSettingsSetCommand(t *testing.T) {
+	t.Run("posts only the flags that were set", func(t *testing.T) {
+		tc := newRealmTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(``)
+		tc.root_cmd_with_server()
+
+		// When
+		tc.run_realm("settings", "set", "--require-branch=false", "--default-priority", "2", "--max-state-size", "1024")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_method_was("POST")
+		tc.request_path_was("/api/realm-settings")
+		tc.request_body_has_value("require_branch", false)
+		tc.request_body_has_value("default_priority", float64(2))
+		tc.request_body_has_value("max_state_size", float64(1024))
+		tc.request_body_lacks("default_rune_type")
+		tc.request_body_lacks("saga_auto_complete")
+	})
+
+	t.Run("returns error when no setting flag is given", func(t *testing.T) {
+		tc := newRealmTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(``)
+		tc.root_cmd_with_server()
+
+		// When
+		tc.run_realm("settings", "set")
+
+		// Then
+		tc.command_has_error_containing("at least one setting flag is required")
+		tc.request_method_was("")
+	})
+
+	t.Run("merges WIP limit flags into the current limits", func(t *testing.T) {
+		tc := newRealmTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(`{"realm_id":"test-realm","wip_limits":{"per_claimant":2,"per_tag":{"deploy":1,"ops":3}}}`)
+		tc.root_cmd_with_server()
+
+		// When
+		tc.run_realm("settings", "set", "--wip-per-realm", "10", "--wip-per-tag", "deploy=2", "--wip-per-tag", "ops=0")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_method_was("POST")
+		tc.request_body_has_value("wip_limits", map[string]any{
+			"per_claimant": float64(2),
+			"per_realm":    float64(10),
+			"per_tag":      map[string]any{"deploy": float64(2)},
+		})
+	})
+
+	t.Run("rejects a malformed --wip-per-tag", func(t *testing.T) {
+		tc := newRealmTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(`{"realm_id":"test-realm"}`)
+		tc.root_cmd_with_server()
+
+		// When
+		tc.run_realm("settings", "set", "--wip-per-tag", "deploy")
+
+		// Then
+		tc.command_has_error_containing("expected tag=N")
+	})
+}
+
+// --- When ---
+
+func (tc *realmTestContext) run_realm(args ...string) {
+	tc.t.Helper()
+	tc.root.Command.SetArgs(append([]string{"realm"}, args...))
+	buf := new(bytes.Buffer)
+	tc.root.Command.SetOut(buf)
+	tc.root.Command.SetErr(buf)
+	tc.cmdErr = tc.root.Command.Execute()
+	tc.output = buf.String()
+}
+
+// --- Then ---
+
+func (tc *realmTestContext) command_has_error_containing(substr string) {
+	tc.t.Helper()
+	require.Error(tc.t, tc.cmdErr)
+	assert.Contains(tc.t, tc.cmdErr.Error(), substr)
+}
+
+func (tc *realmTestContext) request_body_has_value(key string, expected any) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody, "expected request body to be present")
+	val, ok := tc.receivedBody[key]
+	require.True(tc.t, ok, "expected key %q in request body", key)
+	assert.Equal(tc.t, expected, val)
+}
+
+func (tc *realmTestContext) request_body_lacks(key string) {
+	tc.t.Helper()
+	_, ok := tc.receivedBody[key]
+	assert.False(tc.t, ok, "expected key %q to be absent from request body", key)
+}
+
+func (tc *realmTestContext) output_matches(pattern string) {
+	tc.t.Helper()
+	assert.Regexp(tc.t, pattern, tc.output)
+}
@@ -24,11 +24,18 @@ func NewReopenCmd(clientFn func() *Client, out *bytes.Buffer) *ReopenCmd {
 			humanMode, _ := cmd.Flags().GetBool("human")
 
 			body := map[string]any{"id": id, "as_claimed": asClaimed}
+			recursive, err := applyCascadeFlags(cmd, body)
+			if err != nil {
+				return err
+			}
 
-			_, err := clientFn().DoPost("/reopen-rune", body)
+			respBody, err := clientFn().DoPost("/reopen-rune", body)
 			if err != nil {
 				return err
 			}
+			if recursive {
+				return writeCascadeResults(out, respBody, humanMode)
+			}
 
 			if humanMode {
 				fmt.Fprintf(out, "Rune %s reopened", id)
@@ -40,6 +47,7 @@ func NewReopenCmd(clientFn func() *Client, out *bytes.Buffer) *ReopenCmd {
 
 	cmd.Flags().Bool("claim", false, "reopen as claimed (preserves claimant)")
 	cmd.Flags().Bool("human", false, "human-readable output")
+	addCascadeFlags(cmd)
 
 	c.Command = cmd
 	return c
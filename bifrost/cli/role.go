@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+type RoleCmd struct {
+	Command *cobra.Command
+}
+
+func NewRoleCmd(clientFn func() *Client, out *bytes.Buffer) *RoleCmd {
+	c := &RoleCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "role",
+		Short: "Manage custom realm roles",
+		Long: `Manage the roles of the current realm.
+
+The built-in roles (owner, admin, member, viewer) are fixed permission
+bundles. A custom role grants exactly the permissions it lists, e.g. a role
+that can create and note runes but not shatter or sweep them. Defining and
+deleting roles requires the admin role. Assign a custom role with
+"bf admin assign-role".
+
+Subcommands:
+		  list   - List roles and their permissions
+		  define - Add or replace a custom role
+		  delete - Delete a custom role`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(c.newListCmd(clientFn, out))
+	cmd.AddCommand(c.newDefineCmd(clientFn, out))
+	cmd.AddCommand(c.newDeleteCmd(clientFn, out))
+
+	c.Command = cmd
+	return c
+}
+
+func (c *RoleCmd) newListCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List built-in and custom roles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			respBody, err := clientFn().DoGet("/roles")
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var roles []struct {
+					Name        string   `json:"name"`
+					Permissions []string `json:"permissions"`
+				}
+				if json.Unmarshal(data, &roles) != nil {
+					return
+				}
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "NAME\tPERMISSIONS")
+				for _, r := range roles {
+					fmt.Fprintf(tw, "%s\t%s\n", r.Name, strings.Join(r.Permissions, ","))
+				}
+				tw.Flush()
+			})
+		},
+	}
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+func (c *RoleCmd) newDefineCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "define [name]",
+		Short: "Add or replace a custom role",
+		Long: `Add a custom role, or replace the custom role with the same name.
+
+Permissions are named after the commands they allow, plus view-runes and
+view-realm for queries. Run "bf role list" to see the built-in bundles.
+
+Examples:
+		  bf role define triager --permission view-runes,view-realm,create-rune,add-note
+		  bf role define ac-editor --permission view-runes,add-ac,update-ac,remove-ac,verify-ac`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			permissions, _ := cmd.Flags().GetStringSlice("permission")
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			if len(permissions) == 0 {
+				return fmt.Errorf("--permission is required")
+			}
+
+			body := map[string]any{
+				"name":        name,
+				"permissions": permissions,
+			}
+			if _, err := clientFn().DoPost("/define-role", body); err != nil {
+				return err
+			}
+
+			if humanMode {
+				fmt.Fprintf(out, "Role %s defined", name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSlice("permission", nil, "permissions the role grants (repeatable or comma-separated)")
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+func (c *RoleCmd) newDeleteCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete a custom role",
+		Long: `Delete a custom role. Accounts still assigned the role keep it in name
+but hold no permissions until they are assigned another role.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			if _, err := clientFn().DoPost("/delete-role", map[string]string{"name": name}); err != nil {
+				return err
+			}
+
+			if humanMode {
+				fmt.Fprintf(out, "Role %s deleted", name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestRoleCommand(t *testing.T) {
+	t.Run("define sends POST to /define-role with name and permissions", func(t *testing.T) {
+		tc := newRoleTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.client_configured()
+
+		// When
+		tc.execute("define", "triager", "--permission", "view-runes,create-rune", "--permission", "add-note", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/define-role")
+		tc.request_body_has_field("name", "triager")
+		tc.request_body_has_field("permissions", []any{"view-runes", "create-rune", "add-note"})
+		tc.output_contains("Role triager defined")
+	})
+
+	t.Run("define requires --permission", func(t *testing.T) {
+		tc := newRoleTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.client_configured()
+
+		// When
+		tc.execute("define", "triager")
+
+		// Then
+		tc.command_has_error_containing("--permission is required")
+	})
+
+	t.Run("delete sends POST to /delete-role", func(t *testing.T) {
+		tc := newRoleTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.client_configured()
+
+		// When
+		tc.execute("delete", "triager")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/delete-role")
+		tc.request_body_has_field("name", "triager")
+	})
+
+	t.Run("list prints a table in human mode", func(t *testing.T) {
+		tc := newRoleTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK,
+			`[{"name":"viewer","permissions":["view-runes","view-realm"]},{"name":"triager","permissions":["view-runes","add-note"]}]`)
+		tc.client_configured()
+
+		// When
+		tc.execute("list", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/roles")
+		tc.output_matches(`viewer\s+view-runes,view-realm`)
+		tc.output_matches(`triager\s+view-runes,add-note`)
+	})
+}
+
+// --- Test Context ---
+
+type roleTestContext struct {
+	t *testing.T
+
+	server       *httptest.Server
+	client       *Client
+	receivedPath string
+	receivedBody map[string]any
+	buf          *bytes.Buffer
+	err          error
+}
+
+func newRoleTestContext(t *testing.T) *roleTestContext {
+	t.Helper()
+	return &roleTestContext{
+		t:   t,
+		buf: &bytes.Buffer{},
+	}
+}
+
+// --- Given ---
+
+func (tc *roleTestContext) server_that_captures_request_and_returns(status int, body string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedPath = r.URL.Path
+		reqBody, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(reqBody, &tc.receivedBody)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *roleTestContext) client_configured() {
+	tc.t.Helper()
+	tc.client = NewClient(tc.server.URL, "test-key", "test-realm")
+}
+
+// --- When ---
+
+func (tc *roleTestContext) execute(args ...string) {
+	tc.t.Helper()
+	cmd := NewRoleCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
+// --- Then ---
+
+func (tc *roleTestContext) command_has_no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *roleTestContext) command_has_error_containing(substr string) {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+	assert.Contains(tc.t, tc.err.Error(), substr)
+}
+
+func (tc *roleTestContext) request_path_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedPath)
+}
+
+func (tc *roleTestContext) request_body_has_field(key string, expected any) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
+func (tc *roleTestContext) output_contains(substr string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.buf.String(), substr)
+}
+
+func (tc *roleTestContext) output_matches(pattern string) {
+	tc.t.Helper()
+	assert.Regexp(tc.t, pattern, tc.buf.String())
+}
@@ -68,6 +68,7 @@ func NewRootCmd() *RootCmd {
 	cmd.AddCommand(NewLoginCmd())
 	cmd.AddCommand(NewLogoutCmd())
 	cmd.AddCommand(NewAdminCmd().Command)
+	cmd.AddCommand(NewRealmCmd(root))
 
 	return root
 }
@@ -13,6 +13,7 @@ func RegisterRuneCommands(root *RootCmd, out *bytes.Buffer) {
 	root.Command.AddCommand(NewShowCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewListCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewReadyCmd(clientFn, out).Command)
+	root.Command.AddCommand(NewSearchCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewClaimCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewUnclaimCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewFulfillCmd(clientFn, out).Command)
@@ -22,10 +23,17 @@ func RegisterRuneCommands(root *RootCmd, out *bytes.Buffer) {
 	root.Command.AddCommand(NewForgeCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewUpdateCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewNoteCmd(clientFn, out).Command)
+	root.Command.AddCommand(NewVerifyCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewStateCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewRetroCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewEventsCmd(clientFn, out).Command)
 	root.Command.AddCommand(NewSweepCmd(clientFn, out, os.Stdin).Command)
 	root.Command.AddCommand(NewShatterCmd(clientFn, out, os.Stdin).Command)
+	root.Command.AddCommand(NewMoveCmd(clientFn, out).Command)
+	root.Command.AddCommand(NewPolicyCmd(clientFn, out).Command)
+	root.Command.AddCommand(NewWebhookCmd(clientFn, out).Command)
+	root.Command.AddCommand(NewRoleCmd(clientFn, out).Command)
+	root.Command.AddCommand(NewStatusCmd(clientFn, out).Command)
+	root.Command.AddCommand(NewMCPCmd(clientFn, os.Stdin, os.Stdout).Command)
 	root.Command.AddCommand(NewOrchestrateCmd(clientFn, cfgFn).Command)
 }
@@ -23,15 +23,22 @@ func NewSealCmd(clientFn func() *Client, out *bytes.Buffer) *SealCmd {
 			reason, _ := cmd.Flags().GetString("reason")
 			humanMode, _ := cmd.Flags().GetBool("human")
 
-			body := map[string]string{"id": id}
+			body := map[string]any{"id": id}
 			if reason != "" {
 				body["reason"] = reason
 			}
+			recursive, err := applyCascadeFlags(cmd, body)
+			if err != nil {
+				return err
+			}
 
-			_, err := clientFn().DoPost("/seal-rune", body)
+			respBody, err := clientFn().DoPost("/seal-rune", body)
 			if err != nil {
 				return err
 			}
+			if recursive {
+				return writeCascadeResults(out, respBody, humanMode)
+			}
 
 			if humanMode {
 				fmt.Fprintf(out, "Rune %s sealed", id)
@@ -43,6 +50,7 @@ func NewSealCmd(clientFn func() *Client, out *bytes.Buffer) *SealCmd {
 
 	cmd.Flags().String("reason", "", "reason for sealing")
 	cmd.Flags().Bool("human", false, "human-readable output")
+	addCascadeFlags(cmd)
 
 	c.Command = cmd
 	return c
@@ -62,6 +62,55 @@ func TestSealCommand(t *testing.T) {
 		tc.output_contains("Rune bf-abc sealed")
 	})
 
+	t.Run("sends cascade flags and prints results with --recursive --dry-run", func(t *testing.T) {
+		tc := newSealTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`{"dry_run":true,"results":[{"rune_id":"bf-abc","result":"would_apply"},{"rune_id":"bf-abc.1","result":"skipped","reason":"rune is fulfilled"}]}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_seal_with_args("bf-abc", "--recursive", "--dry-run", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_body_has_bool_field("cascade", true)
+		tc.request_body_has_bool_field("dry_run", true)
+		tc.output_contains("Dry run: no changes made")
+		tc.output_contains("bf-abc.1         skipped (rune is fulfilled)")
+	})
+
+	t.Run("returns error when a cascaded rune fails", func(t *testing.T) {
+		tc := newSealTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`{"dry_run":false,"results":[{"rune_id":"bf-abc","result":"applied"},{"rune_id":"bf-abc.1","result":"failed","reason":"boom"}]}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_seal_with_args("bf-abc", "--recursive")
+
+		// Then
+		tc.command_has_error()
+		tc.output_contains(`"result":"failed"`)
+	})
+
+	t.Run("rejects --dry-run without --recursive", func(t *testing.T) {
+		tc := newSealTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute_seal_with_args("bf-abc", "--dry-run")
+
+		// Then
+		tc.command_has_error()
+		tc.output_contains("--dry-run requires --recursive")
+		tc.no_request_was_sent()
+	})
+
 	t.Run("returns error when server responds with error", func(t *testing.T) {
 		tc := newSealTestContext(t)
 
@@ -114,6 +163,19 @@ func (tc *sealTestContext) server_that_captures_request_and_returns_no_content()
 	tc.t.Cleanup(tc.server.Close)
 }
 
+func (tc *sealTestContext) server_that_captures_request_and_returns_json(jsonStr string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedMethod = r.Method
+		tc.receivedPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &tc.receivedBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(jsonStr))
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
 func (tc *sealTestContext) server_that_returns_error(status int, message string) {
 	tc.t.Helper()
 	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -155,6 +217,14 @@ func (tc *sealTestContext) execute_seal_with_human(id string) {
 	tc.err = cmd.Command.Execute()
 }
 
+func (tc *sealTestContext) execute_seal_with_args(args ...string) {
+	tc.t.Helper()
+	cmd := NewSealCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
 // --- Then ---
 
 func (tc *sealTestContext) command_has_no_error() {
@@ -187,3 +257,14 @@ func (tc *sealTestContext) output_contains(substr string) {
 	tc.t.Helper()
 	assert.Contains(tc.t, tc.buf.String(), substr)
 }
+
+func (tc *sealTestContext) request_body_has_bool_field(key string, expected bool) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
+func (tc *sealTestContext) no_request_was_sent() {
+	tc.t.Helper()
+	assert.Empty(tc.t, tc.receivedMethod)
+}
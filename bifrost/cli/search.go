@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+type SearchCmd struct {
+	Command *cobra.Command
+}
+
+func NewSearchCmd(clientFn func() *Client, out *bytes.Buffer) *SearchCmd {
+	c := &SearchCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Full-text search rune titles, descriptions, acceptance criteria, notes and retros",
+		Long: `Full-text search rune titles, descriptions, acceptance criteria, notes and retros.
+
+Results are ranked best match first. The query supports "quoted phrases",
+OR between words and -word to exclude a word.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, _ := cmd.Flags().GetString("status")
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			limit, _ := cmd.Flags().GetInt("limit")
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			params := map[string]string{"q": args[0]}
+			if status != "" {
+				params["status"] = status
+			}
+			if limit > 0 {
+				params["limit"] = strconv.Itoa(limit)
+			}
+			if len(tags) > 0 {
+				normalized := make([]string, 0, len(tags))
+				for _, tag := range tags {
+					tag = strings.ToLower(strings.TrimSpace(tag))
+					if tag != "" {
+						normalized = append(normalized, tag)
+					}
+				}
+				if len(normalized) > 0 {
+					params["tags"] = strings.Join(normalized, ",")
+				}
+			}
+
+			respBody, err := clientFn().DoGetWithParams("/api/search", params)
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var results []map[string]any
+				if json.Unmarshal(data, &results) != nil {
+					return
+				}
+				if len(results) == 0 {
+					fmt.Fprintln(w, "No runes found")
+					return
+				}
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintf(tw, "ID\tTitle\tStatus\tPriority\n")
+				for _, r := range results {
+					id, _ := r["id"].(string)
+					title, _ := r["title"].(string)
+					st, _ := r["status"].(string)
+					p := ""
+					if pv, ok := r["priority"].(float64); ok {
+						p = fmt.Sprintf("%d", int(pv))
+					}
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", id, title, st, p)
+					// The snippet has no tabs, so it does not widen the columns
+					if snippet, _ := r["snippet"].(string); snippet != "" {
+						fmt.Fprintf(tw, "    %s\n", strings.Join(strings.Fields(snippet), " "))
+					}
+				}
+				tw.Flush()
+			})
+		},
+	}
+
+	cmd.Flags().String("status", "", "filter by status (open|claimed|fulfilled|sealed)")
+	cmd.Flags().StringSlice("tag", nil, "filter by tag (repeatable)")
+	cmd.Flags().Int("limit", 0, "maximum number of results (server default 20, max 100)")
+	cmd.Flags().Bool("human", false, "human-readable table output")
+
+	c.Command = cmd
+	return c
+}
@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestSearchCommand(t *testing.T) {
+	t.Run("sends GET to /api/search with the query", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`[]`)
+		tc.client_configured()
+
+		// When
+		tc.execute_search("login redirect")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_method_was("GET")
+		tc.request_path_was("/api/search")
+		tc.request_query_param_was("q", "login redirect")
+		tc.request_query_param_absent("limit")
+	})
+
+	t.Run("sends filters and limit as query params", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`[]`)
+		tc.client_configured()
+
+		// When
+		tc.execute_search("login", "--status", "open", "--tag", "Auth", "--tag", "ui", "--limit", "5")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_query_param_was("status", "open")
+		tc.request_query_param_was("tags", "auth,ui")
+		tc.request_query_param_was("limit", "5")
+	})
+
+	t.Run("outputs JSON response by default", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`[{"id":"bf-1","title":"Fix login","status":"open","priority":1,"rank":2.5,"snippet":"fix **login**"}]`)
+		tc.client_configured()
+
+		// When
+		tc.execute_search("login")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains(`"id":"bf-1"`)
+		tc.output_contains(`"snippet":"fix **login**"`)
+	})
+
+	t.Run("outputs table with snippets when --human flag is set", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`[{"id":"bf-1","title":"Fix login","status":"open","priority":1,"rank":2.5,"snippet":"users see\na **login** loop"}]`)
+		tc.client_configured()
+
+		// When
+		tc.execute_search("login", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("ID")
+		tc.output_contains("bf-1")
+		tc.output_contains("Fix login")
+		tc.output_contains("    users see a **login** loop")
+	})
+
+	t.Run("reports no runes found in human mode", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`[]`)
+		tc.client_configured()
+
+		// When
+		tc.execute_search("nothing", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("No runes found")
+	})
+
+	t.Run("requires a query", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_json(`[]`)
+		tc.client_configured()
+
+		// When
+		tc.execute_search()
+
+		// Then
+		tc.command_has_error()
+	})
+
+	t.Run("returns error when server responds with error", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.server_that_returns_error(http.StatusNotImplemented, "search is not supported by this database")
+		tc.client_configured()
+
+		// When
+		tc.execute_search("login")
+
+		// Then
+		tc.command_has_error()
+		tc.output_contains("search is not supported by this database")
+	})
+}
+
+// --- Test Context ---
+
+type searchTestContext struct {
+	t *testing.T
+
+	server         *httptest.Server
+	client         *Client
+	receivedMethod string
+	receivedPath   string
+	receivedQuery  map[string]string
+	buf            *bytes.Buffer
+	err            error
+}
+
+func newSearchTestContext(t *testing.T) *searchTestContext {
+	t.Helper()
+	return &searchTestContext{
+		t:             t,
+		buf:           &bytes.Buffer{},
+		receivedQuery: make(map[string]string),
+	}
+}
+
+// --- Given ---
+
+func (tc *searchTestContext) server_that_captures_request_and_returns_json(jsonStr string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedMethod = r.Method
+		tc.receivedPath = r.URL.Path
+		for k, v := range r.URL.Query() {
+			tc.receivedQuery[k] = v[0]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(jsonStr))
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *searchTestContext) server_that_returns_error(status int, message string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *searchTestContext) client_configured() {
+	tc.t.Helper()
+	tc.client = NewClient(tc.server.URL, "test-key", "test-realm")
+}
+
+// --- When ---
+
+func (tc *searchTestContext) execute_search(args ...string) {
+	tc.t.Helper()
+	cmd := NewSearchCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetOut(tc.buf)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
+// --- Then ---
+
+func (tc *searchTestContext) command_has_no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *searchTestContext) command_has_error() {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+}
+
+func (tc *searchTestContext) request_method_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedMethod)
+}
+
+func (tc *searchTestContext) request_path_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedPath)
+}
+
+func (tc *searchTestContext) request_query_param_was(key, expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedQuery[key])
+}
+
+func (tc *searchTestContext) request_query_param_absent(key string) {
+	tc.t.Helper()
+	assert.NotContains(tc.t, tc.receivedQuery, key)
+}
+
+func (tc *searchTestContext) output_contains(substr string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.buf.String(), substr)
+}
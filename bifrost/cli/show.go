@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -39,6 +40,9 @@ func NewShowCmd(clientFn func() *Client, out *bytes.Buffer) *ShowCmd {
 					claimant, _ := result["claimant"].(string)
 
 					fmt.Fprintf(w, "ID:          %s\n", id)
+					if alias, ok := result["alias"].(string); ok && alias != "" {
+						fmt.Fprintf(w, "Alias:       %s\n", alias)
+					}
 					fmt.Fprintf(w, "Title:       %s\n", title)
 					fmt.Fprintf(w, "Status:      %s\n", status)
 					if priority, ok := result["priority"].(float64); ok {
@@ -50,9 +54,18 @@ func NewShowCmd(clientFn func() *Client, out *bytes.Buffer) *ShowCmd {
 					if desc != "" {
 						fmt.Fprintf(w, "Description: %s\n", desc)
 					}
+					if agent, _ := result["claimed_by_agent"].(bool); agent && claimant != "" {
+						claimant += " (agent)"
+					}
 					if claimant != "" {
 						fmt.Fprintf(w, "Claimant:    %s\n", claimant)
 					}
+					if dueAt, ok := result["due_at"].(string); ok && dueAt != "" {
+						fmt.Fprintf(w, "Due:         %s\n", dueAt)
+					}
+					if deferUntil, ok := result["defer_until"].(string); ok && deferUntil != "" {
+						fmt.Fprintf(w, "Deferred:    until %s\n", deferUntil)
+					}
 					if tags, ok := result["tags"].([]any); ok {
 						rendered := make([]string, 0, len(tags))
 						for _, raw := range tags {
@@ -66,6 +79,27 @@ func NewShowCmd(clientFn func() *Client, out *bytes.Buffer) *ShowCmd {
 							fmt.Fprintf(w, "Tags:        \n")
 						}
 					}
+					if progress, ok := result["saga_progress"].(map[string]any); ok {
+						total, _ := progress["total"].(float64)
+						percent, _ := progress["percent_complete"].(float64)
+						blocked, _ := progress["blocked"].(float64)
+						counts, _ := progress["counts"].(map[string]any)
+						fulfilled, _ := counts["fulfilled"].(float64)
+						fmt.Fprintf(w, "Progress:    %d/%d fulfilled (%d%%), %d blocked\n", int(fulfilled), int(total), int(percent), int(blocked))
+						statuses := make([]string, 0, len(counts))
+						for status := range counts {
+							statuses = append(statuses, status)
+						}
+						sort.Strings(statuses)
+						rendered := make([]string, 0, len(statuses))
+						for _, status := range statuses {
+							n, _ := counts[status].(float64)
+							rendered = append(rendered, fmt.Sprintf("%s %d", status, int(n)))
+						}
+						if len(rendered) > 0 {
+							fmt.Fprintf(w, "             %s\n", strings.Join(rendered, ", "))
+						}
+					}
 					if deps, ok := result["dependencies"].([]any); ok && len(deps) > 0 {
 						fmt.Fprintf(w, "Dependencies:\n")
 						for _, d := range deps {
@@ -85,7 +119,21 @@ func NewShowCmd(clientFn func() *Client, out *bytes.Buffer) *ShowCmd {
 								id, _ := acMap["id"].(string)
 								scenario, _ := acMap["scenario"].(string)
 								desc, _ := acMap["description"].(string)
-								fmt.Fprintf(w, "  %s: %s - %s\n", id, scenario, desc)
+								status, _ := acMap["status"].(string)
+								if status == "" {
+									fmt.Fprintf(w, "  %s: %s - %s\n", id, scenario, desc)
+								} else {
+									fmt.Fprintf(w, "  %s [%s]: %s - %s\n", id, status, scenario, desc)
+								}
+								evidence, _ := acMap["evidence"].(string)
+								verifier, _ := acMap["verifier"].(string)
+								if evidence != "" || verifier != "" {
+									line := "    Evidence: " + evidence
+									if verifier != "" {
+										line += " (by " + verifier + ")"
+									}
+									fmt.Fprintln(w, strings.TrimRight(line, " "))
+								}
 							}
 						}
 					}
@@ -98,6 +98,31 @@ func TestShowCommand_AcceptanceCriteria(t *testing.T) {
 		tc.ac_output_order_is_sequential()
 	})
 
+	t.Run("human output shows verification status, evidence and verifier", func(t *testing.T) {
+		tc := newShowACTestContext(t)
+
+		// Given
+		tc.server_that_returns_json(`{
+			"id":"bf-abc",
+			"title":"My Rune",
+			"status":"claimed",
+			"priority":1,
+			"acceptance_criteria":[
+				{"id":"AC-01","scenario":"happy path","description":"works","status":"verified","evidence":"TestHappyPath passed","verifier":"agent-1"},
+				{"id":"AC-02","scenario":"sad path","description":"fails cleanly","status":"pending"}
+			]
+		}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_show_with_human("bf-abc")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("AC-01 [verified]: happy path - works")
+		tc.output_contains("Evidence: TestHappyPath passed (by agent-1)")
+		tc.output_contains("AC-02 [pending]: sad path - fails cleanly")
+	})
 }
 
 // ---------------------------------------------------------------------------
@@ -47,6 +47,53 @@ func TestShowCommand(t *testing.T) {
 		tc.output_contains(`"title":"My Rune"`)
 	})
 
+	t.Run("shows due and deferral times in human-readable output", func(t *testing.T) {
+		tc := newShowTestContext(t)
+
+		// Given
+		tc.server_that_returns_json(`{"id":"bf-abc","title":"My Rune","status":"open","priority":1,"due_at":"2026-03-01T12:00:00Z","defer_until":"2026-02-01T08:00:00Z"}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_show_with_human("bf-abc")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("Due:         2026-03-01T12:00:00Z")
+		tc.output_contains("Deferred:    until 2026-02-01T08:00:00Z")
+	})
+
+	t.Run("shows saga progress in human-readable output", func(t *testing.T) {
+		tc := newShowTestContext(t)
+
+		// Given
+		tc.server_that_returns_json(`{"id":"bf-abc","title":"Epic","status":"open","priority":1,"saga_progress":{"total":12,"percent_complete":58,"blocked":2,"counts":{"fulfilled":7,"open":5}}}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_show_with_human("bf-abc")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("Progress:    7/12 fulfilled (58%), 2 blocked")
+		tc.output_contains("fulfilled 7, open 5")
+	})
+
+	t.Run("shows alias of a moved rune in human-readable output", func(t *testing.T) {
+		tc := newShowTestContext(t)
+
+		// Given
+		tc.server_that_returns_json(`{"id":"bf-abc.2","alias":"bf-def.5","title":"Step","status":"open","priority":1}`)
+		tc.client_configured()
+
+		// When
+		tc.execute_show_with_human("bf-def.5")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("ID:          bf-abc.2\nAlias:       bf-def.5\n")
+	})
+
 	t.Run("outputs human-readable format when --human flag is set", func(t *testing.T) {
 		tc := newShowTestContext(t)
 
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+type StatusCmd struct {
+	Command *cobra.Command
+}
+
+type wipUsage struct {
+	Limits struct {
+		PerClaimant int            `json:"per_claimant"`
+		PerAccount  int            `json:"per_account"`
+		PerRealm    int            `json:"per_realm"`
+		PerTag      map[string]int `json:"per_tag"`
+	} `json:"limits"`
+	Realm     int            `json:"realm"`
+	Claimants map[string]int `json:"claimants"`
+	Accounts  map[string]int `json:"accounts"`
+	Tags      map[string]int `json:"tags"`
+}
+
+func NewStatusCmd(clientFn func() *Client, out *bytes.Buffer) *StatusCmd {
+	c := &StatusCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show work-in-progress usage for the current realm",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			respBody, err := clientFn().DoGet("/wip")
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var usage wipUsage
+				if json.Unmarshal(data, &usage) != nil {
+					return
+				}
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintf(tw, "Realm claims:\t%s\n", formatWIPCount(usage.Realm, usage.Limits.PerRealm))
+				writeWIPSection(tw, "Claimants", usage.Claimants, func(string) int { return usage.Limits.PerClaimant })
+				writeWIPSection(tw, "Accounts", usage.Accounts, func(string) int { return usage.Limits.PerAccount })
+				writeWIPSection(tw, "Tags", usage.Tags, func(tag string) int { return usage.Limits.PerTag[tag] })
+				tw.Flush()
+			})
+		},
+	}
+
+	cmd.Flags().Bool("human", false, "human-readable output")
+
+	c.Command = cmd
+	return c
+}
+
+func writeWIPSection(w *tabwriter.Writer, title string, counts map[string]int, limitFor func(string) int) {
+	if len(counts) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(w, "%s:\t\n", title)
+	for _, key := range keys {
+		fmt.Fprintf(w, "  %s\t%s\n", key, formatWIPCount(counts[key], limitFor(key)))
+	}
+}
+
+// formatWIPCount renders a claim count against its limit, where 0 is unlimited.
+func formatWIPCount(count, limit int) string {
+	if limit == 0 {
+		return fmt.Sprintf("%d", count)
+	}
+	return fmt.Sprintf("%d/%d", count, limit)
+}
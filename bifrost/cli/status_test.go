@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestStatusCommand(t *testing.T) {
+	t.Run("sends GET to /wip and prints JSON", func(t *testing.T) {
+		tc := newStatusTestContext(t)
+
+		// Given
+		tc.server_that_returns(`{"limits":{"per_realm":5},"realm":2,"claimants":{"odin":2},"accounts":{},"tags":{}}`)
+		tc.client_configured()
+
+		// When
+		tc.execute()
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/wip")
+		tc.output_contains(`"realm":2`)
+	})
+
+	t.Run("prints usage against limits in human mode", func(t *testing.T) {
+		tc := newStatusTestContext(t)
+
+		// Given
+		tc.server_that_returns(`{"limits":{"per_claimant":3,"per_realm":5,"per_tag":{"deploy":1}},"realm":2,"claimants":{"odin":2},"accounts":{"acct-1":2},"tags":{"deploy":1,"ops":1}}`)
+		tc.client_configured()
+
+		// When
+		tc.execute("--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_matches(`Realm claims:\s+2/5`)
+		tc.output_matches(`odin\s+2/3`)
+		tc.output_matches(`acct-1\s+2\n`)
+		tc.output_matches(`deploy\s+1/1`)
+		tc.output_matches(`ops\s+1\n`)
+	})
+}
+
+// --- Test Context ---
+
+type statusTestContext struct {
+	t *testing.T
+
+	server       *httptest.Server
+	client       *Client
+	receivedPath string
+	buf          *bytes.Buffer
+	err          error
+}
+
+func newStatusTestContext(t *testing.T) *statusTestContext {
+	t.Helper()
+	return &statusTestContext{
+		t:   t,
+		buf: &bytes.Buffer{},
+	}
+}
+
+// --- Given ---
+
+func (tc *statusTestContext) server_that_returns(body string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *statusTestContext) client_configured() {
+	tc.t.Helper()
+	tc.client = NewClient(tc.server.URL, "test-key", "test-realm")
+}
+
+// --- When ---
+
+func (tc *statusTestContext) execute(args ...string) {
+	tc.t.Helper()
+	cmd := NewStatusCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
+// --- Then ---
+
+func (tc *statusTestContext) command_has_no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *statusTestContext) request_path_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedPath)
+}
+
+func (tc *statusTestContext) output_contains(substr string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.buf.String(), substr)
+}
+
+func (tc *statusTestContext) output_matches(pattern string) {
+	tc.t.Helper()
+	assert.Regexp(tc.t, pattern, tc.buf.String())
+}
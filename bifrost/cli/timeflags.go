@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseDuration extends time.ParseDuration with day ("30d") and week ("2w") units.
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration: empty")
+	}
+	unit := s[len(s)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		day := 24 * time.Hour
+		if unit == 'w' {
+			return time.Duration(n) * 7 * day, nil
+		}
+		return time.Duration(n) * day, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+	return d, nil
+}
+
+// parseTimeFlag parses an absolute (RFC 3339 or YYYY-MM-DD) or relative
+// ("3d", "12h") time value. Relative values are offsets from now.
+func parseTimeFlag(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t.UTC(), nil
+	}
+	d, err := parseDuration(strings.TrimPrefix(value, "+"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use RFC 3339, YYYY-MM-DD or a relative duration like 3d", value)
+	}
+	return now.Add(d).UTC(), nil
+}
+
+// parsePastTimeFlag is parseTimeFlag with relative values counted back from
+// now, so "--since 7d" means seven days ago.
+func parsePastTimeFlag(value string, now time.Time) (time.Time, error) {
+	if d, err := parseDuration(value); err == nil {
+		return now.Add(-d).UTC(), nil
+	}
+	return parseTimeFlag(value, now)
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestParseDuration(t *testing.T) {
+	t.Run("parses day units", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.duration_is_parsed("30d")
+
+		// Then
+		tc.no_error()
+		tc.duration_is(30 * 24 * time.Hour)
+	})
+
+	t.Run("parses week units", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.duration_is_parsed("2w")
+
+		// Then
+		tc.no_error()
+		tc.duration_is(14 * 24 * time.Hour)
+	})
+
+	t.Run("falls back to standard Go durations", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.duration_is_parsed("90m")
+
+		// Then
+		tc.no_error()
+		tc.duration_is(90 * time.Minute)
+	})
+
+	t.Run("rejects garbage", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.duration_is_parsed("soon")
+
+		// Then
+		tc.error_contains("invalid duration")
+	})
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	t.Run("parses RFC 3339 timestamps", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.time_is_parsed("2026-03-01T12:00:00+02:00")
+
+		// Then
+		tc.no_error()
+		tc.time_is(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("parses relative durations from now", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.time_is_parsed("3d")
+
+		// Then
+		tc.no_error()
+		tc.time_is(tc.now.Add(72 * time.Hour))
+	})
+
+	t.Run("parses plus-prefixed relative durations", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.time_is_parsed("+12h")
+
+		// Then
+		tc.no_error()
+		tc.time_is(tc.now.Add(12 * time.Hour))
+	})
+
+	t.Run("parses calendar dates", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.time_is_parsed("2026-03-01")
+
+		// Then
+		tc.no_error()
+		tc.time_is(time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local))
+	})
+
+	t.Run("rejects unrecognized values", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.time_is_parsed("next tuesday")
+
+		// Then
+		tc.error_contains("invalid time")
+	})
+}
+
+func TestParsePastTimeFlag(t *testing.T) {
+	t.Run("counts relative durations back from now", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.past_time_is_parsed("7d")
+
+		// Then
+		tc.no_error()
+		tc.time_is(tc.now.Add(-7 * 24 * time.Hour))
+	})
+
+	t.Run("parses absolute times like parseTimeFlag", func(t *testing.T) {
+		tc := newTimeFlagsTestContext(t)
+
+		// When
+		tc.past_time_is_parsed("2026-03-01T12:00:00Z")
+
+		// Then
+		tc.no_error()
+		tc.time_is(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	})
+}
+
+// --- Test Context ---
+
+type timeFlagsTestContext struct {
+	t *testing.T
+
+	now      time.Time
+	duration time.Duration
+	parsed   time.Time
+	err      error
+}
+
+func newTimeFlagsTestContext(t *testing.T) *timeFlagsTestContext {
+	t.Helper()
+	return &timeFlagsTestContext{
+		t:   t,
+		now: time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+// --- When ---
+
+func (tc *timeFlagsTestContext) duration_is_parsed(value string) {
+	tc.t.Helper()
+	tc.duration, tc.err = parseDuration(value)
+}
+
+func (tc *timeFlagsTestContext) time_is_parsed(value string) {
+	tc.t.Helper()
+	tc.parsed, tc.err = parseTimeFlag(value, tc.now)
+}
+
+func (tc *timeFlagsTestContext) past_time_is_parsed(value string) {
+	tc.t.Helper()
+	tc.parsed, tc.err = parsePastTimeFlag(value, tc.now)
+}
+
+// --- Then ---
+
+func (tc *timeFlagsTestContext) no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *timeFlagsTestContext) error_contains(substr string) {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+	assert.Contains(tc.t, tc.err.Error(), substr)
+}
+
+func (tc *timeFlagsTestContext) duration_is(expected time.Duration) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.duration)
+}
+
+func (tc *timeFlagsTestContext) time_is(expected time.Time) {
+	tc.t.Helper()
+	assert.True(tc.t, expected.Equal(tc.parsed), "expected %s, got %s", expected, tc.parsed)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -26,6 +27,20 @@ func NewUpdateCmd(clientFn func() *Client, out *bytes.Buffer) *UpdateCmd {
 			humanMode, _ := cmd.Flags().GetBool("human")
 
 			body := map[string]any{"id": id}
+			recursive, err := applyCascadeFlags(cmd, body)
+			if err != nil {
+				return err
+			}
+			if recursive {
+				if !cmd.Flags().Changed("priority") {
+					return fmt.Errorf("--recursive requires --priority")
+				}
+				for _, name := range []string{"title", "description", "branch", "due", "clear-due", "defer-until", "clear-defer", "add-tag", "remove-tag", "ac-add", "ac-update", "ac-remove"} {
+					if cmd.Flags().Changed(name) {
+						return fmt.Errorf("--recursive only applies to --priority, not --%s", name)
+					}
+				}
+			}
 
 			if cmd.Flags().Changed("title") {
 				title, _ := cmd.Flags().GetString("title")
@@ -47,6 +62,28 @@ func NewUpdateCmd(clientFn func() *Client, out *bytes.Buffer) *UpdateCmd {
 				branch, _ := cmd.Flags().GetString("branch")
 				body["branch"] = branch
 			}
+			if cmd.Flags().Changed("due") {
+				due, _ := cmd.Flags().GetString("due")
+				t, err := parseTimeFlag(due, time.Now())
+				if err != nil {
+					return err
+				}
+				body["due_at"] = t.Format(time.RFC3339)
+			}
+			if clearDue, _ := cmd.Flags().GetBool("clear-due"); clearDue {
+				body["clear_due_at"] = true
+			}
+			if cmd.Flags().Changed("defer-until") {
+				deferUntil, _ := cmd.Flags().GetString("defer-until")
+				t, err := parseTimeFlag(deferUntil, time.Now())
+				if err != nil {
+					return err
+				}
+				body["defer_until"] = t.Format(time.RFC3339)
+			}
+			if clearDefer, _ := cmd.Flags().GetBool("clear-defer"); clearDefer {
+				body["clear_defer_until"] = true
+			}
 			if cmd.Flags().Changed("add-tag") {
 				tags, _ := cmd.Flags().GetStringSlice("add-tag")
 				normalized := make([]string, 0, len(tags))
@@ -70,10 +107,13 @@ func NewUpdateCmd(clientFn func() *Client, out *bytes.Buffer) *UpdateCmd {
 				body["remove_tags"] = normalized
 			}
 
-			_, err := clientFn().DoPost("/update-rune", body)
+			respBody, err := clientFn().DoPost("/update-rune", body)
 			if err != nil {
 				return err
 			}
+			if recursive {
+				return writeCascadeResults(out, respBody, humanMode)
+			}
 
 			// Handle --ac-add flags
 			if cmd.Flags().Changed("ac-add") {
@@ -134,12 +174,17 @@ func NewUpdateCmd(clientFn func() *Client, out *bytes.Buffer) *UpdateCmd {
 	cmd.Flags().String("priority", "", "new priority (0-4)")
 	cmd.Flags().StringP("description", "d", "", "new description")
 	cmd.Flags().String("branch", "", "branch name")
+	cmd.Flags().String("due", "", "due time (RFC 3339, YYYY-MM-DD, or relative like 3d)")
+	cmd.Flags().Bool("clear-due", false, "remove the due time")
+	cmd.Flags().String("defer-until", "", "hide from ready until this time (RFC 3339, YYYY-MM-DD, or relative like 3d)")
+	cmd.Flags().Bool("clear-defer", false, "remove the deferral")
 	cmd.Flags().StringSlice("add-tag", nil, "tag to add (repeatable)")
 	cmd.Flags().StringSlice("remove-tag", nil, "tag to remove (repeatable)")
 	cmd.Flags().StringArray("ac-add", nil, "add acceptance criteria as JSON (repeatable)")
 	cmd.Flags().StringArray("ac-update", nil, "update acceptance criteria as JSON (repeatable)")
 	cmd.Flags().StringArray("ac-remove", nil, "remove acceptance criteria by ID (repeatable)")
 	cmd.Flags().Bool("human", false, "human-readable output")
+	addCascadeFlags(cmd)
 
 	c.Command = cmd
 	return c
@@ -48,6 +48,38 @@ func TestUpdateCommand(t *testing.T) {
 		tc.request_body_has_float_field("priority", 2)
 	})
 
+	t.Run("includes due_at when --due flag is set", func(t *testing.T) {
+		tc := newUpdateTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute_update("bf-abc", "--due", "2026-03-01T12:00:00Z")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_body_has_field("due_at", "2026-03-01T12:00:00Z")
+		tc.request_body_does_not_have_field("clear_due_at")
+	})
+
+	t.Run("sends clear flags when --clear-due and --clear-defer are set", func(t *testing.T) {
+		tc := newUpdateTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute_update("bf-abc", "--clear-due", "--clear-defer")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_body_has_bool_field("clear_due_at", true)
+		tc.request_body_has_bool_field("clear_defer_until", true)
+	})
+
 	t.Run("includes description when -d flag is set", func(t *testing.T) {
 		tc := newUpdateTestContext(t)
 
@@ -124,6 +156,36 @@ func TestUpdateCommand(t *testing.T) {
 		tc.request_body_has_array_field("remove_tags", "old")
 	})
 
+	t.Run("rejects --recursive without --priority", func(t *testing.T) {
+		tc := newUpdateTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute_update("bf-abc", "--recursive", "--title", "New Title")
+
+		// Then
+		tc.command_has_error()
+		tc.output_contains("--recursive requires --priority")
+	})
+
+	t.Run("rejects --recursive combined with other field flags", func(t *testing.T) {
+		tc := newUpdateTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute_update("bf-abc", "--recursive", "--priority", "0", "--title", "New Title")
+
+		// Then
+		tc.command_has_error()
+		tc.output_contains("--recursive only applies to --priority, not --title")
+	})
+
 	t.Run("returns error when server responds with error", func(t *testing.T) {
 		tc := newUpdateTestContext(t)
 
@@ -235,6 +297,12 @@ func (tc *updateTestContext) request_body_has_float_field(key string, expected f
 	assert.Equal(tc.t, expected, tc.receivedBody[key])
 }
 
+func (tc *updateTestContext) request_body_has_bool_field(key string, expected bool) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
 func (tc *updateTestContext) output_contains(substr string) {
 	tc.t.Helper()
 	assert.Contains(tc.t, tc.buf.String(), substr)
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os/user"
+
+	"github.com/spf13/cobra"
+)
+
+type VerifyCmd struct {
+	Command *cobra.Command
+}
+
+func NewVerifyCmd(clientFn func() *Client, out *bytes.Buffer) *VerifyCmd {
+	c := &VerifyCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "verify [id] [ac-id]",
+		Short: "Record the verification status of an acceptance criterion",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			acID := args[1]
+			status, _ := cmd.Flags().GetString("status")
+			evidence, _ := cmd.Flags().GetString("evidence")
+			verifier, _ := cmd.Flags().GetString("as")
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			if verifier == "" {
+				u, err := user.Current()
+				if err == nil {
+					verifier = u.Username
+				}
+			}
+
+			body := map[string]string{
+				"rune_id":  id,
+				"id":       acID,
+				"status":   status,
+				"evidence": evidence,
+				"verifier": verifier,
+			}
+
+			_, err := clientFn().DoPost("/verify-ac", body)
+			if err != nil {
+				return err
+			}
+
+			if humanMode {
+				fmt.Fprintf(out, "%s on rune %s marked %s", acID, id, status)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("status", "verified", "verification status (verified, failed, skipped)")
+	cmd.Flags().String("evidence", "", "evidence such as a test name or command output")
+	cmd.Flags().String("as", "", "verifier name (defaults to system username)")
+	cmd.Flags().Bool("human", false, "human-readable output")
+
+	c.Command = cmd
+	return c
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestVerifyCommand(t *testing.T) {
+	t.Run("sends POST to /verify-ac with status, evidence and verifier", func(t *testing.T) {
+		tc := newVerifyTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute_verify("bf-abc", "AC-01", "--status", "failed", "--evidence", "TestLogin: timeout", "--as", "agent-1")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_method_was("POST")
+		tc.request_path_was("/api/verify-ac")
+		tc.request_body_has_field("rune_id", "bf-abc")
+		tc.request_body_has_field("id", "AC-01")
+		tc.request_body_has_field("status", "failed")
+		tc.request_body_has_field("evidence", "TestLogin: timeout")
+		tc.request_body_has_field("verifier", "agent-1")
+	})
+
+	t.Run("defaults status to verified and verifier to the system username", func(t *testing.T) {
+		tc := newVerifyTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute_verify("bf-abc", "AC-01")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_body_has_field("status", "verified")
+		tc.request_body_has_non_empty_field("verifier")
+	})
+
+	t.Run("outputs human-readable confirmation when --human flag is set", func(t *testing.T) {
+		tc := newVerifyTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns_no_content()
+		tc.client_configured()
+
+		// When
+		tc.execute_verify("bf-abc", "AC-01", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.output_contains("AC-01 on rune bf-abc marked verified")
+	})
+
+	t.Run("returns error when server responds with error", func(t *testing.T) {
+		tc := newVerifyTestContext(t)
+
+		// Given
+		tc.server_that_returns_error(http.StatusUnprocessableEntity, "AC \"AC-99\" does not exist")
+		tc.client_configured()
+
+		// When
+		tc.execute_verify("bf-abc", "AC-99")
+
+		// Then
+		tc.command_has_error()
+		tc.output_contains("AC-99")
+	})
+}
+
+// --- Test Context ---
+
+type verifyTestContext struct {
+	t *testing.T
+
+	server         *httptest.Server
+	client         *Client
+	receivedMethod string
+	receivedPath   string
+	receivedBody   map[string]any
+	buf            *bytes.Buffer
+	err            error
+}
+
+func newVerifyTestContext(t *testing.T) *verifyTestContext {
+	t.Helper()
+	return &verifyTestContext{
+		t:   t,
+		buf: &bytes.Buffer{},
+	}
+}
+
+// --- Given ---
+
+func (tc *verifyTestContext) server_that_captures_request_and_returns_no_content() {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedMethod = r.Method
+		tc.receivedPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &tc.receivedBody)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *verifyTestContext) server_that_returns_error(status int, message string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *verifyTestContext) client_configured() {
+	tc.t.Helper()
+	tc.client = NewClient(tc.server.URL, "test-key", "test-realm")
+}
+
+// --- When ---
+
+func (tc *verifyTestContext) execute_verify(args ...string) {
+	tc.t.Helper()
+	cmd := NewVerifyCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
+// --- Then ---
+
+func (tc *verifyTestContext) command_has_no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *verifyTestContext) command_has_error() {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+}
+
+func (tc *verifyTestContext) request_method_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedMethod)
+}
+
+func (tc *verifyTestContext) request_path_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedPath)
+}
+
+func (tc *verifyTestContext) request_body_has_field(key, expected string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
+func (tc *verifyTestContext) request_body_has_non_empty_field(key string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.NotEmpty(tc.t, tc.receivedBody[key])
+}
+
+func (tc *verifyTestContext) output_contains(substr string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.buf.String(), substr)
+}
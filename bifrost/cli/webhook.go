@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type WebhookCmd struct {
+	Command *cobra.Command
+}
+
+func NewWebhookCmd(clientFn func() *Client, out *bytes.Buffer) *WebhookCmd {
+	c := &WebhookCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Manage realm webhooks",
+		Long: `Manage the webhooks that receive the current realm's rune events.
+
+Each delivery is a JSON POST signed with the webhook's secret: the
+X-Bifrost-Signature header is "sha256=" followed by the hex HMAC-SHA256 of
+the request body. Failed deliveries are retried with exponential backoff.
+Adding, removing and testing webhooks requires the admin role.
+
+Subcommands:
+		  add        - Subscribe a URL to rune events
+		  list       - List webhooks
+		  remove     - Remove a webhook
+		  test       - Send a test delivery
+		  deliveries - Show the delivery log`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(c.newAddCmd(clientFn, out))
+	cmd.AddCommand(c.newListCmd(clientFn, out))
+	cmd.AddCommand(c.newRemoveCmd(clientFn, out))
+	cmd.AddCommand(c.newTestCmd(clientFn, out))
+	cmd.AddCommand(c.newDeliveriesCmd(clientFn, out))
+
+	c.Command = cmd
+	return c
+}
+
+func (c *WebhookCmd) newAddCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add [url]",
+		Short: "Subscribe a URL to rune events",
+		Long: `Subscribe a URL to the realm's rune events. The signing secret is printed
+once; pass --secret to choose it instead.
+
+Examples:
+		  bf webhook add https://ci.example.com/bifrost --on RuneFulfilled,RuneSealed
+		  bf webhook add https://chat.example.com/hook --on RuneClaimed --tag release`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			eventTypes, _ := cmd.Flags().GetStringSlice("on")
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			secret, _ := cmd.Flags().GetString("secret")
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			body := map[string]any{"url": args[0]}
+			if len(eventTypes) > 0 {
+				body["event_types"] = eventTypes
+			}
+			if len(tags) > 0 {
+				body["tags"] = tags
+			}
+			if secret != "" {
+				body["secret"] = secret
+			}
+
+			respBody, err := clientFn().DoPost("/add-webhook", body)
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var result struct {
+					WebhookID string `json:"webhook_id"`
+					Secret    string `json:"secret"`
+				}
+				if json.Unmarshal(data, &result) != nil {
+					return
+				}
+				fmt.Fprintf(w, "Webhook %s added\n", result.WebhookID)
+				fmt.Fprintf(w, "Secret: %s\n", result.Secret)
+				fmt.Fprintln(w, "Store the secret now; it will not be shown again.")
+			})
+		},
+	}
+	cmd.Flags().StringSlice("on", nil, "event types to deliver, e.g. RuneClaimed,RuneFulfilled (default all)")
+	cmd.Flags().StringSlice("tag", nil, "only deliver events for runes with one of these tags")
+	cmd.Flags().String("secret", "", "signing secret (default generated)")
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+func (c *WebhookCmd) newListCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List realm webhooks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			respBody, err := clientFn().DoGet("/webhooks")
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var webhooks []struct {
+					ID         string   `json:"id"`
+					URL        string   `json:"url"`
+					EventTypes []string `json:"event_types"`
+					Tags       []string `json:"tags"`
+				}
+				if json.Unmarshal(data, &webhooks) != nil {
+					return
+				}
+				if len(webhooks) == 0 {
+					fmt.Fprintln(w, "No webhooks")
+					return
+				}
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "ID\tURL\tEVENTS\tTAGS")
+				for _, wh := range webhooks {
+					events := strings.Join(wh.EventTypes, ",")
+					if events == "" {
+						events = "*"
+					}
+					tags := strings.Join(wh.Tags, ",")
+					if tags == "" {
+						tags = "*"
+					}
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", wh.ID, wh.URL, events, tags)
+				}
+				tw.Flush()
+			})
+		},
+	}
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+func (c *WebhookCmd) newRemoveCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove [webhook-id]",
+		Short: "Remove a realm webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			if _, err := clientFn().DoPost("/remove-webhook", map[string]string{"webhook_id": args[0]}); err != nil {
+				return err
+			}
+
+			if humanMode {
+				fmt.Fprintf(out, "Webhook %s removed", args[0])
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+func (c *WebhookCmd) newTestCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test [webhook-id]",
+		Short: "Send a test delivery to a webhook",
+		Long: `Send a signed WebhookTest delivery to a webhook once and report the result.
+A failed test is not retried.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			respBody, err := clientFn().DoPost("/test-webhook", map[string]string{"webhook_id": args[0]})
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var delivery webhookDeliveryView
+				if json.Unmarshal(data, &delivery) != nil {
+					return
+				}
+				if delivery.Status == "delivered" {
+					fmt.Fprintf(w, "Delivered to %s (HTTP %d)\n", args[0], delivery.StatusCode)
+					return
+				}
+				fmt.Fprintf(w, "Delivery to %s failed: %s\n", args[0], delivery.Error)
+			})
+		},
+	}
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+func (c *WebhookCmd) newDeliveriesCmd(clientFn func() *Client, out *bytes.Buffer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deliveries [webhook-id]",
+		Short: "Show the webhook delivery log",
+		Long: `Show the realm's webhook deliveries, newest first, optionally for one webhook.
+Pending deliveries show when they will next be attempted.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			limit, _ := cmd.Flags().GetInt("limit")
+			humanMode, _ := cmd.Flags().GetBool("human")
+
+			params := map[string]string{}
+			if len(args) == 1 {
+				params["webhook_id"] = args[0]
+			}
+			if limit > 0 {
+				params["limit"] = strconv.Itoa(limit)
+			}
+
+			respBody, err := clientFn().DoGetWithParams("/webhook-deliveries", params)
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(out, respBody, humanMode, func(w *bytes.Buffer, data []byte) {
+				var deliveries []webhookDeliveryView
+				if json.Unmarshal(data, &deliveries) != nil {
+					return
+				}
+				if len(deliveries) == 0 {
+					fmt.Fprintln(w, "No deliveries")
+					return
+				}
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "TIME\tWEBHOOK\tEVENT\tRUNE\tSTATUS\tATTEMPTS\tRESULT")
+				for _, d := range deliveries {
+					result := d.Error
+					if d.Status == "delivered" {
+						result = fmt.Sprintf("HTTP %d", d.StatusCode)
+					} else if d.Status == "pending" && d.NextAttemptAt != nil {
+						result = strings.TrimSpace(result + " (next " + d.NextAttemptAt.Local().Format("15:04:05") + ")")
+					}
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+						d.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+						d.WebhookID, d.EventType, dashIfEmpty(d.RuneID), d.Status, d.Attempts, result)
+				}
+				tw.Flush()
+			})
+		},
+	}
+	cmd.Flags().Int("limit", 0, "maximum number of deliveries (server default 50)")
+	cmd.Flags().Bool("human", false, "human-readable output")
+	return cmd
+}
+
+type webhookDeliveryView struct {
+	WebhookID     string     `json:"webhook_id"`
+	EventType     string     `json:"event_type"`
+	RuneID        string     `json:"rune_id"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	StatusCode    int        `json:"status_code"`
+	Error         string     `json:"error"`
+	CreatedAt     time.Time  `json:"created_at"`
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestWebhookCommand(t *testing.T) {
+	t.Run("add sends POST to /add-webhook with URL, event types and tags", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusCreated, `{"webhook_id":"wh-1a2b","secret":"whsec_abc"}`)
+		tc.client_configured()
+
+		// When
+		tc.execute("add", "https://ci.example.com/hook", "--on", "RuneFulfilled,RuneSealed", "--tag", "release", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/add-webhook")
+		tc.request_body_has_field("url", "https://ci.example.com/hook")
+		tc.request_body_has_field("event_types", []any{"RuneFulfilled", "RuneSealed"})
+		tc.request_body_has_field("tags", []any{"release"})
+		tc.output_contains("Webhook wh-1a2b added")
+		tc.output_contains("Secret: whsec_abc")
+	})
+
+	t.Run("list prints a table in human mode", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK,
+			`[{"id":"wh-1","url":"https://ci.example.com/hook","event_types":["RuneFulfilled"]},{"id":"wh-2","url":"https://chat.example.com/hook","tags":["release"]}]`)
+		tc.client_configured()
+
+		// When
+		tc.execute("list", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/webhooks")
+		tc.output_matches(`wh-1\s+https://ci.example.com/hook\s+RuneFulfilled\s+\*`)
+		tc.output_matches(`wh-2\s+https://chat.example.com/hook\s+\*\s+release`)
+	})
+
+	t.Run("remove sends POST to /remove-webhook", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusNoContent, "")
+		tc.client_configured()
+
+		// When
+		tc.execute("remove", "wh-1", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/remove-webhook")
+		tc.request_body_has_field("webhook_id", "wh-1")
+		tc.output_contains("Webhook wh-1 removed")
+	})
+
+	t.Run("test reports a failed delivery", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK,
+			`{"webhook_id":"wh-1","event_type":"WebhookTest","status":"failed","attempts":1,"status_code":500,"error":"500 Internal Server Error"}`)
+		tc.client_configured()
+
+		// When
+		tc.execute("test", "wh-1", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/test-webhook")
+		tc.request_body_has_field("webhook_id", "wh-1")
+		tc.output_contains("Delivery to wh-1 failed: 500 Internal Server Error")
+	})
+
+	t.Run("deliveries filters by webhook and prints a table", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.server_that_captures_request_and_returns(http.StatusOK,
+			`[{"webhook_id":"wh-1","event_type":"RuneFulfilled","rune_id":"bf-a1b2","status":"delivered","attempts":2,"status_code":204,"created_at":"2026-03-01T12:00:00Z"}]`)
+		tc.client_configured()
+
+		// When
+		tc.execute("deliveries", "wh-1", "--limit", "5", "--human")
+
+		// Then
+		tc.command_has_no_error()
+		tc.request_path_was("/api/webhook-deliveries")
+		tc.request_query_was("limit=5&webhook_id=wh-1")
+		tc.output_matches(`wh-1\s+RuneFulfilled\s+bf-a1b2\s+delivered\s+2\s+HTTP 204`)
+	})
+}
+
+// --- Test Context ---
+
+type webhookTestContext struct {
+	t *testing.T
+
+	server        *httptest.Server
+	client        *Client
+	receivedPath  string
+	receivedQuery string
+	receivedBody  map[string]any
+	buf           *bytes.Buffer
+	err           error
+}
+
+func newWebhookTestContext(t *testing.T) *webhookTestContext {
+	t.Helper()
+	return &webhookTestContext{
+		t:   t,
+		buf: &bytes.Buffer{},
+	}
+}
+
+// --- Given ---
+
+func (tc *webhookTestContext) server_that_captures_request_and_returns(status int, body string) {
+	tc.t.Helper()
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.receivedPath = r.URL.Path
+		tc.receivedQuery = r.URL.RawQuery
+		reqBody, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(reqBody, &tc.receivedBody)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	tc.t.Cleanup(tc.server.Close)
+}
+
+func (tc *webhookTestContext) client_configured() {
+	tc.t.Helper()
+	tc.client = NewClient(tc.server.URL, "test-key", "test-realm")
+}
+
+// --- When ---
+
+func (tc *webhookTestContext) execute(args ...string) {
+	tc.t.Helper()
+	cmd := NewWebhookCmd(func() *Client { return tc.client }, tc.buf)
+	cmd.Command.SetArgs(args)
+	cmd.Command.SetErr(tc.buf)
+	tc.err = cmd.Command.Execute()
+}
+
+// --- Then ---
+
+func (tc *webhookTestContext) command_has_no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *webhookTestContext) request_path_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedPath)
+}
+
+func (tc *webhookTestContext) request_query_was(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.receivedQuery)
+}
+
+func (tc *webhookTestContext) request_body_has_field(key string, expected any) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.receivedBody)
+	assert.Equal(tc.t, expected, tc.receivedBody[key])
+}
+
+func (tc *webhookTestContext) output_contains(substr string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.buf.String(), substr)
+}
+
+func (tc *webhookTestContext) output_matches(pattern string) {
+	tc.t.Helper()
+	assert.Regexp(tc.t, pattern, tc.buf.String())
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type actorContextKey struct{}
+
+// WithActor returns a context recording the account performing the request.
+func WithActor(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actorID)
+}
+
+// ActorFromContext returns the account recorded by WithActor.
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actorID, ok := ctx.Value(actorContextKey{}).(string)
+	return actorID, ok && actorID != ""
+}
+
+// EventMetadata is the metadata an ActorEventStore stamps on appended events.
+type EventMetadata struct {
+	ActorID string `json:"actor_id,omitempty"`
+}
+
+// ParseEventMetadata decodes event metadata. Events appended without any
+// decode to the zero value.
+func ParseEventMetadata(raw []byte) EventMetadata {
+	var meta EventMetadata
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &meta)
+	}
+	return meta
+}
+
+type actorEventStore struct {
+	EventStore
+}
+
+// NewActorEventStore wraps store so that events appended without metadata
+// record the actor from the append context.
+func NewActorEventStore(store EventStore) EventStore {
+	return &actorEventStore{EventStore: store}
+}
+
+func (s *actorEventStore) Append(ctx context.Context, realmID string, streamID string, expectedVersion int, events []EventData) ([]Event, error) {
+	actorID, ok := ActorFromContext(ctx)
+	if !ok {
+		return s.EventStore.Append(ctx, realmID, streamID, expectedVersion, events)
+	}
+	stamped := make([]EventData, len(events))
+	for i, event := range events {
+		if event.Metadata == nil {
+			event.Metadata = EventMetadata{ActorID: actorID}
+		}
+		stamped[i] = event
+	}
+	return s.EventStore.Append(ctx, realmID, streamID, expectedVersion, stamped)
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestActorEventStore(t *testing.T) {
+	t.Run("stamps the actor on events without metadata", func(t *testing.T) {
+		tc := newActorTestContext(t)
+
+		// Given
+		tc.an_actor_event_store()
+		tc.context_with_actor("acct-1")
+
+		// When
+		tc.events_are_appended(EventData{EventType: "Something", Data: map[string]string{}})
+
+		// Then
+		tc.appended_metadata_is(0, `{"actor_id":"acct-1"}`)
+	})
+
+	t.Run("keeps metadata set by the caller", func(t *testing.T) {
+		tc := newActorTestContext(t)
+
+		// Given
+		tc.an_actor_event_store()
+		tc.context_with_actor("acct-1")
+
+		// When
+		tc.events_are_appended(EventData{EventType: "Something", Metadata: map[string]string{"source": "import"}})
+
+		// Then
+		tc.appended_metadata_is(0, `{"source":"import"}`)
+	})
+
+	t.Run("leaves events untouched without an actor", func(t *testing.T) {
+		tc := newActorTestContext(t)
+
+		// Given
+		tc.an_actor_event_store()
+
+		// When
+		tc.events_are_appended(EventData{EventType: "Something"})
+
+		// Then
+		tc.appended_metadata_is(0, `null`)
+	})
+
+	t.Run("parses the actor from event metadata", func(t *testing.T) {
+		assert.Equal(t, "acct-1", ParseEventMetadata([]byte(`{"actor_id":"acct-1"}`)).ActorID)
+		assert.Empty(t, ParseEventMetadata(nil).ActorID)
+	})
+}
+
+// --- Test Context ---
+
+type actorTestContext struct {
+	t *testing.T
+
+	inner    *recordingEventStore
+	store    EventStore
+	ctx      context.Context
+	appended []EventData
+}
+
+type recordingEventStore struct {
+	mockEventStore
+	appended []EventData
+}
+
+func (r *recordingEventStore) Append(_ context.Context, _ string, _ string, _ int, events []EventData) ([]Event, error) {
+	r.appended = append(r.appended, events...)
+	return []Event{}, nil
+}
+
+func newActorTestContext(t *testing.T) *actorTestContext {
+	t.Helper()
+	return &actorTestContext{t: t, ctx: context.Background()}
+}
+
+// --- Given ---
+
+func (tc *actorTestContext) an_actor_event_store() {
+	tc.t.Helper()
+	tc.inner = &recordingEventStore{}
+	tc.store = NewActorEventStore(tc.inner)
+}
+
+func (tc *actorTestContext) context_with_actor(actorID string) {
+	tc.t.Helper()
+	tc.ctx = WithActor(tc.ctx, actorID)
+}
+
+// --- When ---
+
+func (tc *actorTestContext) events_are_appended(events ...EventData) {
+	tc.t.Helper()
+	_, err := tc.store.Append(tc.ctx, "_admin", "stream-1", 0, events)
+	require.NoError(tc.t, err)
+	tc.appended = tc.inner.appended
+}
+
+// --- Then ---
+
+func (tc *actorTestContext) appended_metadata_is(index int, expected string) {
+	tc.t.Helper()
+	require.Greater(tc.t, len(tc.appended), index)
+	data, err := json.Marshal(tc.appended[index].Metadata)
+	require.NoError(tc.t, err)
+	assert.JSONEq(tc.t, expected, string(data))
+}
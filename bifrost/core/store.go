@@ -25,3 +25,36 @@ type CheckpointStore interface {
 	GetCheckpoint(ctx context.Context, realmID string, projectorName string) (int64, error)
 	SetCheckpoint(ctx context.Context, realmID string, projectorName string, globalPosition int64) error
 }
+
+// RealmPurger is implemented by stores that can permanently remove everything
+// they hold for a realm.
+type RealmPurger interface {
+	PurgeRealm(ctx context.Context, realmID string) error
+}
+
+// PositionReader is implemented by event stores that can look up a realm's
+// latest global position without reading its events.
+type PositionReader interface {
+	// LatestPosition returns the global position of the realm's most recent
+	// event, or 0 when it has none.
+	LatestPosition(ctx context.Context, realmID string) (int64, error)
+}
+
+// SearchHit is a document matched by a full-text search. Higher ranks are
+// better matches; the snippet is matching text with the query terms wrapped
+// in ** markers.
+type SearchHit struct {
+	Key     string
+	Rank    float64
+	Snippet string
+}
+
+// TextSearcher is implemented by projection stores that can full-text search
+// a table. EnableSearch indexes the named top-level string fields of the
+// table's documents, most important first, and keeps the index current as
+// documents are put and deleted. Search returns the best matches for a
+// realm, all of them when limit is 0.
+type TextSearcher interface {
+	EnableSearch(ctx context.Context, table string, fields ...string) error
+	Search(ctx context.Context, realmID string, table string, query string, limit int) ([]SearchHit, error)
+}
@@ -368,6 +368,162 @@ func TestHandleRemoveACItem(t *testing.T) {
 	})
 }
 
+func TestHandleVerifyACItem(t *testing.T) {
+	t.Run("records verification status, evidence and verifier", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.existing_rune_with_ac_in_stream("bf-a1b2", "AC-01", "happy path", "desc")
+		tc.a_verify_ac_item_command("bf-a1b2", "AC-01", ACStatusVerified)
+
+		// When
+		tc.handle_verify_ac_item()
+
+		// Then
+		tc.no_error()
+		tc.event_was_appended_to_stream("rune-bf-a1b2")
+		tc.appended_event_has_type(EventRuneACVerified)
+		tc.appended_ac_verified_event_is(RuneACVerified{
+			RuneID: "bf-a1b2", ID: "AC-01", Status: ACStatusVerified,
+			Evidence: "TestHappyPath passed", Verifier: "agent-1",
+		})
+	})
+
+	t.Run("rejects unknown status", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.existing_rune_with_ac_in_stream("bf-a1b2", "AC-01", "happy path", "desc")
+		tc.a_verify_ac_item_command("bf-a1b2", "AC-01", "maybe")
+
+		// When
+		tc.handle_verify_ac_item()
+
+		// Then
+		tc.error_contains("invalid AC status")
+	})
+
+	t.Run("returns error when AC ID does not exist", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.a_verify_ac_item_command("bf-a1b2", "AC-99", ACStatusVerified)
+
+		// When
+		tc.handle_verify_ac_item()
+
+		// Then
+		tc.error_contains("AC-99")
+	})
+
+	t.Run("returns error when AC was removed", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.existing_rune_with_ac_added_then_removed_in_stream("bf-a1b2", "AC-01")
+		tc.a_verify_ac_item_command("bf-a1b2", "AC-01", ACStatusVerified)
+
+		// When
+		tc.handle_verify_ac_item()
+
+		// Then
+		tc.error_contains("AC-01")
+	})
+
+	t.Run("returns error when rune does not exist", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.empty_stream("bf-missing")
+		tc.a_verify_ac_item_command("bf-missing", "AC-01", ACStatusVerified)
+
+		// When
+		tc.handle_verify_ac_item()
+
+		// Then
+		tc.error_is_not_found("rune", "bf-missing")
+	})
+
+	t.Run("state-gating: returns error when rune is sealed", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.existing_rune_in_stream("bf-a1b2", "sealed")
+		tc.a_verify_ac_item_command("bf-a1b2", "AC-01", ACStatusVerified)
+
+		// When
+		tc.handle_verify_ac_item()
+
+		// Then
+		tc.error_contains("sealed")
+	})
+}
+
+func TestFulfillRuneACVerificationPolicy(t *testing.T) {
+	t.Run("rejects fulfil when an AC is not verified and the realm requires it", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.realm_requires_ac_verification()
+		tc.claimed_rune_with_acs_in_stream("bf-a1b2", "AC-01", "AC-02")
+		tc.ac_verified_in_stream("bf-a1b2", "AC-01", ACStatusVerified)
+		tc.ac_verified_in_stream("bf-a1b2", "AC-02", ACStatusSkipped)
+
+		// When
+		tc.handle_fulfill_rune("bf-a1b2")
+
+		// Then
+		tc.error_contains("acceptance criteria not verified: AC-02")
+	})
+
+	t.Run("fulfils when every AC is verified", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.realm_requires_ac_verification()
+		tc.claimed_rune_with_acs_in_stream("bf-a1b2", "AC-01")
+		tc.ac_verified_in_stream("bf-a1b2", "AC-01", ACStatusVerified)
+
+		// When
+		tc.handle_fulfill_rune("bf-a1b2")
+
+		// Then
+		tc.no_error()
+		tc.appended_event_has_type(EventRuneFulfilled)
+	})
+
+	t.Run("updating an AC resets it to pending", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.realm_requires_ac_verification()
+		tc.claimed_rune_with_acs_in_stream("bf-a1b2", "AC-01")
+		tc.ac_verified_in_stream("bf-a1b2", "AC-01", ACStatusVerified)
+		tc.ac_updated_in_stream("bf-a1b2", "AC-01")
+
+		// When
+		tc.handle_fulfill_rune("bf-a1b2")
+
+		// Then
+		tc.error_contains("AC-01")
+	})
+
+	t.Run("fulfils with unverified ACs when the policy is off", func(t *testing.T) {
+		tc := newACHandlerTestContext(t)
+
+		// Given
+		tc.a_projection_store()
+		tc.claimed_rune_with_acs_in_stream("bf-a1b2", "AC-01")
+
+		// When
+		tc.handle_fulfill_rune("bf-a1b2")
+
+		// Then
+		tc.no_error()
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Test Context
 // ---------------------------------------------------------------------------
@@ -377,11 +533,13 @@ type acHandlerTestContext struct {
 	ctx     context.Context
 	realmID string
 
-	eventStore *mockEventStore
+	eventStore      *mockEventStore
+	projectionStore *mockProjectionStore
 
 	addACItemCmd    AddACItem
 	updateACItemCmd UpdateACItem
 	removeACItemCmd RemoveACItem
+	verifyACItemCmd VerifyACItem
 
 	err error
 }
@@ -406,6 +564,21 @@ func (tc *acHandlerTestContext) an_event_store() {
 	}
 }
 
+func (tc *acHandlerTestContext) a_projection_store() {
+	tc.t.Helper()
+	if tc.projectionStore == nil {
+		tc.projectionStore = newMockProjectionStore()
+	}
+}
+
+func (tc *acHandlerTestContext) realm_requires_ac_verification() {
+	tc.t.Helper()
+	tc.a_projection_store()
+	settings := DefaultRealmSettings(tc.realmID)
+	settings.RequireACVerification = true
+	tc.projectionStore.data["_admin:realm_settings:"+tc.realmID] = settings
+}
+
 func (tc *acHandlerTestContext) existing_rune_in_stream(runeID, status string) {
 	tc.t.Helper()
 	tc.an_event_store()
@@ -480,6 +653,27 @@ func (tc *acHandlerTestContext) existing_rune_with_ac_updated_in_stream(runeID,
 	tc.eventStore.streams["rune-"+runeID] = events
 }
 
+func (tc *acHandlerTestContext) claimed_rune_with_acs_in_stream(runeID string, acIDs ...string) {
+	tc.t.Helper()
+	tc.existing_rune_in_stream(runeID, "claimed")
+	for _, acID := range acIDs {
+		tc.eventStore.streams["rune-"+runeID] = append(tc.eventStore.streams["rune-"+runeID],
+			makeEvent(EventRuneACAdded, RuneACAdded{RuneID: runeID, ID: acID, Scenario: "scenario", Description: "desc"}))
+	}
+}
+
+func (tc *acHandlerTestContext) ac_verified_in_stream(runeID, acID, status string) {
+	tc.t.Helper()
+	tc.eventStore.streams["rune-"+runeID] = append(tc.eventStore.streams["rune-"+runeID],
+		makeEvent(EventRuneACVerified, RuneACVerified{RuneID: runeID, ID: acID, Status: status}))
+}
+
+func (tc *acHandlerTestContext) ac_updated_in_stream(runeID, acID string) {
+	tc.t.Helper()
+	tc.eventStore.streams["rune-"+runeID] = append(tc.eventStore.streams["rune-"+runeID],
+		makeEvent(EventRuneACUpdated, RuneACUpdated{RuneID: runeID, ID: acID, Scenario: "changed", Description: "changed"}))
+}
+
 func (tc *acHandlerTestContext) an_add_ac_item_command(runeID, scenario, desc string) {
 	tc.t.Helper()
 	tc.addACItemCmd = AddACItem{
@@ -507,23 +701,48 @@ func (tc *acHandlerTestContext) a_remove_ac_item_command(runeID, acID string) {
 	}
 }
 
+func (tc *acHandlerTestContext) a_verify_ac_item_command(runeID, acID, status string) {
+	tc.t.Helper()
+	tc.verifyACItemCmd = VerifyACItem{
+		RuneID:   runeID,
+		ID:       acID,
+		Status:   status,
+		Evidence: "TestHappyPath passed",
+		Verifier: "agent-1",
+	}
+}
+
 // ---------------------------------------------------------------------------
 // When
 // ---------------------------------------------------------------------------
 
 func (tc *acHandlerTestContext) handle_add_ac_item() {
 	tc.t.Helper()
-	tc.err = HandleAddACItem(tc.ctx, tc.realmID, tc.addACItemCmd, tc.eventStore)
+	tc.a_projection_store()
+	tc.err = HandleAddACItem(tc.ctx, tc.realmID, tc.addACItemCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *acHandlerTestContext) handle_update_ac_item() {
 	tc.t.Helper()
-	tc.err = HandleUpdateACItem(tc.ctx, tc.realmID, tc.updateACItemCmd, tc.eventStore)
+	tc.a_projection_store()
+	tc.err = HandleUpdateACItem(tc.ctx, tc.realmID, tc.updateACItemCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *acHandlerTestContext) handle_remove_ac_item() {
 	tc.t.Helper()
-	tc.err = HandleRemoveACItem(tc.ctx, tc.realmID, tc.removeACItemCmd, tc.eventStore)
+	tc.a_projection_store()
+	tc.err = HandleRemoveACItem(tc.ctx, tc.realmID, tc.removeACItemCmd, tc.eventStore, tc.projectionStore)
+}
+
+func (tc *acHandlerTestContext) handle_verify_ac_item() {
+	tc.t.Helper()
+	tc.a_projection_store()
+	tc.err = HandleVerifyACItem(tc.ctx, tc.realmID, tc.verifyACItemCmd, tc.eventStore, tc.projectionStore)
+}
+
+func (tc *acHandlerTestContext) handle_fulfill_rune(runeID string) {
+	tc.t.Helper()
+	tc.err = HandleFulfillRune(tc.ctx, tc.realmID, FulfillRune{ID: runeID}, tc.eventStore, tc.projectionStore)
 }
 
 // ---------------------------------------------------------------------------
@@ -625,3 +844,19 @@ func (tc *acHandlerTestContext) appended_ac_added_event_has_description(expected
 	}
 	tc.t.Fatalf("no %s event found in last Append call", EventRuneACAdded)
 }
+
+func (tc *acHandlerTestContext) appended_ac_verified_event_is(expected RuneACVerified) {
+	tc.t.Helper()
+	require.NotEmpty(tc.t, tc.eventStore.appendedCalls, "expected at least one Append call")
+	lastCall := tc.eventStore.appendedCalls[len(tc.eventStore.appendedCalls)-1]
+	for _, evt := range lastCall.events {
+		if evt.EventType == EventRuneACVerified {
+			dataBytes, _ := json.Marshal(evt.Data)
+			var data RuneACVerified
+			require.NoError(tc.t, json.Unmarshal(dataBytes, &data))
+			assert.Equal(tc.t, expected, data)
+			return
+		}
+	}
+	tc.t.Fatalf("no %s event found in last Append call", EventRuneACVerified)
+}
@@ -1,14 +1,43 @@
 package domain
 
+import "time"
+
 type CreateAccount struct {
 	Username string `json:"username"`
 }
 
+type CreateServiceAccount struct {
+	Username string `json:"username"`
+	OwnerID  string `json:"owner_id"`
+	RealmID  string `json:"realm_id"`
+	Role     string `json:"role,omitempty"`
+}
+
 type SuspendAccount struct {
 	AccountID string `json:"account_id"`
 	Reason    string `json:"reason"`
 }
 
+type ReactivateAccount struct {
+	AccountID string `json:"account_id"`
+}
+
+type RenameAccount struct {
+	AccountID string `json:"account_id"`
+	Username  string `json:"username"`
+}
+
+type DeleteAccount struct {
+	AccountID string `json:"account_id"`
+}
+
+// LinkSSOIdentity lets an identity provider's subject sign in as the account.
+type LinkSSOIdentity struct {
+	AccountID string `json:"account_id"`
+	Issuer    string `json:"issuer"`
+	Subject   string `json:"subject"`
+}
+
 type GrantRealm struct {
 	AccountID string `json:"account_id"`
 	RealmID   string `json:"realm_id"`
@@ -31,8 +60,10 @@ type RevokeRole struct {
 }
 
 type CreatePAT struct {
-	AccountID string `json:"account_id"`
-	Label     string `json:"label"`
+	AccountID string     `json:"account_id"`
+	Label     string     `json:"label"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Scopes    *PATScopes `json:"scopes,omitempty"`
 }
 
 type RevokePAT struct {
@@ -42,6 +73,7 @@ type RevokePAT struct {
 
 type CreateAccountResult struct {
 	AccountID string `json:"account_id"`
+	PATID     string `json:"pat_id"`
 	RawToken  string `json:"raw_token"`
 }
 
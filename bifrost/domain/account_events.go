@@ -3,20 +3,44 @@ package domain
 import "time"
 
 const (
-	EventAccountCreated   = "AccountCreated"
-	EventAccountSuspended = "AccountSuspended"
-	EventRealmGranted     = "RealmGranted"
-	EventRealmRevoked     = "RealmRevoked"
-	EventPATCreated       = "PATCreated"
-	EventPATRevoked       = "PATRevoked"
-	EventRoleAssigned     = "RoleAssigned"
-	EventRoleRevoked      = "RoleRevoked"
+	EventAccountCreated     = "AccountCreated"
+	EventAccountSuspended   = "AccountSuspended"
+	EventAccountReactivated = "AccountReactivated"
+	EventAccountRenamed     = "AccountRenamed"
+	EventAccountDeleted     = "AccountDeleted"
+	EventRealmGranted       = "RealmGranted"
+	EventRealmRevoked       = "RealmRevoked"
+	EventPATCreated         = "PATCreated"
+	EventPATRevoked         = "PATRevoked"
+	EventRoleAssigned       = "RoleAssigned"
+	EventRoleRevoked        = "RoleRevoked"
+	EventSSOIdentityLinked  = "SSOIdentityLinked"
 )
 
+// Account kinds. Accounts created before kinds existed have an empty kind
+// and are human.
+const (
+	AccountKindHuman   = "human"
+	AccountKindService = "service"
+)
+
+// AccountCreated starts an account stream. A service account is owned by a
+// human account and restricted to a single realm.
 type AccountCreated struct {
 	AccountID string    `json:"account_id"`
 	Username  string    `json:"username"`
 	CreatedAt time.Time `json:"created_at"`
+	Kind      string    `json:"kind,omitempty"`
+	OwnerID   string    `json:"owner_id,omitempty"`
+	RealmID   string    `json:"realm_id,omitempty"`
+}
+
+// AccountKind returns the kind of the created account, defaulting to human.
+func (e AccountCreated) AccountKind() string {
+	if e.Kind == "" {
+		return AccountKindHuman
+	}
+	return e.Kind
 }
 
 type AccountSuspended struct {
@@ -24,6 +48,22 @@ type AccountSuspended struct {
 	Reason    string `json:"reason"`
 }
 
+type AccountReactivated struct {
+	AccountID string `json:"account_id"`
+}
+
+type AccountRenamed struct {
+	AccountID   string `json:"account_id"`
+	OldUsername string `json:"old_username"`
+	Username    string `json:"username"`
+}
+
+type AccountDeleted struct {
+	AccountID     string        `json:"account_id"`
+	Username      string        `json:"username"`
+	SSOIdentities []SSOIdentity `json:"sso_identities,omitempty"`
+}
+
 type RealmGranted struct {
 	AccountID string `json:"account_id"`
 	RealmID   string `json:"realm_id"`
@@ -35,11 +75,13 @@ type RealmRevoked struct {
 }
 
 type PATCreated struct {
-	AccountID string    `json:"account_id"`
-	PATID     string    `json:"pat_id"`
-	KeyHash   string    `json:"key_hash"`
-	Label     string    `json:"label"`
-	CreatedAt time.Time `json:"created_at"`
+	AccountID string     `json:"account_id"`
+	PATID     string     `json:"pat_id"`
+	KeyHash   string     `json:"key_hash"`
+	Label     string     `json:"label"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Scopes    *PATScopes `json:"scopes,omitempty"`
 }
 
 type PATRevoked struct {
@@ -57,3 +99,17 @@ type RoleRevoked struct {
 	AccountID string `json:"account_id"`
 	RealmID   string `json:"realm_id"`
 }
+
+// SSOIdentity is a single sign-on identity: the provider's issuer and its
+// stable subject for the user.
+type SSOIdentity struct {
+	Issuer  string `json:"issuer"`
+	Subject string `json:"subject"`
+}
+
+// SSOIdentityLinked records that an identity signs in as the account.
+type SSOIdentityLinked struct {
+	AccountID string `json:"account_id"`
+	Issuer    string `json:"issuer"`
+	Subject   string `json:"subject"`
+}
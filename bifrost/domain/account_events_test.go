@@ -289,12 +289,16 @@ func (tc *acctEvtTestContext) account_event_type_constants_are_correct() {
 	tc.t.Helper()
 	assert.Equal(tc.t, "AccountCreated", EventAccountCreated)
 	assert.Equal(tc.t, "AccountSuspended", EventAccountSuspended)
+	assert.Equal(tc.t, "AccountReactivated", EventAccountReactivated)
+	assert.Equal(tc.t, "AccountRenamed", EventAccountRenamed)
+	assert.Equal(tc.t, "AccountDeleted", EventAccountDeleted)
 	assert.Equal(tc.t, "RealmGranted", EventRealmGranted)
 	assert.Equal(tc.t, "RealmRevoked", EventRealmRevoked)
 	assert.Equal(tc.t, "PATCreated", EventPATCreated)
 	assert.Equal(tc.t, "PATRevoked", EventPATRevoked)
 	assert.Equal(tc.t, "RoleAssigned", EventRoleAssigned)
 	assert.Equal(tc.t, "RoleRevoked", EventRoleRevoked)
+	assert.Equal(tc.t, "SSOIdentityLinked", EventSSOIdentityLinked)
 }
 
 func (tc *acctEvtTestContext) account_created_fields_match() {
@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/devzeebo/bifrost/core"
@@ -22,16 +24,23 @@ type AccountState struct {
 	AccountID string
 	Username  string
 	Status    string
-	Exists    bool
-	Realms    map[string]string
-	PATs      map[string]PATState
+	Kind      string
+	OwnerID   string
+	// ServiceRealmID is the only realm a service account may be granted.
+	ServiceRealmID string
+	Exists         bool
+	Realms         map[string]string
+	PATs           map[string]PATState
+	SSOIdentities  []SSOIdentity
 }
 
 type PATState struct {
-	PATID   string
-	KeyHash string
-	Label   string
-	Revoked bool
+	PATID     string
+	KeyHash   string
+	Label     string
+	ExpiresAt *time.Time
+	Scopes    *PATScopes
+	Revoked   bool
 }
 
 func RebuildAccountState(events []core.Event) AccountState {
@@ -48,8 +57,20 @@ func RebuildAccountState(events []core.Event) AccountState {
 			state.AccountID = data.AccountID
 			state.Username = data.Username
 			state.Status = "active"
+			state.Kind = data.AccountKind()
+			state.OwnerID = data.OwnerID
+			state.ServiceRealmID = data.RealmID
 		case EventAccountSuspended:
 			state.Status = "suspended"
+		case EventAccountReactivated:
+			state.Status = "active"
+		case EventAccountRenamed:
+			var data AccountRenamed
+			_ = json.Unmarshal(evt.Data, &data)
+			state.Username = data.Username
+		case EventAccountDeleted:
+			state.Exists = false
+			state.Status = "deleted"
 		case EventRealmGranted:
 			var data RealmGranted
 			_ = json.Unmarshal(evt.Data, &data)
@@ -70,10 +91,12 @@ func RebuildAccountState(events []core.Event) AccountState {
 			var data PATCreated
 			_ = json.Unmarshal(evt.Data, &data)
 			state.PATs[data.PATID] = PATState{
-				PATID:   data.PATID,
-				KeyHash: data.KeyHash,
-				Label:   data.Label,
-				Revoked: false,
+				PATID:     data.PATID,
+				KeyHash:   data.KeyHash,
+				Label:     data.Label,
+				ExpiresAt: data.ExpiresAt,
+				Scopes:    data.Scopes,
+				Revoked:   false,
 			}
 		case EventPATRevoked:
 			var data PATRevoked
@@ -82,6 +105,10 @@ func RebuildAccountState(events []core.Event) AccountState {
 				pat.Revoked = true
 				state.PATs[data.PATID] = pat
 			}
+		case EventSSOIdentityLinked:
+			var data SSOIdentityLinked
+			_ = json.Unmarshal(evt.Data, &data)
+			state.SSOIdentities = append(state.SSOIdentities, SSOIdentity{Issuer: data.Issuer, Subject: data.Subject})
 		}
 	}
 	return state
@@ -156,18 +183,33 @@ func requireRealmExists(ctx context.Context, realmID string, projectionStore cor
 	return nil
 }
 
-func HandleCreateAccount(ctx context.Context, cmd CreateAccount, store core.EventStore, projectionStore core.ProjectionStore) (CreateAccountResult, error) {
-	// Check username uniqueness via projection
+// requireRealmAllowed rejects realms outside a service account's realm.
+func requireRealmAllowed(state AccountState, realmID string) error {
+	if state.Kind == AccountKindService && realmID != state.ServiceRealmID {
+		return fmt.Errorf("service account %q is restricted to realm %q", state.Username, state.ServiceRealmID)
+	}
+	return nil
+}
+
+// requireUsernameAvailable checks username uniqueness via the username_lookup projection.
+func requireUsernameAvailable(ctx context.Context, username string, projectionStore core.ProjectionStore) error {
 	type usernameEntry struct {
 		AccountID string `json:"account_id"`
 	}
 	var existing usernameEntry
-	err := projectionStore.Get(ctx, AdminRealmID, "username_lookup", cmd.Username, &existing)
+	err := projectionStore.Get(ctx, AdminRealmID, "username_lookup", username, &existing)
 	if err == nil {
-		return CreateAccountResult{}, fmt.Errorf("username %q already exists", cmd.Username)
+		return fmt.Errorf("username %q already exists", username)
 	}
 	var nfe *core.NotFoundError
 	if !errors.As(err, &nfe) {
+		return err
+	}
+	return nil
+}
+
+func HandleCreateAccount(ctx context.Context, cmd CreateAccount, store core.EventStore, projectionStore core.ProjectionStore) (CreateAccountResult, error) {
+	if err := requireUsernameAvailable(ctx, cmd.Username, projectionStore); err != nil {
 		return CreateAccountResult{}, err
 	}
 
@@ -211,6 +253,88 @@ func HandleCreateAccount(ctx context.Context, cmd CreateAccount, store core.Even
 
 	return CreateAccountResult{
 		AccountID: accountID,
+		PATID:     patID,
+		RawToken:  rawToken,
+	}, nil
+}
+
+// HandleCreateServiceAccount creates an account for an agent or orchestrator.
+// It is owned by an active human account, holds a role in exactly one realm,
+// and its initial PAT is scoped to that realm.
+func HandleCreateServiceAccount(ctx context.Context, cmd CreateServiceAccount, store core.EventStore, projectionStore core.ProjectionStore) (CreateAccountResult, error) {
+	cmd.Username = strings.TrimSpace(cmd.Username)
+	if cmd.Username == "" {
+		return CreateAccountResult{}, fmt.Errorf("invalid username: must not be empty")
+	}
+	if cmd.Role == "" {
+		cmd.Role = RoleMember
+	}
+	if !IsValidRole(cmd.Role) || cmd.Role == RoleOwner {
+		return CreateAccountResult{}, fmt.Errorf("invalid role %q for a service account", cmd.Role)
+	}
+	if cmd.RealmID == "" || cmd.RealmID == AdminRealmID {
+		return CreateAccountResult{}, fmt.Errorf("invalid realm_id: a service account needs a non-admin realm")
+	}
+	if err := requireRealmExists(ctx, cmd.RealmID, projectionStore); err != nil {
+		return CreateAccountResult{}, err
+	}
+
+	owner, _, err := readAndRebuildAccountState(ctx, cmd.OwnerID, store)
+	if err != nil {
+		return CreateAccountResult{}, err
+	}
+	if err := requireActiveAccount(owner, cmd.OwnerID); err != nil {
+		return CreateAccountResult{}, err
+	}
+	if owner.Kind == AccountKindService {
+		return CreateAccountResult{}, fmt.Errorf("account %q is a service account and cannot own one", cmd.OwnerID)
+	}
+
+	if err := requireUsernameAvailable(ctx, cmd.Username, projectionStore); err != nil {
+		return CreateAccountResult{}, err
+	}
+
+	accountID, err := generateAccountID()
+	if err != nil {
+		return CreateAccountResult{}, err
+	}
+	rawToken, keyHash, err := generateToken()
+	if err != nil {
+		return CreateAccountResult{}, err
+	}
+	patID, err := generatePATID()
+	if err != nil {
+		return CreateAccountResult{}, err
+	}
+
+	now := time.Now().UTC()
+	streamID := accountStreamID(accountID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, 0, []core.EventData{
+		{EventType: EventAccountCreated, Data: AccountCreated{
+			AccountID: accountID,
+			Username:  cmd.Username,
+			CreatedAt: now,
+			Kind:      AccountKindService,
+			OwnerID:   cmd.OwnerID,
+			RealmID:   cmd.RealmID,
+		}},
+		{EventType: EventRoleAssigned, Data: RoleAssigned{AccountID: accountID, RealmID: cmd.RealmID, Role: cmd.Role}},
+		{EventType: EventPATCreated, Data: PATCreated{
+			AccountID: accountID,
+			PATID:     patID,
+			KeyHash:   keyHash,
+			Label:     "initial",
+			CreatedAt: now,
+			Scopes:    &PATScopes{Realms: []string{cmd.RealmID}},
+		}},
+	})
+	if err != nil {
+		return CreateAccountResult{}, err
+	}
+
+	return CreateAccountResult{
+		AccountID: accountID,
+		PATID:     patID,
 		RawToken:  rawToken,
 	}, nil
 }
@@ -233,6 +357,144 @@ func HandleSuspendAccount(ctx context.Context, cmd SuspendAccount, store core.Ev
 	return err
 }
 
+func HandleReactivateAccount(ctx context.Context, cmd ReactivateAccount, store core.EventStore) error {
+	state, events, err := readAndRebuildAccountState(ctx, cmd.AccountID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "account", ID: cmd.AccountID}
+	}
+	if state.Status != "suspended" {
+		return fmt.Errorf("account %q is not suspended", cmd.AccountID)
+	}
+
+	reactivated := AccountReactivated(cmd)
+
+	streamID := accountStreamID(cmd.AccountID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventAccountReactivated, Data: reactivated},
+	})
+	return err
+}
+
+func HandleRenameAccount(ctx context.Context, cmd RenameAccount, store core.EventStore, projectionStore core.ProjectionStore) error {
+	username := strings.TrimSpace(cmd.Username)
+	if username == "" {
+		return fmt.Errorf("invalid username: must not be empty")
+	}
+
+	state, events, err := readAndRebuildAccountState(ctx, cmd.AccountID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "account", ID: cmd.AccountID}
+	}
+	if state.Username == username {
+		return nil
+	}
+	if err := requireUsernameAvailable(ctx, username, projectionStore); err != nil {
+		return err
+	}
+
+	renamed := AccountRenamed{
+		AccountID:   cmd.AccountID,
+		OldUsername: state.Username,
+		Username:    username,
+	}
+
+	streamID := accountStreamID(cmd.AccountID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventAccountRenamed, Data: renamed},
+	})
+	return err
+}
+
+// HandleDeleteAccount revokes the account's remaining PATs and removes the
+// account. Its username becomes available again.
+func HandleDeleteAccount(ctx context.Context, cmd DeleteAccount, store core.EventStore) error {
+	state, events, err := readAndRebuildAccountState(ctx, cmd.AccountID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "account", ID: cmd.AccountID}
+	}
+
+	patIDs := make([]string, 0, len(state.PATs))
+	for patID, pat := range state.PATs {
+		if !pat.Revoked {
+			patIDs = append(patIDs, patID)
+		}
+	}
+	slices.Sort(patIDs)
+
+	toAppend := make([]core.EventData, 0, len(patIDs)+1)
+	for _, patID := range patIDs {
+		toAppend = append(toAppend, core.EventData{
+			EventType: EventPATRevoked,
+			Data:      PATRevoked{AccountID: cmd.AccountID, PATID: patID},
+		})
+	}
+	toAppend = append(toAppend, core.EventData{
+		EventType: EventAccountDeleted,
+		Data:      AccountDeleted{AccountID: cmd.AccountID, Username: state.Username, SSOIdentities: state.SSOIdentities},
+	})
+
+	streamID := accountStreamID(cmd.AccountID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), toAppend)
+	return err
+}
+
+// HandleLinkSSOIdentity links an identity to the account. An account holds
+// at most one identity per issuer, and an identity signs in as one account.
+func HandleLinkSSOIdentity(ctx context.Context, cmd LinkSSOIdentity, store core.EventStore, projectionStore core.ProjectionStore) error {
+	if cmd.Issuer == "" || cmd.Subject == "" {
+		return fmt.Errorf("invalid SSO identity: issuer and subject are required")
+	}
+	state, events, err := readAndRebuildAccountState(ctx, cmd.AccountID, store)
+	if err != nil {
+		return err
+	}
+	if err := requireActiveAccount(state, cmd.AccountID); err != nil {
+		return err
+	}
+	for _, identity := range state.SSOIdentities {
+		if identity.Issuer != cmd.Issuer {
+			continue
+		}
+		if identity.Subject == cmd.Subject {
+			return nil
+		}
+		return fmt.Errorf("account %q is already linked to another identity at %q", cmd.AccountID, cmd.Issuer)
+	}
+
+	var existing struct {
+		AccountID string `json:"account_id"`
+	}
+	err = projectionStore.Get(ctx, AdminRealmID, "sso_identity", SSOIdentityKey(cmd.Issuer, cmd.Subject), &existing)
+	if err == nil {
+		return fmt.Errorf("invalid SSO identity: already linked to account %q", existing.AccountID)
+	}
+	if !isNotFoundError(err) {
+		return err
+	}
+
+	linked := SSOIdentityLinked(cmd)
+
+	streamID := accountStreamID(cmd.AccountID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventSSOIdentityLinked, Data: linked},
+	})
+	return err
+}
+
+// SSOIdentityKey is the sso_identity projection key of an identity.
+func SSOIdentityKey(issuer, subject string) string {
+	return issuer + "#" + subject
+}
+
 func HandleGrantRealm(ctx context.Context, cmd GrantRealm, store core.EventStore, projectionStore core.ProjectionStore) error {
 	state, events, err := readAndRebuildAccountState(ctx, cmd.AccountID, store)
 	if err != nil {
@@ -241,6 +503,9 @@ func HandleGrantRealm(ctx context.Context, cmd GrantRealm, store core.EventStore
 	if err := requireActiveAccount(state, cmd.AccountID); err != nil {
 		return err
 	}
+	if err := requireRealmAllowed(state, cmd.RealmID); err != nil {
+		return err
+	}
 
 	// Validate realm exists (skip for _admin realm)
 	if cmd.RealmID != AdminRealmID {
@@ -286,8 +551,8 @@ func HandleRevokeRealm(ctx context.Context, cmd RevokeRealm, store core.EventSto
 }
 
 func HandleAssignRole(ctx context.Context, cmd AssignRole, store core.EventStore, projectionStore core.ProjectionStore) error {
-	if !IsValidRole(cmd.Role) {
-		return fmt.Errorf("invalid role %q", cmd.Role)
+	if err := requireAssignableRole(ctx, cmd.RealmID, cmd.Role, projectionStore); err != nil {
+		return err
 	}
 
 	state, events, err := readAndRebuildAccountState(ctx, cmd.AccountID, store)
@@ -297,6 +562,9 @@ func HandleAssignRole(ctx context.Context, cmd AssignRole, store core.EventStore
 	if err := requireActiveAccount(state, cmd.AccountID); err != nil {
 		return err
 	}
+	if err := requireRealmAllowed(state, cmd.RealmID); err != nil {
+		return err
+	}
 
 	// Validate realm exists (skip for _admin realm)
 	if cmd.RealmID != AdminRealmID {
@@ -342,6 +610,20 @@ func HandleRevokeRole(ctx context.Context, cmd RevokeRole, store core.EventStore
 }
 
 func HandleCreatePAT(ctx context.Context, cmd CreatePAT, store core.EventStore) (CreatePATResult, error) {
+	if cmd.ExpiresAt != nil {
+		if !cmd.ExpiresAt.After(time.Now()) {
+			return CreatePATResult{}, fmt.Errorf("invalid expires_at: must be in the future")
+		}
+		expiresAt := cmd.ExpiresAt.UTC()
+		cmd.ExpiresAt = &expiresAt
+	}
+	cmd.Scopes = cmd.Scopes.normalized()
+	if cmd.Scopes != nil {
+		if err := cmd.Scopes.validate(); err != nil {
+			return CreatePATResult{}, err
+		}
+	}
+
 	state, events, err := readAndRebuildAccountState(ctx, cmd.AccountID, store)
 	if err != nil {
 		return CreatePATResult{}, err
@@ -366,6 +648,8 @@ func HandleCreatePAT(ctx context.Context, cmd CreatePAT, store core.EventStore)
 		KeyHash:   keyHash,
 		Label:     cmd.Label,
 		CreatedAt: time.Now().UTC(),
+		ExpiresAt: cmd.ExpiresAt,
+		Scopes:    cmd.Scopes,
 	}
 
 	streamID := accountStreamID(cmd.AccountID)
@@ -407,3 +691,8 @@ func HandleRevokePAT(ctx context.Context, cmd RevokePAT, store core.EventStore)
 	})
 	return err
 }
+
+// IsExpired reports whether a PAT with the given expiry is no longer valid at now.
+func IsExpired(expiresAt *time.Time, now time.Time) bool {
+	return expiresAt != nil && !now.Before(*expiresAt)
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/devzeebo/bifrost/core"
 	"github.com/stretchr/testify/assert"
@@ -746,6 +747,515 @@ func TestHandleRevokePAT(t *testing.T) {
 	})
 }
 
+func TestHandleReactivateAccount(t *testing.T) {
+	t.Run("reactivates a suspended account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "suspended")
+
+		// When
+		tc.handle_reactivate_account("acct-a1b2")
+
+		// Then
+		tc.no_account_error()
+		tc.appended_account_event_has_type(EventAccountReactivated)
+		tc.rebuilt_account_has_status("acct-a1b2", "active")
+	})
+
+	t.Run("returns error when account is not suspended", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+
+		// When
+		tc.handle_reactivate_account("acct-a1b2")
+
+		// Then
+		tc.account_error_contains("is not suspended")
+		tc.no_events_were_appended()
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.empty_account_stream("acct-missing")
+
+		// When
+		tc.handle_reactivate_account("acct-missing")
+
+		// Then
+		tc.account_error_is_not_found("account", "acct-missing")
+	})
+}
+
+func TestHandleRenameAccount(t *testing.T) {
+	t.Run("renames an account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.username_is_available("bob")
+
+		// When
+		tc.handle_rename_account("acct-a1b2", " bob ")
+
+		// Then
+		tc.no_account_error()
+		tc.appended_account_event_has_type(EventAccountRenamed)
+		tc.rebuilt_account_has_username("acct-a1b2", "bob")
+	})
+
+	t.Run("returns error when the username is taken", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.username_is_taken("bob")
+
+		// When
+		tc.handle_rename_account("acct-a1b2", "bob")
+
+		// Then
+		tc.account_error_contains(`username "bob" already exists`)
+		tc.no_events_were_appended()
+	})
+
+	t.Run("does nothing when the username is unchanged", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.username_is_taken("alice")
+
+		// When
+		tc.handle_rename_account("acct-a1b2", "alice")
+
+		// Then
+		tc.no_account_error()
+		tc.no_events_were_appended()
+	})
+
+	t.Run("returns error for an empty username", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.a_store()
+
+		// When
+		tc.handle_rename_account("acct-a1b2", "  ")
+
+		// Then
+		tc.account_error_contains("invalid username")
+	})
+}
+
+func TestHandleDeleteAccount(t *testing.T) {
+	t.Run("revokes remaining PATs and deletes the account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_with_pat("acct-a1b2", "pat-0001")
+
+		// When
+		tc.handle_delete_account("acct-a1b2")
+
+		// Then
+		tc.no_account_error()
+		tc.appended_account_event_types_are(EventPATRevoked, EventAccountDeleted)
+		tc.rebuilt_account_does_not_exist("acct-a1b2")
+	})
+
+	t.Run("records the account's SSO identities on deletion", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_with_sso_identity("acct-a1b2", "https://idp.example.com", "sub-1")
+
+		// When
+		tc.handle_delete_account("acct-a1b2")
+
+		// Then
+		tc.no_account_error()
+		tc.appended_account_event_data_is(0, AccountDeleted{
+			AccountID:     "acct-a1b2",
+			Username:      "alice",
+			SSOIdentities: []SSOIdentity{{Issuer: "https://idp.example.com", Subject: "sub-1"}},
+		})
+	})
+
+	t.Run("deletes a suspended account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "suspended")
+
+		// When
+		tc.handle_delete_account("acct-a1b2")
+
+		// Then
+		tc.no_account_error()
+		tc.appended_account_event_types_are(EventAccountDeleted)
+	})
+
+	t.Run("returns not found for a deleted account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "deleted")
+
+		// When
+		tc.handle_delete_account("acct-a1b2")
+
+		// Then
+		tc.account_error_is_not_found("account", "acct-a1b2")
+	})
+}
+
+func TestHandleLinkSSOIdentity(t *testing.T) {
+	t.Run("links an identity to the account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.a_store()
+
+		// When
+		tc.handle_link_sso_identity("acct-a1b2", "https://idp.example.com", "sub-1")
+
+		// Then
+		tc.no_account_error()
+		tc.appended_account_event_has_type(EventSSOIdentityLinked)
+		tc.rebuilt_account_has_sso_identities("acct-a1b2", SSOIdentity{Issuer: "https://idp.example.com", Subject: "sub-1"})
+	})
+
+	t.Run("does nothing when the identity is already linked to the account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_with_sso_identity("acct-a1b2", "https://idp.example.com", "sub-1")
+		tc.a_store()
+
+		// When
+		tc.handle_link_sso_identity("acct-a1b2", "https://idp.example.com", "sub-1")
+
+		// Then
+		tc.no_account_error()
+		tc.no_events_were_appended()
+	})
+
+	t.Run("returns error when the account is linked to another subject at the issuer", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_with_sso_identity("acct-a1b2", "https://idp.example.com", "sub-1")
+		tc.a_store()
+
+		// When
+		tc.handle_link_sso_identity("acct-a1b2", "https://idp.example.com", "sub-2")
+
+		// Then
+		tc.account_error_contains("already linked to another identity")
+		tc.no_events_were_appended()
+	})
+
+	t.Run("returns error when the identity is linked to another account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.sso_identity_is_linked_to("https://idp.example.com", "sub-1", "acct-other")
+
+		// When
+		tc.handle_link_sso_identity("acct-a1b2", "https://idp.example.com", "sub-1")
+
+		// Then
+		tc.account_error_contains(`already linked to account "acct-other"`)
+		tc.no_events_were_appended()
+	})
+
+	t.Run("returns error for a suspended account", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "suspended")
+		tc.a_store()
+
+		// When
+		tc.handle_link_sso_identity("acct-a1b2", "https://idp.example.com", "sub-1")
+
+		// Then
+		tc.account_error_contains("suspended")
+		tc.no_events_were_appended()
+	})
+}
+
+func TestHandleCreatePAT_Expiry(t *testing.T) {
+	t.Run("records the expiry on the PAT", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		expiresAt := time.Now().Add(30 * 24 * time.Hour)
+		tc.createPATCmd = CreatePAT{AccountID: "acct-a1b2", Label: "CI", ExpiresAt: &expiresAt}
+
+		// When
+		tc.handle_create_pat()
+
+		// Then
+		tc.no_account_error()
+		tc.appended_pat_created_event_expires_at(expiresAt)
+	})
+
+	t.Run("rejects an expiry in the past", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		expiresAt := time.Now().Add(-time.Hour)
+		tc.createPATCmd = CreatePAT{AccountID: "acct-a1b2", Label: "CI", ExpiresAt: &expiresAt}
+
+		// When
+		tc.handle_create_pat()
+
+		// Then
+		tc.account_error_contains("invalid expires_at")
+	})
+}
+
+func TestHandleCreatePAT_Scopes(t *testing.T) {
+	t.Run("records normalized scopes on the PAT", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.createPATCmd = CreatePAT{AccountID: "acct-a1b2", Label: "CI", Scopes: &PATScopes{
+			Realms:   []string{" realm-1 ", "realm-1"},
+			MaxRole:  "Viewer",
+			Commands: []string{"Runes", "rune"},
+		}}
+
+		// When
+		tc.handle_create_pat()
+
+		// Then
+		tc.no_account_error()
+		tc.appended_pat_created_event_scopes_are(&PATScopes{
+			Realms:   []string{"realm-1"},
+			MaxRole:  RoleViewer,
+			Commands: []string{"runes", "rune"},
+		})
+	})
+
+	t.Run("drops empty scopes", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.createPATCmd = CreatePAT{AccountID: "acct-a1b2", Label: "CI", Scopes: &PATScopes{}}
+
+		// When
+		tc.handle_create_pat()
+
+		// Then
+		tc.no_account_error()
+		tc.appended_pat_created_event_scopes_are(nil)
+	})
+
+	t.Run("rejects an unknown max role", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.createPATCmd = CreatePAT{AccountID: "acct-a1b2", Label: "CI", Scopes: &PATScopes{MaxRole: "superuser"}}
+
+		// When
+		tc.handle_create_pat()
+
+		// Then
+		tc.account_error_contains(`invalid scopes: unknown max_role "superuser"`)
+	})
+
+	t.Run("rejects a blank realm", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-a1b2", "active")
+		tc.createPATCmd = CreatePAT{AccountID: "acct-a1b2", Label: "CI", Scopes: &PATScopes{Realms: []string{" "}}}
+
+		// When
+		tc.handle_create_pat()
+
+		// Then
+		tc.account_error_contains("invalid scopes: realm must not be empty")
+	})
+}
+
+func TestHandleCreateServiceAccount(t *testing.T) {
+	t.Run("creates a service account with a realm-scoped PAT", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-owner", "active")
+		tc.realm_exists_in_directory("bf-c3d4", "Test Realm")
+		tc.a_create_service_account_command("ci-bot", "acct-owner", "bf-c3d4", "")
+
+		// When
+		tc.handle_create_service_account()
+
+		// Then
+		tc.no_account_error()
+		tc.create_account_result_has_raw_token()
+		tc.appended_account_event_types_are(EventAccountCreated, EventRoleAssigned, EventPATCreated)
+		tc.appended_account_created_event_is_service_account("acct-owner", "bf-c3d4")
+		tc.appended_service_account_role_is(RoleMember)
+		tc.appended_service_account_pat_is_scoped_to("bf-c3d4")
+	})
+
+	t.Run("rejects the owner role", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-owner", "active")
+		tc.realm_exists_in_directory("bf-c3d4", "Test Realm")
+		tc.a_create_service_account_command("ci-bot", "acct-owner", "bf-c3d4", RoleOwner)
+
+		// When
+		tc.handle_create_service_account()
+
+		// Then
+		tc.account_error_contains(`invalid role "owner" for a service account`)
+		tc.no_events_were_appended()
+	})
+
+	t.Run("rejects the admin realm", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-owner", "active")
+		tc.a_create_service_account_command("ci-bot", "acct-owner", AdminRealmID, "")
+
+		// When
+		tc.handle_create_service_account()
+
+		// Then
+		tc.account_error_contains("invalid realm_id")
+		tc.no_events_were_appended()
+	})
+
+	t.Run("rejects a suspended owner", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-owner", "suspended")
+		tc.realm_exists_in_directory("bf-c3d4", "Test Realm")
+		tc.a_create_service_account_command("ci-bot", "acct-owner", "bf-c3d4", "")
+
+		// When
+		tc.handle_create_service_account()
+
+		// Then
+		tc.account_error_contains("suspended")
+		tc.no_events_were_appended()
+	})
+
+	t.Run("rejects a service account as owner", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_service_account_in_stream("acct-bot", "bf-c3d4")
+		tc.realm_exists_in_directory("bf-c3d4", "Test Realm")
+		tc.a_create_service_account_command("ci-bot-2", "acct-bot", "bf-c3d4", "")
+
+		// When
+		tc.handle_create_service_account()
+
+		// Then
+		tc.account_error_contains("is a service account and cannot own one")
+		tc.no_events_were_appended()
+	})
+
+	t.Run("returns error when username is taken", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_account_in_stream("acct-owner", "active")
+		tc.realm_exists_in_directory("bf-c3d4", "Test Realm")
+		tc.username_is_taken("ci-bot")
+		tc.a_create_service_account_command("ci-bot", "acct-owner", "bf-c3d4", "")
+
+		// When
+		tc.handle_create_service_account()
+
+		// Then
+		tc.account_error_contains(`username "ci-bot" already exists`)
+	})
+}
+
+func TestServiceAccountRealmRestriction(t *testing.T) {
+	t.Run("rejects granting another realm", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_service_account_in_stream("acct-bot", "bf-c3d4")
+		tc.realm_exists_in_directory("bf-other", "Other Realm")
+		tc.a_grant_realm_command("acct-bot", "bf-other")
+
+		// When
+		tc.handle_grant_realm()
+
+		// Then
+		tc.account_error_contains(`service account "ci-bot" is restricted to realm "bf-c3d4"`)
+		tc.no_events_were_appended()
+	})
+
+	t.Run("allows changing the role in its own realm", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_service_account_in_stream("acct-bot", "bf-c3d4")
+		tc.realm_exists_in_directory("bf-c3d4", "Test Realm")
+		tc.an_assign_role_command("acct-bot", "bf-c3d4", RoleViewer)
+
+		// When
+		tc.handle_assign_role()
+
+		// Then
+		tc.no_account_error()
+		tc.appended_role_assigned_event_has_role(RoleViewer)
+	})
+
+	t.Run("rejects assigning a role in another realm", func(t *testing.T) {
+		tc := newAccountHandlerTestContext(t)
+
+		// Given
+		tc.existing_service_account_in_stream("acct-bot", "bf-c3d4")
+		tc.realm_exists_in_directory("bf-other", "Other Realm")
+		tc.an_assign_role_command("acct-bot", "bf-other", RoleMember)
+
+		// When
+		tc.handle_assign_role()
+
+		// Then
+		tc.account_error_contains("is restricted to realm")
+		tc.no_events_were_appended()
+	})
+}
+
+func TestIsExpired(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Second)
+	future := now.Add(time.Second)
+
+	assert.False(t, IsExpired(nil, now))
+	assert.False(t, IsExpired(&future, now))
+	assert.True(t, IsExpired(&now, now))
+	assert.True(t, IsExpired(&past, now))
+}
+
 // --- Test Context ---
 
 type accountHandlerTestContext struct {
@@ -764,6 +1274,8 @@ type accountHandlerTestContext struct {
 	assignRoleCmd     AssignRole
 	revokeRoleCmd     RevokeRole
 
+	createServiceAccountCmd CreateServiceAccount
+
 	createAccountResult CreateAccountResult
 	createPATResult     CreatePATResult
 	accountState        AccountState
@@ -940,9 +1452,27 @@ func (tc *accountHandlerTestContext) existing_account_in_stream(accountID string
 			AccountID: accountID, Reason: "suspended",
 		}))
 	}
+	if status == "deleted" {
+		events = append(events, makeEvent(EventAccountDeleted, AccountDeleted{
+			AccountID: accountID, Username: "alice",
+		}))
+	}
 	tc.eventStore.streams["account-"+accountID] = events
 }
 
+func (tc *accountHandlerTestContext) existing_service_account_in_stream(accountID, realmID string) {
+	tc.t.Helper()
+	tc.an_event_store()
+	tc.eventStore.streams["account-"+accountID] = []core.Event{
+		makeEvent(EventAccountCreated, AccountCreated{
+			AccountID: accountID, Username: "ci-bot", Kind: AccountKindService, OwnerID: "acct-owner", RealmID: realmID,
+		}),
+		makeEvent(EventRoleAssigned, RoleAssigned{
+			AccountID: accountID, RealmID: realmID, Role: RoleMember,
+		}),
+	}
+}
+
 func (tc *accountHandlerTestContext) existing_account_with_realm_granted(accountID string, realmID string) {
 	tc.t.Helper()
 	tc.an_event_store()
@@ -997,6 +1527,21 @@ func (tc *accountHandlerTestContext) username_is_available(username string) {
 	// No entry means username is available (Get returns NotFoundError)
 }
 
+func (tc *accountHandlerTestContext) existing_account_with_sso_identity(accountID, issuer, subject string) {
+	tc.t.Helper()
+	tc.existing_account_in_stream(accountID, "active")
+	streamID := "account-" + accountID
+	tc.eventStore.streams[streamID] = append(tc.eventStore.streams[streamID], makeEvent(EventSSOIdentityLinked, SSOIdentityLinked{
+		AccountID: accountID, Issuer: issuer, Subject: subject,
+	}))
+}
+
+func (tc *accountHandlerTestContext) sso_identity_is_linked_to(issuer, subject, accountID string) {
+	tc.t.Helper()
+	tc.a_store()
+	tc.projectionStore.data["_admin:sso_identity:"+SSOIdentityKey(issuer, subject)] = map[string]any{"issuer": issuer, "subject": subject, "account_id": accountID}
+}
+
 func (tc *accountHandlerTestContext) username_is_taken(username string) {
 	tc.t.Helper()
 	tc.a_store()
@@ -1008,6 +1553,11 @@ func (tc *accountHandlerTestContext) a_create_account_command(username string) {
 	tc.createAccountCmd = CreateAccount{Username: username}
 }
 
+func (tc *accountHandlerTestContext) a_create_service_account_command(username, ownerID, realmID, role string) {
+	tc.t.Helper()
+	tc.createServiceAccountCmd = CreateServiceAccount{Username: username, OwnerID: ownerID, RealmID: realmID, Role: role}
+}
+
 func (tc *accountHandlerTestContext) a_suspend_account_command(accountID, reason string) {
 	tc.t.Helper()
 	tc.suspendAccountCmd = SuspendAccount{AccountID: accountID, Reason: reason}
@@ -1078,6 +1628,11 @@ func (tc *accountHandlerTestContext) handle_create_account() {
 	tc.createAccountResult, tc.err = HandleCreateAccount(tc.ctx, tc.createAccountCmd, tc.eventStore, tc.projectionStore)
 }
 
+func (tc *accountHandlerTestContext) handle_create_service_account() {
+	tc.t.Helper()
+	tc.createAccountResult, tc.err = HandleCreateServiceAccount(tc.ctx, tc.createServiceAccountCmd, tc.eventStore, tc.projectionStore)
+}
+
 func (tc *accountHandlerTestContext) handle_suspend_account() {
 	tc.t.Helper()
 	tc.err = HandleSuspendAccount(tc.ctx, tc.suspendAccountCmd, tc.eventStore)
@@ -1115,8 +1670,116 @@ func (tc *accountHandlerTestContext) handle_revoke_role() {
 	tc.err = HandleRevokeRole(tc.ctx, tc.revokeRoleCmd, tc.eventStore)
 }
 
+func (tc *accountHandlerTestContext) handle_reactivate_account(accountID string) {
+	tc.t.Helper()
+	tc.err = HandleReactivateAccount(tc.ctx, ReactivateAccount{AccountID: accountID}, tc.eventStore)
+}
+
+func (tc *accountHandlerTestContext) handle_rename_account(accountID, username string) {
+	tc.t.Helper()
+	tc.err = HandleRenameAccount(tc.ctx, RenameAccount{AccountID: accountID, Username: username}, tc.eventStore, tc.projectionStore)
+}
+
+func (tc *accountHandlerTestContext) handle_link_sso_identity(accountID, issuer, subject string) {
+	tc.t.Helper()
+	tc.err = HandleLinkSSOIdentity(tc.ctx, LinkSSOIdentity{AccountID: accountID, Issuer: issuer, Subject: subject}, tc.eventStore, tc.projectionStore)
+}
+
+func (tc *accountHandlerTestContext) handle_delete_account(accountID string) {
+	tc.t.Helper()
+	tc.err = HandleDeleteAccount(tc.ctx, DeleteAccount{AccountID: accountID}, tc.eventStore)
+}
+
 // --- Then ---
 
+// rebuilt_account rebuilds the account from its stream plus the events appended by the handler.
+func (tc *accountHandlerTestContext) rebuilt_account(accountID string) AccountState {
+	tc.t.Helper()
+	events := append([]core.Event{}, tc.eventStore.streams["account-"+accountID]...)
+	for _, call := range tc.eventStore.appendedCalls {
+		if call.streamID != "account-"+accountID {
+			continue
+		}
+		for _, evt := range call.events {
+			events = append(events, makeEvent(evt.EventType, evt.Data))
+		}
+	}
+	return RebuildAccountState(events)
+}
+
+func (tc *accountHandlerTestContext) rebuilt_account_has_status(accountID, expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.rebuilt_account(accountID).Status)
+}
+
+func (tc *accountHandlerTestContext) rebuilt_account_has_username(accountID, expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.rebuilt_account(accountID).Username)
+}
+
+func (tc *accountHandlerTestContext) rebuilt_account_has_sso_identities(accountID string, expected ...SSOIdentity) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.rebuilt_account(accountID).SSOIdentities)
+}
+
+func (tc *accountHandlerTestContext) rebuilt_account_does_not_exist(accountID string) {
+	tc.t.Helper()
+	assert.False(tc.t, tc.rebuilt_account(accountID).Exists)
+}
+
+func (tc *accountHandlerTestContext) appended_account_event_types_are(expected ...string) {
+	tc.t.Helper()
+	require.Len(tc.t, tc.eventStore.appendedCalls, 1)
+	actual := make([]string, 0, len(tc.eventStore.appendedCalls[0].events))
+	for _, evt := range tc.eventStore.appendedCalls[0].events {
+		actual = append(actual, evt.EventType)
+	}
+	assert.Equal(tc.t, expected, actual)
+}
+
+func (tc *accountHandlerTestContext) appended_pat_created_event_expires_at(expected time.Time) {
+	tc.t.Helper()
+	require.Len(tc.t, tc.eventStore.appendedCalls, 1)
+	patEvt, ok := tc.eventStore.appendedCalls[0].events[0].Data.(PATCreated)
+	require.True(tc.t, ok, "expected PATCreated data")
+	require.NotNil(tc.t, patEvt.ExpiresAt)
+	assert.True(tc.t, expected.Equal(*patEvt.ExpiresAt))
+}
+
+func (tc *accountHandlerTestContext) appended_pat_created_event_scopes_are(expected *PATScopes) {
+	tc.t.Helper()
+	require.Len(tc.t, tc.eventStore.appendedCalls, 1)
+	patEvt, ok := tc.eventStore.appendedCalls[0].events[0].Data.(PATCreated)
+	require.True(tc.t, ok, "expected PATCreated data")
+	assert.Equal(tc.t, expected, patEvt.Scopes)
+}
+
+func (tc *accountHandlerTestContext) appended_account_created_event_is_service_account(ownerID, realmID string) {
+	tc.t.Helper()
+	require.Len(tc.t, tc.eventStore.appendedCalls, 1)
+	created, ok := tc.eventStore.appendedCalls[0].events[0].Data.(AccountCreated)
+	require.True(tc.t, ok, "expected AccountCreated data")
+	assert.Equal(tc.t, AccountKindService, created.Kind)
+	assert.Equal(tc.t, ownerID, created.OwnerID)
+	assert.Equal(tc.t, realmID, created.RealmID)
+}
+
+func (tc *accountHandlerTestContext) appended_service_account_role_is(expected string) {
+	tc.t.Helper()
+	require.Len(tc.t, tc.eventStore.appendedCalls, 1)
+	assigned, ok := tc.eventStore.appendedCalls[0].events[1].Data.(RoleAssigned)
+	require.True(tc.t, ok, "expected RoleAssigned data")
+	assert.Equal(tc.t, expected, assigned.Role)
+}
+
+func (tc *accountHandlerTestContext) appended_service_account_pat_is_scoped_to(realmID string) {
+	tc.t.Helper()
+	require.Len(tc.t, tc.eventStore.appendedCalls, 1)
+	patEvt, ok := tc.eventStore.appendedCalls[0].events[2].Data.(PATCreated)
+	require.True(tc.t, ok, "expected PATCreated data")
+	assert.Equal(tc.t, &PATScopes{Realms: []string{realmID}}, patEvt.Scopes)
+}
+
 func (tc *accountHandlerTestContext) no_account_error() {
 	tc.t.Helper()
 	assert.NoError(tc.t, tc.err)
@@ -1263,6 +1926,7 @@ func (tc *accountHandlerTestContext) pat_created_event_has_hashed_key() {
 	expectedHash := sha256.Sum256(rawTokenBytes)
 	expectedHashStr := base64.RawURLEncoding.EncodeToString(expectedHash[:])
 	assert.Equal(tc.t, expectedHashStr, patEvt.KeyHash)
+	assert.Equal(tc.t, patEvt.PATID, tc.createAccountResult.PATID)
 }
 
 func (tc *accountHandlerTestContext) account_event_was_appended_to_stream(streamID string) {
@@ -1278,6 +1942,14 @@ func (tc *accountHandlerTestContext) account_event_was_appended_to_stream(stream
 	assert.True(tc.t, found, "expected Append to stream %q", streamID)
 }
 
+func (tc *accountHandlerTestContext) appended_account_event_data_is(index int, expected any) {
+	tc.t.Helper()
+	require.Len(tc.t, tc.eventStore.appendedCalls, 1)
+	events := tc.eventStore.appendedCalls[0].events
+	require.Greater(tc.t, len(events), index)
+	assert.Equal(tc.t, expected, events[index].Data)
+}
+
 func (tc *accountHandlerTestContext) appended_account_event_has_type(eventType string) {
 	tc.t.Helper()
 	require.NotEmpty(tc.t, tc.eventStore.appendedCalls, "expected at least one Append call")
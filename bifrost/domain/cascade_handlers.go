@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// Cascade operations supported by HandleCascadeRune.
+const (
+	CascadeSeal         = "seal"
+	CascadeFail         = "fail"
+	CascadeReopen       = "reopen"
+	CascadeReprioritize = "reprioritize"
+)
+
+// Per-rune outcomes reported in a CascadeResult.
+const (
+	CascadeApplied    = "applied"
+	CascadeWouldApply = "would_apply"
+	CascadeSkipped    = "skipped"
+	CascadeFailed     = "failed"
+)
+
+// CascadeResult reports what a cascading operation did to a single rune.
+type CascadeResult struct {
+	RuneID string `json:"rune_id"`
+	Result string `json:"result"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// HandleCascadeRune applies cmd.Operation to cmd.ID and every descendant,
+// parents before children. Ineligible runes are skipped and per-rune errors
+// are reported rather than aborting the walk. With DryRun set nothing is
+// written and eligible runes are reported as would_apply. Realm policies are
+// evaluated for every rune in the subtree; a rejection of the root aborts the
+// cascade, while a rejected descendant is reported as failed.
+func HandleCascadeRune(ctx context.Context, realmID string, cmd CascadeRune, store core.EventStore, projStore core.ProjectionStore) ([]CascadeResult, error) {
+	switch cmd.Operation {
+	case CascadeSeal, CascadeReopen:
+	case CascadeFail:
+		if cmd.Reason == "" {
+			return nil, fmt.Errorf("cannot cascade fail without a reason")
+		}
+	case CascadeReprioritize:
+		if cmd.Priority == nil {
+			return nil, fmt.Errorf("cannot cascade reprioritize without a priority")
+		}
+	default:
+		return nil, fmt.Errorf("unknown cascade operation %q", cmd.Operation)
+	}
+
+	root, _, err := readAndRebuild(ctx, realmID, cmd.ID, store)
+	if err != nil {
+		return nil, err
+	}
+	if !root.Exists {
+		return nil, &core.NotFoundError{Entity: "rune", ID: cmd.ID}
+	}
+	action, rootCmd := cascadeCommand(cmd, cmd.ID)
+	if err := enforcePolicies(ctx, realmID, action, cmd.ID, rootCmd, store, projStore); err != nil {
+		return nil, err
+	}
+
+	runeIDs, err := collectDescendants(ctx, realmID, cmd.ID, projStore)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CascadeResult, 0, len(runeIDs))
+	for _, runeID := range runeIDs {
+		results = append(results, cascadeOne(ctx, realmID, runeID, cmd, store, projStore))
+	}
+	return results, nil
+}
+
+func cascadeOne(ctx context.Context, realmID, runeID string, cmd CascadeRune, store core.EventStore, projStore core.ProjectionStore) CascadeResult {
+	state, _, err := readAndRebuild(ctx, realmID, runeID, store)
+	if err != nil {
+		return CascadeResult{RuneID: runeID, Result: CascadeFailed, Reason: err.Error()}
+	}
+	if !state.Exists {
+		return CascadeResult{RuneID: runeID, Result: CascadeSkipped, Reason: "rune does not exist"}
+	}
+	if reason := cascadeIneligibility(cmd.Operation, state.Status); reason != "" {
+		return CascadeResult{RuneID: runeID, Result: CascadeSkipped, Reason: reason}
+	}
+	action, runeCmd := cascadeCommand(cmd, runeID)
+	if cmd.DryRun {
+		if err := enforcePolicies(ctx, realmID, action, runeID, runeCmd, store, projStore); err != nil {
+			return CascadeResult{RuneID: runeID, Result: CascadeFailed, Reason: err.Error()}
+		}
+		return CascadeResult{RuneID: runeID, Result: CascadeWouldApply}
+	}
+
+	switch c := runeCmd.(type) {
+	case SealRune:
+		err = HandleSealRune(ctx, realmID, c, store, projStore)
+	case FailRune:
+		err = HandleFailRune(ctx, realmID, c, store, projStore)
+	case ReopenRune:
+		err = HandleReopenRune(ctx, realmID, c, store, projStore)
+	case UpdateRune:
+		err = HandleUpdateRune(ctx, realmID, c, store, projStore)
+	}
+	if err != nil {
+		return CascadeResult{RuneID: runeID, Result: CascadeFailed, Reason: err.Error()}
+	}
+	return CascadeResult{RuneID: runeID, Result: CascadeApplied}
+}
+
+// cascadeCommand returns the policy action and single-rune command that
+// cmd.Operation applies to runeID.
+func cascadeCommand(cmd CascadeRune, runeID string) (string, any) {
+	switch cmd.Operation {
+	case CascadeSeal:
+		return "seal", SealRune{ID: runeID, Reason: cmd.Reason}
+	case CascadeFail:
+		return "fail", FailRune{ID: runeID, Reason: cmd.Reason}
+	case CascadeReopen:
+		return "reopen", ReopenRune{ID: runeID, AsClaimed: cmd.AsClaimed}
+	default:
+		return "update", UpdateRune{ID: runeID, Priority: cmd.Priority}
+	}
+}
+
+// cascadeIneligibility returns why a rune in status cannot take part in the
+// operation, or "" when it is eligible.
+func cascadeIneligibility(operation, status string) string {
+	switch operation {
+	case CascadeSeal:
+		switch status {
+		case "fulfilled", "sealed", "shattered":
+			return "rune is " + status
+		}
+	case CascadeFail:
+		switch status {
+		case "fulfilled", "sealed", "failed", "shattered":
+			return "rune is " + status
+		}
+	case CascadeReopen:
+		if status != "failed" {
+			return "rune is not failed"
+		}
+	case CascadeReprioritize:
+		switch status {
+		case "sealed", "shattered":
+			return "rune is " + status
+		}
+	}
+	return ""
+}
+
+// collectDescendants returns runeID followed by its descendants in
+// breadth-first order, using saga_progress for child membership.
+func collectDescendants(ctx context.Context, realmID, runeID string, projStore core.ProjectionStore) ([]string, error) {
+	ordered := []string{runeID}
+	seen := map[string]bool{runeID: true}
+	for i := 0; i < len(ordered); i++ {
+		childMap, err := readSagaChildren(ctx, realmID, ordered[i], projStore)
+		if err != nil {
+			return nil, err
+		}
+		children := make([]string, 0, len(childMap))
+		for id := range childMap {
+			if !seen[id] {
+				children = append(children, id)
+			}
+		}
+		// Sibling IDs share a prefix, so ordering by length first keeps
+		// "x.2" ahead of "x.10".
+		slices.SortFunc(children, func(a, b string) int {
+			return cmp.Or(cmp.Compare(len(a), len(b)), cmp.Compare(a, b))
+		})
+		for _, id := range children {
+			seen[id] = true
+			ordered = append(ordered, id)
+		}
+	}
+	return ordered, nil
+}
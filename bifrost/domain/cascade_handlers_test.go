@@ -0,0 +1,269 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestHandleCascadeRune(t *testing.T) {
+	t.Run("seals the rune and every eligible descendant", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.a_cascade_command("bf-p", CascadeSeal)
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.no_error()
+		tc.cascade_results_are(
+			CascadeResult{RuneID: "bf-p", Result: CascadeApplied},
+			CascadeResult{RuneID: "bf-p.1", Result: CascadeSkipped, Reason: "rune is fulfilled"},
+			CascadeResult{RuneID: "bf-p.2", Result: CascadeApplied},
+			CascadeResult{RuneID: "bf-p.2.1", Result: CascadeApplied},
+		)
+		tc.events_appended_to_stream("rune-bf-p.2.1", EventRuneSealed)
+	})
+
+	t.Run("dry run reports eligible runes without appending", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.a_cascade_command("bf-p", CascadeSeal)
+		tc.cascadeCmd.DryRun = true
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.no_error()
+		tc.cascade_result_for("bf-p.2", CascadeWouldApply)
+		tc.cascade_result_for("bf-p.1", CascadeSkipped)
+		assert.Empty(t, tc.eventStore.appendedCalls)
+	})
+
+	t.Run("reprioritize updates priority on the whole subtree", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.a_cascade_command("bf-p", CascadeReprioritize)
+		tc.cascadeCmd.Priority = intPtr(0)
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.no_error()
+		tc.cascade_result_for("bf-p.1", CascadeApplied)
+		tc.cascade_result_for("bf-p.2.1", CascadeApplied)
+		tc.events_appended_to_stream("rune-bf-p.2.1", EventRuneUpdated)
+	})
+
+	t.Run("reopen only touches failed descendants", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_failed_rune_in_stream("bf-p", "alice")
+		tc.existing_failed_rune_in_stream("bf-p.1", "bob")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "open")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2")
+		tc.a_cascade_command("bf-p", CascadeReopen)
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.no_error()
+		tc.cascade_result_for("bf-p", CascadeApplied)
+		tc.cascade_result_for("bf-p.1", CascadeApplied)
+		tc.cascade_result_for("bf-p.2", CascadeSkipped)
+	})
+
+	t.Run("reports per-rune failures and keeps going", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.eventStore.appendErr = errors.New("disk full")
+		tc.a_cascade_command("bf-p", CascadeFail)
+		tc.cascadeCmd.Reason = "cancelled"
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.no_error()
+		tc.cascade_result_for("bf-p", CascadeFailed)
+		tc.cascade_result_for("bf-p.2.1", CascadeFailed)
+	})
+
+	t.Run("rejects fail without a reason", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.a_cascade_command("bf-p", CascadeFail)
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.error_contains("cannot cascade fail without a reason")
+	})
+
+	t.Run("rejects unknown operation", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.a_cascade_command("bf-p", "explode")
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.error_contains("unknown cascade operation")
+	})
+
+	t.Run("returns not found for missing root", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.empty_stream("bf-nope")
+		tc.a_cascade_command("bf-nope", CascadeSeal)
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.error_is_not_found("rune", "bf-nope")
+	})
+	t.Run("reports descendants rejected by a policy as failed", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.realm_policy_with_message("no-sealing-claimed", `rune.status != "claimed"`, "claimed runes stay open", "seal")
+		tc.a_cascade_command("bf-p", CascadeSeal)
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.no_error()
+		tc.cascade_result_for("bf-p", CascadeApplied)
+		tc.cascade_result_for("bf-p.2", CascadeApplied)
+		tc.cascade_result_for("bf-p.2.1", CascadeFailed)
+		tc.no_events_appended_to_stream("rune-bf-p.2.1")
+	})
+
+	t.Run("dry run reports descendants a policy would reject", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.realm_policy("no-sealing-claimed", `rune.status != "claimed"`, "seal")
+		tc.a_cascade_command("bf-p", CascadeSeal)
+		tc.cascadeCmd.DryRun = true
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.no_error()
+		tc.cascade_result_for("bf-p.2", CascadeWouldApply)
+		tc.cascade_result_for("bf-p.2.1", CascadeFailed)
+	})
+
+	t.Run("aborts when a policy rejects the root", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.a_saga_tree()
+		tc.realm_policy("keep-root", `rune.id != "bf-p"`, "seal")
+		tc.a_cascade_command("bf-p", CascadeSeal)
+
+		// When
+		tc.handle_cascade_rune()
+
+		// Then
+		tc.policy_violation_is("keep-root", `rune.id != "bf-p"`)
+		assert.Empty(t, tc.eventStore.appendedCalls)
+	})
+}
+
+// --- Given ---
+
+// a_saga_tree builds bf-p (open) with children bf-p.1 (fulfilled) and
+// bf-p.2 (open), and grandchild bf-p.2.1 (claimed).
+func (tc *handlerTestContext) a_saga_tree() {
+	tc.t.Helper()
+	tc.existing_rune_in_stream("bf-p", "open")
+	tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "fulfilled")
+	tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "open")
+	tc.existing_child_rune_in_stream("bf-p.2.1", "bf-p.2", "claimed")
+	tc.saga_progress_with_children("bf-p", "bf-p.2", "bf-p.1")
+	tc.saga_progress_with_children("bf-p.2", "bf-p.2.1")
+}
+
+func (tc *handlerTestContext) a_cascade_command(id, operation string) {
+	tc.t.Helper()
+	tc.cascadeCmd = CascadeRune{ID: id, Operation: operation}
+}
+
+// --- When ---
+
+func (tc *handlerTestContext) handle_cascade_rune() {
+	tc.t.Helper()
+	tc.a_store()
+	tc.cascadeResults, tc.err = HandleCascadeRune(tc.ctx, tc.realmID, tc.cascadeCmd, tc.eventStore, tc.projectionStore)
+}
+
+// --- Then ---
+
+func (tc *handlerTestContext) cascade_results_are(expected ...CascadeResult) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.cascadeResults)
+}
+
+func (tc *handlerTestContext) cascade_result_for(runeID, expected string) {
+	tc.t.Helper()
+	for _, r := range tc.cascadeResults {
+		if r.RuneID == runeID {
+			assert.Equal(tc.t, expected, r.Result, "result for %s", runeID)
+			return
+		}
+	}
+	require.Failf(tc.t, "missing cascade result", "no result for %s", runeID)
+}
@@ -1,13 +1,17 @@
 package domain
 
+import "time"
+
 type CreateRune struct {
 	Title       string  `json:"title"`
 	Description string  `json:"description,omitempty"`
-	Priority    int     `json:"priority"`
+	Priority    *int    `json:"priority,omitempty"`
 	ParentID    string  `json:"parent_id,omitempty"`
 	Branch      *string `json:"branch,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
 	Type        string  `json:"type,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	DeferUntil  *time.Time `json:"defer_until,omitempty"`
 }
 
 type UpdateRune struct {
@@ -19,11 +23,17 @@ type UpdateRune struct {
 	Tags        *[]string `json:"tags,omitempty"`
 	AddTags     []string `json:"add_tags,omitempty"`
 	RemoveTags  []string `json:"remove_tags,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	DeferUntil  *time.Time `json:"defer_until,omitempty"`
+	ClearDueAt      bool `json:"clear_due_at,omitempty"`
+	ClearDeferUntil bool `json:"clear_defer_until,omitempty"`
 }
 
 type ClaimRune struct {
-	ID       string `json:"id"`
-	Claimant string `json:"claimant"`
+	ID        string `json:"id"`
+	Claimant  string `json:"claimant"`
+	AccountID string `json:"account_id,omitempty"`
+	Agent     bool   `json:"agent,omitempty"`
 }
 
 type UnclaimRune struct {
@@ -57,6 +67,11 @@ type ShatterRune struct {
 	ID string `json:"id"`
 }
 
+type MoveRune struct {
+	ID       string `json:"id"`
+	ParentID string `json:"parent_id,omitempty"`
+}
+
 type AddDependency struct {
 	RuneID       string `json:"rune_id"`
 	TargetID     string `json:"target_id"`
@@ -97,6 +112,14 @@ type RemoveACItem struct {
 	ID     string `json:"id"`
 }
 
+type VerifyACItem struct {
+	RuneID   string `json:"rune_id"`
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Evidence string `json:"evidence,omitempty"`
+	Verifier string `json:"verifier,omitempty"`
+}
+
 type UpdateRuneState struct {
 	RuneID   string `json:"rune_id"`
 	Patch    string `json:"patch"`
@@ -106,3 +129,12 @@ type UpdateRuneState struct {
 type ClearRuneState struct {
 	RuneID string `json:"rune_id"`
 }
+
+type CascadeRune struct {
+	ID        string `json:"id"`
+	Operation string `json:"operation"`
+	Reason    string `json:"reason,omitempty"`
+	Priority  *int   `json:"priority,omitempty"`
+	AsClaimed bool   `json:"as_claimed,omitempty"`
+	DryRun    bool   `json:"dry_run,omitempty"`
+}
@@ -37,6 +37,7 @@ func TestCreateRuneCommand(t *testing.T) {
 
 		// Then
 		tc.cmd_json_omits_key("description")
+		tc.cmd_json_omits_key("priority")
 		tc.cmd_json_omits_key("parent_id")
 		tc.cmd_json_omits_key("branch")
 		tc.cmd_json_omits_key("tags")
@@ -254,7 +255,7 @@ func (tc *cmdTestContext) create_rune_command() {
 	tc.createRune = CreateRune{
 		Title:       "Fix the bridge",
 		Description: "The rainbow bridge needs repair",
-		Priority:    1,
+		Priority:    intPtr(1),
 		ParentID:    "epic-1",
 		Branch:      &branch,
 		Tags:        []string{"backend", "p1"},
@@ -264,8 +265,7 @@ func (tc *cmdTestContext) create_rune_command() {
 func (tc *cmdTestContext) create_rune_command_without_optional_fields() {
 	tc.t.Helper()
 	tc.createRune = CreateRune{
-		Title:    "Fix the bridge",
-		Priority: 1,
+		Title: "Fix the bridge",
 	}
 }
 
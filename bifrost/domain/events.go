@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 const (
 	EventRuneCreated        = "RuneCreated"
 	EventRuneUpdated        = "RuneUpdated"
@@ -18,7 +20,13 @@ const (
 	EventRuneACAdded        = "RuneACAdded"
 	EventRuneACUpdated      = "RuneACUpdated"
 	EventRuneACRemoved      = "RuneACRemoved"
+	EventRuneACVerified     = "RuneACVerified"
 	EventRuneStateUpdated   = "RuneStateUpdated"
+	EventRuneMoved          = "RuneMoved"
+	EventRuneAliasReserved  = "RuneAliasReserved"
+	EventRuneAliasReleased  = "RuneAliasReleased"
+	EventWIPClaimReserved   = "WIPClaimReserved"
+	EventWIPClaimReleased   = "WIPClaimReleased"
 )
 
 const (
@@ -43,6 +51,8 @@ type RuneCreated struct {
 	Branch      string `json:"branch,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
 	Type        string `json:"type,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	DeferUntil  *time.Time `json:"defer_until,omitempty"`
 }
 
 type RuneForged struct {
@@ -58,11 +68,17 @@ type RuneUpdated struct {
 	Tags        *[]string `json:"tags,omitempty"`
 	AddTags     []string `json:"add_tags,omitempty"`
 	RemoveTags  []string `json:"remove_tags,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	DeferUntil  *time.Time `json:"defer_until,omitempty"`
+	ClearDueAt      bool `json:"clear_due_at,omitempty"`
+	ClearDeferUntil bool `json:"clear_defer_until,omitempty"`
 }
 
 type RuneClaimed struct {
-	ID       string `json:"id"`
-	Claimant string `json:"claimant"`
+	ID        string `json:"id"`
+	Claimant  string `json:"claimant"`
+	AccountID string `json:"account_id,omitempty"`
+	Agent     bool   `json:"agent,omitempty"`
 }
 
 type RuneFulfilled struct {
@@ -80,8 +96,9 @@ type RuneFailed struct {
 }
 
 type RuneReopened struct {
-	ID       string `json:"id"`
-	Claimant string `json:"claimant,omitempty"`
+	ID        string `json:"id"`
+	Claimant  string `json:"claimant,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
 }
 
 type DependencyAdded struct {
@@ -169,7 +186,62 @@ type RuneACRemoved struct {
 	ID     string `json:"id"`
 }
 
+// RuneACVerified records the outcome of checking one acceptance criterion.
+type RuneACVerified struct {
+	RuneID   string `json:"rune_id"`
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Evidence string `json:"evidence,omitempty"`
+	Verifier string `json:"verifier,omitempty"`
+}
+
 type RuneStateUpdated struct {
 	RuneID string                 `json:"rune_id"`
 	Patch  map[string]interface{} `json:"patch"`
 }
+
+// RuneMoved records a change of parent. Alias is the rune's new hierarchical
+// ID under NewParentID; it is empty when the new position matches the one
+// encoded in the original ID. Status is the rune's status at the time of the
+// move so projections can place it under the new parent.
+type RuneMoved struct {
+	ID          string `json:"id"`
+	OldParentID string `json:"old_parent_id,omitempty"`
+	NewParentID string `json:"new_parent_id,omitempty"`
+	Alias       string `json:"alias,omitempty"`
+	Status      string `json:"status"`
+}
+
+// RuneAliasReserved is the first event in an alias stream. It claims the
+// alias so that a later child created under the same parent cannot reuse the
+// ID.
+type RuneAliasReserved struct {
+	Alias  string `json:"alias"`
+	RuneID string `json:"rune_id"`
+}
+
+// RuneAliasReleased follows RuneAliasReserved when the move that reserved the
+// alias was never recorded. The alias stays burned but no longer refers to
+// RuneID.
+type RuneAliasReleased struct {
+	Alias  string `json:"alias"`
+	RuneID string `json:"rune_id"`
+}
+
+// WIPClaimReserved is appended to a realm's WIP stream before a claim is
+// recorded on the rune. Reservations are appended at the stream's expected
+// version, so concurrent claims are counted against each other's limits.
+type WIPClaimReserved struct {
+	RuneID    string   `json:"rune_id"`
+	Claimant  string   `json:"claimant"`
+	AccountID string   `json:"account_id,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// WIPClaimReleased follows WIPClaimReserved when the claim that reserved the
+// slot was never recorded on the rune.
+type WIPClaimReleased struct {
+	RuneID    string `json:"rune_id"`
+	Claimant  string `json:"claimant"`
+	AccountID string `json:"account_id,omitempty"`
+}
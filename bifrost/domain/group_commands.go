@@ -0,0 +1,30 @@
+package domain
+
+type CreateGroup struct {
+	Name string `json:"name"`
+}
+
+type DeleteGroup struct {
+	GroupID string `json:"group_id"`
+}
+
+type AddGroupMember struct {
+	GroupID   string `json:"group_id"`
+	AccountID string `json:"account_id"`
+}
+
+type RemoveGroupMember struct {
+	GroupID   string `json:"group_id"`
+	AccountID string `json:"account_id"`
+}
+
+type AssignGroupRole struct {
+	GroupID string `json:"group_id"`
+	RealmID string `json:"realm_id"`
+	Role    string `json:"role"`
+}
+
+type RevokeGroupRole struct {
+	GroupID string `json:"group_id"`
+	RealmID string `json:"realm_id"`
+}
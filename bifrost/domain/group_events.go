@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const (
+	EventGroupCreated       = "GroupCreated"
+	EventGroupDeleted       = "GroupDeleted"
+	EventGroupMemberAdded   = "GroupMemberAdded"
+	EventGroupMemberRemoved = "GroupMemberRemoved"
+	EventGroupRoleAssigned  = "GroupRoleAssigned"
+	EventGroupRoleRevoked   = "GroupRoleRevoked"
+)
+
+type GroupCreated struct {
+	GroupID   string    `json:"group_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GroupDeleted removes a group. Its members lose the roles granted through it.
+type GroupDeleted struct {
+	GroupID string `json:"group_id"`
+	Name    string `json:"name"`
+}
+
+type GroupMemberAdded struct {
+	GroupID   string `json:"group_id"`
+	AccountID string `json:"account_id"`
+}
+
+type GroupMemberRemoved struct {
+	GroupID   string `json:"group_id"`
+	AccountID string `json:"account_id"`
+}
+
+// GroupRoleAssigned grants a role in a realm to every member of a group.
+type GroupRoleAssigned struct {
+	GroupID string `json:"group_id"`
+	RealmID string `json:"realm_id"`
+	Role    string `json:"role"`
+}
+
+type GroupRoleRevoked struct {
+	GroupID string `json:"group_id"`
+	RealmID string `json:"realm_id"`
+}
@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+const groupStreamPrefix = "group-"
+
+// GroupState is a group rebuilt from its stream. Roles maps realm ID to the
+// role the group grants its members in that realm.
+type GroupState struct {
+	GroupID string
+	Name    string
+	Exists  bool
+	Members map[string]bool
+	Roles   map[string]string
+}
+
+type CreateGroupResult struct {
+	GroupID string
+}
+
+func rebuildGroupState(events []core.Event) GroupState {
+	state := GroupState{
+		Members: make(map[string]bool),
+		Roles:   make(map[string]string),
+	}
+	for _, evt := range events {
+		switch evt.EventType {
+		case EventGroupCreated:
+			var data GroupCreated
+			_ = json.Unmarshal(evt.Data, &data)
+			state.Exists = true
+			state.GroupID = data.GroupID
+			state.Name = data.Name
+		case EventGroupDeleted:
+			state.Exists = false
+		case EventGroupMemberAdded:
+			var data GroupMemberAdded
+			_ = json.Unmarshal(evt.Data, &data)
+			state.Members[data.AccountID] = true
+		case EventGroupMemberRemoved:
+			var data GroupMemberRemoved
+			_ = json.Unmarshal(evt.Data, &data)
+			delete(state.Members, data.AccountID)
+		case EventGroupRoleAssigned:
+			var data GroupRoleAssigned
+			_ = json.Unmarshal(evt.Data, &data)
+			state.Roles[data.RealmID] = data.Role
+		case EventGroupRoleRevoked:
+			var data GroupRoleRevoked
+			_ = json.Unmarshal(evt.Data, &data)
+			delete(state.Roles, data.RealmID)
+		}
+	}
+	return state
+}
+
+func groupStreamID(groupID string) string {
+	return groupStreamPrefix + groupID
+}
+
+func generateGroupID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate group ID: %w", err)
+	}
+	return "grp-" + hex.EncodeToString(b), nil
+}
+
+func readAndRebuildGroupState(ctx context.Context, groupID string, store core.EventStore) (GroupState, []core.Event, error) {
+	events, err := store.ReadStream(ctx, AdminRealmID, groupStreamID(groupID), 0)
+	if err != nil {
+		return GroupState{}, nil, err
+	}
+	return rebuildGroupState(events), events, nil
+}
+
+func readExistingGroup(ctx context.Context, groupID string, store core.EventStore) (GroupState, []core.Event, error) {
+	state, events, err := readAndRebuildGroupState(ctx, groupID, store)
+	if err != nil {
+		return GroupState{}, nil, err
+	}
+	if !state.Exists {
+		return GroupState{}, nil, &core.NotFoundError{Entity: "group", ID: groupID}
+	}
+	return state, events, nil
+}
+
+// requireGroupNameAvailable checks group name uniqueness via the group_directory projection.
+func requireGroupNameAvailable(ctx context.Context, name string, projectionStore core.ProjectionStore) error {
+	raws, err := projectionStore.List(ctx, AdminRealmID, "group_directory")
+	if err != nil {
+		return fmt.Errorf("check group name: %w", err)
+	}
+	for _, raw := range raws {
+		var entry struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		if strings.EqualFold(entry.Name, name) {
+			return fmt.Errorf("group name %q already exists", name)
+		}
+	}
+	return nil
+}
+
+func HandleCreateGroup(ctx context.Context, cmd CreateGroup, store core.EventStore, projectionStore core.ProjectionStore) (CreateGroupResult, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return CreateGroupResult{}, fmt.Errorf("invalid group name: must not be empty")
+	}
+	if err := requireGroupNameAvailable(ctx, name, projectionStore); err != nil {
+		return CreateGroupResult{}, err
+	}
+
+	groupID, err := generateGroupID()
+	if err != nil {
+		return CreateGroupResult{}, err
+	}
+
+	_, err = store.Append(ctx, AdminRealmID, groupStreamID(groupID), 0, []core.EventData{
+		{EventType: EventGroupCreated, Data: GroupCreated{GroupID: groupID, Name: name, CreatedAt: time.Now().UTC()}},
+	})
+	if err != nil {
+		return CreateGroupResult{}, err
+	}
+	return CreateGroupResult{GroupID: groupID}, nil
+}
+
+func HandleDeleteGroup(ctx context.Context, cmd DeleteGroup, store core.EventStore) error {
+	state, events, err := readExistingGroup(ctx, cmd.GroupID, store)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.Append(ctx, AdminRealmID, groupStreamID(cmd.GroupID), len(events), []core.EventData{
+		{EventType: EventGroupDeleted, Data: GroupDeleted{GroupID: cmd.GroupID, Name: state.Name}},
+	})
+	return err
+}
+
+func HandleAddGroupMember(ctx context.Context, cmd AddGroupMember, store core.EventStore) error {
+	state, events, err := readExistingGroup(ctx, cmd.GroupID, store)
+	if err != nil {
+		return err
+	}
+
+	account, _, err := readAndRebuildAccountState(ctx, cmd.AccountID, store)
+	if err != nil {
+		return err
+	}
+	if err := requireActiveAccount(account, cmd.AccountID); err != nil {
+		return err
+	}
+
+	if state.Members[cmd.AccountID] {
+		return nil
+	}
+
+	_, err = store.Append(ctx, AdminRealmID, groupStreamID(cmd.GroupID), len(events), []core.EventData{
+		{EventType: EventGroupMemberAdded, Data: GroupMemberAdded(cmd)},
+	})
+	return err
+}
+
+func HandleRemoveGroupMember(ctx context.Context, cmd RemoveGroupMember, store core.EventStore) error {
+	state, events, err := readExistingGroup(ctx, cmd.GroupID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Members[cmd.AccountID] {
+		return fmt.Errorf("account %q is not a member of group %q", cmd.AccountID, state.Name)
+	}
+
+	_, err = store.Append(ctx, AdminRealmID, groupStreamID(cmd.GroupID), len(events), []core.EventData{
+		{EventType: EventGroupMemberRemoved, Data: GroupMemberRemoved(cmd)},
+	})
+	return err
+}
+
+func HandleAssignGroupRole(ctx context.Context, cmd AssignGroupRole, store core.EventStore, projectionStore core.ProjectionStore) error {
+	if err := requireAssignableRole(ctx, cmd.RealmID, cmd.Role, projectionStore); err != nil {
+		return err
+	}
+
+	state, events, err := readExistingGroup(ctx, cmd.GroupID, store)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RealmID != AdminRealmID {
+		if err := requireRealmExists(ctx, cmd.RealmID, projectionStore); err != nil {
+			return err
+		}
+	}
+
+	if state.Roles[cmd.RealmID] == cmd.Role {
+		return nil
+	}
+
+	_, err = store.Append(ctx, AdminRealmID, groupStreamID(cmd.GroupID), len(events), []core.EventData{
+		{EventType: EventGroupRoleAssigned, Data: GroupRoleAssigned(cmd)},
+	})
+	return err
+}
+
+func HandleRevokeGroupRole(ctx context.Context, cmd RevokeGroupRole, store core.EventStore) error {
+	state, events, err := readExistingGroup(ctx, cmd.GroupID, store)
+	if err != nil {
+		return err
+	}
+	if _, ok := state.Roles[cmd.RealmID]; !ok {
+		return fmt.Errorf("group %q has no role in realm %q", state.Name, cmd.RealmID)
+	}
+
+	_, err = store.Append(ctx, AdminRealmID, groupStreamID(cmd.GroupID), len(events), []core.EventData{
+		{EventType: EventGroupRoleRevoked, Data: GroupRoleRevoked(cmd)},
+	})
+	return err
+}
+
+// revokeGroupRealmGrants appends GroupRoleRevoked to every group granting a role in the realm.
+func revokeGroupRealmGrants(ctx context.Context, realmID string, store core.EventStore, projectionStore core.ProjectionStore) error {
+	raws, err := projectionStore.List(ctx, AdminRealmID, "group_directory")
+	if err != nil {
+		return fmt.Errorf("list groups: %w", err)
+	}
+	for _, raw := range raws {
+		var entry struct {
+			GroupID string            `json:"group_id"`
+			Roles   map[string]string `json:"roles"`
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		if _, ok := entry.Roles[realmID]; !ok {
+			continue
+		}
+
+		state, events, err := readAndRebuildGroupState(ctx, entry.GroupID, store)
+		if err != nil {
+			return err
+		}
+		if _, ok := state.Roles[realmID]; !ok || !state.Exists {
+			continue
+		}
+		_, err = store.Append(ctx, AdminRealmID, groupStreamID(entry.GroupID), len(events), []core.EventData{
+			{EventType: EventGroupRoleRevoked, Data: GroupRoleRevoked{GroupID: entry.GroupID, RealmID: realmID}},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,441 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildGroupState(t *testing.T) {
+	t.Run("tracks members and roles", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.group_has_member("grp-1", "acct-a")
+		tc.group_has_member("grp-1", "acct-b")
+		tc.group_has_role("grp-1", "bf-c3d4", RoleMember)
+		tc.group_stream_has(EventGroupMemberRemoved, GroupMemberRemoved{GroupID: "grp-1", AccountID: "acct-a"})
+
+		// When
+		tc.group_state_is_rebuilt("grp-1")
+
+		// Then
+		assert.True(t, tc.groupState.Exists)
+		assert.Equal(t, "squad", tc.groupState.Name)
+		assert.Equal(t, map[string]bool{"acct-b": true}, tc.groupState.Members)
+		assert.Equal(t, map[string]string{"bf-c3d4": RoleMember}, tc.groupState.Roles)
+	})
+}
+
+func TestHandleCreateGroup(t *testing.T) {
+	t.Run("creates a group with a generated ID", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// When
+		tc.handle_create_group("squad")
+
+		// Then
+		tc.no_group_error()
+		assert.Regexp(t, `^grp-[0-9a-f]{8}$`, tc.createResult.GroupID)
+		tc.appended_group_event_is(EventGroupCreated)
+	})
+
+	t.Run("rejects an empty name", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// When
+		tc.handle_create_group("  ")
+
+		// Then
+		tc.group_error_contains("invalid group name")
+		tc.no_group_events_appended()
+	})
+
+	t.Run("rejects a name that is taken", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.group_name_is_taken("Squad")
+
+		// When
+		tc.handle_create_group("squad")
+
+		// Then
+		tc.group_error_contains(`group name "squad" already exists`)
+		tc.no_group_events_appended()
+	})
+}
+
+func TestHandleAddGroupMember(t *testing.T) {
+	t.Run("adds an active account", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.existing_account("acct-a", false)
+
+		// When
+		tc.handle_add_group_member("grp-1", "acct-a")
+
+		// Then
+		tc.no_group_error()
+		tc.appended_group_event_is(EventGroupMemberAdded)
+	})
+
+	t.Run("is idempotent for an existing member", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.group_has_member("grp-1", "acct-a")
+		tc.existing_account("acct-a", false)
+
+		// When
+		tc.handle_add_group_member("grp-1", "acct-a")
+
+		// Then
+		tc.no_group_error()
+		tc.no_group_events_appended()
+	})
+
+	t.Run("rejects a suspended account", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.existing_account("acct-a", true)
+
+		// When
+		tc.handle_add_group_member("grp-1", "acct-a")
+
+		// Then
+		tc.group_error_contains("suspended")
+		tc.no_group_events_appended()
+	})
+
+	t.Run("returns not found for an unknown group", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_account("acct-a", false)
+
+		// When
+		tc.handle_add_group_member("grp-missing", "acct-a")
+
+		// Then
+		tc.group_error_is_not_found()
+	})
+}
+
+func TestHandleRemoveGroupMember(t *testing.T) {
+	t.Run("removes a member", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.group_has_member("grp-1", "acct-a")
+
+		// When
+		tc.handle_remove_group_member("grp-1", "acct-a")
+
+		// Then
+		tc.no_group_error()
+		tc.appended_group_event_is(EventGroupMemberRemoved)
+	})
+
+	t.Run("rejects an account that is not a member", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+
+		// When
+		tc.handle_remove_group_member("grp-1", "acct-a")
+
+		// Then
+		tc.group_error_contains("is not a member")
+	})
+}
+
+func TestHandleAssignGroupRole(t *testing.T) {
+	t.Run("grants a role in an existing realm", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.realm_exists("bf-c3d4")
+
+		// When
+		tc.handle_assign_group_role("grp-1", "bf-c3d4", RoleAdmin)
+
+		// Then
+		tc.no_group_error()
+		tc.appended_group_event_is(EventGroupRoleAssigned)
+	})
+
+	t.Run("is idempotent for the same role", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.group_has_role("grp-1", "bf-c3d4", RoleAdmin)
+		tc.realm_exists("bf-c3d4")
+
+		// When
+		tc.handle_assign_group_role("grp-1", "bf-c3d4", RoleAdmin)
+
+		// Then
+		tc.no_group_error()
+		tc.no_group_events_appended()
+	})
+
+	t.Run("rejects an invalid role", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+
+		// When
+		tc.handle_assign_group_role("grp-1", "bf-c3d4", "root")
+
+		// Then
+		tc.group_error_contains(`invalid role "root"`)
+	})
+
+	t.Run("returns not found for an unknown realm", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+
+		// When
+		tc.handle_assign_group_role("grp-1", "bf-missing", RoleMember)
+
+		// Then
+		tc.group_error_is_not_found()
+	})
+}
+
+func TestHandleRevokeGroupRole(t *testing.T) {
+	t.Run("revokes a granted role", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.group_has_role("grp-1", "bf-c3d4", RoleMember)
+
+		// When
+		tc.handle_revoke_group_role("grp-1", "bf-c3d4")
+
+		// Then
+		tc.no_group_error()
+		tc.appended_group_event_is(EventGroupRoleRevoked)
+	})
+
+	t.Run("rejects a realm the group has no role in", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+
+		// When
+		tc.handle_revoke_group_role("grp-1", "bf-c3d4")
+
+		// Then
+		tc.group_error_contains("has no role in realm")
+	})
+}
+
+func TestHandleDeleteGroup(t *testing.T) {
+	t.Run("deletes an existing group", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+
+		// When
+		tc.handle_delete_group("grp-1")
+
+		// Then
+		tc.no_group_error()
+		tc.appended_group_event_is(EventGroupDeleted)
+	})
+
+	t.Run("returns not found for a deleted group", func(t *testing.T) {
+		tc := newGroupHandlerTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.group_stream_has(EventGroupDeleted, GroupDeleted{GroupID: "grp-1", Name: "squad"})
+
+		// When
+		tc.handle_delete_group("grp-1")
+
+		// Then
+		tc.group_error_is_not_found()
+	})
+}
+
+// --- Test Context ---
+
+type groupHandlerTestContext struct {
+	t   *testing.T
+	ctx context.Context
+
+	eventStore      *mockEventStore
+	projectionStore *mockProjectionStore
+
+	groupState   GroupState
+	createResult CreateGroupResult
+	err          error
+}
+
+func newGroupHandlerTestContext(t *testing.T) *groupHandlerTestContext {
+	t.Helper()
+	store := newMockProjectionStore()
+	store.data = make(map[string]any)
+	return &groupHandlerTestContext{
+		t:               t,
+		ctx:             context.Background(),
+		eventStore:      newMockEventStore(),
+		projectionStore: store,
+	}
+}
+
+// --- Given ---
+
+func (tc *groupHandlerTestContext) group_stream_has(eventType string, data any) {
+	tc.t.Helper()
+	id := ""
+	switch d := data.(type) {
+	case GroupCreated:
+		id = d.GroupID
+	case GroupDeleted:
+		id = d.GroupID
+	case GroupMemberAdded:
+		id = d.GroupID
+	case GroupMemberRemoved:
+		id = d.GroupID
+	case GroupRoleAssigned:
+		id = d.GroupID
+	}
+	tc.eventStore.streams[groupStreamID(id)] = append(tc.eventStore.streams[groupStreamID(id)], makeEvent(eventType, data))
+}
+
+func (tc *groupHandlerTestContext) existing_group(groupID, name string) {
+	tc.t.Helper()
+	tc.group_stream_has(EventGroupCreated, GroupCreated{GroupID: groupID, Name: name})
+}
+
+func (tc *groupHandlerTestContext) group_has_member(groupID, accountID string) {
+	tc.t.Helper()
+	tc.group_stream_has(EventGroupMemberAdded, GroupMemberAdded{GroupID: groupID, AccountID: accountID})
+}
+
+func (tc *groupHandlerTestContext) group_has_role(groupID, realmID, role string) {
+	tc.t.Helper()
+	tc.group_stream_has(EventGroupRoleAssigned, GroupRoleAssigned{GroupID: groupID, RealmID: realmID, Role: role})
+}
+
+func (tc *groupHandlerTestContext) existing_account(accountID string, suspended bool) {
+	tc.t.Helper()
+	events := []core.Event{
+		makeEvent(EventAccountCreated, AccountCreated{AccountID: accountID, Username: "alice"}),
+	}
+	if suspended {
+		events = append(events, makeEvent(EventAccountSuspended, AccountSuspended{AccountID: accountID}))
+	}
+	tc.eventStore.streams[accountStreamID(accountID)] = events
+}
+
+func (tc *groupHandlerTestContext) group_name_is_taken(name string) {
+	tc.t.Helper()
+	raw, _ := json.Marshal(map[string]string{"group_id": "grp-existing", "name": name})
+	tc.projectionStore.listData["_admin:group_directory"] = []json.RawMessage{raw}
+}
+
+func (tc *groupHandlerTestContext) realm_exists(realmID string) {
+	tc.t.Helper()
+	tc.projectionStore.data["_admin:realm_directory:"+realmID] = map[string]any{
+		"realm_id": realmID,
+		"name":     "Test Realm",
+		"status":   "active",
+	}
+}
+
+// --- When ---
+
+func (tc *groupHandlerTestContext) group_state_is_rebuilt(groupID string) {
+	tc.t.Helper()
+	tc.groupState = rebuildGroupState(tc.eventStore.streams[groupStreamID(groupID)])
+}
+
+func (tc *groupHandlerTestContext) handle_create_group(name string) {
+	tc.t.Helper()
+	tc.createResult, tc.err = HandleCreateGroup(tc.ctx, CreateGroup{Name: name}, tc.eventStore, tc.projectionStore)
+}
+
+func (tc *groupHandlerTestContext) handle_delete_group(groupID string) {
+	tc.t.Helper()
+	tc.err = HandleDeleteGroup(tc.ctx, DeleteGroup{GroupID: groupID}, tc.eventStore)
+}
+
+func (tc *groupHandlerTestContext) handle_add_group_member(groupID, accountID string) {
+	tc.t.Helper()
+	tc.err = HandleAddGroupMember(tc.ctx, AddGroupMember{GroupID: groupID, AccountID: accountID}, tc.eventStore)
+}
+
+func (tc *groupHandlerTestContext) handle_remove_group_member(groupID, accountID string) {
+	tc.t.Helper()
+	tc.err = HandleRemoveGroupMember(tc.ctx, RemoveGroupMember{GroupID: groupID, AccountID: accountID}, tc.eventStore)
+}
+
+func (tc *groupHandlerTestContext) handle_assign_group_role(groupID, realmID, role string) {
+	tc.t.Helper()
+	tc.err = HandleAssignGroupRole(tc.ctx, AssignGroupRole{GroupID: groupID, RealmID: realmID, Role: role}, tc.eventStore, tc.projectionStore)
+}
+
+func (tc *groupHandlerTestContext) handle_revoke_group_role(groupID, realmID string) {
+	tc.t.Helper()
+	tc.err = HandleRevokeGroupRole(tc.ctx, RevokeGroupRole{GroupID: groupID, RealmID: realmID}, tc.eventStore)
+}
+
+// --- Then ---
+
+func (tc *groupHandlerTestContext) no_group_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *groupHandlerTestContext) group_error_contains(substring string) {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+	assert.Contains(tc.t, tc.err.Error(), substring)
+}
+
+func (tc *groupHandlerTestContext) group_error_is_not_found() {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+	var nfe *core.NotFoundError
+	assert.ErrorAs(tc.t, tc.err, &nfe)
+}
+
+func (tc *groupHandlerTestContext) appended_group_event_is(eventType string) {
+	tc.t.Helper()
+	require.Len(tc.t, tc.eventStore.appendedCalls, 1)
+	call := tc.eventStore.appendedCalls[0]
+	assert.Equal(tc.t, AdminRealmID, call.realmID)
+	assert.Regexp(tc.t, `^group-`, call.streamID)
+	require.Len(tc.t, call.events, 1)
+	assert.Equal(tc.t, eventType, call.events[0].EventType)
+}
+
+func (tc *groupHandlerTestContext) no_group_events_appended() {
+	tc.t.Helper()
+	assert.Empty(tc.t, tc.eventStore.appendedCalls)
+}
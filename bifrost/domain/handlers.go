@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/devzeebo/bifrost/core"
 )
@@ -21,11 +22,15 @@ type RuneState struct {
 	Description string
 	Status      string
 	Claimant    string
+	AccountID   string
 	ParentID    string
+	Alias       string
 	Branch      string
 	Tags        []string
 	Priority    int
 	Type        string
+	DueAt       *time.Time
+	DeferUntil  *time.Time
 	State       map[string]any
 	Exists      bool
 }
@@ -50,6 +55,8 @@ func RebuildRuneState(events []core.Event) RuneState {
 			if state.Type == "" {
 				state.Type = "rune"
 			}
+			state.DueAt = data.DueAt
+			state.DeferUntil = data.DeferUntil
 			state.Status = "draft"
 		case EventRuneUpdated:
 			var data RuneUpdated
@@ -67,14 +74,18 @@ func RebuildRuneState(events []core.Event) RuneState {
 				state.Branch = *data.Branch
 			}
 			state.Tags = applyTagMutations(state.Tags, data.Tags, data.AddTags, data.RemoveTags)
+			state.DueAt = ApplyTimeMutation(state.DueAt, data.DueAt, data.ClearDueAt)
+			state.DeferUntil = ApplyTimeMutation(state.DeferUntil, data.DeferUntil, data.ClearDeferUntil)
 		case EventRuneClaimed:
 			var data RuneClaimed
 			_ = json.Unmarshal(evt.Data, &data)
 			state.Status = "claimed"
 			state.Claimant = data.Claimant
+			state.AccountID = data.AccountID
 		case EventRuneUnclaimed:
 			state.Status = "open"
 			state.Claimant = ""
+			state.AccountID = ""
 		case EventRuneFulfilled:
 			state.Status = "fulfilled"
 		case EventRuneForged:
@@ -89,12 +100,19 @@ func RebuildRuneState(events []core.Event) RuneState {
 			if data.Claimant != "" {
 				state.Status = "claimed"
 				state.Claimant = data.Claimant
+				state.AccountID = data.AccountID
 			} else {
 				state.Status = "open"
 				state.Claimant = ""
+				state.AccountID = ""
 			}
 		case EventRuneShattered:
 			state.Status = "shattered"
+		case EventRuneMoved:
+			var data RuneMoved
+			_ = json.Unmarshal(evt.Data, &data)
+			state.ParentID = data.NewParentID
+			state.Alias = data.Alias
 		case EventRuneStateUpdated:
 			var data RuneStateUpdated
 			_ = json.Unmarshal(evt.Data, &data)
@@ -131,6 +149,14 @@ func readAndRebuild(ctx context.Context, realmID string, runeID string, store co
 }
 
 func HandleCreateRune(ctx context.Context, realmID string, cmd CreateRune, store core.EventStore, projStore core.ProjectionStore) (RuneCreated, error) {
+	if err := enforcePolicies(ctx, realmID, "create", "", cmd, store, projStore); err != nil {
+		return RuneCreated{}, err
+	}
+	settings, err := ReadRealmSettings(ctx, realmID, projStore)
+	if err != nil {
+		return RuneCreated{}, err
+	}
+
 	var runeID string
 
 	var branch string
@@ -168,12 +194,12 @@ func HandleCreateRune(ctx context.Context, realmID string, cmd CreateRune, store
 		}
 		runeID = fmt.Sprintf("%s.%d", cmd.ParentID, entry.Count+1)
 	} else {
-		if cmd.Branch == nil {
+		if cmd.Branch != nil {
+			branch = *cmd.Branch
+		} else if settings.RequireBranch {
 			return RuneCreated{}, fmt.Errorf("branch is required for top-level runes")
 		}
-		branch = *cmd.Branch
 
-		var err error
 		runeID, err = generateRuneID()
 		if err != nil {
 			return RuneCreated{}, err
@@ -182,21 +208,27 @@ func HandleCreateRune(ctx context.Context, realmID string, cmd CreateRune, store
 
 	runeType := cmd.Type
 	if runeType == "" {
-		runeType = "rune"
+		runeType = settings.DefaultRuneType
+	}
+	priority := settings.DefaultPriority
+	if cmd.Priority != nil {
+		priority = *cmd.Priority
 	}
 	created := RuneCreated{
 		ID:          runeID,
 		Title:       cmd.Title,
 		Description: cmd.Description,
-		Priority:    cmd.Priority,
+		Priority:    priority,
 		ParentID:    cmd.ParentID,
 		Branch:      branch,
 		Tags:        normalizeTags(cmd.Tags),
 		Type:        runeType,
+		DueAt:       normalizeTime(cmd.DueAt),
+		DeferUntil:  normalizeTime(cmd.DeferUntil),
 	}
 
 	streamID := runeStreamID(runeID)
-	_, err := store.Append(ctx, realmID, streamID, 0, []core.EventData{
+	_, err = store.Append(ctx, realmID, streamID, 0, []core.EventData{
 		{EventType: EventRuneCreated, Data: created},
 	})
 	if err != nil {
@@ -206,7 +238,7 @@ func HandleCreateRune(ctx context.Context, realmID string, cmd CreateRune, store
 	return created, nil
 }
 
-func HandleUpdateRune(ctx context.Context, realmID string, cmd UpdateRune, store core.EventStore) error {
+func HandleUpdateRune(ctx context.Context, realmID string, cmd UpdateRune, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
 	if err != nil {
 		return err
@@ -214,12 +246,21 @@ func HandleUpdateRune(ctx context.Context, realmID string, cmd UpdateRune, store
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "update", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "sealed" {
 		return fmt.Errorf("cannot update sealed rune %q", cmd.ID)
 	}
 	if state.Status == "shattered" {
 		return fmt.Errorf("cannot update shattered rune %q", cmd.ID)
 	}
+	if cmd.DueAt != nil && cmd.ClearDueAt {
+		return fmt.Errorf("cannot both set and clear due_at on rune %q", cmd.ID)
+	}
+	if cmd.DeferUntil != nil && cmd.ClearDeferUntil {
+		return fmt.Errorf("cannot both set and clear defer_until on rune %q", cmd.ID)
+	}
 
 	updated := RuneUpdated{
 		ID:          cmd.ID,
@@ -230,6 +271,10 @@ func HandleUpdateRune(ctx context.Context, realmID string, cmd UpdateRune, store
 		Tags:        normalizeTagPointer(cmd.Tags),
 		AddTags:     normalizeTags(cmd.AddTags),
 		RemoveTags:  normalizeTags(cmd.RemoveTags),
+		DueAt:           normalizeTime(cmd.DueAt),
+		DeferUntil:      normalizeTime(cmd.DeferUntil),
+		ClearDueAt:      cmd.ClearDueAt,
+		ClearDeferUntil: cmd.ClearDeferUntil,
 	}
 
 	streamID := runeStreamID(cmd.ID)
@@ -239,7 +284,7 @@ func HandleUpdateRune(ctx context.Context, realmID string, cmd UpdateRune, store
 	return err
 }
 
-func HandleClaimRune(ctx context.Context, realmID string, cmd ClaimRune, store core.EventStore) error {
+func HandleClaimRune(ctx context.Context, realmID string, cmd ClaimRune, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
 	if err != nil {
 		return err
@@ -247,6 +292,9 @@ func HandleClaimRune(ctx context.Context, realmID string, cmd ClaimRune, store c
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "claim", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "draft" {
 		return fmt.Errorf("cannot claim draft rune %q", cmd.ID)
 	}
@@ -265,6 +313,11 @@ func HandleClaimRune(ctx context.Context, realmID string, cmd ClaimRune, store c
 	if state.Status == "fulfilled" {
 		return fmt.Errorf("cannot claim fulfilled rune %q", cmd.ID)
 	}
+	claim := ActiveClaim{RuneID: cmd.ID, Claimant: cmd.Claimant, AccountID: cmd.AccountID, Tags: state.Tags}
+	reservation, err := reserveWIPClaim(ctx, realmID, claim, store, projStore)
+	if err != nil {
+		return err
+	}
 
 	claimed := RuneClaimed(cmd)
 
@@ -272,10 +325,13 @@ func HandleClaimRune(ctx context.Context, realmID string, cmd ClaimRune, store c
 	_, err = store.Append(ctx, realmID, streamID, len(events), []core.EventData{
 		{EventType: EventRuneClaimed, Data: claimed},
 	})
+	if err != nil && reservation > 0 {
+		releaseWIPClaim(ctx, realmID, claim, reservation, store)
+	}
 	return err
 }
 
-func HandleUnclaimRune(ctx context.Context, realmID string, cmd UnclaimRune, store core.EventStore) error {
+func HandleUnclaimRune(ctx context.Context, realmID string, cmd UnclaimRune, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
 	if err != nil {
 		return err
@@ -283,6 +339,9 @@ func HandleUnclaimRune(ctx context.Context, realmID string, cmd UnclaimRune, sto
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "unclaim", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "sealed" {
 		return fmt.Errorf("cannot unclaim sealed rune %q", cmd.ID)
 	}
@@ -310,6 +369,9 @@ func HandleForgeRune(ctx context.Context, realmID string, cmd ForgeRune, store c
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "forge", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	// Shattered runes are tombstones - skip them silently (no-op).
 	if state.Status == "shattered" || state.Status != "draft" {
 		return nil
@@ -328,7 +390,7 @@ func HandleForgeRune(ctx context.Context, realmID string, cmd ForgeRune, store c
 	return nil
 }
 
-func HandleFulfillRune(ctx context.Context, realmID string, cmd FulfillRune, store core.EventStore) error {
+func HandleFulfillRune(ctx context.Context, realmID string, cmd FulfillRune, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
 	if err != nil {
 		return err
@@ -336,6 +398,9 @@ func HandleFulfillRune(ctx context.Context, realmID string, cmd FulfillRune, sto
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "fulfill", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "sealed" {
 		return fmt.Errorf("cannot fulfill sealed rune %q", cmd.ID)
 	}
@@ -351,6 +416,15 @@ func HandleFulfillRune(ctx context.Context, realmID string, cmd FulfillRune, sto
 	if state.Status != "claimed" {
 		return fmt.Errorf("cannot fulfill rune %q: not claimed", cmd.ID)
 	}
+	if pending := unverifiedACs(events); len(pending) > 0 && projStore != nil {
+		settings, err := ReadRealmSettings(ctx, realmID, projStore)
+		if err != nil {
+			return err
+		}
+		if settings.RequireACVerification {
+			return fmt.Errorf("cannot fulfill rune %q: acceptance criteria not verified: %s", cmd.ID, strings.Join(pending, ", "))
+		}
+	}
 
 	fulfilled := RuneFulfilled(cmd)
 
@@ -358,10 +432,15 @@ func HandleFulfillRune(ctx context.Context, realmID string, cmd FulfillRune, sto
 	_, err = store.Append(ctx, realmID, streamID, len(events), []core.EventData{
 		{EventType: EventRuneFulfilled, Data: fulfilled},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	autoCompleteSagaParent(ctx, realmID, cmd.ID, "fulfilled", state.ParentID, store, projStore)
+	return nil
 }
 
-func HandleSealRune(ctx context.Context, realmID string, cmd SealRune, store core.EventStore) error {
+func HandleSealRune(ctx context.Context, realmID string, cmd SealRune, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
 	if err != nil {
 		return err
@@ -369,6 +448,9 @@ func HandleSealRune(ctx context.Context, realmID string, cmd SealRune, store cor
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "seal", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "sealed" {
 		return fmt.Errorf("rune %q is already sealed", cmd.ID)
 	}
@@ -382,10 +464,15 @@ func HandleSealRune(ctx context.Context, realmID string, cmd SealRune, store cor
 	_, err = store.Append(ctx, realmID, streamID, len(events), []core.EventData{
 		{EventType: EventRuneSealed, Data: sealed},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	autoCompleteSagaParent(ctx, realmID, cmd.ID, "sealed", state.ParentID, store, projStore)
+	return nil
 }
 
-func HandleFailRune(ctx context.Context, realmID string, cmd FailRune, store core.EventStore) error {
+func HandleFailRune(ctx context.Context, realmID string, cmd FailRune, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
 	if err != nil {
 		return err
@@ -393,6 +480,9 @@ func HandleFailRune(ctx context.Context, realmID string, cmd FailRune, store cor
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "fail", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "shattered" {
 		return fmt.Errorf("cannot fail shattered rune %q", cmd.ID)
 	}
@@ -412,6 +502,10 @@ func HandleFailRune(ctx context.Context, realmID string, cmd FailRune, store cor
 }
 
 func HandleAddDependency(ctx context.Context, realmID string, cmd AddDependency, store core.EventStore, projStore core.ProjectionStore) error {
+	if err := enforcePolicies(ctx, realmID, "add-dependency", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
+
 	if !isKnownRelationship(cmd.Relationship) {
 		return fmt.Errorf("unknown relationship type %q", cmd.Relationship)
 	}
@@ -513,6 +607,10 @@ func handleAddDependencyOnce(ctx context.Context, realmID string, cmd AddDepende
 }
 
 func HandleRemoveDependency(ctx context.Context, realmID string, cmd RemoveDependency, store core.EventStore, projStore core.ProjectionStore) error {
+	if err := enforcePolicies(ctx, realmID, "remove-dependency", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
+
 	if IsInverseRelationship(cmd.Relationship) {
 		cmd.RuneID, cmd.TargetID = cmd.TargetID, cmd.RuneID
 		cmd.Relationship = ReflectRelationship(cmd.Relationship)
@@ -591,7 +689,7 @@ func handleRemoveDependencyOnce(ctx context.Context, realmID string, cmd RemoveD
 	return err
 }
 
-func HandleAddNote(ctx context.Context, realmID string, cmd AddNote, store core.EventStore) error {
+func HandleAddNote(ctx context.Context, realmID string, cmd AddNote, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.RuneID, store)
 	if err != nil {
 		return err
@@ -599,6 +697,9 @@ func HandleAddNote(ctx context.Context, realmID string, cmd AddNote, store core.
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.RuneID}
 	}
+	if err := enforcePolicies(ctx, realmID, "note", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "shattered" {
 		return fmt.Errorf("cannot add note to shattered rune %q", cmd.RuneID)
 	}
@@ -612,7 +713,7 @@ func HandleAddNote(ctx context.Context, realmID string, cmd AddNote, store core.
 	return err
 }
 
-func HandleAddRetro(ctx context.Context, realmID string, cmd AddRetro, store core.EventStore) error {
+func HandleAddRetro(ctx context.Context, realmID string, cmd AddRetro, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.RuneID, store)
 	if err != nil {
 		return err
@@ -620,6 +721,9 @@ func HandleAddRetro(ctx context.Context, realmID string, cmd AddRetro, store cor
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.RuneID}
 	}
+	if err := enforcePolicies(ctx, realmID, "retro", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
 	// No status gate — retro items are allowed in all states including shattered.
 
 	retroed := RuneRetroed(cmd)
@@ -631,7 +735,7 @@ func HandleAddRetro(ctx context.Context, realmID string, cmd AddRetro, store cor
 	return err
 }
 
-func HandleShatterRune(ctx context.Context, realmID string, cmd ShatterRune, store core.EventStore) error {
+func HandleShatterRune(ctx context.Context, realmID string, cmd ShatterRune, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
 	if err != nil {
 		return err
@@ -639,6 +743,9 @@ func HandleShatterRune(ctx context.Context, realmID string, cmd ShatterRune, sto
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "shatter", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status != "sealed" && state.Status != "fulfilled" {
 		return fmt.Errorf("cannot shatter rune %q: must be sealed or fulfilled", cmd.ID)
 	}
@@ -649,10 +756,15 @@ func HandleShatterRune(ctx context.Context, realmID string, cmd ShatterRune, sto
 	_, err = store.Append(ctx, realmID, streamID, len(events), []core.EventData{
 		{EventType: EventRuneShattered, Data: shattered},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	autoCompleteSagaParent(ctx, realmID, cmd.ID, "shattered", state.ParentID, store, projStore)
+	return nil
 }
 
-func HandleReopenRune(ctx context.Context, realmID string, cmd ReopenRune, store core.EventStore) error {
+func HandleReopenRune(ctx context.Context, realmID string, cmd ReopenRune, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
 	if err != nil {
 		return err
@@ -660,20 +772,22 @@ func HandleReopenRune(ctx context.Context, realmID string, cmd ReopenRune, store
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.ID}
 	}
+	if err := enforcePolicies(ctx, realmID, "reopen", cmd.ID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status != "failed" {
 		return fmt.Errorf("can only reopen failed runes")
 	}
 
-	claimant := ""
+	reopened := RuneReopened{ID: cmd.ID}
 	if cmd.AsClaimed {
-		claimant = state.Claimant
-		if claimant == "" {
+		if state.Claimant == "" {
 			return fmt.Errorf("cannot reopen as claimed: rune has no previous claimant")
 		}
+		reopened.Claimant = state.Claimant
+		reopened.AccountID = state.AccountID
 	}
 
-	reopened := RuneReopened{ID: cmd.ID, Claimant: claimant}
-
 	streamID := runeStreamID(cmd.ID)
 	_, err = store.Append(ctx, realmID, streamID, len(events), []core.EventData{
 		{EventType: EventRuneReopened, Data: reopened},
@@ -710,7 +824,12 @@ func HandleSweepRunes(ctx context.Context, realmID string, store core.EventStore
 			continue
 		}
 
-		if err := HandleShatterRune(ctx, realmID, ShatterRune{ID: candidate.ID}, store); err != nil {
+		if err := HandleShatterRune(ctx, realmID, ShatterRune{ID: candidate.ID}, store, projStore); err != nil {
+			// A rune a policy will not let us shatter is left in place.
+			var policyErr *PolicyViolationError
+			if errors.As(err, &policyErr) {
+				continue
+			}
 			return nil, err
 		}
 		shattered = append(shattered, candidate.ID)
@@ -770,7 +889,7 @@ func isActiveRuneInProjection(ctx context.Context, realmID string, runeID string
 	return s.Status != "sealed" && s.Status != "fulfilled" && s.Status != "failed"
 }
 
-func HandleAddACItem(ctx context.Context, realmID string, cmd AddACItem, store core.EventStore) error {
+func HandleAddACItem(ctx context.Context, realmID string, cmd AddACItem, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.RuneID, store)
 	if err != nil {
 		return err
@@ -778,6 +897,9 @@ func HandleAddACItem(ctx context.Context, realmID string, cmd AddACItem, store c
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.RuneID}
 	}
+	if err := enforcePolicies(ctx, realmID, "add-ac", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "sealed" {
 		return fmt.Errorf("cannot add AC to sealed rune %q", cmd.RuneID)
 	}
@@ -822,7 +944,7 @@ func HandleAddACItem(ctx context.Context, realmID string, cmd AddACItem, store c
 	return err
 }
 
-func HandleUpdateACItem(ctx context.Context, realmID string, cmd UpdateACItem, store core.EventStore) error {
+func HandleUpdateACItem(ctx context.Context, realmID string, cmd UpdateACItem, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.RuneID, store)
 	if err != nil {
 		return err
@@ -830,6 +952,9 @@ func HandleUpdateACItem(ctx context.Context, realmID string, cmd UpdateACItem, s
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.RuneID}
 	}
+	if err := enforcePolicies(ctx, realmID, "update-ac", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "sealed" {
 		return fmt.Errorf("cannot update AC on sealed rune %q", cmd.RuneID)
 	}
@@ -872,7 +997,7 @@ func HandleUpdateACItem(ctx context.Context, realmID string, cmd UpdateACItem, s
 	return err
 }
 
-func HandleRemoveACItem(ctx context.Context, realmID string, cmd RemoveACItem, store core.EventStore) error {
+func HandleRemoveACItem(ctx context.Context, realmID string, cmd RemoveACItem, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.RuneID, store)
 	if err != nil {
 		return err
@@ -880,6 +1005,9 @@ func HandleRemoveACItem(ctx context.Context, realmID string, cmd RemoveACItem, s
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.RuneID}
 	}
+	if err := enforcePolicies(ctx, realmID, "remove-ac", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "sealed" {
 		return fmt.Errorf("cannot remove AC from sealed rune %q", cmd.RuneID)
 	}
@@ -922,7 +1050,16 @@ func HandleRemoveACItem(ctx context.Context, realmID string, cmd RemoveACItem, s
 	return err
 }
 
-func HandleUpdateRuneState(ctx context.Context, realmID string, cmd UpdateRuneState, store core.EventStore) error {
+// Verification statuses for an acceptance criterion. Every AC starts pending
+// and returns to pending when its scenario or description is updated.
+const (
+	ACStatusPending  = "pending"
+	ACStatusVerified = "verified"
+	ACStatusFailed   = "failed"
+	ACStatusSkipped  = "skipped"
+)
+
+func HandleVerifyACItem(ctx context.Context, realmID string, cmd VerifyACItem, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.RuneID, store)
 	if err != nil {
 		return err
@@ -930,6 +1067,87 @@ func HandleUpdateRuneState(ctx context.Context, realmID string, cmd UpdateRuneSt
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.RuneID}
 	}
+	if err := enforcePolicies(ctx, realmID, "verify-ac", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
+	if state.Status == "sealed" {
+		return fmt.Errorf("cannot verify AC on sealed rune %q", cmd.RuneID)
+	}
+	if state.Status == "failed" {
+		return fmt.Errorf("cannot verify AC on failed rune %q", cmd.RuneID)
+	}
+	if state.Status == "shattered" {
+		return fmt.Errorf("cannot verify AC on shattered rune %q", cmd.RuneID)
+	}
+	switch cmd.Status {
+	case ACStatusVerified, ACStatusFailed, ACStatusSkipped:
+	default:
+		return fmt.Errorf("invalid AC status %q: must be one of verified, failed, skipped", cmd.Status)
+	}
+
+	if _, ok := acStatuses(events)[cmd.ID]; !ok {
+		return fmt.Errorf("AC %q does not exist on rune %q", cmd.ID, cmd.RuneID)
+	}
+
+	acVerified := RuneACVerified(cmd)
+
+	streamID := runeStreamID(cmd.RuneID)
+	_, err = store.Append(ctx, realmID, streamID, len(events), []core.EventData{
+		{EventType: EventRuneACVerified, Data: acVerified},
+	})
+	return err
+}
+
+// acStatuses returns the verification status of each acceptance criterion
+// currently on the rune, keyed by AC ID.
+func acStatuses(events []core.Event) map[string]string {
+	statuses := make(map[string]string)
+	for _, evt := range events {
+		switch evt.EventType {
+		case EventRuneACAdded, EventRuneACUpdated:
+			var data struct {
+				ID string `json:"id"`
+			}
+			_ = json.Unmarshal(evt.Data, &data)
+			statuses[data.ID] = ACStatusPending
+		case EventRuneACRemoved:
+			var data RuneACRemoved
+			_ = json.Unmarshal(evt.Data, &data)
+			delete(statuses, data.ID)
+		case EventRuneACVerified:
+			var data RuneACVerified
+			_ = json.Unmarshal(evt.Data, &data)
+			if _, ok := statuses[data.ID]; ok {
+				statuses[data.ID] = data.Status
+			}
+		}
+	}
+	return statuses
+}
+
+// unverifiedACs returns the sorted IDs of acceptance criteria that are not verified.
+func unverifiedACs(events []core.Event) []string {
+	var ids []string
+	for id, status := range acStatuses(events) {
+		if status != ACStatusVerified {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func HandleUpdateRuneState(ctx context.Context, realmID string, cmd UpdateRuneState, store core.EventStore, projStore core.ProjectionStore) error {
+	state, events, err := readAndRebuild(ctx, realmID, cmd.RuneID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "rune", ID: cmd.RuneID}
+	}
+	if err := enforcePolicies(ctx, realmID, "update-state", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "shattered" {
 		return &core.BadRequestError{Message: fmt.Sprintf("rune %q is shattered", cmd.RuneID)}
 	}
@@ -945,6 +1163,23 @@ func HandleUpdateRuneState(ctx context.Context, realmID string, cmd UpdateRuneSt
 		return fmt.Errorf("invalid patch: must be a JSON object, not null")
 	}
 
+	// Validate the merged state against the realm's size cap
+	settings, err := ReadRealmSettings(ctx, realmID, projStore)
+	if err != nil {
+		return err
+	}
+	merged, err := MergePatch(state.State, patch)
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+	if err := ValidateStateSizeLimit(mergedJSON, settings.MaxStateSize); err != nil {
+		return err
+	}
+
 	// Create the event
 	stateUpdated := RuneStateUpdated{
 		RuneID: cmd.RuneID,
@@ -958,7 +1193,7 @@ func HandleUpdateRuneState(ctx context.Context, realmID string, cmd UpdateRuneSt
 	return err
 }
 
-func HandleClearRuneState(ctx context.Context, realmID string, cmd ClearRuneState, store core.EventStore) error {
+func HandleClearRuneState(ctx context.Context, realmID string, cmd ClearRuneState, store core.EventStore, projStore core.ProjectionStore) error {
 	state, events, err := readAndRebuild(ctx, realmID, cmd.RuneID, store)
 	if err != nil {
 		return err
@@ -966,6 +1201,9 @@ func HandleClearRuneState(ctx context.Context, realmID string, cmd ClearRuneStat
 	if !state.Exists {
 		return &core.NotFoundError{Entity: "rune", ID: cmd.RuneID}
 	}
+	if err := enforcePolicies(ctx, realmID, "clear-state", cmd.RuneID, cmd, store, projStore); err != nil {
+		return err
+	}
 	if state.Status == "shattered" {
 		return &core.BadRequestError{Message: fmt.Sprintf("rune %q is shattered", cmd.RuneID)}
 	}
@@ -1055,4 +1293,23 @@ func applyTagMutations(current []string, replacement *[]string, addTags []string
 	}
 	sort.Strings(out)
 	return out
-}
\ No newline at end of file
+}
+func normalizeTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// ApplyTimeMutation returns the next value of an optional timestamp given an
+// update's replacement value and clear flag. Replacements are stored in UTC.
+func ApplyTimeMutation(current *time.Time, replacement *time.Time, clear bool) *time.Time {
+	if clear {
+		return nil
+	}
+	if replacement != nil {
+		return normalizeTime(replacement)
+	}
+	return current
+}
@@ -41,6 +41,23 @@ func TestHandleReopenRune(t *testing.T) {
 		tc.appended_event_has_type(EventRuneReopened)
 	})
 
+	t.Run("carries the previous claim's account when reopening as claimed", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.existing_failed_rune_claimed_by_account("bf-a1b2", "odin", "acct-1")
+		tc.a_reopen_rune_command("bf-a1b2", true)
+
+		// When
+		tc.handle_reopen_rune()
+
+		// Then
+		tc.no_error()
+		tc.reopened_event_is(RuneReopened{ID: "bf-a1b2", Claimant: "odin", AccountID: "acct-1"})
+	})
+
 	t.Run("rejects non-failed rune", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
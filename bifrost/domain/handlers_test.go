@@ -1324,6 +1324,28 @@ func TestHandleSweepRunes(t *testing.T) {
 		tc.no_error()
 		tc.sweep_result_is_empty()
 	})
+	t.Run("leaves runes that a shatter policy rejects", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-a1b2", "sealed")
+		tc.existing_rune_in_stream("bf-c3d4", "fulfilled")
+		tc.rune_in_rune_list("bf-a1b2", "sealed")
+		tc.rune_in_rune_list("bf-c3d4", "fulfilled")
+		tc.realm_policy("keep-a1b2", `rune.id != "bf-a1b2"`, "shatter")
+
+		// When
+		tc.handle_sweep_runes()
+
+		// Then
+		tc.no_error()
+		tc.sweep_result_has_length(1)
+		tc.sweep_result_contains("bf-c3d4")
+		tc.no_events_appended_to_stream("rune-bf-a1b2")
+	})
 }
 
 func TestHandleCreateRune_RejectsShatteredParent(t *testing.T) {
@@ -1534,11 +1556,15 @@ type handlerTestContext struct {
 	removeDepCmd RemoveDependency
 	addNoteCmd  AddNote
 	shatterCmd  ShatterRune
+	cascadeCmd  CascadeRune
+	moveCmd     MoveRune
 
 	createdEvent RuneCreated
 	state        RuneState
 	events       []core.Event
 	sweepResult  []string
+	cascadeResults []CascadeResult
+	movedEvent   RuneMoved
 	err          error
 }
 
@@ -1827,6 +1853,11 @@ func (tc *handlerTestContext) empty_stream(runeID string) {
 }
 
 func (tc *handlerTestContext) existing_failed_rune_in_stream(runeID, claimant string) {
+	tc.t.Helper()
+	tc.existing_failed_rune_claimed_by_account(runeID, claimant, "")
+}
+
+func (tc *handlerTestContext) existing_failed_rune_claimed_by_account(runeID, claimant, accountID string) {
 	tc.t.Helper()
 	tc.an_event_store()
 	events := []core.Event{
@@ -1837,7 +1868,7 @@ func (tc *handlerTestContext) existing_failed_rune_in_stream(runeID, claimant st
 			ID: runeID,
 		}),
 		makeEvent(EventRuneClaimed, RuneClaimed{
-			ID: runeID, Claimant: claimant,
+			ID: runeID, Claimant: claimant, AccountID: accountID,
 		}),
 		makeEvent(EventRuneFailed, RuneFailed{
 			ID: runeID, Reason: "it failed",
@@ -1898,7 +1929,7 @@ func (tc *handlerTestContext) a_create_rune_command(title, description string, p
 	tc.createCmd = CreateRune{
 		Title:       title,
 		Description: description,
-		Priority:    priority,
+		Priority:    &priority,
 		ParentID:    parentID,
 	}
 }
@@ -2027,66 +2058,79 @@ func (tc *handlerTestContext) state_is_rebuilt() {
 
 func (tc *handlerTestContext) handle_create_rune() {
 	tc.t.Helper()
+	tc.a_store()
 	tc.createdEvent, tc.err = HandleCreateRune(tc.ctx, tc.realmID, tc.createCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_update_rune() {
 	tc.t.Helper()
-	tc.err = HandleUpdateRune(tc.ctx, tc.realmID, tc.updateCmd, tc.eventStore)
+	tc.a_store()
+	tc.err = HandleUpdateRune(tc.ctx, tc.realmID, tc.updateCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_claim_rune() {
 	tc.t.Helper()
-	tc.err = HandleClaimRune(tc.ctx, tc.realmID, tc.claimCmd, tc.eventStore)
+	tc.a_store()
+	tc.err = HandleClaimRune(tc.ctx, tc.realmID, tc.claimCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_unclaim_rune() {
 	tc.t.Helper()
-	tc.err = HandleUnclaimRune(tc.ctx, tc.realmID, tc.unclaimCmd, tc.eventStore)
+	tc.a_store()
+	tc.err = HandleUnclaimRune(tc.ctx, tc.realmID, tc.unclaimCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_fulfill_rune() {
 	tc.t.Helper()
-	tc.err = HandleFulfillRune(tc.ctx, tc.realmID, tc.fulfillCmd, tc.eventStore)
+	tc.a_store()
+	tc.err = HandleFulfillRune(tc.ctx, tc.realmID, tc.fulfillCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_seal_rune() {
 	tc.t.Helper()
-	tc.err = HandleSealRune(tc.ctx, tc.realmID, tc.sealCmd, tc.eventStore)
+	tc.a_store()
+	tc.err = HandleSealRune(tc.ctx, tc.realmID, tc.sealCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_add_dependency() {
 	tc.t.Helper()
+	tc.a_store()
 	tc.err = HandleAddDependency(tc.ctx, tc.realmID, tc.addDepCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_remove_dependency() {
 	tc.t.Helper()
+	tc.a_store()
 	tc.err = HandleRemoveDependency(tc.ctx, tc.realmID, tc.removeDepCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_add_note() {
 	tc.t.Helper()
-	tc.err = HandleAddNote(tc.ctx, tc.realmID, tc.addNoteCmd, tc.eventStore)
+	tc.a_store()
+	tc.err = HandleAddNote(tc.ctx, tc.realmID, tc.addNoteCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_forge_rune() {
 	tc.t.Helper()
+	tc.a_store()
 	tc.err = HandleForgeRune(tc.ctx, tc.realmID, tc.forgeCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_shatter_rune() {
 	tc.t.Helper()
-	tc.err = HandleShatterRune(tc.ctx, tc.realmID, tc.shatterCmd, tc.eventStore)
+	tc.a_store()
+	tc.err = HandleShatterRune(tc.ctx, tc.realmID, tc.shatterCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_reopen_rune() {
 	tc.t.Helper()
-	tc.err = HandleReopenRune(tc.ctx, tc.realmID, tc.reopenCmd, tc.eventStore)
+	tc.a_store()
+	tc.err = HandleReopenRune(tc.ctx, tc.realmID, tc.reopenCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *handlerTestContext) handle_sweep_runes() {
 	tc.t.Helper()
+	tc.a_store()
 	tc.sweepResult, tc.err = HandleSweepRunes(tc.ctx, tc.realmID, tc.eventStore, tc.projectionStore)
 }
 
@@ -2271,6 +2315,14 @@ func (tc *handlerTestContext) appended_event_has_type(eventType string) {
 	assert.True(tc.t, found, "expected event type %q in appended events", eventType)
 }
 
+func (tc *handlerTestContext) reopened_event_is(expected RuneReopened) {
+	tc.t.Helper()
+	require.NotEmpty(tc.t, tc.eventStore.appendedCalls, "expected at least one Append call")
+	lastCall := tc.eventStore.appendedCalls[len(tc.eventStore.appendedCalls)-1]
+	require.Len(tc.t, lastCall.events, 1)
+	assert.Equal(tc.t, expected, lastCall.events[0].Data)
+}
+
 func (tc *handlerTestContext) seal_event_was_appended_to_stream(streamID string) {
 	tc.t.Helper()
 	require.NotEmpty(tc.t, tc.eventStore.appendedCalls, "expected at least one Append call")
@@ -2373,6 +2425,7 @@ type mockEventStore struct {
 	streams       map[string][]core.Event
 	appendedCalls []appendCall
 	appendErr     error
+	appendErrs    map[string]error
 }
 
 func newMockEventStore() *mockEventStore {
@@ -2391,6 +2444,9 @@ func (m *mockEventStore) Append(ctx context.Context, realmID string, streamID st
 	if m.appendErr != nil {
 		return nil, m.appendErr
 	}
+	if err := m.appendErrs[streamID]; err != nil {
+		return nil, err
+	}
 	var result []core.Event
 	for i, ed := range events {
 		dataBytes, _ := json.Marshal(ed.Data)
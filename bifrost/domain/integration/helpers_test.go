@@ -17,6 +17,8 @@ func openTestDB(t *testing.T) *sql.DB {
 	t.Helper()
 	db, err := sql.Open("sqlite", ":memory:")
 	require.NoError(t, err)
+	// Each connection to ":memory:" is a separate database.
+	db.SetMaxOpenConns(1)
 	t.Cleanup(func() { db.Close() })
 	return db
 }
@@ -50,6 +52,10 @@ func newTestStack(t *testing.T) *testStack {
 			projectors.NewDependencyExistenceProjector(),
 			projectors.NewDependencyCycleCheckProjector(),
 			projectors.NewRuneChildCountProjector(),
+			projectors.NewSagaProgressProjector(),
+			projectors.NewRuneAliasProjector(),
+			projectors.NewWIPClaimsProjector(),
+			projectors.NewRuneSearchProjector(),
 		},
 	}
 }
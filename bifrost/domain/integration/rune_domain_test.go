@@ -3,6 +3,8 @@ package integration
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/devzeebo/bifrost/core"
@@ -158,6 +160,53 @@ func TestClaimRune_Sealed(t *testing.T) {
 	})
 }
 
+func TestClaimRune_WIPLimit(t *testing.T) {
+	t.Run("rejects a claim over the claimant limit until an earlier claim is fulfilled", func(t *testing.T) {
+		tc := newIntegrationTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.realm_wip_limits(domain.WIPLimits{PerClaimant: 1})
+		tc.two_existing_runes("First", "Second")
+		tc.claim_specific_rune(tc.runeIDs[0], "odin")
+		require.NoError(t, tc.err)
+		tc.project_all_events()
+
+		// When
+		tc.claim_specific_rune(tc.runeIDs[1], "odin")
+
+		// Then
+		tc.error_contains(`WIP limit reached: claimant "odin" has 1 of 1 claims`)
+
+		// When
+		tc.fulfill_specific_rune(tc.runeIDs[0])
+		require.NoError(t, tc.err)
+		tc.project_all_events()
+		tc.claim_specific_rune(tc.runeIDs[1], "odin")
+
+		// Then
+		tc.no_error()
+	})
+
+	t.Run("admits only the realm limit when claims race the projection", func(t *testing.T) {
+		tc := newIntegrationTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.realm_wip_limits(domain.WIPLimits{PerRealm: 2})
+		tc.existing_runes("First", "Second", "Third", "Fourth", "Fifth")
+		tc.project_all_events()
+
+		// When
+		tc.claim_runes_concurrently()
+
+		// Then
+		tc.successful_claims_are(2)
+		tc.project_all_events()
+		tc.realm_wip_usage_is(2)
+	})
+}
+
 func TestFulfillRune(t *testing.T) {
 	t.Run("fulfills claimed rune, emits RuneFulfilled event", func(t *testing.T) {
 		tc := newIntegrationTestContext(t)
@@ -176,6 +225,65 @@ func TestFulfillRune(t *testing.T) {
 	})
 }
 
+func TestFulfillRune_SagaAutoComplete(t *testing.T) {
+	t.Run("fulfilling the last child fulfills the parent when enabled", func(t *testing.T) {
+		tc := newIntegrationTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.realm_saga_auto_complete("fulfill")
+		tc.an_existing_top_level_rune("Saga", 1)
+		tc.an_existing_claimed_child_rune("Only step", "odin")
+
+		// When
+		tc.fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.rune_stream_has_event_type(tc.parentID, domain.EventRuneFulfilled)
+		tc.project_all_events()
+		tc.saga_progress_has_percent_complete(tc.parentID, 100)
+	})
+}
+
+func TestMoveRune(t *testing.T) {
+	t.Run("moves a claimed child to another saga and keeps projections consistent", func(t *testing.T) {
+		tc := newIntegrationTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_existing_top_level_rune("Wrong saga", 1)
+		tc.an_existing_claimed_child_rune("Misfiled step", "odin")
+		childID := tc.createdEvent.ID
+		oldParentID := tc.parentID
+		tc.an_existing_top_level_rune("Right saga", 1)
+		newParentID := tc.parentID
+		tc.create_child_rune("Existing step", "", 1)
+		require.NoError(t, tc.err)
+		tc.project_all_events()
+
+		// When
+		tc.move_rune(childID, newParentID)
+
+		// Then
+		tc.no_error()
+		tc.project_all_events()
+		assert.Equal(t, newParentID+".2", tc.movedEvent.Alias)
+		tc.rune_list_entry_has_parent_id(childID, newParentID)
+		tc.rune_list_entry_has_status(childID, "claimed")
+		tc.saga_progress_has_total(oldParentID, 0)
+		tc.saga_progress_has_total(newParentID, 2)
+		tc.alias_resolves_to(newParentID+".2", childID)
+
+		// When: a new child is created under the new parent
+		tc.create_child_rune("Next step", "", 1)
+
+		// Then: it does not reuse the moved rune's alias
+		tc.no_error()
+		tc.created_event_has_id(newParentID + ".3")
+	})
+}
+
 func TestFulfillRune_Unclaimed(t *testing.T) {
 	t.Run("returns error when fulfilling unclaimed rune", func(t *testing.T) {
 		tc := newIntegrationTestContext(t)
@@ -544,7 +652,7 @@ func TestRuneRetroProjector_FullLifecycle(t *testing.T) {
 
 		// When: shatter the rune (fulfill first to satisfy shatter precondition)
 		// Note: shatter requires fulfilled or sealed state
-		err := domain.HandleShatterRune(tc.ctx, tc.realmID, domain.ShatterRune{ID: runeID}, tc.stack.EventStore)
+		err := domain.HandleShatterRune(tc.ctx, tc.realmID, domain.ShatterRune{ID: runeID}, tc.stack.EventStore, tc.stack.ProjectionStore)
 		require.NoError(t, err)
 		tc.project_all_events()
 
@@ -556,6 +664,35 @@ func TestRuneRetroProjector_FullLifecycle(t *testing.T) {
 	})
 }
 
+func TestRuneSearchProjector_FullLifecycle(t *testing.T) {
+	t.Run("indexes rune text, notes and retro items for search", func(t *testing.T) {
+		tc := newIntegrationTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.search_is_enabled()
+
+		// When
+		tc.create_top_level_rune("Fix the bridge", "Needs repair", 1)
+		tc.no_error()
+		firstID := tc.createdEvent.ID
+		tc.add_note("Login redirect loops on the bridge page")
+		tc.no_error()
+		tc.create_top_level_rune("Paint the hall", "", 2)
+		tc.no_error()
+		secondID := tc.createdEvent.ID
+		tc.add_retro("Redirects were the hard part")
+		tc.no_error()
+		tc.project_all_events()
+
+		// Then
+		tc.search_finds("bridge", firstID)
+		tc.search_finds("redirect", firstID, secondID)
+		tc.search_finds("paint", secondID)
+		tc.search_finds("tunnel")
+	})
+}
+
 // --- Projector Integration Tests ---
 
 func TestRuneListProjector_FullLifecycle(t *testing.T) {
@@ -817,8 +954,10 @@ type integrationTestContext struct {
 
 	parentID     string
 	createdEvent domain.RuneCreated
+	movedEvent   domain.RuneMoved
 	runeIDs      []string
 	err          error
+	claimErrs    []error
 
 	lastProjectedPosition int
 }
@@ -844,7 +983,7 @@ func (tc *integrationTestContext) an_existing_top_level_rune(title string, prior
 	branch := "test-branch"
 	tc.createdEvent, tc.err = domain.HandleCreateRune(tc.ctx, tc.realmID, domain.CreateRune{
 		Title:    title,
-		Priority: priority,
+		Priority: &priority,
 		Branch:   &branch,
 	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 	require.NoError(tc.t, tc.err)
@@ -859,7 +998,7 @@ func (tc *integrationTestContext) an_existing_sealed_rune(title string, priority
 	tc.an_existing_top_level_rune(title, priority)
 	tc.err = domain.HandleSealRune(tc.ctx, tc.realmID, domain.SealRune{
 		ID: tc.createdEvent.ID, Reason: "sealed for test",
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 	require.NoError(tc.t, tc.err)
 	tc.project_all_events()
 }
@@ -869,32 +1008,62 @@ func (tc *integrationTestContext) an_existing_claimed_rune(title string, priorit
 	tc.an_existing_top_level_rune(title, priority)
 	tc.err = domain.HandleClaimRune(tc.ctx, tc.realmID, domain.ClaimRune{
 		ID: tc.createdEvent.ID, Claimant: claimant,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 	require.NoError(tc.t, tc.err)
 }
 
-func (tc *integrationTestContext) two_existing_runes(titleA, titleB string) {
+func (tc *integrationTestContext) an_existing_claimed_child_rune(title, claimant string) {
 	tc.t.Helper()
-	tc.runeIDs = nil
+	tc.project_all_events()
+	tc.create_child_rune(title, "", 1)
+	require.NoError(tc.t, tc.err)
+	tc.project_all_events()
+	tc.forge_rune()
+	require.NoError(tc.t, tc.err)
+	tc.claim_rune(claimant)
+	require.NoError(tc.t, tc.err)
+	tc.project_all_events()
+}
 
-	branch := "test-branch"
-	evtA, err := domain.HandleCreateRune(tc.ctx, tc.realmID, domain.CreateRune{
-		Title: titleA, Priority: 1, Branch: &branch,
-	}, tc.stack.EventStore, tc.stack.ProjectionStore)
+func (tc *integrationTestContext) realm_saga_auto_complete(mode string) {
+	tc.t.Helper()
+	settings := domain.DefaultRealmSettings(tc.realmID)
+	settings.SagaAutoComplete = mode
+	err := tc.stack.ProjectionStore.Put(tc.ctx, domain.AdminRealmID, "realm_settings", tc.realmID, settings)
 	require.NoError(tc.t, err)
-	tc.runeIDs = append(tc.runeIDs, evtA.ID)
+}
 
-	evtB, err := domain.HandleCreateRune(tc.ctx, tc.realmID, domain.CreateRune{
-		Title: titleB, Priority: 1, Branch: &branch,
-	}, tc.stack.EventStore, tc.stack.ProjectionStore)
+func (tc *integrationTestContext) realm_wip_limits(limits domain.WIPLimits) {
+	tc.t.Helper()
+	settings := domain.DefaultRealmSettings(tc.realmID)
+	settings.WIPLimits = limits
+	err := tc.stack.ProjectionStore.Put(tc.ctx, domain.AdminRealmID, "realm_settings", tc.realmID, settings)
 	require.NoError(tc.t, err)
-	tc.runeIDs = append(tc.runeIDs, evtB.ID)
+}
+
+func (tc *integrationTestContext) two_existing_runes(titleA, titleB string) {
+	tc.t.Helper()
+	tc.existing_runes(titleA, titleB)
+}
+
+func (tc *integrationTestContext) existing_runes(titles ...string) {
+	tc.t.Helper()
+	tc.runeIDs = nil
+
+	branch := "test-branch"
+	for _, title := range titles {
+		evt, err := domain.HandleCreateRune(tc.ctx, tc.realmID, domain.CreateRune{
+			Title: title, Priority: intPtr(1), Branch: &branch,
+		}, tc.stack.EventStore, tc.stack.ProjectionStore)
+		require.NoError(tc.t, err)
+		tc.runeIDs = append(tc.runeIDs, evt.ID)
+	}
 
 	tc.project_all_events()
-	err = domain.HandleForgeRune(tc.ctx, tc.realmID, domain.ForgeRune{ID: evtA.ID}, tc.stack.EventStore, tc.stack.ProjectionStore)
-	require.NoError(tc.t, err)
-	err = domain.HandleForgeRune(tc.ctx, tc.realmID, domain.ForgeRune{ID: evtB.ID}, tc.stack.EventStore, tc.stack.ProjectionStore)
-	require.NoError(tc.t, err)
+	for _, runeID := range tc.runeIDs {
+		err := domain.HandleForgeRune(tc.ctx, tc.realmID, domain.ForgeRune{ID: runeID}, tc.stack.EventStore, tc.stack.ProjectionStore)
+		require.NoError(tc.t, err)
+	}
 }
 
 func (tc *integrationTestContext) store_cycle_detection_entry(sourceID, targetID string) {
@@ -924,7 +1093,7 @@ func (tc *integrationTestContext) create_top_level_rune(title, description strin
 	tc.t.Helper()
 	branch := "test-branch"
 	tc.createdEvent, tc.err = domain.HandleCreateRune(tc.ctx, tc.realmID, domain.CreateRune{
-		Title: title, Description: description, Priority: priority, Branch: &branch,
+		Title: title, Description: description, Priority: &priority, Branch: &branch,
 	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 	if tc.err == nil {
 		tc.parentID = tc.createdEvent.ID
@@ -934,7 +1103,14 @@ func (tc *integrationTestContext) create_top_level_rune(title, description strin
 func (tc *integrationTestContext) create_child_rune(title, description string, priority int) {
 	tc.t.Helper()
 	tc.createdEvent, tc.err = domain.HandleCreateRune(tc.ctx, tc.realmID, domain.CreateRune{
-		Title: title, Description: description, Priority: priority, ParentID: tc.parentID,
+		Title: title, Description: description, Priority: &priority, ParentID: tc.parentID,
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
+}
+
+func (tc *integrationTestContext) move_rune(runeID, parentID string) {
+	tc.t.Helper()
+	tc.movedEvent, tc.err = domain.HandleMoveRune(tc.ctx, tc.realmID, domain.MoveRune{
+		ID: runeID, ParentID: parentID,
 	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 }
 
@@ -942,7 +1118,7 @@ func (tc *integrationTestContext) update_rune(title, description *string, priori
 	tc.t.Helper()
 	tc.err = domain.HandleUpdateRune(tc.ctx, tc.realmID, domain.UpdateRune{
 		ID: tc.createdEvent.ID, Title: title, Description: description, Priority: priority,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 }
 
 func (tc *integrationTestContext) forge_rune() {
@@ -963,35 +1139,53 @@ func (tc *integrationTestContext) claim_rune(claimant string) {
 	tc.t.Helper()
 	tc.err = domain.HandleClaimRune(tc.ctx, tc.realmID, domain.ClaimRune{
 		ID: tc.createdEvent.ID, Claimant: claimant,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 }
 
 func (tc *integrationTestContext) claim_specific_rune(runeID, claimant string) {
 	tc.t.Helper()
 	tc.err = domain.HandleClaimRune(tc.ctx, tc.realmID, domain.ClaimRune{
 		ID: runeID, Claimant: claimant,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
+}
+
+// claim_runes_concurrently claims every rune in tc.runeIDs at once, each by
+// its own claimant, without projecting in between.
+func (tc *integrationTestContext) claim_runes_concurrently() {
+	tc.t.Helper()
+	tc.claimErrs = make([]error, len(tc.runeIDs))
+	var wg sync.WaitGroup
+	for i, runeID := range tc.runeIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tc.claimErrs[i] = domain.HandleClaimRune(tc.ctx, tc.realmID, domain.ClaimRune{
+				ID: runeID, Claimant: fmt.Sprintf("agent-%d", i),
+			}, tc.stack.EventStore, tc.stack.ProjectionStore)
+		}()
+	}
+	wg.Wait()
 }
 
 func (tc *integrationTestContext) fulfill_rune() {
 	tc.t.Helper()
 	tc.err = domain.HandleFulfillRune(tc.ctx, tc.realmID, domain.FulfillRune{
 		ID: tc.createdEvent.ID,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 }
 
 func (tc *integrationTestContext) fulfill_specific_rune(runeID string) {
 	tc.t.Helper()
 	tc.err = domain.HandleFulfillRune(tc.ctx, tc.realmID, domain.FulfillRune{
 		ID: runeID,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 }
 
 func (tc *integrationTestContext) seal_rune(reason string) {
 	tc.t.Helper()
 	tc.err = domain.HandleSealRune(tc.ctx, tc.realmID, domain.SealRune{
 		ID: tc.createdEvent.ID, Reason: reason,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 }
 
 func (tc *integrationTestContext) add_dependency(sourceID, targetID, relationship string) {
@@ -1012,7 +1206,25 @@ func (tc *integrationTestContext) add_note(text string) {
 	tc.t.Helper()
 	tc.err = domain.HandleAddNote(tc.ctx, tc.realmID, domain.AddNote{
 		RuneID: tc.createdEvent.ID, Text: text,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
+}
+
+func (tc *integrationTestContext) search_is_enabled() {
+	tc.t.Helper()
+	searcher, ok := tc.stack.ProjectionStore.(core.TextSearcher)
+	require.True(tc.t, ok)
+	require.NoError(tc.t, searcher.EnableSearch(tc.ctx, projectors.RuneSearchTable.Name, projectors.RuneSearchFields...))
+}
+
+func (tc *integrationTestContext) search_finds(query string, runeIDs ...string) {
+	tc.t.Helper()
+	hits, err := tc.stack.ProjectionStore.(core.TextSearcher).Search(tc.ctx, tc.realmID, projectors.RuneSearchTable.Name, query, 0)
+	require.NoError(tc.t, err)
+	keys := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		keys = append(keys, hit.Key)
+	}
+	assert.ElementsMatch(tc.t, runeIDs, keys, query)
 }
 
 func (tc *integrationTestContext) project_all_events() {
@@ -1032,6 +1244,27 @@ func (tc *integrationTestContext) no_error() {
 	assert.NoError(tc.t, tc.err)
 }
 
+func (tc *integrationTestContext) successful_claims_are(expected int) {
+	tc.t.Helper()
+	succeeded := 0
+	for _, err := range tc.claimErrs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var wipErr *domain.WIPLimitError
+		assert.ErrorAs(tc.t, err, &wipErr)
+	}
+	assert.Equal(tc.t, expected, succeeded)
+}
+
+func (tc *integrationTestContext) realm_wip_usage_is(expected int) {
+	tc.t.Helper()
+	usage, err := domain.ReadWIPUsage(tc.ctx, tc.realmID, tc.stack.ProjectionStore)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, usage.Realm)
+}
+
 func (tc *integrationTestContext) error_contains(substring string) {
 	tc.t.Helper()
 	require.Error(tc.t, tc.err)
@@ -1097,6 +1330,30 @@ func (tc *integrationTestContext) rune_stream_has_event_type(runeID, eventType s
 	assert.True(tc.t, found, "expected event type %q in stream rune-%s", eventType, runeID)
 }
 
+func (tc *integrationTestContext) saga_progress_has_percent_complete(runeID string, expected int) {
+	tc.t.Helper()
+	var progress projectors.SagaProgress
+	err := tc.stack.ProjectionStore.Get(tc.ctx, tc.realmID, "saga_progress", runeID, &progress)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, progress.PercentComplete)
+}
+
+func (tc *integrationTestContext) saga_progress_has_total(runeID string, expected int) {
+	tc.t.Helper()
+	var progress projectors.SagaProgress
+	err := tc.stack.ProjectionStore.Get(tc.ctx, tc.realmID, "saga_progress", runeID, &progress)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, progress.Total)
+}
+
+func (tc *integrationTestContext) alias_resolves_to(alias, expected string) {
+	tc.t.Helper()
+	var entry projectors.RuneAliasEntry
+	err := tc.stack.ProjectionStore.Get(tc.ctx, tc.realmID, "rune_alias", alias, &entry)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, entry.RuneID)
+}
+
 func (tc *integrationTestContext) rune_is_sealed(runeID string) {
 	tc.t.Helper()
 	tc.rune_stream_has_event_type(runeID, domain.EventRuneSealed)
@@ -1152,6 +1409,14 @@ func (tc *integrationTestContext) rune_list_entry_has_status(runeID, expected st
 	assert.Equal(tc.t, expected, summary.Status)
 }
 
+func (tc *integrationTestContext) rune_list_entry_has_parent_id(runeID, expected string) {
+	tc.t.Helper()
+	var summary projectors.RuneSummary
+	err := tc.stack.ProjectionStore.Get(tc.ctx, tc.realmID, "rune_summary", runeID, &summary)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, summary.ParentID)
+}
+
 func (tc *integrationTestContext) rune_list_entry_has_priority(runeID string, expected int) {
 	tc.t.Helper()
 	var summary projectors.RuneSummary
@@ -1407,7 +1672,7 @@ func (tc *integrationTestContext) add_retro(text string) {
 	tc.t.Helper()
 	tc.err = domain.HandleAddRetro(tc.ctx, tc.realmID, domain.AddRetro{
 		RuneID: tc.createdEvent.ID, Text: text,
-	}, tc.stack.EventStore)
+	}, tc.stack.EventStore, tc.stack.ProjectionStore)
 }
 
 func (tc *integrationTestContext) rune_retro_entry_exists(runeID string) {
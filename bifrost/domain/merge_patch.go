@@ -70,12 +70,19 @@ func ParseAndApplyPatch(currentState json.RawMessage, patchJSON string) (map[str
 	return result, nil
 }
 
+// MaxStateSize is the default state size cap; realms can override it with
+// the max_state_size setting.
 const MaxStateSize = 64 * 1024 // 64 KB
 
 // ValidateStateSize checks if the JSON state is within size limits.
 func ValidateStateSize(stateJSON []byte) error {
-	if len(stateJSON) > MaxStateSize {
-		return fmt.Errorf("state size %d bytes exceeds maximum %d bytes", len(stateJSON), MaxStateSize)
+	return ValidateStateSizeLimit(stateJSON, MaxStateSize)
+}
+
+// ValidateStateSizeLimit checks if the JSON state is within the given limit.
+func ValidateStateSizeLimit(stateJSON []byte, limit int) error {
+	if len(stateJSON) > limit {
+		return fmt.Errorf("invalid state: size %d bytes exceeds maximum %d bytes", len(stateJSON), limit)
 	}
 	return nil
 }
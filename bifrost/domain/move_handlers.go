@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// HandleMoveRune changes a rune's parent, or makes it top-level when
+// cmd.ParentID is empty. The rune keeps its ID and stream; it is given a new
+// hierarchical alias under the new parent, which is reserved as its own
+// stream so that child creation cannot hand out the same ID. The reservation
+// is released again if the move itself cannot be recorded. Losing a child may
+// leave the old parent's saga complete, so its auto-complete policy is applied.
+func HandleMoveRune(ctx context.Context, realmID string, cmd MoveRune, store core.EventStore, projStore core.ProjectionStore) (RuneMoved, error) {
+	state, events, err := readAndRebuild(ctx, realmID, cmd.ID, store)
+	if err != nil {
+		return RuneMoved{}, err
+	}
+	if !state.Exists {
+		return RuneMoved{}, &core.NotFoundError{Entity: "rune", ID: cmd.ID}
+	}
+	if err := enforcePolicies(ctx, realmID, "move", cmd.ID, cmd, store, projStore); err != nil {
+		return RuneMoved{}, err
+	}
+	if state.Status == "shattered" {
+		return RuneMoved{}, fmt.Errorf("cannot move shattered rune %q", cmd.ID)
+	}
+	if cmd.ParentID == state.ParentID {
+		if cmd.ParentID == "" {
+			return RuneMoved{}, fmt.Errorf("rune %q is already top-level", cmd.ID)
+		}
+		return RuneMoved{}, fmt.Errorf("rune %q is already a child of %q", cmd.ID, cmd.ParentID)
+	}
+	if cmd.ParentID != "" {
+		if err := validateMoveTarget(ctx, realmID, cmd.ID, cmd.ParentID, store); err != nil {
+			return RuneMoved{}, err
+		}
+	}
+
+	alias, err := reserveMoveAlias(ctx, realmID, cmd.ID, cmd.ParentID, store, projStore)
+	if err != nil {
+		return RuneMoved{}, err
+	}
+
+	moved := RuneMoved{
+		ID:          cmd.ID,
+		OldParentID: state.ParentID,
+		NewParentID: cmd.ParentID,
+		Alias:       alias,
+		Status:      state.Status,
+	}
+	_, err = store.Append(ctx, realmID, runeStreamID(cmd.ID), len(events), []core.EventData{
+		{EventType: EventRuneMoved, Data: moved},
+	})
+	if err != nil {
+		if alias != "" {
+			if releaseErr := releaseMoveAlias(ctx, realmID, alias, cmd.ID, store); releaseErr != nil {
+				return RuneMoved{}, errors.Join(err, releaseErr)
+			}
+		}
+		return RuneMoved{}, err
+	}
+
+	autoCompleteSagaParent(ctx, realmID, cmd.ID, "", state.ParentID, store, projStore)
+	return moved, nil
+}
+
+// releaseMoveAlias records that a reserved alias was not used by the move it
+// was reserved for. The reservation is always the alias stream's only event.
+func releaseMoveAlias(ctx context.Context, realmID, alias, runeID string, store core.EventStore) error {
+	_, err := store.Append(ctx, realmID, runeStreamID(alias), 1, []core.EventData{
+		{EventType: EventRuneAliasReleased, Data: RuneAliasReleased{Alias: alias, RuneID: runeID}},
+	})
+	if err != nil {
+		return fmt.Errorf("release alias %q: %w", alias, err)
+	}
+	return nil
+}
+
+// validateMoveTarget checks that parentID can accept runeID as a child: it
+// must exist, be neither sealed nor shattered, and not be runeID or one of
+// its descendants.
+func validateMoveTarget(ctx context.Context, realmID, runeID, parentID string, store core.EventStore) error {
+	if parentID == runeID {
+		return fmt.Errorf("cannot move rune %q under itself", runeID)
+	}
+	parentState, _, err := readAndRebuild(ctx, realmID, parentID, store)
+	if err != nil {
+		return err
+	}
+	if !parentState.Exists {
+		return &core.NotFoundError{Entity: "rune", ID: parentID}
+	}
+	if parentState.Status == "sealed" {
+		return fmt.Errorf("cannot move rune under sealed rune %q", parentID)
+	}
+	if parentState.Status == "shattered" {
+		return fmt.Errorf("cannot move rune under shattered rune %q", parentID)
+	}
+
+	seen := map[string]bool{parentID: true}
+	for ancestorID := parentState.ParentID; ancestorID != "" && !seen[ancestorID]; {
+		if ancestorID == runeID {
+			return fmt.Errorf("cannot move rune %q under its own descendant %q", runeID, parentID)
+		}
+		seen[ancestorID] = true
+		ancestor, _, err := readAndRebuild(ctx, realmID, ancestorID, store)
+		if err != nil {
+			return err
+		}
+		ancestorID = ancestor.ParentID
+	}
+	return nil
+}
+
+// reserveMoveAlias picks the rune's alias under parentID and claims its
+// stream. Moving a rune back to the position encoded in its own ID needs no
+// alias, and moving to top level takes a fresh top-level ID.
+func reserveMoveAlias(ctx context.Context, realmID, runeID, parentID string, store core.EventStore, projStore core.ProjectionStore) (string, error) {
+	if parentID == originalParentID(runeID) {
+		return "", nil
+	}
+
+	next := 1
+	if parentID != "" {
+		var entry struct {
+			Count int `json:"count"`
+		}
+		err := projStore.Get(ctx, realmID, "rune_child_count", parentID, &entry)
+		if err != nil && !isNotFoundError(err) {
+			return "", err
+		}
+		next = entry.Count + 1
+	}
+
+	const maxRetries = 10
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		var alias string
+		if parentID != "" {
+			alias = fmt.Sprintf("%s.%d", parentID, next+attempt)
+		} else {
+			var err error
+			alias, err = generateRuneID()
+			if err != nil {
+				return "", err
+			}
+		}
+
+		_, err := store.Append(ctx, realmID, runeStreamID(alias), 0, []core.EventData{
+			{EventType: EventRuneAliasReserved, Data: RuneAliasReserved{Alias: alias, RuneID: runeID}},
+		})
+		if err == nil {
+			return alias, nil
+		}
+		var concErr *core.ConcurrencyError
+		if !errors.As(err, &concErr) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("cannot move rune %q: no free alias under %q", runeID, parentID)
+}
+
+// originalParentID returns the parent encoded in a rune ID, e.g.
+// "bf-a1b2.3" -> "bf-a1b2", or "" for a top-level ID.
+func originalParentID(runeID string) string {
+	lastDot := strings.LastIndex(runeID, ".")
+	if lastDot == -1 {
+		return ""
+	}
+	return runeID[:lastDot]
+}
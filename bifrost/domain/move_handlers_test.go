@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestHandleMoveRune(t *testing.T) {
+	t.Run("moves a child to another saga with the next alias", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-a", "open")
+		tc.existing_rune_in_stream("bf-b", "open")
+		tc.existing_child_rune_in_stream("bf-a.1", "bf-a", "claimed")
+		tc.returns_child_count("bf-b", 2)
+		tc.a_move_command("bf-a.1", "bf-b")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.no_error()
+		tc.moved_event_is(RuneMoved{ID: "bf-a.1", OldParentID: "bf-a", NewParentID: "bf-b", Alias: "bf-b.3", Status: "claimed"})
+		tc.events_appended_to_stream("rune-bf-b.3", EventRuneAliasReserved)
+		tc.events_appended_to_stream("rune-bf-a.1", EventRuneMoved)
+		assert.Equal(t, 3, tc.append_call_for_stream("rune-bf-a.1").expectedVersion)
+	})
+
+	t.Run("releases the reserved alias when the move cannot be recorded", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-a", "open")
+		tc.existing_rune_in_stream("bf-b", "open")
+		tc.existing_child_rune_in_stream("bf-a.1", "bf-a", "claimed")
+		tc.returns_child_count("bf-b", 2)
+		tc.append_to_stream_fails("rune-bf-a.1", &core.ConcurrencyError{StreamID: "rune-bf-a.1", ExpectedVersion: 3, ActualVersion: 4})
+		tc.a_move_command("bf-a.1", "bf-b")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		var concErr *core.ConcurrencyError
+		assert.ErrorAs(t, tc.err, &concErr)
+		tc.alias_stream_events_are("rune-bf-b.3", EventRuneAliasReserved, EventRuneAliasReleased)
+	})
+
+	t.Run("moving to top level assigns a fresh top-level alias", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_child_rune_in_stream("bf-a.1", "bf-a", "open")
+		tc.a_move_command("bf-a.1", "")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.no_error()
+		assert.Regexp(t, `^bf-[0-9a-f]{4}$`, tc.movedEvent.Alias)
+		assert.Empty(t, tc.movedEvent.NewParentID)
+		tc.events_appended_to_stream("rune-"+tc.movedEvent.Alias, EventRuneAliasReserved)
+	})
+
+	t.Run("moving back to the original position clears the alias", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-a", "open")
+		tc.existing_rune_in_stream("bf-b", "open")
+		tc.existing_child_rune_in_stream("bf-a.1", "bf-a", "open")
+		tc.rune_was_moved("bf-a.1", "bf-a", "bf-b", "bf-b.1")
+		tc.a_move_command("bf-a.1", "bf-a")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.no_error()
+		tc.moved_event_is(RuneMoved{ID: "bf-a.1", OldParentID: "bf-b", NewParentID: "bf-a", Status: "open"})
+		assert.Len(t, tc.eventStore.appendedCalls, 1)
+	})
+
+	t.Run("rejects moving under a descendant", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-a", "open")
+		tc.existing_child_rune_in_stream("bf-a.1", "bf-a", "open")
+		tc.existing_child_rune_in_stream("bf-a.1.1", "bf-a.1", "open")
+		tc.a_move_command("bf-a", "bf-a.1.1")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.error_contains("cannot move rune \"bf-a\" under its own descendant")
+		assert.Empty(t, tc.eventStore.appendedCalls)
+	})
+
+	t.Run("rejects moving under a sealed rune", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-a", "open")
+		tc.existing_rune_in_stream("bf-b", "sealed")
+		tc.a_move_command("bf-a", "bf-b")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.error_contains("cannot move rune under sealed rune")
+	})
+
+	t.Run("rejects a move to the current parent", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-a", "open")
+		tc.a_move_command("bf-a", "")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.error_contains("already top-level")
+	})
+
+	t.Run("returns not found for missing parent", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-a", "open")
+		tc.a_move_command("bf-a", "bf-nope")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.error_is_not_found("rune", "bf-nope")
+	})
+}
+
+func TestRebuildRuneState_RuneMoved(t *testing.T) {
+	t.Run("tracks the new parent and alias", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.events = []core.Event{
+			makeEvent(EventRuneCreated, RuneCreated{ID: "bf-a.1", ParentID: "bf-a"}),
+			makeEvent(EventRuneMoved, RuneMoved{ID: "bf-a.1", OldParentID: "bf-a", NewParentID: "bf-b", Alias: "bf-b.4"}),
+		}
+
+		// When
+		tc.state_is_rebuilt()
+
+		// Then
+		assert.Equal(t, "bf-b", tc.state.ParentID)
+		assert.Equal(t, "bf-b.4", tc.state.Alias)
+	})
+}
+
+// --- Given ---
+
+func (tc *handlerTestContext) a_move_command(id, parentID string) {
+	tc.t.Helper()
+	tc.moveCmd = MoveRune{ID: id, ParentID: parentID}
+}
+
+func (tc *handlerTestContext) rune_was_moved(runeID, oldParentID, newParentID, alias string) {
+	tc.t.Helper()
+	tc.eventStore.streams["rune-"+runeID] = append(tc.eventStore.streams["rune-"+runeID],
+		makeEvent(EventRuneMoved, RuneMoved{ID: runeID, OldParentID: oldParentID, NewParentID: newParentID, Alias: alias}))
+}
+
+// --- When ---
+
+func (tc *handlerTestContext) handle_move_rune() {
+	tc.t.Helper()
+	tc.a_store()
+	tc.movedEvent, tc.err = HandleMoveRune(tc.ctx, tc.realmID, tc.moveCmd, tc.eventStore, tc.projectionStore)
+}
+
+// --- Then ---
+
+func (tc *handlerTestContext) alias_stream_events_are(streamID string, eventTypes ...string) {
+	tc.t.Helper()
+	var actual []string
+	for _, call := range tc.eventStore.appendedCalls {
+		if call.streamID != streamID {
+			continue
+		}
+		for _, evt := range call.events {
+			actual = append(actual, evt.EventType)
+		}
+	}
+	assert.Equal(tc.t, eventTypes, actual)
+}
+
+func (tc *handlerTestContext) moved_event_is(expected RuneMoved) {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+	assert.Equal(tc.t, expected, tc.movedEvent)
+}
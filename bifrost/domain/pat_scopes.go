@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PATScopes narrows what a personal access token may do. Scopes only ever
+// restrict the owning account's own grants: an empty field is unrestricted.
+// Realms lists the realms the token may address, MaxRole caps the role it
+// acts with, and Commands lists the API endpoints (e.g. "claim-rune",
+// "runes") it may call.
+type PATScopes struct {
+	Realms   []string `json:"realms,omitempty"`
+	MaxRole  string   `json:"max_role,omitempty"`
+	Commands []string `json:"commands,omitempty"`
+}
+
+// IsEmpty reports whether the scopes impose no restriction.
+func (s *PATScopes) IsEmpty() bool {
+	return s == nil || (len(s.Realms) == 0 && s.MaxRole == "" && len(s.Commands) == 0)
+}
+
+// AllowsRealm reports whether the token may address realmID.
+func (s *PATScopes) AllowsRealm(realmID string) bool {
+	if s == nil || len(s.Realms) == 0 {
+		return true
+	}
+	return slices.Contains(s.Realms, realmID)
+}
+
+// AllowsCommand reports whether the token may call the named endpoint.
+func (s *PATScopes) AllowsCommand(command string) bool {
+	if s == nil || len(s.Commands) == 0 {
+		return true
+	}
+	return slices.Contains(s.Commands, command)
+}
+
+// CapRole returns role lowered to MaxRole when the token is capped below it.
+func (s *PATScopes) CapRole(role string) string {
+	if s == nil || s.MaxRole == "" {
+		return role
+	}
+	if RoleLevel(role) > RoleLevel(s.MaxRole) {
+		return s.MaxRole
+	}
+	return role
+}
+
+// AllowsPermission reports whether a token capped at MaxRole may use perm.
+// The cap also applies to custom roles, which have no level to compare.
+func (s *PATScopes) AllowsPermission(perm string) bool {
+	if s == nil || s.MaxRole == "" {
+		return true
+	}
+	return slices.Contains(builtinRolePermissions[s.MaxRole], perm)
+}
+
+func (s PATScopes) validate() error {
+	if s.MaxRole != "" && !IsValidRole(s.MaxRole) {
+		return fmt.Errorf("invalid scopes: unknown max_role %q", s.MaxRole)
+	}
+	for _, realmID := range s.Realms {
+		if realmID == "" {
+			return fmt.Errorf("invalid scopes: realm must not be empty")
+		}
+	}
+	for _, command := range s.Commands {
+		if command == "" {
+			return fmt.Errorf("invalid scopes: command must not be empty")
+		}
+	}
+	return nil
+}
+
+// normalized returns the scopes with entries trimmed, lowercased where
+// case-insensitive, and de-duplicated, or nil when nothing is restricted.
+func (s *PATScopes) normalized() *PATScopes {
+	if s.IsEmpty() {
+		return nil
+	}
+	return &PATScopes{
+		Realms:   dedupe(s.Realms, strings.TrimSpace),
+		MaxRole:  strings.ToLower(strings.TrimSpace(s.MaxRole)),
+		Commands: dedupe(s.Commands, func(c string) string { return strings.ToLower(strings.TrimSpace(c)) }),
+	}
+}
+
+func dedupe(values []string, normalize func(string) string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = normalize(v)
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// --- Tests ---
+
+func TestPATScopes(t *testing.T) {
+	t.Run("nil scopes allow everything", func(t *testing.T) {
+		tc := newPATScopesTestContext(t)
+
+		// Given
+		tc.scopes_are(nil)
+
+		// Then
+		tc.scopes_are_empty(true)
+		tc.realm_is_allowed("realm-1", true)
+		tc.command_is_allowed("claim-rune", true)
+		tc.role_is_capped(RoleOwner, RoleOwner)
+	})
+
+	t.Run("realm scope allows only listed realms", func(t *testing.T) {
+		tc := newPATScopesTestContext(t)
+
+		// Given
+		tc.scopes_are(&PATScopes{Realms: []string{"realm-1"}})
+
+		// Then
+		tc.scopes_are_empty(false)
+		tc.realm_is_allowed("realm-1", true)
+		tc.realm_is_allowed("realm-2", false)
+		tc.realm_is_allowed("_admin", false)
+	})
+
+	t.Run("max role caps higher roles and keeps lower ones", func(t *testing.T) {
+		tc := newPATScopesTestContext(t)
+
+		// Given
+		tc.scopes_are(&PATScopes{MaxRole: RoleMember})
+
+		// Then
+		tc.role_is_capped(RoleOwner, RoleMember)
+		tc.role_is_capped(RoleAdmin, RoleMember)
+		tc.role_is_capped(RoleMember, RoleMember)
+		tc.role_is_capped(RoleViewer, RoleViewer)
+	})
+
+	t.Run("max role limits permissions to its bundle", func(t *testing.T) {
+		tc := newPATScopesTestContext(t)
+
+		// Given
+		tc.scopes_are(&PATScopes{MaxRole: RoleViewer})
+
+		// Then
+		tc.permission_is_allowed(PermViewRunes, true)
+		tc.permission_is_allowed(PermAddNote, false)
+	})
+
+	t.Run("command scope allows only listed endpoints", func(t *testing.T) {
+		tc := newPATScopesTestContext(t)
+
+		// Given
+		tc.scopes_are(&PATScopes{Commands: []string{"runes", "claim-rune"}})
+
+		// Then
+		tc.command_is_allowed("claim-rune", true)
+		tc.command_is_allowed("runes", true)
+		tc.command_is_allowed("create-rune", false)
+	})
+}
+
+// --- Test Context ---
+
+type patScopesTestContext struct {
+	t      *testing.T
+	scopes *PATScopes
+}
+
+func newPATScopesTestContext(t *testing.T) *patScopesTestContext {
+	t.Helper()
+	return &patScopesTestContext{t: t}
+}
+
+// --- Given ---
+
+func (tc *patScopesTestContext) scopes_are(scopes *PATScopes) {
+	tc.t.Helper()
+	tc.scopes = scopes
+}
+
+// --- Then ---
+
+func (tc *patScopesTestContext) scopes_are_empty(expected bool) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.scopes.IsEmpty())
+}
+
+func (tc *patScopesTestContext) realm_is_allowed(realmID string, expected bool) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.scopes.AllowsRealm(realmID), "realm %q", realmID)
+}
+
+func (tc *patScopesTestContext) command_is_allowed(command string, expected bool) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.scopes.AllowsCommand(command), "command %q", command)
+}
+
+func (tc *patScopesTestContext) role_is_capped(role, expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.scopes.CapRole(role), "role %q", role)
+}
+
+func (tc *patScopesTestContext) permission_is_allowed(perm string, expected bool) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.scopes.AllowsPermission(perm), "permission %q", perm)
+}
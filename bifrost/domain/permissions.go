@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// Permissions are named after the API command they allow. The two view
+// permissions cover the read-only queries.
+const (
+	PermViewRunes = "view-runes"
+	PermViewRealm = "view-realm"
+
+	PermCreateRune       = "create-rune"
+	PermUpdateRune       = "update-rune"
+	PermClaimRune        = "claim-rune"
+	PermUnclaimRune      = "unclaim-rune"
+	PermFulfillRune      = "fulfill-rune"
+	PermSealRune         = "seal-rune"
+	PermFailRune         = "fail-rune"
+	PermReopenRune       = "reopen-rune"
+	PermForgeRune        = "forge-rune"
+	PermAddDependency    = "add-dependency"
+	PermRemoveDependency = "remove-dependency"
+	PermAddNote          = "add-note"
+	PermAddRetro         = "add-retro"
+	PermAddAC            = "add-ac"
+	PermUpdateAC         = "update-ac"
+	PermRemoveAC         = "remove-ac"
+	PermVerifyAC         = "verify-ac"
+	PermUpdateRuneState  = "update-rune-state"
+	PermClearRuneState   = "clear-rune-state"
+	PermShatterRune      = "shatter-rune"
+	PermMoveRune         = "move-rune"
+	PermSweepRunes       = "sweep-runes"
+
+	PermAssignRole          = "assign-role"
+	PermRevokeRole          = "revoke-role"
+	PermUpdateRealmSettings = "update-realm-settings"
+	PermSetPolicy           = "set-policy"
+	PermRemovePolicy        = "remove-policy"
+	PermDefineRole          = "define-role"
+	PermDeleteRole          = "delete-role"
+	PermAddWebhook          = "add-webhook"
+	PermRemoveWebhook       = "remove-webhook"
+	PermTestWebhook         = "test-webhook"
+
+	PermCreateRealm        = "create-realm"
+	PermSuspendRealm       = "suspend-realm"
+	PermRenameRealm        = "rename-realm"
+	PermReactivateRealm    = "reactivate-realm"
+	PermArchiveRealm       = "archive-realm"
+	PermDeleteRealm        = "delete-realm"
+	PermListRealms         = "list-realms"
+	PermRebuildProjections = "rebuild-projections"
+	PermResolveUsername    = "resolve-username"
+)
+
+var viewerPermissions = []string{PermViewRunes, PermViewRealm}
+
+var runePermissions = []string{
+	PermCreateRune, PermUpdateRune, PermClaimRune, PermUnclaimRune, PermFulfillRune,
+	PermSealRune, PermFailRune, PermReopenRune, PermForgeRune, PermAddDependency,
+	PermRemoveDependency, PermAddNote, PermAddRetro, PermAddAC, PermUpdateAC,
+	PermRemoveAC, PermVerifyAC, PermUpdateRuneState, PermClearRuneState,
+	PermShatterRune, PermMoveRune, PermSweepRunes,
+}
+
+var realmAdminPermissions = []string{
+	PermAssignRole, PermRevokeRole, PermUpdateRealmSettings, PermSetPolicy,
+	PermRemovePolicy, PermDefineRole, PermDeleteRole, PermAddWebhook,
+	PermRemoveWebhook, PermTestWebhook,
+}
+
+// systemPermissions only apply in the _admin realm, where custom roles
+// cannot be defined.
+var systemPermissions = []string{
+	PermCreateRealm, PermSuspendRealm, PermRenameRealm, PermReactivateRealm,
+	PermArchiveRealm, PermDeleteRealm, PermListRealms, PermRebuildProjections, PermResolveUsername,
+}
+
+// RealmPermissions lists the permissions a custom realm role may hold.
+var RealmPermissions = slices.Concat(viewerPermissions, runePermissions, realmAdminPermissions)
+
+// builtinRolePermissions maps each built-in role to its permission bundle.
+var builtinRolePermissions = map[string][]string{
+	RoleViewer: viewerPermissions,
+	RoleMember: slices.Concat(viewerPermissions, runePermissions),
+	RoleAdmin:  slices.Concat(RealmPermissions, systemPermissions),
+	RoleOwner:  slices.Concat(RealmPermissions, systemPermissions),
+}
+
+// BuiltinRolePermissions returns the permissions of a built-in role, or nil
+// for any other role.
+func BuiltinRolePermissions(role string) []string {
+	return slices.Clone(builtinRolePermissions[role])
+}
+
+// CustomRole is a realm-defined role granting a set of permissions.
+type CustomRole struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// RealmRoles is the custom role set for a realm as projected into realm_roles.
+type RealmRoles struct {
+	RealmID string       `json:"realm_id"`
+	Roles   []CustomRole `json:"roles"`
+}
+
+// Find returns the custom role with the given name.
+func (r RealmRoles) Find(name string) (CustomRole, bool) {
+	for _, role := range r.Roles {
+		if role.Name == name {
+			return role, true
+		}
+	}
+	return CustomRole{}, false
+}
+
+// ApplyCustomRoleDefined adds the role from a CustomRoleDefined event,
+// replacing any role with the same name in place.
+func ApplyCustomRoleDefined(roles RealmRoles, data CustomRoleDefined) RealmRoles {
+	role := CustomRole{Name: data.Name, Permissions: data.Permissions}
+	updated := make([]CustomRole, 0, len(roles.Roles)+1)
+	replaced := false
+	for _, existing := range roles.Roles {
+		if existing.Name == data.Name {
+			updated = append(updated, role)
+			replaced = true
+			continue
+		}
+		updated = append(updated, existing)
+	}
+	if !replaced {
+		updated = append(updated, role)
+	}
+	roles.Roles = updated
+	return roles
+}
+
+// ApplyCustomRoleDeleted drops the role named in a CustomRoleDeleted event.
+func ApplyCustomRoleDeleted(roles RealmRoles, data CustomRoleDeleted) RealmRoles {
+	updated := make([]CustomRole, 0, len(roles.Roles))
+	for _, existing := range roles.Roles {
+		if existing.Name != data.Name {
+			updated = append(updated, existing)
+		}
+	}
+	roles.Roles = updated
+	return roles
+}
+
+func rebuildRealmRoles(realmID string, events []core.Event) RealmRoles {
+	roles := RealmRoles{RealmID: realmID, Roles: []CustomRole{}}
+	for _, evt := range events {
+		switch evt.EventType {
+		case EventCustomRoleDefined:
+			var data CustomRoleDefined
+			_ = json.Unmarshal(evt.Data, &data)
+			roles = ApplyCustomRoleDefined(roles, data)
+		case EventCustomRoleDeleted:
+			var data CustomRoleDeleted
+			_ = json.Unmarshal(evt.Data, &data)
+			roles = ApplyCustomRoleDeleted(roles, data)
+		}
+	}
+	return roles
+}
+
+// ReadRealmRoles loads the projected custom roles for a realm. A realm
+// without custom roles yields an empty set.
+func ReadRealmRoles(ctx context.Context, realmID string, projStore core.ProjectionStore) (RealmRoles, error) {
+	var roles RealmRoles
+	err := projStore.Get(ctx, AdminRealmID, "realm_roles", realmID, &roles)
+	if err != nil {
+		if isNotFoundError(err) {
+			return RealmRoles{RealmID: realmID, Roles: []CustomRole{}}, nil
+		}
+		return RealmRoles{}, fmt.Errorf("read realm roles: %w", err)
+	}
+	return roles, nil
+}
+
+// RolePermissions returns the permissions role holds in realmID: the bundle
+// of a built-in role, or the permission set of a custom role defined in the
+// realm. An unknown role holds no permissions.
+func RolePermissions(ctx context.Context, realmID, role string, projStore core.ProjectionStore) ([]string, error) {
+	if perms, ok := builtinRolePermissions[role]; ok {
+		return perms, nil
+	}
+	roles, err := ReadRealmRoles(ctx, realmID, projStore)
+	if err != nil {
+		return nil, err
+	}
+	custom, _ := roles.Find(role)
+	return custom.Permissions, nil
+}
+
+// requireAssignableRole checks that role is built in or defined in realmID.
+func requireAssignableRole(ctx context.Context, realmID, role string, projStore core.ProjectionStore) error {
+	if IsValidRole(role) {
+		return nil
+	}
+	if realmID != AdminRealmID && projStore != nil {
+		roles, err := ReadRealmRoles(ctx, realmID, projStore)
+		if err != nil {
+			return err
+		}
+		if _, ok := roles.Find(role); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid role %q", role)
+}
+
+func HandleDefineCustomRole(ctx context.Context, cmd DefineCustomRole, store core.EventStore) error {
+	if cmd.RealmID == AdminRealmID {
+		return fmt.Errorf("cannot define roles in the %s realm", AdminRealmID)
+	}
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if cmd.Name == "" || strings.ContainsAny(cmd.Name, " \t\n") {
+		return fmt.Errorf("invalid role name %q: must be non-empty with no whitespace", cmd.Name)
+	}
+	if IsValidRole(cmd.Name) {
+		return fmt.Errorf("invalid role name %q: built-in roles cannot be redefined", cmd.Name)
+	}
+	if len(cmd.Permissions) == 0 {
+		return fmt.Errorf("invalid role %q: at least one permission is required", cmd.Name)
+	}
+	perms := dedupe(cmd.Permissions, func(p string) string { return strings.ToLower(strings.TrimSpace(p)) })
+	for _, perm := range perms {
+		if !slices.Contains(RealmPermissions, perm) {
+			return fmt.Errorf("unknown permission %q", perm)
+		}
+	}
+
+	defined := CustomRoleDefined{RealmID: cmd.RealmID, Name: cmd.Name, Permissions: perms}
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventCustomRoleDefined, Data: defined},
+	})
+	return err
+}
+
+func HandleDeleteCustomRole(ctx context.Context, cmd DeleteCustomRole, store core.EventStore) error {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if _, ok := rebuildRealmRoles(cmd.RealmID, events).Find(cmd.Name); !ok {
+		return fmt.Errorf("unknown role %q", cmd.Name)
+	}
+
+	deleted := CustomRoleDeleted(cmd)
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventCustomRoleDeleted, Data: deleted},
+	})
+	return err
+}
@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestBuiltinRolePermissions(t *testing.T) {
+	t.Run("each built-in role extends the one below it", func(t *testing.T) {
+		viewer := BuiltinRolePermissions(RoleViewer)
+		member := BuiltinRolePermissions(RoleMember)
+		admin := BuiltinRolePermissions(RoleAdmin)
+
+		assert.Subset(t, member, viewer)
+		assert.Subset(t, admin, member)
+		assert.ElementsMatch(t, admin, BuiltinRolePermissions(RoleOwner))
+		assert.NotContains(t, viewer, PermCreateRune)
+		assert.NotContains(t, member, PermAssignRole)
+		assert.Contains(t, admin, PermCreateRealm)
+	})
+
+	t.Run("returns nil for a custom role", func(t *testing.T) {
+		assert.Nil(t, BuiltinRolePermissions("triager"))
+	})
+}
+
+func TestRolePermissions(t *testing.T) {
+	t.Run("resolves a custom role defined in the realm", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.data["_admin:realm_roles:bf-a1b2"] = RealmRoles{RealmID: "bf-a1b2", Roles: []CustomRole{
+			{Name: "triager", Permissions: []string{PermViewRunes, PermAddNote}},
+		}}
+
+		perms, err := RolePermissions(context.Background(), "bf-a1b2", "triager", store)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{PermViewRunes, PermAddNote}, perms)
+	})
+
+	t.Run("an unknown role has no permissions", func(t *testing.T) {
+		perms, err := RolePermissions(context.Background(), "bf-a1b2", "ghost", newMockProjectionStore())
+
+		require.NoError(t, err)
+		assert.Empty(t, perms)
+	})
+}
+
+func TestHandleDefineCustomRole(t *testing.T) {
+	t.Run("appends CustomRoleDefined with normalized permissions", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_define_role_command("bf-a1b2", "triager", "view-runes", " Add-Note ", "view-runes")
+
+		// When
+		tc.handle_define_role()
+
+		// Then
+		tc.no_realm_error()
+		tc.realm_event_was_appended_to_stream("realm-bf-a1b2")
+		tc.appended_realm_event_has_type(EventCustomRoleDefined)
+		defined := tc.eventStore.appendedCalls[0].events[0].Data.(CustomRoleDefined)
+		assert.Equal(t, []string{PermViewRunes, PermAddNote}, defined.Permissions)
+	})
+
+	t.Run("rejects redefining a built-in role", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_define_role_command("bf-a1b2", RoleMember, "view-runes")
+
+		// When
+		tc.handle_define_role()
+
+		// Then
+		tc.realm_error_contains("built-in roles cannot be redefined")
+	})
+
+	t.Run("rejects unknown permissions", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_define_role_command("bf-a1b2", "triager", "teleport")
+
+		// When
+		tc.handle_define_role()
+
+		// Then
+		tc.realm_error_contains(`unknown permission "teleport"`)
+	})
+
+	t.Run("rejects system permissions", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_define_role_command("bf-a1b2", "realm-maker", PermCreateRealm)
+
+		// When
+		tc.handle_define_role()
+
+		// Then
+		tc.realm_error_contains(`unknown permission "create-realm"`)
+	})
+
+	t.Run("requires at least one permission", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_define_role_command("bf-a1b2", "nobody")
+
+		// When
+		tc.handle_define_role()
+
+		// Then
+		tc.realm_error_contains("at least one permission")
+	})
+
+	t.Run("rejects defining roles in the admin realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.a_define_role_command("_admin", "triager", "view-runes")
+
+		// When
+		tc.handle_define_role()
+
+		// Then
+		tc.realm_error_contains("cannot define roles")
+	})
+
+	t.Run("returns not found for missing realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.empty_realm_stream("bf-missing")
+		tc.a_define_role_command("bf-missing", "triager", "view-runes")
+
+		// When
+		tc.handle_define_role()
+
+		// Then
+		tc.realm_error_is_not_found("realm", "bf-missing")
+	})
+}
+
+func TestHandleDeleteCustomRole(t *testing.T) {
+	t.Run("appends CustomRoleDeleted for an existing role", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.realm_has_custom_role_in_stream("bf-a1b2", "triager")
+		tc.a_delete_role_command("bf-a1b2", "triager")
+
+		// When
+		tc.handle_delete_role()
+
+		// Then
+		tc.no_realm_error()
+		tc.appended_realm_event_has_type(EventCustomRoleDeleted)
+	})
+
+	t.Run("rejects unknown role", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_delete_role_command("bf-a1b2", "missing")
+
+		// When
+		tc.handle_delete_role()
+
+		// Then
+		tc.realm_error_contains(`unknown role "missing"`)
+	})
+}
+
+func TestRequireAssignableRole(t *testing.T) {
+	store := newMockProjectionStore()
+	store.data["_admin:realm_roles:bf-a1b2"] = RealmRoles{RealmID: "bf-a1b2", Roles: []CustomRole{
+		{Name: "triager", Permissions: []string{PermViewRunes}},
+	}}
+
+	t.Run("accepts built-in roles", func(t *testing.T) {
+		assert.NoError(t, requireAssignableRole(context.Background(), "bf-a1b2", RoleViewer, store))
+	})
+
+	t.Run("accepts a custom role defined in the realm", func(t *testing.T) {
+		assert.NoError(t, requireAssignableRole(context.Background(), "bf-a1b2", "triager", store))
+	})
+
+	t.Run("rejects a custom role from another realm", func(t *testing.T) {
+		err := requireAssignableRole(context.Background(), "bf-c3d4", "triager", store)
+
+		assert.EqualError(t, err, `invalid role "triager"`)
+	})
+}
+
+func TestApplyCustomRoleDefined(t *testing.T) {
+	t.Run("replaces a role with the same name in place", func(t *testing.T) {
+		roles := RealmRoles{RealmID: "realm-1"}
+		roles = ApplyCustomRoleDefined(roles, CustomRoleDefined{Name: "a", Permissions: []string{PermViewRunes}})
+		roles = ApplyCustomRoleDefined(roles, CustomRoleDefined{Name: "b", Permissions: []string{PermViewRunes}})
+
+		roles = ApplyCustomRoleDefined(roles, CustomRoleDefined{Name: "a", Permissions: []string{PermAddNote}})
+
+		require.Len(t, roles.Roles, 2)
+		assert.Equal(t, "a", roles.Roles[0].Name)
+		assert.Equal(t, []string{PermAddNote}, roles.Roles[0].Permissions)
+	})
+}
+
+// --- Given ---
+
+func (tc *realmHandlerTestContext) a_define_role_command(realmID, name string, permissions ...string) {
+	tc.t.Helper()
+	tc.defineRoleCmd = DefineCustomRole{RealmID: realmID, Name: name, Permissions: permissions}
+}
+
+func (tc *realmHandlerTestContext) a_delete_role_command(realmID, name string) {
+	tc.t.Helper()
+	tc.deleteRoleCmd = DeleteCustomRole{RealmID: realmID, Name: name}
+}
+
+func (tc *realmHandlerTestContext) realm_has_custom_role_in_stream(realmID, name string) {
+	tc.t.Helper()
+	stream := "realm-" + realmID
+	tc.eventStore.streams[stream] = append(tc.eventStore.streams[stream],
+		makeEvent(EventCustomRoleDefined, CustomRoleDefined{RealmID: realmID, Name: name, Permissions: []string{PermViewRunes}}))
+}
+
+// --- When ---
+
+func (tc *realmHandlerTestContext) handle_define_role() {
+	tc.t.Helper()
+	tc.err = HandleDefineCustomRole(tc.ctx, tc.defineRoleCmd, tc.eventStore)
+}
+
+func (tc *realmHandlerTestContext) handle_delete_role() {
+	tc.t.Helper()
+	tc.err = HandleDeleteCustomRole(tc.ctx, tc.deleteRoleCmd, tc.eventStore)
+}
@@ -0,0 +1,347 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// PolicyActions lists the rune commands a policy can gate. A policy with no
+// actions applies to all of them.
+var PolicyActions = []string{
+	"create", "update", "claim", "unclaim", "forge", "fulfill", "seal", "fail",
+	"reopen", "shatter", "move", "add-dependency", "remove-dependency", "note",
+	"retro", "add-ac", "update-ac", "remove-ac", "verify-ac", "update-state",
+	"clear-state",
+}
+
+// Policy is a realm-scoped rule evaluated before a rune command appends
+// events. The command is rejected unless Expression evaluates to true.
+type Policy struct {
+	Name       string   `json:"name"`
+	Actions    []string `json:"actions,omitempty"`
+	Expression string   `json:"expression"`
+	Message    string   `json:"message,omitempty"`
+}
+
+func (p Policy) appliesTo(action string) bool {
+	return len(p.Actions) == 0 || slices.Contains(p.Actions, action)
+}
+
+// RealmPolicies is the ordered policy set for a realm as projected into realm_policies.
+type RealmPolicies struct {
+	RealmID  string   `json:"realm_id"`
+	Policies []Policy `json:"policies"`
+}
+
+// ApplyPolicySet adds the policy from a PolicySet event, replacing any policy
+// with the same name in place.
+func ApplyPolicySet(policies RealmPolicies, data PolicySet) RealmPolicies {
+	policy := Policy{Name: data.Name, Actions: data.Actions, Expression: data.Expression, Message: data.Message}
+	updated := make([]Policy, 0, len(policies.Policies)+1)
+	replaced := false
+	for _, existing := range policies.Policies {
+		if existing.Name == data.Name {
+			updated = append(updated, policy)
+			replaced = true
+			continue
+		}
+		updated = append(updated, existing)
+	}
+	if !replaced {
+		updated = append(updated, policy)
+	}
+	policies.Policies = updated
+	return policies
+}
+
+// ApplyPolicyRemoved drops the policy named in a PolicyRemoved event.
+func ApplyPolicyRemoved(policies RealmPolicies, data PolicyRemoved) RealmPolicies {
+	updated := make([]Policy, 0, len(policies.Policies))
+	for _, existing := range policies.Policies {
+		if existing.Name != data.Name {
+			updated = append(updated, existing)
+		}
+	}
+	policies.Policies = updated
+	return policies
+}
+
+func rebuildRealmPolicies(realmID string, events []core.Event) RealmPolicies {
+	policies := RealmPolicies{RealmID: realmID, Policies: []Policy{}}
+	for _, evt := range events {
+		switch evt.EventType {
+		case EventPolicySet:
+			var data PolicySet
+			_ = json.Unmarshal(evt.Data, &data)
+			policies = ApplyPolicySet(policies, data)
+		case EventPolicyRemoved:
+			var data PolicyRemoved
+			_ = json.Unmarshal(evt.Data, &data)
+			policies = ApplyPolicyRemoved(policies, data)
+		}
+	}
+	return policies
+}
+
+// ReadRealmPolicies loads the projected policy set for a realm. A realm
+// without policies yields an empty set.
+func ReadRealmPolicies(ctx context.Context, realmID string, projStore core.ProjectionStore) (RealmPolicies, error) {
+	var policies RealmPolicies
+	err := projStore.Get(ctx, AdminRealmID, "realm_policies", realmID, &policies)
+	if err != nil {
+		if isNotFoundError(err) {
+			return RealmPolicies{RealmID: realmID, Policies: []Policy{}}, nil
+		}
+		return RealmPolicies{}, fmt.Errorf("read realm policies: %w", err)
+	}
+	return policies, nil
+}
+
+func HandleSetPolicy(ctx context.Context, cmd SetPolicy, store core.EventStore) error {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if cmd.Name == "" || strings.ContainsAny(cmd.Name, " \t\n") {
+		return fmt.Errorf("invalid policy name %q: must be non-empty with no whitespace", cmd.Name)
+	}
+	for _, action := range cmd.Actions {
+		if !slices.Contains(PolicyActions, action) {
+			return fmt.Errorf("unknown policy action %q", action)
+		}
+	}
+	if _, err := compilePolicyExpr(cmd.Expression); err != nil {
+		return fmt.Errorf("invalid policy expression: %v", err)
+	}
+
+	set := PolicySet(cmd)
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventPolicySet, Data: set},
+	})
+	return err
+}
+
+func HandleRemovePolicy(ctx context.Context, cmd RemovePolicy, store core.EventStore) error {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	found := slices.ContainsFunc(rebuildRealmPolicies(cmd.RealmID, events).Policies, func(p Policy) bool {
+		return p.Name == cmd.Name
+	})
+	if !found {
+		return fmt.Errorf("unknown policy %q", cmd.Name)
+	}
+
+	removed := PolicyRemoved(cmd)
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventPolicyRemoved, Data: removed},
+	})
+	return err
+}
+
+// PolicyViolationError reports the realm policy that rejected a command.
+type PolicyViolationError struct {
+	Policy  string
+	Message string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("policy %q rejected the command: %s", e.Policy, e.Message)
+}
+
+// PolicyInput describes a rune command for policy evaluation. RuneID is empty
+// for commands that do not act on an existing rune, such as create.
+type PolicyInput struct {
+	Action  string
+	RuneID  string
+	Command any
+	Role    string
+}
+
+type policyRoleContextKey struct{}
+
+// WithPolicyRole returns a context recording the caller's realm role, which
+// policy expressions see as role.
+func WithPolicyRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, policyRoleContextKey{}, role)
+}
+
+// PolicyRoleFromContext returns the role recorded by WithPolicyRole.
+func PolicyRoleFromContext(ctx context.Context) string {
+	role, _ := ctx.Value(policyRoleContextKey{}).(string)
+	return role
+}
+
+// enforcePolicies is called by every rune command handler before it appends
+// events, so that commands issued internally (cascades, sweeps, saga
+// auto-completion) are gated the same way as direct requests. The caller's
+// role comes from the context. Without a projection store there are no
+// policies to read.
+func enforcePolicies(ctx context.Context, realmID, action, runeID string, cmd any, store core.EventStore, projStore core.ProjectionStore) error {
+	if projStore == nil {
+		return nil
+	}
+	return EvaluatePolicies(ctx, realmID, PolicyInput{
+		Action:  action,
+		RuneID:  runeID,
+		Command: cmd,
+		Role:    PolicyRoleFromContext(ctx),
+	}, store, projStore)
+}
+
+// EvaluatePolicies checks a command against the realm's policies that apply
+// to its action, returning a *PolicyViolationError for the first one that
+// does not hold. An expression that fails to evaluate counts as a rejection.
+//
+// Expressions see four variables: action, role, command (the request body)
+// and rune (the current rune, with note, retro and AC counts).
+func EvaluatePolicies(ctx context.Context, realmID string, input PolicyInput, store core.EventStore, projStore core.ProjectionStore) error {
+	policies, err := ReadRealmPolicies(ctx, realmID, projStore)
+	if err != nil {
+		return err
+	}
+	var applicable []Policy
+	for _, policy := range policies.Policies {
+		if policy.appliesTo(input.Action) {
+			applicable = append(applicable, policy)
+		}
+	}
+	if len(applicable) == 0 {
+		return nil
+	}
+
+	env, err := policyEnv(ctx, realmID, input, store)
+	if err != nil {
+		return err
+	}
+	for _, policy := range applicable {
+		expr, err := cachedPolicyExpr(realmID, policy)
+		if err != nil {
+			return &PolicyViolationError{Policy: policy.Name, Message: err.Error()}
+		}
+		ok, err := evalPolicyBool(expr, env)
+		if err != nil {
+			return &PolicyViolationError{Policy: policy.Name, Message: err.Error()}
+		}
+		if !ok {
+			msg := policy.Message
+			if msg == "" {
+				msg = policy.Expression
+			}
+			return &PolicyViolationError{Policy: policy.Name, Message: msg}
+		}
+	}
+	return nil
+}
+
+// compiledPolicies caches compiled expressions per realm and policy name, so
+// that rune commands do not re-parse them. An entry is replaced when the
+// policy's expression changes.
+var compiledPolicies sync.Map // policyCacheKey -> compiledPolicy
+
+type policyCacheKey struct {
+	realmID string
+	name    string
+}
+
+type compiledPolicy struct {
+	expression string
+	expr       policyExpr
+	err        error
+}
+
+// cachedPolicyExpr returns the compiled expression for policy, compiling it on
+// first use or after its expression changed. Expressions are validated when a
+// policy is set, so a compile error here only comes from older events.
+func cachedPolicyExpr(realmID string, policy Policy) (policyExpr, error) {
+	key := policyCacheKey{realmID: realmID, name: policy.Name}
+	if v, ok := compiledPolicies.Load(key); ok {
+		if cached := v.(compiledPolicy); cached.expression == policy.Expression {
+			return cached.expr, cached.err
+		}
+	}
+	expr, err := compilePolicyExpr(policy.Expression)
+	compiledPolicies.Store(key, compiledPolicy{expression: policy.Expression, expr: expr, err: err})
+	return expr, err
+}
+
+func policyEnv(ctx context.Context, realmID string, input PolicyInput, store core.EventStore) (map[string]any, error) {
+	command := map[string]any{}
+	if input.Command != nil {
+		raw, err := json.Marshal(input.Command)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &command); err != nil {
+			return nil, err
+		}
+	}
+
+	runeView := map[string]any{"exists": false}
+	if input.RuneID != "" {
+		state, events, err := readAndRebuild(ctx, realmID, input.RuneID, store)
+		if err != nil {
+			return nil, err
+		}
+		runeView = policyRuneView(state, events)
+	}
+
+	return map[string]any{
+		"action":  input.Action,
+		"role":    input.Role,
+		"command": command,
+		"rune":    runeView,
+	}, nil
+}
+
+// policyRuneView exposes a rune's state in the JSON shape expressions use.
+func policyRuneView(state RuneState, events []core.Event) map[string]any {
+	tags := make([]any, 0, len(state.Tags))
+	for _, tag := range state.Tags {
+		tags = append(tags, tag)
+	}
+	var notes, retros int
+	for _, evt := range events {
+		switch evt.EventType {
+		case EventRuneNoted:
+			notes++
+		case EventRuneRetroed:
+			retros++
+		}
+	}
+	return map[string]any{
+		"exists":              state.Exists,
+		"id":                  state.ID,
+		"title":               state.Title,
+		"description":         state.Description,
+		"status":              state.Status,
+		"priority":            float64(state.Priority),
+		"claimant":            state.Claimant,
+		"parent_id":           state.ParentID,
+		"branch":              state.Branch,
+		"type":                state.Type,
+		"tags":                tags,
+		"state":               state.State,
+		"note_count":          float64(notes),
+		"retro_count":         float64(retros),
+		"ac_count":            float64(len(acStatuses(events))),
+		"unverified_ac_count": float64(len(unverifiedACs(events))),
+	}
+}
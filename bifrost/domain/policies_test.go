@@ -0,0 +1,327 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestHandleSetPolicy(t *testing.T) {
+	t.Run("appends PolicySet to the realm stream", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_set_policy_command("bf-a1b2", "retro-before-fulfill", `rune.retro_count > 0`, "fulfill")
+
+		// When
+		tc.handle_set_policy()
+
+		// Then
+		tc.no_realm_error()
+		tc.realm_event_was_appended_to_stream("realm-bf-a1b2")
+		tc.appended_realm_event_has_type(EventPolicySet)
+	})
+
+	t.Run("rejects an expression that does not compile", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_set_policy_command("bf-a1b2", "broken", `rune.status ==`, "claim")
+
+		// When
+		tc.handle_set_policy()
+
+		// Then
+		tc.realm_error_contains("invalid policy expression")
+	})
+
+	t.Run("rejects unknown actions", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_set_policy_command("bf-a1b2", "p", `true`, "teleport")
+
+		// When
+		tc.handle_set_policy()
+
+		// Then
+		tc.realm_error_contains(`unknown policy action "teleport"`)
+	})
+
+	t.Run("rejects an empty name", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_set_policy_command("bf-a1b2", "", `true`)
+
+		// When
+		tc.handle_set_policy()
+
+		// Then
+		tc.realm_error_contains("invalid policy name")
+	})
+
+	t.Run("returns not found for missing realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.empty_realm_stream("bf-missing")
+		tc.a_set_policy_command("bf-missing", "p", `true`)
+
+		// When
+		tc.handle_set_policy()
+
+		// Then
+		tc.realm_error_is_not_found("realm", "bf-missing")
+	})
+}
+
+func TestHandleRemovePolicy(t *testing.T) {
+	t.Run("appends PolicyRemoved for an existing policy", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.realm_has_policy_in_stream("bf-a1b2", "tagged-claims")
+		tc.a_remove_policy_command("bf-a1b2", "tagged-claims")
+
+		// When
+		tc.handle_remove_policy()
+
+		// Then
+		tc.no_realm_error()
+		tc.appended_realm_event_has_type(EventPolicyRemoved)
+	})
+
+	t.Run("rejects unknown policy", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_remove_policy_command("bf-a1b2", "missing")
+
+		// When
+		tc.handle_remove_policy()
+
+		// Then
+		tc.realm_error_contains(`unknown policy "missing"`)
+	})
+}
+
+func TestApplyPolicySet(t *testing.T) {
+	t.Run("replaces a policy with the same name in place", func(t *testing.T) {
+		policies := RealmPolicies{RealmID: "realm-1"}
+		policies = ApplyPolicySet(policies, PolicySet{Name: "a", Expression: "true"})
+		policies = ApplyPolicySet(policies, PolicySet{Name: "b", Expression: "true"})
+
+		policies = ApplyPolicySet(policies, PolicySet{Name: "a", Expression: "false"})
+
+		require.Len(t, policies.Policies, 2)
+		assert.Equal(t, "a", policies.Policies[0].Name)
+		assert.Equal(t, "false", policies.Policies[0].Expression)
+	})
+}
+
+func TestEvaluatePolicies(t *testing.T) {
+	t.Run("allows the command when no policy applies", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "claimed")
+		tc.realm_policy("claim-needs-tag", `"ready" in rune.tags`, "claim")
+
+		// When
+		tc.evaluate_policies(PolicyInput{Action: "fulfill", RuneID: "bf-a1b2"})
+
+		// Then
+		tc.no_error()
+	})
+
+	t.Run("rejects with the failing policy's name and message", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "claimed")
+		tc.realm_policy_with_message("retro-before-fulfill", `rune.retro_count > 0`, "add a retro before fulfilling", "fulfill")
+
+		// When
+		tc.evaluate_policies(PolicyInput{Action: "fulfill", RuneID: "bf-a1b2", Command: FulfillRune{ID: "bf-a1b2"}})
+
+		// Then
+		tc.policy_violation_is("retro-before-fulfill", "add a retro before fulfilling")
+	})
+
+	t.Run("evaluates the command body and caller role", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_policy("p0-needs-description", `role == "admin" || command.priority != 0 || len(command.description) > 0`, "create")
+
+		// When
+		tc.evaluate_policies(PolicyInput{Action: "create", Command: CreateRune{Title: "Outage", Priority: intPtr(0)}, Role: "member"})
+
+		// Then
+		tc.policy_violation_is("p0-needs-description", "len(command.description) > 0")
+	})
+
+	t.Run("treats an evaluation error as a rejection", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_policy("bad-types", `command.title > 3`)
+
+		// When
+		tc.evaluate_policies(PolicyInput{Action: "create", Command: CreateRune{Title: "x"}})
+
+		// Then
+		tc.policy_violation_is("bad-types", "cannot compare")
+	})
+	t.Run("rune commands are checked with the role recorded on the context", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_policy("admins-claim", `role == "admin"`, "claim")
+		tc.ctx = WithPolicyRole(tc.ctx, "member")
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.policy_violation_is("admins-claim", `role == "admin"`)
+		assert.Empty(t, tc.eventStore.appendedCalls)
+	})
+
+	t.Run("reuses the compiled expression across evaluations", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-policy-cache")
+		tc.a_store()
+		tc.realm_policy("titled", `len(command.title) > 0`, "create")
+		tc.evaluate_policies(PolicyInput{Action: "create", Command: CreateRune{Title: "first"}})
+		compiled := tc.cached_policy_expr("titled")
+
+		// When
+		tc.evaluate_policies(PolicyInput{Action: "create", Command: CreateRune{Title: "second"}})
+
+		// Then
+		tc.no_error()
+		assert.Same(t, compiled, tc.cached_policy_expr("titled"))
+	})
+
+	t.Run("recompiles a policy whose expression changed", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-policy-change")
+		tc.a_store()
+		tc.realm_policy("gate", `true`, "create")
+		tc.evaluate_policies(PolicyInput{Action: "create", Command: CreateRune{Title: "x"}})
+		tc.no_error()
+		tc.realm_policy_expression_changes("gate", `false`)
+
+		// When
+		tc.evaluate_policies(PolicyInput{Action: "create", Command: CreateRune{Title: "x"}})
+
+		// Then
+		tc.policy_violation_is("gate", "false")
+	})
+}
+
+// --- Given ---
+
+func (tc *realmHandlerTestContext) a_set_policy_command(realmID, name, expression string, actions ...string) {
+	tc.t.Helper()
+	tc.setPolicyCmd = SetPolicy{RealmID: realmID, Name: name, Expression: expression, Actions: actions}
+}
+
+func (tc *realmHandlerTestContext) a_remove_policy_command(realmID, name string) {
+	tc.t.Helper()
+	tc.removePolicyCmd = RemovePolicy{RealmID: realmID, Name: name}
+}
+
+func (tc *realmHandlerTestContext) realm_has_policy_in_stream(realmID, name string) {
+	tc.t.Helper()
+	stream := "realm-" + realmID
+	tc.eventStore.streams[stream] = append(tc.eventStore.streams[stream],
+		makeEvent(EventPolicySet, PolicySet{RealmID: realmID, Name: name, Expression: "true"}))
+}
+
+func (tc *handlerTestContext) realm_policy(name, expression string, actions ...string) {
+	tc.t.Helper()
+	tc.realm_policy_with_message(name, expression, "", actions...)
+}
+
+func (tc *handlerTestContext) realm_policy_with_message(name, expression, message string, actions ...string) {
+	tc.t.Helper()
+	tc.a_store()
+	key := "_admin:realm_policies:" + tc.realmID
+	policies, _ := tc.projectionStore.data[key].(RealmPolicies)
+	policies.RealmID = tc.realmID
+	policies.Policies = append(policies.Policies, Policy{
+		Name: name, Actions: actions, Expression: expression, Message: message,
+	})
+	tc.projectionStore.data[key] = policies
+}
+
+func (tc *handlerTestContext) realm_policy_expression_changes(name, expression string) {
+	tc.t.Helper()
+	key := "_admin:realm_policies:" + tc.realmID
+	policies := tc.projectionStore.data[key].(RealmPolicies)
+	policies = ApplyPolicySet(policies, PolicySet{RealmID: tc.realmID, Name: name, Expression: expression})
+	tc.projectionStore.data[key] = policies
+}
+
+// --- When ---
+
+func (tc *realmHandlerTestContext) handle_set_policy() {
+	tc.t.Helper()
+	tc.err = HandleSetPolicy(tc.ctx, tc.setPolicyCmd, tc.eventStore)
+}
+
+func (tc *realmHandlerTestContext) handle_remove_policy() {
+	tc.t.Helper()
+	tc.err = HandleRemovePolicy(tc.ctx, tc.removePolicyCmd, tc.eventStore)
+}
+
+func (tc *handlerTestContext) evaluate_policies(input PolicyInput) {
+	tc.t.Helper()
+	tc.an_event_store()
+	tc.err = EvaluatePolicies(tc.ctx, tc.realmID, input, tc.eventStore, tc.projectionStore)
+}
+
+// --- Then ---
+
+func (tc *handlerTestContext) policy_violation_is(policy, messageSubstr string) {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+	var violation *PolicyViolationError
+	require.True(tc.t, errors.As(tc.err, &violation), "expected PolicyViolationError, got %T: %v", tc.err, tc.err)
+	assert.Equal(tc.t, policy, violation.Policy)
+	assert.Contains(tc.t, violation.Message, messageSubstr)
+}
+
+func (tc *handlerTestContext) cached_policy_expr(name string) policyExpr {
+	tc.t.Helper()
+	v, ok := compiledPolicies.Load(policyCacheKey{realmID: tc.realmID, name: name})
+	require.True(tc.t, ok, "expected a cached expression for policy %q", name)
+	return v.(compiledPolicy).expr
+}
@@ -0,0 +1,574 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// policyExpr is a compiled policy expression. Expressions are evaluated
+// against an environment of JSON-shaped values: strings, float64 numbers,
+// bools, nil, []any and map[string]any.
+//
+// The grammar is deliberately small:
+//
+//	expr    := or
+//	or      := and ("||" and)*
+//	and     := compare ("&&" compare)*
+//	compare := unary (("==" | "!=" | "<" | "<=" | ">" | ">=" | "in") unary)?
+//	unary   := "!" unary | "-" unary | primary
+//	primary := literal | path | call | "(" expr ")" | "[" (expr ("," expr)*)? "]"
+//	path    := ident ("." ident)*
+//	call    := ident "(" expr ")"        // len(x) is the only function
+//
+// Comparisons do not chain, so "a < b < c" is a compile error. Strings are
+// quoted with " or ' and accept the escapes \\, \", \', \n and \t.
+type policyExpr interface {
+	eval(env map[string]any) (any, error)
+}
+
+const (
+	// maxPolicyExprLength bounds the source of a policy expression.
+	maxPolicyExprLength = 4096
+	// maxPolicyExprDepth bounds how deeply groups, lists, calls and unary
+	// operators may nest, keeping parsing and evaluation recursion shallow.
+	maxPolicyExprDepth = 32
+)
+
+// compilePolicyExpr parses src into an expression tree.
+func compilePolicyExpr(src string) (policyExpr, error) {
+	if len(src) > maxPolicyExprLength {
+		return nil, fmt.Errorf("expression is longer than %d characters", maxPolicyExprLength)
+	}
+	tokens, err := tokenizePolicyExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &policyParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return expr, nil
+}
+
+// evalPolicyBool evaluates expr and requires a boolean result.
+func evalPolicyBool(expr policyExpr, env map[string]any) (bool, error) {
+	v, err := expr.eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must evaluate to a bool, got %s", policyTypeName(v))
+	}
+	return b, nil
+}
+
+// --- Tokens ---
+
+type policyTokenKind int
+
+const (
+	tokEOF policyTokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+type policyToken struct {
+	kind policyTokenKind
+	text string
+	pos  int
+}
+
+var policyStringEscapes = map[byte]byte{'\\': '\\', '"': '"', '\'': '\'', 'n': '\n', 't': '\t'}
+
+var policyOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "-", "(", ")", "[", "]", ",", "."}
+
+func tokenizePolicyExpr(src string) ([]policyToken, error) {
+	var tokens []policyToken
+	i := 0
+	for i < len(src) {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for i < len(src) && rune(src[i]) != c {
+				if src[i] == '\\' {
+					if i+1 >= len(src) {
+						i = len(src)
+						break
+					}
+					escaped, ok := policyStringEscapes[src[i+1]]
+					if !ok {
+						return nil, fmt.Errorf("invalid escape \\%c at position %d", src[i+1], i)
+					}
+					sb.WriteByte(escaped)
+					i += 2
+					continue
+				}
+				sb.WriteByte(src[i])
+				i++
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, policyToken{kind: tokString, text: sb.String(), pos: start})
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, policyToken{kind: tokNumber, text: src[start:i], pos: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i])) || src[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, policyToken{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			matched := false
+			for _, op := range policyOperators {
+				if strings.HasPrefix(src[i:], op) {
+					tokens = append(tokens, policyToken{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+		}
+	}
+	return append(tokens, policyToken{kind: tokEOF, pos: len(src)}), nil
+}
+
+// --- Parser ---
+
+type policyParser struct {
+	tokens []policyToken
+	pos    int
+	depth  int
+}
+
+// enter records one more level of nesting, failing past maxPolicyExprDepth.
+// Callers defer p.leave() on success.
+func (p *policyParser) enter(pos int) error {
+	p.depth++
+	if p.depth > maxPolicyExprDepth {
+		return fmt.Errorf("expression nested more than %d levels deep at position %d", maxPolicyExprDepth, pos)
+	}
+	return nil
+}
+
+func (p *policyParser) leave() {
+	p.depth--
+}
+
+func (p *policyParser) peek() policyToken {
+	return p.tokens[p.pos]
+}
+
+func (p *policyParser) next() policyToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *policyParser) acceptOp(op string) bool {
+	if tok := p.peek(); tok.kind == tokOp && tok.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *policyParser) expectOp(op string) error {
+	if !p.acceptOp(op) {
+		tok := p.peek()
+		if tok.kind == tokEOF {
+			return fmt.Errorf("expected %q at end of expression", op)
+		}
+		return fmt.Errorf("expected %q at position %d, got %q", op, tok.pos, tok.text)
+	}
+	return nil
+}
+
+func (p *policyParser) parseOr() (policyExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *policyParser) parseAnd() (policyExpr, error) {
+	left, err := p.parseCompare()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp("&&") {
+		right, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *policyParser) parseCompare() (policyExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	if !isPolicyCompareOp(tok) {
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &compareExpr{op: tok.text, left: left, right: right}, nil
+}
+
+func isPolicyCompareOp(tok policyToken) bool {
+	if tok.kind == tokIdent {
+		return tok.text == "in"
+	}
+	switch tok.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return tok.kind == tokOp
+	}
+	return false
+}
+
+func (p *policyParser) parseUnary() (policyExpr, error) {
+	if tok := p.peek(); tok.kind == tokOp && (tok.text == "!" || tok.text == "-") {
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+	}
+	if p.acceptOp("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{operand: operand}, nil
+	}
+	if p.acceptOp("-") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negateExpr{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *policyParser) parsePrimary() (policyExpr, error) {
+	tok := p.next()
+	if tok.kind == tokOp && (tok.text == "(" || tok.text == "[") {
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+	}
+	switch tok.kind {
+	case tokString:
+		return literalExpr{value: tok.text}, nil
+	case tokNumber:
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return literalExpr{value: n}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return literalExpr{value: true}, nil
+		case "false":
+			return literalExpr{value: false}, nil
+		case "null":
+			return literalExpr{value: nil}, nil
+		}
+		if p.acceptOp("(") {
+			return p.parseCall(tok)
+		}
+		path := []string{tok.text}
+		for p.acceptOp(".") {
+			field := p.next()
+			if field.kind != tokIdent {
+				return nil, fmt.Errorf("expected field name at position %d", field.pos)
+			}
+			path = append(path, field.text)
+		}
+		return pathExpr{path: path}, nil
+	case tokOp:
+		switch tok.text {
+		case "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return inner, p.expectOp(")")
+		case "[":
+			list := &listExpr{}
+			if p.acceptOp("]") {
+				return list, nil
+			}
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+				if p.acceptOp("]") {
+					return list, nil
+				}
+				if err := p.expectOp(","); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func (p *policyParser) parseCall(name policyToken) (policyExpr, error) {
+	if name.text != "len" {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	if err := p.enter(name.pos); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+	return &lenExpr{arg: arg}, nil
+}
+
+// --- Nodes ---
+
+type literalExpr struct{ value any }
+
+func (e literalExpr) eval(map[string]any) (any, error) { return e.value, nil }
+
+// pathExpr looks up a dotted path. Missing keys evaluate to nil so that
+// policies can test optional command fields.
+type pathExpr struct{ path []string }
+
+func (e pathExpr) eval(env map[string]any) (any, error) {
+	root, ok := env[e.path[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown identifier %q", e.path[0])
+	}
+	current := root
+	for _, field := range e.path[1:] {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		current = m[field]
+	}
+	return current, nil
+}
+
+type listExpr struct{ items []policyExpr }
+
+func (e *listExpr) eval(env map[string]any) (any, error) {
+	values := make([]any, 0, len(e.items))
+	for _, item := range e.items {
+		v, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+type notExpr struct{ operand policyExpr }
+
+func (e *notExpr) eval(env map[string]any) (any, error) {
+	b, err := evalPolicyBool(e.operand, env)
+	if err != nil {
+		return nil, err
+	}
+	return !b, nil
+}
+
+type negateExpr struct{ operand policyExpr }
+
+func (e *negateExpr) eval(env map[string]any) (any, error) {
+	v, err := e.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("cannot negate %s", policyTypeName(v))
+	}
+	return -n, nil
+}
+
+type logicalExpr struct {
+	op          string
+	left, right policyExpr
+}
+
+func (e *logicalExpr) eval(env map[string]any) (any, error) {
+	left, err := evalPolicyBool(e.left, env)
+	if err != nil {
+		return nil, err
+	}
+	if e.op == "&&" && !left {
+		return false, nil
+	}
+	if e.op == "||" && left {
+		return true, nil
+	}
+	return evalPolicyBool(e.right, env)
+}
+
+type compareExpr struct {
+	op          string
+	left, right policyExpr
+}
+
+func (e *compareExpr) eval(env map[string]any) (any, error) {
+	left, err := e.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch e.op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	case "in":
+		switch container := right.(type) {
+		case []any:
+			for _, item := range container {
+				if reflect.DeepEqual(left, item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case string:
+			s, ok := left.(string)
+			if !ok {
+				return nil, fmt.Errorf("cannot test %s in string", policyTypeName(left))
+			}
+			return strings.Contains(container, s), nil
+		case map[string]any:
+			s, ok := left.(string)
+			if !ok {
+				return nil, fmt.Errorf("cannot test %s in map", policyTypeName(left))
+			}
+			_, found := container[s]
+			return found, nil
+		case nil:
+			return false, nil
+		}
+		return nil, fmt.Errorf("cannot test membership in %s", policyTypeName(right))
+	}
+
+	if l, ok := left.(float64); ok {
+		if r, ok := right.(float64); ok {
+			return compareOrdered(e.op, l, r), nil
+		}
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			return compareOrdered(e.op, l, r), nil
+		}
+	}
+	return nil, fmt.Errorf("cannot compare %s %s %s", policyTypeName(left), e.op, policyTypeName(right))
+}
+
+func compareOrdered[T float64 | string](op string, l, r T) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	}
+	return l >= r
+}
+
+type lenExpr struct{ arg policyExpr }
+
+func (e *lenExpr) eval(env map[string]any) (any, error) {
+	v, err := e.arg.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch x := v.(type) {
+	case nil:
+		return float64(0), nil
+	case string:
+		return float64(len(x)), nil
+	case []any:
+		return float64(len(x)), nil
+	case map[string]any:
+		return float64(len(x)), nil
+	}
+	return nil, fmt.Errorf("len() does not accept %s", policyTypeName(v))
+}
+
+func policyTypeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "map"
+	}
+	return fmt.Sprintf("%T", v)
+}
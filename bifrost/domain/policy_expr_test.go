@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyExpr(t *testing.T) {
+	env := map[string]any{
+		"action": "fulfill",
+		"role":   "member",
+		"command": map[string]any{
+			"priority": float64(0),
+			"title":    "Fix login",
+		},
+		"rune": map[string]any{
+			"status":      "claimed",
+			"tags":        []any{"backend", "urgent"},
+			"retro_count": float64(2),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected bool
+	}{
+		{name: "string equality", expr: `action == "fulfill"`, expected: true},
+		{name: "inequality", expr: `role != 'admin'`, expected: true},
+		{name: "number comparison", expr: `rune.retro_count >= 2`, expected: true},
+		{name: "negative number", expr: `command.priority > -1`, expected: true},
+		{name: "membership in list field", expr: `"urgent" in rune.tags`, expected: true},
+		{name: "membership in literal list", expr: `role in ["admin", "owner"]`, expected: false},
+		{name: "substring", expr: `"login" in command.title`, expected: true},
+		{name: "missing field is null", expr: `command.description == null`, expected: true},
+		{name: "len of missing field is zero", expr: `len(command.description) == 0`, expected: true},
+		{name: "short-circuit or", expr: `command.priority != 0 || len(command.description) > 0`, expected: false},
+		{name: "negation and grouping", expr: `!(rune.status == "open" && true)`, expected: true},
+		{name: "and binds tighter than or", expr: `false && false || true`, expected: true},
+		{name: "and binds tighter than or on the right", expr: `true || false && false`, expected: true},
+		{name: "or is left associative", expr: `false || false || true`, expected: true},
+		{name: "and is left associative", expr: `true && true && false`, expected: false},
+		{name: "comparison binds tighter than and", expr: `rune.retro_count > 1 && role == "member"`, expected: true},
+		{name: "not binds tighter than comparison", expr: `!true == false`, expected: true},
+		{name: "double negation", expr: `!!true`, expected: true},
+		{name: "negation of negative number", expr: `--2 == 2`, expected: true},
+		{name: "grouping overrides precedence", expr: `false && (false || true)`, expected: false},
+		{name: "decimal number", expr: `1.5 > 1`, expected: true},
+		{name: "escaped double quote", expr: `"say \"hi\"" == 'say "hi"'`, expected: true},
+		{name: "escaped single quote", expr: `'it\'s' == "it's"`, expected: true},
+		{name: "escaped backslash", expr: `len("a\\b") == 3`, expected: true},
+		{name: "newline and tab escapes", expr: `len("\n\t") == 2`, expected: true},
+		{name: "membership in map keys", expr: `"status" in rune`, expected: true},
+		{name: "missing key in map", expr: `"nope" in rune`, expected: false},
+		{name: "membership of number in list", expr: `2 in [1, 2, 3]`, expected: true},
+		{name: "membership in empty list", expr: `"x" in []`, expected: false},
+		{name: "membership in missing field", expr: `"x" in command.labels`, expected: false},
+		{name: "list equality", expr: `rune.tags == ["backend", "urgent"]`, expected: true},
+		{name: "values of different types are not equal", expr: `command.priority == "0"`, expected: false},
+		{name: "null equals null", expr: `null == null`, expected: true},
+		{name: "nested missing field is null", expr: `command.meta.owner == null`, expected: true},
+		{name: "field of a non-map is null", expr: `rune.status.length == null`, expected: true},
+		{name: "string ordering", expr: `"a" < "b"`, expected: true},
+		{name: "len of list", expr: `len(rune.tags) == 2`, expected: true},
+		{name: "len of map", expr: `len(command) == 2`, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := compilePolicyExpr(tt.expr)
+			require.NoError(t, err)
+
+			result, err := evalPolicyBool(expr, env)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPolicyExprErrors(t *testing.T) {
+	t.Run("compile errors", func(t *testing.T) {
+		for _, src := range []string{
+			``, `action ==`, `(true`, `"open`, `action = "x"`, `size(rune.tags) > 1`, `[1, 2`,
+			`1.2.3 == 1`, `1..2 == 1`, `"bad \q escape"`, `"trailing \`, `1 < 2 < 3`, `a == b == c`,
+			`rune.`, `rune.1`, `len()`, `len(1, 2)`, `true false`, `@`, `[1,]`, `)`,
+		} {
+			_, err := compilePolicyExpr(src)
+			assert.Error(t, err, "expected compile error for %q", src)
+		}
+	})
+
+	t.Run("evaluation errors", func(t *testing.T) {
+		env := map[string]any{"rune": map[string]any{"priority": float64(1), "tags": []any{"a"}}}
+		for _, src := range []string{
+			`unknown == 1`, `rune.priority`, `rune.priority < "high"`, `!rune.priority`,
+			`true && rune.priority`, `rune.priority || true`, `-rune.tags == 1`, `rune.tags < 2`,
+			`null < 1`, `1 in "abc"`, `1 in rune`, `"a" in rune.priority`, `len(rune.priority) == 1`,
+			`true < false`, `1 == 1 && 1 > "0"`,
+		} {
+			expr, err := compilePolicyExpr(src)
+			require.NoError(t, err, src)
+
+			_, err = evalPolicyBool(expr, env)
+
+			assert.Error(t, err, "expected evaluation error for %q", src)
+		}
+	})
+}
+
+func TestPolicyExprLimits(t *testing.T) {
+	t.Run("rejects deeply nested groups", func(t *testing.T) {
+		src := strings.Repeat("(", 1000) + "true" + strings.Repeat(")", 1000)
+
+		_, err := compilePolicyExpr(src)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nested more than")
+	})
+
+	t.Run("rejects deeply nested lists", func(t *testing.T) {
+		src := `1 in ` + strings.Repeat("[", 1000) + strings.Repeat("]", 1000)
+
+		_, err := compilePolicyExpr(src)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nested more than")
+	})
+
+	t.Run("rejects long runs of unary operators", func(t *testing.T) {
+		src := strings.Repeat("!", 1000) + "true"
+
+		_, err := compilePolicyExpr(src)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nested more than")
+	})
+
+	t.Run("accepts nesting up to the limit", func(t *testing.T) {
+		src := strings.Repeat("(", maxPolicyExprDepth) + "true" + strings.Repeat(")", maxPolicyExprDepth)
+
+		expr, err := compilePolicyExpr(src)
+		require.NoError(t, err)
+		result, err := evalPolicyBool(expr, nil)
+
+		require.NoError(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("rejects overly long expressions", func(t *testing.T) {
+		src := strings.Repeat("true || ", maxPolicyExprLength/8) + "true"
+
+		_, err := compilePolicyExpr(src)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "longer than")
+	})
+
+	t.Run("evaluates a long flat chain within the length limit", func(t *testing.T) {
+		src := strings.Repeat("false || ", 400) + "true"
+
+		expr, err := compilePolicyExpr(src)
+		require.NoError(t, err)
+		result, err := evalPolicyBool(expr, nil)
+
+		require.NoError(t, err)
+		assert.True(t, result)
+	})
+}
@@ -5,18 +5,30 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/devzeebo/bifrost/core"
 	"github.com/devzeebo/bifrost/domain"
 )
 
+// AccountAuthEntry holds what authentication needs to know about an account.
+// Realms and Roles are effective: the highest of the account's direct role and
+// the roles granted by its groups. DirectRealms and DirectRoles hold the
+// account's own grants; they are empty on entries projected before groups
+// existed, whose Realms and Roles are all direct.
 type AccountAuthEntry struct {
-	AccountID string            `json:"account_id"`
-	Username  string            `json:"username"`
-	Status    string            `json:"status"`
-	Realms    []string          `json:"realms"`
-	Roles     map[string]string `json:"roles"`
+	AccountID      string            `json:"account_id"`
+	Username       string            `json:"username"`
+	Status         string            `json:"status"`
+	Realms         []string          `json:"realms"`
+	Roles          map[string]string `json:"roles"`
 	RealmNames map[string]string `json:"realm_names"` // realm_id -> realm_name mapping
+	Kind           string            `json:"kind,omitempty"`
+	OwnerID        string            `json:"owner_id,omitempty"`
+	ServiceRealmID string            `json:"service_realm_id,omitempty"`
+	DirectRealms   []string          `json:"direct_realms,omitempty"`
+	DirectRoles    map[string]string `json:"direct_roles,omitempty"`
+	Groups         []string          `json:"groups,omitempty"`
 }
 
 // AccountAuthTable is the typed table reference for this projector.
@@ -42,6 +54,12 @@ func (p *AccountAuthProjector) Handle(ctx context.Context, event core.Event, sto
 		return p.handleAccountCreated(ctx, event, store)
 	case domain.EventAccountSuspended:
 		return p.handleAccountSuspended(ctx, event, store)
+	case domain.EventAccountReactivated:
+		return p.handleAccountReactivated(ctx, event, store)
+	case domain.EventAccountRenamed:
+		return p.handleAccountRenamed(ctx, event, store)
+	case domain.EventAccountDeleted:
+		return p.handleAccountDeleted(ctx, event, store)
 	case domain.EventRealmGranted:
 		return p.handleRealmGranted(ctx, event, store)
 	case domain.EventRealmRevoked:
@@ -50,6 +68,12 @@ func (p *AccountAuthProjector) Handle(ctx context.Context, event core.Event, sto
 		return p.handleRoleAssigned(ctx, event, store)
 	case domain.EventRoleRevoked:
 		return p.handleRoleRevoked(ctx, event, store)
+	case domain.EventGroupMemberAdded:
+		return p.handleGroupMemberAdded(ctx, event, store)
+	case domain.EventGroupMemberRemoved:
+		return p.handleGroupMemberRemoved(ctx, event, store)
+	case domain.EventGroupRoleAssigned, domain.EventGroupRoleRevoked, domain.EventGroupDeleted:
+		return p.handleGroupChanged(ctx, event, store)
 	}
 	return nil
 }
@@ -77,6 +101,11 @@ func (p *AccountAuthProjector) handleAccountCreated(ctx context.Context, event c
 		Status:    "active",
 		Realms:    []string{},
 		Roles:     map[string]string{},
+		Kind:      data.AccountKind(),
+		OwnerID:   data.OwnerID,
+	}
+	if entry.Kind == domain.AccountKindService {
+		entry.ServiceRealmID = data.RealmID
 	}
 	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
 }
@@ -95,6 +124,42 @@ func (p *AccountAuthProjector) handleAccountSuspended(ctx context.Context, event
 	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
 }
 
+func (p *AccountAuthProjector) handleAccountReactivated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountReactivated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("account_auth: unmarshal %s: %w", domain.EventAccountReactivated, err)
+	}
+
+	entry, err := core.GetRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID)
+	if err != nil {
+		return err
+	}
+	entry.Status = "active"
+	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
+}
+
+func (p *AccountAuthProjector) handleAccountRenamed(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountRenamed
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("account_auth: unmarshal %s: %w", domain.EventAccountRenamed, err)
+	}
+
+	entry, err := core.GetRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID)
+	if err != nil {
+		return err
+	}
+	entry.Username = data.Username
+	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
+}
+
+func (p *AccountAuthProjector) handleAccountDeleted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountDeleted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("account_auth: unmarshal %s: %w", domain.EventAccountDeleted, err)
+	}
+	return core.DeleteRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID)
+}
+
 func (p *AccountAuthProjector) handleRealmGranted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
 	var data domain.RealmGranted
 	if err := json.Unmarshal(event.Data, &data); err != nil {
@@ -106,18 +171,20 @@ func (p *AccountAuthProjector) handleRealmGranted(ctx context.Context, event cor
 		return err
 	}
 
+	entry.adoptDirectGrants()
+
 	// Check for duplicate for idempotency
-	for _, r := range entry.Realms {
+	for _, r := range entry.DirectRealms {
 		if r == data.RealmID {
 			return nil // Already exists, idempotent
 		}
 	}
 
-	entry.Realms = append(entry.Realms, data.RealmID)
-	if entry.Roles == nil {
-		entry.Roles = make(map[string]string)
+	entry.DirectRealms = append(entry.DirectRealms, data.RealmID)
+	entry.DirectRoles[data.RealmID] = "member"
+	if err := resolveEffectiveRoles(ctx, store, &entry); err != nil {
+		return err
 	}
-	entry.Roles[data.RealmID] = "member"
 	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
 }
 
@@ -132,9 +199,12 @@ func (p *AccountAuthProjector) handleRealmRevoked(ctx context.Context, event cor
 		return err
 	}
 
-	entry.Realms = removeString(entry.Realms, data.RealmID)
-	delete(entry.Roles, data.RealmID)
-	delete(entry.RealmNames, data.RealmID)
+	entry.adoptDirectGrants()
+	entry.DirectRealms = removeString(entry.DirectRealms, data.RealmID)
+	delete(entry.DirectRoles, data.RealmID)
+	if err := resolveEffectiveRoles(ctx, store, &entry); err != nil {
+		return err
+	}
 
 	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
 }
@@ -150,17 +220,18 @@ func (p *AccountAuthProjector) handleRoleAssigned(ctx context.Context, event cor
 		return err
 	}
 
-	if entry.Roles == nil {
-		entry.Roles = make(map[string]string)
-	}
+	entry.adoptDirectGrants()
 
 	// Check if realm already in list
-	_, alreadyInRealms := entry.Roles[data.RealmID]
-	entry.Roles[data.RealmID] = data.Role
+	_, alreadyInRealms := entry.DirectRoles[data.RealmID]
+	entry.DirectRoles[data.RealmID] = data.Role
 
 	// Add realm to list if not already present
 	if !alreadyInRealms {
-		entry.Realms = append(entry.Realms, data.RealmID)
+		entry.DirectRealms = append(entry.DirectRealms, data.RealmID)
+	}
+	if err := resolveEffectiveRoles(ctx, store, &entry); err != nil {
+		return err
 	}
 
 	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
@@ -177,9 +248,151 @@ func (p *AccountAuthProjector) handleRoleRevoked(ctx context.Context, event core
 		return err
 	}
 
-	entry.Realms = removeString(entry.Realms, data.RealmID)
-	delete(entry.Roles, data.RealmID)
-	delete(entry.RealmNames, data.RealmID)
+	entry.adoptDirectGrants()
+	entry.DirectRealms = removeString(entry.DirectRealms, data.RealmID)
+	delete(entry.DirectRoles, data.RealmID)
+	if err := resolveEffectiveRoles(ctx, store, &entry); err != nil {
+		return err
+	}
+
+	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
+}
+
+func (p *AccountAuthProjector) handleGroupMemberAdded(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.GroupMemberAdded
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("account_auth: unmarshal %s: %w", domain.EventGroupMemberAdded, err)
+	}
+
+	entry, err := core.GetRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(entry.Groups, data.GroupID) {
+		return nil
+	}
+
+	entry.adoptDirectGrants()
+	entry.Groups = append(entry.Groups, data.GroupID)
+	if err := resolveEffectiveRoles(ctx, store, &entry); err != nil {
+		return err
+	}
+	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
+}
+
+func (p *AccountAuthProjector) handleGroupMemberRemoved(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.GroupMemberRemoved
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("account_auth: unmarshal %s: %w", domain.EventGroupMemberRemoved, err)
+	}
+
+	entry, err := core.GetRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID)
+	if err != nil {
+		return err
+	}
 
+	entry.adoptDirectGrants()
+	entry.Groups = removeString(entry.Groups, data.GroupID)
+	if err := resolveEffectiveRoles(ctx, store, &entry); err != nil {
+		return err
+	}
 	return core.PutRef(ctx, store, event.RealmID, AccountAuthTable, data.AccountID, entry)
 }
+
+// handleGroupChanged re-resolves the roles of every member of a group whose
+// grants changed or which was deleted.
+func (p *AccountAuthProjector) handleGroupChanged(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data struct {
+		GroupID string `json:"group_id"`
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("account_auth: unmarshal %s: %w", event.EventType, err)
+	}
+
+	raws, err := core.ListRef(ctx, store, event.RealmID, AccountAuthTable)
+	if err != nil {
+		return err
+	}
+	for _, raw := range raws {
+		var entry AccountAuthEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		if !slices.Contains(entry.Groups, data.GroupID) {
+			continue
+		}
+		if event.EventType == domain.EventGroupDeleted {
+			entry.Groups = removeString(entry.Groups, data.GroupID)
+		}
+		if err := resolveEffectiveRoles(ctx, store, &entry); err != nil {
+			return err
+		}
+		if err := core.PutRef(ctx, store, event.RealmID, AccountAuthTable, entry.AccountID, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adoptDirectGrants treats the effective grants of an entry projected before
+// groups existed as the account's direct grants.
+func (e *AccountAuthEntry) adoptDirectGrants() {
+	if e.DirectRoles == nil {
+		if len(e.Groups) == 0 {
+			e.DirectRealms = slices.Clone(e.Realms)
+			e.DirectRoles = make(map[string]string, len(e.Roles))
+			for realmID, role := range e.Roles {
+				e.DirectRoles[realmID] = role
+			}
+		} else {
+			e.DirectRoles = make(map[string]string)
+		}
+	}
+}
+
+// resolveEffectiveRoles sets Realms and Roles to the highest of the entry's
+// direct roles and the roles granted by its groups. Group grants never reach
+// outside a service account's realm.
+func resolveEffectiveRoles(ctx context.Context, store core.ProjectionStore, entry *AccountAuthEntry) error {
+	realms := slices.Clone(entry.DirectRealms)
+	roles := make(map[string]string, len(entry.DirectRoles))
+	for realmID, role := range entry.DirectRoles {
+		roles[realmID] = role
+	}
+
+	var groupRealms []string
+	for _, groupID := range entry.Groups {
+		group, err := core.GetRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, groupID)
+		if err != nil {
+			if errors.As(err, new(*core.NotFoundError)) {
+				continue
+			}
+			return err
+		}
+		for realmID, role := range group.Roles {
+			if entry.Kind == domain.AccountKindService && realmID != entry.ServiceRealmID {
+				continue
+			}
+			current, ok := roles[realmID]
+			if !ok {
+				groupRealms = append(groupRealms, realmID)
+			}
+			if !ok || domain.RoleLevel(role) > domain.RoleLevel(current) {
+				roles[realmID] = role
+			}
+		}
+	}
+	slices.Sort(groupRealms)
+
+	entry.Realms = append(realms, groupRealms...)
+	if entry.Realms == nil {
+		entry.Realms = []string{}
+	}
+	entry.Roles = roles
+	for realmID := range entry.RealmNames {
+		if _, ok := roles[realmID]; !ok {
+			delete(entry.RealmNames, realmID)
+		}
+	}
+	return nil
+}
@@ -269,6 +269,198 @@ func TestAccountAuthProjector(t *testing.T) {
 		tc.no_error()
 		tc.entry_has_empty_realms("acct-1")
 	})
+
+	t.Run("handles AccountReactivated", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.existing_entry("acct-1", "alice", "suspended")
+		tc.event = makeEvent(domain.EventAccountReactivated, domain.AccountReactivated{AccountID: "acct-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_status("acct-1", "active")
+	})
+
+	t.Run("handles AccountRenamed", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.existing_entry("acct-1", "alice", "active")
+		tc.event = makeEvent(domain.EventAccountRenamed, domain.AccountRenamed{AccountID: "acct-1", OldUsername: "alice", Username: "bob"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_username("acct-1", "bob")
+	})
+
+	t.Run("handles AccountDeleted", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.existing_entry_with_realms("acct-1", "alice", "active", []string{"realm-1"})
+		tc.event = makeEvent(domain.EventAccountDeleted, domain.AccountDeleted{AccountID: "acct-1", Username: "alice"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		assert.Nil(t, tc.getEntry("acct-1"))
+	})
+}
+
+func TestAccountAuthProjectorGroups(t *testing.T) {
+	t.Run("GroupMemberAdded grants the group's roles", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.existing_entry_with_realms("acct-1", "alice", "active", []string{"bf-a"})
+		tc.a_group_with_roles("grp-1", map[string]string{"bf-a": "admin", "bf-b": "viewer"})
+		tc.a_group_member_added_event("grp-1", "acct-1")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_realms("acct-1", []string{"bf-a", "bf-b"})
+		tc.entry_has_role("acct-1", "bf-a", "admin")
+		tc.entry_has_role("acct-1", "bf-b", "viewer")
+	})
+
+	t.Run("a direct role that outranks the group role is kept", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.existing_entry_with_realms("acct-1", "alice", "active", []string{"bf-a"})
+		tc.a_group_with_roles("grp-1", map[string]string{"bf-a": "viewer"})
+		tc.a_group_member_added_event("grp-1", "acct-1")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_role("acct-1", "bf-a", "member")
+	})
+
+	t.Run("GroupMemberRemoved restores the direct roles", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.existing_entry_with_realms("acct-1", "alice", "active", []string{"bf-a"})
+		tc.a_group_with_roles("grp-1", map[string]string{"bf-a": "admin", "bf-b": "viewer"})
+		tc.a_group_member_added_event("grp-1", "acct-1")
+		tc.handle_is_called()
+		tc.event = makeEvent(domain.EventGroupMemberRemoved, domain.GroupMemberRemoved{GroupID: "grp-1", AccountID: "acct-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_realms("acct-1", []string{"bf-a"})
+		tc.entry_has_role("acct-1", "bf-a", "member")
+		tc.entry_has_no_role("acct-1", "bf-b")
+	})
+
+	t.Run("RoleRevoked keeps the role granted by a group", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.existing_entry_with_realms("acct-1", "alice", "active", []string{"bf-a"})
+		tc.a_group_with_roles("grp-1", map[string]string{"bf-a": "viewer"})
+		tc.a_group_member_added_event("grp-1", "acct-1")
+		tc.handle_is_called()
+		tc.a_role_revoked_event("acct-1", "bf-a")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_role("acct-1", "bf-a", "viewer")
+	})
+
+	t.Run("GroupRoleAssigned and GroupDeleted re-resolve every member", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.existing_entry("acct-1", "alice", "active")
+		tc.a_group_with_roles("grp-1", map[string]string{})
+		tc.a_group_member_added_event("grp-1", "acct-1")
+		tc.handle_is_called()
+		tc.a_group_with_roles("grp-1", map[string]string{"bf-a": "member"})
+		tc.event = makeEvent(domain.EventGroupRoleAssigned, domain.GroupRoleAssigned{GroupID: "grp-1", RealmID: "bf-a", Role: "member"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_role("acct-1", "bf-a", "member")
+
+		// Given
+		delete(tc.store.data, "_admin:group_directory:grp-1")
+		tc.event = makeEvent(domain.EventGroupDeleted, domain.GroupDeleted{GroupID: "grp-1", Name: "squad"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_no_role("acct-1", "bf-a")
+		assert.Empty(t, tc.getEntry("acct-1").Groups)
+	})
+
+	t.Run("service accounts only receive group roles in their own realm", func(t *testing.T) {
+		tc := newAccountAuthTestContext(t)
+
+		// Given
+		tc.an_account_auth_projector()
+		tc.a_store()
+		tc.event = makeEvent(domain.EventAccountCreated, domain.AccountCreated{
+			AccountID: "acct-svc",
+			Username:  "ci-bot",
+			Kind:      domain.AccountKindService,
+			OwnerID:   "acct-1",
+			RealmID:   "bf-a",
+		})
+		tc.handle_is_called()
+		tc.a_group_with_roles("grp-1", map[string]string{"bf-a": "member", "bf-b": "admin"})
+		tc.a_group_member_added_event("grp-1", "acct-svc")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.entry_has_role("acct-svc", "bf-a", "member")
+		tc.entry_has_no_role("acct-svc", "bf-b")
+	})
 }
 
 // --- Test Context ---
@@ -384,6 +576,24 @@ func (tc *accountAuthTestContext) existing_entry_with_realms(accountID, username
 	tc.store.data[key] = entry
 }
 
+func (tc *accountAuthTestContext) a_group_with_roles(groupID string, roles map[string]string) {
+	tc.t.Helper()
+	tc.store.put(domain.AdminRealmID, "group_directory", groupID, GroupDirectoryEntry{
+		GroupID: groupID,
+		Name:    "squad",
+		Members: []string{},
+		Roles:   roles,
+	})
+}
+
+func (tc *accountAuthTestContext) a_group_member_added_event(groupID, accountID string) {
+	tc.t.Helper()
+	tc.event = makeEvent(domain.EventGroupMemberAdded, domain.GroupMemberAdded{
+		GroupID:   groupID,
+		AccountID: accountID,
+	})
+}
+
 // --- When ---
 
 func (tc *accountAuthTestContext) name_is_called() {
@@ -12,10 +12,12 @@ import (
 
 // PATEntry represents a PAT in the account directory.
 type PATEntry struct {
-	PATID     string    `json:"pat_id"`
-	KeyHash   string    `json:"key_hash"`
-	Label     string    `json:"label"`
-	CreatedAt time.Time `json:"created_at"`
+	PATID     string            `json:"pat_id"`
+	KeyHash   string            `json:"key_hash"`
+	Label     string            `json:"label"`
+	CreatedAt time.Time         `json:"created_at"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	Scopes    *domain.PATScopes `json:"scopes,omitempty"`
 }
 
 // AccountDirectoryEntry is the projection document for an account.
@@ -27,6 +29,8 @@ type AccountDirectoryEntry struct {
 	Roles     map[string]string `json:"roles"`
 	PATs      []PATEntry        `json:"pats"`
 	CreatedAt time.Time         `json:"created_at"`
+	Kind      string            `json:"kind,omitempty"`
+	OwnerID   string            `json:"owner_id,omitempty"`
 }
 
 // PATCount returns the number of PATs (derived from len(pats)).
@@ -58,6 +62,12 @@ func (p *AccountDirectoryProjector) Handle(ctx context.Context, event core.Event
 		return p.handleAccountCreated(ctx, event, store)
 	case domain.EventAccountSuspended:
 		return p.handleAccountSuspended(ctx, event, store)
+	case domain.EventAccountReactivated:
+		return p.handleAccountReactivated(ctx, event, store)
+	case domain.EventAccountRenamed:
+		return p.handleAccountRenamed(ctx, event, store)
+	case domain.EventAccountDeleted:
+		return p.handleAccountDeleted(ctx, event, store)
 	case domain.EventRealmGranted:
 		return p.handleRealmGranted(ctx, event, store)
 	case domain.EventRealmRevoked:
@@ -101,6 +111,8 @@ func (p *AccountDirectoryProjector) handleAccountCreated(ctx context.Context, ev
 		Roles:     map[string]string{},
 		PATs:      []PATEntry{},
 		CreatedAt: data.CreatedAt,
+		Kind:      data.AccountKind(),
+		OwnerID:   data.OwnerID,
 	}
 	return core.PutRef(ctx, store, "_admin", AccountDirectoryTable, data.AccountID, entry)
 }
@@ -118,6 +130,40 @@ func (p *AccountDirectoryProjector) handleAccountSuspended(ctx context.Context,
 	return core.PutRef(ctx, store, "_admin", AccountDirectoryTable, data.AccountID, entry)
 }
 
+func (p *AccountDirectoryProjector) handleAccountReactivated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountReactivated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	entry, err := core.GetRef(ctx, store, "_admin", AccountDirectoryTable, data.AccountID)
+	if err != nil {
+		return err
+	}
+	entry.Status = "active"
+	return core.PutRef(ctx, store, "_admin", AccountDirectoryTable, data.AccountID, entry)
+}
+
+func (p *AccountDirectoryProjector) handleAccountRenamed(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountRenamed
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	entry, err := core.GetRef(ctx, store, "_admin", AccountDirectoryTable, data.AccountID)
+	if err != nil {
+		return err
+	}
+	entry.Username = data.Username
+	return core.PutRef(ctx, store, "_admin", AccountDirectoryTable, data.AccountID, entry)
+}
+
+func (p *AccountDirectoryProjector) handleAccountDeleted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountDeleted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return core.DeleteRef(ctx, store, "_admin", AccountDirectoryTable, data.AccountID)
+}
+
 func (p *AccountDirectoryProjector) handleRealmGranted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
 	var data domain.RealmGranted
 	if err := json.Unmarshal(event.Data, &data); err != nil {
@@ -229,6 +275,8 @@ func (p *AccountDirectoryProjector) handlePATCreated(ctx context.Context, event
 		KeyHash:   data.KeyHash,
 		Label:     data.Label,
 		CreatedAt: data.CreatedAt,
+		ExpiresAt: data.ExpiresAt,
+		Scopes:    data.Scopes,
 	})
 	return core.PutRef(ctx, store, "_admin", AccountDirectoryTable, data.AccountID, entry)
 }
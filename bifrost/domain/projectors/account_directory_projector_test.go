@@ -305,6 +305,116 @@ func TestAccountDirectoryProjector(t *testing.T) {
 		tc.no_error()
 		tc.account_entry_has_realms("acct-1", []string{"realm-1"}) // Still just one
 	})
+
+	t.Run("handles AccountReactivated", func(t *testing.T) {
+		tc := newAccountDirectoryTestContext(t)
+
+		// Given
+		tc.an_account_directory_projector()
+		tc.a_store()
+		tc.existing_account_entry("acct-1", "alice", "suspended")
+		tc.event = makeEvent(domain.EventAccountReactivated, domain.AccountReactivated{AccountID: "acct-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.account_entry_has_status("acct-1", "active")
+	})
+
+	t.Run("handles AccountRenamed", func(t *testing.T) {
+		tc := newAccountDirectoryTestContext(t)
+
+		// Given
+		tc.an_account_directory_projector()
+		tc.a_store()
+		tc.existing_account_entry("acct-1", "alice", "active")
+		tc.event = makeEvent(domain.EventAccountRenamed, domain.AccountRenamed{AccountID: "acct-1", OldUsername: "alice", Username: "bob"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.account_entry_has_username("acct-1", "bob")
+	})
+
+	t.Run("handles AccountDeleted", func(t *testing.T) {
+		tc := newAccountDirectoryTestContext(t)
+
+		// Given
+		tc.an_account_directory_projector()
+		tc.a_store()
+		tc.existing_account_entry("acct-1", "alice", "active")
+		tc.event = makeEvent(domain.EventAccountDeleted, domain.AccountDeleted{AccountID: "acct-1", Username: "alice"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.account_entry_does_not_exist("acct-1")
+	})
+
+	t.Run("AccountCreated records a service account's kind and owner", func(t *testing.T) {
+		tc := newAccountDirectoryTestContext(t)
+
+		// Given
+		tc.an_account_directory_projector()
+		tc.a_store()
+		tc.event = makeEvent(domain.EventAccountCreated, domain.AccountCreated{
+			AccountID: "acct-2", Username: "ci-bot", Kind: domain.AccountKindService, OwnerID: "acct-1", RealmID: "bf-c3d4",
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.account_entry_has_kind("acct-2", domain.AccountKindService)
+		tc.account_entry_has_owner("acct-2", "acct-1")
+	})
+
+	t.Run("AccountCreated defaults the kind to human", func(t *testing.T) {
+		tc := newAccountDirectoryTestContext(t)
+
+		// Given
+		tc.an_account_directory_projector()
+		tc.a_store()
+		tc.an_account_created_event("acct-1", "alice")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.account_entry_has_kind("acct-1", domain.AccountKindHuman)
+		tc.account_entry_has_owner("acct-1", "")
+	})
+
+	t.Run("PATCreated records the expiry", func(t *testing.T) {
+		tc := newAccountDirectoryTestContext(t)
+
+		// Given
+		tc.an_account_directory_projector()
+		tc.a_store()
+		tc.existing_account_entry("acct-1", "alice", "active")
+		createdAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+		expiresAt := createdAt.Add(30 * 24 * time.Hour)
+		tc.event = makeEvent(domain.EventPATCreated, domain.PATCreated{
+			AccountID: "acct-1", PATID: "pat-1", KeyHash: "hash-1", Label: "ci", CreatedAt: createdAt, ExpiresAt: &expiresAt,
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.account_entry_has_pats("acct-1", []PATEntry{
+			{PATID: "pat-1", KeyHash: "hash-1", Label: "ci", CreatedAt: createdAt, ExpiresAt: &expiresAt},
+		})
+	})
 }
 
 // --- Test Context ---
@@ -515,6 +625,22 @@ func (tc *accountDirectoryTestContext) account_entry_has_username(accountID, exp
 	assert.Equal(tc.t, expected, entry.Username)
 }
 
+func (tc *accountDirectoryTestContext) account_entry_has_kind(accountID, expected string) {
+	tc.t.Helper()
+	var entry AccountDirectoryEntry
+	err := tc.store.Get(tc.ctx, "_admin", "account_directory", accountID, &entry)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, entry.Kind)
+}
+
+func (tc *accountDirectoryTestContext) account_entry_has_owner(accountID, expected string) {
+	tc.t.Helper()
+	var entry AccountDirectoryEntry
+	err := tc.store.Get(tc.ctx, "_admin", "account_directory", accountID, &entry)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, entry.OwnerID)
+}
+
 func (tc *accountDirectoryTestContext) account_entry_has_status(accountID, expected string) {
 	tc.t.Helper()
 	var entry AccountDirectoryEntry
@@ -562,3 +688,9 @@ func (tc *accountDirectoryTestContext) account_entry_has_created_at(accountID st
 	require.NoError(tc.t, err)
 	assert.False(tc.t, entry.CreatedAt.IsZero(), "expected CreatedAt to be set")
 }
+
+func (tc *accountDirectoryTestContext) account_entry_does_not_exist(accountID string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "_admin", AccountDirectoryTable, accountID)
+	assert.True(tc.t, isNotFoundError(err), "expected account entry to be deleted, got %v", err)
+}
@@ -0,0 +1,121 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// AuditLogEntry is one security-relevant action. Entries projected from
+// _admin events are named after the event type; the server's security log
+// uses the same shape for actions that are not events, such as failed logins.
+type AuditLogEntry struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	ActorID   string    `json:"actor_id,omitempty"`
+	TargetID  string    `json:"target_id,omitempty"`
+	AccountID string    `json:"account_id,omitempty"`
+	RealmID   string    `json:"realm_id,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	// Count is how many requests a collapsed security log entry stands for.
+	Count int `json:"count,omitempty"`
+}
+
+// Involves reports whether id is the entry's target, account or realm.
+func (e AuditLogEntry) Involves(id string) bool {
+	return id == e.TargetID || id == e.AccountID || id == e.RealmID
+}
+
+// AuditLogTable is the typed table reference for this projector.
+var AuditLogTable = core.TableRef[AuditLogEntry]{Name: "audit_log"}
+
+// AuditLogProjector records every event in the _admin realm — account, PAT,
+// role, group and realm changes — with the actor that caused it.
+type AuditLogProjector struct{}
+
+// NewAuditLogProjector creates a new AuditLogProjector.
+func NewAuditLogProjector() *AuditLogProjector {
+	return &AuditLogProjector{}
+}
+
+// Name returns the projector name.
+func (p *AuditLogProjector) Name() string {
+	return AuditLogTable.Name
+}
+
+// TableName returns the projection table name.
+func (p *AuditLogProjector) TableName() string {
+	return AuditLogTable.Name
+}
+
+// Handle processes events and updates the projection.
+func (p *AuditLogProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	if event.RealmID != domain.AdminRealmID {
+		return nil
+	}
+
+	// Only identifying fields are read, so secrets such as a PAT's key hash
+	// never reach the audit log.
+	var fields struct {
+		AccountID string `json:"account_id"`
+		PATID     string `json:"pat_id"`
+		GroupID   string `json:"group_id"`
+		RealmID   string `json:"realm_id"`
+		WebhookID string `json:"webhook_id"`
+		URL       string `json:"url"`
+		Username  string `json:"username"`
+		Name      string `json:"name"`
+		Role      string `json:"role"`
+		Label     string `json:"label"`
+		Reason    string `json:"reason"`
+	}
+	if err := json.Unmarshal(event.Data, &fields); err != nil {
+		return fmt.Errorf("audit_log: unmarshal %s: %w", event.EventType, err)
+	}
+
+	entry := AuditLogEntry{
+		ID:        fmt.Sprintf("evt-%020d", event.GlobalPosition),
+		Timestamp: event.Timestamp.UTC(),
+		Action:    event.EventType,
+		ActorID:   core.ParseEventMetadata(event.Metadata).ActorID,
+		TargetID:  firstNonEmpty(fields.PATID, fields.GroupID, fields.WebhookID, fields.AccountID, fields.RealmID),
+		AccountID: fields.AccountID,
+		RealmID:   fields.RealmID,
+		Detail: auditDetail(
+			"username", fields.Username,
+			"name", fields.Name,
+			"role", fields.Role,
+			"label", fields.Label,
+			"reason", fields.Reason,
+			"url", fields.URL,
+		),
+	}
+	return core.PutRef(ctx, store, domain.AdminRealmID, AuditLogTable, entry.ID, entry)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// auditDetail formats the non-empty values of key/value pairs as key=value.
+func auditDetail(pairs ...string) string {
+	var parts []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			parts = append(parts, pairs[i]+"="+pairs[i+1])
+		}
+	}
+	return strings.Join(parts, " ")
+}
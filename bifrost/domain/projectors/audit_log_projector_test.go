@@ -0,0 +1,174 @@
+package projectors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestAuditLogProjector(t *testing.T) {
+	t.Run("Name returns audit_log", func(t *testing.T) {
+		tc := newAuditLogTestContext(t)
+
+		// Given
+		tc.an_audit_log_projector()
+
+		// Then
+		assert.Equal(t, "audit_log", tc.projector.Name())
+		assert.Equal(t, "audit_log", tc.projector.TableName())
+	})
+
+	t.Run("records the actor and target of an admin event", func(t *testing.T) {
+		tc := newAuditLogTestContext(t)
+
+		// Given
+		tc.an_audit_log_projector()
+		tc.a_store()
+		tc.an_admin_event(7, domain.EventRoleAssigned, domain.RoleAssigned{AccountID: "acct-1", RealmID: "realm-1", Role: "admin"})
+		tc.event_has_actor("acct-admin")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		entry := tc.entry_exists(7)
+		assert.Equal(t, domain.EventRoleAssigned, entry.Action)
+		assert.Equal(t, "acct-admin", entry.ActorID)
+		assert.Equal(t, "acct-1", entry.TargetID)
+		assert.Equal(t, "realm-1", entry.RealmID)
+		assert.Equal(t, "role=admin", entry.Detail)
+	})
+
+	t.Run("targets the PAT without recording its key hash", func(t *testing.T) {
+		tc := newAuditLogTestContext(t)
+
+		// Given
+		tc.an_audit_log_projector()
+		tc.a_store()
+		tc.an_admin_event(3, domain.EventPATCreated, domain.PATCreated{AccountID: "acct-1", PATID: "pat-1", KeyHash: "secret-hash", Label: "ci"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		entry := tc.entry_exists(3)
+		assert.Equal(t, "pat-1", entry.TargetID)
+		assert.Equal(t, "acct-1", entry.AccountID)
+		assert.Equal(t, "label=ci", entry.Detail)
+		assert.Empty(t, entry.ActorID)
+	})
+
+	t.Run("targets the webhook without recording its secret", func(t *testing.T) {
+		tc := newAuditLogTestContext(t)
+
+		// Given
+		tc.an_audit_log_projector()
+		tc.a_store()
+		tc.an_admin_event(4, domain.EventWebhookAdded, domain.WebhookAdded{RealmID: "realm-1", WebhookID: "wh-1", URL: "https://ci.example.com/hook", Secret: "whsec_x"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		entry := tc.entry_exists(4)
+		assert.Equal(t, "wh-1", entry.TargetID)
+		assert.Equal(t, "realm-1", entry.RealmID)
+		assert.Equal(t, "url=https://ci.example.com/hook", entry.Detail)
+	})
+
+	t.Run("ignores events outside the admin realm", func(t *testing.T) {
+		tc := newAuditLogTestContext(t)
+
+		// Given
+		tc.an_audit_log_projector()
+		tc.a_store()
+		tc.an_event(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-1", Title: "A rune"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		assert.Empty(t, tc.store.data)
+	})
+}
+
+// --- Test Context ---
+
+type auditLogTestContext struct {
+	t *testing.T
+
+	projector *AuditLogProjector
+	store     *mockProjectionStore
+	event     core.Event
+	ctx       context.Context
+	err       error
+}
+
+func newAuditLogTestContext(t *testing.T) *auditLogTestContext {
+	t.Helper()
+	return &auditLogTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *auditLogTestContext) an_audit_log_projector() {
+	tc.t.Helper()
+	tc.projector = NewAuditLogProjector()
+}
+
+func (tc *auditLogTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *auditLogTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
+func (tc *auditLogTestContext) an_admin_event(position int64, eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+	tc.event.RealmID = domain.AdminRealmID
+	tc.event.GlobalPosition = position
+}
+
+func (tc *auditLogTestContext) event_has_actor(actorID string) {
+	tc.t.Helper()
+	tc.event.Metadata = []byte(`{"actor_id":"` + actorID + `"}`)
+}
+
+// --- When ---
+
+func (tc *auditLogTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *auditLogTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *auditLogTestContext) entry_exists(position int64) AuditLogEntry {
+	tc.t.Helper()
+	entry, err := core.GetRef(tc.ctx, tc.store, domain.AdminRealmID, AuditLogTable, fmt.Sprintf("evt-%020d", position))
+	require.NoError(tc.t, err)
+	return entry
+}
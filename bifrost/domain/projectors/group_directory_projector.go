@@ -0,0 +1,172 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// GroupDirectoryEntry is a group with its members and the roles it grants.
+// Roles maps realm ID to role.
+type GroupDirectoryEntry struct {
+	GroupID   string            `json:"group_id"`
+	Name      string            `json:"name"`
+	Members   []string          `json:"members"`
+	Roles     map[string]string `json:"roles"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// GroupDirectoryTable is the typed table reference for this projector.
+var GroupDirectoryTable = core.TableRef[GroupDirectoryEntry]{Name: "group_directory"}
+
+type GroupDirectoryProjector struct{}
+
+func NewGroupDirectoryProjector() *GroupDirectoryProjector {
+	return &GroupDirectoryProjector{}
+}
+
+func (p *GroupDirectoryProjector) Name() string {
+	return GroupDirectoryTable.Name
+}
+
+func (p *GroupDirectoryProjector) TableName() string {
+	return GroupDirectoryTable.Name
+}
+
+func (p *GroupDirectoryProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventGroupCreated:
+		return p.handleCreated(ctx, event, store)
+	case domain.EventGroupDeleted:
+		return p.handleDeleted(ctx, event, store)
+	case domain.EventGroupMemberAdded:
+		return p.handleMemberAdded(ctx, event, store)
+	case domain.EventGroupMemberRemoved:
+		return p.handleMemberRemoved(ctx, event, store)
+	case domain.EventGroupRoleAssigned:
+		return p.handleRoleAssigned(ctx, event, store)
+	case domain.EventGroupRoleRevoked:
+		return p.handleRoleRevoked(ctx, event, store)
+	case domain.EventAccountDeleted:
+		return p.handleAccountDeleted(ctx, event, store)
+	}
+	return nil
+}
+
+func (p *GroupDirectoryProjector) handleCreated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.GroupCreated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("group_directory: unmarshal %s: %w", domain.EventGroupCreated, err)
+	}
+	if _, err := core.GetRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID); err == nil {
+		return nil
+	} else if !errors.As(err, new(*core.NotFoundError)) {
+		return err
+	}
+	entry := GroupDirectoryEntry{
+		GroupID:   data.GroupID,
+		Name:      data.Name,
+		Members:   []string{},
+		Roles:     map[string]string{},
+		CreatedAt: data.CreatedAt,
+	}
+	return core.PutRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID, entry)
+}
+
+func (p *GroupDirectoryProjector) handleDeleted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.GroupDeleted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("group_directory: unmarshal %s: %w", domain.EventGroupDeleted, err)
+	}
+	return core.DeleteRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID)
+}
+
+func (p *GroupDirectoryProjector) handleMemberAdded(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.GroupMemberAdded
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("group_directory: unmarshal %s: %w", domain.EventGroupMemberAdded, err)
+	}
+	entry, err := core.GetRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(entry.Members, data.AccountID) {
+		return nil
+	}
+	entry.Members = append(entry.Members, data.AccountID)
+	return core.PutRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID, entry)
+}
+
+func (p *GroupDirectoryProjector) handleMemberRemoved(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.GroupMemberRemoved
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("group_directory: unmarshal %s: %w", domain.EventGroupMemberRemoved, err)
+	}
+	entry, err := core.GetRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID)
+	if err != nil {
+		return err
+	}
+	entry.Members = removeString(entry.Members, data.AccountID)
+	return core.PutRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID, entry)
+}
+
+func (p *GroupDirectoryProjector) handleRoleAssigned(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.GroupRoleAssigned
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("group_directory: unmarshal %s: %w", domain.EventGroupRoleAssigned, err)
+	}
+	entry, err := core.GetRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID)
+	if err != nil {
+		return err
+	}
+	if entry.Roles == nil {
+		entry.Roles = make(map[string]string)
+	}
+	entry.Roles[data.RealmID] = data.Role
+	return core.PutRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID, entry)
+}
+
+func (p *GroupDirectoryProjector) handleRoleRevoked(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.GroupRoleRevoked
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("group_directory: unmarshal %s: %w", domain.EventGroupRoleRevoked, err)
+	}
+	entry, err := core.GetRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID)
+	if err != nil {
+		return err
+	}
+	delete(entry.Roles, data.RealmID)
+	return core.PutRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, data.GroupID, entry)
+}
+
+// handleAccountDeleted drops a deleted account from every group it belonged to.
+func (p *GroupDirectoryProjector) handleAccountDeleted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountDeleted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("group_directory: unmarshal %s: %w", domain.EventAccountDeleted, err)
+	}
+	raws, err := core.ListRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable)
+	if err != nil {
+		return err
+	}
+	for _, raw := range raws {
+		var entry GroupDirectoryEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		if !slices.Contains(entry.Members, data.AccountID) {
+			continue
+		}
+		entry.Members = removeString(entry.Members, data.AccountID)
+		if err := core.PutRef(ctx, store, domain.AdminRealmID, GroupDirectoryTable, entry.GroupID, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
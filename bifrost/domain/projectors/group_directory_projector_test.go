@@ -0,0 +1,204 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestGroupDirectoryProjector(t *testing.T) {
+	t.Run("Name returns group_directory", func(t *testing.T) {
+		tc := newGroupDirectoryTestContext(t)
+
+		// When
+		tc.name_is_called()
+
+		// Then
+		tc.name_is("group_directory")
+	})
+
+	t.Run("handles GroupCreated by putting an empty group", func(t *testing.T) {
+		tc := newGroupDirectoryTestContext(t)
+
+		// Given
+		tc.an_event(domain.EventGroupCreated, domain.GroupCreated{GroupID: "grp-1", Name: "squad", CreatedAt: time.Now()})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		entry := tc.group_entry("grp-1")
+		assert.Equal(t, "squad", entry.Name)
+		assert.Empty(t, entry.Members)
+		assert.Empty(t, entry.Roles)
+	})
+
+	t.Run("handles GroupMemberAdded once per account", func(t *testing.T) {
+		tc := newGroupDirectoryTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.an_event(domain.EventGroupMemberAdded, domain.GroupMemberAdded{GroupID: "grp-1", AccountID: "acct-a"})
+
+		// When
+		tc.handle_is_called()
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		assert.Equal(t, []string{"acct-a"}, tc.group_entry("grp-1").Members)
+	})
+
+	t.Run("handles GroupMemberRemoved", func(t *testing.T) {
+		tc := newGroupDirectoryTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad", "acct-a", "acct-b")
+		tc.an_event(domain.EventGroupMemberRemoved, domain.GroupMemberRemoved{GroupID: "grp-1", AccountID: "acct-a"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		assert.Equal(t, []string{"acct-b"}, tc.group_entry("grp-1").Members)
+	})
+
+	t.Run("handles GroupRoleAssigned and GroupRoleRevoked", func(t *testing.T) {
+		tc := newGroupDirectoryTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.an_event(domain.EventGroupRoleAssigned, domain.GroupRoleAssigned{GroupID: "grp-1", RealmID: "bf-c3d4", Role: domain.RoleAdmin})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		assert.Equal(t, map[string]string{"bf-c3d4": domain.RoleAdmin}, tc.group_entry("grp-1").Roles)
+
+		// Given
+		tc.an_event(domain.EventGroupRoleRevoked, domain.GroupRoleRevoked{GroupID: "grp-1", RealmID: "bf-c3d4"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		assert.Empty(t, tc.group_entry("grp-1").Roles)
+	})
+
+	t.Run("handles GroupDeleted by removing the group", func(t *testing.T) {
+		tc := newGroupDirectoryTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad")
+		tc.an_event(domain.EventGroupDeleted, domain.GroupDeleted{GroupID: "grp-1", Name: "squad"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		_, exists := tc.store.data["_admin:group_directory:grp-1"]
+		assert.False(t, exists)
+	})
+
+	t.Run("handles AccountDeleted by removing the account from every group", func(t *testing.T) {
+		tc := newGroupDirectoryTestContext(t)
+
+		// Given
+		tc.existing_group("grp-1", "squad", "acct-a", "acct-b")
+		tc.existing_group("grp-2", "crew", "acct-a")
+		tc.an_event(domain.EventAccountDeleted, domain.AccountDeleted{AccountID: "acct-a", Username: "alice"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		assert.Equal(t, []string{"acct-b"}, tc.group_entry("grp-1").Members)
+		assert.Empty(t, tc.group_entry("grp-2").Members)
+	})
+}
+
+// --- Test Context ---
+
+type groupDirectoryTestContext struct {
+	t         *testing.T
+	projector *GroupDirectoryProjector
+	store     *mockProjectionStore
+	event     core.Event
+	err       error
+	name      string
+}
+
+func newGroupDirectoryTestContext(t *testing.T) *groupDirectoryTestContext {
+	t.Helper()
+	return &groupDirectoryTestContext{
+		t:         t,
+		projector: NewGroupDirectoryProjector(),
+		store:     newMockProjectionStore(),
+	}
+}
+
+// --- Given ---
+
+func (tc *groupDirectoryTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+	tc.event.RealmID = domain.AdminRealmID
+}
+
+func (tc *groupDirectoryTestContext) existing_group(groupID, name string, members ...string) {
+	tc.t.Helper()
+	if members == nil {
+		members = []string{}
+	}
+	tc.store.put(domain.AdminRealmID, "group_directory", groupID, GroupDirectoryEntry{
+		GroupID: groupID,
+		Name:    name,
+		Members: members,
+		Roles:   map[string]string{},
+	})
+}
+
+// --- When ---
+
+func (tc *groupDirectoryTestContext) name_is_called() {
+	tc.t.Helper()
+	tc.name = tc.projector.Name()
+}
+
+func (tc *groupDirectoryTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(context.Background(), tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *groupDirectoryTestContext) name_is(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.name)
+}
+
+func (tc *groupDirectoryTestContext) no_error() {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+}
+
+func (tc *groupDirectoryTestContext) group_entry(groupID string) GroupDirectoryEntry {
+	tc.t.Helper()
+	var entry GroupDirectoryEntry
+	require.NoError(tc.t, tc.store.Get(context.Background(), domain.AdminRealmID, "group_directory", groupID, &entry))
+	return entry
+}
@@ -3,6 +3,8 @@ package projectors
 import (
 	"context"
 	"encoding/json"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/devzeebo/bifrost/core"
@@ -24,6 +26,11 @@ var _ core.Projector = (*DependencyCycleCheckProjector)(nil)
 var _ core.Projector = (*AccountAuthProjector)(nil)
 var _ core.Projector = (*RuneSummaryProjector)(nil)
 var _ core.Projector = (*RuneRetroProjector)(nil)
+var _ core.Projector = (*GroupDirectoryProjector)(nil)
+var _ core.Projector = (*RealmRolesProjector)(nil)
+var _ core.Projector = (*RealmWebhooksProjector)(nil)
+var _ core.Projector = (*AuditLogProjector)(nil)
+var _ core.Projector = (*RuneSearchProjector)(nil)
 
 // --- Helpers ---
 
@@ -86,8 +93,24 @@ func (m *mockProjectionStore) Put(_ context.Context, realmID string, table strin
 	return nil
 }
 
-func (m *mockProjectionStore) List(_ context.Context, _ string, _ string) ([]json.RawMessage, error) {
-	return nil, nil
+func (m *mockProjectionStore) List(_ context.Context, realmID string, table string) ([]json.RawMessage, error) {
+	prefix := realmID + ":" + table + ":"
+	keys := make([]string, 0)
+	for key := range m.data {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var results []json.RawMessage
+	for _, key := range keys {
+		dataBytes, err := json.Marshal(m.data[key])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, dataBytes)
+	}
+	return results, nil
 }
 
 func (m *mockProjectionStore) Delete(_ context.Context, realmID string, table string, key string) error {
@@ -3,6 +3,7 @@ package projectors
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/devzeebo/bifrost/core"
 	"github.com/devzeebo/bifrost/domain"
@@ -10,9 +11,11 @@ import (
 
 // PATIDEntry represents a PAT lookup by ID.
 type PATIDEntry struct {
-	PATID     string `json:"pat_id"`
-	KeyHash   string `json:"key_hash"`
-	AccountID string `json:"account_id"`
+	PATID     string            `json:"pat_id"`
+	KeyHash   string            `json:"key_hash"`
+	AccountID string            `json:"account_id"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	Scopes    *domain.PATScopes `json:"scopes,omitempty"`
 }
 
 // PATByIDTable is the typed table reference for this projector.
@@ -56,6 +59,8 @@ func (p *PATIDProjector) handlePATCreated(ctx context.Context, event core.Event,
 		PATID:     data.PATID,
 		KeyHash:   data.KeyHash,
 		AccountID: data.AccountID,
+		ExpiresAt: data.ExpiresAt,
+		Scopes:    data.Scopes,
 	}
 	return core.PutRef(ctx, store, event.RealmID, PATByIDTable, data.PATID, entry)
 }
@@ -124,6 +124,49 @@ func TestPATIDProjector(t *testing.T) {
 		// Then
 		tc.no_error()
 	})
+
+	t.Run("PATCreated records the expiry", func(t *testing.T) {
+		tc := newPATIDTestContext(t)
+
+		// Given
+		tc.a_pat_id_projector()
+		tc.a_store()
+		expiresAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		tc.event = makeEvent(domain.EventPATCreated, domain.PATCreated{
+			PATID: "pat-1", KeyHash: "hash-1", AccountID: "acct-1", ExpiresAt: &expiresAt,
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		entry, err := core.GetRef(tc.ctx, tc.store, "realm-1", PATByIDTable, "pat-1")
+		require.NoError(t, err)
+		require.NotNil(t, entry.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*entry.ExpiresAt))
+	})
+
+	t.Run("PATCreated records the scopes", func(t *testing.T) {
+		tc := newPATIDTestContext(t)
+
+		// Given
+		tc.a_pat_id_projector()
+		tc.a_store()
+		scopes := &domain.PATScopes{Realms: []string{"realm-1"}, MaxRole: domain.RoleViewer}
+		tc.event = makeEvent(domain.EventPATCreated, domain.PATCreated{
+			PATID: "pat-1", KeyHash: "hash-1", AccountID: "acct-1", Scopes: scopes,
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		entry, err := core.GetRef(tc.ctx, tc.store, "realm-1", PATByIDTable, "pat-1")
+		require.NoError(t, err)
+		assert.Equal(t, scopes, entry.Scopes)
+	})
 }
 
 // --- Test Context ---
@@ -39,6 +39,14 @@ func (p *RealmDirectoryProjector) Handle(ctx context.Context, event core.Event,
 		return p.handleCreated(ctx, event, store)
 	case domain.EventRealmSuspended:
 		return p.handleSuspended(ctx, event, store)
+	case domain.EventRealmRenamed:
+		return p.handleRenamed(ctx, event, store)
+	case domain.EventRealmReactivated:
+		return p.setStatus(ctx, event, "active", store)
+	case domain.EventRealmArchived:
+		return p.setStatus(ctx, event, "archived", store)
+	case domain.EventRealmDeleted:
+		return p.handleDeleted(ctx, event, store)
 	}
 	return nil
 }
@@ -69,3 +77,39 @@ func (p *RealmDirectoryProjector) handleSuspended(ctx context.Context, event cor
 	entry.Status = "suspended"
 	return core.PutRef(ctx, store, "_admin", RealmDirectoryTable, data.RealmID, entry)
 }
+
+func (p *RealmDirectoryProjector) handleRenamed(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RealmRenamed
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	entry, err := core.GetRef(ctx, store, "_admin", RealmDirectoryTable, data.RealmID)
+	if err != nil {
+		return err
+	}
+	entry.Name = data.Name
+	return core.PutRef(ctx, store, "_admin", RealmDirectoryTable, data.RealmID, entry)
+}
+
+func (p *RealmDirectoryProjector) setStatus(ctx context.Context, event core.Event, status string, store core.ProjectionStore) error {
+	var data struct {
+		RealmID string `json:"realm_id"`
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	entry, err := core.GetRef(ctx, store, "_admin", RealmDirectoryTable, data.RealmID)
+	if err != nil {
+		return err
+	}
+	entry.Status = status
+	return core.PutRef(ctx, store, "_admin", RealmDirectoryTable, data.RealmID, entry)
+}
+
+func (p *RealmDirectoryProjector) handleDeleted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RealmDeleted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return store.Delete(ctx, "_admin", RealmDirectoryTable.Name, data.RealmID)
+}
@@ -77,6 +77,62 @@ func TestRealmDirectoryProjector(t *testing.T) {
 		tc.realm_entry_has_name("realm-1", "My Realm")
 	})
 
+	t.Run("handles RealmRenamed by updating the name", func(t *testing.T) {
+		tc := newRealmDirectoryTestContext(t)
+
+		// Given
+		tc.a_realm_directory_projector()
+		tc.existing_realm_entry("realm-1", "My Realm", "active")
+		tc.an_event(domain.EventRealmRenamed, domain.RealmRenamed{RealmID: "realm-1", OldName: "My Realm", Name: "Asgard"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.realm_entry_has_name("realm-1", "Asgard")
+	})
+
+	t.Run("handles RealmArchived and RealmReactivated by updating status", func(t *testing.T) {
+		tc := newRealmDirectoryTestContext(t)
+
+		// Given
+		tc.a_realm_directory_projector()
+		tc.existing_realm_entry("realm-1", "My Realm", "active")
+		tc.an_event(domain.EventRealmArchived, domain.RealmArchived{RealmID: "realm-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.realm_entry_has_status("realm-1", "archived")
+
+		// When
+		tc.an_event(domain.EventRealmReactivated, domain.RealmReactivated{RealmID: "realm-1"})
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.realm_entry_has_status("realm-1", "active")
+	})
+
+	t.Run("handles RealmDeleted by removing the entry", func(t *testing.T) {
+		tc := newRealmDirectoryTestContext(t)
+
+		// Given
+		tc.a_realm_directory_projector()
+		tc.existing_realm_entry("realm-1", "My Realm", "archived")
+		tc.an_event(domain.EventRealmDeleted, domain.RealmDeleted{RealmID: "realm-1", Name: "My Realm"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.realm_entry_does_not_exist("realm-1")
+	})
+
 	t.Run("ignores unknown event types", func(t *testing.T) {
 		tc := newRealmDirectoryTestContext(t)
 
@@ -153,6 +209,11 @@ func (tc *realmDirectoryTestContext) an_unknown_event() {
 	tc.event = core.Event{EventType: "UnknownEvent", Data: []byte(`{}`)}
 }
 
+func (tc *realmDirectoryTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
 func (tc *realmDirectoryTestContext) existing_realm_entry(realmID, name, status string) {
 	tc.t.Helper()
 	if tc.store == nil {
@@ -231,3 +292,10 @@ func (tc *realmDirectoryTestContext) realm_entry_has_created_at(realmID string)
 	require.NoError(tc.t, err)
 	assert.False(tc.t, entry.CreatedAt.IsZero(), "expected CreatedAt to be set")
 }
+
+func (tc *realmDirectoryTestContext) realm_entry_does_not_exist(realmID string) {
+	tc.t.Helper()
+	var entry RealmDirectoryEntry
+	err := tc.store.Get(tc.ctx, "_admin", "realm_directory", realmID, &entry)
+	assert.True(tc.t, isNotFoundError(err), "expected no realm directory entry for %s, got %v", realmID, err)
+}
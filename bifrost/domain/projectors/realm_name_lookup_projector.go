@@ -36,6 +36,10 @@ func (p *RealmNameLookupProjector) Handle(ctx context.Context, event core.Event,
 	switch event.EventType {
 	case domain.EventRealmCreated:
 		return p.handleRealmCreated(ctx, event, store)
+	case domain.EventRealmRenamed:
+		return p.handleRealmRenamed(ctx, event, store)
+	case domain.EventRealmDeleted:
+		return p.handleRealmDeleted(ctx, event, store)
 	}
 	return nil
 }
@@ -58,3 +62,41 @@ func (p *RealmNameLookupProjector) handleRealmCreated(ctx context.Context, event
 	}
 	return core.PutRef(ctx, store, "_admin", RealmNameLookupTable, data.Name, entry)
 }
+
+func (p *RealmNameLookupProjector) handleRealmRenamed(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RealmRenamed
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	if err := p.release(ctx, data.OldName, data.RealmID, store); err != nil {
+		return err
+	}
+	entry := RealmNameLookupEntry{
+		Name:    data.Name,
+		RealmID: data.RealmID,
+	}
+	return core.PutRef(ctx, store, "_admin", RealmNameLookupTable, data.Name, entry)
+}
+
+func (p *RealmNameLookupProjector) handleRealmDeleted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RealmDeleted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.release(ctx, data.Name, data.RealmID, store)
+}
+
+// release removes the name's entry if it still points at realmID.
+func (p *RealmNameLookupProjector) release(ctx context.Context, name, realmID string, store core.ProjectionStore) error {
+	entry, err := core.GetRef(ctx, store, "_admin", RealmNameLookupTable, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if entry.RealmID != realmID {
+		return nil
+	}
+	return store.Delete(ctx, "_admin", RealmNameLookupTable.Name, name)
+}
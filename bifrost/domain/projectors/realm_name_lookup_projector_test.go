@@ -75,6 +75,41 @@ func TestRealmNameLookupProjector(t *testing.T) {
 		tc.lookup_entry_has_realm_id("my-realm", "realm-original")
 	})
 
+	t.Run("handles RealmRenamed by moving the mapping to the new name", func(t *testing.T) {
+		tc := newRealmNameLookupTestContext(t)
+
+		// Given
+		tc.a_realm_name_lookup_projector()
+		tc.a_store()
+		tc.existing_lookup_entry("my-realm", "realm-123")
+		tc.an_event(domain.EventRealmRenamed, domain.RealmRenamed{RealmID: "realm-123", OldName: "my-realm", Name: "asgard"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.lookup_entry_has_realm_id("asgard", "realm-123")
+		tc.lookup_entry_does_not_exist("my-realm")
+	})
+
+	t.Run("RealmDeleted keeps a name that belongs to another realm", func(t *testing.T) {
+		tc := newRealmNameLookupTestContext(t)
+
+		// Given
+		tc.a_realm_name_lookup_projector()
+		tc.a_store()
+		tc.existing_lookup_entry("my-realm", "realm-original")
+		tc.an_event(domain.EventRealmDeleted, domain.RealmDeleted{RealmID: "realm-dup", Name: "my-realm"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.lookup_entry_has_realm_id("my-realm", "realm-original")
+	})
+
 	t.Run("ignores unknown event types", func(t *testing.T) {
 		tc := newRealmNameLookupTestContext(t)
 
@@ -138,6 +173,11 @@ func (tc *realmNameLookupTestContext) an_unknown_event() {
 	tc.event = core.Event{EventType: "UnknownEvent", Data: []byte(`{}`)}
 }
 
+func (tc *realmNameLookupTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
 func (tc *realmNameLookupTestContext) existing_lookup_entry(name, realmID string) {
 	tc.t.Helper()
 	if tc.store == nil {
@@ -206,3 +246,10 @@ func (tc *realmNameLookupTestContext) lookup_entry_has_name(name, expected strin
 	require.NoError(tc.t, err)
 	assert.Equal(tc.t, expected, entry.Name)
 }
+
+func (tc *realmNameLookupTestContext) lookup_entry_does_not_exist(name string) {
+	tc.t.Helper()
+	var entry RealmNameLookupEntry
+	err := tc.store.Get(tc.ctx, "_admin", "realm_name_lookup", name, &entry)
+	assert.True(tc.t, isNotFoundError(err), "expected no lookup entry for name %s, got %v", name, err)
+}
@@ -0,0 +1,66 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// RealmPoliciesTable is the typed table reference for this projector.
+var RealmPoliciesTable = core.TableRef[domain.RealmPolicies]{Name: "realm_policies"}
+
+// RealmPoliciesProjector projects each realm's policy set into the admin realm.
+type RealmPoliciesProjector struct{}
+
+func NewRealmPoliciesProjector() *RealmPoliciesProjector {
+	return &RealmPoliciesProjector{}
+}
+
+func (p *RealmPoliciesProjector) Name() string {
+	return RealmPoliciesTable.Name
+}
+
+func (p *RealmPoliciesProjector) TableName() string {
+	return RealmPoliciesTable.Name
+}
+
+func (p *RealmPoliciesProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventPolicySet:
+		var data domain.PolicySet
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.update(ctx, store, data.RealmID, func(policies domain.RealmPolicies) domain.RealmPolicies {
+			return domain.ApplyPolicySet(policies, data)
+		})
+	case domain.EventPolicyRemoved:
+		var data domain.PolicyRemoved
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.update(ctx, store, data.RealmID, func(policies domain.RealmPolicies) domain.RealmPolicies {
+			return domain.ApplyPolicyRemoved(policies, data)
+		})
+	case domain.EventRealmDeleted:
+		var data domain.RealmDeleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return core.DeleteRef(ctx, store, domain.AdminRealmID, RealmPoliciesTable, data.RealmID)
+	}
+	return nil
+}
+
+func (p *RealmPoliciesProjector) update(ctx context.Context, store core.ProjectionStore, realmID string, apply func(domain.RealmPolicies) domain.RealmPolicies) error {
+	policies, err := core.GetRef(ctx, store, domain.AdminRealmID, RealmPoliciesTable, realmID)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return err
+		}
+		policies = domain.RealmPolicies{RealmID: realmID, Policies: []domain.Policy{}}
+	}
+	return core.PutRef(ctx, store, domain.AdminRealmID, RealmPoliciesTable, realmID, apply(policies))
+}
@@ -0,0 +1,156 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestRealmPoliciesProjector(t *testing.T) {
+	t.Run("Name returns realm_policies", func(t *testing.T) {
+		tc := newRealmPoliciesTestContext(t)
+
+		// Given
+		tc.a_realm_policies_projector()
+
+		// Then
+		assert.Equal(t, "realm_policies", tc.projector.Name())
+	})
+
+	t.Run("handles PolicySet by adding the policy", func(t *testing.T) {
+		tc := newRealmPoliciesTestContext(t)
+
+		// Given
+		tc.a_realm_policies_projector()
+		tc.a_store()
+		tc.an_event(domain.EventPolicySet, domain.PolicySet{
+			RealmID: "realm-1", Name: "retro-before-fulfill", Actions: []string{"fulfill"}, Expression: "rune.retro_count > 0",
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.policies_are("realm-1", "retro-before-fulfill")
+	})
+
+	t.Run("handles PolicyRemoved by dropping the policy", func(t *testing.T) {
+		tc := newRealmPoliciesTestContext(t)
+
+		// Given
+		tc.a_realm_policies_projector()
+		tc.a_store()
+		tc.existing_policies("realm-1", "a", "b")
+		tc.an_event(domain.EventPolicyRemoved, domain.PolicyRemoved{RealmID: "realm-1", Name: "a"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.policies_are("realm-1", "b")
+	})
+
+	t.Run("handles RealmDeleted by removing the realm's policies", func(t *testing.T) {
+		tc := newRealmPoliciesTestContext(t)
+
+		// Given
+		tc.a_realm_policies_projector()
+		tc.a_store()
+		tc.existing_policies("realm-1", "no-claims")
+		tc.existing_policies("realm-2", "no-seals")
+		tc.an_event(domain.EventRealmDeleted, domain.RealmDeleted{RealmID: "realm-1", Name: "My Realm"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.policies_do_not_exist("realm-1")
+		tc.policies_are("realm-2", "no-seals")
+	})
+}
+
+// --- Test Context ---
+
+type realmPoliciesTestContext struct {
+	t *testing.T
+
+	projector *RealmPoliciesProjector
+	store     *mockProjectionStore
+	event     core.Event
+	ctx       context.Context
+	err       error
+}
+
+func newRealmPoliciesTestContext(t *testing.T) *realmPoliciesTestContext {
+	t.Helper()
+	return &realmPoliciesTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *realmPoliciesTestContext) a_realm_policies_projector() {
+	tc.t.Helper()
+	tc.projector = NewRealmPoliciesProjector()
+}
+
+func (tc *realmPoliciesTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *realmPoliciesTestContext) existing_policies(realmID string, names ...string) {
+	tc.t.Helper()
+	policies := domain.RealmPolicies{RealmID: realmID}
+	for _, name := range names {
+		policies.Policies = append(policies.Policies, domain.Policy{Name: name, Expression: "true"})
+	}
+	require.NoError(tc.t, core.PutRef(tc.ctx, tc.store, "_admin", RealmPoliciesTable, realmID, policies))
+}
+
+func (tc *realmPoliciesTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
+// --- When ---
+
+func (tc *realmPoliciesTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *realmPoliciesTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *realmPoliciesTestContext) policies_are(realmID string, names ...string) {
+	tc.t.Helper()
+	policies, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmPoliciesTable, realmID)
+	require.NoError(tc.t, err)
+	actual := make([]string, 0, len(policies.Policies))
+	for _, policy := range policies.Policies {
+		actual = append(actual, policy.Name)
+	}
+	assert.Equal(tc.t, names, actual)
+}
+
+func (tc *realmPoliciesTestContext) policies_do_not_exist(realmID string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmPoliciesTable, realmID)
+	assert.True(tc.t, isNotFoundError(err), "expected no realm policies for %s, got %v", realmID, err)
+}
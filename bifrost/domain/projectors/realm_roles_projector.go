@@ -0,0 +1,66 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// RealmRolesTable is the typed table reference for this projector.
+var RealmRolesTable = core.TableRef[domain.RealmRoles]{Name: "realm_roles"}
+
+// RealmRolesProjector projects each realm's custom roles into the admin realm.
+type RealmRolesProjector struct{}
+
+func NewRealmRolesProjector() *RealmRolesProjector {
+	return &RealmRolesProjector{}
+}
+
+func (p *RealmRolesProjector) Name() string {
+	return RealmRolesTable.Name
+}
+
+func (p *RealmRolesProjector) TableName() string {
+	return RealmRolesTable.Name
+}
+
+func (p *RealmRolesProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventCustomRoleDefined:
+		var data domain.CustomRoleDefined
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.update(ctx, store, data.RealmID, func(roles domain.RealmRoles) domain.RealmRoles {
+			return domain.ApplyCustomRoleDefined(roles, data)
+		})
+	case domain.EventCustomRoleDeleted:
+		var data domain.CustomRoleDeleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.update(ctx, store, data.RealmID, func(roles domain.RealmRoles) domain.RealmRoles {
+			return domain.ApplyCustomRoleDeleted(roles, data)
+		})
+	case domain.EventRealmDeleted:
+		var data domain.RealmDeleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return core.DeleteRef(ctx, store, domain.AdminRealmID, RealmRolesTable, data.RealmID)
+	}
+	return nil
+}
+
+func (p *RealmRolesProjector) update(ctx context.Context, store core.ProjectionStore, realmID string, apply func(domain.RealmRoles) domain.RealmRoles) error {
+	roles, err := core.GetRef(ctx, store, domain.AdminRealmID, RealmRolesTable, realmID)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return err
+		}
+		roles = domain.RealmRoles{RealmID: realmID, Roles: []domain.CustomRole{}}
+	}
+	return core.PutRef(ctx, store, domain.AdminRealmID, RealmRolesTable, realmID, apply(roles))
+}
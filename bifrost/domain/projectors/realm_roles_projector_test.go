@@ -0,0 +1,156 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestRealmRolesProjector(t *testing.T) {
+	t.Run("Name returns realm_roles", func(t *testing.T) {
+		tc := newRealmRolesTestContext(t)
+
+		// Given
+		tc.a_realm_roles_projector()
+
+		// Then
+		assert.Equal(t, "realm_roles", tc.projector.Name())
+	})
+
+	t.Run("handles CustomRoleDefined by adding the role", func(t *testing.T) {
+		tc := newRealmRolesTestContext(t)
+
+		// Given
+		tc.a_realm_roles_projector()
+		tc.a_store()
+		tc.an_event(domain.EventCustomRoleDefined, domain.CustomRoleDefined{
+			RealmID: "realm-1", Name: "triager", Permissions: []string{domain.PermViewRunes, domain.PermAddNote},
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.roles_are("realm-1", "triager")
+	})
+
+	t.Run("handles CustomRoleDeleted by dropping the role", func(t *testing.T) {
+		tc := newRealmRolesTestContext(t)
+
+		// Given
+		tc.a_realm_roles_projector()
+		tc.a_store()
+		tc.existing_roles("realm-1", "a", "b")
+		tc.an_event(domain.EventCustomRoleDeleted, domain.CustomRoleDeleted{RealmID: "realm-1", Name: "a"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.roles_are("realm-1", "b")
+	})
+
+	t.Run("handles RealmDeleted by removing the realm's roles", func(t *testing.T) {
+		tc := newRealmRolesTestContext(t)
+
+		// Given
+		tc.a_realm_roles_projector()
+		tc.a_store()
+		tc.existing_roles("realm-1", "triager")
+		tc.existing_roles("realm-2", "reviewer")
+		tc.an_event(domain.EventRealmDeleted, domain.RealmDeleted{RealmID: "realm-1", Name: "My Realm"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.roles_do_not_exist("realm-1")
+		tc.roles_are("realm-2", "reviewer")
+	})
+}
+
+// --- Test Context ---
+
+type realmRolesTestContext struct {
+	t *testing.T
+
+	projector *RealmRolesProjector
+	store     *mockProjectionStore
+	event     core.Event
+	ctx       context.Context
+	err       error
+}
+
+func newRealmRolesTestContext(t *testing.T) *realmRolesTestContext {
+	t.Helper()
+	return &realmRolesTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *realmRolesTestContext) a_realm_roles_projector() {
+	tc.t.Helper()
+	tc.projector = NewRealmRolesProjector()
+}
+
+func (tc *realmRolesTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *realmRolesTestContext) existing_roles(realmID string, names ...string) {
+	tc.t.Helper()
+	roles := domain.RealmRoles{RealmID: realmID}
+	for _, name := range names {
+		roles.Roles = append(roles.Roles, domain.CustomRole{Name: name, Permissions: []string{domain.PermViewRunes}})
+	}
+	require.NoError(tc.t, core.PutRef(tc.ctx, tc.store, "_admin", RealmRolesTable, realmID, roles))
+}
+
+func (tc *realmRolesTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
+// --- When ---
+
+func (tc *realmRolesTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *realmRolesTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *realmRolesTestContext) roles_are(realmID string, names ...string) {
+	tc.t.Helper()
+	roles, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmRolesTable, realmID)
+	require.NoError(tc.t, err)
+	actual := make([]string, 0, len(roles.Roles))
+	for _, role := range roles.Roles {
+		actual = append(actual, role.Name)
+	}
+	assert.Equal(tc.t, names, actual)
+}
+
+func (tc *realmRolesTestContext) roles_do_not_exist(realmID string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmRolesTable, realmID)
+	assert.True(tc.t, isNotFoundError(err), "expected no realm roles for %s, got %v", realmID, err)
+}
@@ -0,0 +1,70 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// RealmSettingsTable is the typed table reference for this projector.
+var RealmSettingsTable = core.TableRef[domain.RealmSettings]{Name: "realm_settings"}
+
+// RealmSettingsProjector projects per-realm settings into the admin realm.
+type RealmSettingsProjector struct{}
+
+func NewRealmSettingsProjector() *RealmSettingsProjector {
+	return &RealmSettingsProjector{}
+}
+
+func (p *RealmSettingsProjector) Name() string {
+	return RealmSettingsTable.Name
+}
+
+func (p *RealmSettingsProjector) TableName() string {
+	return RealmSettingsTable.Name
+}
+
+func (p *RealmSettingsProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventRealmCreated:
+		return p.handleCreated(ctx, event, store)
+	case domain.EventRealmSettingsUpdated:
+		return p.handleSettingsUpdated(ctx, event, store)
+	case domain.EventRealmDeleted:
+		return p.handleDeleted(ctx, event, store)
+	}
+	return nil
+}
+
+func (p *RealmSettingsProjector) handleCreated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RealmCreated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return core.PutRef(ctx, store, domain.AdminRealmID, RealmSettingsTable, data.RealmID, domain.DefaultRealmSettings(data.RealmID))
+}
+
+func (p *RealmSettingsProjector) handleSettingsUpdated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RealmSettingsUpdated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	// Read through the domain so entries projected before a setting existed
+	// pick up its default.
+	settings, err := domain.ReadRealmSettings(ctx, data.RealmID, store)
+	if err != nil {
+		return err
+	}
+	settings = domain.ApplyRealmSettingsUpdate(settings, data)
+	return core.PutRef(ctx, store, domain.AdminRealmID, RealmSettingsTable, data.RealmID, settings)
+}
+
+func (p *RealmSettingsProjector) handleDeleted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RealmDeleted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return core.DeleteRef(ctx, store, domain.AdminRealmID, RealmSettingsTable, data.RealmID)
+}
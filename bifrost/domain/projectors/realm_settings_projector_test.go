@@ -0,0 +1,192 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestRealmSettingsProjector(t *testing.T) {
+	t.Run("Name returns realm_settings", func(t *testing.T) {
+		tc := newRealmSettingsTestContext(t)
+
+		// Given
+		tc.a_realm_settings_projector()
+
+		// When
+		tc.name_is_called()
+
+		// Then
+		tc.name_is("realm_settings")
+	})
+
+	t.Run("handles RealmCreated by storing default settings", func(t *testing.T) {
+		tc := newRealmSettingsTestContext(t)
+
+		// Given
+		tc.a_realm_settings_projector()
+		tc.a_store()
+		tc.an_event(domain.EventRealmCreated, domain.RealmCreated{RealmID: "realm-1", Name: "My Realm"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.settings_have_saga_auto_complete("realm-1", domain.SagaAutoCompleteOff)
+	})
+
+	t.Run("handles RealmSettingsUpdated by applying changed fields", func(t *testing.T) {
+		tc := newRealmSettingsTestContext(t)
+		mode := domain.SagaAutoCompleteFlag
+
+		// Given
+		tc.a_realm_settings_projector()
+		tc.a_store()
+		tc.an_event(domain.EventRealmSettingsUpdated, domain.RealmSettingsUpdated{RealmID: "realm-1", SagaAutoComplete: &mode})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.settings_have_saga_auto_complete("realm-1", domain.SagaAutoCompleteFlag)
+	})
+
+	t.Run("keeps defaults for fields missing from an older entry", func(t *testing.T) {
+		tc := newRealmSettingsTestContext(t)
+		priority := 2
+
+		// Given
+		tc.a_realm_settings_projector()
+		tc.a_store()
+		tc.existing_settings_entry("realm-1", map[string]any{"realm_id": "realm-1", "saga_auto_complete": "flag"})
+		tc.an_event(domain.EventRealmSettingsUpdated, domain.RealmSettingsUpdated{RealmID: "realm-1", DefaultPriority: &priority})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.settings_are("realm-1", domain.RealmSettings{
+			RealmID:          "realm-1",
+			SagaAutoComplete: domain.SagaAutoCompleteFlag,
+			RequireBranch:    true,
+			DefaultPriority:  2,
+			DefaultRuneType:  "rune",
+			MaxStateSize:     domain.MaxStateSize,
+		})
+	})
+
+	t.Run("handles RealmDeleted by removing the realm's settings", func(t *testing.T) {
+		tc := newRealmSettingsTestContext(t)
+
+		// Given
+		tc.a_realm_settings_projector()
+		tc.a_store()
+		tc.existing_settings_entry("realm-1", map[string]any{"realm_id": "realm-1", "saga_auto_complete": "off"})
+		tc.existing_settings_entry("realm-2", map[string]any{"realm_id": "realm-2", "saga_auto_complete": "off"})
+		tc.an_event(domain.EventRealmDeleted, domain.RealmDeleted{RealmID: "realm-1", Name: "My Realm"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.settings_do_not_exist("realm-1")
+		tc.settings_have_saga_auto_complete("realm-2", "off")
+	})
+}
+
+// --- Test Context ---
+
+type realmSettingsTestContext struct {
+	t *testing.T
+
+	projector  *RealmSettingsProjector
+	store      *mockProjectionStore
+	event      core.Event
+	ctx        context.Context
+	nameResult string
+	err        error
+}
+
+func newRealmSettingsTestContext(t *testing.T) *realmSettingsTestContext {
+	t.Helper()
+	return &realmSettingsTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *realmSettingsTestContext) a_realm_settings_projector() {
+	tc.t.Helper()
+	tc.projector = NewRealmSettingsProjector()
+}
+
+func (tc *realmSettingsTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *realmSettingsTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
+func (tc *realmSettingsTestContext) existing_settings_entry(realmID string, entry map[string]any) {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.store.Put(tc.ctx, "_admin", RealmSettingsTable.Name, realmID, entry))
+}
+
+// --- When ---
+
+func (tc *realmSettingsTestContext) name_is_called() {
+	tc.t.Helper()
+	tc.nameResult = tc.projector.Name()
+}
+
+func (tc *realmSettingsTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *realmSettingsTestContext) name_is(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.nameResult)
+}
+
+func (tc *realmSettingsTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *realmSettingsTestContext) settings_have_saga_auto_complete(realmID, expected string) {
+	tc.t.Helper()
+	settings, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmSettingsTable, realmID)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, settings.SagaAutoComplete)
+}
+
+func (tc *realmSettingsTestContext) settings_are(realmID string, expected domain.RealmSettings) {
+	tc.t.Helper()
+	settings, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmSettingsTable, realmID)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, settings)
+}
+
+func (tc *realmSettingsTestContext) settings_do_not_exist(realmID string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmSettingsTable, realmID)
+	assert.True(tc.t, isNotFoundError(err), "expected no realm settings for %s, got %v", realmID, err)
+}
@@ -0,0 +1,66 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// RealmWebhooksTable is the typed table reference for this projector.
+var RealmWebhooksTable = core.TableRef[domain.RealmWebhooks]{Name: "realm_webhooks"}
+
+// RealmWebhooksProjector projects each realm's webhooks into the admin realm.
+type RealmWebhooksProjector struct{}
+
+func NewRealmWebhooksProjector() *RealmWebhooksProjector {
+	return &RealmWebhooksProjector{}
+}
+
+func (p *RealmWebhooksProjector) Name() string {
+	return RealmWebhooksTable.Name
+}
+
+func (p *RealmWebhooksProjector) TableName() string {
+	return RealmWebhooksTable.Name
+}
+
+func (p *RealmWebhooksProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventWebhookAdded:
+		var data domain.WebhookAdded
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.update(ctx, store, data.RealmID, func(webhooks domain.RealmWebhooks) domain.RealmWebhooks {
+			return domain.ApplyWebhookAdded(webhooks, data)
+		})
+	case domain.EventWebhookRemoved:
+		var data domain.WebhookRemoved
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.update(ctx, store, data.RealmID, func(webhooks domain.RealmWebhooks) domain.RealmWebhooks {
+			return domain.ApplyWebhookRemoved(webhooks, data)
+		})
+	case domain.EventRealmDeleted:
+		var data domain.RealmDeleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return core.DeleteRef(ctx, store, domain.AdminRealmID, RealmWebhooksTable, data.RealmID)
+	}
+	return nil
+}
+
+func (p *RealmWebhooksProjector) update(ctx context.Context, store core.ProjectionStore, realmID string, apply func(domain.RealmWebhooks) domain.RealmWebhooks) error {
+	webhooks, err := core.GetRef(ctx, store, domain.AdminRealmID, RealmWebhooksTable, realmID)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return err
+		}
+		webhooks = domain.RealmWebhooks{RealmID: realmID, Webhooks: []domain.Webhook{}}
+	}
+	return core.PutRef(ctx, store, domain.AdminRealmID, RealmWebhooksTable, realmID, apply(webhooks))
+}
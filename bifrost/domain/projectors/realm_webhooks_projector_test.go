@@ -0,0 +1,157 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestRealmWebhooksProjector(t *testing.T) {
+	t.Run("Name returns realm_webhooks", func(t *testing.T) {
+		tc := newRealmWebhooksTestContext(t)
+
+		// Given
+		tc.a_realm_webhooks_projector()
+
+		// Then
+		assert.Equal(t, "realm_webhooks", tc.projector.Name())
+	})
+
+	t.Run("handles WebhookAdded by adding the webhook", func(t *testing.T) {
+		tc := newRealmWebhooksTestContext(t)
+
+		// Given
+		tc.a_realm_webhooks_projector()
+		tc.a_store()
+		tc.an_event(domain.EventWebhookAdded, domain.WebhookAdded{
+			RealmID: "realm-1", WebhookID: "wh-1", URL: "https://ci.example.com/hook",
+			EventTypes: []string{domain.EventRuneFulfilled}, Secret: "s3cret",
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.webhooks_are("realm-1", "wh-1")
+	})
+
+	t.Run("handles WebhookRemoved by dropping the webhook", func(t *testing.T) {
+		tc := newRealmWebhooksTestContext(t)
+
+		// Given
+		tc.a_realm_webhooks_projector()
+		tc.a_store()
+		tc.existing_webhooks("realm-1", "wh-1", "wh-2")
+		tc.an_event(domain.EventWebhookRemoved, domain.WebhookRemoved{RealmID: "realm-1", WebhookID: "wh-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.webhooks_are("realm-1", "wh-2")
+	})
+
+	t.Run("handles RealmDeleted by removing the realm's webhooks", func(t *testing.T) {
+		tc := newRealmWebhooksTestContext(t)
+
+		// Given
+		tc.a_realm_webhooks_projector()
+		tc.a_store()
+		tc.existing_webhooks("realm-1", "wh-1")
+		tc.existing_webhooks("realm-2", "wh-2")
+		tc.an_event(domain.EventRealmDeleted, domain.RealmDeleted{RealmID: "realm-1", Name: "My Realm"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.webhooks_do_not_exist("realm-1")
+		tc.webhooks_are("realm-2", "wh-2")
+	})
+}
+
+// --- Test Context ---
+
+type realmWebhooksTestContext struct {
+	t *testing.T
+
+	projector *RealmWebhooksProjector
+	store     *mockProjectionStore
+	event     core.Event
+	ctx       context.Context
+	err       error
+}
+
+func newRealmWebhooksTestContext(t *testing.T) *realmWebhooksTestContext {
+	t.Helper()
+	return &realmWebhooksTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *realmWebhooksTestContext) a_realm_webhooks_projector() {
+	tc.t.Helper()
+	tc.projector = NewRealmWebhooksProjector()
+}
+
+func (tc *realmWebhooksTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *realmWebhooksTestContext) existing_webhooks(realmID string, ids ...string) {
+	tc.t.Helper()
+	webhooks := domain.RealmWebhooks{RealmID: realmID}
+	for _, id := range ids {
+		webhooks.Webhooks = append(webhooks.Webhooks, domain.Webhook{ID: id, URL: "https://example.com/" + id})
+	}
+	require.NoError(tc.t, core.PutRef(tc.ctx, tc.store, "_admin", RealmWebhooksTable, realmID, webhooks))
+}
+
+func (tc *realmWebhooksTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
+// --- When ---
+
+func (tc *realmWebhooksTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *realmWebhooksTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *realmWebhooksTestContext) webhooks_are(realmID string, ids ...string) {
+	tc.t.Helper()
+	webhooks, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmWebhooksTable, realmID)
+	require.NoError(tc.t, err)
+	actual := make([]string, 0, len(webhooks.Webhooks))
+	for _, webhook := range webhooks.Webhooks {
+		actual = append(actual, webhook.ID)
+	}
+	assert.Equal(tc.t, ids, actual)
+}
+
+func (tc *realmWebhooksTestContext) webhooks_do_not_exist(realmID string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "_admin", RealmWebhooksTable, realmID)
+	assert.True(tc.t, isNotFoundError(err), "expected no realm webhooks for %s, got %v", realmID, err)
+}
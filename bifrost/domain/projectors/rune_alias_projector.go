@@ -0,0 +1,59 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// RuneAliasEntry is the projection document mapping a rune alias to the
+// rune's original ID.
+type RuneAliasEntry struct {
+	Alias  string `json:"alias"`
+	RuneID string `json:"rune_id"`
+}
+
+// RuneAliasTable is the typed table reference for this projector.
+var RuneAliasTable = core.TableRef[RuneAliasEntry]{Name: "rune_alias"}
+
+// RuneAliasProjector provides alias-to-ID resolution for moved runes. Earlier
+// aliases stay resolvable after further moves; their streams are reserved, so
+// they can never name a different rune.
+type RuneAliasProjector struct{}
+
+func NewRuneAliasProjector() *RuneAliasProjector {
+	return &RuneAliasProjector{}
+}
+
+func (p *RuneAliasProjector) Name() string {
+	return RuneAliasTable.Name
+}
+
+func (p *RuneAliasProjector) TableName() string {
+	return RuneAliasTable.Name
+}
+
+func (p *RuneAliasProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventRuneMoved:
+		return p.handleMoved(ctx, event, store)
+	}
+	return nil
+}
+
+func (p *RuneAliasProjector) handleMoved(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneMoved
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	if data.Alias == "" {
+		return nil
+	}
+	entry := RuneAliasEntry{
+		Alias:  data.Alias,
+		RuneID: data.ID,
+	}
+	return core.PutRef(ctx, store, event.RealmID, RuneAliasTable, data.Alias, entry)
+}
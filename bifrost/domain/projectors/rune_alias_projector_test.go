@@ -0,0 +1,153 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile-time interface satisfaction check
+var _ core.Projector = (*RuneAliasProjector)(nil)
+
+// --- Tests ---
+
+func TestRuneAliasProjector(t *testing.T) {
+	t.Run("Name returns rune_alias", func(t *testing.T) {
+		tc := newRuneAliasTestContext(t)
+
+		// Given
+		tc.a_rune_alias_projector()
+
+		// When
+		tc.name_is_called()
+
+		// Then
+		tc.name_is("rune_alias")
+	})
+
+	t.Run("handles RuneMoved by mapping the alias to the rune ID", func(t *testing.T) {
+		tc := newRuneAliasTestContext(t)
+
+		// Given
+		tc.a_rune_alias_projector()
+		tc.a_store()
+		tc.a_rune_moved_event("bf-a.1", "bf-b.2")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.alias_resolves_to("bf-b.2", "bf-a.1")
+	})
+
+	t.Run("earlier aliases stay resolvable after another move", func(t *testing.T) {
+		tc := newRuneAliasTestContext(t)
+
+		// Given
+		tc.a_rune_alias_projector()
+		tc.a_store()
+		tc.a_rune_moved_event("bf-a.1", "bf-b.2")
+		tc.handle_is_called()
+		tc.a_rune_moved_event("bf-a.1", "bf-c.1")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.alias_resolves_to("bf-b.2", "bf-a.1")
+		tc.alias_resolves_to("bf-c.1", "bf-a.1")
+	})
+
+	t.Run("RuneMoved without alias stores nothing", func(t *testing.T) {
+		tc := newRuneAliasTestContext(t)
+
+		// Given
+		tc.a_rune_alias_projector()
+		tc.a_store()
+		tc.a_rune_moved_event("bf-a.1", "")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		assert.Empty(t, tc.store.data)
+	})
+}
+
+// --- Test Context ---
+
+type runeAliasTestContext struct {
+	t *testing.T
+
+	projector  *RuneAliasProjector
+	store      *mockProjectionStore
+	event      core.Event
+	ctx        context.Context
+	nameResult string
+	err        error
+}
+
+func newRuneAliasTestContext(t *testing.T) *runeAliasTestContext {
+	t.Helper()
+	return &runeAliasTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *runeAliasTestContext) a_rune_alias_projector() {
+	tc.t.Helper()
+	tc.projector = NewRuneAliasProjector()
+}
+
+func (tc *runeAliasTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *runeAliasTestContext) a_rune_moved_event(id, alias string) {
+	tc.t.Helper()
+	tc.event = makeEvent(domain.EventRuneMoved, domain.RuneMoved{
+		ID: id, NewParentID: "bf-b", Alias: alias, Status: "open",
+	})
+}
+
+// --- When ---
+
+func (tc *runeAliasTestContext) name_is_called() {
+	tc.t.Helper()
+	tc.nameResult = tc.projector.Name()
+}
+
+func (tc *runeAliasTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *runeAliasTestContext) name_is(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.nameResult)
+}
+
+func (tc *runeAliasTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *runeAliasTestContext) alias_resolves_to(alias, expected string) {
+	tc.t.Helper()
+	entry, err := core.GetRef(tc.ctx, tc.store, "realm-1", RuneAliasTable, alias)
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, entry.RuneID)
+}
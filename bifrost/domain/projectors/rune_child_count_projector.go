@@ -40,31 +40,45 @@ func (p *RuneChildCountProjector) TableName() string {
 
 // Handle processes events and updates the projection.
 func (p *RuneChildCountProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
-	if event.EventType != domain.EventRuneCreated {
-		return nil
-	}
-
-	var data domain.RuneCreated
-	if err := json.Unmarshal(event.Data, &data); err != nil {
-		return err
+	switch event.EventType {
+	case domain.EventRuneCreated:
+		var data domain.RuneCreated
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.recordChild(ctx, event.RealmID, data.ParentID, data.ID, store)
+	case domain.EventRuneMoved:
+		// A moved rune takes up a sequence number under its new parent via
+		// its alias. The old parent keeps its count so IDs are never reused.
+		var data domain.RuneMoved
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		if data.Alias == "" {
+			return nil
+		}
+		return p.recordChild(ctx, event.RealmID, data.NewParentID, data.Alias, store)
 	}
+	return nil
+}
 
-	if data.ParentID == "" {
+func (p *RuneChildCountProjector) recordChild(ctx context.Context, realmID, parentID, childID string, store core.ProjectionStore) error {
+	if parentID == "" {
 		return nil
 	}
 
 	// Extract sequence number from child ID (e.g., "parent.3" -> 3)
-	sequenceNum := extractSequenceNumber(data.ID)
+	sequenceNum := extractSequenceNumber(childID)
 
 	// Get current entry
-	entry, err := core.GetRef(ctx, store, event.RealmID, RuneChildCountTable, data.ParentID)
+	entry, err := core.GetRef(ctx, store, realmID, RuneChildCountTable, parentID)
 	if err != nil {
 		var nfe *core.NotFoundError
 		if !errors.As(err, &nfe) {
 			return err
 		}
 		entry = RuneChildCountEntry{
-			ParentRuneID: data.ParentID,
+			ParentRuneID: parentID,
 			Count:        0,
 		}
 	}
@@ -72,7 +86,7 @@ func (p *RuneChildCountProjector) Handle(ctx context.Context, event core.Event,
 	// Idempotency: only increment if count < sequence number
 	if entry.Count < sequenceNum {
 		entry.Count = sequenceNum
-		return core.PutRef(ctx, store, event.RealmID, RuneChildCountTable, data.ParentID, entry)
+		return core.PutRef(ctx, store, realmID, RuneChildCountTable, parentID, entry)
 	}
 
 	// Already processed this or a later sequence, no-op
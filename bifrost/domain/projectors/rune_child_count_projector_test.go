@@ -191,6 +191,39 @@ func TestRuneChildCountProjector(t *testing.T) {
 		tc.no_error()
 		tc.no_auxiliary_keys()
 	})
+
+	t.Run("RuneMoved with alias bumps the new parent's count", func(t *testing.T) {
+		tc := newRuneChildCountTestContext(t)
+
+		// Given
+		tc.a_rune_child_count_projector()
+		tc.a_store()
+		tc.existing_entry_with_count("bf-b", 2)
+		tc.a_rune_moved_event("bf-a.1", "bf-a", "bf-b", "bf-b.3")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.count_is("bf-b", 3)
+	})
+
+	t.Run("RuneMoved without alias is ignored", func(t *testing.T) {
+		tc := newRuneChildCountTestContext(t)
+
+		// Given
+		tc.a_rune_child_count_projector()
+		tc.a_store()
+		tc.a_rune_moved_event("bf-a.1", "bf-b", "bf-a", "")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.no_entry_stored()
+	})
 }
 
 // --- Test Context ---
@@ -238,6 +271,13 @@ func (tc *runeChildCountTestContext) a_rune_created_event_with_parent(id, parent
 	})
 }
 
+func (tc *runeChildCountTestContext) a_rune_moved_event(id, oldParentID, newParentID, alias string) {
+	tc.t.Helper()
+	tc.event = makeEvent(domain.EventRuneMoved, domain.RuneMoved{
+		ID: id, OldParentID: oldParentID, NewParentID: newParentID, Alias: alias, Status: "open",
+	})
+}
+
 func (tc *runeChildCountTestContext) a_rune_created_event_without_parent(id string) {
 	tc.t.Helper()
 	tc.event = makeEvent(domain.EventRuneCreated, domain.RuneCreated{
@@ -20,9 +20,13 @@ type NoteEntry struct {
 }
 
 type ACEntry struct {
-	ID          string `json:"id"`
-	Scenario    string `json:"scenario"`
-	Description string `json:"description"`
+	ID          string     `json:"id"`
+	Scenario    string     `json:"scenario"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Evidence    string     `json:"evidence,omitempty"`
+	Verifier    string     `json:"verifier,omitempty"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
 }
 
 type RuneDetail struct {
@@ -32,10 +36,14 @@ type RuneDetail struct {
 	Status             string          `json:"status"`
 	Priority           int             `json:"priority"`
 	Claimant           string          `json:"claimant,omitempty"`
+	ClaimedByAgent     bool            `json:"claimed_by_agent,omitempty"`
 	ParentID           string          `json:"parent_id,omitempty"`
+	Alias              string          `json:"alias,omitempty"`
 	Branch             string          `json:"branch,omitempty"`
 	Tags               []string        `json:"tags"`
 	Type               string          `json:"type,omitempty"`
+	DueAt              *time.Time      `json:"due_at,omitempty"`
+	DeferUntil         *time.Time      `json:"defer_until,omitempty"`
 	Dependencies       []DependencyRef `json:"dependencies"`
 	Notes              []NoteEntry     `json:"notes"`
 	RetroItems         []RetroEntry    `json:"retro_items"`
@@ -98,8 +106,12 @@ func (p *RuneDetailProjector) Handle(ctx context.Context, event core.Event, stor
 		return p.handleACUpdated(ctx, event, store)
 	case domain.EventRuneACRemoved:
 		return p.handleACRemoved(ctx, event, store)
+	case domain.EventRuneACVerified:
+		return p.handleACVerified(ctx, event, store)
 	case domain.EventRuneStateUpdated:
 		return p.handleStateUpdated(ctx, event, store)
+	case domain.EventRuneMoved:
+		return p.handleMoved(ctx, event, store)
 	}
 	return nil
 }
@@ -119,6 +131,8 @@ func (p *RuneDetailProjector) handleCreated(ctx context.Context, event core.Even
 		Branch:             data.Branch,
 		Tags:               normalizeTags(data.Tags),
 		Type:               data.Type,
+		DueAt:              data.DueAt,
+		DeferUntil:         data.DeferUntil,
 		Dependencies:       []DependencyRef{},
 		Notes:              []NoteEntry{},
 		RetroItems:         []RetroEntry{},
@@ -130,6 +144,21 @@ func (p *RuneDetailProjector) handleCreated(ctx context.Context, event core.Even
 	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.ID, detail)
 }
 
+func (p *RuneDetailProjector) handleMoved(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneMoved
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	detail, err := core.GetRef(ctx, store, event.RealmID, RuneDetailTable, data.ID)
+	if err != nil {
+		return err
+	}
+	detail.ParentID = data.NewParentID
+	detail.Alias = data.Alias
+	detail.UpdatedAt = event.Timestamp
+	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.ID, detail)
+}
+
 func (p *RuneDetailProjector) handleForged(ctx context.Context, event core.Event, store core.ProjectionStore) error {
 	var data domain.RuneForged
 	if err := json.Unmarshal(event.Data, &data); err != nil {
@@ -166,6 +195,8 @@ func (p *RuneDetailProjector) handleUpdated(ctx context.Context, event core.Even
 		detail.Branch = *data.Branch
 	}
 	detail.Tags = applyTagMutations(detail.Tags, data.Tags, data.AddTags, data.RemoveTags)
+	detail.DueAt = domain.ApplyTimeMutation(detail.DueAt, data.DueAt, data.ClearDueAt)
+	detail.DeferUntil = domain.ApplyTimeMutation(detail.DeferUntil, data.DeferUntil, data.ClearDeferUntil)
 	detail.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.ID, detail)
 }
@@ -181,6 +212,7 @@ func (p *RuneDetailProjector) handleClaimed(ctx context.Context, event core.Even
 	}
 	detail.Status = "claimed"
 	detail.Claimant = data.Claimant
+	detail.ClaimedByAgent = data.Agent
 	detail.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.ID, detail)
 }
@@ -247,6 +279,7 @@ func (p *RuneDetailProjector) handleReopened(ctx context.Context, event core.Eve
 		detail.Status = "open"
 		detail.Claimant = ""
 	}
+	detail.ClaimedByAgent = false
 	detail.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.ID, detail)
 }
@@ -262,6 +295,7 @@ func (p *RuneDetailProjector) handleUnclaimed(ctx context.Context, event core.Ev
 	}
 	detail.Status = "open"
 	detail.Claimant = ""
+	detail.ClaimedByAgent = false
 	detail.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.ID, detail)
 }
@@ -405,6 +439,7 @@ func (p *RuneDetailProjector) handleACAdded(ctx context.Context, event core.Even
 		ID:          data.ID,
 		Scenario:    data.Scenario,
 		Description: data.Description,
+		Status:      domain.ACStatusPending,
 	})
 	detail.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.RuneID, detail)
@@ -419,11 +454,15 @@ func (p *RuneDetailProjector) handleACUpdated(ctx context.Context, event core.Ev
 	if err != nil {
 		return err
 	}
-	// Find and update the AC entry
+	// Find and update the AC entry; a changed criterion must be verified again
 	for i, ac := range detail.AcceptanceCriteria {
 		if ac.ID == data.ID {
-			detail.AcceptanceCriteria[i].Scenario = data.Scenario
-			detail.AcceptanceCriteria[i].Description = data.Description
+			detail.AcceptanceCriteria[i] = ACEntry{
+				ID:          ac.ID,
+				Scenario:    data.Scenario,
+				Description: data.Description,
+				Status:      domain.ACStatusPending,
+			}
 			break
 		}
 	}
@@ -452,6 +491,29 @@ func (p *RuneDetailProjector) handleACRemoved(ctx context.Context, event core.Ev
 	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.RuneID, detail)
 }
 
+func (p *RuneDetailProjector) handleACVerified(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneACVerified
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	detail, err := core.GetRef(ctx, store, event.RealmID, RuneDetailTable, data.RuneID)
+	if err != nil {
+		return err
+	}
+	verifiedAt := event.Timestamp
+	for i, ac := range detail.AcceptanceCriteria {
+		if ac.ID == data.ID {
+			detail.AcceptanceCriteria[i].Status = data.Status
+			detail.AcceptanceCriteria[i].Evidence = data.Evidence
+			detail.AcceptanceCriteria[i].Verifier = data.Verifier
+			detail.AcceptanceCriteria[i].VerifiedAt = &verifiedAt
+			break
+		}
+	}
+	detail.UpdatedAt = event.Timestamp
+	return core.PutRef(ctx, store, event.RealmID, RuneDetailTable, data.RuneID, detail)
+}
+
 func (p *RuneDetailProjector) handleStateUpdated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
 	var data domain.RuneStateUpdated
 	if err := json.Unmarshal(event.Data, &data); err != nil {
@@ -85,6 +85,61 @@ func TestRuneDetailProjector_AcceptanceCriteria(t *testing.T) {
 		tc.stored_detail_has_ac_entry(0, "AC-01", "new scenario", "new desc")
 	})
 
+	t.Run("handles RuneACAdded with pending status", func(t *testing.T) {
+		tc := newRuneDetailTestContext(t)
+
+		// Given
+		tc.a_rune_detail_projector()
+		tc.a_store()
+		tc.existing_detail("bf-a1b2", "Fix the bridge", "", "open", 1, "", "")
+		tc.a_rune_ac_added_event("bf-a1b2", "AC-01", "happy path", "desc")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.stored_detail_ac_has_status(0, domain.ACStatusPending)
+	})
+
+	t.Run("handles RuneACVerified by recording status, evidence and verifier", func(t *testing.T) {
+		tc := newRuneDetailTestContext(t)
+
+		// Given
+		tc.a_rune_detail_projector()
+		tc.a_store()
+		tc.existing_detail_with_ac("bf-a1b2", "AC-01", "happy path", "desc")
+		tc.a_rune_ac_verified_event("bf-a1b2", "AC-01", domain.ACStatusFailed, "TestHappyPath: timeout", "agent-1")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.stored_detail_ac_has_status(0, domain.ACStatusFailed)
+		tc.stored_detail_ac_has_verification(0, "TestHappyPath: timeout", "agent-1")
+	})
+
+	t.Run("handles RuneACUpdated by resetting verification to pending", func(t *testing.T) {
+		tc := newRuneDetailTestContext(t)
+
+		// Given
+		tc.a_rune_detail_projector()
+		tc.a_store()
+		tc.existing_detail_with_multiple_acs("bf-a1b2",
+			ACEntry{ID: "AC-01", Scenario: "old", Description: "old desc", Status: domain.ACStatusVerified, Evidence: "ok", Verifier: "agent-1"},
+		)
+		tc.a_rune_ac_updated_event("bf-a1b2", "AC-01", "new scenario", "new desc")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.stored_detail_ac_has_status(0, domain.ACStatusPending)
+		tc.stored_detail_ac_has_verification(0, "", "")
+	})
+
 	t.Run("US4-AC01: handles RuneACRemoved by removing the AC entry", func(t *testing.T) {
 		tc := newRuneDetailTestContext(t)
 
@@ -187,6 +242,13 @@ func (tc *runeDetailTestContext) a_rune_ac_updated_event(runeID, acID, scenario,
 	})
 }
 
+func (tc *runeDetailTestContext) a_rune_ac_verified_event(runeID, acID, status, evidence, verifier string) {
+	tc.t.Helper()
+	tc.event = makeEvent(domain.EventRuneACVerified, domain.RuneACVerified{
+		RuneID: runeID, ID: acID, Status: status, Evidence: evidence, Verifier: verifier,
+	})
+}
+
 func (tc *runeDetailTestContext) a_rune_ac_removed_event(runeID, acID string) {
 	tc.t.Helper()
 	tc.event = makeEvent(domain.EventRuneACRemoved, domain.RuneACRemoved{
@@ -289,3 +351,24 @@ func (tc *runeDetailTestContext) stored_detail_has_ac_description_field(index in
 	require.Greater(tc.t, len(tc.storedDetail.AcceptanceCriteria), index)
 	assert.Equal(tc.t, expected, tc.storedDetail.AcceptanceCriteria[index].Description)
 }
+
+func (tc *runeDetailTestContext) stored_detail_ac_has_status(index int, expected string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.storedDetail)
+	require.Greater(tc.t, len(tc.storedDetail.AcceptanceCriteria), index)
+	assert.Equal(tc.t, expected, tc.storedDetail.AcceptanceCriteria[index].Status)
+}
+
+func (tc *runeDetailTestContext) stored_detail_ac_has_verification(index int, evidence, verifier string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.storedDetail)
+	require.Greater(tc.t, len(tc.storedDetail.AcceptanceCriteria), index)
+	entry := tc.storedDetail.AcceptanceCriteria[index]
+	assert.Equal(tc.t, evidence, entry.Evidence)
+	assert.Equal(tc.t, verifier, entry.Verifier)
+	if verifier == "" {
+		assert.Nil(tc.t, entry.VerifiedAt)
+	} else {
+		assert.NotNil(tc.t, entry.VerifiedAt)
+	}
+}
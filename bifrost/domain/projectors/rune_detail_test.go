@@ -470,6 +470,24 @@ func TestRuneDetailProjector(t *testing.T) {
 		tc.detail_was_stored("bf-a1b2")
 		tc.stored_detail_has_type("bug")
 	})
+
+	t.Run("handles RuneMoved by updating parent ID and alias", func(t *testing.T) {
+		tc := newRuneDetailTestContext(t)
+
+		// Given
+		tc.a_rune_detail_projector()
+		tc.a_store()
+		tc.existing_detail("bf-a.1", "Child task", "", "open", 1, "", "bf-a")
+		tc.a_rune_moved_event("bf-a.1", "bf-a", "", "bf-c3d4")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.stored_detail_has_parent_id("")
+		tc.stored_detail_has_alias("bf-c3d4")
+	})
 }
 
 // --- Test Context ---
@@ -604,6 +622,13 @@ func (tc *runeDetailTestContext) a_rune_shattered_event(id string) {
 	})
 }
 
+func (tc *runeDetailTestContext) a_rune_moved_event(id, oldParentID, newParentID, alias string) {
+	tc.t.Helper()
+	tc.event = makeEvent(domain.EventRuneMoved, domain.RuneMoved{
+		ID: id, OldParentID: oldParentID, NewParentID: newParentID, Alias: alias, Status: "open",
+	})
+}
+
 func (tc *runeDetailTestContext) an_unknown_event() {
 	tc.t.Helper()
 	tc.event = core.Event{EventType: "UnknownEvent", Data: []byte(`{}`)}
@@ -768,6 +793,12 @@ func (tc *runeDetailTestContext) stored_detail_has_parent_id(expected string) {
 	assert.Equal(tc.t, expected, tc.storedDetail.ParentID)
 }
 
+func (tc *runeDetailTestContext) stored_detail_has_alias(expected string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.storedDetail)
+	assert.Equal(tc.t, expected, tc.storedDetail.Alias)
+}
+
 func (tc *runeDetailTestContext) stored_detail_has_empty_dependencies() {
 	tc.t.Helper()
 	require.NotNil(tc.t, tc.storedDetail)
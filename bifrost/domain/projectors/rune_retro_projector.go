@@ -87,6 +87,8 @@ func (p *RuneRetroProjector) Handle(ctx context.Context, event core.Event, store
 		})
 	case domain.EventRuneRetroed:
 		return p.handleRetroed(ctx, event, store)
+	case domain.EventRuneMoved:
+		return p.handleMoved(ctx, event, store)
 	}
 	return nil
 }
@@ -139,6 +141,20 @@ func (p *RuneRetroProjector) handleStatusChange(ctx context.Context, event core.
 	return core.PutRef(ctx, store, event.RealmID, RuneRetroTable, id, retro)
 }
 
+func (p *RuneRetroProjector) handleMoved(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneMoved
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	retro, err := core.GetRef(ctx, store, event.RealmID, RuneRetroTable, data.ID)
+	if err != nil {
+		return err
+	}
+	retro.ParentID = data.NewParentID
+	retro.UpdatedAt = event.Timestamp
+	return core.PutRef(ctx, store, event.RealmID, RuneRetroTable, data.ID, retro)
+}
+
 func (p *RuneRetroProjector) handleRetroed(ctx context.Context, event core.Event, store core.ProjectionStore) error {
 	var data domain.RuneRetroed
 	if err := json.Unmarshal(event.Data, &data); err != nil {
@@ -0,0 +1,218 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// RuneSearchDocument is the text of a rune that full-text search indexes.
+// The flattened fields are rebuilt from the items on every write, so the
+// index only has to read top-level strings.
+type RuneSearchDocument struct {
+	ID                 string `json:"id"`
+	Title              string `json:"title"`
+	Description        string `json:"description"`
+	Notes              string `json:"notes"`
+	Retros             string `json:"retros"`
+	AcceptanceCriteria string `json:"acceptance_criteria"`
+
+	NoteItems  []NoteEntry  `json:"note_items"`
+	RetroItems []RetroEntry `json:"retro_items"`
+	ACItems    []ACEntry    `json:"ac_items"`
+}
+
+// RuneSearchTable is the typed table reference for this projector.
+var RuneSearchTable = core.TableRef[RuneSearchDocument]{Name: "rune_search"}
+
+// RuneSearchFields are the indexed fields of RuneSearchDocument, most
+// important first.
+var RuneSearchFields = []string{"title", "description", "acceptance_criteria", "notes", "retros"}
+
+// RuneSearchProjector keeps the searchable text of each rune. Shattered runes
+// are removed, like their summaries.
+type RuneSearchProjector struct{}
+
+func NewRuneSearchProjector() *RuneSearchProjector {
+	return &RuneSearchProjector{}
+}
+
+func (p *RuneSearchProjector) Name() string {
+	return RuneSearchTable.Name
+}
+
+func (p *RuneSearchProjector) TableName() string {
+	return RuneSearchTable.Name
+}
+
+func (p *RuneSearchProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventRuneCreated:
+		return p.handleCreated(ctx, event, store)
+	case domain.EventRuneUpdated:
+		return p.handleUpdated(ctx, event, store)
+	case domain.EventRuneNoted:
+		return p.handleNoted(ctx, event, store)
+	case domain.EventRuneRetroed:
+		return p.handleRetroed(ctx, event, store)
+	case domain.EventRuneACAdded:
+		return p.handleACAdded(ctx, event, store)
+	case domain.EventRuneACUpdated:
+		return p.handleACUpdated(ctx, event, store)
+	case domain.EventRuneACRemoved:
+		return p.handleACRemoved(ctx, event, store)
+	case domain.EventRuneShattered:
+		return p.handleShattered(ctx, event, store)
+	}
+	return nil
+}
+
+func (p *RuneSearchProjector) handleCreated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneCreated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	doc := RuneSearchDocument{ID: data.ID, Title: data.Title, Description: data.Description}
+	return p.put(ctx, store, event.RealmID, doc)
+}
+
+func (p *RuneSearchProjector) handleUpdated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneUpdated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.update(ctx, store, event.RealmID, data.ID, func(doc *RuneSearchDocument) bool {
+		if data.Title != nil {
+			doc.Title = *data.Title
+		}
+		if data.Description != nil {
+			doc.Description = *data.Description
+		}
+		return true
+	})
+}
+
+func (p *RuneSearchProjector) handleNoted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneNoted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.update(ctx, store, event.RealmID, data.RuneID, func(doc *RuneSearchDocument) bool {
+		// Notes are unique by text + timestamp, so a replayed event is skipped
+		for _, note := range doc.NoteItems {
+			if note.Text == data.Text && note.CreatedAt.Equal(event.Timestamp) {
+				return false
+			}
+		}
+		doc.NoteItems = append(doc.NoteItems, NoteEntry{Text: data.Text, CreatedAt: event.Timestamp})
+		return true
+	})
+}
+
+func (p *RuneSearchProjector) handleRetroed(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneRetroed
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.update(ctx, store, event.RealmID, data.RuneID, func(doc *RuneSearchDocument) bool {
+		for _, item := range doc.RetroItems {
+			if item.Text == data.Text && item.CreatedAt.Equal(event.Timestamp) {
+				return false
+			}
+		}
+		doc.RetroItems = append(doc.RetroItems, RetroEntry{Text: data.Text, CreatedAt: event.Timestamp})
+		return true
+	})
+}
+
+func (p *RuneSearchProjector) handleACAdded(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneACAdded
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.update(ctx, store, event.RealmID, data.RuneID, func(doc *RuneSearchDocument) bool {
+		for _, ac := range doc.ACItems {
+			if ac.ID == data.ID {
+				return false
+			}
+		}
+		doc.ACItems = append(doc.ACItems, ACEntry{ID: data.ID, Scenario: data.Scenario, Description: data.Description})
+		return true
+	})
+}
+
+func (p *RuneSearchProjector) handleACUpdated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneACUpdated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.update(ctx, store, event.RealmID, data.RuneID, func(doc *RuneSearchDocument) bool {
+		for i := range doc.ACItems {
+			if doc.ACItems[i].ID == data.ID {
+				doc.ACItems[i].Scenario = data.Scenario
+				doc.ACItems[i].Description = data.Description
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (p *RuneSearchProjector) handleACRemoved(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneACRemoved
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.update(ctx, store, event.RealmID, data.RuneID, func(doc *RuneSearchDocument) bool {
+		for i, ac := range doc.ACItems {
+			if ac.ID == data.ID {
+				doc.ACItems = append(doc.ACItems[:i], doc.ACItems[i+1:]...)
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (p *RuneSearchProjector) handleShattered(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneShattered
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return core.DeleteRef(ctx, store, event.RealmID, RuneSearchTable, data.ID)
+}
+
+// update applies change to the rune's document and stores it if change
+// reports that it modified the document.
+func (p *RuneSearchProjector) update(ctx context.Context, store core.ProjectionStore, realmID, runeID string, change func(*RuneSearchDocument) bool) error {
+	doc, err := core.GetRef(ctx, store, realmID, RuneSearchTable, runeID)
+	if err != nil {
+		return err
+	}
+	if !change(&doc) {
+		return nil
+	}
+	return p.put(ctx, store, realmID, doc)
+}
+
+func (p *RuneSearchProjector) put(ctx context.Context, store core.ProjectionStore, realmID string, doc RuneSearchDocument) error {
+	notes := make([]string, 0, len(doc.NoteItems))
+	for _, note := range doc.NoteItems {
+		notes = append(notes, note.Text)
+	}
+	retros := make([]string, 0, len(doc.RetroItems))
+	for _, item := range doc.RetroItems {
+		retros = append(retros, item.Text)
+	}
+	acs := make([]string, 0, len(doc.ACItems))
+	for _, ac := range doc.ACItems {
+		acs = append(acs, ac.Scenario+": "+ac.Description)
+	}
+	doc.Notes = strings.Join(notes, "\n")
+	doc.Retros = strings.Join(retros, "\n")
+	doc.AcceptanceCriteria = strings.Join(acs, "\n")
+	return core.PutRef(ctx, store, realmID, RuneSearchTable, doc.ID, doc)
+}
@@ -0,0 +1,165 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuneSearchProjector(t *testing.T) {
+	t.Run("Name and TableName return rune_search", func(t *testing.T) {
+		p := NewRuneSearchProjector()
+		assert.Equal(t, "rune_search", p.Name())
+		assert.Equal(t, "rune_search", p.TableName())
+	})
+
+	t.Run("handles RuneCreated by indexing title and description", func(t *testing.T) {
+		tc := newRuneSearchTestContext(t)
+
+		// When
+		tc.handle(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-a1b2", Title: "Fix login redirect", Description: "Blank page after SSO"})
+
+		// Then
+		doc := tc.stored_document("bf-a1b2")
+		assert.Equal(t, "Fix login redirect", doc.Title)
+		assert.Equal(t, "Blank page after SSO", doc.Description)
+	})
+
+	t.Run("handles RuneUpdated by replacing changed fields", func(t *testing.T) {
+		tc := newRuneSearchTestContext(t)
+
+		// Given
+		tc.handle(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-a1b2", Title: "Old", Description: "Kept"})
+
+		// When
+		tc.handle(domain.EventRuneUpdated, domain.RuneUpdated{ID: "bf-a1b2", Title: strPtr("New")})
+
+		// Then
+		doc := tc.stored_document("bf-a1b2")
+		assert.Equal(t, "New", doc.Title)
+		assert.Equal(t, "Kept", doc.Description)
+	})
+
+	t.Run("flattens notes and retro items", func(t *testing.T) {
+		tc := newRuneSearchTestContext(t)
+
+		// Given
+		tc.handle(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-a1b2", Title: "Task"})
+
+		// When
+		tc.handle(domain.EventRuneNoted, domain.RuneNoted{RuneID: "bf-a1b2", Text: "first note"})
+		tc.handle(domain.EventRuneNoted, domain.RuneNoted{RuneID: "bf-a1b2", Text: "second note"})
+		tc.handle(domain.EventRuneRetroed, domain.RuneRetroed{RuneID: "bf-a1b2", Text: "went well"})
+
+		// Then
+		doc := tc.stored_document("bf-a1b2")
+		assert.Equal(t, "first note\nsecond note", doc.Notes)
+		assert.Equal(t, "went well", doc.Retros)
+	})
+
+	t.Run("skips a replayed note", func(t *testing.T) {
+		tc := newRuneSearchTestContext(t)
+
+		// Given
+		tc.handle(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-a1b2", Title: "Task"})
+		event := makeEventWithTimestamp(domain.EventRuneNoted, domain.RuneNoted{RuneID: "bf-a1b2", Text: "note"}, time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC))
+
+		// When
+		tc.handle_event(event)
+		tc.handle_event(event)
+
+		// Then
+		assert.Equal(t, "note", tc.stored_document("bf-a1b2").Notes)
+	})
+
+	t.Run("keeps acceptance criteria text current", func(t *testing.T) {
+		tc := newRuneSearchTestContext(t)
+
+		// Given
+		tc.handle(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-a1b2", Title: "Task"})
+		tc.handle(domain.EventRuneACAdded, domain.RuneACAdded{RuneID: "bf-a1b2", ID: "AC-01", Scenario: "Login", Description: "redirects home"})
+		tc.handle(domain.EventRuneACAdded, domain.RuneACAdded{RuneID: "bf-a1b2", ID: "AC-02", Scenario: "Logout", Description: "clears session"})
+
+		// When
+		tc.handle(domain.EventRuneACUpdated, domain.RuneACUpdated{RuneID: "bf-a1b2", ID: "AC-01", Scenario: "Login", Description: "redirects back"})
+		tc.handle(domain.EventRuneACRemoved, domain.RuneACRemoved{RuneID: "bf-a1b2", ID: "AC-02"})
+
+		// Then
+		assert.Equal(t, "Login: redirects back", tc.stored_document("bf-a1b2").AcceptanceCriteria)
+	})
+
+	t.Run("handles RuneShattered by deleting the document", func(t *testing.T) {
+		tc := newRuneSearchTestContext(t)
+
+		// Given
+		tc.handle(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-a1b2", Title: "Task"})
+
+		// When
+		tc.handle(domain.EventRuneShattered, domain.RuneShattered{ID: "bf-a1b2"})
+
+		// Then
+		tc.document_does_not_exist("bf-a1b2")
+	})
+
+	t.Run("ignores unrelated events", func(t *testing.T) {
+		tc := newRuneSearchTestContext(t)
+
+		// When
+		tc.handle(domain.EventRuneClaimed, domain.RuneClaimed{ID: "bf-a1b2", Claimant: "odin"})
+
+		// Then
+		tc.document_does_not_exist("bf-a1b2")
+	})
+}
+
+// --- Test Context ---
+
+type runeSearchTestContext struct {
+	t         *testing.T
+	ctx       context.Context
+	projector *RuneSearchProjector
+	store     *mockProjectionStore
+}
+
+func newRuneSearchTestContext(t *testing.T) *runeSearchTestContext {
+	t.Helper()
+	return &runeSearchTestContext{
+		t:         t,
+		ctx:       context.Background(),
+		projector: NewRuneSearchProjector(),
+		store:     newMockProjectionStore(),
+	}
+}
+
+// --- When ---
+
+func (tc *runeSearchTestContext) handle(eventType string, data any) {
+	tc.t.Helper()
+	tc.handle_event(makeEvent(eventType, data))
+}
+
+func (tc *runeSearchTestContext) handle_event(event core.Event) {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.projector.Handle(tc.ctx, event, tc.store))
+}
+
+// --- Then ---
+
+func (tc *runeSearchTestContext) stored_document(id string) RuneSearchDocument {
+	tc.t.Helper()
+	doc, err := core.GetRef(tc.ctx, tc.store, "realm-1", RuneSearchTable, id)
+	require.NoError(tc.t, err)
+	return doc
+}
+
+func (tc *runeSearchTestContext) document_does_not_exist(id string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "realm-1", RuneSearchTable, id)
+	var nfe *core.NotFoundError
+	assert.ErrorAs(tc.t, err, &nfe)
+}
@@ -11,17 +11,21 @@ import (
 
 // RuneSummary represents a projected view of a rune for list queries.
 type RuneSummary struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Status    string    `json:"status"`
-	Priority  int       `json:"priority"`
-	Claimant  string    `json:"claimant,omitempty"`
-	ParentID  string    `json:"parent_id,omitempty"`
-	Branch    string    `json:"branch,omitempty"`
-	Tags      []string  `json:"tags"`
-	Type      string    `json:"type,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Status         string     `json:"status"`
+	Priority       int        `json:"priority"`
+	Claimant       string     `json:"claimant,omitempty"`
+	ClaimedByAgent bool       `json:"claimed_by_agent,omitempty"`
+	ParentID       string     `json:"parent_id,omitempty"`
+	Alias          string     `json:"alias,omitempty"`
+	Branch         string     `json:"branch,omitempty"`
+	Tags           []string   `json:"tags"`
+	Type           string     `json:"type,omitempty"`
+	DueAt          *time.Time `json:"due_at,omitempty"`
+	DeferUntil     *time.Time `json:"defer_until,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
 // RuneSummaryTable is the typed table reference for this projector.
@@ -68,6 +72,8 @@ func (p *RuneSummaryProjector) Handle(ctx context.Context, event core.Event, sto
 		return p.handleUnclaimed(ctx, event, store)
 	case domain.EventRuneShattered:
 		return p.handleShattered(ctx, event, store)
+	case domain.EventRuneMoved:
+		return p.handleMoved(ctx, event, store)
 	}
 	return nil
 }
@@ -78,16 +84,18 @@ func (p *RuneSummaryProjector) handleCreated(ctx context.Context, event core.Eve
 		return err
 	}
 	summary := RuneSummary{
-		ID:        data.ID,
-		Title:     data.Title,
-		Status:    "draft",
-		Priority:  data.Priority,
-		ParentID:  data.ParentID,
-		Branch:    data.Branch,
-		Tags:      normalizeTags(data.Tags),
-		Type:      data.Type,
-		CreatedAt: event.Timestamp,
-		UpdatedAt: event.Timestamp,
+		ID:         data.ID,
+		Title:      data.Title,
+		Status:     "draft",
+		Priority:   data.Priority,
+		ParentID:   data.ParentID,
+		Branch:     data.Branch,
+		Tags:       normalizeTags(data.Tags),
+		Type:       data.Type,
+		DueAt:      data.DueAt,
+		DeferUntil: data.DeferUntil,
+		CreatedAt:  event.Timestamp,
+		UpdatedAt:  event.Timestamp,
 	}
 	return core.PutRef(ctx, store, event.RealmID, RuneSummaryTable, data.ID, summary)
 }
@@ -125,6 +133,8 @@ func (p *RuneSummaryProjector) handleUpdated(ctx context.Context, event core.Eve
 		summary.Branch = *data.Branch
 	}
 	summary.Tags = applyTagMutations(summary.Tags, data.Tags, data.AddTags, data.RemoveTags)
+	summary.DueAt = domain.ApplyTimeMutation(summary.DueAt, data.DueAt, data.ClearDueAt)
+	summary.DeferUntil = domain.ApplyTimeMutation(summary.DeferUntil, data.DeferUntil, data.ClearDeferUntil)
 	summary.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneSummaryTable, data.ID, summary)
 }
@@ -140,6 +150,7 @@ func (p *RuneSummaryProjector) handleClaimed(ctx context.Context, event core.Eve
 	}
 	summary.Status = "claimed"
 	summary.Claimant = data.Claimant
+	summary.ClaimedByAgent = data.Agent
 	summary.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneSummaryTable, data.ID, summary)
 }
@@ -202,6 +213,7 @@ func (p *RuneSummaryProjector) handleReopened(ctx context.Context, event core.Ev
 		summary.Status = "open"
 		summary.Claimant = ""
 	}
+	summary.ClaimedByAgent = false
 	summary.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneSummaryTable, data.ID, summary)
 }
@@ -217,6 +229,22 @@ func (p *RuneSummaryProjector) handleUnclaimed(ctx context.Context, event core.E
 	}
 	summary.Status = "open"
 	summary.Claimant = ""
+	summary.ClaimedByAgent = false
+	summary.UpdatedAt = event.Timestamp
+	return core.PutRef(ctx, store, event.RealmID, RuneSummaryTable, data.ID, summary)
+}
+
+func (p *RuneSummaryProjector) handleMoved(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneMoved
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	summary, err := core.GetRef(ctx, store, event.RealmID, RuneSummaryTable, data.ID)
+	if err != nil {
+		return err
+	}
+	summary.ParentID = data.NewParentID
+	summary.Alias = data.Alias
 	summary.UpdatedAt = event.Timestamp
 	return core.PutRef(ctx, store, event.RealmID, RuneSummaryTable, data.ID, summary)
 }
@@ -132,6 +132,41 @@ func TestRuneSummaryProjector(t *testing.T) {
 		tc.stored_summary_has_status("open")
 	})
 
+	t.Run("handles RuneCreated with due and defer times", func(t *testing.T) {
+		tc := newRuneSummaryTestContext(t)
+
+		// Given
+		tc.a_rune_summary_projector()
+		tc.a_store()
+		tc.a_rune_created_event_with_schedule("bf-a1b2", dateOf(2026, 3, 1), dateOf(2026, 2, 1))
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.stored_summary_has_due_at(dateOf(2026, 3, 1))
+		tc.stored_summary_has_defer_until(dateOf(2026, 2, 1))
+	})
+
+	t.Run("handles RuneUpdated schedule changes and clears", func(t *testing.T) {
+		tc := newRuneSummaryTestContext(t)
+
+		// Given
+		tc.a_rune_summary_projector()
+		tc.a_store()
+		tc.existing_summary_with_schedule("bf-a1b2", dateOf(2026, 3, 1), dateOf(2026, 2, 1))
+		tc.a_rune_updated_event_with_schedule("bf-a1b2", dateOf(2026, 4, 1), true)
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.stored_summary_has_due_at(dateOf(2026, 4, 1))
+		tc.stored_summary_has_defer_until(nil)
+	})
+
 	t.Run("handles RuneUpdated with partial fields", func(t *testing.T) {
 		tc := newRuneSummaryTestContext(t)
 
@@ -220,6 +255,26 @@ func TestRuneSummaryProjector(t *testing.T) {
 		tc.stored_summary_has_claimant("odin")
 	})
 
+	t.Run("handles RuneClaimed by an agent by labelling the claim", func(t *testing.T) {
+		tc := newRuneSummaryTestContext(t)
+
+		// Given
+		tc.a_rune_summary_projector()
+		tc.a_store()
+		tc.existing_summary("bf-a1b2", "Fix the bridge", "open", 1, "", "")
+		tc.event = makeEvent(domain.EventRuneClaimed, domain.RuneClaimed{
+			ID: "bf-a1b2", Claimant: "ci-bot", Agent: true,
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.stored_summary_has_claimant("ci-bot")
+		tc.stored_summary_is_claimed_by_agent(true)
+	})
+
 	t.Run("handles RuneUnclaimed by setting status to open and clearing claimant", func(t *testing.T) {
 		tc := newRuneSummaryTestContext(t)
 
@@ -371,6 +426,25 @@ func TestRuneSummaryProjector(t *testing.T) {
 		tc.no_error()
 		tc.read_modify_write_was_used("bf-a1b2")
 	})
+
+	t.Run("handles RuneMoved by updating parent ID and alias", func(t *testing.T) {
+		tc := newRuneSummaryTestContext(t)
+
+		// Given
+		tc.a_rune_summary_projector()
+		tc.a_store()
+		tc.existing_summary("bf-a.1", "Child task", "open", 1, "", "bf-a")
+		tc.a_rune_moved_event("bf-a.1", "bf-a", "bf-b", "bf-b.2")
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.summary_was_stored("bf-a.1")
+		tc.stored_summary_has_parent_id("bf-b")
+		tc.stored_summary_has_alias("bf-b.2")
+	})
 }
 
 // --- Test Context ---
@@ -467,6 +541,28 @@ func (tc *runeSummaryTestContext) a_rune_updated_event_with_timestamp(id string,
 	}, time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC))
 }
 
+func (tc *runeSummaryTestContext) a_rune_created_event_with_schedule(id string, dueAt, deferUntil *time.Time) {
+	tc.t.Helper()
+	tc.event = makeEvent(domain.EventRuneCreated, domain.RuneCreated{
+		ID: id, Title: "Scheduled", DueAt: dueAt, DeferUntil: deferUntil,
+	})
+}
+
+func (tc *runeSummaryTestContext) a_rune_updated_event_with_schedule(id string, dueAt *time.Time, clearDefer bool) {
+	tc.t.Helper()
+	tc.event = makeEvent(domain.EventRuneUpdated, domain.RuneUpdated{
+		ID: id, DueAt: dueAt, ClearDeferUntil: clearDefer,
+	})
+}
+
+func (tc *runeSummaryTestContext) existing_summary_with_schedule(id string, dueAt, deferUntil *time.Time) {
+	tc.t.Helper()
+	summary := RuneSummary{
+		ID: id, Title: "Scheduled", Status: "open", Tags: []string{}, DueAt: dueAt, DeferUntil: deferUntil,
+	}
+	tc.store.put(tc.realmID, "rune_summary", id, summary)
+}
+
 func (tc *runeSummaryTestContext) a_rune_claimed_event(id, claimant string) {
 	tc.t.Helper()
 	tc.event = makeEvent(domain.EventRuneClaimed, domain.RuneClaimed{
@@ -509,6 +605,13 @@ func (tc *runeSummaryTestContext) a_rune_shattered_event(id string) {
 	})
 }
 
+func (tc *runeSummaryTestContext) a_rune_moved_event(id, oldParentID, newParentID, alias string) {
+	tc.t.Helper()
+	tc.event = makeEvent(domain.EventRuneMoved, domain.RuneMoved{
+		ID: id, OldParentID: oldParentID, NewParentID: newParentID, Alias: alias, Status: "open",
+	})
+}
+
 func (tc *runeSummaryTestContext) an_unknown_event() {
 	tc.t.Helper()
 	tc.event = core.Event{
@@ -634,18 +737,42 @@ func (tc *runeSummaryTestContext) stored_summary_has_priority(expected int) {
 	assert.Equal(tc.t, expected, tc.storedSummary.Priority)
 }
 
+func (tc *runeSummaryTestContext) stored_summary_has_due_at(expected *time.Time) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.storedSummary)
+	assert.Equal(tc.t, expected, tc.storedSummary.DueAt)
+}
+
+func (tc *runeSummaryTestContext) stored_summary_has_defer_until(expected *time.Time) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.storedSummary)
+	assert.Equal(tc.t, expected, tc.storedSummary.DeferUntil)
+}
+
 func (tc *runeSummaryTestContext) stored_summary_has_claimant(expected string) {
 	tc.t.Helper()
 	require.NotNil(tc.t, tc.storedSummary)
 	assert.Equal(tc.t, expected, tc.storedSummary.Claimant)
 }
 
+func (tc *runeSummaryTestContext) stored_summary_is_claimed_by_agent(expected bool) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.storedSummary)
+	assert.Equal(tc.t, expected, tc.storedSummary.ClaimedByAgent)
+}
+
 func (tc *runeSummaryTestContext) stored_summary_has_parent_id(expected string) {
 	tc.t.Helper()
 	require.NotNil(tc.t, tc.storedSummary)
 	assert.Equal(tc.t, expected, tc.storedSummary.ParentID)
 }
 
+func (tc *runeSummaryTestContext) stored_summary_has_alias(expected string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.storedSummary)
+	assert.Equal(tc.t, expected, tc.storedSummary.Alias)
+}
+
 func (tc *runeSummaryTestContext) stored_summary_has_branch(expected string) {
 	tc.t.Helper()
 	require.NotNil(tc.t, tc.storedSummary)
@@ -749,6 +876,11 @@ func (tc *runeSummaryTestContext) load_stored_summary() {
 		if err := json.Unmarshal(tc.event.Data, &data); err == nil {
 			id = data.ID
 		}
+	case domain.EventRuneMoved:
+		var data domain.RuneMoved
+		if err := json.Unmarshal(tc.event.Data, &data); err == nil {
+			id = data.ID
+		}
 	}
 	
 	if id == "" {
@@ -760,3 +892,8 @@ func (tc *runeSummaryTestContext) load_stored_summary() {
 		tc.storedSummary = &summary
 	}
 }
+
+func dateOf(year int, month time.Month, day int) *time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
@@ -0,0 +1,224 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// SagaProgress is the projection document rolling up a rune's children by status.
+// Every rune has an entry so that child status events can find their parent.
+type SagaProgress struct {
+	RuneID          string            `json:"rune_id"`
+	ParentID        string            `json:"parent_id,omitempty"`
+	Total           int               `json:"total"`
+	Counts          map[string]int    `json:"counts"`
+	PercentComplete int               `json:"percent_complete"`
+	Blocked         int               `json:"blocked"`
+	Children        map[string]string `json:"children"`
+}
+
+// SagaProgressTable is the typed table reference for this projector.
+var SagaProgressTable = core.TableRef[SagaProgress]{Name: "saga_progress"}
+
+// SagaProgressProjector projects per-status child counts for parent runes.
+type SagaProgressProjector struct{}
+
+// NewSagaProgressProjector creates a new SagaProgressProjector.
+func NewSagaProgressProjector() *SagaProgressProjector {
+	return &SagaProgressProjector{}
+}
+
+// Name returns the projector name.
+func (p *SagaProgressProjector) Name() string {
+	return SagaProgressTable.Name
+}
+
+// TableName returns the projection table name.
+func (p *SagaProgressProjector) TableName() string {
+	return SagaProgressTable.Name
+}
+
+// Handle processes events and updates the projection.
+func (p *SagaProgressProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventRuneCreated:
+		return p.handleCreated(ctx, event, store)
+	case domain.EventRuneForged:
+		return p.handleStatus(ctx, event, store, "open")
+	case domain.EventRuneClaimed:
+		return p.handleStatus(ctx, event, store, "claimed")
+	case domain.EventRuneUnclaimed:
+		return p.handleStatus(ctx, event, store, "open")
+	case domain.EventRuneFulfilled:
+		return p.handleStatus(ctx, event, store, "fulfilled")
+	case domain.EventRuneSealed:
+		return p.handleStatus(ctx, event, store, "sealed")
+	case domain.EventRuneFailed:
+		return p.handleStatus(ctx, event, store, "failed")
+	case domain.EventRuneReopened:
+		return p.handleReopened(ctx, event, store)
+	case domain.EventRuneShattered:
+		return p.handleShattered(ctx, event, store)
+	case domain.EventRuneMoved:
+		return p.handleMoved(ctx, event, store)
+	}
+	return nil
+}
+
+func (p *SagaProgressProjector) handleCreated(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneCreated
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	entry, err := p.getOrNew(ctx, event.RealmID, data.ID, store)
+	if err != nil {
+		return err
+	}
+	entry.ParentID = data.ParentID
+	if err := core.PutRef(ctx, store, event.RealmID, SagaProgressTable, data.ID, entry); err != nil {
+		return err
+	}
+	if data.ParentID == "" {
+		return nil
+	}
+	return p.setChildStatus(ctx, event.RealmID, data.ParentID, data.ID, "draft", store)
+}
+
+func (p *SagaProgressProjector) handleStatus(ctx context.Context, event core.Event, store core.ProjectionStore, status string) error {
+	var data struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.updateParent(ctx, event.RealmID, data.ID, status, store)
+}
+
+func (p *SagaProgressProjector) handleReopened(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneReopened
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	status := "open"
+	if data.Claimant != "" {
+		status = "claimed"
+	}
+	return p.updateParent(ctx, event.RealmID, data.ID, status, store)
+}
+
+func (p *SagaProgressProjector) handleShattered(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneShattered
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	entry, err := core.GetRef(ctx, store, event.RealmID, SagaProgressTable, data.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if entry.ParentID != "" {
+		parent, err := core.GetRef(ctx, store, event.RealmID, SagaProgressTable, entry.ParentID)
+		if err != nil && !isNotFoundError(err) {
+			return err
+		}
+		if err == nil {
+			delete(parent.Children, data.ID)
+			recountSagaProgress(&parent)
+			if err := core.PutRef(ctx, store, event.RealmID, SagaProgressTable, entry.ParentID, parent); err != nil {
+				return err
+			}
+		}
+	}
+	return core.DeleteRef(ctx, store, event.RealmID, SagaProgressTable, data.ID)
+}
+
+func (p *SagaProgressProjector) handleMoved(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.RuneMoved
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	if data.OldParentID != "" {
+		parent, err := core.GetRef(ctx, store, event.RealmID, SagaProgressTable, data.OldParentID)
+		if err != nil && !isNotFoundError(err) {
+			return err
+		}
+		if err == nil {
+			delete(parent.Children, data.ID)
+			recountSagaProgress(&parent)
+			if err := core.PutRef(ctx, store, event.RealmID, SagaProgressTable, data.OldParentID, parent); err != nil {
+				return err
+			}
+		}
+	}
+
+	entry, err := p.getOrNew(ctx, event.RealmID, data.ID, store)
+	if err != nil {
+		return err
+	}
+	entry.ParentID = data.NewParentID
+	if err := core.PutRef(ctx, store, event.RealmID, SagaProgressTable, data.ID, entry); err != nil {
+		return err
+	}
+	if data.NewParentID == "" {
+		return nil
+	}
+	return p.setChildStatus(ctx, event.RealmID, data.NewParentID, data.ID, data.Status, store)
+}
+
+// updateParent records runeID's new status on its parent's entry, if it has one.
+func (p *SagaProgressProjector) updateParent(ctx context.Context, realmID, runeID, status string, store core.ProjectionStore) error {
+	entry, err := core.GetRef(ctx, store, realmID, SagaProgressTable, runeID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if entry.ParentID == "" {
+		return nil
+	}
+	return p.setChildStatus(ctx, realmID, entry.ParentID, runeID, status, store)
+}
+
+func (p *SagaProgressProjector) setChildStatus(ctx context.Context, realmID, parentID, childID, status string, store core.ProjectionStore) error {
+	parent, err := p.getOrNew(ctx, realmID, parentID, store)
+	if err != nil {
+		return err
+	}
+	parent.Children[childID] = status
+	recountSagaProgress(&parent)
+	return core.PutRef(ctx, store, realmID, SagaProgressTable, parentID, parent)
+}
+
+func (p *SagaProgressProjector) getOrNew(ctx context.Context, realmID, runeID string, store core.ProjectionStore) (SagaProgress, error) {
+	entry, err := core.GetRef(ctx, store, realmID, SagaProgressTable, runeID)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return SagaProgress{}, err
+		}
+		entry = SagaProgress{RuneID: runeID}
+	}
+	if entry.Children == nil {
+		entry.Children = map[string]string{}
+	}
+	return entry, nil
+}
+
+// recountSagaProgress derives totals from the children map. Sealed children
+// are excluded from the percent complete denominator.
+func recountSagaProgress(entry *SagaProgress) {
+	entry.Counts = map[string]int{}
+	for _, status := range entry.Children {
+		entry.Counts[status]++
+	}
+	entry.Total = len(entry.Children)
+	entry.PercentComplete = 0
+	if active := entry.Total - entry.Counts["sealed"]; active > 0 {
+		entry.PercentComplete = entry.Counts["fulfilled"] * 100 / active
+	}
+}
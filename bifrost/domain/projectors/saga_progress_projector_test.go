@@ -0,0 +1,254 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile-time interface satisfaction check
+var _ core.Projector = (*SagaProgressProjector)(nil)
+
+// --- Tests ---
+
+func TestSagaProgressProjector(t *testing.T) {
+	t.Run("Name returns saga_progress", func(t *testing.T) {
+		tc := newSagaProgressTestContext(t)
+
+		// Given
+		tc.a_saga_progress_projector()
+
+		// When
+		tc.name_is_called()
+
+		// Then
+		tc.name_is("saga_progress")
+	})
+
+	t.Run("RuneCreated with ParentID adds a draft child to the parent", func(t *testing.T) {
+		tc := newSagaProgressTestContext(t)
+
+		// Given
+		tc.a_saga_progress_projector()
+		tc.a_store()
+
+		// When
+		tc.events_are_handled(
+			makeEvent(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-p"}),
+			makeEvent(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-p.1", ParentID: "bf-p"}),
+		)
+
+		// Then
+		tc.no_error()
+		tc.progress_has_total("bf-p", 1)
+		tc.progress_has_count("bf-p", "draft", 1)
+		tc.progress_has_percent_complete("bf-p", 0)
+	})
+
+	t.Run("child status changes roll up to counts and percent complete", func(t *testing.T) {
+		tc := newSagaProgressTestContext(t)
+
+		// Given
+		tc.a_saga_progress_projector()
+		tc.a_store()
+		tc.a_saga_with_children("bf-p", "bf-p.1", "bf-p.2", "bf-p.3")
+
+		// When
+		tc.events_are_handled(
+			makeEvent(domain.EventRuneForged, domain.RuneForged{ID: "bf-p.1"}),
+			makeEvent(domain.EventRuneClaimed, domain.RuneClaimed{ID: "bf-p.1", Claimant: "alice"}),
+			makeEvent(domain.EventRuneFulfilled, domain.RuneFulfilled{ID: "bf-p.1"}),
+			makeEvent(domain.EventRuneForged, domain.RuneForged{ID: "bf-p.2"}),
+			makeEvent(domain.EventRuneSealed, domain.RuneSealed{ID: "bf-p.3"}),
+		)
+
+		// Then
+		tc.no_error()
+		tc.progress_has_total("bf-p", 3)
+		tc.progress_has_count("bf-p", "fulfilled", 1)
+		tc.progress_has_count("bf-p", "open", 1)
+		tc.progress_has_count("bf-p", "sealed", 1)
+		tc.progress_has_percent_complete("bf-p", 50)
+	})
+
+	t.Run("RuneReopened with claimant marks child claimed", func(t *testing.T) {
+		tc := newSagaProgressTestContext(t)
+
+		// Given
+		tc.a_saga_progress_projector()
+		tc.a_store()
+		tc.a_saga_with_children("bf-p", "bf-p.1")
+
+		// When
+		tc.events_are_handled(
+			makeEvent(domain.EventRuneFailed, domain.RuneFailed{ID: "bf-p.1", Reason: "broke"}),
+			makeEvent(domain.EventRuneReopened, domain.RuneReopened{ID: "bf-p.1", Claimant: "alice"}),
+		)
+
+		// Then
+		tc.no_error()
+		tc.progress_has_count("bf-p", "claimed", 1)
+		tc.progress_has_count("bf-p", "failed", 0)
+	})
+
+	t.Run("RuneShattered removes the child from the parent", func(t *testing.T) {
+		tc := newSagaProgressTestContext(t)
+
+		// Given
+		tc.a_saga_progress_projector()
+		tc.a_store()
+		tc.a_saga_with_children("bf-p", "bf-p.1", "bf-p.2")
+
+		// When
+		tc.events_are_handled(
+			makeEvent(domain.EventRuneShattered, domain.RuneShattered{ID: "bf-p.2"}),
+		)
+
+		// Then
+		tc.no_error()
+		tc.progress_has_total("bf-p", 1)
+		tc.no_progress_entry("bf-p.2")
+	})
+
+	t.Run("status events for top-level runes are ignored", func(t *testing.T) {
+		tc := newSagaProgressTestContext(t)
+
+		// Given
+		tc.a_saga_progress_projector()
+		tc.a_store()
+
+		// When
+		tc.events_are_handled(
+			makeEvent(domain.EventRuneCreated, domain.RuneCreated{ID: "bf-x"}),
+			makeEvent(domain.EventRuneForged, domain.RuneForged{ID: "bf-x"}),
+		)
+
+		// Then
+		tc.no_error()
+		tc.progress_has_total("bf-x", 0)
+	})
+
+	t.Run("RuneMoved moves the child between parents with its status", func(t *testing.T) {
+		tc := newSagaProgressTestContext(t)
+
+		// Given
+		tc.a_saga_progress_projector()
+		tc.a_store()
+		tc.a_saga_with_children("bf-a", "bf-a.1", "bf-a.2")
+		tc.a_saga_with_children("bf-b")
+
+		// When
+		tc.events_are_handled(
+			makeEvent(domain.EventRuneMoved, domain.RuneMoved{ID: "bf-a.1", OldParentID: "bf-a", NewParentID: "bf-b", Alias: "bf-b.1", Status: "claimed"}),
+			makeEvent(domain.EventRuneFulfilled, domain.RuneFulfilled{ID: "bf-a.1"}),
+		)
+
+		// Then
+		tc.no_error()
+		tc.progress_has_total("bf-a", 1)
+		tc.progress_has_total("bf-b", 1)
+		tc.progress_has_count("bf-b", "fulfilled", 1)
+		tc.progress_has_percent_complete("bf-b", 100)
+	})
+}
+
+// --- Test Context ---
+
+type sagaProgressTestContext struct {
+	t *testing.T
+
+	projector  *SagaProgressProjector
+	store      *mockProjectionStore
+	ctx        context.Context
+	nameResult string
+	err        error
+}
+
+func newSagaProgressTestContext(t *testing.T) *sagaProgressTestContext {
+	t.Helper()
+	return &sagaProgressTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *sagaProgressTestContext) a_saga_progress_projector() {
+	tc.t.Helper()
+	tc.projector = NewSagaProgressProjector()
+}
+
+func (tc *sagaProgressTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *sagaProgressTestContext) a_saga_with_children(parentID string, childIDs ...string) {
+	tc.t.Helper()
+	tc.events_are_handled(makeEvent(domain.EventRuneCreated, domain.RuneCreated{ID: parentID}))
+	for _, id := range childIDs {
+		tc.events_are_handled(makeEvent(domain.EventRuneCreated, domain.RuneCreated{ID: id, ParentID: parentID}))
+	}
+	require.NoError(tc.t, tc.err)
+}
+
+// --- When ---
+
+func (tc *sagaProgressTestContext) name_is_called() {
+	tc.t.Helper()
+	tc.nameResult = tc.projector.Name()
+}
+
+func (tc *sagaProgressTestContext) events_are_handled(events ...core.Event) {
+	tc.t.Helper()
+	for _, evt := range events {
+		if tc.err = tc.projector.Handle(tc.ctx, evt, tc.store); tc.err != nil {
+			return
+		}
+	}
+}
+
+// --- Then ---
+
+func (tc *sagaProgressTestContext) name_is(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.nameResult)
+}
+
+func (tc *sagaProgressTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *sagaProgressTestContext) progress_for(runeID string) SagaProgress {
+	tc.t.Helper()
+	entry, err := core.GetRef(tc.ctx, tc.store, "realm-1", SagaProgressTable, runeID)
+	require.NoError(tc.t, err, "expected saga progress entry for %s", runeID)
+	return entry
+}
+
+func (tc *sagaProgressTestContext) progress_has_total(runeID string, expected int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.progress_for(runeID).Total)
+}
+
+func (tc *sagaProgressTestContext) progress_has_count(runeID, status string, expected int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.progress_for(runeID).Counts[status])
+}
+
+func (tc *sagaProgressTestContext) progress_has_percent_complete(runeID string, expected int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.progress_for(runeID).PercentComplete)
+}
+
+func (tc *sagaProgressTestContext) no_progress_entry(runeID string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "realm-1", SagaProgressTable, runeID)
+	assert.Error(tc.t, err)
+}
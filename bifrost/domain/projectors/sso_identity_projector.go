@@ -0,0 +1,74 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// SSOIdentityEntry is the projection document for SSO identity lookups.
+type SSOIdentityEntry struct {
+	Issuer    string `json:"issuer"`
+	Subject   string `json:"subject"`
+	AccountID string `json:"account_id"`
+}
+
+// SSOIdentityTable is the typed table reference for this projector.
+// Key: domain.SSOIdentityKey(issuer, subject).
+var SSOIdentityTable = core.TableRef[SSOIdentityEntry]{Name: "sso_identity"}
+
+// SSOIdentityProjector resolves a linked SSO identity to its account.
+type SSOIdentityProjector struct{}
+
+func NewSSOIdentityProjector() *SSOIdentityProjector {
+	return &SSOIdentityProjector{}
+}
+
+func (p *SSOIdentityProjector) Name() string {
+	return SSOIdentityTable.Name
+}
+
+func (p *SSOIdentityProjector) TableName() string {
+	return SSOIdentityTable.Name
+}
+
+func (p *SSOIdentityProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventSSOIdentityLinked:
+		var data domain.SSOIdentityLinked
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		entry := SSOIdentityEntry{Issuer: data.Issuer, Subject: data.Subject, AccountID: data.AccountID}
+		return core.PutRef(ctx, store, "_admin", SSOIdentityTable, domain.SSOIdentityKey(data.Issuer, data.Subject), entry)
+	case domain.EventAccountDeleted:
+		var data domain.AccountDeleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		for _, identity := range data.SSOIdentities {
+			if err := p.release(ctx, identity, data.AccountID, store); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// release deletes the identity entry if it still belongs to accountID.
+func (p *SSOIdentityProjector) release(ctx context.Context, identity domain.SSOIdentity, accountID string, store core.ProjectionStore) error {
+	key := domain.SSOIdentityKey(identity.Issuer, identity.Subject)
+	entry, err := core.GetRef(ctx, store, "_admin", SSOIdentityTable, key)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if entry.AccountID != accountID {
+		return nil
+	}
+	return core.DeleteRef(ctx, store, "_admin", SSOIdentityTable, key)
+}
@@ -0,0 +1,153 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestSSOIdentityProjector(t *testing.T) {
+	t.Run("Name returns sso_identity", func(t *testing.T) {
+		tc := newSSOIdentityTestContext(t)
+
+		// Given
+		tc.an_sso_identity_projector()
+
+		// Then
+		assert.Equal(t, "sso_identity", tc.projector.Name())
+	})
+
+	t.Run("handles SSOIdentityLinked by mapping the identity to the account", func(t *testing.T) {
+		tc := newSSOIdentityTestContext(t)
+
+		// Given
+		tc.an_sso_identity_projector()
+		tc.a_store()
+		tc.an_event(domain.EventSSOIdentityLinked, domain.SSOIdentityLinked{AccountID: "acct-1", Issuer: "https://idp.example.com", Subject: "sub-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.identity_is_linked_to("https://idp.example.com", "sub-1", "acct-1")
+	})
+
+	t.Run("handles AccountDeleted by removing the account's identities", func(t *testing.T) {
+		tc := newSSOIdentityTestContext(t)
+
+		// Given
+		tc.an_sso_identity_projector()
+		tc.a_store()
+		tc.existing_identity("https://idp.example.com", "sub-1", "acct-1")
+		tc.an_event(domain.EventAccountDeleted, domain.AccountDeleted{
+			AccountID:     "acct-1",
+			Username:      "alice",
+			SSOIdentities: []domain.SSOIdentity{{Issuer: "https://idp.example.com", Subject: "sub-1"}},
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.identity_is_not_linked("https://idp.example.com", "sub-1")
+	})
+
+	t.Run("keeps an identity relinked to another account", func(t *testing.T) {
+		tc := newSSOIdentityTestContext(t)
+
+		// Given
+		tc.an_sso_identity_projector()
+		tc.a_store()
+		tc.existing_identity("https://idp.example.com", "sub-1", "acct-2")
+		tc.an_event(domain.EventAccountDeleted, domain.AccountDeleted{
+			AccountID:     "acct-1",
+			Username:      "alice",
+			SSOIdentities: []domain.SSOIdentity{{Issuer: "https://idp.example.com", Subject: "sub-1"}},
+		})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.identity_is_linked_to("https://idp.example.com", "sub-1", "acct-2")
+	})
+}
+
+// --- Test Context ---
+
+type ssoIdentityTestContext struct {
+	t *testing.T
+
+	projector *SSOIdentityProjector
+	store     *mockProjectionStore
+	event     core.Event
+	ctx       context.Context
+	err       error
+}
+
+func newSSOIdentityTestContext(t *testing.T) *ssoIdentityTestContext {
+	t.Helper()
+	return &ssoIdentityTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *ssoIdentityTestContext) an_sso_identity_projector() {
+	tc.t.Helper()
+	tc.projector = NewSSOIdentityProjector()
+}
+
+func (tc *ssoIdentityTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *ssoIdentityTestContext) existing_identity(issuer, subject, accountID string) {
+	tc.t.Helper()
+	entry := SSOIdentityEntry{Issuer: issuer, Subject: subject, AccountID: accountID}
+	tc.store.put("_admin", SSOIdentityTable.Name, domain.SSOIdentityKey(issuer, subject), entry)
+}
+
+func (tc *ssoIdentityTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
+// --- When ---
+
+func (tc *ssoIdentityTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *ssoIdentityTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *ssoIdentityTestContext) identity_is_linked_to(issuer, subject, accountID string) {
+	tc.t.Helper()
+	entry, err := core.GetRef(tc.ctx, tc.store, "_admin", SSOIdentityTable, domain.SSOIdentityKey(issuer, subject))
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, SSOIdentityEntry{Issuer: issuer, Subject: subject, AccountID: accountID}, entry)
+}
+
+func (tc *ssoIdentityTestContext) identity_is_not_linked(issuer, subject string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "_admin", SSOIdentityTable, domain.SSOIdentityKey(issuer, subject))
+	assert.True(tc.t, isNotFoundError(err), "expected no identity entry, got %v", err)
+}
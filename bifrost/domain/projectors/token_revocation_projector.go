@@ -0,0 +1,110 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// Token revocation entry kinds. PAT and account entries reject every access
+// token of a revoked PAT or suspended account. Grant entries reject only the
+// tokens issued before RevokedAt, since those carry roles the account, group
+// or realm has since lost; a fresh exchange picks up the current roles.
+const (
+	RevocationKindPAT          = "pat"
+	RevocationKindAccount      = "account"
+	RevocationKindAccountGrant = "account_grant"
+	RevocationKindGroupGrant   = "group_grant"
+	RevocationKindRealmGrant   = "realm_grant"
+)
+
+// TokenRevocationEntry records a PAT, account, group or realm whose
+// short-lived access tokens must no longer be accepted.
+type TokenRevocationEntry struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// TokenRevocationsTable is the typed table reference for this projector.
+var TokenRevocationsTable = core.TableRef[TokenRevocationEntry]{Name: "token_revocations"}
+
+// TokenRevocationKey returns the projection key for a revocation entry.
+func TokenRevocationKey(kind, id string) string {
+	return kind + ":" + id
+}
+
+// TokenRevocationProjector projects revoked PATs, suspended accounts and
+// changes to granted roles so the server can reject access tokens without
+// looking up each PAT.
+type TokenRevocationProjector struct{}
+
+// NewTokenRevocationProjector creates a new TokenRevocationProjector.
+func NewTokenRevocationProjector() *TokenRevocationProjector {
+	return &TokenRevocationProjector{}
+}
+
+// Name returns the projector name.
+func (p *TokenRevocationProjector) Name() string {
+	return "token_revocation"
+}
+
+// TableName returns the projection table name.
+func (p *TokenRevocationProjector) TableName() string {
+	return TokenRevocationsTable.Name
+}
+
+// Handle processes events and updates the projection.
+func (p *TokenRevocationProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventPATRevoked:
+		var data domain.PATRevoked
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.revoke(ctx, store, RevocationKindPAT, data.PATID, event.Timestamp)
+	case domain.EventAccountSuspended:
+		var data domain.AccountSuspended
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.revoke(ctx, store, RevocationKindAccount, data.AccountID, event.Timestamp)
+	case domain.EventAccountReactivated:
+		var data domain.AccountReactivated
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return core.DeleteRef(ctx, store, "_admin", TokenRevocationsTable, TokenRevocationKey(RevocationKindAccount, data.AccountID))
+	case domain.EventRoleAssigned, domain.EventRoleRevoked, domain.EventRealmRevoked, domain.EventGroupMemberRemoved:
+		var data struct {
+			AccountID string `json:"account_id"`
+		}
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.revoke(ctx, store, RevocationKindAccountGrant, data.AccountID, event.Timestamp)
+	case domain.EventGroupRoleAssigned, domain.EventGroupRoleRevoked, domain.EventGroupDeleted:
+		var data struct {
+			GroupID string `json:"group_id"`
+		}
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.revoke(ctx, store, RevocationKindGroupGrant, data.GroupID, event.Timestamp)
+	case domain.EventRealmDeleted:
+		var data domain.RealmDeleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.revoke(ctx, store, RevocationKindRealmGrant, data.RealmID, event.Timestamp)
+	}
+	return nil
+}
+
+func (p *TokenRevocationProjector) revoke(ctx context.Context, store core.ProjectionStore, kind, id string, at time.Time) error {
+	entry := TokenRevocationEntry{Kind: kind, ID: id, RevokedAt: at}
+	return core.PutRef(ctx, store, "_admin", TokenRevocationsTable, TokenRevocationKey(kind, id), entry)
+}
@@ -0,0 +1,216 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestTokenRevocationProjector(t *testing.T) {
+	t.Run("Name returns token_revocation", func(t *testing.T) {
+		tc := newTokenRevocationTestContext(t)
+
+		// Given
+		tc.a_token_revocation_projector()
+
+		// Then
+		assert.Equal(t, "token_revocation", tc.projector.Name())
+		assert.Equal(t, "token_revocations", tc.projector.TableName())
+	})
+
+	t.Run("handles PATRevoked by recording the PAT", func(t *testing.T) {
+		tc := newTokenRevocationTestContext(t)
+
+		// Given
+		tc.a_token_revocation_projector()
+		tc.a_store()
+		tc.an_event(domain.EventPATRevoked, domain.PATRevoked{AccountID: "acct-1", PATID: "pat-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.revocation_exists(RevocationKindPAT, "pat-1")
+	})
+
+	t.Run("handles AccountSuspended by recording the account", func(t *testing.T) {
+		tc := newTokenRevocationTestContext(t)
+
+		// Given
+		tc.a_token_revocation_projector()
+		tc.a_store()
+		tc.an_event(domain.EventAccountSuspended, domain.AccountSuspended{AccountID: "acct-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.revocation_exists(RevocationKindAccount, "acct-1")
+	})
+
+	t.Run("handles AccountReactivated by clearing the account", func(t *testing.T) {
+		tc := newTokenRevocationTestContext(t)
+
+		// Given
+		tc.a_token_revocation_projector()
+		tc.a_store()
+		tc.an_event(domain.EventAccountSuspended, domain.AccountSuspended{AccountID: "acct-1"})
+		tc.handle_is_called()
+		tc.an_event(domain.EventAccountReactivated, domain.AccountReactivated{AccountID: "acct-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.revocation_does_not_exist(RevocationKindAccount, "acct-1")
+	})
+
+	t.Run("handles account grant changes by recording when they happened", func(t *testing.T) {
+		events := map[string]any{
+			domain.EventRoleAssigned:       domain.RoleAssigned{AccountID: "acct-1", RealmID: "realm-1", Role: "viewer"},
+			domain.EventRoleRevoked:        domain.RoleRevoked{AccountID: "acct-1", RealmID: "realm-1"},
+			domain.EventRealmRevoked:       domain.RealmRevoked{AccountID: "acct-1", RealmID: "realm-1"},
+			domain.EventGroupMemberRemoved: domain.GroupMemberRemoved{GroupID: "group-1", AccountID: "acct-1"},
+		}
+		for eventType, data := range events {
+			t.Run(eventType, func(t *testing.T) {
+				tc := newTokenRevocationTestContext(t)
+
+				// Given
+				tc.a_token_revocation_projector()
+				tc.a_store()
+				tc.an_event(eventType, data)
+
+				// When
+				tc.handle_is_called()
+
+				// Then
+				tc.no_error()
+				tc.revocation_exists(RevocationKindAccountGrant, "acct-1")
+				tc.revocation_is_dated_by_the_event(RevocationKindAccountGrant, "acct-1")
+			})
+		}
+	})
+
+	t.Run("handles group grant changes by recording the group", func(t *testing.T) {
+		events := map[string]any{
+			domain.EventGroupRoleAssigned: domain.GroupRoleAssigned{GroupID: "group-1", RealmID: "realm-1", Role: "viewer"},
+			domain.EventGroupRoleRevoked:  domain.GroupRoleRevoked{GroupID: "group-1", RealmID: "realm-1"},
+			domain.EventGroupDeleted:      domain.GroupDeleted{GroupID: "group-1", Name: "ops"},
+		}
+		for eventType, data := range events {
+			t.Run(eventType, func(t *testing.T) {
+				tc := newTokenRevocationTestContext(t)
+
+				// Given
+				tc.a_token_revocation_projector()
+				tc.a_store()
+				tc.an_event(eventType, data)
+
+				// When
+				tc.handle_is_called()
+
+				// Then
+				tc.no_error()
+				tc.revocation_exists(RevocationKindGroupGrant, "group-1")
+			})
+		}
+	})
+
+	t.Run("handles RealmDeleted by recording the realm", func(t *testing.T) {
+		tc := newTokenRevocationTestContext(t)
+
+		// Given
+		tc.a_token_revocation_projector()
+		tc.a_store()
+		tc.an_event(domain.EventRealmDeleted, domain.RealmDeleted{RealmID: "realm-1", Name: "old"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.revocation_exists(RevocationKindRealmGrant, "realm-1")
+	})
+}
+
+// --- Test Context ---
+
+type tokenRevocationTestContext struct {
+	t *testing.T
+
+	projector *TokenRevocationProjector
+	store     *mockProjectionStore
+	event     core.Event
+	ctx       context.Context
+	err       error
+}
+
+func newTokenRevocationTestContext(t *testing.T) *tokenRevocationTestContext {
+	t.Helper()
+	return &tokenRevocationTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *tokenRevocationTestContext) a_token_revocation_projector() {
+	tc.t.Helper()
+	tc.projector = NewTokenRevocationProjector()
+}
+
+func (tc *tokenRevocationTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *tokenRevocationTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
+// --- When ---
+
+func (tc *tokenRevocationTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *tokenRevocationTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *tokenRevocationTestContext) revocation_exists(kind, id string) {
+	tc.t.Helper()
+	entry, err := core.GetRef(tc.ctx, tc.store, "_admin", TokenRevocationsTable, TokenRevocationKey(kind, id))
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, kind, entry.Kind)
+	assert.Equal(tc.t, id, entry.ID)
+}
+
+func (tc *tokenRevocationTestContext) revocation_is_dated_by_the_event(kind, id string) {
+	tc.t.Helper()
+	entry, err := core.GetRef(tc.ctx, tc.store, "_admin", TokenRevocationsTable, TokenRevocationKey(kind, id))
+	require.NoError(tc.t, err)
+	assert.True(tc.t, tc.event.Timestamp.Equal(entry.RevokedAt))
+}
+
+func (tc *tokenRevocationTestContext) revocation_does_not_exist(kind, id string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "_admin", TokenRevocationsTable, TokenRevocationKey(kind, id))
+	assert.True(tc.t, isNotFoundError(err))
+}
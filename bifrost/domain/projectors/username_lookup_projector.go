@@ -36,6 +36,10 @@ func (p *UsernameLookupProjector) Handle(ctx context.Context, event core.Event,
 	switch event.EventType {
 	case domain.EventAccountCreated:
 		return p.handleAccountCreated(ctx, event, store)
+	case domain.EventAccountRenamed:
+		return p.handleAccountRenamed(ctx, event, store)
+	case domain.EventAccountDeleted:
+		return p.handleAccountDeleted(ctx, event, store)
 	}
 	return nil
 }
@@ -58,3 +62,41 @@ func (p *UsernameLookupProjector) handleAccountCreated(ctx context.Context, even
 	}
 	return core.PutRef(ctx, store, "_admin", UsernameLookupTable, data.Username, entry)
 }
+
+func (p *UsernameLookupProjector) handleAccountRenamed(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountRenamed
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	if err := p.release(ctx, data.OldUsername, data.AccountID, store); err != nil {
+		return err
+	}
+	entry := UsernameLookupEntry{
+		Username:  data.Username,
+		AccountID: data.AccountID,
+	}
+	return core.PutRef(ctx, store, "_admin", UsernameLookupTable, data.Username, entry)
+}
+
+func (p *UsernameLookupProjector) handleAccountDeleted(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	var data domain.AccountDeleted
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	return p.release(ctx, data.Username, data.AccountID, store)
+}
+
+// release deletes the username entry if it still belongs to accountID.
+func (p *UsernameLookupProjector) release(ctx context.Context, username, accountID string, store core.ProjectionStore) error {
+	entry, err := core.GetRef(ctx, store, "_admin", UsernameLookupTable, username)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if entry.AccountID != accountID {
+		return nil
+	}
+	return core.DeleteRef(ctx, store, "_admin", UsernameLookupTable, username)
+}
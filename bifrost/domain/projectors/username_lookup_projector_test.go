@@ -89,6 +89,58 @@ func TestUsernameLookupProjector(t *testing.T) {
 		// Then
 		tc.no_error()
 	})
+
+	t.Run("AccountRenamed moves the lookup to the new username", func(t *testing.T) {
+		tc := newUsernameLookupTestContext(t)
+
+		// Given
+		tc.a_username_lookup_projector()
+		tc.a_store()
+		tc.existing_lookup_entry("alice", "acct-1")
+		tc.event = makeEvent(domain.EventAccountRenamed, domain.AccountRenamed{AccountID: "acct-1", OldUsername: "alice", Username: "bob"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.lookup_entry_has_account_id("bob", "acct-1")
+		tc.lookup_entry_does_not_exist("alice")
+	})
+
+	t.Run("AccountDeleted releases the username", func(t *testing.T) {
+		tc := newUsernameLookupTestContext(t)
+
+		// Given
+		tc.a_username_lookup_projector()
+		tc.a_store()
+		tc.existing_lookup_entry("alice", "acct-1")
+		tc.event = makeEvent(domain.EventAccountDeleted, domain.AccountDeleted{AccountID: "acct-1", Username: "alice"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.lookup_entry_does_not_exist("alice")
+	})
+
+	t.Run("AccountDeleted keeps a username now owned by another account", func(t *testing.T) {
+		tc := newUsernameLookupTestContext(t)
+
+		// Given
+		tc.a_username_lookup_projector()
+		tc.a_store()
+		tc.existing_lookup_entry("alice", "acct-2")
+		tc.event = makeEvent(domain.EventAccountDeleted, domain.AccountDeleted{AccountID: "acct-1", Username: "alice"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.lookup_entry_has_account_id("alice", "acct-2")
+	})
 }
 
 // --- Test Context ---
@@ -206,3 +258,9 @@ func (tc *usernameLookupTestContext) lookup_entry_has_username(username, expecte
 	require.NoError(tc.t, err)
 	assert.Equal(tc.t, expected, entry.Username)
 }
+
+func (tc *usernameLookupTestContext) lookup_entry_does_not_exist(username string) {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "_admin", UsernameLookupTable, username)
+	assert.True(tc.t, isNotFoundError(err), "expected no lookup entry for %q, got %v", username, err)
+}
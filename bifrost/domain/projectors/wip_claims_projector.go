@@ -0,0 +1,128 @@
+package projectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// WIPClaimsTable is the typed table reference for this projector.
+var WIPClaimsTable = core.TableRef[domain.ActiveClaims]{Name: "wip_claims"}
+
+// wipClaimsKey is the single document key per realm.
+const wipClaimsKey = "claims"
+
+// WIPClaimsProjector tracks the runes currently claimed in each realm, with
+// the claimant, account and tags that WIP limits count them against.
+type WIPClaimsProjector struct{}
+
+func NewWIPClaimsProjector() *WIPClaimsProjector {
+	return &WIPClaimsProjector{}
+}
+
+func (p *WIPClaimsProjector) Name() string {
+	return WIPClaimsTable.Name
+}
+
+func (p *WIPClaimsProjector) TableName() string {
+	return WIPClaimsTable.Name
+}
+
+func (p *WIPClaimsProjector) Handle(ctx context.Context, event core.Event, store core.ProjectionStore) error {
+	switch event.EventType {
+	case domain.EventRuneClaimed:
+		var data domain.RuneClaimed
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.claim(ctx, event.RealmID, data.ID, data.Claimant, data.AccountID, store)
+	case domain.EventRuneReopened:
+		var data domain.RuneReopened
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		if data.Claimant != "" {
+			return p.claim(ctx, event.RealmID, data.ID, data.Claimant, data.AccountID, store)
+		}
+		return p.release(ctx, event.RealmID, data.ID, store)
+	case domain.EventRuneUnclaimed, domain.EventRuneFulfilled, domain.EventRuneSealed,
+		domain.EventRuneFailed, domain.EventRuneShattered:
+		var data struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		return p.release(ctx, event.RealmID, data.ID, store)
+	case domain.EventWIPClaimReserved, domain.EventWIPClaimReleased:
+		var applyErr error
+		if err := p.update(ctx, event.RealmID, store, func(doc *domain.ActiveClaims) bool {
+			applyErr = doc.Apply(event)
+			return applyErr == nil
+		}); err != nil {
+			return err
+		}
+		return applyErr
+	case domain.EventRuneUpdated:
+		var data domain.RuneUpdated
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		if data.Tags == nil && len(data.AddTags) == 0 && len(data.RemoveTags) == 0 {
+			return nil
+		}
+		return p.update(ctx, event.RealmID, store, func(doc *domain.ActiveClaims) bool {
+			claim, ok := doc.Claims[data.ID]
+			if !ok {
+				return false
+			}
+			claim.Tags = applyTagMutations(claim.Tags, data.Tags, data.AddTags, data.RemoveTags)
+			doc.Claims[data.ID] = claim
+			return true
+		})
+	}
+	return nil
+}
+
+func (p *WIPClaimsProjector) claim(ctx context.Context, realmID, runeID, claimant, accountID string, store core.ProjectionStore) error {
+	var tags []string
+	summary, err := core.GetRef(ctx, store, realmID, RuneSummaryTable, runeID)
+	if err != nil {
+		if !isNotFoundError(err) {
+			return err
+		}
+	} else {
+		tags = summary.Tags
+	}
+	return p.update(ctx, realmID, store, func(doc *domain.ActiveClaims) bool {
+		doc.Claims[runeID] = domain.ActiveClaim{RuneID: runeID, Claimant: claimant, AccountID: accountID, Tags: tags}
+		return true
+	})
+}
+
+func (p *WIPClaimsProjector) release(ctx context.Context, realmID, runeID string, store core.ProjectionStore) error {
+	return p.update(ctx, realmID, store, func(doc *domain.ActiveClaims) bool {
+		if _, ok := doc.Claims[runeID]; !ok {
+			return false
+		}
+		delete(doc.Claims, runeID)
+		return true
+	})
+}
+
+// update applies change to the realm's claims and stores them if change reports a modification.
+func (p *WIPClaimsProjector) update(ctx context.Context, realmID string, store core.ProjectionStore, change func(*domain.ActiveClaims) bool) error {
+	doc, err := core.GetRef(ctx, store, realmID, WIPClaimsTable, wipClaimsKey)
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+	if doc.Claims == nil {
+		doc.Claims = map[string]domain.ActiveClaim{}
+	}
+	if !change(&doc) {
+		return nil
+	}
+	return core.PutRef(ctx, store, realmID, WIPClaimsTable, wipClaimsKey, doc)
+}
@@ -0,0 +1,282 @@
+package projectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestWIPClaimsProjector(t *testing.T) {
+	t.Run("Name returns wip_claims", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+
+		// Then
+		assert.Equal(t, "wip_claims", tc.projector.Name())
+	})
+
+	t.Run("handles RuneClaimed by recording the claim with the rune's tags", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.existing_rune_summary("bf-a1b2", "deploy", "backend")
+		tc.an_event(domain.EventRuneClaimed, domain.RuneClaimed{ID: "bf-a1b2", Claimant: "agent-1", AccountID: "acct-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.claim_is("bf-a1b2", domain.ActiveClaim{
+			RuneID: "bf-a1b2", Claimant: "agent-1", AccountID: "acct-1", Tags: []string{"deploy", "backend"},
+		})
+	})
+
+	t.Run("releases the claim when the rune is fulfilled", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.existing_claims(domain.ActiveClaim{RuneID: "bf-a1b2", Claimant: "agent-1"}, domain.ActiveClaim{RuneID: "bf-c3d4", Claimant: "agent-2"})
+		tc.an_event(domain.EventRuneFulfilled, domain.RuneFulfilled{ID: "bf-a1b2"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.claimed_runes_are("bf-c3d4")
+	})
+
+	t.Run("releases the claim when the rune is unclaimed", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.existing_claims(domain.ActiveClaim{RuneID: "bf-a1b2", Claimant: "agent-1"})
+		tc.an_event(domain.EventRuneUnclaimed, domain.RuneUnclaimed{ID: "bf-a1b2"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.claimed_runes_are()
+	})
+
+	t.Run("records a reopen that restores the claimant", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.an_event(domain.EventRuneReopened, domain.RuneReopened{ID: "bf-a1b2", Claimant: "agent-1", AccountID: "acct-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.claim_is("bf-a1b2", domain.ActiveClaim{RuneID: "bf-a1b2", Claimant: "agent-1", AccountID: "acct-1"})
+	})
+
+	t.Run("records a WIP reservation and the stream version it was applied at", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.a_wip_stream_event(3, domain.EventWIPClaimReserved, domain.WIPClaimReserved{RuneID: "bf-a1b2", Claimant: "agent-1", AccountID: "acct-1", Tags: []string{"deploy"}})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.claim_is("bf-a1b2", domain.ActiveClaim{RuneID: "bf-a1b2", Claimant: "agent-1", AccountID: "acct-1", Tags: []string{"deploy"}})
+		tc.wip_stream_version_is(3)
+	})
+
+	t.Run("keeps a later claim on the rune when an earlier reservation is released", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.existing_claims(domain.ActiveClaim{RuneID: "bf-a1b2", Claimant: "agent-2"})
+		tc.a_wip_stream_event(4, domain.EventWIPClaimReleased, domain.WIPClaimReleased{RuneID: "bf-a1b2", Claimant: "agent-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.claimed_runes_are("bf-a1b2")
+		tc.wip_stream_version_is(4)
+	})
+
+	t.Run("removes a released reservation", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.existing_claims(domain.ActiveClaim{RuneID: "bf-a1b2", Claimant: "agent-1"})
+		tc.a_wip_stream_event(2, domain.EventWIPClaimReleased, domain.WIPClaimReleased{RuneID: "bf-a1b2", Claimant: "agent-1"})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.claimed_runes_are()
+	})
+
+	t.Run("applies tag changes to a claimed rune", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.existing_claims(domain.ActiveClaim{RuneID: "bf-a1b2", Claimant: "agent-1", Tags: []string{"backend"}})
+		tc.an_event(domain.EventRuneUpdated, domain.RuneUpdated{ID: "bf-a1b2", AddTags: []string{"Deploy"}})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.claim_is("bf-a1b2", domain.ActiveClaim{RuneID: "bf-a1b2", Claimant: "agent-1", Tags: []string{"backend", "deploy"}})
+	})
+
+	t.Run("ignores tag changes to runes that are not claimed", func(t *testing.T) {
+		tc := newWIPClaimsTestContext(t)
+
+		// Given
+		tc.a_wip_claims_projector()
+		tc.a_store()
+		tc.an_event(domain.EventRuneUpdated, domain.RuneUpdated{ID: "bf-a1b2", AddTags: []string{"deploy"}})
+
+		// When
+		tc.handle_is_called()
+
+		// Then
+		tc.no_error()
+		tc.no_claims_document()
+	})
+}
+
+// --- Test Context ---
+
+type wipClaimsTestContext struct {
+	t *testing.T
+
+	projector *WIPClaimsProjector
+	store     *mockProjectionStore
+	event     core.Event
+	ctx       context.Context
+	err       error
+}
+
+func newWIPClaimsTestContext(t *testing.T) *wipClaimsTestContext {
+	t.Helper()
+	return &wipClaimsTestContext{
+		t:   t,
+		ctx: context.Background(),
+	}
+}
+
+// --- Given ---
+
+func (tc *wipClaimsTestContext) a_wip_claims_projector() {
+	tc.t.Helper()
+	tc.projector = NewWIPClaimsProjector()
+}
+
+func (tc *wipClaimsTestContext) a_store() {
+	tc.t.Helper()
+	tc.store = newMockProjectionStore()
+}
+
+func (tc *wipClaimsTestContext) existing_rune_summary(runeID string, tags ...string) {
+	tc.t.Helper()
+	tc.store.put("realm-1", RuneSummaryTable.Name, runeID, RuneSummary{ID: runeID, Status: "open", Tags: tags})
+}
+
+func (tc *wipClaimsTestContext) existing_claims(claims ...domain.ActiveClaim) {
+	tc.t.Helper()
+	doc := domain.ActiveClaims{Claims: map[string]domain.ActiveClaim{}}
+	for _, claim := range claims {
+		doc.Claims[claim.RuneID] = claim
+	}
+	require.NoError(tc.t, core.PutRef(tc.ctx, tc.store, "realm-1", WIPClaimsTable, "claims", doc))
+}
+
+func (tc *wipClaimsTestContext) an_event(eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+}
+
+func (tc *wipClaimsTestContext) a_wip_stream_event(version int, eventType string, data any) {
+	tc.t.Helper()
+	tc.event = makeEvent(eventType, data)
+	tc.event.StreamID = "wip-claims"
+	tc.event.Version = version
+}
+
+// --- When ---
+
+func (tc *wipClaimsTestContext) handle_is_called() {
+	tc.t.Helper()
+	tc.err = tc.projector.Handle(tc.ctx, tc.event, tc.store)
+}
+
+// --- Then ---
+
+func (tc *wipClaimsTestContext) no_error() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *wipClaimsTestContext) claim_is(runeID string, expected domain.ActiveClaim) {
+	tc.t.Helper()
+	doc, err := core.GetRef(tc.ctx, tc.store, "realm-1", WIPClaimsTable, "claims")
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, doc.Claims[runeID])
+}
+
+func (tc *wipClaimsTestContext) wip_stream_version_is(expected int) {
+	tc.t.Helper()
+	doc, err := core.GetRef(tc.ctx, tc.store, "realm-1", WIPClaimsTable, "claims")
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, doc.Version)
+}
+
+func (tc *wipClaimsTestContext) claimed_runes_are(runeIDs ...string) {
+	tc.t.Helper()
+	doc, err := core.GetRef(tc.ctx, tc.store, "realm-1", WIPClaimsTable, "claims")
+	require.NoError(tc.t, err)
+	actual := make([]string, 0, len(doc.Claims))
+	for runeID := range doc.Claims {
+		actual = append(actual, runeID)
+	}
+	assert.ElementsMatch(tc.t, runeIDs, actual)
+}
+
+func (tc *wipClaimsTestContext) no_claims_document() {
+	tc.t.Helper()
+	_, err := core.GetRef(tc.ctx, tc.store, "realm-1", WIPClaimsTable, "claims")
+	assert.True(tc.t, isNotFoundError(err), "expected no claims document, got %v", err)
+}
@@ -8,3 +8,76 @@ type SuspendRealm struct {
 	RealmID string `json:"realm_id"`
 	Reason  string `json:"reason"`
 }
+
+type RenameRealm struct {
+	RealmID string `json:"realm_id"`
+	Name    string `json:"name"`
+}
+
+type ReactivateRealm struct {
+	RealmID string `json:"realm_id"`
+}
+
+type ArchiveRealm struct {
+	RealmID string `json:"realm_id"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// DeleteRealm permanently removes a realm. Confirm must repeat the realm ID.
+type DeleteRealm struct {
+	RealmID string `json:"realm_id"`
+	Confirm string `json:"confirm"`
+}
+
+type UpdateRealmSettings struct {
+	RealmID               string     `json:"realm_id"`
+	SagaAutoComplete      *string    `json:"saga_auto_complete,omitempty"`
+	RequireACVerification *bool      `json:"require_ac_verification,omitempty"`
+	RequireBranch         *bool      `json:"require_branch,omitempty"`
+	DefaultPriority       *int       `json:"default_priority,omitempty"`
+	DefaultRuneType       *string    `json:"default_rune_type,omitempty"`
+	MaxStateSize          *int       `json:"max_state_size,omitempty"`
+	WIPLimits             *WIPLimits `json:"wip_limits,omitempty"`
+}
+
+type SetPolicy struct {
+	RealmID    string   `json:"realm_id"`
+	Name       string   `json:"name"`
+	Actions    []string `json:"actions,omitempty"`
+	Expression string   `json:"expression"`
+	Message    string   `json:"message,omitempty"`
+}
+
+type RemovePolicy struct {
+	RealmID string `json:"realm_id"`
+	Name    string `json:"name"`
+}
+
+type DefineCustomRole struct {
+	RealmID     string   `json:"realm_id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type DeleteCustomRole struct {
+	RealmID string `json:"realm_id"`
+	Name    string `json:"name"`
+}
+
+// AddWebhook subscribes a URL to the realm's rune events. Secret signs the
+// payloads; one is generated when it is empty.
+type AddWebhook struct {
+	RealmID    string   `json:"realm_id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Secret     string   `json:"secret,omitempty"`
+	// AllowedHosts lists the non-public hosts and networks the server lets
+	// webhooks reach. It is server configuration, never read from requests.
+	AllowedHosts []string `json:"-"`
+}
+
+type RemoveWebhook struct {
+	RealmID   string `json:"realm_id"`
+	WebhookID string `json:"webhook_id"`
+}
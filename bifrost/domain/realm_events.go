@@ -3,8 +3,19 @@ package domain
 import "time"
 
 const (
-	EventRealmCreated   = "RealmCreated"
-	EventRealmSuspended = "RealmSuspended"
+	EventRealmCreated         = "RealmCreated"
+	EventRealmSuspended       = "RealmSuspended"
+	EventRealmRenamed         = "RealmRenamed"
+	EventRealmReactivated     = "RealmReactivated"
+	EventRealmArchived        = "RealmArchived"
+	EventRealmDeleted         = "RealmDeleted"
+	EventRealmSettingsUpdated = "RealmSettingsUpdated"
+	EventPolicySet            = "PolicySet"
+	EventPolicyRemoved        = "PolicyRemoved"
+	EventCustomRoleDefined    = "CustomRoleDefined"
+	EventCustomRoleDeleted    = "CustomRoleDeleted"
+	EventWebhookAdded         = "WebhookAdded"
+	EventWebhookRemoved       = "WebhookRemoved"
 )
 
 type RealmCreated struct {
@@ -17,3 +28,80 @@ type RealmSuspended struct {
 	RealmID string `json:"realm_id"`
 	Reason  string `json:"reason"`
 }
+
+type RealmRenamed struct {
+	RealmID string `json:"realm_id"`
+	OldName string `json:"old_name"`
+	Name    string `json:"name"`
+}
+
+type RealmReactivated struct {
+	RealmID string `json:"realm_id"`
+}
+
+// RealmArchived makes a realm read-only until it is reactivated.
+type RealmArchived struct {
+	RealmID string `json:"realm_id"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// RealmDeleted records that a realm's events, projections and checkpoints
+// were purged. It stays in the _admin realm as a tombstone.
+type RealmDeleted struct {
+	RealmID string `json:"realm_id"`
+	Name    string `json:"name"`
+}
+
+type RealmSettingsUpdated struct {
+	RealmID               string     `json:"realm_id"`
+	SagaAutoComplete      *string    `json:"saga_auto_complete,omitempty"`
+	RequireACVerification *bool      `json:"require_ac_verification,omitempty"`
+	RequireBranch         *bool      `json:"require_branch,omitempty"`
+	DefaultPriority       *int       `json:"default_priority,omitempty"`
+	DefaultRuneType       *string    `json:"default_rune_type,omitempty"`
+	MaxStateSize          *int       `json:"max_state_size,omitempty"`
+	WIPLimits             *WIPLimits `json:"wip_limits,omitempty"`
+}
+
+// PolicySet adds a realm policy, or replaces the policy with the same name.
+type PolicySet struct {
+	RealmID    string   `json:"realm_id"`
+	Name       string   `json:"name"`
+	Actions    []string `json:"actions,omitempty"`
+	Expression string   `json:"expression"`
+	Message    string   `json:"message,omitempty"`
+}
+
+type PolicyRemoved struct {
+	RealmID string `json:"realm_id"`
+	Name    string `json:"name"`
+}
+
+// CustomRoleDefined adds a realm role, or replaces the role with the same name.
+type CustomRoleDefined struct {
+	RealmID     string   `json:"realm_id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type CustomRoleDeleted struct {
+	RealmID string `json:"realm_id"`
+	Name    string `json:"name"`
+}
+
+// WebhookAdded subscribes a URL to a realm's rune events. Only events
+// appended at or after CreatedAt are delivered.
+type WebhookAdded struct {
+	RealmID    string    `json:"realm_id"`
+	WebhookID  string    `json:"webhook_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	Secret     string    `json:"secret"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookRemoved struct {
+	RealmID   string `json:"realm_id"`
+	WebhookID string `json:"webhook_id"`
+}
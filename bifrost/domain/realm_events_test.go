@@ -116,6 +116,10 @@ func (tc *realmEvtTestContext) realm_event_type_constants_are_correct() {
 	tc.t.Helper()
 	assert.Equal(tc.t, "RealmCreated", EventRealmCreated)
 	assert.Equal(tc.t, "RealmSuspended", EventRealmSuspended)
+	assert.Equal(tc.t, "RealmRenamed", EventRealmRenamed)
+	assert.Equal(tc.t, "RealmReactivated", EventRealmReactivated)
+	assert.Equal(tc.t, "RealmArchived", EventRealmArchived)
+	assert.Equal(tc.t, "RealmDeleted", EventRealmDeleted)
 }
 
 func (tc *realmEvtTestContext) realm_created_fields_match() {
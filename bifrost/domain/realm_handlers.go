@@ -5,7 +5,10 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/devzeebo/bifrost/core"
@@ -40,6 +43,17 @@ func rebuildRealmState(events []core.Event) RealmState {
 			state.Status = "active"
 		case EventRealmSuspended:
 			state.Status = "suspended"
+		case EventRealmRenamed:
+			var data RealmRenamed
+			_ = json.Unmarshal(evt.Data, &data)
+			state.Name = data.Name
+		case EventRealmReactivated:
+			state.Status = "active"
+		case EventRealmArchived:
+			state.Status = "archived"
+		case EventRealmDeleted:
+			state.Exists = false
+			state.Status = "deleted"
 		}
 	}
 	return state
@@ -112,3 +126,165 @@ func HandleSuspendRealm(ctx context.Context, cmd SuspendRealm, store core.EventS
 	})
 	return err
 }
+
+func HandleRenameRealm(ctx context.Context, cmd RenameRealm, store core.EventStore, projectionStore core.ProjectionStore) error {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return fmt.Errorf("invalid realm name: must not be empty")
+	}
+
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if state.Name == name {
+		return nil
+	}
+
+	// Check name uniqueness via projection
+	var existing struct {
+		RealmID string `json:"realm_id"`
+	}
+	err = projectionStore.Get(ctx, AdminRealmID, "realm_name_lookup", name, &existing)
+	if err == nil && existing.RealmID != cmd.RealmID {
+		return fmt.Errorf("realm name %q is already taken", name)
+	}
+	if err != nil && !errors.As(err, new(*core.NotFoundError)) {
+		return fmt.Errorf("check realm name: %w", err)
+	}
+
+	renamed := RealmRenamed{RealmID: cmd.RealmID, OldName: state.Name, Name: name}
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventRealmRenamed, Data: renamed},
+	})
+	return err
+}
+
+func HandleReactivateRealm(ctx context.Context, cmd ReactivateRealm, store core.EventStore) error {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if state.Status == "active" {
+		return fmt.Errorf("realm %q is already active", cmd.RealmID)
+	}
+
+	reactivated := RealmReactivated(cmd)
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventRealmReactivated, Data: reactivated},
+	})
+	return err
+}
+
+func HandleArchiveRealm(ctx context.Context, cmd ArchiveRealm, store core.EventStore) error {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if state.Status == "archived" {
+		return fmt.Errorf("realm %q is already archived", cmd.RealmID)
+	}
+
+	archived := RealmArchived(cmd)
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventRealmArchived, Data: archived},
+	})
+	return err
+}
+
+// HandleDeleteRealm permanently removes a suspended or archived realm. It
+// revokes every account's and group's role in the realm, records a
+// RealmDeleted tombstone and then purges the realm's events, projections and
+// checkpoints.
+func HandleDeleteRealm(ctx context.Context, cmd DeleteRealm, store core.EventStore, projectionStore core.ProjectionStore, purgers ...core.RealmPurger) error {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if state.Status == "active" {
+		return fmt.Errorf("cannot delete active realm %q: suspend or archive it first", cmd.RealmID)
+	}
+	if cmd.Confirm != cmd.RealmID {
+		return fmt.Errorf("cannot delete realm %q: confirm must repeat the realm ID", cmd.RealmID)
+	}
+
+	if err := revokeRealmGrants(ctx, cmd.RealmID, store, projectionStore); err != nil {
+		return err
+	}
+	if err := revokeGroupRealmGrants(ctx, cmd.RealmID, store, projectionStore); err != nil {
+		return err
+	}
+
+	deleted := RealmDeleted{RealmID: cmd.RealmID, Name: state.Name}
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventRealmDeleted, Data: deleted},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, purger := range purgers {
+		if err := purger.PurgeRealm(ctx, cmd.RealmID); err != nil {
+			return fmt.Errorf("purge realm %q: %w", cmd.RealmID, err)
+		}
+	}
+	return nil
+}
+
+// revokeRealmGrants appends RoleRevoked to every account holding a role in the realm.
+func revokeRealmGrants(ctx context.Context, realmID string, store core.EventStore, projectionStore core.ProjectionStore) error {
+	type accountAuthEntry struct {
+		AccountID string            `json:"account_id"`
+		Realms    []string          `json:"realms"`
+		Roles     map[string]string `json:"roles"`
+	}
+	raws, err := projectionStore.List(ctx, AdminRealmID, "account_auth")
+	if err != nil {
+		return fmt.Errorf("list accounts: %w", err)
+	}
+	for _, raw := range raws {
+		var entry accountAuthEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		_, hasRole := entry.Roles[realmID]
+		if !hasRole && !slices.Contains(entry.Realms, realmID) {
+			continue
+		}
+
+		state, events, err := readAndRebuildAccountState(ctx, entry.AccountID, store)
+		if err != nil {
+			return err
+		}
+		if _, ok := state.Realms[realmID]; !ok {
+			continue
+		}
+		_, err = store.Append(ctx, AdminRealmID, accountStreamID(entry.AccountID), len(events), []core.EventData{
+			{EventType: EventRoleRevoked, Data: RoleRevoked{AccountID: entry.AccountID, RealmID: realmID}},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -42,6 +43,39 @@ func TestRebuildRealmState(t *testing.T) {
 		tc.realm_state_has_status("active")
 	})
 
+	t.Run("applies rename, archive and reactivate", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.events_from_created_realm()
+		tc.realm_events_follow(
+			makeEvent(EventRealmRenamed, RealmRenamed{RealmID: "bf-a1b2", OldName: "Test Realm", Name: "Asgard"}),
+			makeEvent(EventRealmArchived, RealmArchived{RealmID: "bf-a1b2"}),
+		)
+
+		// When
+		tc.realm_state_is_rebuilt()
+
+		// Then
+		tc.realm_state_has_name("Asgard")
+		tc.realm_state_has_status("archived")
+	})
+
+	t.Run("applies RealmDeleted as not existing", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.events_from_created_realm()
+		tc.realm_events_follow(makeEvent(EventRealmDeleted, RealmDeleted{RealmID: "bf-a1b2"}))
+
+		// When
+		tc.realm_state_is_rebuilt()
+
+		// Then
+		tc.realm_state_does_not_exist()
+		tc.realm_state_has_status("deleted")
+	})
+
 	t.Run("applies RealmSuspended", func(t *testing.T) {
 		tc := newRealmHandlerTestContext(t)
 
@@ -124,18 +158,213 @@ func TestHandleSuspendRealm(t *testing.T) {
 	})
 }
 
+func TestHandleRenameRealm(t *testing.T) {
+	t.Run("renames a realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_rename_realm_command("bf-a1b2", "Asgard")
+
+		// When
+		tc.handle_rename_realm()
+
+		// Then
+		tc.no_realm_error()
+		tc.appended_realm_event_has_type(EventRealmRenamed)
+	})
+
+	t.Run("returns error when the name belongs to another realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.realm_name_taken("Asgard", "bf-c3d4")
+		tc.a_rename_realm_command("bf-a1b2", "Asgard")
+
+		// When
+		tc.handle_rename_realm()
+
+		// Then
+		tc.realm_error_contains(`realm name "Asgard" is already taken`)
+		tc.no_realm_events_appended()
+	})
+
+	t.Run("returns error for an empty name", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_rename_realm_command("bf-a1b2", "  ")
+
+		// When
+		tc.handle_rename_realm()
+
+		// Then
+		tc.realm_error_contains("invalid realm name")
+	})
+}
+
+func TestHandleReactivateRealm(t *testing.T) {
+	t.Run("reactivates a suspended realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "suspended")
+
+		// When
+		tc.handle_reactivate_realm("bf-a1b2")
+
+		// Then
+		tc.no_realm_error()
+		tc.appended_realm_event_has_type(EventRealmReactivated)
+	})
+
+	t.Run("reactivates an archived realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "archived")
+
+		// When
+		tc.handle_reactivate_realm("bf-a1b2")
+
+		// Then
+		tc.no_realm_error()
+	})
+
+	t.Run("returns error when realm is already active", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+
+		// When
+		tc.handle_reactivate_realm("bf-a1b2")
+
+		// Then
+		tc.realm_error_contains("already active")
+	})
+}
+
+func TestHandleArchiveRealm(t *testing.T) {
+	t.Run("archives an active realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+
+		// When
+		tc.handle_archive_realm("bf-a1b2")
+
+		// Then
+		tc.no_realm_error()
+		tc.appended_realm_event_has_type(EventRealmArchived)
+	})
+
+	t.Run("returns error when realm is already archived", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "archived")
+
+		// When
+		tc.handle_archive_realm("bf-a1b2")
+
+		// Then
+		tc.realm_error_contains("already archived")
+	})
+}
+
+func TestHandleDeleteRealm(t *testing.T) {
+	t.Run("revokes grants, records the tombstone and purges the realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "archived")
+		tc.account_with_role("acct-1", "bf-a1b2")
+		tc.a_delete_realm_command("bf-a1b2", "bf-a1b2")
+
+		// When
+		tc.handle_delete_realm()
+
+		// Then
+		tc.no_realm_error()
+		tc.realm_event_was_appended_to_stream("account-acct-1")
+		tc.appended_realm_event_has_type(EventRealmDeleted)
+		tc.realm_was_purged("bf-a1b2")
+	})
+
+	t.Run("returns error when the realm is active", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_delete_realm_command("bf-a1b2", "bf-a1b2")
+
+		// When
+		tc.handle_delete_realm()
+
+		// Then
+		tc.realm_error_contains("suspend or archive it first")
+		tc.realm_was_not_purged()
+	})
+
+	t.Run("returns error when confirm does not match", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "suspended")
+		tc.a_delete_realm_command("bf-a1b2", "yes")
+
+		// When
+		tc.handle_delete_realm()
+
+		// Then
+		tc.realm_error_contains("confirm must repeat the realm ID")
+		tc.no_realm_events_appended()
+		tc.realm_was_not_purged()
+	})
+
+	t.Run("treats a deleted realm as not found", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "deleted")
+		tc.a_delete_realm_command("bf-a1b2", "bf-a1b2")
+
+		// When
+		tc.handle_delete_realm()
+
+		// Then
+		tc.realm_error_is_not_found("realm", "bf-a1b2")
+	})
+}
+
 // --- Test Context ---
 
 type realmHandlerTestContext struct {
 	t *testing.T
 
-	eventStore *mockEventStore
-	ctx        context.Context
-
-	createRealmCmd  CreateRealm
-	suspendRealmCmd SuspendRealm
+	eventStore      *mockEventStore
+	projectionStore *mockProjectionStore
+	purger          *mockRealmPurger
+	ctx             context.Context
+
+	createRealmCmd         CreateRealm
+	suspendRealmCmd        SuspendRealm
+	renameRealmCmd         RenameRealm
+	deleteRealmCmd         DeleteRealm
+	updateRealmSettingsCmd UpdateRealmSettings
+	setPolicyCmd           SetPolicy
+	removePolicyCmd        RemovePolicy
+	defineRoleCmd          DefineCustomRole
+	deleteRoleCmd          DeleteCustomRole
+	addWebhookCmd          AddWebhook
+	removeWebhookCmd       RemoveWebhook
 
 	createRealmResult CreateRealmResult
+	addWebhookResult  AddWebhookResult
 	realmState        RealmState
 	realmEvents       []core.Event
 	err               error
@@ -144,11 +373,22 @@ type realmHandlerTestContext struct {
 func newRealmHandlerTestContext(t *testing.T) *realmHandlerTestContext {
 	t.Helper()
 	return &realmHandlerTestContext{
-		t:   t,
-		ctx: context.Background(),
+		t:               t,
+		projectionStore: newMockProjectionStore(),
+		purger:          &mockRealmPurger{},
+		ctx:             context.Background(),
 	}
 }
 
+type mockRealmPurger struct {
+	purged []string
+}
+
+func (m *mockRealmPurger) PurgeRealm(_ context.Context, realmID string) error {
+	m.purged = append(m.purged, realmID)
+	return nil
+}
+
 // --- Given ---
 
 func (tc *realmHandlerTestContext) an_event_store() {
@@ -184,6 +424,11 @@ func (tc *realmHandlerTestContext) events_from_created_and_suspended_realm() {
 	}
 }
 
+func (tc *realmHandlerTestContext) realm_events_follow(events ...core.Event) {
+	tc.t.Helper()
+	tc.realmEvents = append(tc.realmEvents, events...)
+}
+
 func (tc *realmHandlerTestContext) existing_realm_in_stream(realmID string, status string) {
 	tc.t.Helper()
 	tc.an_event_store()
@@ -192,10 +437,18 @@ func (tc *realmHandlerTestContext) existing_realm_in_stream(realmID string, stat
 			RealmID: realmID, Name: "Existing Realm",
 		}),
 	}
-	if status == "suspended" {
+	switch status {
+	case "suspended":
 		events = append(events, makeEvent(EventRealmSuspended, RealmSuspended{
 			RealmID: realmID, Reason: "suspended",
 		}))
+	case "archived":
+		events = append(events, makeEvent(EventRealmArchived, RealmArchived{RealmID: realmID}))
+	case "deleted":
+		events = append(events,
+			makeEvent(EventRealmArchived, RealmArchived{RealmID: realmID}),
+			makeEvent(EventRealmDeleted, RealmDeleted{RealmID: realmID}),
+		)
 	}
 	tc.eventStore.streams["realm-"+realmID] = events
 }
@@ -216,6 +469,36 @@ func (tc *realmHandlerTestContext) a_suspend_realm_command(realmID, reason strin
 	tc.suspendRealmCmd = SuspendRealm{RealmID: realmID, Reason: reason}
 }
 
+func (tc *realmHandlerTestContext) a_rename_realm_command(realmID, name string) {
+	tc.t.Helper()
+	tc.renameRealmCmd = RenameRealm{RealmID: realmID, Name: name}
+}
+
+func (tc *realmHandlerTestContext) a_delete_realm_command(realmID, confirm string) {
+	tc.t.Helper()
+	tc.deleteRealmCmd = DeleteRealm{RealmID: realmID, Confirm: confirm}
+}
+
+func (tc *realmHandlerTestContext) realm_name_taken(name, realmID string) {
+	tc.t.Helper()
+	tc.projectionStore.data["_admin:realm_name_lookup:"+name] = map[string]string{"name": name, "realm_id": realmID}
+}
+
+func (tc *realmHandlerTestContext) account_with_role(accountID, realmID string) {
+	tc.t.Helper()
+	tc.an_event_store()
+	tc.eventStore.streams["account-"+accountID] = []core.Event{
+		makeEvent(EventAccountCreated, AccountCreated{AccountID: accountID, Username: accountID}),
+		makeEvent(EventRoleAssigned, RoleAssigned{AccountID: accountID, RealmID: realmID, Role: RoleMember}),
+	}
+	entry, _ := json.Marshal(map[string]any{
+		"account_id": accountID,
+		"realms":     []string{realmID},
+		"roles":      map[string]string{realmID: RoleMember},
+	})
+	tc.projectionStore.listData["_admin:account_auth"] = append(tc.projectionStore.listData["_admin:account_auth"], entry)
+}
+
 // --- When ---
 
 func (tc *realmHandlerTestContext) realm_state_is_rebuilt() {
@@ -233,6 +516,26 @@ func (tc *realmHandlerTestContext) handle_suspend_realm() {
 	tc.err = HandleSuspendRealm(tc.ctx, tc.suspendRealmCmd, tc.eventStore)
 }
 
+func (tc *realmHandlerTestContext) handle_rename_realm() {
+	tc.t.Helper()
+	tc.err = HandleRenameRealm(tc.ctx, tc.renameRealmCmd, tc.eventStore, tc.projectionStore)
+}
+
+func (tc *realmHandlerTestContext) handle_reactivate_realm(realmID string) {
+	tc.t.Helper()
+	tc.err = HandleReactivateRealm(tc.ctx, ReactivateRealm{RealmID: realmID}, tc.eventStore)
+}
+
+func (tc *realmHandlerTestContext) handle_archive_realm(realmID string) {
+	tc.t.Helper()
+	tc.err = HandleArchiveRealm(tc.ctx, ArchiveRealm{RealmID: realmID}, tc.eventStore)
+}
+
+func (tc *realmHandlerTestContext) handle_delete_realm() {
+	tc.t.Helper()
+	tc.err = HandleDeleteRealm(tc.ctx, tc.deleteRealmCmd, tc.eventStore, tc.projectionStore, tc.purger)
+}
+
 // --- Then ---
 
 func (tc *realmHandlerTestContext) no_realm_error() {
@@ -326,3 +629,18 @@ func (tc *realmHandlerTestContext) appended_realm_event_has_type(eventType strin
 	}
 	assert.True(tc.t, found, "expected event type %q in appended events", eventType)
 }
+
+func (tc *realmHandlerTestContext) no_realm_events_appended() {
+	tc.t.Helper()
+	assert.Empty(tc.t, tc.eventStore.appendedCalls)
+}
+
+func (tc *realmHandlerTestContext) realm_was_purged(realmID string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, []string{realmID}, tc.purger.purged)
+}
+
+func (tc *realmHandlerTestContext) realm_was_not_purged() {
+	tc.t.Helper()
+	assert.Empty(tc.t, tc.purger.purged)
+}
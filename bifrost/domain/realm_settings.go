@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// Saga auto-complete modes control what happens to a parent rune once every
+// non-sealed child has been fulfilled.
+const (
+	SagaAutoCompleteOff     = "off"
+	SagaAutoCompleteFulfill = "fulfill"
+	SagaAutoCompleteFlag    = "flag"
+)
+
+// ReadyForReviewTag is added to a parent rune when saga auto-complete runs in flag mode.
+const ReadyForReviewTag = "ready-for-review"
+
+// RealmSettings holds per-realm configuration as projected into realm_settings.
+type RealmSettings struct {
+	RealmID               string    `json:"realm_id"`
+	SagaAutoComplete      string    `json:"saga_auto_complete"`
+	RequireACVerification bool      `json:"require_ac_verification"`
+	RequireBranch         bool      `json:"require_branch"`
+	DefaultPriority       int       `json:"default_priority"`
+	DefaultRuneType       string    `json:"default_rune_type"`
+	MaxStateSize          int       `json:"max_state_size"`
+	WIPLimits             WIPLimits `json:"wip_limits"`
+}
+
+// DefaultRealmSettings returns the settings a realm has before any RealmSettingsUpdated event.
+func DefaultRealmSettings(realmID string) RealmSettings {
+	return RealmSettings{
+		RealmID:          realmID,
+		SagaAutoComplete: SagaAutoCompleteOff,
+		RequireBranch:    true,
+		DefaultPriority:  0,
+		DefaultRuneType:  "rune",
+		MaxStateSize:     MaxStateSize,
+	}
+}
+
+// ApplyRealmSettingsUpdate merges a RealmSettingsUpdated event into settings.
+func ApplyRealmSettingsUpdate(settings RealmSettings, update RealmSettingsUpdated) RealmSettings {
+	if update.SagaAutoComplete != nil {
+		settings.SagaAutoComplete = *update.SagaAutoComplete
+	}
+	if update.RequireACVerification != nil {
+		settings.RequireACVerification = *update.RequireACVerification
+	}
+	if update.RequireBranch != nil {
+		settings.RequireBranch = *update.RequireBranch
+	}
+	if update.DefaultPriority != nil {
+		settings.DefaultPriority = *update.DefaultPriority
+	}
+	if update.DefaultRuneType != nil {
+		settings.DefaultRuneType = *update.DefaultRuneType
+	}
+	if update.MaxStateSize != nil {
+		settings.MaxStateSize = *update.MaxStateSize
+	}
+	if update.WIPLimits != nil {
+		settings.WIPLimits = *update.WIPLimits
+	}
+	return settings
+}
+
+func isValidSagaAutoComplete(mode string) bool {
+	switch mode {
+	case SagaAutoCompleteOff, SagaAutoCompleteFulfill, SagaAutoCompleteFlag:
+		return true
+	}
+	return false
+}
+
+// ReadRealmSettings loads the projected settings for a realm, falling back to
+// defaults when the realm has no settings entry yet. Fields missing from an
+// older entry keep their default values.
+func ReadRealmSettings(ctx context.Context, realmID string, projStore core.ProjectionStore) (RealmSettings, error) {
+	settings := DefaultRealmSettings(realmID)
+	err := projStore.Get(ctx, AdminRealmID, "realm_settings", realmID, &settings)
+	if err != nil {
+		if isNotFoundError(err) {
+			return DefaultRealmSettings(realmID), nil
+		}
+		return RealmSettings{}, fmt.Errorf("read realm settings: %w", err)
+	}
+	return settings, nil
+}
+
+func HandleUpdateRealmSettings(ctx context.Context, cmd UpdateRealmSettings, store core.EventStore) error {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if cmd.SagaAutoComplete != nil && !isValidSagaAutoComplete(*cmd.SagaAutoComplete) {
+		return fmt.Errorf("invalid saga_auto_complete %q: must be one of off, fulfill, flag", *cmd.SagaAutoComplete)
+	}
+	if cmd.DefaultPriority != nil && (*cmd.DefaultPriority < 0 || *cmd.DefaultPriority > 4) {
+		return fmt.Errorf("invalid default_priority %d: must be between 0 and 4", *cmd.DefaultPriority)
+	}
+	if cmd.DefaultRuneType != nil && strings.TrimSpace(*cmd.DefaultRuneType) == "" {
+		return fmt.Errorf("invalid default_rune_type: must not be empty")
+	}
+	if cmd.MaxStateSize != nil && *cmd.MaxStateSize <= 0 {
+		return fmt.Errorf("invalid max_state_size %d: must be positive", *cmd.MaxStateSize)
+	}
+	if cmd.WIPLimits != nil {
+		if err := cmd.WIPLimits.validate(); err != nil {
+			return err
+		}
+		limits := cmd.WIPLimits.normalized()
+		cmd.WIPLimits = &limits
+	}
+
+	updated := RealmSettingsUpdated(cmd)
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventRealmSettingsUpdated, Data: updated},
+	})
+	return err
+}
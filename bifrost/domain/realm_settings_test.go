@@ -0,0 +1,275 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestHandleUpdateRealmSettings(t *testing.T) {
+	t.Run("appends RealmSettingsUpdated to the realm stream", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.an_update_realm_settings_command("bf-a1b2", SagaAutoCompleteFulfill)
+
+		// When
+		tc.handle_update_realm_settings()
+
+		// Then
+		tc.no_realm_error()
+		tc.realm_event_was_appended_to_stream("realm-bf-a1b2")
+		tc.appended_realm_event_has_type(EventRealmSettingsUpdated)
+		tc.appended_realm_settings_has_saga_auto_complete(SagaAutoCompleteFulfill)
+	})
+
+	t.Run("rejects unknown saga_auto_complete mode", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.an_update_realm_settings_command("bf-a1b2", "sometimes")
+
+		// When
+		tc.handle_update_realm_settings()
+
+		// Then
+		tc.realm_error_contains("invalid saga_auto_complete")
+	})
+
+	t.Run("rejects non-positive max_state_size", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+		size := 0
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.updateRealmSettingsCmd = UpdateRealmSettings{RealmID: "bf-a1b2", MaxStateSize: &size}
+
+		// When
+		tc.handle_update_realm_settings()
+
+		// Then
+		tc.realm_error_contains("invalid max_state_size")
+	})
+
+	t.Run("rejects out of range default_priority", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+		priority := 7
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.updateRealmSettingsCmd = UpdateRealmSettings{RealmID: "bf-a1b2", DefaultPriority: &priority}
+
+		// When
+		tc.handle_update_realm_settings()
+
+		// Then
+		tc.realm_error_contains("invalid default_priority")
+	})
+
+	t.Run("rejects empty default_rune_type", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+		runeType := " "
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.updateRealmSettingsCmd = UpdateRealmSettings{RealmID: "bf-a1b2", DefaultRuneType: &runeType}
+
+		// When
+		tc.handle_update_realm_settings()
+
+		// Then
+		tc.realm_error_contains("invalid default_rune_type")
+	})
+
+	t.Run("returns not found for missing realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.empty_realm_stream("bf-missing")
+		tc.an_update_realm_settings_command("bf-missing", SagaAutoCompleteFlag)
+
+		// When
+		tc.handle_update_realm_settings()
+
+		// Then
+		tc.realm_error_is_not_found("realm", "bf-missing")
+	})
+}
+
+func TestReadRealmSettings(t *testing.T) {
+	t.Run("returns defaults when realm has no settings entry", func(t *testing.T) {
+		store := newMockProjectionStore()
+
+		settings, err := ReadRealmSettings(context.Background(), "realm-1", store)
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultRealmSettings("realm-1"), settings)
+	})
+
+	t.Run("returns projected settings", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.data["_admin:realm_settings:realm-1"] = RealmSettings{RealmID: "realm-1", SagaAutoComplete: SagaAutoCompleteFlag}
+
+		settings, err := ReadRealmSettings(context.Background(), "realm-1", store)
+
+		require.NoError(t, err)
+		assert.Equal(t, SagaAutoCompleteFlag, settings.SagaAutoComplete)
+	})
+
+	t.Run("keeps defaults for fields missing from an older entry", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.data["_admin:realm_settings:realm-1"] = map[string]any{"realm_id": "realm-1", "saga_auto_complete": "flag"}
+
+		settings, err := ReadRealmSettings(context.Background(), "realm-1", store)
+
+		require.NoError(t, err)
+		assert.True(t, settings.RequireBranch)
+		assert.Equal(t, "rune", settings.DefaultRuneType)
+		assert.Equal(t, MaxStateSize, settings.MaxStateSize)
+	})
+}
+
+func TestHandleCreateRune_RealmSettings(t *testing.T) {
+	t.Run("uses the realm's default priority and rune type when omitted", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+		branch := "main"
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.realm_settings(func(s *RealmSettings) {
+			s.DefaultPriority = 3
+			s.DefaultRuneType = "task"
+		})
+		tc.createCmd = CreateRune{Title: "Fix the bridge", Branch: &branch}
+
+		// When
+		tc.handle_create_rune()
+
+		// Then
+		tc.no_error()
+		tc.created_event_has_priority(3)
+		tc.created_event_has_rune_type("task")
+	})
+
+	t.Run("explicit priority and type override the realm defaults", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+		branch := "main"
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.realm_settings(func(s *RealmSettings) {
+			s.DefaultPriority = 3
+			s.DefaultRuneType = "task"
+		})
+		tc.createCmd = CreateRune{Title: "Fix the bridge", Branch: &branch, Priority: intPtr(0), Type: "bug"}
+
+		// When
+		tc.handle_create_rune()
+
+		// Then
+		tc.no_error()
+		tc.created_event_has_priority(0)
+		tc.created_event_has_rune_type("bug")
+	})
+
+	t.Run("allows top-level rune without branch when require_branch is off", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.realm_settings(func(s *RealmSettings) {
+			s.RequireBranch = false
+		})
+		tc.a_create_rune_command("Fix the bridge", "Needs repair", 1, "")
+
+		// When
+		tc.handle_create_rune()
+
+		// Then
+		tc.no_error()
+		tc.created_event_has_branch("")
+	})
+}
+
+func TestApplyRealmSettingsUpdate(t *testing.T) {
+	t.Run("merges only the fields present in the update", func(t *testing.T) {
+		on := true
+		settings := RealmSettings{RealmID: "realm-1", SagaAutoComplete: SagaAutoCompleteFlag}
+
+		settings = ApplyRealmSettingsUpdate(settings, RealmSettingsUpdated{RealmID: "realm-1", RequireACVerification: &on})
+
+		assert.Equal(t, SagaAutoCompleteFlag, settings.SagaAutoComplete)
+		assert.True(t, settings.RequireACVerification)
+	})
+
+	t.Run("applies branch, default and limit fields", func(t *testing.T) {
+		off := false
+		priority := 2
+		runeType := "task"
+		size := 1024
+		settings := DefaultRealmSettings("realm-1")
+
+		settings = ApplyRealmSettingsUpdate(settings, RealmSettingsUpdated{
+			RealmID:         "realm-1",
+			RequireBranch:   &off,
+			DefaultPriority: &priority,
+			DefaultRuneType: &runeType,
+			MaxStateSize:    &size,
+		})
+
+		assert.False(t, settings.RequireBranch)
+		assert.Equal(t, 2, settings.DefaultPriority)
+		assert.Equal(t, "task", settings.DefaultRuneType)
+		assert.Equal(t, 1024, settings.MaxStateSize)
+	})
+}
+
+// --- Given ---
+
+func (tc *realmHandlerTestContext) an_update_realm_settings_command(realmID, sagaAutoComplete string) {
+	tc.t.Helper()
+	tc.updateRealmSettingsCmd = UpdateRealmSettings{RealmID: realmID, SagaAutoComplete: &sagaAutoComplete}
+}
+
+func (tc *handlerTestContext) realm_settings(configure func(*RealmSettings)) {
+	tc.t.Helper()
+	tc.a_store()
+	settings := DefaultRealmSettings(tc.realmID)
+	configure(&settings)
+	tc.projectionStore.data["_admin:realm_settings:"+tc.realmID] = settings
+}
+
+// --- When ---
+
+func (tc *realmHandlerTestContext) handle_update_realm_settings() {
+	tc.t.Helper()
+	tc.err = HandleUpdateRealmSettings(tc.ctx, tc.updateRealmSettingsCmd, tc.eventStore)
+}
+
+// --- Then ---
+
+func (tc *realmHandlerTestContext) appended_realm_settings_has_saga_auto_complete(expected string) {
+	tc.t.Helper()
+	require.NotEmpty(tc.t, tc.eventStore.appendedCalls, "expected at least one Append call")
+	lastCall := tc.eventStore.appendedCalls[len(tc.eventStore.appendedCalls)-1]
+	dataBytes, _ := json.Marshal(lastCall.events[0].Data)
+	var data RealmSettingsUpdated
+	require.NoError(tc.t, json.Unmarshal(dataBytes, &data))
+	require.NotNil(tc.t, data.SagaAutoComplete)
+	assert.Equal(tc.t, expected, *data.SagaAutoComplete)
+}
+
+func (tc *handlerTestContext) created_event_has_rune_type(expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.createdEvent.Type)
+}
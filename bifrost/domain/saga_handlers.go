@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// autoCompleteSagaParent runs completeSagaParent after the child's own event
+// has been persisted. The child command has already succeeded at that point,
+// so a failure to complete the parent is logged rather than returned. An
+// empty childStatus means the child has just been moved away from parentID.
+func autoCompleteSagaParent(ctx context.Context, realmID, childID, childStatus, parentID string, store core.EventStore, projStore core.ProjectionStore) {
+	if err := completeSagaParent(ctx, realmID, childID, childStatus, parentID, store, projStore); err != nil {
+		log.Printf("saga auto-complete: realm %s: parent %q of %q: %v", realmID, parentID, childID, err)
+	}
+}
+
+// completeSagaParent applies the realm's saga auto-complete policy to parentID
+// after one of its children (childID) reached childStatus. When every
+// non-sealed child is fulfilled the parent is either fulfilled or flagged for
+// review, and fulfilment cascades up to the grandparent. Auto-fulfilment is
+// subject to the realm's fulfill policies; a rejected parent is flagged
+// instead, which is in turn subject to its update policies.
+func completeSagaParent(ctx context.Context, realmID, childID, childStatus, parentID string, store core.EventStore, projStore core.ProjectionStore) error {
+	if parentID == "" || projStore == nil {
+		return nil
+	}
+	settings, err := ReadRealmSettings(ctx, realmID, projStore)
+	if err != nil {
+		return err
+	}
+	if settings.SagaAutoComplete != SagaAutoCompleteFulfill && settings.SagaAutoComplete != SagaAutoCompleteFlag {
+		return nil
+	}
+
+	const maxRetries = 10
+	for attempt := range maxRetries {
+		completed, grandparentID, err := completeSagaParentOnce(ctx, realmID, childID, childStatus, parentID, settings.SagaAutoComplete, settings.RequireACVerification, store, projStore)
+		if err == nil {
+			if completed {
+				return completeSagaParent(ctx, realmID, parentID, "fulfilled", grandparentID, store, projStore)
+			}
+			return nil
+		}
+		var concErr *core.ConcurrencyError
+		if !errors.As(err, &concErr) || attempt == maxRetries-1 {
+			return err
+		}
+	}
+	return nil
+}
+
+func completeSagaParentOnce(ctx context.Context, realmID, childID, childStatus, parentID, mode string, requireACVerification bool, store core.EventStore, projStore core.ProjectionStore) (bool, string, error) {
+	parentState, parentEvents, err := readAndRebuild(ctx, realmID, parentID, store)
+	if err != nil {
+		return false, "", err
+	}
+	if !parentState.Exists {
+		return false, "", nil
+	}
+
+	done, err := sagaChildrenDone(ctx, realmID, childID, childStatus, parentID, store, projStore)
+	if err != nil || !done {
+		return false, "", err
+	}
+
+	// A parent that could not be fulfilled by hand is flagged instead.
+	if mode == SagaAutoCompleteFulfill && requireACVerification && len(unverifiedACs(parentEvents)) > 0 {
+		mode = SagaAutoCompleteFlag
+	}
+	if mode == SagaAutoCompleteFulfill && (parentState.Status == "open" || parentState.Status == "claimed") {
+		err := enforcePolicies(ctx, realmID, "fulfill", parentID, FulfillRune{ID: parentID}, store, projStore)
+		var policyErr *PolicyViolationError
+		if errors.As(err, &policyErr) {
+			mode = SagaAutoCompleteFlag
+		} else if err != nil {
+			return false, "", err
+		}
+	}
+
+	streamID := runeStreamID(parentID)
+	switch mode {
+	case SagaAutoCompleteFulfill:
+		if parentState.Status != "open" && parentState.Status != "claimed" {
+			return false, "", nil
+		}
+		_, err = store.Append(ctx, realmID, streamID, len(parentEvents), []core.EventData{
+			{EventType: EventRuneFulfilled, Data: RuneFulfilled{ID: parentID}},
+			{EventType: EventRuneNoted, Data: RuneNoted{RuneID: parentID, Text: "Auto-fulfilled: all child runes fulfilled"}},
+		})
+		if err != nil {
+			return false, "", err
+		}
+		return true, parentState.ParentID, nil
+	case SagaAutoCompleteFlag:
+		switch parentState.Status {
+		case "fulfilled", "sealed", "failed", "shattered":
+			return false, "", nil
+		}
+		if slices.Contains(parentState.Tags, ReadyForReviewTag) {
+			return false, "", nil
+		}
+		flag := UpdateRune{ID: parentID, AddTags: []string{ReadyForReviewTag}}
+		if err := enforcePolicies(ctx, realmID, "update", parentID, flag, store, projStore); err != nil {
+			return false, "", err
+		}
+		_, err = store.Append(ctx, realmID, streamID, len(parentEvents), []core.EventData{
+			{EventType: EventRuneUpdated, Data: RuneUpdated{ID: parentID, AddTags: []string{ReadyForReviewTag}}},
+			{EventType: EventRuneNoted, Data: RuneNoted{RuneID: parentID, Text: "Ready for review: all child runes fulfilled"}},
+		})
+		return false, "", err
+	}
+	return false, "", nil
+}
+
+// sagaChildrenDone reports whether every non-sealed child of parentID is
+// fulfilled. Child membership comes from the saga_progress projection and
+// sibling statuses are rebuilt from their streams; childID has just moved to
+// childStatus, which the projection may not reflect yet. An empty childStatus
+// leaves childID out, as it is no longer a child of parentID.
+func sagaChildrenDone(ctx context.Context, realmID, childID, childStatus, parentID string, store core.EventStore, projStore core.ProjectionStore) (bool, error) {
+	children, err := readSagaChildren(ctx, realmID, parentID, projStore)
+	if err != nil {
+		return false, err
+	}
+	var statuses []string
+	if childStatus != "" {
+		statuses = append(statuses, childStatus)
+	}
+	for id := range children {
+		if id == childID {
+			continue
+		}
+		state, _, err := readAndRebuild(ctx, realmID, id, store)
+		if err != nil {
+			return false, err
+		}
+		if !state.Exists || state.ParentID != parentID {
+			continue
+		}
+		statuses = append(statuses, state.Status)
+	}
+
+	fulfilled := 0
+	for _, status := range statuses {
+		switch status {
+		case "sealed", "shattered":
+			continue
+		case "fulfilled":
+			fulfilled++
+		default:
+			return false, nil
+		}
+	}
+	return fulfilled > 0, nil
+}
+
+// readSagaChildren returns the child IDs recorded for runeID in saga_progress,
+// keyed to their last projected status.
+func readSagaChildren(ctx context.Context, realmID, runeID string, projStore core.ProjectionStore) (map[string]string, error) {
+	var progress struct {
+		Children map[string]string `json:"children"`
+	}
+	if err := projStore.Get(ctx, realmID, "saga_progress", runeID, &progress); err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read saga progress: %w", err)
+	}
+	return progress.Children, nil
+}
@@ -0,0 +1,448 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestSagaAutoComplete(t *testing.T) {
+	t.Run("does nothing when realm setting is off", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "claimed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1")
+		tc.a_fulfill_rune_command("bf-p.1")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.no_events_appended_to_stream("rune-bf-p")
+	})
+
+	t.Run("fulfills parent when last child is fulfilled in fulfill mode", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "fulfilled")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "sealed")
+		tc.existing_child_rune_in_stream("bf-p.3", "bf-p", "claimed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2", "bf-p.3")
+		tc.a_fulfill_rune_command("bf-p.3")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p", EventRuneFulfilled, EventRuneNoted)
+	})
+
+	t.Run("leaves parent alone while a child is still open", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "claimed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2")
+		tc.a_fulfill_rune_command("bf-p.2")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.no_events_appended_to_stream("rune-bf-p")
+	})
+
+	t.Run("sealing the last open child completes the parent", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "claimed")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "fulfilled")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "open")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2")
+		tc.a_seal_rune_command("bf-p.2", "not needed")
+
+		// When
+		tc.handle_seal_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p", EventRuneFulfilled, EventRuneNoted)
+	})
+
+	t.Run("does not complete parent when every child is sealed", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "open")
+		tc.saga_progress_with_children("bf-p", "bf-p.1")
+		tc.a_seal_rune_command("bf-p.1", "not needed")
+
+		// When
+		tc.handle_seal_rune()
+
+		// Then
+		tc.no_error()
+		tc.no_events_appended_to_stream("rune-bf-p")
+	})
+
+	t.Run("flags parent for review in flag mode", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFlag)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "claimed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1")
+		tc.a_fulfill_rune_command("bf-p.1")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p", EventRuneUpdated, EventRuneNoted)
+	})
+
+	t.Run("flags parent instead of fulfilling when its ACs are not verified", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.realm_requires_ac_verification()
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.rune_has_ac_in_stream("bf-p", "AC-01")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "claimed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1")
+		tc.a_fulfill_rune_command("bf-p.1")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p", EventRuneUpdated, EventRuneNoted)
+	})
+
+	t.Run("cascades fulfilment to the grandparent", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-g", "open")
+		tc.existing_child_rune_in_stream("bf-g.1", "bf-g", "open")
+		tc.existing_child_rune_in_stream("bf-g.1.1", "bf-g.1", "claimed")
+		tc.saga_progress_with_children("bf-g", "bf-g.1")
+		tc.saga_progress_with_children("bf-g.1", "bf-g.1.1")
+		tc.a_fulfill_rune_command("bf-g.1.1")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-g.1", EventRuneFulfilled, EventRuneNoted)
+		tc.events_appended_to_stream("rune-bf-g", EventRuneFulfilled, EventRuneNoted)
+	})
+
+	t.Run("child fulfilment succeeds when completing the parent fails", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "claimed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1")
+		tc.append_to_stream_fails("rune-bf-p", errors.New("disk full"))
+		tc.a_fulfill_rune_command("bf-p.1")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p.1", EventRuneFulfilled)
+	})
+
+	t.Run("child seal succeeds when completing the parent fails", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "fulfilled")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "open")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2")
+		tc.append_to_stream_fails("rune-bf-p", errors.New("disk full"))
+		tc.a_seal_rune_command("bf-p.2", "not needed")
+
+		// When
+		tc.handle_seal_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p.2", EventRuneSealed)
+	})
+	t.Run("flags the parent when a fulfill policy rejects auto-fulfilment", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.realm_policy("retro-before-fulfill", `rune.retro_count > 0`, "fulfill")
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "claimed")
+		tc.rune_has_retro_in_stream("bf-p.1")
+		tc.saga_progress_with_children("bf-p", "bf-p.1")
+		tc.a_fulfill_rune_command("bf-p.1")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p.1", EventRuneFulfilled)
+		tc.events_appended_to_stream("rune-bf-p", EventRuneUpdated, EventRuneNoted)
+	})
+
+	t.Run("leaves the parent alone when policies reject both fulfilling and flagging", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.realm_policy("frozen-parent", `rune.id != "bf-p"`, "fulfill", "update")
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "claimed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1")
+		tc.a_fulfill_rune_command("bf-p.1")
+
+		// When
+		tc.handle_fulfill_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p.1", EventRuneFulfilled)
+		tc.no_events_appended_to_stream("rune-bf-p")
+	})
+
+	t.Run("moving the last unfinished child away completes the old parent", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_rune_in_stream("bf-q", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "fulfilled")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "claimed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2")
+		tc.returns_child_count("bf-q", 0)
+		tc.a_move_command("bf-p.2", "bf-q")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p.2", EventRuneMoved)
+		tc.events_appended_to_stream("rune-bf-p", EventRuneFulfilled, EventRuneNoted)
+	})
+
+	t.Run("moving a child away leaves the old parent alone while another child is open", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_rune_in_stream("bf-q", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "fulfilled")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2")
+		tc.returns_child_count("bf-q", 0)
+		tc.a_move_command("bf-p.2", "bf-q")
+
+		// When
+		tc.handle_move_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p.2", EventRuneMoved)
+		tc.no_events_appended_to_stream("rune-bf-p")
+	})
+
+	t.Run("shattering a sealed child completes a parent whose other children are fulfilled", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "fulfilled")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "sealed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2")
+		tc.a_shatter_rune_command("bf-p.2")
+
+		// When
+		tc.handle_shatter_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p.2", EventRuneShattered)
+		tc.events_appended_to_stream("rune-bf-p", EventRuneFulfilled, EventRuneNoted)
+	})
+
+	t.Run("shattering the only fulfilled child does not complete the parent", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.a_store()
+		tc.realm_settings_with_saga_auto_complete(SagaAutoCompleteFulfill)
+		tc.existing_rune_in_stream("bf-p", "open")
+		tc.existing_child_rune_in_stream("bf-p.1", "bf-p", "fulfilled")
+		tc.existing_child_rune_in_stream("bf-p.2", "bf-p", "sealed")
+		tc.saga_progress_with_children("bf-p", "bf-p.1", "bf-p.2")
+		tc.a_shatter_rune_command("bf-p.1")
+
+		// When
+		tc.handle_shatter_rune()
+
+		// Then
+		tc.no_error()
+		tc.events_appended_to_stream("rune-bf-p.1", EventRuneShattered)
+		tc.no_events_appended_to_stream("rune-bf-p")
+	})
+}
+
+// --- Given ---
+
+func (tc *handlerTestContext) existing_child_rune_in_stream(runeID, parentID, status string) {
+	tc.t.Helper()
+	tc.existing_rune_in_stream(runeID, status)
+	events := tc.eventStore.streams["rune-"+runeID]
+	events[0] = makeEvent(EventRuneCreated, RuneCreated{
+		ID: runeID, Title: "Child rune", Priority: 1, ParentID: parentID,
+	})
+}
+
+func (tc *handlerTestContext) append_to_stream_fails(streamID string, err error) {
+	tc.t.Helper()
+	if tc.eventStore.appendErrs == nil {
+		tc.eventStore.appendErrs = make(map[string]error)
+	}
+	tc.eventStore.appendErrs[streamID] = err
+}
+
+func (tc *handlerTestContext) realm_settings_with_saga_auto_complete(mode string) {
+	tc.t.Helper()
+	tc.a_store()
+	settings := DefaultRealmSettings(tc.realmID)
+	settings.SagaAutoComplete = mode
+	tc.projectionStore.data["_admin:realm_settings:"+tc.realmID] = settings
+}
+
+func (tc *handlerTestContext) realm_requires_ac_verification() {
+	tc.t.Helper()
+	key := "_admin:realm_settings:" + tc.realmID
+	settings, _ := tc.projectionStore.data[key].(RealmSettings)
+	settings.RequireACVerification = true
+	tc.projectionStore.data[key] = settings
+}
+
+func (tc *handlerTestContext) rune_has_ac_in_stream(runeID, acID string) {
+	tc.t.Helper()
+	tc.eventStore.streams["rune-"+runeID] = append(tc.eventStore.streams["rune-"+runeID],
+		makeEvent(EventRuneACAdded, RuneACAdded{RuneID: runeID, ID: acID, Scenario: "scenario", Description: "desc"}))
+}
+
+func (tc *handlerTestContext) rune_has_retro_in_stream(runeID string) {
+	tc.t.Helper()
+	tc.eventStore.streams["rune-"+runeID] = append(tc.eventStore.streams["rune-"+runeID],
+		makeEvent(EventRuneRetroed, RuneRetroed{RuneID: runeID, Text: "went well"}))
+}
+
+func (tc *handlerTestContext) saga_progress_with_children(parentID string, childIDs ...string) {
+	tc.t.Helper()
+	tc.a_store()
+	children := make(map[string]string, len(childIDs))
+	for _, id := range childIDs {
+		children[id] = "open"
+	}
+	tc.projectionStore.data[tc.realmID+":saga_progress:"+parentID] = map[string]any{
+		"rune_id":  parentID,
+		"children": children,
+	}
+}
+
+// --- Then ---
+
+func (tc *handlerTestContext) events_appended_to_stream(streamID string, eventTypes ...string) {
+	tc.t.Helper()
+	call := tc.append_call_for_stream(streamID)
+	require.NotNil(tc.t, call, "expected Append to stream %q", streamID)
+	actual := make([]string, 0, len(call.events))
+	for _, evt := range call.events {
+		actual = append(actual, evt.EventType)
+	}
+	assert.Equal(tc.t, eventTypes, actual)
+}
+
+func (tc *handlerTestContext) no_events_appended_to_stream(streamID string) {
+	tc.t.Helper()
+	assert.Nil(tc.t, tc.append_call_for_stream(streamID), "expected no Append to stream %q", streamID)
+}
+
+func (tc *handlerTestContext) append_call_for_stream(streamID string) *appendCall {
+	tc.t.Helper()
+	for i := range tc.eventStore.appendedCalls {
+		if tc.eventStore.appendedCalls[i].streamID == streamID {
+			return &tc.eventStore.appendedCalls[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestRuneSchedule(t *testing.T) {
+	dueAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	deferUntil := time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("rebuilds due_at and defer_until from RuneCreated", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.events_from_created_rune_with_schedule(&dueAt, &deferUntil)
+
+		// When
+		tc.state_is_rebuilt()
+
+		// Then
+		tc.state_has_due_at(&dueAt)
+		tc.state_has_defer_until(&deferUntil)
+	})
+
+	t.Run("RuneUpdated replaces due_at and clears defer_until", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+		newDue := dueAt.Add(48 * time.Hour)
+
+		// Given
+		tc.events_from_created_rune_with_schedule(&dueAt, &deferUntil)
+		tc.events_include(EventRuneUpdated, RuneUpdated{ID: "bf-a1b2", DueAt: &newDue, ClearDeferUntil: true})
+
+		// When
+		tc.state_is_rebuilt()
+
+		// Then
+		tc.state_has_due_at(&newDue)
+		tc.state_has_defer_until(nil)
+	})
+
+	t.Run("RuneUpdated without schedule fields leaves them unchanged", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.events_from_created_rune_with_schedule(&dueAt, &deferUntil)
+		tc.events_include(EventRuneUpdated, RuneUpdated{ID: "bf-a1b2", Title: strPtr("Renamed")})
+
+		// When
+		tc.state_is_rebuilt()
+
+		// Then
+		tc.state_has_due_at(&dueAt)
+		tc.state_has_defer_until(&deferUntil)
+	})
+
+	t.Run("HandleCreateRune records schedule in UTC", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+		local := time.Date(2026, 3, 1, 14, 0, 0, 0, time.FixedZone("EET", 2*60*60))
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.a_store()
+		tc.a_create_rune_command("Ship it", "", 1, "")
+		tc.with_branch_on_create_command("main")
+		tc.with_schedule_on_create_command(&local, nil)
+
+		// When
+		tc.handle_create_rune()
+
+		// Then
+		tc.no_error()
+		tc.created_event_has_due_at(&dueAt)
+	})
+
+	t.Run("HandleUpdateRune appends schedule changes", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.an_update_rune_command("bf-a1b2", nil, nil, nil)
+		tc.updateCmd.DeferUntil = &deferUntil
+		tc.updateCmd.ClearDueAt = true
+
+		// When
+		tc.handle_update_rune()
+
+		// Then
+		tc.no_error()
+		tc.appended_rune_updated_event_has_schedule(nil, &deferUntil, true, false)
+	})
+
+	t.Run("HandleUpdateRune rejects setting and clearing due_at together", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.an_event_store()
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.an_update_rune_command("bf-a1b2", nil, nil, nil)
+		tc.updateCmd.DueAt = &dueAt
+		tc.updateCmd.ClearDueAt = true
+
+		// When
+		tc.handle_update_rune()
+
+		// Then
+		tc.error_contains("cannot both set and clear due_at")
+	})
+}
+
+// --- Given ---
+
+func (tc *handlerTestContext) events_from_created_rune_with_schedule(dueAt, deferUntil *time.Time) {
+	tc.t.Helper()
+	tc.events = append(tc.events, makeEvent(EventRuneCreated, RuneCreated{
+		ID: "bf-a1b2", Title: "Fix the bridge", Priority: 1, DueAt: dueAt, DeferUntil: deferUntil,
+	}))
+}
+
+func (tc *handlerTestContext) events_include(eventType string, data any) {
+	tc.t.Helper()
+	tc.events = append(tc.events, makeEvent(eventType, data))
+}
+
+func (tc *handlerTestContext) with_schedule_on_create_command(dueAt, deferUntil *time.Time) {
+	tc.t.Helper()
+	tc.createCmd.DueAt = dueAt
+	tc.createCmd.DeferUntil = deferUntil
+}
+
+// --- Then ---
+
+func (tc *handlerTestContext) state_has_due_at(expected *time.Time) {
+	tc.t.Helper()
+	assertTimePtrEqual(tc.t, expected, tc.state.DueAt)
+}
+
+func (tc *handlerTestContext) state_has_defer_until(expected *time.Time) {
+	tc.t.Helper()
+	assertTimePtrEqual(tc.t, expected, tc.state.DeferUntil)
+}
+
+func (tc *handlerTestContext) created_event_has_due_at(expected *time.Time) {
+	tc.t.Helper()
+	assertTimePtrEqual(tc.t, expected, tc.createdEvent.DueAt)
+	if tc.createdEvent.DueAt != nil {
+		assert.Equal(tc.t, time.UTC, tc.createdEvent.DueAt.Location())
+	}
+}
+
+func (tc *handlerTestContext) appended_rune_updated_event_has_schedule(dueAt, deferUntil *time.Time, clearDue, clearDefer bool) {
+	tc.t.Helper()
+	require.NotEmpty(tc.t, tc.eventStore.appendedCalls, "expected at least one Append call")
+	lastCall := tc.eventStore.appendedCalls[len(tc.eventStore.appendedCalls)-1]
+	for _, evt := range lastCall.events {
+		if evt.EventType == EventRuneUpdated {
+			dataBytes, _ := json.Marshal(evt.Data)
+			var data RuneUpdated
+			require.NoError(tc.t, json.Unmarshal(dataBytes, &data))
+			assertTimePtrEqual(tc.t, dueAt, data.DueAt)
+			assertTimePtrEqual(tc.t, deferUntil, data.DeferUntil)
+			assert.Equal(tc.t, clearDue, data.ClearDueAt)
+			assert.Equal(tc.t, clearDefer, data.ClearDeferUntil)
+			return
+		}
+	}
+	tc.t.Fatal("no RuneUpdated event found in last Append call")
+}
+
+// --- Helpers ---
+
+func assertTimePtrEqual(t *testing.T, expected, actual *time.Time) {
+	t.Helper()
+	if expected == nil {
+		assert.Nil(t, actual)
+		return
+	}
+	require.NotNil(t, actual)
+	assert.True(t, expected.Equal(*actual), "expected %s, got %s", expected, actual)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/devzeebo/bifrost/core"
@@ -185,6 +186,54 @@ func TestHandleUpdateRuneState(t *testing.T) {
 		// Then
 		tc.no_error()
 	})
+
+	t.Run("rejects state over the default size cap", func(t *testing.T) {
+		tc := newStateHandlerTestContext(t)
+
+		// Given
+		tc.an_event_store()
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.an_update_state_command("bf-a1b2", `{"blob": "`+strings.Repeat("x", MaxStateSize)+`"}`)
+
+		// When
+		tc.handle_update_state()
+
+		// Then
+		tc.error_contains("exceeds maximum 65536 bytes")
+	})
+
+	t.Run("rejects merged state over the realm's max_state_size", func(t *testing.T) {
+		tc := newStateHandlerTestContext(t)
+
+		// Given
+		tc.an_event_store()
+		tc.existing_rune_with_state_in_stream("bf-a1b2", `{"notes": "0123456789"}`)
+		tc.realm_max_state_size(32)
+		tc.an_update_state_command("bf-a1b2", `{"more": "0123456789"}`)
+
+		// When
+		tc.handle_update_state()
+
+		// Then
+		tc.error_contains("exceeds maximum 32 bytes")
+	})
+
+	t.Run("allows state within the realm's max_state_size", func(t *testing.T) {
+		tc := newStateHandlerTestContext(t)
+
+		// Given
+		tc.an_event_store()
+		tc.existing_rune_with_state_in_stream("bf-a1b2", `{"notes": "0123456789"}`)
+		tc.realm_max_state_size(32)
+		tc.an_update_state_command("bf-a1b2", `{"notes": null, "x": 1}`)
+
+		// When
+		tc.handle_update_state()
+
+		// Then
+		tc.no_error()
+		tc.appended_event_has_type(EventRuneStateUpdated)
+	})
 }
 
 func TestHandleClearRuneState(t *testing.T) {
@@ -261,18 +310,20 @@ type stateHandlerTestContext struct {
 	ctx     context.Context
 	realmID string
 
-	eventStore     *mockEventStore
-	updateStateCmd UpdateRuneState
-	clearStateCmd  ClearRuneState
-	err            error
+	eventStore      *mockEventStore
+	projectionStore *mockProjectionStore
+	updateStateCmd  UpdateRuneState
+	clearStateCmd   ClearRuneState
+	err             error
 }
 
 func newStateHandlerTestContext(t *testing.T) *stateHandlerTestContext {
 	t.Helper()
 	return &stateHandlerTestContext{
-		t:       t,
-		ctx:     context.Background(),
-		realmID: "realm-1",
+		t:               t,
+		ctx:             context.Background(),
+		realmID:         "realm-1",
+		projectionStore: newMockProjectionStore(),
 	}
 }
 
@@ -335,6 +386,13 @@ func (tc *stateHandlerTestContext) existing_rune_with_state_in_stream(runeID, st
 	tc.eventStore.streams["rune-"+runeID] = events
 }
 
+func (tc *stateHandlerTestContext) realm_max_state_size(limit int) {
+	tc.t.Helper()
+	settings := DefaultRealmSettings(tc.realmID)
+	settings.MaxStateSize = limit
+	tc.projectionStore.data[AdminRealmID+":realm_settings:"+tc.realmID] = settings
+}
+
 func (tc *stateHandlerTestContext) empty_stream(runeID string) {
 	tc.t.Helper()
 	tc.an_event_store()
@@ -362,12 +420,12 @@ func (tc *stateHandlerTestContext) a_clear_state_command(runeID string) {
 
 func (tc *stateHandlerTestContext) handle_update_state() {
 	tc.t.Helper()
-	tc.err = HandleUpdateRuneState(tc.ctx, tc.realmID, tc.updateStateCmd, tc.eventStore)
+	tc.err = HandleUpdateRuneState(tc.ctx, tc.realmID, tc.updateStateCmd, tc.eventStore, tc.projectionStore)
 }
 
 func (tc *stateHandlerTestContext) handle_clear_state() {
 	tc.t.Helper()
-	tc.err = HandleClearRuneState(tc.ctx, tc.realmID, tc.clearStateCmd, tc.eventStore)
+	tc.err = HandleClearRuneState(tc.ctx, tc.realmID, tc.clearStateCmd, tc.eventStore, tc.projectionStore)
 }
 
 // ---------------------------------------------------------------------------
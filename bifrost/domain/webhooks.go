@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// WebhookEventTypes lists the rune events a webhook can subscribe to. A
+// webhook with no event types receives all of them.
+var WebhookEventTypes = []string{
+	EventRuneCreated, EventRuneUpdated, EventRuneClaimed, EventRuneUnclaimed,
+	EventRuneFulfilled, EventRuneForged, EventRuneSealed, EventRuneFailed,
+	EventRuneReopened, EventRuneShattered, EventRuneMoved, EventDependencyAdded,
+	EventDependencyRemoved, EventRuneNoted, EventRuneRetroed, EventRuneACAdded,
+	EventRuneACUpdated, EventRuneACRemoved, EventRuneACVerified,
+	EventRuneStateUpdated,
+}
+
+// Webhook is a realm's subscription of a URL to rune events.
+type Webhook struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	Secret     string    `json:"secret"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Matches reports whether an event of eventType on a rune with runeTags is
+// delivered to the webhook. A webhook with tags only receives events for
+// runes carrying at least one of them.
+func (w Webhook) Matches(eventType string, runeTags []string) bool {
+	if len(w.EventTypes) > 0 && !slices.Contains(w.EventTypes, eventType) {
+		return false
+	}
+	if len(w.Tags) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(w.Tags, func(tag string) bool {
+		return slices.Contains(runeTags, tag)
+	})
+}
+
+// RealmWebhooks is the webhook set for a realm as projected into realm_webhooks.
+type RealmWebhooks struct {
+	RealmID  string    `json:"realm_id"`
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// Find returns the webhook with the given ID.
+func (r RealmWebhooks) Find(webhookID string) (Webhook, bool) {
+	for _, webhook := range r.Webhooks {
+		if webhook.ID == webhookID {
+			return webhook, true
+		}
+	}
+	return Webhook{}, false
+}
+
+// ApplyWebhookAdded adds the webhook from a WebhookAdded event.
+func ApplyWebhookAdded(webhooks RealmWebhooks, data WebhookAdded) RealmWebhooks {
+	webhooks = ApplyWebhookRemoved(webhooks, WebhookRemoved{RealmID: data.RealmID, WebhookID: data.WebhookID})
+	webhooks.Webhooks = append(webhooks.Webhooks, Webhook{
+		ID:         data.WebhookID,
+		URL:        data.URL,
+		EventTypes: data.EventTypes,
+		Tags:       data.Tags,
+		Secret:     data.Secret,
+		CreatedAt:  data.CreatedAt,
+	})
+	return webhooks
+}
+
+// ApplyWebhookRemoved drops the webhook named in a WebhookRemoved event.
+func ApplyWebhookRemoved(webhooks RealmWebhooks, data WebhookRemoved) RealmWebhooks {
+	updated := make([]Webhook, 0, len(webhooks.Webhooks))
+	for _, existing := range webhooks.Webhooks {
+		if existing.ID != data.WebhookID {
+			updated = append(updated, existing)
+		}
+	}
+	webhooks.Webhooks = updated
+	return webhooks
+}
+
+func rebuildRealmWebhooks(realmID string, events []core.Event) RealmWebhooks {
+	webhooks := RealmWebhooks{RealmID: realmID, Webhooks: []Webhook{}}
+	for _, evt := range events {
+		switch evt.EventType {
+		case EventWebhookAdded:
+			var data WebhookAdded
+			_ = json.Unmarshal(evt.Data, &data)
+			webhooks = ApplyWebhookAdded(webhooks, data)
+		case EventWebhookRemoved:
+			var data WebhookRemoved
+			_ = json.Unmarshal(evt.Data, &data)
+			webhooks = ApplyWebhookRemoved(webhooks, data)
+		}
+	}
+	return webhooks
+}
+
+// ReadRealmWebhooks loads the projected webhook set for a realm. A realm
+// without webhooks yields an empty set.
+func ReadRealmWebhooks(ctx context.Context, realmID string, projStore core.ProjectionStore) (RealmWebhooks, error) {
+	var webhooks RealmWebhooks
+	err := projStore.Get(ctx, AdminRealmID, "realm_webhooks", realmID, &webhooks)
+	if err != nil {
+		if isNotFoundError(err) {
+			return RealmWebhooks{RealmID: realmID, Webhooks: []Webhook{}}, nil
+		}
+		return RealmWebhooks{}, fmt.Errorf("read realm webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// WebhookDestinationAllowed reports whether webhooks may be sent to host, a
+// hostname or IP address. Localhost and loopback, private, link-local and
+// other non-public addresses are refused unless allowed lists the host, or a
+// network containing the address. A hostname is only checked by name here;
+// the addresses it resolves to are checked again when a delivery connects.
+func WebhookDestinationAllowed(host string, allowed []string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	addr, addrErr := netip.ParseAddr(host)
+	for _, entry := range allowed {
+		if strings.EqualFold(entry, host) {
+			return true
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil && addrErr == nil && prefix.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	if addrErr != nil {
+		return host != "" && host != "localhost" && !strings.HasSuffix(host, ".localhost")
+	}
+	return isPublicAddr(addr)
+}
+
+// cgnatPrefix is the shared address space carriers use for NAT (RFC 6598).
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	ip := net.IP(addr.AsSlice())
+	return addr.IsGlobalUnicast() && !ip.IsPrivate() && !cgnatPrefix.Contains(addr)
+}
+
+// AddWebhookResult carries the new webhook's ID and signing secret. The
+// secret is not shown again.
+type AddWebhookResult struct {
+	WebhookID string `json:"webhook_id"`
+	Secret    string `json:"secret"`
+}
+
+func HandleAddWebhook(ctx context.Context, cmd AddWebhook, store core.EventStore) (AddWebhookResult, error) {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return AddWebhookResult{}, err
+	}
+	if !state.Exists {
+		return AddWebhookResult{}, &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	target, err := url.Parse(cmd.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return AddWebhookResult{}, fmt.Errorf("invalid webhook URL %q: must be an absolute http or https URL", cmd.URL)
+	}
+	if !WebhookDestinationAllowed(target.Hostname(), cmd.AllowedHosts) {
+		return AddWebhookResult{}, fmt.Errorf("invalid webhook URL %q: destination must be a public address", cmd.URL)
+	}
+	for _, eventType := range cmd.EventTypes {
+		if !slices.Contains(WebhookEventTypes, eventType) {
+			return AddWebhookResult{}, fmt.Errorf("unknown webhook event type %q", eventType)
+		}
+	}
+
+	webhookID, err := generateWebhookID()
+	if err != nil {
+		return AddWebhookResult{}, err
+	}
+	secret := cmd.Secret
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return AddWebhookResult{}, err
+		}
+	}
+
+	added := WebhookAdded{
+		RealmID:    cmd.RealmID,
+		WebhookID:  webhookID,
+		URL:        cmd.URL,
+		EventTypes: cmd.EventTypes,
+		Tags:       normalizeTags(cmd.Tags),
+		Secret:     secret,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventWebhookAdded, Data: added},
+	})
+	if err != nil {
+		return AddWebhookResult{}, err
+	}
+	return AddWebhookResult{WebhookID: webhookID, Secret: secret}, nil
+}
+
+func HandleRemoveWebhook(ctx context.Context, cmd RemoveWebhook, store core.EventStore) error {
+	state, events, err := readAndRebuildRealmState(ctx, cmd.RealmID, store)
+	if err != nil {
+		return err
+	}
+	if !state.Exists {
+		return &core.NotFoundError{Entity: "realm", ID: cmd.RealmID}
+	}
+	if _, found := rebuildRealmWebhooks(cmd.RealmID, events).Find(cmd.WebhookID); !found {
+		return &core.NotFoundError{Entity: "webhook", ID: cmd.WebhookID}
+	}
+
+	removed := WebhookRemoved(cmd)
+
+	streamID := realmStreamID(cmd.RealmID)
+	_, err = store.Append(ctx, AdminRealmID, streamID, len(events), []core.EventData{
+		{EventType: EventWebhookRemoved, Data: removed},
+	})
+	return err
+}
+
+func generateWebhookID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate webhook ID: %w", err)
+	}
+	return "wh-" + hex.EncodeToString(b), nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
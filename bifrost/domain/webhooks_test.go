@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestHandleAddWebhook(t *testing.T) {
+	t.Run("appends WebhookAdded with a generated secret", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.an_add_webhook_command("bf-a1b2", "https://ci.example.com/hook", EventRuneFulfilled)
+
+		// When
+		tc.handle_add_webhook()
+
+		// Then
+		tc.no_realm_error()
+		tc.realm_event_was_appended_to_stream("realm-bf-a1b2")
+		tc.appended_realm_event_has_type(EventWebhookAdded)
+		tc.webhook_result_has_generated_id_and_secret()
+	})
+
+	t.Run("normalizes tags", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.an_add_webhook_command("bf-a1b2", "https://ci.example.com/hook")
+		tc.addWebhookCmd.Tags = []string{" Release ", "release", "ci"}
+
+		// When
+		tc.handle_add_webhook()
+
+		// Then
+		tc.no_realm_error()
+		tc.appended_webhook_has_tags("ci", "release")
+	})
+
+	t.Run("rejects a URL that is not http or https", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.an_add_webhook_command("bf-a1b2", "ftp://example.com/hook")
+
+		// When
+		tc.handle_add_webhook()
+
+		// Then
+		tc.realm_error_contains("invalid webhook URL")
+	})
+
+	t.Run("rejects private and loopback destinations", func(t *testing.T) {
+		for _, target := range []string{
+			"http://localhost:8080/hook",
+			"http://api.localhost/hook",
+			"http://127.0.0.1/hook",
+			"http://[::1]/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://10.0.0.5/hook",
+			"http://192.168.1.10/hook",
+			"http://[::ffff:172.16.0.1]/hook",
+			"http://0.0.0.0/hook",
+		} {
+			tc := newRealmHandlerTestContext(t)
+
+			// Given
+			tc.existing_realm_in_stream("bf-a1b2", "active")
+			tc.an_add_webhook_command("bf-a1b2", target)
+
+			// When
+			tc.handle_add_webhook()
+
+			// Then
+			tc.realm_error_contains("destination must be a public address")
+		}
+	})
+
+	t.Run("accepts allowed private destinations", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.an_add_webhook_command("bf-a1b2", "http://10.0.0.5/hook")
+		tc.addWebhookCmd.AllowedHosts = []string{"10.0.0.0/8"}
+
+		// When
+		tc.handle_add_webhook()
+
+		// Then
+		tc.no_realm_error()
+		tc.appended_realm_event_has_type(EventWebhookAdded)
+	})
+
+	t.Run("rejects unknown event types", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.an_add_webhook_command("bf-a1b2", "https://ci.example.com/hook", "RuneTeleported")
+
+		// When
+		tc.handle_add_webhook()
+
+		// Then
+		tc.realm_error_contains(`unknown webhook event type "RuneTeleported"`)
+	})
+
+	t.Run("returns not found for missing realm", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.empty_realm_stream("bf-missing")
+		tc.an_add_webhook_command("bf-missing", "https://ci.example.com/hook")
+
+		// When
+		tc.handle_add_webhook()
+
+		// Then
+		tc.realm_error_is_not_found("realm", "bf-missing")
+	})
+}
+
+func TestHandleRemoveWebhook(t *testing.T) {
+	t.Run("appends WebhookRemoved for an existing webhook", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.realm_has_webhook_in_stream("bf-a1b2", "wh-1")
+		tc.a_remove_webhook_command("bf-a1b2", "wh-1")
+
+		// When
+		tc.handle_remove_webhook()
+
+		// Then
+		tc.no_realm_error()
+		tc.appended_realm_event_has_type(EventWebhookRemoved)
+	})
+
+	t.Run("returns not found for an unknown webhook", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.a_remove_webhook_command("bf-a1b2", "wh-missing")
+
+		// When
+		tc.handle_remove_webhook()
+
+		// Then
+		tc.realm_error_is_not_found("webhook", "wh-missing")
+	})
+}
+
+func TestWebhookMatches(t *testing.T) {
+	t.Run("matches every event without filters", func(t *testing.T) {
+		assert.True(t, Webhook{}.Matches(EventRuneClaimed, nil))
+	})
+
+	t.Run("matches only the subscribed event types", func(t *testing.T) {
+		webhook := Webhook{EventTypes: []string{EventRuneFulfilled}}
+
+		assert.True(t, webhook.Matches(EventRuneFulfilled, nil))
+		assert.False(t, webhook.Matches(EventRuneClaimed, nil))
+	})
+
+	t.Run("matches runes carrying any of the tags", func(t *testing.T) {
+		webhook := Webhook{Tags: []string{"release", "ci"}}
+
+		assert.True(t, webhook.Matches(EventRuneClaimed, []string{"backend", "ci"}))
+		assert.False(t, webhook.Matches(EventRuneClaimed, []string{"backend"}))
+	})
+}
+
+func TestWebhookDestinationAllowed(t *testing.T) {
+	t.Run("allows public hostnames and addresses", func(t *testing.T) {
+		assert.True(t, WebhookDestinationAllowed("ci.example.com", nil))
+		assert.True(t, WebhookDestinationAllowed("93.184.216.34", nil))
+		assert.True(t, WebhookDestinationAllowed("2606:2800:220:1::", nil))
+	})
+
+	t.Run("refuses localhost and non-public addresses", func(t *testing.T) {
+		for _, host := range []string{"localhost", "LOCALHOST.", "db.localhost", "127.0.0.1", "::1", "169.254.169.254", "fe80::1", "10.1.2.3", "172.16.0.1", "192.168.0.1", "100.64.0.1", "fd00::1", "0.0.0.0", "224.0.0.1", ""} {
+			assert.False(t, WebhookDestinationAllowed(host, nil), host)
+		}
+	})
+
+	t.Run("allows listed hosts and networks", func(t *testing.T) {
+		allowed := []string{"localhost", "192.168.0.0/16"}
+
+		assert.True(t, WebhookDestinationAllowed("localhost", allowed))
+		assert.True(t, WebhookDestinationAllowed("192.168.4.2", allowed))
+		assert.False(t, WebhookDestinationAllowed("10.0.0.1", allowed))
+	})
+}
+
+// --- Given ---
+
+func (tc *realmHandlerTestContext) an_add_webhook_command(realmID, url string, eventTypes ...string) {
+	tc.t.Helper()
+	tc.addWebhookCmd = AddWebhook{RealmID: realmID, URL: url, EventTypes: eventTypes}
+}
+
+func (tc *realmHandlerTestContext) a_remove_webhook_command(realmID, webhookID string) {
+	tc.t.Helper()
+	tc.removeWebhookCmd = RemoveWebhook{RealmID: realmID, WebhookID: webhookID}
+}
+
+func (tc *realmHandlerTestContext) realm_has_webhook_in_stream(realmID, webhookID string) {
+	tc.t.Helper()
+	stream := "realm-" + realmID
+	tc.eventStore.streams[stream] = append(tc.eventStore.streams[stream],
+		makeEvent(EventWebhookAdded, WebhookAdded{RealmID: realmID, WebhookID: webhookID, URL: "https://example.com/hook"}))
+}
+
+// --- When ---
+
+func (tc *realmHandlerTestContext) handle_add_webhook() {
+	tc.t.Helper()
+	tc.addWebhookResult, tc.err = HandleAddWebhook(tc.ctx, tc.addWebhookCmd, tc.eventStore)
+}
+
+func (tc *realmHandlerTestContext) handle_remove_webhook() {
+	tc.t.Helper()
+	tc.err = HandleRemoveWebhook(tc.ctx, tc.removeWebhookCmd, tc.eventStore)
+}
+
+// --- Then ---
+
+func (tc *realmHandlerTestContext) webhook_result_has_generated_id_and_secret() {
+	tc.t.Helper()
+	assert.True(tc.t, strings.HasPrefix(tc.addWebhookResult.WebhookID, "wh-"), tc.addWebhookResult.WebhookID)
+	assert.True(tc.t, strings.HasPrefix(tc.addWebhookResult.Secret, "whsec_"), tc.addWebhookResult.Secret)
+}
+
+func (tc *realmHandlerTestContext) appended_webhook_has_tags(expected ...string) {
+	tc.t.Helper()
+	require.NotEmpty(tc.t, tc.eventStore.appendedCalls)
+	lastCall := tc.eventStore.appendedCalls[len(tc.eventStore.appendedCalls)-1]
+	require.Len(tc.t, lastCall.events, 1)
+	added, ok := lastCall.events[0].Data.(WebhookAdded)
+	require.True(tc.t, ok, "expected WebhookAdded, got %T", lastCall.events[0].Data)
+	assert.Equal(tc.t, expected, added.Tags)
+}
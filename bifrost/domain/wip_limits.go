@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// WIP limit kinds reported by WIPLimitError.
+const (
+	WIPLimitClaimant = "claimant"
+	WIPLimitAccount  = "account"
+	WIPLimitTag      = "tag"
+	WIPLimitRealm    = "realm"
+)
+
+// wipStreamID is the realm-level stream claims are reserved on while the
+// realm has WIP limits. Its expected version serializes concurrent claims.
+const wipStreamID = "wip-claims"
+
+// WIPLimits caps the number of runes claimed at once in a realm. A zero
+// limit is unlimited. PerTag maps a tag to the claims allowed across runes
+// carrying it.
+type WIPLimits struct {
+	PerClaimant int            `json:"per_claimant,omitempty"`
+	PerAccount  int            `json:"per_account,omitempty"`
+	PerRealm    int            `json:"per_realm,omitempty"`
+	PerTag      map[string]int `json:"per_tag,omitempty"`
+}
+
+func (l WIPLimits) isUnlimited() bool {
+	return l.PerClaimant == 0 && l.PerAccount == 0 && l.PerRealm == 0 && len(l.PerTag) == 0
+}
+
+func (l WIPLimits) validate() error {
+	if l.PerClaimant < 0 || l.PerAccount < 0 || l.PerRealm < 0 {
+		return fmt.Errorf("invalid wip_limits: limits must not be negative")
+	}
+	for tag, limit := range l.PerTag {
+		if limit < 0 {
+			return fmt.Errorf("invalid wip_limits: limit for tag %q must not be negative", tag)
+		}
+	}
+	return nil
+}
+
+// normalized returns the limits with PerTag keys normalized like rune tags.
+func (l WIPLimits) normalized() WIPLimits {
+	if len(l.PerTag) == 0 {
+		l.PerTag = nil
+		return l
+	}
+	perTag := make(map[string]int, len(l.PerTag))
+	for tag, limit := range l.PerTag {
+		perTag[strings.ToLower(strings.TrimSpace(tag))] = limit
+	}
+	l.PerTag = perTag
+	return l
+}
+
+// ActiveClaim is a claimed rune as tracked in the wip_claims projection.
+type ActiveClaim struct {
+	RuneID    string   `json:"rune_id"`
+	Claimant  string   `json:"claimant"`
+	AccountID string   `json:"account_id,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// ActiveClaims is the wip_claims projection document for a realm, keyed by rune ID.
+// Version is the last WIP stream event applied to it.
+// Key: constant string 'claims' (single row per realm).
+type ActiveClaims struct {
+	Claims  map[string]ActiveClaim `json:"claims"`
+	Version int                    `json:"version,omitempty"`
+}
+
+// ReadActiveClaims loads the runes currently claimed in a realm.
+func ReadActiveClaims(ctx context.Context, realmID string, projStore core.ProjectionStore) (ActiveClaims, error) {
+	var claims ActiveClaims
+	err := projStore.Get(ctx, realmID, "wip_claims", "claims", &claims)
+	if err != nil && !isNotFoundError(err) {
+		return ActiveClaims{}, fmt.Errorf("read active claims: %w", err)
+	}
+	if claims.Claims == nil {
+		claims.Claims = map[string]ActiveClaim{}
+	}
+	return claims, nil
+}
+
+// WIPUsage reports current claim counts next to the realm's limits.
+type WIPUsage struct {
+	Limits    WIPLimits      `json:"limits"`
+	Realm     int            `json:"realm"`
+	Claimants map[string]int `json:"claimants"`
+	Accounts  map[string]int `json:"accounts"`
+	Tags      map[string]int `json:"tags"`
+}
+
+// Usage counts the active claims by claimant, account and tag.
+func (c ActiveClaims) Usage(limits WIPLimits) WIPUsage {
+	usage := WIPUsage{
+		Limits:    limits,
+		Realm:     len(c.Claims),
+		Claimants: map[string]int{},
+		Accounts:  map[string]int{},
+		Tags:      map[string]int{},
+	}
+	for _, claim := range c.Claims {
+		usage.Claimants[claim.Claimant]++
+		if claim.AccountID != "" {
+			usage.Accounts[claim.AccountID]++
+		}
+		for _, tag := range claim.Tags {
+			usage.Tags[tag]++
+		}
+	}
+	return usage
+}
+
+// ReadWIPUsage loads the realm's WIP limits and current claim counts.
+func ReadWIPUsage(ctx context.Context, realmID string, projStore core.ProjectionStore) (WIPUsage, error) {
+	settings, err := ReadRealmSettings(ctx, realmID, projStore)
+	if err != nil {
+		return WIPUsage{}, err
+	}
+	claims, err := ReadActiveClaims(ctx, realmID, projStore)
+	if err != nil {
+		return WIPUsage{}, err
+	}
+	return claims.Usage(settings.WIPLimits), nil
+}
+
+// WIPLimitError reports the WIP limit a claim would exceed.
+type WIPLimitError struct {
+	Limit string
+	Key   string
+	Count int
+	Max   int
+}
+
+func (e *WIPLimitError) Error() string {
+	if e.Limit == WIPLimitRealm {
+		return fmt.Sprintf("WIP limit reached: realm has %d of %d claims", e.Count, e.Max)
+	}
+	return fmt.Sprintf("WIP limit reached: %s %q has %d of %d claims", e.Limit, e.Key, e.Count, e.Max)
+}
+
+// reserveWIPClaim checks a claim against the realm's WIP limits and reserves
+// it on the realm's WIP stream. The wip_claims projection may lag behind, so
+// reservations it has not applied yet are read from the stream, and a
+// concurrent reservation makes the append fail and the check run again. It
+// returns the reservation's stream version, or 0 if the realm has no limits.
+func reserveWIPClaim(ctx context.Context, realmID string, claim ActiveClaim, store core.EventStore, projStore core.ProjectionStore) (int, error) {
+	settings, err := ReadRealmSettings(ctx, realmID, projStore)
+	if err != nil {
+		return 0, err
+	}
+	limits := settings.WIPLimits
+	if limits.isUnlimited() {
+		return 0, nil
+	}
+
+	const maxRetries = 10
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		claims, err := readCurrentClaims(ctx, realmID, store, projStore)
+		if err != nil {
+			return 0, err
+		}
+		if err := checkWIPLimits(limits, claims.Usage(limits), claim); err != nil {
+			return 0, err
+		}
+		_, err = store.Append(ctx, realmID, wipStreamID, claims.Version, []core.EventData{
+			{EventType: EventWIPClaimReserved, Data: WIPClaimReserved(claim)},
+		})
+		if err == nil {
+			return claims.Version + 1, nil
+		}
+		var concErr *core.ConcurrencyError
+		if !errors.As(err, &concErr) {
+			return 0, err
+		}
+	}
+	return 0, fmt.Errorf("cannot claim rune %q: too many concurrent claims", claim.RuneID)
+}
+
+// releaseWIPClaim records that a reserved claim was never recorded on the
+// rune, so it stops counting against the realm's limits.
+func releaseWIPClaim(ctx context.Context, realmID string, claim ActiveClaim, version int, store core.EventStore) {
+	released := WIPClaimReleased{RuneID: claim.RuneID, Claimant: claim.Claimant, AccountID: claim.AccountID}
+	const maxRetries = 10
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		_, err := store.Append(ctx, realmID, wipStreamID, version, []core.EventData{
+			{EventType: EventWIPClaimReleased, Data: released},
+		})
+		if err == nil {
+			return
+		}
+		var concErr *core.ConcurrencyError
+		if !errors.As(err, &concErr) {
+			log.Printf("release WIP claim on rune %q: %v", claim.RuneID, err)
+			return
+		}
+		events, err := store.ReadStream(ctx, realmID, wipStreamID, version+1)
+		if err != nil {
+			log.Printf("release WIP claim on rune %q: %v", claim.RuneID, err)
+			return
+		}
+		for _, evt := range events {
+			version = max(version, evt.Version)
+		}
+	}
+	log.Printf("release WIP claim on rune %q: too many concurrent claims", claim.RuneID)
+}
+
+// readCurrentClaims loads the wip_claims projection and applies the WIP
+// stream events it has not caught up with yet.
+func readCurrentClaims(ctx context.Context, realmID string, store core.EventStore, projStore core.ProjectionStore) (ActiveClaims, error) {
+	claims, err := ReadActiveClaims(ctx, realmID, projStore)
+	if err != nil {
+		return ActiveClaims{}, err
+	}
+	events, err := store.ReadStream(ctx, realmID, wipStreamID, claims.Version+1)
+	if err != nil {
+		return ActiveClaims{}, err
+	}
+	for _, evt := range events {
+		if evt.Version <= claims.Version {
+			continue
+		}
+		if err := claims.Apply(evt); err != nil {
+			return ActiveClaims{}, err
+		}
+	}
+	return claims, nil
+}
+
+// Apply records a WIP stream event. A release only removes the claim it was
+// reserved for, not a later claim on the same rune.
+func (c *ActiveClaims) Apply(evt core.Event) error {
+	switch evt.EventType {
+	case EventWIPClaimReserved:
+		var data WIPClaimReserved
+		if err := json.Unmarshal(evt.Data, &data); err != nil {
+			return err
+		}
+		c.Claims[data.RuneID] = ActiveClaim(data)
+	case EventWIPClaimReleased:
+		var data WIPClaimReleased
+		if err := json.Unmarshal(evt.Data, &data); err != nil {
+			return err
+		}
+		if claim, ok := c.Claims[data.RuneID]; ok && claim.Claimant == data.Claimant && claim.AccountID == data.AccountID {
+			delete(c.Claims, data.RuneID)
+		}
+	default:
+		return nil
+	}
+	c.Version = evt.Version
+	return nil
+}
+
+// checkWIPLimits rejects a claim that would take any of the realm's WIP
+// counts past its limit. Limits are checked from the narrowest to the widest.
+func checkWIPLimits(limits WIPLimits, usage WIPUsage, claim ActiveClaim) error {
+	if limits.PerClaimant > 0 && usage.Claimants[claim.Claimant] >= limits.PerClaimant {
+		return &WIPLimitError{Limit: WIPLimitClaimant, Key: claim.Claimant, Count: usage.Claimants[claim.Claimant], Max: limits.PerClaimant}
+	}
+	if limits.PerAccount > 0 && claim.AccountID != "" && usage.Accounts[claim.AccountID] >= limits.PerAccount {
+		return &WIPLimitError{Limit: WIPLimitAccount, Key: claim.AccountID, Count: usage.Accounts[claim.AccountID], Max: limits.PerAccount}
+	}
+	for _, tag := range claim.Tags {
+		if limit := limits.PerTag[tag]; limit > 0 && usage.Tags[tag] >= limit {
+			return &WIPLimitError{Limit: WIPLimitTag, Key: tag, Count: usage.Tags[tag], Max: limit}
+		}
+	}
+	if limits.PerRealm > 0 && usage.Realm >= limits.PerRealm {
+		return &WIPLimitError{Limit: WIPLimitRealm, Count: usage.Realm, Max: limits.PerRealm}
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestHandleClaimRune_WIPLimits(t *testing.T) {
+	t.Run("claims when the claimant is under its limit", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_wip_limits(WIPLimits{PerClaimant: 2})
+		tc.active_claims(ActiveClaim{RuneID: "bf-0001", Claimant: "odin"})
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.no_error()
+		tc.appended_event_has_type(EventRuneClaimed)
+	})
+
+	t.Run("rejects when the claimant is at its limit", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_wip_limits(WIPLimits{PerClaimant: 1})
+		tc.active_claims(ActiveClaim{RuneID: "bf-0001", Claimant: "odin"})
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.wip_limit_error(WIPLimitClaimant, "odin")
+		tc.error_contains(`WIP limit reached: claimant "odin" has 1 of 1 claims`)
+		tc.no_events_were_appended()
+	})
+
+	t.Run("rejects when the account is at its limit under another claimant name", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_wip_limits(WIPLimits{PerAccount: 1})
+		tc.active_claims(ActiveClaim{RuneID: "bf-0001", Claimant: "agent-1", AccountID: "acct-1"})
+		tc.claimCmd = ClaimRune{ID: "bf-a1b2", Claimant: "agent-2", AccountID: "acct-1"}
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.wip_limit_error(WIPLimitAccount, "acct-1")
+	})
+
+	t.Run("rejects when a tag on the rune is at its limit", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_tagged_rune_in_stream("bf-a1b2", "deploy")
+		tc.realm_wip_limits(WIPLimits{PerTag: map[string]int{"deploy": 1}})
+		tc.active_claims(ActiveClaim{RuneID: "bf-0001", Claimant: "thor", Tags: []string{"deploy"}})
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.wip_limit_error(WIPLimitTag, "deploy")
+	})
+
+	t.Run("ignores tag limits for tags the rune does not carry", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_wip_limits(WIPLimits{PerTag: map[string]int{"deploy": 1}})
+		tc.active_claims(ActiveClaim{RuneID: "bf-0001", Claimant: "thor", Tags: []string{"deploy"}})
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.no_error()
+	})
+
+	t.Run("rejects when the realm is at its limit", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_wip_limits(WIPLimits{PerRealm: 2})
+		tc.active_claims(ActiveClaim{RuneID: "bf-0001", Claimant: "thor"}, ActiveClaim{RuneID: "bf-0002", Claimant: "loki"})
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.wip_limit_error(WIPLimitRealm, "")
+		tc.error_contains("WIP limit reached: realm has 2 of 2 claims")
+	})
+
+	t.Run("reserves the claim on the realm's WIP stream before claiming the rune", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_tagged_rune_in_stream("bf-a1b2", "deploy")
+		tc.realm_wip_limits(WIPLimits{PerClaimant: 2})
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.no_error()
+		tc.wip_claim_was_reserved(0, WIPClaimReserved{RuneID: "bf-a1b2", Claimant: "odin", Tags: []string{"deploy"}})
+		tc.appended_event_has_type(EventRuneClaimed)
+	})
+
+	t.Run("counts reservations the projection has not applied yet", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_wip_limits(WIPLimits{PerClaimant: 1})
+		tc.active_claims()
+		tc.wip_stream_events(makeEvent(EventWIPClaimReserved, WIPClaimReserved{RuneID: "bf-0001", Claimant: "odin"}))
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.wip_limit_error(WIPLimitClaimant, "odin")
+		tc.no_events_were_appended()
+	})
+
+	t.Run("does not count a reservation that was released", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_wip_limits(WIPLimits{PerClaimant: 1})
+		tc.active_claims()
+		tc.wip_stream_events(
+			makeEvent(EventWIPClaimReserved, WIPClaimReserved{RuneID: "bf-0001", Claimant: "odin"}),
+			makeEvent(EventWIPClaimReleased, WIPClaimReleased{RuneID: "bf-0001", Claimant: "odin"}),
+		)
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.no_error()
+		tc.wip_claim_was_reserved(2, WIPClaimReserved{RuneID: "bf-a1b2", Claimant: "odin"})
+	})
+
+	t.Run("releases the reservation when the claim cannot be recorded", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.realm_wip_limits(WIPLimits{PerClaimant: 1})
+		tc.active_claims()
+		tc.append_to_stream_fails("rune-bf-a1b2", &core.ConcurrencyError{StreamID: "rune-bf-a1b2", ExpectedVersion: 2, ActualVersion: 3})
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		require.Error(t, tc.err)
+		tc.wip_claim_was_released(1, WIPClaimReleased{RuneID: "bf-a1b2", Claimant: "odin"})
+	})
+
+	t.Run("does not reserve when the realm has no limits", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.a_realm("realm-1")
+		tc.existing_rune_in_stream("bf-a1b2", "open")
+		tc.a_claim_rune_command("bf-a1b2", "odin")
+
+		// When
+		tc.handle_claim_rune()
+
+		// Then
+		tc.no_error()
+		tc.nothing_was_appended_to_stream(wipStreamID)
+	})
+}
+
+func TestReadWIPUsage(t *testing.T) {
+	t.Run("counts active claims by claimant, account and tag", func(t *testing.T) {
+		store := newMockProjectionStore()
+		settings := DefaultRealmSettings("realm-1")
+		settings.WIPLimits = WIPLimits{PerClaimant: 3}
+		store.data["_admin:realm_settings:realm-1"] = settings
+		store.data["realm-1:wip_claims:claims"] = ActiveClaims{Claims: map[string]ActiveClaim{
+			"bf-1": {RuneID: "bf-1", Claimant: "odin", AccountID: "acct-1", Tags: []string{"deploy"}},
+			"bf-2": {RuneID: "bf-2", Claimant: "odin", AccountID: "acct-1"},
+			"bf-3": {RuneID: "bf-3", Claimant: "thor", Tags: []string{"deploy", "ops"}},
+		}}
+
+		usage, err := ReadWIPUsage(context.Background(), "realm-1", store)
+
+		require.NoError(t, err)
+		assert.Equal(t, WIPUsage{
+			Limits:    WIPLimits{PerClaimant: 3},
+			Realm:     3,
+			Claimants: map[string]int{"odin": 2, "thor": 1},
+			Accounts:  map[string]int{"acct-1": 2},
+			Tags:      map[string]int{"deploy": 2, "ops": 1},
+		}, usage)
+	})
+
+	t.Run("reports zero usage when nothing is claimed", func(t *testing.T) {
+		usage, err := ReadWIPUsage(context.Background(), "realm-1", newMockProjectionStore())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, usage.Realm)
+		assert.Empty(t, usage.Claimants)
+	})
+}
+
+func TestHandleUpdateRealmSettings_WIPLimits(t *testing.T) {
+	t.Run("rejects negative limits", func(t *testing.T) {
+		tc := newRealmHandlerTestContext(t)
+
+		// Given
+		tc.existing_realm_in_stream("bf-a1b2", "active")
+		tc.updateRealmSettingsCmd = UpdateRealmSettings{RealmID: "bf-a1b2", WIPLimits: &WIPLimits{PerTag: map[string]int{"deploy": -1}}}
+
+		// When
+		tc.handle_update_realm_settings()
+
+		// Then
+		tc.realm_error_contains("invalid wip_limits")
+	})
+}
+
+// --- Given ---
+
+func (tc *handlerTestContext) realm_wip_limits(limits WIPLimits) {
+	tc.t.Helper()
+	tc.realm_settings(func(s *RealmSettings) {
+		s.WIPLimits = limits
+	})
+}
+
+func (tc *handlerTestContext) active_claims(claims ...ActiveClaim) {
+	tc.t.Helper()
+	tc.a_store()
+	doc := ActiveClaims{Claims: map[string]ActiveClaim{}}
+	for _, claim := range claims {
+		doc.Claims[claim.RuneID] = claim
+	}
+	tc.projectionStore.data[tc.realmID+":wip_claims:claims"] = doc
+}
+
+func (tc *handlerTestContext) existing_tagged_rune_in_stream(runeID string, tags ...string) {
+	tc.t.Helper()
+	tc.an_event_store()
+	tc.eventStore.streams["rune-"+runeID] = []core.Event{
+		makeEvent(EventRuneCreated, RuneCreated{ID: runeID, Title: "Existing rune", Priority: 1, Tags: tags}),
+		makeEvent(EventRuneForged, RuneForged{ID: runeID}),
+	}
+}
+
+func (tc *handlerTestContext) wip_stream_events(events ...core.Event) {
+	tc.t.Helper()
+	tc.an_event_store()
+	for i := range events {
+		events[i].Version = i + 1
+	}
+	tc.eventStore.streams[wipStreamID] = events
+}
+
+// --- Then ---
+
+func (tc *handlerTestContext) wip_claim_was_reserved(expectedVersion int, expected WIPClaimReserved) {
+	tc.t.Helper()
+	tc.wip_stream_event_was_appended(expectedVersion, EventWIPClaimReserved, expected)
+}
+
+func (tc *handlerTestContext) wip_claim_was_released(expectedVersion int, expected WIPClaimReleased) {
+	tc.t.Helper()
+	tc.wip_stream_event_was_appended(expectedVersion, EventWIPClaimReleased, expected)
+}
+
+func (tc *handlerTestContext) wip_stream_event_was_appended(expectedVersion int, eventType string, expected any) {
+	tc.t.Helper()
+	for _, call := range tc.eventStore.appendedCalls {
+		if call.streamID != wipStreamID || call.events[0].EventType != eventType {
+			continue
+		}
+		assert.Equal(tc.t, expectedVersion, call.expectedVersion)
+		assert.Equal(tc.t, expected, call.events[0].Data)
+		return
+	}
+	tc.t.Errorf("expected %s appended to %q, got calls: %v", eventType, wipStreamID, tc.eventStore.appendedCalls)
+}
+
+func (tc *handlerTestContext) nothing_was_appended_to_stream(streamID string) {
+	tc.t.Helper()
+	for _, call := range tc.eventStore.appendedCalls {
+		assert.NotEqual(tc.t, streamID, call.streamID)
+	}
+}
+
+func (tc *handlerTestContext) wip_limit_error(limit, key string) {
+	tc.t.Helper()
+	require.Error(tc.t, tc.err)
+	var wipErr *WIPLimitError
+	require.True(tc.t, errors.As(tc.err, &wipErr), "expected WIPLimitError, got %T: %v", tc.err, tc.err)
+	assert.Equal(tc.t, limit, wipErr.Limit)
+	assert.Equal(tc.t, key, wipErr.Key)
+}
@@ -44,4 +44,10 @@ func (s *CheckpointStore) SetCheckpoint(ctx context.Context, realmID string, pro
 		realmID, projectorName, globalPosition,
 	)
 	return err
-}
\ No newline at end of file
+}
+
+// PurgeRealm deletes every projector checkpoint for the realm.
+func (s *CheckpointStore) PurgeRealm(ctx context.Context, realmID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM checkpoints WHERE realm_id = $1`, realmID)
+	return err
+}
@@ -14,6 +14,7 @@ import (
 
 // Compile-time interface satisfaction check
 var _ core.CheckpointStore = (*CheckpointStore)(nil)
+var _ core.RealmPurger = (*CheckpointStore)(nil)
 
 func TestNewCheckpointStore(t *testing.T) {
 	t.Skip("Skipping PostgreSQL tests - requires database connection")
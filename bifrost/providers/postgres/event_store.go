@@ -149,6 +149,17 @@ func (s *EventStore) ReadAll(ctx context.Context, realmID string, fromGlobalPosi
 	return scanEvents(rows)
 }
 
+// LatestPosition returns the global position of the realm's most recent
+// event, or 0 when it has none.
+func (s *EventStore) LatestPosition(ctx context.Context, realmID string) (int64, error) {
+	var position int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(global_position), 0) FROM events WHERE realm_id = $1`,
+		realmID,
+	).Scan(&position)
+	return position, err
+}
+
 // ListRealmIDs returns all distinct realm IDs from the events table.
 func (s *EventStore) ListRealmIDs(ctx context.Context) ([]string, error) {
 	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT realm_id FROM events`)
@@ -171,6 +182,12 @@ func (s *EventStore) ListRealmIDs(ctx context.Context) ([]string, error) {
 	return realmIDs, nil
 }
 
+// PurgeRealm permanently deletes every event in the realm.
+func (s *EventStore) PurgeRealm(ctx context.Context, realmID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM events WHERE realm_id = $1`, realmID)
+	return err
+}
+
 func scanEvents(rows *sql.Rows) ([]core.Event, error) {
 	events := make([]core.Event, 0)
 	for rows.Next() {
@@ -14,6 +14,8 @@ import (
 
 // Compile-time interface satisfaction check
 var _ core.EventStore = (*EventStore)(nil)
+var _ core.RealmPurger = (*EventStore)(nil)
+var _ core.PositionReader = (*EventStore)(nil)
 
 // --- Tests ---
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"sync"
 
 	"github.com/devzeebo/bifrost/core"
 )
@@ -11,6 +12,11 @@ import (
 // ProjectionStore is a PostgreSQL-backed implementation of core.ProjectionStore.
 type ProjectionStore struct {
 	db *sql.DB
+
+	// searchFields holds the indexed fields of each table EnableSearch was
+	// called for.
+	searchMu     sync.RWMutex
+	searchFields map[string][]string
 }
 
 // NewProjectionStore creates a new ProjectionStore backed by the given database.
@@ -110,4 +116,38 @@ func (s *ProjectionStore) Delete(ctx context.Context, realmID string, table stri
 func (s *ProjectionStore) ClearTable(ctx context.Context, table string) error {
 	_, err := s.db.ExecContext(ctx, `DELETE FROM projection_` + table)
 	return err
-}
\ No newline at end of file
+}
+
+// PurgeRealm deletes the realm's entries from every projection table.
+func (s *ProjectionStore) PurgeRealm(ctx context.Context, realmID string) error {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT table_name FROM information_schema.tables
+		 WHERE table_schema = current_schema() AND table_name LIKE 'projection\_%'`,
+	)
+	if err != nil {
+		return err
+	}
+	tables, err := scanTableNames(rows)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM `+table+` WHERE realm_id = $1`, realmID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanTableNames(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
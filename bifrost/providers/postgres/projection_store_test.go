@@ -14,6 +14,7 @@ import (
 
 // Compile-time interface satisfaction check
 var _ core.ProjectionStore = (*ProjectionStore)(nil)
+var _ core.RealmPurger = (*ProjectionStore)(nil)
 
 func TestNewProjectionStore(t *testing.T) {
 	t.Skip("Skipping PostgreSQL tests - requires database connection")
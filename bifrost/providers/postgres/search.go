@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// EnableSearch indexes the given fields of the table's documents in a
+// generated tsvector column, search_vector, with a GIN index. Postgres keeps
+// the column current on every write. The indexed fields are recorded in
+// search_index_fields, and the column is only recreated when they change.
+func (s *ProjectionStore) EnableSearch(ctx context.Context, table string, fields ...string) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("search on %s needs at least one field", table)
+	}
+	if err := s.ensureTable(ctx, table); err != nil {
+		return err
+	}
+
+	source := "projection_" + table
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	indexed, err := indexedFields(ctx, tx, table)
+	if err != nil {
+		return fmt.Errorf("enable search on %s: %w", table, err)
+	}
+	rebuild := indexed != strings.Join(fields, ",")
+
+	var statements []string
+	if rebuild {
+		statements = append(statements, `ALTER TABLE `+source+` DROP COLUMN IF EXISTS search_vector`)
+	}
+	statements = append(statements,
+		`ALTER TABLE `+source+` ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (`+searchVector(fields)+`) STORED`,
+		`CREATE INDEX IF NOT EXISTS `+source+`_search_idx ON `+source+` USING GIN (search_vector)`,
+	)
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("enable search on %s: %w", table, err)
+		}
+	}
+	if rebuild {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO search_index_fields (table_name, fields) VALUES ($1, $2)
+			 ON CONFLICT (table_name) DO UPDATE SET fields = excluded.fields`,
+			table, strings.Join(fields, ","),
+		)
+		if err != nil {
+			return fmt.Errorf("enable search on %s: %w", table, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.searchMu.Lock()
+	defer s.searchMu.Unlock()
+	if s.searchFields == nil {
+		s.searchFields = make(map[string][]string)
+	}
+	s.searchFields[table] = fields
+	return nil
+}
+
+// indexedFields returns the comma-separated fields the table's search column
+// was built on, or "" when it has none.
+func indexedFields(ctx context.Context, tx *sql.Tx, table string) (string, error) {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS search_index_fields (
+		table_name TEXT PRIMARY KEY,
+		fields TEXT NOT NULL
+	)`)
+	if err != nil {
+		return "", err
+	}
+	var fields string
+	err = tx.QueryRowContext(ctx, `SELECT fields FROM search_index_fields WHERE table_name = $1`, table).Scan(&fields)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return fields, err
+}
+
+// Search returns the realm's documents in the table that match query, best
+// first. The query is read by websearch_to_tsquery: words, "quoted phrases",
+// OR and -excluded words.
+func (s *ProjectionStore) Search(ctx context.Context, realmID string, table string, query string, limit int) ([]core.SearchHit, error) {
+	s.searchMu.RLock()
+	fields, ok := s.searchFields[table]
+	s.searchMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("search is not enabled on %s", table)
+	}
+	var limitArg any
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	text := make([]string, len(fields))
+	for i, field := range fields {
+		text[i] = fmt.Sprintf("value::jsonb->>'%s'", field)
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT key, ts_rank(search_vector, query) AS rank,
+			ts_headline('english', concat_ws(' … ', `+strings.Join(text, ", ")+`), query,
+				'StartSel="**", StopSel="**", MaxWords=24, MinWords=8, MaxFragments=1')
+		 FROM projection_`+table+`, websearch_to_tsquery('english', $2) AS query
+		 WHERE realm_id = $1 AND search_vector @@ query
+		 ORDER BY rank DESC, key
+		 LIMIT $3`,
+		realmID, query, limitArg,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := make([]core.SearchHit, 0)
+	for rows.Next() {
+		var hit core.SearchHit
+		if err := rows.Scan(&hit.Key, &hit.Rank, &hit.Snippet); err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+// searchVector is the expression of the search_vector column. The first
+// field is weighted A, the second B and the rest C.
+func searchVector(fields []string) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		weight := "C"
+		switch i {
+		case 0:
+			weight = "A"
+		case 1:
+			weight = "B"
+		}
+		parts[i] = fmt.Sprintf("setweight(to_tsvector('english', coalesce(value::jsonb->>'%s', '')), '%s')", field, weight)
+	}
+	return strings.Join(parts, " || ")
+}
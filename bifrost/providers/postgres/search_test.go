@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile-time interface satisfaction check
+var _ core.TextSearcher = (*ProjectionStore)(nil)
+
+func TestProjectionStore_Search(t *testing.T) {
+	t.Run("finds documents by any indexed field, best match first", func(t *testing.T) {
+		tc := newProjectionStoreTestContext(t)
+
+		// Given
+		tc.a_database_with_schema()
+		tc.new_projection_store_is_created()
+		require.NoError(t, tc.store.EnableSearch(context.Background(), "docs", "title", "body"))
+		tc.put_is_called("realm-1", "docs", "bf-1", json.RawMessage(`{"title":"Fix login redirect","body":""}`))
+		tc.put_is_called("realm-1", "docs", "bf-2", json.RawMessage(`{"title":"Update docs","body":"mention the login redirect"}`))
+		tc.put_is_called("realm-2", "docs", "bf-3", json.RawMessage(`{"title":"Fix login redirect","body":""}`))
+
+		// When
+		hits, err := tc.store.Search(context.Background(), "realm-1", "docs", "login redirects", 10)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, hits, 2)
+		assert.Equal(t, "bf-1", hits[0].Key)
+		assert.Equal(t, "bf-2", hits[1].Key)
+		assert.Contains(t, hits[0].Snippet, "**login**")
+	})
+}
+
+func TestSearchVector(t *testing.T) {
+	t.Run("weights fields by position", func(t *testing.T) {
+		// When
+		expr := searchVector([]string{"title", "description", "notes"})
+
+		// Then
+		assert.Equal(t,
+			"setweight(to_tsvector('english', coalesce(value::jsonb->>'title', '')), 'A') || "+
+				"setweight(to_tsvector('english', coalesce(value::jsonb->>'description', '')), 'B') || "+
+				"setweight(to_tsvector('english', coalesce(value::jsonb->>'notes', '')), 'C')",
+			expr)
+	})
+}
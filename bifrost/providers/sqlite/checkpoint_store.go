@@ -44,3 +44,9 @@ func (s *CheckpointStore) SetCheckpoint(ctx context.Context, realmID string, pro
 	)
 	return err
 }
+
+// PurgeRealm deletes every projector checkpoint for the realm.
+func (s *CheckpointStore) PurgeRealm(ctx context.Context, realmID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM checkpoints WHERE realm_id = ?`, realmID)
+	return err
+}
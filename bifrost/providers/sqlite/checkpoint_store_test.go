@@ -13,6 +13,7 @@ import (
 
 // Compile-time interface satisfaction check
 var _ core.CheckpointStore = (*CheckpointStore)(nil)
+var _ core.RealmPurger = (*CheckpointStore)(nil)
 
 // --- Tests ---
 
@@ -117,6 +118,28 @@ func TestCheckpointStore_SetCheckpoint(t *testing.T) {
 	})
 }
 
+func TestCheckpointStore_PurgeRealm(t *testing.T) {
+	t.Run("resets only the realm's checkpoints", func(t *testing.T) {
+		tc := newCheckpointTestContext(t)
+
+		// Given
+		tc.a_database_with_schema()
+		tc.new_checkpoint_store_is_created()
+		tc.set_checkpoint_is_called("realm-1", "projector-1", 10)
+		tc.set_checkpoint_is_called("realm-2", "projector-1", 20)
+
+		// When
+		tc.purge_realm_is_called("realm-1")
+
+		// Then
+		tc.no_error_occurred()
+		tc.get_checkpoint_is_called("realm-1", "projector-1")
+		tc.checkpoint_position_is(0)
+		tc.get_checkpoint_is_called("realm-2", "projector-1")
+		tc.checkpoint_position_is(20)
+	})
+}
+
 // --- Test Context ---
 
 type checkpointTestContext struct {
@@ -162,6 +185,11 @@ func (tc *checkpointTestContext) set_checkpoint_is_called(realmID, projectorName
 	require.NoError(tc.t, tc.err)
 }
 
+func (tc *checkpointTestContext) purge_realm_is_called(realmID string) {
+	tc.t.Helper()
+	tc.err = tc.store.PurgeRealm(context.Background(), realmID)
+}
+
 // --- Then ---
 
 func (tc *checkpointTestContext) no_error_occurred() {
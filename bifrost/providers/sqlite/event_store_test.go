@@ -16,6 +16,8 @@ import (
 
 // Compile-time interface satisfaction check
 var _ core.EventStore = (*EventStore)(nil)
+var _ core.RealmPurger = (*EventStore)(nil)
+var _ core.PositionReader = (*EventStore)(nil)
 
 // --- Tests ---
 
@@ -262,6 +264,62 @@ func TestEventStore_ReadAll(t *testing.T) {
 	})
 }
 
+func TestEventStore_LatestPosition(t *testing.T) {
+	t.Run("returns the realm's highest global position", func(t *testing.T) {
+		tc := newEventStoreTestContext(t)
+
+		// Given
+		tc.a_database_with_schema()
+		tc.new_event_store_is_created()
+		tc.stream_has_events("realm-1", "stream-1", 2)
+		tc.stream_has_events("realm-2", "stream-1", 3)
+
+		// When
+		tc.latest_position_is_called("realm-1")
+
+		// Then
+		tc.no_error_occurred()
+		tc.latest_position_is(2)
+	})
+
+	t.Run("returns 0 for a realm without events", func(t *testing.T) {
+		tc := newEventStoreTestContext(t)
+
+		// Given
+		tc.a_database_with_schema()
+		tc.new_event_store_is_created()
+
+		// When
+		tc.latest_position_is_called("realm-1")
+
+		// Then
+		tc.no_error_occurred()
+		tc.latest_position_is(0)
+	})
+}
+
+func TestEventStore_PurgeRealm(t *testing.T) {
+	t.Run("deletes only the realm's events", func(t *testing.T) {
+		tc := newEventStoreTestContext(t)
+
+		// Given
+		tc.a_database_with_schema()
+		tc.new_event_store_is_created()
+		tc.stream_has_events("realm-1", "stream-1", 2)
+		tc.stream_has_events("realm-2", "stream-1", 3)
+
+		// When
+		tc.purge_realm_is_called("realm-1")
+
+		// Then
+		tc.no_error_occurred()
+		tc.read_all_is_called("realm-1", 0)
+		tc.read_events_count_is(0)
+		tc.read_all_is_called("realm-2", 0)
+		tc.read_events_count_is(3)
+	})
+}
+
 func TestEventStore_Concurrency(t *testing.T) {
 	t.Run("concurrent appends to same stream: one succeeds, one gets ConcurrencyError", func(t *testing.T) {
 		tc := newEventStoreTestContext(t)
@@ -323,6 +381,7 @@ type eventStoreTestContext struct {
 	store          *EventStore
 	appendedEvents []core.Event
 	readEvents     []core.Event
+	latestPosition int64
 	err            error
 	concurrentErrs []error
 }
@@ -405,6 +464,16 @@ func (tc *eventStoreTestContext) read_all_is_called(realmID string, fromGlobalPo
 	tc.readEvents, tc.err = tc.store.ReadAll(context.Background(), realmID, fromGlobalPosition)
 }
 
+func (tc *eventStoreTestContext) latest_position_is_called(realmID string) {
+	tc.t.Helper()
+	tc.latestPosition, tc.err = tc.store.LatestPosition(context.Background(), realmID)
+}
+
+func (tc *eventStoreTestContext) purge_realm_is_called(realmID string) {
+	tc.t.Helper()
+	tc.err = tc.store.PurgeRealm(context.Background(), realmID)
+}
+
 func (tc *eventStoreTestContext) two_concurrent_appends_to_same_stream(realmID, streamID string) {
 	tc.t.Helper()
 	var wg sync.WaitGroup
@@ -501,6 +570,11 @@ func (tc *eventStoreTestContext) concurrency_error_is_returned(streamID string,
 	assert.Equal(tc.t, actualVersion, concErr.ActualVersion)
 }
 
+func (tc *eventStoreTestContext) latest_position_is(expected int64) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.latestPosition)
+}
+
 func (tc *eventStoreTestContext) read_events_count_is(expected int) {
 	tc.t.Helper()
 	assert.Len(tc.t, tc.readEvents, expected)
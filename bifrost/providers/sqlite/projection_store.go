@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"strings"
+	"sync"
 
 	"github.com/devzeebo/bifrost/core"
 )
@@ -12,6 +13,11 @@ import (
 // ProjectionStore is a SQLite-backed implementation of core.ProjectionStore.
 type ProjectionStore struct {
 	db *sql.DB
+
+	// searchFields holds the indexed fields of each table EnableSearch was
+	// called for.
+	searchMu     sync.RWMutex
+	searchFields map[string][]string
 }
 
 // NewProjectionStore creates a new ProjectionStore backed by the given database.
@@ -100,7 +106,8 @@ func (s *ProjectionStore) Put(ctx context.Context, realmID string, table string,
 		return err
 	}
 	_, err = s.db.ExecContext(ctx,
-		`INSERT OR REPLACE INTO projection_`+table+` (realm_id, key, value) VALUES (?, ?, ?)`,
+		`INSERT INTO projection_`+table+` (realm_id, key, value) VALUES (?, ?, ?)
+		 ON CONFLICT (realm_id, key) DO UPDATE SET value = excluded.value`,
 		realmID, key, string(data),
 	)
 	return err
@@ -129,6 +136,39 @@ func (s *ProjectionStore) ClearTable(ctx context.Context, table string) error {
 	return err
 }
 
+// PurgeRealm deletes the realm's entries from every projection table.
+func (s *ProjectionStore) PurgeRealm(ctx context.Context, realmID string) error {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name LIKE 'projection\_%' ESCAPE '\'`,
+	)
+	if err != nil {
+		return err
+	}
+	tables, err := scanTableNames(rows)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM `+table+` WHERE realm_id = ?`, realmID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanTableNames(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
 // isTableNotExistError checks if the error indicates the table doesn't exist.
 func isTableNotExistError(err error) bool {
 	if err == nil {
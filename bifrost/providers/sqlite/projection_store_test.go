@@ -15,6 +15,7 @@ import (
 
 // Compile-time interface satisfaction check
 var _ core.ProjectionStore = (*ProjectionStore)(nil)
+var _ core.RealmPurger = (*ProjectionStore)(nil)
 
 // --- Tests ---
 
@@ -237,6 +238,31 @@ func TestProjectionStore_Delete(t *testing.T) {
 	})
 }
 
+func TestProjectionStore_PurgeRealm(t *testing.T) {
+	t.Run("deletes the realm's entries from every table", func(t *testing.T) {
+		tc := newProjectionTestContext(t)
+
+		// Given
+		tc.a_database_with_schema()
+		tc.new_projection_store_is_created()
+		tc.projection_has_entries("realm-1", "rune_summary", map[string]string{"rune-1": `{"id":"rune-1"}`})
+		tc.projection_has_entries("realm-1", "rune_detail", map[string]string{"rune-1": `{"id":"rune-1"}`})
+		tc.projection_has_entries("realm-2", "rune_summary", map[string]string{"rune-1": `{"id":"rune-1"}`})
+
+		// When
+		tc.purge_realm_is_called("realm-1")
+
+		// Then
+		tc.no_error_occurred()
+		tc.list_is_called("realm-1", "rune_summary")
+		tc.list_has_n_entries(0)
+		tc.list_is_called("realm-1", "rune_detail")
+		tc.list_has_n_entries(0)
+		tc.list_is_called("realm-2", "rune_summary")
+		tc.list_has_n_entries(1)
+	})
+}
+
 func TestProjectionStore_Put_StoresValueAsText(t *testing.T) {
 	t.Run("stores value as text not blob", func(t *testing.T) {
 		tc := newProjectionTestContext(t)
@@ -371,6 +397,11 @@ func (tc *projectionTestContext) list_is_called(realmID, table string) {
 	tc.listResult, tc.err = tc.store.List(context.Background(), realmID, table)
 }
 
+func (tc *projectionTestContext) purge_realm_is_called(realmID string) {
+	tc.t.Helper()
+	tc.err = tc.store.PurgeRealm(context.Background(), realmID)
+}
+
 // --- Then ---
 
 func (tc *projectionTestContext) no_error_occurred() {
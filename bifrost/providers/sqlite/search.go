@@ -0,0 +1,248 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/devzeebo/bifrost/core"
+)
+
+// EnableSearch indexes the given fields of the table's documents in an FTS5
+// table, search_<table>, whose rows share the projection rows' rowids.
+// Triggers keep it in step with Put, Delete and ClearTable. The indexed
+// fields are recorded in search_index_fields, and the index is only rebuilt
+// when they change.
+func (s *ProjectionStore) EnableSearch(ctx context.Context, table string, fields ...string) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("search on %s needs at least one field", table)
+	}
+	if err := s.ensureTable(ctx, table); err != nil {
+		return err
+	}
+
+	source := "projection_" + table
+	index := "search_" + table
+	columns := "realm_id, key, " + strings.Join(fields, ", ")
+	values := func(row string) string {
+		extracts := make([]string, len(fields))
+		for i, field := range fields {
+			extracts[i] = fmt.Sprintf("json_extract(%s.value, '$.%s')", row, field)
+		}
+		return row + ".realm_id, " + row + ".key, " + strings.Join(extracts, ", ")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	indexed, err := indexedFields(ctx, tx, table)
+	if err != nil {
+		return fmt.Errorf("enable search on %s: %w", table, err)
+	}
+	rebuild := indexed != strings.Join(fields, ",")
+
+	var statements []string
+	if rebuild {
+		statements = append(statements,
+			`DROP TRIGGER IF EXISTS `+index+`_insert`,
+			`DROP TRIGGER IF EXISTS `+index+`_update`,
+			`DROP TRIGGER IF EXISTS `+index+`_delete`,
+			`DROP TABLE IF EXISTS `+index,
+		)
+	}
+	statements = append(statements,
+		`CREATE VIRTUAL TABLE IF NOT EXISTS `+index+` USING fts5(realm_id UNINDEXED, key UNINDEXED, `+
+			strings.Join(fields, ", ")+`, tokenize = 'porter unicode61')`,
+	)
+	if rebuild {
+		statements = append(statements,
+			`INSERT INTO `+index+` (rowid, `+columns+`) SELECT `+source+`.rowid, `+values(source)+` FROM `+source,
+		)
+	}
+	statements = append(statements,
+		`CREATE TRIGGER IF NOT EXISTS `+index+`_insert AFTER INSERT ON `+source+` BEGIN
+			INSERT INTO `+index+` (rowid, `+columns+`) VALUES (new.rowid, `+values("new")+`);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS `+index+`_update AFTER UPDATE ON `+source+` BEGIN
+			DELETE FROM `+index+` WHERE rowid = old.rowid;
+			INSERT INTO `+index+` (rowid, `+columns+`) VALUES (new.rowid, `+values("new")+`);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS `+index+`_delete AFTER DELETE ON `+source+` BEGIN
+			DELETE FROM `+index+` WHERE rowid = old.rowid;
+		END`,
+	)
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("enable search on %s: %w", table, err)
+		}
+	}
+	if rebuild {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO search_index_fields (table_name, fields) VALUES (?, ?)
+			 ON CONFLICT (table_name) DO UPDATE SET fields = excluded.fields`,
+			table, strings.Join(fields, ","),
+		)
+		if err != nil {
+			return fmt.Errorf("enable search on %s: %w", table, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.searchMu.Lock()
+	defer s.searchMu.Unlock()
+	if s.searchFields == nil {
+		s.searchFields = make(map[string][]string)
+	}
+	s.searchFields[table] = fields
+	return nil
+}
+
+// indexedFields returns the comma-separated fields the table's search index
+// was built on, or "" when it has none.
+func indexedFields(ctx context.Context, tx *sql.Tx, table string) (string, error) {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS search_index_fields (
+		table_name TEXT PRIMARY KEY,
+		fields TEXT NOT NULL
+	)`)
+	if err != nil {
+		return "", err
+	}
+	var fields string
+	err = tx.QueryRowContext(ctx, `SELECT fields FROM search_index_fields WHERE table_name = ?`, table).Scan(&fields)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return fields, err
+}
+
+// Search returns the realm's documents in the table that match query, best
+// first. The query is read like a web search: words, "quoted phrases", OR
+// and -excluded words.
+func (s *ProjectionStore) Search(ctx context.Context, realmID string, table string, query string, limit int) ([]core.SearchHit, error) {
+	s.searchMu.RLock()
+	fields, ok := s.searchFields[table]
+	s.searchMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("search is not enabled on %s", table)
+	}
+	match := ftsQuery(query)
+	if match == "" {
+		return []core.SearchHit{}, nil
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+
+	// Weight fields like Postgres's A, B and C ranks: the first counts five
+	// times as much as the rest, the second twice as much. The unindexed
+	// realm_id and key columns take the first two weights.
+	weights := []string{"0", "0"}
+	for i := range fields {
+		switch i {
+		case 0:
+			weights = append(weights, "10")
+		case 1:
+			weights = append(weights, "4")
+		default:
+			weights = append(weights, "2")
+		}
+	}
+
+	index := "search_" + table
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT key, -bm25(`+index+`, `+strings.Join(weights, ", ")+`) AS rank,
+			snippet(`+index+`, -1, '**', '**', '…', 16)
+		 FROM `+index+`
+		 WHERE `+index+` MATCH ? AND realm_id = ?
+		 ORDER BY rank DESC, key
+		 LIMIT ?`,
+		match, realmID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := make([]core.SearchHit, 0)
+	for rows.Next() {
+		var hit core.SearchHit
+		if err := rows.Scan(&hit.Key, &hit.Rank, &hit.Snippet); err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+// ftsQuery translates a web-style search into an FTS5 query. Every term is
+// quoted, so punctuation in the search can never be a syntax error. It
+// returns "" when no term is left to match.
+func ftsQuery(query string) string {
+	var groups [][]string
+	var excluded []string
+	or := false
+
+	for rest := strings.TrimSpace(query); rest != ""; rest = strings.TrimSpace(rest) {
+		negate := strings.HasPrefix(rest, "-")
+		if negate {
+			rest = rest[1:]
+		}
+		var term string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				term, rest = rest[1:], ""
+			} else {
+				term, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			term, rest = rest[:end], rest[end:]
+			if !negate && strings.EqualFold(term, "or") && len(groups) > 0 {
+				or = true
+				continue
+			}
+		}
+		if !strings.ContainsFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			continue
+		}
+
+		quoted := `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		switch {
+		case negate:
+			excluded = append(excluded, quoted)
+		case or:
+			groups[len(groups)-1] = append(groups[len(groups)-1], quoted)
+		default:
+			groups = append(groups, []string{quoted})
+		}
+		or = false
+	}
+	if len(groups) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		parts[i] = strings.Join(group, " OR ")
+		if len(group) > 1 {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	match := strings.Join(parts, " AND ")
+	for _, term := range excluded {
+		match = "(" + match + ") NOT " + term
+	}
+	return match
+}
@@ -0,0 +1,259 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile-time interface satisfaction check
+var _ core.TextSearcher = (*ProjectionStore)(nil)
+
+// --- Tests ---
+
+func TestProjectionStore_Search(t *testing.T) {
+	t.Run("finds documents by any indexed field, best match first", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.search_is_enabled()
+		tc.document("realm-1", "bf-1", `{"title":"Fix login redirect","body":"users land on a blank page"}`)
+		tc.document("realm-1", "bf-2", `{"title":"Update docs","body":"mention the login redirect"}`)
+		tc.document("realm-1", "bf-3", `{"title":"Unrelated","body":"nothing to see"}`)
+
+		// When
+		tc.search_is_called("realm-1", "login redirect")
+
+		// Then
+		tc.hits_are("bf-1", "bf-2")
+		tc.snippet_contains(0, "**login**")
+	})
+
+	t.Run("stems words", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.search_is_enabled()
+		tc.document("realm-1", "bf-1", `{"title":"Redirecting users after login","body":""}`)
+
+		// When
+		tc.search_is_called("realm-1", "redirects")
+
+		// Then
+		tc.hits_are("bf-1")
+	})
+
+	t.Run("only searches the realm", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.search_is_enabled()
+		tc.document("realm-1", "bf-1", `{"title":"Login","body":""}`)
+		tc.document("realm-2", "bf-2", `{"title":"Login","body":""}`)
+
+		// When
+		tc.search_is_called("realm-2", "login")
+
+		// Then
+		tc.hits_are("bf-2")
+	})
+
+	t.Run("follows updates and deletes", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.search_is_enabled()
+		tc.document("realm-1", "bf-1", `{"title":"Login","body":""}`)
+		tc.document("realm-1", "bf-1", `{"title":"Logout","body":""}`)
+		tc.document("realm-1", "bf-2", `{"title":"Logout","body":""}`)
+		tc.document_is_deleted("realm-1", "bf-2")
+
+		// When
+		tc.search_is_called("realm-1", "login")
+		tc.hits_are()
+		tc.search_is_called("realm-1", "logout")
+
+		// Then
+		tc.hits_are("bf-1")
+	})
+
+	t.Run("indexes documents stored before search was enabled", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.document("realm-1", "bf-1", `{"title":"Login","body":""}`)
+		tc.search_is_enabled()
+
+		// When
+		tc.search_is_called("realm-1", "login")
+
+		// Then
+		tc.hits_are("bf-1")
+	})
+
+	t.Run("keeps the index when enabled again with the same fields", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.search_is_enabled()
+		tc.document("realm-1", "bf-1", `{"title":"Login","body":""}`)
+		tc.index_has_row_without_document("realm-1", "bf-9", "Login")
+
+		// When
+		tc.search_is_enabled()
+		tc.search_is_called("realm-1", "login")
+
+		// Then
+		tc.hits_are("bf-1", "bf-9")
+	})
+
+	t.Run("rebuilds the index when the fields change", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.search_is_enabled()
+		tc.document("realm-1", "bf-1", `{"title":"Redirect","body":"login"}`)
+
+		// When
+		tc.search_is_enabled_on("title")
+		tc.search_is_called("realm-1", "login")
+
+		// Then
+		tc.hits_are()
+		tc.search_is_called("realm-1", "redirect")
+		tc.hits_are("bf-1")
+	})
+
+	t.Run("supports phrases, OR and excluded words", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.search_is_enabled()
+		tc.document("realm-1", "bf-1", `{"title":"login redirect","body":""}`)
+		tc.document("realm-1", "bf-2", `{"title":"redirect after login","body":""}`)
+		tc.document("realm-1", "bf-3", `{"title":"logout redirect","body":"flaky"}`)
+
+		// When
+		tc.search_is_called("realm-1", `"login redirect"`)
+		tc.hits_are("bf-1")
+		tc.search_is_called("realm-1", "login OR logout -flaky")
+
+		// Then
+		tc.hits_are("bf-1", "bf-2")
+	})
+
+	t.Run("treats punctuation as text", func(t *testing.T) {
+		tc := newSearchTestContext(t)
+
+		// Given
+		tc.search_is_enabled()
+		tc.document("realm-1", "bf-1", `{"title":"Login","body":""}`)
+
+		// When
+		tc.search_is_called("realm-1", `login* AND (NEAR "`)
+
+		// Then
+		tc.no_error_occurred()
+	})
+}
+
+func TestFTSQuery(t *testing.T) {
+	cases := map[string]string{
+		"login redirect":        `"login" AND "redirect"`,
+		`"login redirect" page`: `"login redirect" AND "page"`,
+		"login OR logout":       `("login" OR "logout")`,
+		"login -flaky":          `("login") NOT "flaky"`,
+		`say "hi`:               `"say" AND "hi"`,
+		"- * ()":                "",
+		"-flaky":                "",
+	}
+	for query, expected := range cases {
+		assert.Equal(t, expected, ftsQuery(query), query)
+	}
+}
+
+// --- Test Context ---
+
+type searchTestContext struct {
+	t     *testing.T
+	store *ProjectionStore
+	hits  []core.SearchHit
+	err   error
+}
+
+func newSearchTestContext(t *testing.T) *searchTestContext {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	// Every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	store, err := NewProjectionStore(db)
+	require.NoError(t, err)
+	return &searchTestContext{t: t, store: store}
+}
+
+// --- Given ---
+
+func (tc *searchTestContext) search_is_enabled() {
+	tc.t.Helper()
+	tc.search_is_enabled_on("title", "body")
+}
+
+func (tc *searchTestContext) search_is_enabled_on(fields ...string) {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.store.EnableSearch(context.Background(), "docs", fields...))
+}
+
+// index_has_row_without_document writes straight to the index, so the row
+// only survives while the index is not rebuilt.
+func (tc *searchTestContext) index_has_row_without_document(realmID, key, title string) {
+	tc.t.Helper()
+	_, err := tc.store.db.Exec(`INSERT INTO search_docs (rowid, realm_id, key, title, body) VALUES (999, ?, ?, ?, '')`, realmID, key, title)
+	require.NoError(tc.t, err)
+}
+
+func (tc *searchTestContext) document(realmID, key, value string) {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.store.Put(context.Background(), realmID, "docs", key, json.RawMessage(value)))
+}
+
+func (tc *searchTestContext) document_is_deleted(realmID, key string) {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.store.Delete(context.Background(), realmID, "docs", key))
+}
+
+// --- When ---
+
+func (tc *searchTestContext) search_is_called(realmID, query string) {
+	tc.t.Helper()
+	tc.hits, tc.err = tc.store.Search(context.Background(), realmID, "docs", query, 10)
+}
+
+// --- Then ---
+
+func (tc *searchTestContext) no_error_occurred() {
+	tc.t.Helper()
+	assert.NoError(tc.t, tc.err)
+}
+
+func (tc *searchTestContext) hits_are(keys ...string) {
+	tc.t.Helper()
+	require.NoError(tc.t, tc.err)
+	actual := make([]string, 0, len(tc.hits))
+	for _, hit := range tc.hits {
+		actual = append(actual, hit.Key)
+	}
+	assert.Equal(tc.t, append([]string{}, keys...), actual)
+}
+
+func (tc *searchTestContext) snippet_contains(index int, expected string) {
+	tc.t.Helper()
+	require.Greater(tc.t, len(tc.hits), index)
+	assert.Contains(tc.t, tc.hits[index].Snippet, expected)
+}
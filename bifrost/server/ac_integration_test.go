@@ -316,6 +316,56 @@ func TestRemoveACItem_E2E(t *testing.T) {
 	})
 }
 
+func TestVerifyACItem_E2E(t *testing.T) {
+	t.Run("POST /verify-ac records status and evidence on the AC", func(t *testing.T) {
+		tc := newE2EContext(t)
+
+		// Given
+		tc.server_is_running()
+		tc.a_realm_exists("Verify AC Realm")
+		tc.a_rune_exists("Task with AC", 1)
+		tc.an_ac_exists_on_rune(tc.lastRuneID, "happy path", "desc")
+
+		// When
+		body, _ := json.Marshal(map[string]any{
+			"rune_id":  tc.lastRuneID,
+			"id":       "AC-01",
+			"status":   "verified",
+			"evidence": "TestHappyPath passed",
+			"verifier": "agent-1",
+		})
+		tc.post("/api/verify-ac", string(body), tc.realmPATToken)
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+		tc.get("/api/rune?id="+tc.lastRuneID, tc.realmPATToken)
+		tc.status_is(http.StatusOK)
+		tc.response_ac_has_field(0, "status", "verified")
+		tc.response_ac_has_field(0, "evidence", "TestHappyPath passed")
+		tc.response_ac_has_field(0, "verifier", "agent-1")
+	})
+
+	t.Run("POST /verify-ac with unknown status returns error", func(t *testing.T) {
+		tc := newE2EContext(t)
+
+		// Given
+		tc.server_is_running()
+		tc.a_realm_exists("Verify AC Err Realm")
+		tc.a_rune_exists("Task with AC", 1)
+		tc.an_ac_exists_on_rune(tc.lastRuneID, "happy path", "desc")
+
+		// When
+		body, _ := json.Marshal(map[string]any{
+			"rune_id": tc.lastRuneID,
+			"id":      "AC-01",
+			"status":  "maybe",
+		})
+		tc.post("/api/verify-ac", string(body), tc.realmPATToken)
+
+		// Then
+		tc.status_is(http.StatusUnprocessableEntity)
+	})
+}
 func TestACAuthRequired_E2E(t *testing.T) {
 	t.Run("AC endpoints return 401 without auth header", func(t *testing.T) {
 		tc := newE2EContext(t)
@@ -331,6 +381,7 @@ func TestACAuthRequired_E2E(t *testing.T) {
 			{"POST", "/api/add-ac", `{"rune_id":"bf-0001","scenario":"s","description":"d"}`},
 			{"POST", "/api/update-ac", `{"rune_id":"bf-0001","id":"AC-01","scenario":"s","description":"d"}`},
 			{"POST", "/api/remove-ac", `{"rune_id":"bf-0001","id":"AC-01"}`},
+			{"POST", "/api/verify-ac", `{"rune_id":"bf-0001","id":"AC-01","status":"verified"}`},
 		}
 
 		for _, ep := range endpoints {
@@ -403,3 +454,14 @@ func (tc *e2eTestContext) response_ac_has_description(index int, expected string
 	require.True(tc.t, ok, "expected AC[%d] to be an object", index)
 	assert.Equal(tc.t, expected, entry["description"], "AC[%d].description", index)
 }
+
+func (tc *e2eTestContext) response_ac_has_field(index int, field string, expected any) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.respJSON, "response is not a JSON object")
+	acs, ok := tc.respJSON["acceptance_criteria"].([]any)
+	require.True(tc.t, ok, "expected 'acceptance_criteria' to be an array")
+	require.Greater(tc.t, len(acs), index, "expected at least %d AC items", index+1)
+	entry, ok := acs[index].(map[string]any)
+	require.True(tc.t, ok, "expected AC[%d] to be an object", index)
+	assert.Equal(tc.t, expected, entry[field], "AC[%d].%s", index, field)
+}
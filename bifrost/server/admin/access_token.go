@@ -0,0 +1,249 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// DefaultAccessTokenExpiry is how long an access token from POST /api/token is valid.
+	DefaultAccessTokenExpiry = 5 * time.Minute
+	// DefaultRevocationRefreshInterval is how often the revocation list is reloaded.
+	DefaultRevocationRefreshInterval = 5 * time.Second
+
+	accessTokenType  = "access"
+	sessionTokenType = "session"
+)
+
+// AccessTokenClaims are the claims of a short-lived access token exchanged
+// from a PAT. They carry everything the middleware needs to authorize a
+// request, already narrowed to the PAT's scopes, so validating one needs no
+// projection lookups.
+type AccessTokenClaims struct {
+	Type       string            `json:"typ"`
+	AccountID  string            `json:"sub"`
+	PATID      string            `json:"pat"`
+	Username   string            `json:"username"`
+	Kind       string            `json:"kind,omitempty"`
+	Realms     []string          `json:"realms,omitempty"`
+	Roles      map[string]string `json:"roles,omitempty"`
+	RealmNames map[string]string `json:"realm_names,omitempty"`
+	Groups     []string          `json:"groups,omitempty"`
+	Scopes     *domain.PATScopes `json:"scopes,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// AccountEntry returns the account the token was issued for.
+func (c *AccessTokenClaims) AccountEntry() *projectors.AccountAuthEntry {
+	return &projectors.AccountAuthEntry{
+		AccountID:  c.AccountID,
+		Username:   c.Username,
+		Status:     "active",
+		Realms:     c.Realms,
+		Roles:      c.Roles,
+		RealmNames: c.RealmNames,
+		Kind:       c.Kind,
+	}
+}
+
+// IsAccessToken reports whether a bearer token is an access token rather
+// than a PAT. PATs are base64url and never contain dots.
+func IsAccessToken(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// GenerateAccessToken signs an access token for entry, authenticated by
+// patID. It expires after cfg.AccessTokenExpiry, or when the PAT does if
+// that is sooner.
+func GenerateAccessToken(cfg *AuthConfig, entry *projectors.AccountAuthEntry, patID string, scopes *domain.PATScopes, patExpiresAt *time.Time) (string, time.Time, error) {
+	if len(cfg.SigningKey) == 0 {
+		return "", time.Time{}, errors.New("JWT signing key not configured")
+	}
+
+	expiry := cfg.AccessTokenExpiry
+	if expiry <= 0 {
+		expiry = DefaultAccessTokenExpiry
+	}
+	now := time.Now()
+	expiresAt := now.Add(expiry)
+	if patExpiresAt != nil && patExpiresAt.Before(expiresAt) {
+		expiresAt = *patExpiresAt
+	}
+
+	claims := AccessTokenClaims{
+		Type:       accessTokenType,
+		AccountID:  entry.AccountID,
+		PATID:      patID,
+		Username:   entry.Username,
+		Kind:       entry.Kind,
+		Realms:     entry.Realms,
+		Roles:      entry.Roles,
+		RealmNames: entry.RealmNames,
+		Groups:     entry.Groups,
+		Scopes:     scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(cfg.SigningKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// AuthenticateAccessToken validates an access token and checks that neither
+// its PAT nor its account has been revoked, and that none of the roles it
+// carries has been revoked, since it was issued. With a
+// revocation list configured this needs no projection lookups; without one
+// it falls back to looking up the PAT.
+func AuthenticateAccessToken(ctx context.Context, cfg *AuthConfig, projectionStore core.ProjectionStore, tokenString string) (*AccessTokenClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &AccessTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Method.Alg())
+		}
+		return cfg.SigningKey, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*AccessTokenClaims)
+	if !ok || claims.Type != accessTokenType || claims.PATID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	if cfg.Revocations == nil {
+		if _, _, err := CheckPATStatus(ctx, projectionStore, claims.PATID); err != nil {
+			return nil, err
+		}
+		return claims, nil
+	}
+	if err := cfg.Revocations.Check(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// RevocationList caches the token_revocations projection in memory so
+// access tokens can be checked against revoked PATs, suspended accounts and
+// revoked grants without a lookup per request. Until the first successful
+// refresh every token is treated as revoked.
+type RevocationList struct {
+	store    core.ProjectionStore
+	interval time.Duration
+
+	mu       sync.RWMutex
+	loaded   bool
+	pats     map[string]bool
+	accounts map[string]bool
+	// grants holds, by revocation key, when an account, group or realm last
+	// lost a grant.
+	grants map[string]time.Time
+}
+
+// NewRevocationList creates a revocation list that reloads from store every interval.
+func NewRevocationList(store core.ProjectionStore, interval time.Duration) *RevocationList {
+	if interval <= 0 {
+		interval = DefaultRevocationRefreshInterval
+	}
+	return &RevocationList{store: store, interval: interval}
+}
+
+// Check returns ErrPATRevoked or ErrAccountSuspended if tokens for the PAT
+// or account must be rejected, and ErrGrantsChanged if the token was issued
+// before its account, one of its groups or one of its realms lost a grant.
+func (l *RevocationList) Check(claims *AccessTokenClaims) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	switch {
+	case !l.loaded || l.pats[claims.PATID]:
+		return ErrPATRevoked
+	case l.accounts[claims.AccountID]:
+		return ErrAccountSuspended
+	}
+
+	if claims.IssuedAt == nil {
+		return ErrGrantsChanged
+	}
+	issuedAt := claims.IssuedAt.Time
+	keys := []string{projectors.TokenRevocationKey(projectors.RevocationKindAccountGrant, claims.AccountID)}
+	for _, groupID := range claims.Groups {
+		keys = append(keys, projectors.TokenRevocationKey(projectors.RevocationKindGroupGrant, groupID))
+	}
+	for realmID := range claims.Roles {
+		keys = append(keys, projectors.TokenRevocationKey(projectors.RevocationKindRealmGrant, realmID))
+	}
+	for _, key := range keys {
+		// Issue times are whole seconds, so a token issued in the same second
+		// as the change is rejected too.
+		if revokedAt, ok := l.grants[key]; ok && !issuedAt.After(revokedAt.Truncate(time.Second)) {
+			return ErrGrantsChanged
+		}
+	}
+	return nil
+}
+
+// Refresh reloads the list from the token_revocations projection.
+func (l *RevocationList) Refresh(ctx context.Context) error {
+	raws, err := core.ListRef(ctx, l.store, domain.AdminRealmID, projectors.TokenRevocationsTable)
+	if err != nil {
+		return fmt.Errorf("load token revocations: %w", err)
+	}
+
+	pats := make(map[string]bool)
+	accounts := make(map[string]bool)
+	grants := make(map[string]time.Time)
+	for _, raw := range raws {
+		var entry projectors.TokenRevocationEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return fmt.Errorf("load token revocations: %w", err)
+		}
+		switch entry.Kind {
+		case projectors.RevocationKindPAT:
+			pats[entry.ID] = true
+		case projectors.RevocationKindAccount:
+			accounts[entry.ID] = true
+		case projectors.RevocationKindAccountGrant, projectors.RevocationKindGroupGrant, projectors.RevocationKindRealmGrant:
+			grants[projectors.TokenRevocationKey(entry.Kind, entry.ID)] = entry.RevokedAt
+		}
+	}
+
+	l.mu.Lock()
+	l.loaded, l.pats, l.accounts, l.grants = true, pats, accounts, grants
+	l.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the list every interval until ctx is cancelled. A failed
+// refresh keeps the previous list.
+func (l *RevocationList) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.Refresh(ctx); err != nil {
+				log.Printf("token revocations: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessTokens(t *testing.T) {
+	setup := func(t *testing.T) (*http.ServeMux, *AuthConfig, *mockProjectionStore) {
+		t.Helper()
+		store := newMockProjectionStoreWithAccount()
+
+		authCfg := DefaultAuthConfig()
+		authCfg.SigningKey = make([]byte, 32)
+		_, err := rand.Read(authCfg.SigningKey)
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+		RegisterTokenAPIRoutes(mux, &RouteConfig{AuthConfig: authCfg, ProjectionStore: store})
+		mux.Handle("GET /api/whoami", AuthMiddleware(authCfg, store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accountID, _ := AccountIDFromContext(r.Context())
+			patID, _ := PATIDFromContext(r.Context())
+			_, _ = w.Write([]byte(accountID + "/" + patID))
+		})))
+		return mux, authCfg, store
+	}
+
+	do := func(mux *http.ServeMux, method, target, bearer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	exchange := func(t *testing.T, mux *http.ServeMux, pat string) TokenExchangeResponse {
+		t.Helper()
+		rec := do(mux, "POST", "/api/token", pat)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp TokenExchangeResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+
+	revocations := func(t *testing.T, store *mockProjectionStore, entries ...projectors.TokenRevocationEntry) *RevocationList {
+		t.Helper()
+		raws := make([]json.RawMessage, 0, len(entries))
+		for _, entry := range entries {
+			raw, err := json.Marshal(entry)
+			require.NoError(t, err)
+			raws = append(raws, raw)
+		}
+		store.listData[projectors.TokenRevocationsTable.Name] = raws
+		list := NewRevocationList(store, time.Minute)
+		require.NoError(t, list.Refresh(context.Background()))
+		return list
+	}
+
+	t.Run("exchanges a PAT for an access token", func(t *testing.T) {
+		mux, _, store := setup(t)
+
+		resp := exchange(t, mux, store.validToken)
+
+		assert.True(t, IsAccessToken(resp.AccessToken))
+		assert.Equal(t, "Bearer", resp.TokenType)
+		assert.InDelta(t, DefaultAccessTokenExpiry.Seconds(), resp.ExpiresIn, 2)
+	})
+
+	t.Run("access token authenticates without PAT lookups", func(t *testing.T) {
+		mux, cfg, store := setup(t)
+		token := exchange(t, mux, store.validToken).AccessToken
+		cfg.Revocations = revocations(t, store)
+		store.getError = assert.AnError
+
+		rec := do(mux, "GET", "/api/whoami", token)
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "account-test-123/pat-test-123", rec.Body.String())
+		assert.Empty(t, rec.Header().Get(TokenExchangeHeader))
+	})
+
+	t.Run("PAT responses advertise token exchange", func(t *testing.T) {
+		mux, _, store := setup(t)
+
+		rec := do(mux, "GET", "/api/whoami", store.validToken)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "/api/token", rec.Header().Get(TokenExchangeHeader))
+	})
+
+	t.Run("rejects access tokens of revoked PATs", func(t *testing.T) {
+		mux, cfg, store := setup(t)
+		token := exchange(t, mux, store.validToken).AccessToken
+		cfg.Revocations = revocations(t, store, projectors.TokenRevocationEntry{Kind: projectors.RevocationKindPAT, ID: "pat-test-123"})
+
+		rec := do(mux, "GET", "/api/whoami", token)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), ErrPATRevoked.Error())
+	})
+
+	t.Run("rejects access tokens of suspended accounts", func(t *testing.T) {
+		mux, cfg, store := setup(t)
+		token := exchange(t, mux, store.validToken).AccessToken
+		cfg.Revocations = revocations(t, store, projectors.TokenRevocationEntry{Kind: projectors.RevocationKindAccount, ID: "account-test-123"})
+
+		rec := do(mux, "GET", "/api/whoami", token)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), ErrAccountSuspended.Error())
+	})
+
+	t.Run("treats every token as revoked until the list has loaded", func(t *testing.T) {
+		list := NewRevocationList(newMockProjectionStore(), time.Minute)
+
+		assert.ErrorIs(t, list.Check(&AccessTokenClaims{PATID: "pat-1", AccountID: "acct-1"}), ErrPATRevoked)
+	})
+
+	t.Run("rejects access tokens issued before a grant was revoked", func(t *testing.T) {
+		revoked := []projectors.TokenRevocationEntry{
+			{Kind: projectors.RevocationKindAccountGrant, ID: "account-test-123"},
+			{Kind: projectors.RevocationKindGroupGrant, ID: "group-1"},
+			{Kind: projectors.RevocationKindRealmGrant, ID: "realm-1"},
+		}
+		for _, entry := range revoked {
+			t.Run(entry.Kind, func(t *testing.T) {
+				mux, cfg, store := setup(t)
+				entry.RevokedAt = time.Now()
+				cfg.Revocations = revocations(t, store, entry)
+				claims := &projectors.AccountAuthEntry{
+					AccountID: "account-test-123",
+					Roles:     map[string]string{"realm-1": "admin", "_admin": "admin"},
+					Groups:    []string{"group-1"},
+				}
+				token, _, err := GenerateAccessToken(cfg, claims, "pat-test-123", nil, nil)
+				require.NoError(t, err)
+
+				rec := do(mux, "GET", "/api/whoami", token)
+
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+				assert.Contains(t, rec.Body.String(), ErrGrantsChanged.Error())
+			})
+		}
+	})
+
+	t.Run("accepts access tokens issued after a grant was revoked", func(t *testing.T) {
+		mux, cfg, store := setup(t)
+		cfg.Revocations = revocations(t, store, projectors.TokenRevocationEntry{
+			Kind:      projectors.RevocationKindAccountGrant,
+			ID:        "account-test-123",
+			RevokedAt: time.Now().Add(-2 * time.Second),
+		}, projectors.TokenRevocationEntry{
+			Kind:      projectors.RevocationKindRealmGrant,
+			ID:        "realm-elsewhere",
+			RevokedAt: time.Now(),
+		})
+		token := exchange(t, mux, store.validToken).AccessToken
+
+		rec := do(mux, "GET", "/api/whoami", token)
+
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	})
+
+	t.Run("looks up the PAT when no revocation list is configured", func(t *testing.T) {
+		mux, _, store := setup(t)
+		token := exchange(t, mux, store.validToken).AccessToken
+		delete(store.data, compositeKey(domain.AdminRealmID, "pat_by_id", "pat-test-123"))
+
+		rec := do(mux, "GET", "/api/whoami", token)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("rejects expired access tokens", func(t *testing.T) {
+		mux, cfg, store := setup(t)
+		cfg.Revocations = revocations(t, store)
+		entry := &projectors.AccountAuthEntry{AccountID: "account-test-123"}
+		expired := time.Now().Add(-time.Minute)
+		token, _, err := GenerateAccessToken(cfg, entry, "pat-test-123", nil, &expired)
+		require.NoError(t, err)
+
+		rec := do(mux, "GET", "/api/whoami", token)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), ErrTokenExpired.Error())
+	})
+
+	t.Run("does not accept a session JWT as an access token", func(t *testing.T) {
+		mux, cfg, store := setup(t)
+		cfg.Revocations = revocations(t, store)
+		session, err := GenerateJWT(cfg, "account-test-123", "pat-test-123")
+		require.NoError(t, err)
+
+		rec := do(mux, "GET", "/api/whoami", session)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("an access token cannot be exchanged for another", func(t *testing.T) {
+		mux, _, store := setup(t)
+		token := exchange(t, mux, store.validToken).AccessToken
+
+		rec := do(mux, "POST", "/api/token", token)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("access tokens expire with their PAT", func(t *testing.T) {
+		_, cfg, _ := setup(t)
+		patExpires := time.Now().Add(time.Minute)
+
+		_, expiresAt, err := GenerateAccessToken(cfg, &projectors.AccountAuthEntry{AccountID: "a"}, "p", nil, &patExpires)
+
+		require.NoError(t, err)
+		assert.Equal(t, patExpires, expiresAt)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/devzeebo/bifrost/domain"
 	"github.com/devzeebo/bifrost/domain/projectors"
@@ -15,6 +16,8 @@ import (
 type AccountListEntry struct {
 	AccountID string            `json:"account_id"`
 	Username  string            `json:"username"`
+	Kind      string            `json:"kind"`
+	OwnerID   string            `json:"owner_id,omitempty"`
 	Status    string            `json:"status"`
 	Realms    []string          `json:"realms"`
 	Roles     map[string]string `json:"roles"`
@@ -26,6 +29,8 @@ type AccountListEntry struct {
 type AccountDetail struct {
 	AccountID string            `json:"account_id"`
 	Username  string            `json:"username"`
+	Kind      string            `json:"kind"`
+	OwnerID   string            `json:"owner_id,omitempty"`
 	Status    string            `json:"status"`
 	Realms    []string          `json:"realms"`
 	Roles     map[string]string `json:"roles"`
@@ -44,12 +49,37 @@ type CreateAccountResponse struct {
 	PAT       string `json:"pat"`
 }
 
+// CreateServiceAccountRequest is the request body for POST /create-service-account.
+// OwnerID defaults to the caller.
+type CreateServiceAccountRequest struct {
+	Username string `json:"username"`
+	RealmID  string `json:"realm_id"`
+	Role     string `json:"role,omitempty"`
+	OwnerID  string `json:"owner_id,omitempty"`
+}
+
 // SuspendAccountRequest is the request body for POST /suspend-account.
 type SuspendAccountRequest struct {
 	ID      string `json:"id"`
 	Suspend bool   `json:"suspend"`
 }
 
+// ReactivateAccountRequest is the request body for POST /reactivate-account.
+type ReactivateAccountRequest struct {
+	AccountID string `json:"account_id"`
+}
+
+// RenameAccountRequest is the request body for POST /rename-account.
+type RenameAccountRequest struct {
+	AccountID string `json:"account_id"`
+	Username  string `json:"username"`
+}
+
+// DeleteAccountRequest is the request body for POST /delete-account.
+type DeleteAccountRequest struct {
+	AccountID string `json:"account_id"`
+}
+
 // GrantRealmRequest is the request body for POST /grant-realm.
 type GrantRealmRequest struct {
 	AccountID string `json:"account_id"`
@@ -65,14 +95,17 @@ type RevokeRealmRequest struct {
 
 // CreatePatRequest is the request body for POST /create-pat.
 type CreatePatRequest struct {
-	AccountID string `json:"account_id"`
-	Label     string `json:"label"`
+	AccountID string            `json:"account_id"`
+	Label     string            `json:"label"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	Scopes    *domain.PATScopes `json:"scopes,omitempty"`
 }
 
 // CreatePatResponse is the response for POST /create-pat.
 type CreatePatResponse struct {
-	PAT   string `json:"pat"`
-	PATID string `json:"pat_id"`
+	PAT       string `json:"pat"`
+	PATID     string `json:"pat_id"`
+	ExpiresAt string `json:"expires_at,omitempty"`
 }
 
 // RevokePatRequest is the request body for POST /revoke-pat.
@@ -83,11 +116,26 @@ type RevokePatRequest struct {
 
 // PatEntry is the JSON response for a PAT in the list.
 type PatEntry struct {
-	ID           string `json:"id"`
-	Label        string `json:"label,omitempty"`
-	TokenPreview string `json:"token_preview,omitempty"`
-	CreatedAt    string `json:"created_at"`
-	LastUsed     string `json:"last_used,omitempty"`
+	ID           string            `json:"id"`
+	Label        string            `json:"label,omitempty"`
+	TokenPreview string            `json:"token_preview,omitempty"`
+	CreatedAt    string            `json:"created_at"`
+	ExpiresAt    string            `json:"expires_at,omitempty"`
+	Scopes       *domain.PATScopes `json:"scopes,omitempty"`
+	LastUsed     string            `json:"last_used,omitempty"`
+	LastIP       string            `json:"last_ip,omitempty"`
+	RequestCount int64             `json:"request_count"`
+}
+
+// RevokeStalePatsRequest is the request body for POST /revoke-stale-pats.
+type RevokeStalePatsRequest struct {
+	AccountID   string    `json:"account_id"`
+	UnusedSince time.Time `json:"unused_since"`
+}
+
+// RevokeStalePatsResponse is the response for POST /revoke-stale-pats.
+type RevokeStalePatsResponse struct {
+	Revoked []string `json:"revoked"`
 }
 
 
@@ -102,7 +150,11 @@ func RegisterAccountsAPIRoutes(mux *http.ServeMux, cfg *RouteConfig) {
 
 	// Account management
 	mux.Handle("POST /api/create-account", authMiddleware(requireAdmin(http.HandlerFunc(handleCreateAccount(cfg)))))
+	mux.Handle("POST /api/create-service-account", authMiddleware(http.HandlerFunc(handleCreateServiceAccount(cfg))))
 	mux.Handle("POST /api/suspend-account", authMiddleware(requireAdmin(http.HandlerFunc(handleSuspendAccount(cfg)))))
+	mux.Handle("POST /api/reactivate-account", authMiddleware(requireAdmin(http.HandlerFunc(handleReactivateAccount(cfg)))))
+	mux.Handle("POST /api/rename-account", authMiddleware(requireAdmin(http.HandlerFunc(handleRenameAccount(cfg)))))
+	mux.Handle("POST /api/delete-account", authMiddleware(requireAdmin(http.HandlerFunc(handleDeleteAccount(cfg)))))
 
 	// Realm access management
 	mux.Handle("POST /api/grant-realm", authMiddleware(requireAdmin(http.HandlerFunc(handleGrantRealm(cfg)))))
@@ -112,6 +164,7 @@ func RegisterAccountsAPIRoutes(mux *http.ServeMux, cfg *RouteConfig) {
 	mux.Handle("POST /api/create-pat", authMiddleware(http.HandlerFunc(handleCreatePat(cfg))))
 	mux.Handle("POST /api/revoke-pat", authMiddleware(http.HandlerFunc(handleRevokePat(cfg))))
 	mux.Handle("GET /api/pats", authMiddleware(http.HandlerFunc(handleGetPats(cfg))))
+	mux.Handle("POST /api/revoke-stale-pats", authMiddleware(http.HandlerFunc(handleRevokeStalePats(cfg))))
 }
 
 func canManageAccount(ctx context.Context, targetAccountID string) bool {
@@ -127,8 +180,23 @@ func canManageAccount(ctx context.Context, targetAccountID string) bool {
 	return ok && isAdmin(roles)
 }
 
+// accountKind returns the kind of a directory entry, treating accounts
+// created before kinds existed as human.
+func accountKind(entry projectors.AccountDirectoryEntry) string {
+	if entry.Kind == "" {
+		return domain.AccountKindHuman
+	}
+	return entry.Kind
+}
+
 func handleGetAccounts(cfg *RouteConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		kind := r.URL.Query().Get("kind")
+		if kind != "" && kind != domain.AccountKindHuman && kind != domain.AccountKindService {
+			writeError(w, http.StatusBadRequest, "kind must be human or service")
+			return
+		}
+
 		// Get all accounts from projection
 		var accounts []AccountListEntry
 		if cfg.ProjectionStore != nil {
@@ -144,9 +212,14 @@ func handleGetAccounts(cfg *RouteConfig) http.HandlerFunc {
 				if err := json.Unmarshal(raw, &account); err != nil {
 					continue
 				}
+				if kind != "" && accountKind(account) != kind {
+					continue
+				}
 				accounts = append(accounts, AccountListEntry{
 					AccountID: account.AccountID,
 					Username:  account.Username,
+					Kind:      accountKind(account),
+					OwnerID:   account.OwnerID,
 					Status:    account.Status,
 					Realms:    account.Realms,
 					Roles:     account.Roles,
@@ -189,6 +262,8 @@ func handleGetAccount(cfg *RouteConfig) http.HandlerFunc {
 		detail := AccountDetail{
 			AccountID: account.AccountID,
 			Username:  account.Username,
+			Kind:      accountKind(account),
+			OwnerID:   account.OwnerID,
 			Status:    account.Status,
 			Realms:    account.Realms,
 			Roles:     account.Roles,
@@ -245,6 +320,70 @@ func handleCreateAccount(cfg *RouteConfig) http.HandlerFunc {
 	}
 }
 
+// handleCreateServiceAccount lets a realm admin create a service account
+// restricted to that realm. System admins may also assign another owner.
+func handleCreateServiceAccount(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateServiceAccountRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+
+		username := strings.TrimSpace(req.Username)
+		if username == "" {
+			writeError(w, http.StatusBadRequest, "username is required")
+			return
+		}
+		if req.RealmID == "" {
+			writeError(w, http.StatusBadRequest, "realm_id is required")
+			return
+		}
+
+		callerID, _ := AccountIDFromContext(r.Context())
+		roles, _ := RolesFromContext(r.Context())
+		systemAdmin := isAdmin(roles)
+		if !systemAdmin && domain.RoleLevel(roles[req.RealmID]) < domain.RoleLevel(domain.RoleAdmin) {
+			writeError(w, http.StatusForbidden, "realm admin required")
+			return
+		}
+
+		ownerID := req.OwnerID
+		if ownerID == "" {
+			ownerID = callerID
+		}
+		if ownerID != callerID && !systemAdmin {
+			writeError(w, http.StatusForbidden, "only system admins can assign another owner")
+			return
+		}
+
+		result, err := domain.HandleCreateServiceAccount(r.Context(), domain.CreateServiceAccount{
+			Username: username,
+			OwnerID:  ownerID,
+			RealmID:  req.RealmID,
+			Role:     req.Role,
+		}, cfg.EventStore, cfg.ProjectionStore)
+		if err != nil {
+			if strings.Contains(err.Error(), "already exists") {
+				writeError(w, http.StatusConflict, "username already exists")
+				return
+			}
+			log.Printf("handleCreateServiceAccount: failed to create account: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(CreateAccountResponse{
+			AccountID: result.AccountID,
+			PAT:       result.RawToken,
+		}); err != nil {
+			log.Printf("handleCreateServiceAccount: failed to encode response: %v", err)
+		}
+	}
+}
+
 func handleSuspendAccount(cfg *RouteConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req SuspendAccountRequest
@@ -263,20 +402,109 @@ func handleSuspendAccount(cfg *RouteConfig) http.HandlerFunc {
 			return
 		}
 
-		// Suspend/unsuspend account via domain command
-		var reason string
+		// Suspend or reactivate the account via domain command
+		var err error
 		if req.Suspend {
-			reason = "suspended via admin UI"
+			err = domain.HandleSuspendAccount(r.Context(), domain.SuspendAccount{
+				AccountID: req.ID,
+				Reason:    "suspended via admin UI",
+			}, cfg.EventStore)
 		} else {
-			reason = "unsuspended via admin UI"
+			err = domain.HandleReactivateAccount(r.Context(), domain.ReactivateAccount{
+				AccountID: req.ID,
+			}, cfg.EventStore)
+		}
+		if err != nil {
+			log.Printf("handleSuspendAccount: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleReactivateAccount(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ReactivateAccountRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+
+		if req.AccountID == "" {
+			writeError(w, http.StatusBadRequest, "account_id is required")
+			return
 		}
 
-		err := domain.HandleSuspendAccount(r.Context(), domain.SuspendAccount{
-			AccountID: req.ID,
-			Reason:    reason,
+		err := domain.HandleReactivateAccount(r.Context(), domain.ReactivateAccount{
+			AccountID: req.AccountID,
 		}, cfg.EventStore)
 		if err != nil {
-			log.Printf("handleSuspendAccount: failed: %v", err)
+			log.Printf("handleReactivateAccount: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleRenameAccount(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RenameAccountRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+
+		username := strings.TrimSpace(req.Username)
+		if req.AccountID == "" || username == "" {
+			writeError(w, http.StatusBadRequest, "account_id and username are required")
+			return
+		}
+
+		err := domain.HandleRenameAccount(r.Context(), domain.RenameAccount{
+			AccountID: req.AccountID,
+			Username:  username,
+		}, cfg.EventStore, cfg.ProjectionStore)
+		if err != nil {
+			if strings.Contains(err.Error(), "already exists") {
+				writeError(w, http.StatusConflict, "username already exists")
+				return
+			}
+			log.Printf("handleRenameAccount: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleDeleteAccount(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DeleteAccountRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+
+		if req.AccountID == "" {
+			writeError(w, http.StatusBadRequest, "account_id is required")
+			return
+		}
+
+		if requesterAccountID, ok := AccountIDFromContext(r.Context()); ok && requesterAccountID == req.AccountID {
+			writeError(w, http.StatusBadRequest, "cannot delete your own account")
+			return
+		}
+
+		err := domain.HandleDeleteAccount(r.Context(), domain.DeleteAccount{
+			AccountID: req.AccountID,
+		}, cfg.EventStore)
+		if err != nil {
+			log.Printf("handleDeleteAccount: failed: %v", err)
 			handleDomainError(w, err)
 			return
 		}
@@ -360,6 +588,12 @@ func handleCreatePat(cfg *RouteConfig) http.HandlerFunc {
 			return
 		}
 
+		// A scoped PAT must not be able to mint a broader one.
+		if _, scoped := PATScopesFromContext(r.Context()); scoped {
+			writeError(w, http.StatusForbidden, "scoped PATs cannot create PATs")
+			return
+		}
+
 		label := strings.TrimSpace(req.Label)
 		if label == "" {
 			label = "PAT"
@@ -369,6 +603,8 @@ func handleCreatePat(cfg *RouteConfig) http.HandlerFunc {
 		result, err := domain.HandleCreatePAT(r.Context(), domain.CreatePAT{
 			AccountID: req.AccountID,
 			Label:     label,
+			ExpiresAt: req.ExpiresAt,
+			Scopes:    req.Scopes,
 		}, cfg.EventStore)
 		if err != nil {
 			log.Printf("handleCreatePat: failed: %v", err)
@@ -380,6 +616,9 @@ func handleCreatePat(cfg *RouteConfig) http.HandlerFunc {
 			PAT:   result.RawToken,
 			PATID: result.PATID,
 		}
+		if req.ExpiresAt != nil {
+			resp.ExpiresAt = req.ExpiresAt.UTC().Format("2006-01-02T15:04:05.000Z")
+		}
 
 
 		w.Header().Set("Content-Type", "application/json")
@@ -465,12 +704,26 @@ func handleGetPats(cfg *RouteConfig) http.HandlerFunc {
 		// Build PAT list from account_directory.pats array
 		pats := make([]PatEntry, 0, len(account.PATs))
 		for _, pat := range account.PATs {
-			pats = append(pats, PatEntry{
+			entry := PatEntry{
 				ID:           pat.PATID,
 				Label:        pat.Label,
 				TokenPreview: "",
 				CreatedAt:    pat.CreatedAt.Format("2006-01-02T15:04:05.000Z"),
-			})
+				Scopes:       pat.Scopes,
+			}
+			if pat.ExpiresAt != nil {
+				entry.ExpiresAt = pat.ExpiresAt.Format("2006-01-02T15:04:05.000Z")
+			}
+			usage, used, err := lookupPATUsage(r.Context(), cfg, pat.PATID)
+			if err != nil {
+				log.Printf("handleGetPats: failed to read usage for %s: %v", pat.PATID, err)
+			}
+			if used {
+				entry.LastUsed = usage.LastUsedAt.Format("2006-01-02T15:04:05.000Z")
+				entry.LastIP = usage.LastIP
+				entry.RequestCount = usage.RequestCount
+			}
+			pats = append(pats, entry)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -479,3 +732,97 @@ func handleGetPats(cfg *RouteConfig) http.HandlerFunc {
 		}
 	}
 }
+
+// handleRevokeStalePats revokes an account's PATs that have not been used
+// since the cutoff; a PAT that was never used counts from its creation. The
+// PAT making the request is never revoked, and the most recently active PAT
+// is kept so the account is not left without one.
+func handleRevokeStalePats(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RevokeStalePatsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+
+		if req.AccountID == "" || req.UnusedSince.IsZero() {
+			writeError(w, http.StatusBadRequest, "account_id and unused_since are required")
+			return
+		}
+
+		if !canManageAccount(r.Context(), req.AccountID) {
+			writeError(w, http.StatusForbidden, "forbidden")
+			return
+		}
+
+		var account projectors.AccountDirectoryEntry
+		if err := cfg.ProjectionStore.Get(r.Context(), domain.AdminRealmID, "account_directory", req.AccountID, &account); err != nil {
+			writeError(w, http.StatusNotFound, "account not found")
+			return
+		}
+
+		callerPATID, _ := PATIDFromContext(r.Context())
+		type activity struct {
+			patID string
+			at    time.Time
+		}
+		var stale []activity
+		var newest activity
+		for _, pat := range account.PATs {
+			usage, used, err := lookupPATUsage(r.Context(), cfg, pat.PATID)
+			if err != nil {
+				log.Printf("handleRevokeStalePats: failed to read usage for %s: %v", pat.PATID, err)
+				writeError(w, http.StatusInternalServerError, "failed to read PAT usage")
+				return
+			}
+			lastActive := pat.CreatedAt
+			if used {
+				lastActive = usage.LastUsedAt
+			}
+			if newest.patID == "" || lastActive.After(newest.at) {
+				newest = activity{patID: pat.PATID, at: lastActive}
+			}
+			if pat.PATID != callerPATID && lastActive.Before(req.UnusedSince) {
+				stale = append(stale, activity{patID: pat.PATID, at: lastActive})
+			}
+		}
+
+		resp := RevokeStalePatsResponse{Revoked: []string{}}
+		for _, pat := range stale {
+			if len(stale) == len(account.PATs) && pat.patID == newest.patID {
+				continue
+			}
+			err := domain.HandleRevokePAT(r.Context(), domain.RevokePAT{
+				AccountID: req.AccountID,
+				PATID:     pat.patID,
+			}, cfg.EventStore)
+			if err != nil {
+				log.Printf("handleRevokeStalePats: failed: %v", err)
+				handleDomainError(w, err)
+				return
+			}
+			resp.Revoked = append(resp.Revoked, pat.patID)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("handleRevokeStalePats: failed to encode response: %v", err)
+		}
+	}
+}
+
+// lookupPATUsage returns a PAT's usage, including usage the recorder has not
+// flushed yet.
+func lookupPATUsage(ctx context.Context, cfg *RouteConfig, patID string) (PATUsage, bool, error) {
+	if cfg.AuthConfig != nil && cfg.AuthConfig.Usage != nil {
+		return cfg.AuthConfig.Usage.Usage(ctx, patID)
+	}
+	if cfg.ProjectionStore == nil {
+		return PATUsage{}, false, nil
+	}
+	usage, err := readPATUsage(ctx, cfg.ProjectionStore, patID)
+	if err != nil {
+		return PATUsage{}, false, err
+	}
+	return usage, usage.RequestCount > 0, nil
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
 	"github.com/devzeebo/bifrost/domain/projectors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -217,3 +219,406 @@ func TestHandleRevokePat_UsesAccountDirectory(t *testing.T) {
 		assert.Contains(t, rec.Body.String(), "cannot revoke the last PAT")
 	})
 }
+
+func TestAccountLifecycleAPI(t *testing.T) {
+	adminRequest := func(path, body string) *http.Request {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), accountIDKey, "acct-admin")
+		ctx = context.WithValue(ctx, rolesKey, map[string]string{"_admin": "admin"})
+		return req.WithContext(ctx)
+	}
+
+	seedAccount := func(t *testing.T, events *mockEventStore, suspended bool) {
+		t.Helper()
+		data := []core.EventData{
+			{EventType: domain.EventAccountCreated, Data: domain.AccountCreated{AccountID: "acct-1", Username: "alice"}},
+		}
+		if suspended {
+			data = append(data, core.EventData{EventType: domain.EventAccountSuspended, Data: domain.AccountSuspended{AccountID: "acct-1"}})
+		}
+		_, err := events.Append(context.Background(), domain.AdminRealmID, "account-acct-1", 0, data)
+		require.NoError(t, err)
+	}
+
+	lastEventType := func(events *mockEventStore) string {
+		stream := events.streams[domain.AdminRealmID+"|account-acct-1"]
+		return stream[len(stream)-1].EventType
+	}
+
+	t.Run("suspend-account with suspend false reactivates the account", func(t *testing.T) {
+		events := newMockEventStore()
+		seedAccount(t, events, true)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleSuspendAccount(cfg).ServeHTTP(rec, adminRequest("/api/suspend-account", `{"id":"acct-1","suspend":false}`))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, domain.EventAccountReactivated, lastEventType(events))
+	})
+
+	t.Run("reactivate-account rejects an active account", func(t *testing.T) {
+		events := newMockEventStore()
+		seedAccount(t, events, false)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleReactivateAccount(cfg).ServeHTTP(rec, adminRequest("/api/reactivate-account", `{"account_id":"acct-1"}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("rename-account renames the account", func(t *testing.T) {
+		events := newMockEventStore()
+		seedAccount(t, events, false)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleRenameAccount(cfg).ServeHTTP(rec, adminRequest("/api/rename-account", `{"account_id":"acct-1","username":"bob"}`))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, domain.EventAccountRenamed, lastEventType(events))
+	})
+
+	t.Run("rename-account returns 409 when the username is taken", func(t *testing.T) {
+		events := newMockEventStore()
+		seedAccount(t, events, false)
+		store := newMockProjectionStore()
+		store.data[compositeKey("_admin", "username_lookup", "bob")] = projectors.UsernameLookupEntry{Username: "bob", AccountID: "acct-2"}
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: store}
+
+		rec := httptest.NewRecorder()
+		handleRenameAccount(cfg).ServeHTTP(rec, adminRequest("/api/rename-account", `{"account_id":"acct-1","username":"bob"}`))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("delete-account deletes the account", func(t *testing.T) {
+		events := newMockEventStore()
+		seedAccount(t, events, false)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleDeleteAccount(cfg).ServeHTTP(rec, adminRequest("/api/delete-account", `{"account_id":"acct-1"}`))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, domain.EventAccountDeleted, lastEventType(events))
+	})
+
+	t.Run("delete-account refuses to delete the caller's own account", func(t *testing.T) {
+		cfg := &RouteConfig{EventStore: newMockEventStore(), ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleDeleteAccount(cfg).ServeHTTP(rec, adminRequest("/api/delete-account", `{"account_id":"acct-admin"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandleCreatePat_Scopes(t *testing.T) {
+	seedAccount := func(t *testing.T, events *mockEventStore) {
+		t.Helper()
+		_, err := events.Append(context.Background(), domain.AdminRealmID, "account-acct-1", 0, []core.EventData{
+			{EventType: domain.EventAccountCreated, Data: domain.AccountCreated{AccountID: "acct-1", Username: "alice"}},
+		})
+		require.NoError(t, err)
+	}
+
+	request := func(body string, scopes *domain.PATScopes) *http.Request {
+		req := httptest.NewRequest("POST", "/api/create-pat", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), accountIDKey, "acct-1")
+		ctx = context.WithValue(ctx, rolesKey, map[string]string{"realm-1": "member"})
+		if scopes != nil {
+			ctx = context.WithValue(ctx, scopesKey, scopes)
+		}
+		return req.WithContext(ctx)
+	}
+
+	t.Run("records the requested scopes", func(t *testing.T) {
+		events := newMockEventStore()
+		seedAccount(t, events)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleCreatePat(cfg).ServeHTTP(rec, request(`{"account_id":"acct-1","label":"CI","scopes":{"realms":["realm-1"],"max_role":"viewer"}}`, nil))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		stream := events.streams[domain.AdminRealmID+"|account-acct-1"]
+		var created domain.PATCreated
+		require.NoError(t, json.Unmarshal(stream[len(stream)-1].Data, &created))
+		assert.Equal(t, &domain.PATScopes{Realms: []string{"realm-1"}, MaxRole: "viewer"}, created.Scopes)
+	})
+
+	t.Run("rejects an invalid max role", func(t *testing.T) {
+		events := newMockEventStore()
+		seedAccount(t, events)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleCreatePat(cfg).ServeHTTP(rec, request(`{"account_id":"acct-1","scopes":{"max_role":"root"}}`, nil))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("refuses callers authenticated with a scoped PAT", func(t *testing.T) {
+		events := newMockEventStore()
+		seedAccount(t, events)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleCreatePat(cfg).ServeHTTP(rec, request(`{"account_id":"acct-1"}`, &domain.PATScopes{MaxRole: "viewer"}))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), "scoped PATs cannot create PATs")
+	})
+}
+
+func TestHandleGetPats_Usage(t *testing.T) {
+	t.Run("includes recorded usage", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.data[compositeKey("_admin", "account_directory", "acct-1")] = projectors.AccountDirectoryEntry{
+			AccountID: "acct-1",
+			Username:  "alice",
+			PATs: []projectors.PATEntry{
+				{PATID: "pat-1", Label: "used", CreatedAt: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)},
+				{PATID: "pat-2", Label: "unused", CreatedAt: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+			},
+		}
+		store.data[compositeKey("_admin", PATUsageTable, "pat-1")] = PATUsage{
+			PATID: "pat-1", LastUsedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC), LastIP: "10.0.0.1", RequestCount: 41,
+		}
+		usage := NewPATUsageRecorder(store, time.Minute)
+		usage.Record("pat-1", "10.0.0.2", time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC))
+		cfg := &RouteConfig{ProjectionStore: store, AuthConfig: &AuthConfig{Usage: usage}}
+
+		req := httptest.NewRequest("GET", "/api/pats?account_id=acct-1", nil)
+		req = req.WithContext(context.WithValue(req.Context(), accountIDKey, "acct-1"))
+		rec := httptest.NewRecorder()
+		handleGetPats(cfg).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var pats []PatEntry
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&pats))
+		require.Len(t, pats, 2)
+		assert.Equal(t, "2026-03-02T08:00:00.000Z", pats[0].LastUsed)
+		assert.Equal(t, "10.0.0.2", pats[0].LastIP)
+		assert.Equal(t, int64(42), pats[0].RequestCount)
+		assert.Empty(t, pats[1].LastUsed)
+		assert.Zero(t, pats[1].RequestCount)
+	})
+}
+
+func TestHandleRevokeStalePats(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T, pats ...string) (*RouteConfig, *mockEventStore, *mockProjectionStore) {
+		t.Helper()
+		events := newMockEventStore()
+		store := newMockProjectionStore()
+		data := []core.EventData{
+			{EventType: domain.EventAccountCreated, Data: domain.AccountCreated{AccountID: "acct-1", Username: "alice"}},
+		}
+		entry := projectors.AccountDirectoryEntry{AccountID: "acct-1", Username: "alice"}
+		for _, patID := range pats {
+			data = append(data, core.EventData{EventType: domain.EventPATCreated, Data: domain.PATCreated{AccountID: "acct-1", PATID: patID, CreatedAt: created}})
+			entry.PATs = append(entry.PATs, projectors.PATEntry{PATID: patID, CreatedAt: created})
+		}
+		_, err := events.Append(context.Background(), domain.AdminRealmID, "account-acct-1", 0, data)
+		require.NoError(t, err)
+		store.data[compositeKey("_admin", "account_directory", "acct-1")] = entry
+		return &RouteConfig{EventStore: events, ProjectionStore: store}, events, store
+	}
+
+	usedAt := func(store *mockProjectionStore, patID string, at time.Time) {
+		store.data[compositeKey("_admin", PATUsageTable, patID)] = PATUsage{PATID: patID, LastUsedAt: at, RequestCount: 1}
+	}
+
+	revoke := func(cfg *RouteConfig, callerPATID string) (*httptest.ResponseRecorder, RevokeStalePatsResponse) {
+		body := `{"account_id":"acct-1","unused_since":"` + cutoff.Format(time.RFC3339) + `"}`
+		req := httptest.NewRequest("POST", "/api/revoke-stale-pats", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), accountIDKey, "acct-admin")
+		ctx = context.WithValue(ctx, rolesKey, map[string]string{"_admin": "admin"})
+		ctx = context.WithValue(ctx, patIDKey, callerPATID)
+		rec := httptest.NewRecorder()
+		handleRevokeStalePats(cfg).ServeHTTP(rec, req.WithContext(ctx))
+		var resp RevokeStalePatsResponse
+		_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+		return rec, resp
+	}
+
+	t.Run("revokes PATs unused since the cutoff", func(t *testing.T) {
+		cfg, _, store := setup(t, "pat-old", "pat-fresh", "pat-never")
+		usedAt(store, "pat-old", cutoff.Add(-time.Hour))
+		usedAt(store, "pat-fresh", cutoff.Add(time.Hour))
+
+		rec, resp := revoke(cfg, "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []string{"pat-old", "pat-never"}, resp.Revoked)
+	})
+
+	t.Run("keeps the most recently active PAT when all are stale", func(t *testing.T) {
+		cfg, _, store := setup(t, "pat-a", "pat-b")
+		usedAt(store, "pat-a", cutoff.Add(-48*time.Hour))
+		usedAt(store, "pat-b", cutoff.Add(-24*time.Hour))
+
+		_, resp := revoke(cfg, "")
+
+		assert.Equal(t, []string{"pat-a"}, resp.Revoked)
+	})
+
+	t.Run("never revokes the caller's PAT", func(t *testing.T) {
+		cfg, _, _ := setup(t, "pat-a", "pat-b")
+
+		_, resp := revoke(cfg, "pat-a")
+
+		assert.Equal(t, []string{"pat-b"}, resp.Revoked)
+	})
+
+	t.Run("requires a cutoff", func(t *testing.T) {
+		cfg, _, _ := setup(t, "pat-a")
+		req := httptest.NewRequest("POST", "/api/revoke-stale-pats", strings.NewReader(`{"account_id":"acct-1"}`))
+		rec := httptest.NewRecorder()
+
+		handleRevokeStalePats(cfg).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandleCreateServiceAccount(t *testing.T) {
+	setup := func(t *testing.T) (*RouteConfig, *mockEventStore) {
+		t.Helper()
+		events := newMockEventStore()
+		for _, id := range []string{"acct-1", "acct-2"} {
+			_, err := events.Append(context.Background(), domain.AdminRealmID, "account-"+id, 0, []core.EventData{
+				{EventType: domain.EventAccountCreated, Data: domain.AccountCreated{AccountID: id, Username: id}},
+			})
+			require.NoError(t, err)
+		}
+		store := newMockProjectionStore()
+		store.data[compositeKey("_admin", "realm_directory", "realm-1")] = projectors.RealmDirectoryEntry{
+			RealmID: "realm-1", Name: "Realm One", Status: "active",
+		}
+		return &RouteConfig{EventStore: events, ProjectionStore: store}, events
+	}
+
+	create := func(cfg *RouteConfig, body string, roles map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/create-service-account", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), accountIDKey, "acct-1")
+		ctx = context.WithValue(ctx, rolesKey, roles)
+		rec := httptest.NewRecorder()
+		handleCreateServiceAccount(cfg).ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	createdServiceAccount := func(t *testing.T, events *mockEventStore) domain.AccountCreated {
+		t.Helper()
+		for key, stream := range events.streams {
+			if key == domain.AdminRealmID+"|account-acct-1" || key == domain.AdminRealmID+"|account-acct-2" {
+				continue
+			}
+			var created domain.AccountCreated
+			require.NoError(t, json.Unmarshal(stream[0].Data, &created))
+			return created
+		}
+		t.Fatal("no service account was created")
+		return domain.AccountCreated{}
+	}
+
+	t.Run("realm admin creates a service account they own", func(t *testing.T) {
+		cfg, events := setup(t)
+
+		rec := create(cfg, `{"username":"ci-bot","realm_id":"realm-1"}`, map[string]string{"realm-1": "admin"})
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var resp CreateAccountResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.NotEmpty(t, resp.PAT)
+		created := createdServiceAccount(t, events)
+		assert.Equal(t, domain.AccountKindService, created.Kind)
+		assert.Equal(t, "acct-1", created.OwnerID)
+		assert.Equal(t, "realm-1", created.RealmID)
+	})
+
+	t.Run("realm member is forbidden", func(t *testing.T) {
+		cfg, _ := setup(t)
+
+		rec := create(cfg, `{"username":"ci-bot","realm_id":"realm-1"}`, map[string]string{"realm-1": "member"})
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("realm admin cannot assign another owner", func(t *testing.T) {
+		cfg, _ := setup(t)
+
+		rec := create(cfg, `{"username":"ci-bot","realm_id":"realm-1","owner_id":"acct-2"}`, map[string]string{"realm-1": "admin"})
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("system admin can assign another owner", func(t *testing.T) {
+		cfg, events := setup(t)
+
+		rec := create(cfg, `{"username":"ci-bot","realm_id":"realm-1","owner_id":"acct-2"}`, map[string]string{"_admin": "admin"})
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "acct-2", createdServiceAccount(t, events).OwnerID)
+	})
+
+	t.Run("rejects the owner role", func(t *testing.T) {
+		cfg, _ := setup(t)
+
+		rec := create(cfg, `{"username":"ci-bot","realm_id":"realm-1","role":"owner"}`, map[string]string{"realm-1": "owner"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestHandleGetAccounts_KindFilter(t *testing.T) {
+	store := newMockProjectionStore()
+	store.listData["account_directory"] = []json.RawMessage{
+		json.RawMessage(`{"account_id":"acct-1","username":"alice","status":"active","created_at":"2026-01-15T10:00:00Z"}`),
+		json.RawMessage(`{"account_id":"acct-2","username":"ci-bot","kind":"service","owner_id":"acct-1","status":"active","created_at":"2026-01-16T10:00:00Z"}`),
+	}
+	cfg := &RouteConfig{ProjectionStore: store}
+
+	list := func(query string) (*httptest.ResponseRecorder, []AccountListEntry) {
+		req := httptest.NewRequest("GET", "/api/accounts"+query, nil)
+		rec := httptest.NewRecorder()
+		handleGetAccounts(cfg).ServeHTTP(rec, req)
+		var accounts []AccountListEntry
+		_ = json.Unmarshal(rec.Body.Bytes(), &accounts)
+		return rec, accounts
+	}
+
+	t.Run("reports each account's kind", func(t *testing.T) {
+		_, accounts := list("")
+
+		require.Len(t, accounts, 2)
+		assert.Equal(t, domain.AccountKindHuman, accounts[0].Kind)
+		assert.Equal(t, domain.AccountKindService, accounts[1].Kind)
+		assert.Equal(t, "acct-1", accounts[1].OwnerID)
+	})
+
+	t.Run("lists only service accounts", func(t *testing.T) {
+		_, accounts := list("?kind=service")
+
+		require.Len(t, accounts, 1)
+		assert.Equal(t, "ci-bot", accounts[0].Username)
+	})
+
+	t.Run("lists only human accounts", func(t *testing.T) {
+		_, accounts := list("?kind=human")
+
+		require.Len(t, accounts, 1)
+		assert.Equal(t, "alice", accounts[0].Username)
+	})
+
+	t.Run("rejects an unknown kind", func(t *testing.T) {
+		rec, _ := list("?kind=robot")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,420 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+)
+
+// SecurityLogTable holds security-relevant actions that are not events:
+// logins, authentication failures and projection rebuilds. Like pat_usage it
+// is written directly, so it survives projection rebuilds.
+const SecurityLogTable = "security_log"
+
+// DefaultSecurityLogFlushInterval is how often recorded entries are written out.
+const DefaultSecurityLogFlushInterval = 5 * time.Second
+
+// maxPendingSecurityEntries bounds the entries buffered between flushes, so a
+// flood of failed requests cannot grow memory without limit.
+const maxPendingSecurityEntries = 10000
+
+// securityFailureWindow is how long repeated failures from one client IP are
+// collapsed into a single counted entry.
+const securityFailureWindow = time.Minute
+
+// The security_log table keeps entries for DefaultSecurityLogRetention and at
+// most maxSecurityLogRows of them, pruned every securityLogPruneInterval.
+const (
+	DefaultSecurityLogRetention = 90 * 24 * time.Hour
+	maxSecurityLogRows          = 100000
+	securityLogPruneInterval    = time.Hour
+)
+
+// Security log actions.
+const (
+	ActionLoginSucceeded       = "LoginSucceeded"
+	ActionLoginFailed          = "LoginFailed"
+	ActionAuthenticationFailed = "AuthenticationFailed"
+	ActionAuthorizationDenied  = "AuthorizationDenied"
+	ActionProjectionsRebuilt   = "ProjectionsRebuilt"
+)
+
+// SecurityLog buffers security log entries in memory and writes them to the
+// security_log table every flush interval. Failed logins and rejected
+// requests from one client IP are collapsed into one entry per
+// securityFailureWindow, so anonymous callers cannot write a row per request.
+// Entries older than the retention period are pruned. A nil log records
+// nothing.
+type SecurityLog struct {
+	store     core.ProjectionStore
+	interval  time.Duration
+	retention time.Duration
+	maxRows   int
+	now       func() time.Time
+
+	mu        sync.Mutex
+	pending   []projectors.AuditLogEntry
+	failures  map[string]*projectors.AuditLogEntry
+	seq       uint64
+	dropped   int
+	lastPrune time.Time
+}
+
+// NewSecurityLog creates a security log that flushes to store every interval.
+func NewSecurityLog(store core.ProjectionStore, interval time.Duration) *SecurityLog {
+	if interval <= 0 {
+		interval = DefaultSecurityLogFlushInterval
+	}
+	return &SecurityLog{
+		store:     store,
+		interval:  interval,
+		retention: DefaultSecurityLogRetention,
+		maxRows:   maxSecurityLogRows,
+		now:       time.Now,
+		failures:  make(map[string]*projectors.AuditLogEntry),
+	}
+}
+
+// Record buffers entry, assigning its ID and, when unset, its timestamp.
+func (l *SecurityLog) Record(entry projectors.AuditLogEntry) {
+	if l == nil {
+		return
+	}
+	entry = l.stamp(entry)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.reserveLocked() {
+		return
+	}
+	l.pending = append(l.pending, l.identifyLocked(entry))
+}
+
+// recordFailure buffers a failure entry, folding it into the open entry for
+// the same client IP, action and actor while its window lasts.
+func (l *SecurityLog) recordFailure(entry projectors.AuditLogEntry) {
+	if l == nil {
+		return
+	}
+	entry = l.stamp(entry)
+	key := entry.IP + "|" + entry.Action + "|" + entry.ActorID
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if open, ok := l.failures[key]; ok {
+		if entry.Timestamp.Sub(open.Timestamp) < securityFailureWindow {
+			open.Count++
+			return
+		}
+		l.pending = append(l.pending, *open)
+		delete(l.failures, key)
+	}
+	if !l.reserveLocked() {
+		return
+	}
+	entry.Count = 1
+	entry = l.identifyLocked(entry)
+	l.failures[key] = &entry
+}
+
+func (l *SecurityLog) stamp(entry projectors.AuditLogEntry) projectors.AuditLogEntry {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = l.now()
+	}
+	entry.Timestamp = entry.Timestamp.UTC()
+	return entry
+}
+
+// reserveLocked reports whether another entry fits in the buffer, counting it
+// as dropped when it does not.
+func (l *SecurityLog) reserveLocked() bool {
+	if len(l.pending)+len(l.failures) >= maxPendingSecurityEntries {
+		l.dropped++
+		return false
+	}
+	return true
+}
+
+func (l *SecurityLog) identifyLocked(entry projectors.AuditLogEntry) projectors.AuditLogEntry {
+	l.seq++
+	entry.ID = fmt.Sprintf("sec-%d-%d", entry.Timestamp.UnixNano(), l.seq)
+	return entry
+}
+
+// RecordRequest records action for the request r, taking the client IP from r
+// and the actor from its authenticated context.
+func (l *SecurityLog) RecordRequest(r *http.Request, action string, entry projectors.AuditLogEntry) {
+	if l == nil {
+		return
+	}
+	entry.Action = action
+	entry.IP = ClientIP(r)
+	if entry.ActorID == "" {
+		entry.ActorID, _ = core.ActorFromContext(r.Context())
+	}
+	l.Record(entry)
+}
+
+// RecordAuthFailure records a request rejected by authentication middleware
+// with the given status: 401 as a failed authentication, 403 as a denied
+// authorization.
+func (l *SecurityLog) RecordAuthFailure(r *http.Request, actorID string, status int, reason string) {
+	action := ActionAuthenticationFailed
+	if status == http.StatusForbidden {
+		action = ActionAuthorizationDenied
+	}
+	l.recordFailedRequest(r, action, projectors.AuditLogEntry{
+		ActorID: actorID,
+		Detail:  fmt.Sprintf("path=%s reason=%q", r.URL.Path, reason),
+	})
+}
+
+// recordFailedRequest is RecordRequest for failures, which are collapsed per
+// client IP. A collapsed entry keeps the detail of its first request.
+func (l *SecurityLog) recordFailedRequest(r *http.Request, action string, entry projectors.AuditLogEntry) {
+	if l == nil {
+		return
+	}
+	entry.Action = action
+	entry.IP = ClientIP(r)
+	if entry.ActorID == "" {
+		entry.ActorID, _ = core.ActorFromContext(r.Context())
+	}
+	l.recordFailure(entry)
+}
+
+// recordLoginSuccess records a sign-in to the UI by the given method.
+func recordLoginSuccess(r *http.Request, cfg *RouteConfig, accountID, patID, method string) {
+	cfg.AuthConfig.SecurityLog.RecordRequest(r, ActionLoginSucceeded, projectors.AuditLogEntry{
+		ActorID:   accountID,
+		TargetID:  patID,
+		AccountID: accountID,
+		Detail:    "method=" + method,
+	})
+}
+
+// recordLoginFailure records a rejected sign-in. accountID is empty when the
+// credentials did not identify an account.
+func recordLoginFailure(r *http.Request, cfg *RouteConfig, accountID, method, reason string) {
+	cfg.AuthConfig.SecurityLog.recordFailedRequest(r, ActionLoginFailed, projectors.AuditLogEntry{
+		ActorID:   accountID,
+		AccountID: accountID,
+		Detail:    fmt.Sprintf("method=%s reason=%q", method, reason),
+	})
+}
+
+// Flush writes all pending entries to the store, including collapsed failures
+// whose window has closed, and prunes the table once per prune interval.
+// Entries that fail to write are kept for the next flush.
+func (l *SecurityLog) Flush(ctx context.Context) error {
+	return l.flush(ctx, false)
+}
+
+func (l *SecurityLog) flush(ctx context.Context, all bool) error {
+	if l == nil {
+		return nil
+	}
+	now := l.now()
+	l.mu.Lock()
+	for key, open := range l.failures {
+		if all || now.Sub(open.Timestamp) >= securityFailureWindow {
+			l.pending = append(l.pending, *open)
+			delete(l.failures, key)
+		}
+	}
+	prune := now.Sub(l.lastPrune) >= securityLogPruneInterval
+	if prune {
+		l.lastPrune = now
+	}
+	pending := l.pending
+	dropped := l.dropped
+	l.pending = nil
+	l.dropped = 0
+	l.mu.Unlock()
+
+	if dropped > 0 {
+		log.Printf("security log: dropped %d entries while the buffer was full", dropped)
+	}
+
+	var failed []projectors.AuditLogEntry
+	var errs []error
+	for _, entry := range pending {
+		if err := l.store.Put(ctx, domain.AdminRealmID, SecurityLogTable, entry.ID, entry); err != nil {
+			errs = append(errs, fmt.Errorf("flush security log entry %s: %w", entry.ID, err))
+			failed = append(failed, entry)
+		}
+	}
+	if len(failed) > 0 {
+		l.mu.Lock()
+		l.pending = append(failed, l.pending...)
+		l.mu.Unlock()
+	}
+	if prune {
+		if err := l.prune(ctx, now); err != nil {
+			errs = append(errs, fmt.Errorf("prune security log: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// prune deletes entries older than the retention period and, beyond the
+// newest maxRows, the oldest of the rest.
+func (l *SecurityLog) prune(ctx context.Context, now time.Time) error {
+	raws, err := l.store.List(ctx, domain.AdminRealmID, SecurityLogTable)
+	if err != nil {
+		return err
+	}
+	entries := decodeAuditEntries(raws)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+	cutoff := now.Add(-l.retention)
+	for i, entry := range entries {
+		if i < l.maxRows && !entry.Timestamp.Before(cutoff) {
+			continue
+		}
+		if err := l.store.Delete(ctx, domain.AdminRealmID, SecurityLogTable, entry.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run flushes pending entries every interval until ctx is cancelled, then
+// flushes once more.
+func (l *SecurityLog) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.Flush(ctx); err != nil {
+				log.Printf("security log: %v", err)
+			}
+		case <-ctx.Done():
+			if err := l.flush(context.Background(), true); err != nil {
+				log.Printf("security log: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// Entries returns every security log entry, including those not yet flushed
+// and collapsed failures whose window is still open.
+func (l *SecurityLog) Entries(ctx context.Context) ([]projectors.AuditLogEntry, error) {
+	if l == nil {
+		return nil, nil
+	}
+	raws, err := l.store.List(ctx, domain.AdminRealmID, SecurityLogTable)
+	if err != nil {
+		return nil, err
+	}
+	entries := decodeAuditEntries(raws)
+	l.mu.Lock()
+	entries = append(entries, l.pending...)
+	for _, open := range l.failures {
+		entries = append(entries, *open)
+	}
+	l.mu.Unlock()
+	return entries, nil
+}
+
+// DefaultAuditLimit and MaxAuditLimit bound the entries GET /api/audit returns.
+const (
+	DefaultAuditLimit = 100
+	MaxAuditLimit     = 1000
+)
+
+// AuditFilter selects audit log entries. Zero fields match everything.
+type AuditFilter struct {
+	ActorID string
+	// Target matches an entry's target, account or realm.
+	Target string
+	// Action matches case-insensitively.
+	Action string
+	Since  time.Time
+	Until  time.Time
+	Limit  int
+}
+
+func (f AuditFilter) matches(entry projectors.AuditLogEntry) bool {
+	if f.ActorID != "" && entry.ActorID != f.ActorID {
+		return false
+	}
+	if f.Target != "" && !entry.Involves(f.Target) {
+		return false
+	}
+	if f.Action != "" && !strings.EqualFold(entry.Action, f.Action) {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.Timestamp.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// QueryAuditLog merges the audit_log projection with the security log and
+// returns the entries matching filter, newest first.
+func QueryAuditLog(ctx context.Context, store core.ProjectionStore, securityLog *SecurityLog, filter AuditFilter) ([]projectors.AuditLogEntry, error) {
+	raws, err := core.ListRef(ctx, store, domain.AdminRealmID, projectors.AuditLogTable)
+	if err != nil {
+		return nil, fmt.Errorf("list audit log: %w", err)
+	}
+	projected := decodeAuditEntries(raws)
+	security, err := securityLog.Entries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list security log: %w", err)
+	}
+
+	entries := make([]projectors.AuditLogEntry, 0, len(projected)+len(security))
+	for _, entry := range append(projected, security...) {
+		if filter.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return entries[i].Timestamp.After(entries[j].Timestamp)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultAuditLimit
+	}
+	if limit > MaxAuditLimit {
+		limit = MaxAuditLimit
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func decodeAuditEntries(raws []json.RawMessage) []projectors.AuditLogEntry {
+	entries := make([]projectors.AuditLogEntry, 0, len(raws))
+	for _, raw := range raws {
+		var entry projectors.AuditLogEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+)
+
+// AuditEntry is the JSON response for an entry in GET /api/audit.
+type AuditEntry struct {
+	projectors.AuditLogEntry
+	ActorUsername string `json:"actor_username,omitempty"`
+}
+
+// RegisterAuditAPIRoutes registers the audit log query route.
+func RegisterAuditAPIRoutes(mux *http.ServeMux, cfg *RouteConfig) {
+	authMiddleware := AuthMiddleware(cfg.AuthConfig, cfg.ProjectionStore)
+	requireAdmin := RequireAdminMiddleware()
+
+	mux.Handle("GET /api/audit", authMiddleware(requireAdmin(http.HandlerFunc(handleGetAudit(cfg)))))
+}
+
+// handleGetAudit lists audit entries newest first. The actor and target
+// parameters accept an account ID or a username; since and until are RFC 3339
+// times bounding the entries' timestamps.
+func handleGetAudit(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		filter := AuditFilter{
+			ActorID: resolveAccountRef(r.Context(), cfg, q.Get("actor")),
+			Target:  resolveAccountRef(r.Context(), cfg, q.Get("target")),
+			Action:  q.Get("action"),
+		}
+		for _, bound := range []struct {
+			name string
+			dest *time.Time
+		}{{"since", &filter.Since}, {"until", &filter.Until}} {
+			value := q.Get(bound.name)
+			if value == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, bound.name+" must be an RFC 3339 time")
+				return
+			}
+			*bound.dest = t
+		}
+		if value := q.Get("limit"); value != "" {
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit <= 0 {
+				writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+				return
+			}
+			filter.Limit = limit
+		}
+
+		entries, err := QueryAuditLog(r.Context(), cfg.ProjectionStore, cfg.AuthConfig.SecurityLog, filter)
+		if err != nil {
+			log.Printf("handleGetAudit: %v", err)
+			writeError(w, http.StatusInternalServerError, "failed to read audit log")
+			return
+		}
+
+		usernames := accountUsernames(r.Context(), cfg)
+		resp := make([]AuditEntry, 0, len(entries))
+		for _, entry := range entries {
+			resp = append(resp, AuditEntry{AuditLogEntry: entry, ActorUsername: usernames[entry.ActorID]})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("handleGetAudit: failed to encode response: %v", err)
+		}
+	}
+}
+
+// resolveAccountRef returns the account ID for ref when it is a username, and
+// ref unchanged otherwise.
+func resolveAccountRef(ctx context.Context, cfg *RouteConfig, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	var lookup projectors.UsernameLookupEntry
+	if err := cfg.ProjectionStore.Get(ctx, domain.AdminRealmID, "username_lookup", ref, &lookup); err == nil {
+		return lookup.AccountID
+	}
+	return ref
+}
+
+// accountUsernames maps account IDs to usernames. A failed lookup leaves
+// entries without usernames rather than failing the request.
+func accountUsernames(ctx context.Context, cfg *RouteConfig) map[string]string {
+	usernames := make(map[string]string)
+	raws, err := cfg.ProjectionStore.List(ctx, domain.AdminRealmID, "account_directory")
+	if err != nil {
+		log.Printf("accountUsernames: failed to list accounts: %v", err)
+		return usernames
+	}
+	for _, raw := range raws {
+		var account projectors.AccountDirectoryEntry
+		if err := json.Unmarshal(raw, &account); err != nil {
+			continue
+		}
+		usernames[account.AccountID] = account.Username
+	}
+	return usernames
+}
@@ -0,0 +1,270 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	seed := func(t *testing.T, store *mockProjectionStore, entries ...projectors.AuditLogEntry) {
+		t.Helper()
+		for _, entry := range entries {
+			raw, err := json.Marshal(entry)
+			require.NoError(t, err)
+			store.listData[projectors.AuditLogTable.Name] = append(store.listData[projectors.AuditLogTable.Name], raw)
+		}
+	}
+
+	setup := func(t *testing.T) (*http.ServeMux, *mockProjectionStore, *SecurityLog) {
+		t.Helper()
+		store := newMockProjectionStoreWithAccount()
+		store.listData["account_directory"] = []json.RawMessage{
+			json.RawMessage(`{"account_id":"acct-admin","username":"root"}`),
+		}
+		store.data[compositeKey("_admin", "username_lookup", "root")] = projectors.UsernameLookupEntry{Username: "root", AccountID: "acct-admin"}
+		seed(t, store,
+			projectors.AuditLogEntry{ID: "evt-1", Timestamp: t0, Action: "AccountCreated", ActorID: "acct-admin", TargetID: "acct-1", AccountID: "acct-1"},
+			projectors.AuditLogEntry{ID: "evt-2", Timestamp: t0.Add(time.Hour), Action: "RoleAssigned", ActorID: "acct-admin", TargetID: "acct-1", AccountID: "acct-1", RealmID: "realm-1"},
+			projectors.AuditLogEntry{ID: "evt-3", Timestamp: t0.Add(2 * time.Hour), Action: "PATRevoked", ActorID: "acct-1", TargetID: "pat-9", AccountID: "acct-1"},
+		)
+
+		authCfg := DefaultAuthConfig()
+		authCfg.SecurityLog = NewSecurityLog(store, time.Minute)
+		mux := http.NewServeMux()
+		RegisterAuditAPIRoutes(mux, &RouteConfig{AuthConfig: authCfg, ProjectionStore: store})
+		return mux, store, authCfg.SecurityLog
+	}
+
+	query := func(t *testing.T, mux *http.ServeMux, store *mockProjectionStore, params string) []AuditEntry {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/api/audit?"+params, nil)
+		req.Header.Set("Authorization", "Bearer "+store.validToken)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var entries []AuditEntry
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+		return entries
+	}
+
+	ids := func(entries []AuditEntry) []string {
+		out := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			out = append(out, entry.ID)
+		}
+		return out
+	}
+
+	t.Run("lists entries newest first with actor usernames", func(t *testing.T) {
+		mux, store, _ := setup(t)
+
+		entries := query(t, mux, store, "")
+
+		assert.Equal(t, []string{"evt-3", "evt-2", "evt-1"}, ids(entries))
+		assert.Equal(t, "root", entries[1].ActorUsername)
+	})
+
+	t.Run("filters by actor username", func(t *testing.T) {
+		mux, store, _ := setup(t)
+
+		entries := query(t, mux, store, "actor=root")
+
+		assert.Equal(t, []string{"evt-2", "evt-1"}, ids(entries))
+	})
+
+	t.Run("filters by target, action and time range", func(t *testing.T) {
+		mux, store, _ := setup(t)
+
+		assert.Equal(t, []string{"evt-2"}, ids(query(t, mux, store, "target=realm-1")))
+		assert.Equal(t, []string{"evt-3"}, ids(query(t, mux, store, "action=patrevoked")))
+		since := t0.Add(30 * time.Minute).Format(time.RFC3339)
+		until := t0.Add(2 * time.Hour).Format(time.RFC3339)
+		assert.Equal(t, []string{"evt-2"}, ids(query(t, mux, store, "since="+since+"&until="+until)))
+	})
+
+	t.Run("includes security log entries", func(t *testing.T) {
+		mux, store, securityLog := setup(t)
+		securityLog.Record(projectors.AuditLogEntry{Action: ActionLoginFailed, Timestamp: t0.Add(3 * time.Hour), IP: "10.0.0.1"})
+
+		entries := query(t, mux, store, "limit=1")
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, ActionLoginFailed, entries[0].Action)
+		assert.Equal(t, "10.0.0.1", entries[0].IP)
+	})
+
+	t.Run("rejects a malformed time", func(t *testing.T) {
+		mux, store, _ := setup(t)
+		req := httptest.NewRequest("GET", "/api/audit?since=yesterday", nil)
+		req.Header.Set("Authorization", "Bearer "+store.validToken)
+		rec := httptest.NewRecorder()
+
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("requires an admin", func(t *testing.T) {
+		mux, store, _ := setup(t)
+		store.data[compositeKey("_admin", "account_auth", "account-test-123")] = projectors.AccountAuthEntry{
+			AccountID: "account-test-123",
+			Username:  "testuser",
+			Status:    "active",
+			Roles:     map[string]string{"realm-1": "admin"},
+		}
+		req := httptest.NewRequest("GET", "/api/audit", nil)
+		req.Header.Set("Authorization", "Bearer "+store.validToken)
+		rec := httptest.NewRecorder()
+
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("records rejected Bearer tokens", func(t *testing.T) {
+		mux, _, securityLog := setup(t)
+		req := httptest.NewRequest("GET", "/api/audit", nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		rec := httptest.NewRecorder()
+
+		mux.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		entries, err := securityLog.Entries(context.Background())
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, ActionAuthenticationFailed, entries[0].Action)
+		assert.Contains(t, entries[0].Detail, "path=/api/audit")
+	})
+
+	t.Run("collapses a burst of rejected Bearer tokens into one entry per IP", func(t *testing.T) {
+		mux, store, securityLog := setup(t)
+		now := t0
+		securityLog.now = func() time.Time { return now }
+		for range 50 {
+			req := httptest.NewRequest("GET", "/api/audit", nil)
+			req.RemoteAddr = "203.0.113.7:4000"
+			req.Header.Set("Authorization", "Bearer not-a-token")
+			mux.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		now = now.Add(securityFailureWindow)
+		require.NoError(t, securityLog.Flush(context.Background()))
+
+		var saved []projectors.AuditLogEntry
+		for key, value := range store.data {
+			if strings.HasPrefix(key, compositeKey("_admin", SecurityLogTable, "")) {
+				saved = append(saved, value.(projectors.AuditLogEntry))
+			}
+		}
+		require.Len(t, saved, 1)
+		assert.Equal(t, ActionAuthenticationFailed, saved[0].Action)
+		assert.Equal(t, "203.0.113.7", saved[0].IP)
+		assert.Equal(t, 50, saved[0].Count)
+	})
+
+	t.Run("keeps failures from different IPs apart", func(t *testing.T) {
+		mux, _, securityLog := setup(t)
+		for _, addr := range []string{"203.0.113.7:4000", "203.0.113.8:4000", "203.0.113.7:4001"} {
+			req := httptest.NewRequest("GET", "/api/audit", nil)
+			req.RemoteAddr = addr
+			req.Header.Set("Authorization", "Bearer not-a-token")
+			mux.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		entries, err := securityLog.Entries(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+	})
+
+	t.Run("records failed UI logins", func(t *testing.T) {
+		store := newMockProjectionStore()
+		authCfg := DefaultAuthConfig()
+		authCfg.SecurityLog = NewSecurityLog(store, time.Minute)
+		cfg := &RouteConfig{AuthConfig: authCfg, ProjectionStore: store}
+		req := httptest.NewRequest("POST", "/api/ui/login", strings.NewReader(`{"pat":"bogus"}`))
+		rec := httptest.NewRecorder()
+
+		handleUILogin(cfg).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		entries, err := authCfg.SecurityLog.Entries(context.Background())
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, ActionLoginFailed, entries[0].Action)
+		assert.Contains(t, entries[0].Detail, "method=pat")
+	})
+
+	t.Run("flushes the security log to its table", func(t *testing.T) {
+		store := newMockProjectionStore()
+		securityLog := NewSecurityLog(store, time.Minute)
+		securityLog.Record(projectors.AuditLogEntry{Action: ActionProjectionsRebuilt, ActorID: "acct-admin", Timestamp: t0})
+
+		require.NoError(t, securityLog.Flush(context.Background()))
+
+		require.Len(t, store.data, 1)
+		for _, value := range store.data {
+			assert.Equal(t, ActionProjectionsRebuilt, value.(projectors.AuditLogEntry).Action)
+		}
+	})
+
+	t.Run("prunes entries past the retention period", func(t *testing.T) {
+		store := newMockProjectionStore()
+		securityLog := NewSecurityLog(store, time.Minute)
+		securityLog.now = func() time.Time { return t0 }
+		for _, entry := range []projectors.AuditLogEntry{
+			{ID: "sec-old", Timestamp: t0.Add(-DefaultSecurityLogRetention - time.Hour), Action: ActionLoginFailed},
+			{ID: "sec-new", Timestamp: t0.Add(-time.Hour), Action: ActionLoginFailed},
+		} {
+			raw, err := json.Marshal(entry)
+			require.NoError(t, err)
+			store.listData[SecurityLogTable] = append(store.listData[SecurityLogTable], raw)
+			store.data[compositeKey("_admin", SecurityLogTable, entry.ID)] = entry
+		}
+
+		require.NoError(t, securityLog.Flush(context.Background()))
+
+		assert.NotContains(t, store.data, compositeKey("_admin", SecurityLogTable, "sec-old"))
+		assert.Contains(t, store.data, compositeKey("_admin", SecurityLogTable, "sec-new"))
+	})
+
+	t.Run("prunes the oldest entries beyond the row cap", func(t *testing.T) {
+		store := newMockProjectionStore()
+		securityLog := NewSecurityLog(store, time.Minute)
+		securityLog.now = func() time.Time { return t0 }
+		securityLog.maxRows = 2
+		for i := range 3 {
+			entry := projectors.AuditLogEntry{ID: fmt.Sprintf("sec-%d", i), Timestamp: t0.Add(time.Duration(i) * time.Minute), Action: ActionLoginFailed}
+			raw, err := json.Marshal(entry)
+			require.NoError(t, err)
+			store.listData[SecurityLogTable] = append(store.listData[SecurityLogTable], raw)
+			store.data[compositeKey("_admin", SecurityLogTable, entry.ID)] = entry
+		}
+
+		require.NoError(t, securityLog.Flush(context.Background()))
+
+		assert.NotContains(t, store.data, compositeKey("_admin", SecurityLogTable, "sec-0"))
+		assert.Contains(t, store.data, compositeKey("_admin", SecurityLogTable, "sec-2"))
+	})
+
+	t.Run("a nil security log records nothing", func(t *testing.T) {
+		var securityLog *SecurityLog
+
+		securityLog.Record(projectors.AuditLogEntry{Action: ActionLoginFailed})
+
+		assert.NoError(t, securityLog.Flush(context.Background()))
+	})
+}
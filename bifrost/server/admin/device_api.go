@@ -0,0 +1,249 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// DeviceCodeRequest is the request body for POST /api/ui/device/code.
+type DeviceCodeRequest struct {
+	Label string `json:"label"`
+}
+
+// DeviceCodeResponse is the response for POST /api/ui/device/code.
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceTokenRequest is the request body for POST /api/ui/device/token.
+type DeviceTokenRequest struct {
+	DeviceCode string `json:"device_code"`
+}
+
+// DeviceTokenResponse is the response for an approved POST /api/ui/device/token.
+type DeviceTokenResponse struct {
+	Token string `json:"token"`
+	PATID string `json:"pat_id"`
+}
+
+// DeviceLoginInfo is the response for GET /api/ui/device.
+type DeviceLoginInfo struct {
+	UserCode  string `json:"user_code"`
+	Label     string `json:"label"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// DeviceUserCodeRequest is the request body for POST /api/ui/device/approve
+// and /api/ui/device/deny.
+type DeviceUserCodeRequest struct {
+	UserCode string `json:"user_code"`
+}
+
+const (
+	defaultDeviceLabel = "bf login"
+	maxDeviceLabelLen  = 100
+)
+
+// RegisterDeviceAPIRoutes registers the device authorization routes used by
+// bf login --web. The code and token endpoints are unauthenticated; the CLI
+// holds only its device code until a signed-in user approves the user code.
+// Starting a login is throttled per client IP; see DeviceAuthorizations.
+func RegisterDeviceAPIRoutes(mux *http.ServeMux, cfg *RouteConfig) {
+	authMiddleware := AuthMiddleware(cfg.AuthConfig, cfg.ProjectionStore)
+
+	mux.HandleFunc("POST /api/ui/device/code", handleDeviceCode(cfg))
+	mux.HandleFunc("POST /api/ui/device/token", handleDeviceToken(cfg))
+	mux.Handle("GET /api/ui/device", authMiddleware(http.HandlerFunc(handleGetDeviceLogin(cfg))))
+	mux.Handle("POST /api/ui/device/approve", authMiddleware(http.HandlerFunc(handleApproveDeviceLogin(cfg))))
+	mux.Handle("POST /api/ui/device/deny", authMiddleware(http.HandlerFunc(handleDenyDeviceLogin(cfg))))
+}
+
+func handleDeviceCode(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DeviceCodeRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid JSON")
+				return
+			}
+		}
+		label := strings.TrimSpace(req.Label)
+		if label == "" {
+			label = defaultDeviceLabel
+		}
+		if len(label) > maxDeviceLabelLen {
+			label = label[:maxDeviceLabelLen]
+		}
+
+		deviceCode, userCode, err := cfg.Devices.Start(label, ClientIP(r))
+		switch {
+		case errors.Is(err, errDeviceLoginThrottled):
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(cfg.Devices.StartInterval().Seconds()))))
+			writeError(w, http.StatusTooManyRequests, err.Error())
+			return
+		case errors.Is(err, errTooManyDeviceLogins):
+			writeError(w, http.StatusTooManyRequests, err.Error())
+			return
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, "failed to start device login")
+			return
+		}
+
+		verificationURI := requestOrigin(r) + UIPrefix + "/device"
+		resp := DeviceCodeResponse{
+			DeviceCode:              deviceCode,
+			UserCode:                userCode,
+			VerificationURI:         verificationURI,
+			VerificationURIComplete: verificationURI + "?code=" + userCode,
+			ExpiresIn:               int(cfg.Devices.TTL().Seconds()),
+			Interval:                int(cfg.Devices.Interval().Seconds()),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("handleDeviceCode: failed to encode response: %v", err)
+		}
+	}
+}
+
+// handleDeviceToken answers CLI polls. Until the login is approved it
+// returns 400 with an RFC 8628 error code: authorization_pending,
+// slow_down, expired_token or access_denied. Once approved it returns a PAT
+// that expires after DeviceTokenTTL.
+func handleDeviceToken(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DeviceTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		if req.DeviceCode == "" {
+			writeError(w, http.StatusBadRequest, "device_code is required")
+			return
+		}
+
+		accountID, label, err := cfg.Devices.Poll(req.DeviceCode)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ttl := cfg.DeviceTokenTTL
+		if ttl <= 0 {
+			ttl = DefaultDeviceTokenTTL
+		}
+		expiresAt := time.Now().Add(ttl)
+		pat, err := domain.HandleCreatePAT(r.Context(), domain.CreatePAT{
+			AccountID: accountID,
+			Label:     label,
+			ExpiresAt: &expiresAt,
+		}, cfg.EventStore)
+		if err != nil {
+			handleDomainError(w, err)
+			return
+		}
+		// Let the CLI use the PAT on its very next request.
+		if cfg.ProjectionEngine != nil {
+			cfg.ProjectionEngine.RunCatchUpOnce(r.Context())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(DeviceTokenResponse{Token: pat.RawToken, PATID: pat.PATID}); err != nil {
+			log.Printf("handleDeviceToken: failed to encode response: %v", err)
+		}
+	}
+}
+
+func handleGetDeviceLogin(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userCode := r.URL.Query().Get("user_code")
+		label, expiresAt, err := cfg.Devices.Lookup(userCode)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		resp := DeviceLoginInfo{
+			UserCode:  formatUserCode(normalizeUserCode(userCode)),
+			Label:     label,
+			ExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("handleGetDeviceLogin: failed to encode response: %v", err)
+		}
+	}
+}
+
+func handleApproveDeviceLogin(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DeviceUserCodeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+
+		accountID, ok := AccountIDFromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		// Approving mints an unscoped PAT, which a scoped session must not do.
+		if _, scoped := PATScopesFromContext(r.Context()); scoped {
+			writeError(w, http.StatusForbidden, "scoped PATs cannot approve device logins")
+			return
+		}
+
+		if err := cfg.Devices.Approve(req.UserCode, accountID); err != nil {
+			writeDeviceLoginError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleDenyDeviceLogin(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DeviceUserCodeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+
+		if err := cfg.Devices.Deny(req.UserCode); err != nil {
+			writeDeviceLoginError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeDeviceLoginError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errUserCodeNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+// requestOrigin returns the scheme and host the client used to reach the
+// server, honouring X-Forwarded-Proto from a TLS-terminating proxy.
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
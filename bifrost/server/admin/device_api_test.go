@@ -0,0 +1,175 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceAPI(t *testing.T) {
+	setupWithTTL := func(t *testing.T, tokenTTL time.Duration) (*http.ServeMux, *RouteConfig, *mockEventStore, string) {
+		t.Helper()
+		store := newMockProjectionStoreWithAccount()
+		events := newMockEventStore()
+		_, err := events.Append(context.Background(), domain.AdminRealmID, "account-account-test-123", 0, []core.EventData{
+			{EventType: domain.EventAccountCreated, Data: domain.AccountCreated{AccountID: "account-test-123", Username: "testuser"}},
+		})
+		require.NoError(t, err)
+
+		authCfg := DefaultAuthConfig()
+		authCfg.SigningKey = make([]byte, 32)
+		_, err = rand.Read(authCfg.SigningKey)
+		require.NoError(t, err)
+
+		devices := NewDeviceAuthorizations(time.Minute, time.Second)
+		cfg := &RouteConfig{AuthConfig: authCfg, ProjectionStore: store, EventStore: events, Devices: devices, DeviceTokenTTL: tokenTTL}
+		mux := http.NewServeMux()
+		_, err = RegisterRoutes(mux, cfg)
+		require.NoError(t, err)
+		return mux, cfg, events, store.validToken
+	}
+	setup := func(t *testing.T) (*http.ServeMux, *RouteConfig, *mockEventStore, string) {
+		t.Helper()
+		return setupWithTTL(t, 0)
+	}
+
+	do := func(mux *http.ServeMux, method, target, body, pat string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if pat != "" {
+			req.Header.Set("Authorization", "Bearer "+pat)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	start := func(t *testing.T, mux *http.ServeMux) DeviceCodeResponse {
+		t.Helper()
+		rec := do(mux, "POST", "/api/ui/device/code", `{"label":"bf login on laptop"}`, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp DeviceCodeResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+
+	t.Run("issues codes pointing at the UI approval page", func(t *testing.T) {
+		mux, _, _, _ := setup(t)
+
+		resp := start(t, mux)
+
+		assert.NotEmpty(t, resp.DeviceCode)
+		assert.Equal(t, "http://example.com/ui/device", resp.VerificationURI)
+		assert.Equal(t, resp.VerificationURI+"?code="+resp.UserCode, resp.VerificationURIComplete)
+		assert.Equal(t, 60, resp.ExpiresIn)
+		assert.Equal(t, 1, resp.Interval)
+	})
+
+	t.Run("token polls are pending until approved", func(t *testing.T) {
+		mux, _, _, _ := setup(t)
+		resp := start(t, mux)
+
+		rec := do(mux, "POST", "/api/ui/device/token", `{"device_code":"`+resp.DeviceCode+`"}`, "")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "authorization_pending")
+	})
+
+	t.Run("approval mints a labelled PAT for the approving account", func(t *testing.T) {
+		mux, _, events, pat := setup(t)
+		resp := start(t, mux)
+
+		info := do(mux, "GET", "/api/ui/device?user_code="+resp.UserCode, "", pat)
+		require.Equal(t, http.StatusOK, info.Code, info.Body.String())
+		assert.Contains(t, info.Body.String(), "bf login on laptop")
+
+		approve := do(mux, "POST", "/api/ui/device/approve", `{"user_code":"`+resp.UserCode+`"}`, pat)
+		require.Equal(t, http.StatusNoContent, approve.Code, approve.Body.String())
+
+		rec := do(mux, "POST", "/api/ui/device/token", `{"device_code":"`+resp.DeviceCode+`"}`, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var token DeviceTokenResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &token))
+		assert.NotEmpty(t, token.Token)
+
+		var created domain.PATCreated
+		for _, evt := range events.streams[domain.AdminRealmID+"|account-account-test-123"] {
+			if evt.EventType == domain.EventPATCreated {
+				require.NoError(t, json.Unmarshal(evt.Data, &created))
+			}
+		}
+		assert.Equal(t, token.PATID, created.PATID)
+		assert.Equal(t, "bf login on laptop", created.Label)
+		require.NotNil(t, created.ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(DefaultDeviceTokenTTL), *created.ExpiresAt, time.Minute)
+
+		again := do(mux, "POST", "/api/ui/device/token", `{"device_code":"`+resp.DeviceCode+`"}`, "")
+		assert.Contains(t, again.Body.String(), "expired_token")
+	})
+
+	t.Run("approved PATs expire after the configured TTL", func(t *testing.T) {
+		mux, _, events, pat := setupWithTTL(t, 24*time.Hour)
+		resp := start(t, mux)
+		approve := do(mux, "POST", "/api/ui/device/approve", `{"user_code":"`+resp.UserCode+`"}`, pat)
+		require.Equal(t, http.StatusNoContent, approve.Code, approve.Body.String())
+
+		rec := do(mux, "POST", "/api/ui/device/token", `{"device_code":"`+resp.DeviceCode+`"}`, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var created domain.PATCreated
+		for _, evt := range events.streams[domain.AdminRealmID+"|account-account-test-123"] {
+			if evt.EventType == domain.EventPATCreated {
+				require.NoError(t, json.Unmarshal(evt.Data, &created))
+			}
+		}
+		require.NotNil(t, created.ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), *created.ExpiresAt, time.Minute)
+	})
+
+	t.Run("throttles codes requested from one client", func(t *testing.T) {
+		mux, _, _, _ := setup(t)
+		start(t, mux)
+
+		rec := do(mux, "POST", "/api/ui/device/code", `{}`, "")
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("denial is reported to the CLI", func(t *testing.T) {
+		mux, _, _, pat := setup(t)
+		resp := start(t, mux)
+
+		deny := do(mux, "POST", "/api/ui/device/deny", `{"user_code":"`+resp.UserCode+`"}`, pat)
+		require.Equal(t, http.StatusNoContent, deny.Code)
+
+		rec := do(mux, "POST", "/api/ui/device/token", `{"device_code":"`+resp.DeviceCode+`"}`, "")
+		assert.Contains(t, rec.Body.String(), "access_denied")
+	})
+
+	t.Run("approval requires a signed-in user", func(t *testing.T) {
+		mux, _, _, _ := setup(t)
+		resp := start(t, mux)
+
+		rec := do(mux, "POST", "/api/ui/device/approve", `{"user_code":"`+resp.UserCode+`"}`, "")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("unknown user codes return 404", func(t *testing.T) {
+		mux, _, _, pat := setup(t)
+
+		rec := do(mux, "POST", "/api/ui/device/approve", `{"user_code":"BCDF-GHJK"}`, pat)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,294 @@
+package admin
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDeviceCodeTTL is how long a device login waits for approval.
+	DefaultDeviceCodeTTL = 10 * time.Minute
+	// DefaultDevicePollInterval is the minimum time between token polls.
+	DefaultDevicePollInterval = 5 * time.Second
+	// DefaultDeviceTokenTTL is the lifetime of the PAT issued to
+	// "bf login --web".
+	DefaultDeviceTokenTTL = 30 * 24 * time.Hour
+
+	// maxPendingDevices caps pending logins across all clients, and
+	// maxPendingDevicesPerClient those started from one client IP.
+	maxPendingDevices          = 1000
+	maxPendingDevicesPerClient = 5
+	// deviceStartInterval is the minimum time between logins started from
+	// one client IP.
+	deviceStartInterval = 2 * time.Second
+)
+
+// Device token errors, named after the RFC 8628 error codes the token
+// endpoint returns.
+var (
+	errAuthorizationPending = errors.New("authorization_pending")
+	errSlowDown             = errors.New("slow_down")
+	errExpiredToken         = errors.New("expired_token")
+	errAccessDenied         = errors.New("access_denied")
+	errUserCodeNotFound     = errors.New("device login not found or expired")
+	errTooManyDeviceLogins  = errors.New("too many pending device logins")
+	errDeviceLoginThrottled = errors.New("device logins started too quickly")
+)
+
+// userCodeAlphabet avoids vowels and look-alike characters so codes are easy
+// to read aloud and never spell words.
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+type deviceAuthorization struct {
+	userCode  string
+	client    string
+	label     string
+	expiresAt time.Time
+	interval  time.Duration
+	lastPoll  time.Time
+	approved  bool
+	denied    bool
+	accountID string
+}
+
+// DeviceAuthorizations holds pending device logins in memory. A login is
+// keyed by the hash of its device code, which only the CLI knows, and by the
+// user code the user types into the admin UI. Pending logins do not survive
+// a restart. Since logins are started without authentication, the number
+// pending is capped overall and per client IP, and each client IP may start
+// one login per deviceStartInterval.
+type DeviceAuthorizations struct {
+	ttl           time.Duration
+	interval      time.Duration
+	maxPending    int
+	maxPerClient  int
+	startInterval time.Duration
+	now           func() time.Time
+
+	mu        sync.Mutex
+	byDevice  map[string]*deviceAuthorization
+	byUser    map[string]string
+	byClient  map[string]int
+	lastStart map[string]time.Time
+}
+
+// NewDeviceAuthorizations creates a store whose logins expire after ttl and
+// may be polled once per interval.
+func NewDeviceAuthorizations(ttl, interval time.Duration) *DeviceAuthorizations {
+	if ttl <= 0 {
+		ttl = DefaultDeviceCodeTTL
+	}
+	if interval <= 0 {
+		interval = DefaultDevicePollInterval
+	}
+	return &DeviceAuthorizations{
+		ttl:           ttl,
+		interval:      interval,
+		maxPending:    maxPendingDevices,
+		maxPerClient:  maxPendingDevicesPerClient,
+		startInterval: deviceStartInterval,
+		now:           time.Now,
+		byDevice:      make(map[string]*deviceAuthorization),
+		byUser:        make(map[string]string),
+		byClient:      make(map[string]int),
+		lastStart:     make(map[string]time.Time),
+	}
+}
+
+// Start begins a device login for the client at the given IP and returns
+// its device and user codes.
+func (d *DeviceAuthorizations) Start(label, client string) (deviceCode, userCode string, err error) {
+	deviceCode, err = randomToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pruneLocked()
+
+	now := d.now()
+	if last, ok := d.lastStart[client]; ok && now.Sub(last) < d.startInterval {
+		return "", "", errDeviceLoginThrottled
+	}
+	if len(d.byDevice) >= d.maxPending || d.byClient[client] >= d.maxPerClient {
+		return "", "", errTooManyDeviceLogins
+	}
+
+	for {
+		userCode, err = newUserCode()
+		if err != nil {
+			return "", "", err
+		}
+		if _, taken := d.byUser[userCode]; !taken {
+			break
+		}
+	}
+
+	key := deviceKey(deviceCode)
+	d.byDevice[key] = &deviceAuthorization{
+		userCode:  userCode,
+		client:    client,
+		label:     label,
+		expiresAt: now.Add(d.ttl),
+		interval:  d.interval,
+	}
+	d.byUser[userCode] = key
+	d.byClient[client]++
+	d.lastStart[client] = now
+	return deviceCode, formatUserCode(userCode), nil
+}
+
+// TTL returns how long a login waits for approval.
+func (d *DeviceAuthorizations) TTL() time.Duration { return d.ttl }
+
+// Interval returns the minimum time between token polls.
+func (d *DeviceAuthorizations) Interval() time.Duration { return d.interval }
+
+// StartInterval returns the minimum time between logins started from one
+// client IP.
+func (d *DeviceAuthorizations) StartInterval() time.Duration { return d.startInterval }
+
+// Lookup returns the label and expiry of a pending login.
+func (d *DeviceAuthorizations) Lookup(userCode string) (label string, expiresAt time.Time, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	auth, err := d.pendingLocked(userCode)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return auth.label, auth.expiresAt, nil
+}
+
+// Approve lets the CLI holding the login's device code sign in as accountID.
+func (d *DeviceAuthorizations) Approve(userCode, accountID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	auth, err := d.pendingLocked(userCode)
+	if err != nil {
+		return err
+	}
+	auth.approved = true
+	auth.accountID = accountID
+	return nil
+}
+
+// Deny rejects a pending login.
+func (d *DeviceAuthorizations) Deny(userCode string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	auth, err := d.pendingLocked(userCode)
+	if err != nil {
+		return err
+	}
+	auth.denied = true
+	return nil
+}
+
+// Poll reports the state of the login for deviceCode. An approved login is
+// returned once, with the approving account, and then forgotten.
+func (d *DeviceAuthorizations) Poll(deviceCode string) (accountID, label string, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := deviceKey(deviceCode)
+	auth, ok := d.byDevice[key]
+	now := d.now()
+	if !ok || now.After(auth.expiresAt) {
+		d.removeLocked(key)
+		return "", "", errExpiredToken
+	}
+	if auth.denied {
+		d.removeLocked(key)
+		return "", "", errAccessDenied
+	}
+	if auth.approved {
+		d.removeLocked(key)
+		return auth.accountID, auth.label, nil
+	}
+	if !auth.lastPoll.IsZero() && now.Sub(auth.lastPoll) < auth.interval {
+		auth.lastPoll = now
+		auth.interval += DefaultDevicePollInterval
+		return "", "", errSlowDown
+	}
+	auth.lastPoll = now
+	return "", "", errAuthorizationPending
+}
+
+func (d *DeviceAuthorizations) pendingLocked(userCode string) (*deviceAuthorization, error) {
+	key, ok := d.byUser[normalizeUserCode(userCode)]
+	if !ok {
+		return nil, errUserCodeNotFound
+	}
+	auth := d.byDevice[key]
+	if d.now().After(auth.expiresAt) || auth.approved || auth.denied {
+		return nil, errUserCodeNotFound
+	}
+	return auth, nil
+}
+
+func (d *DeviceAuthorizations) removeLocked(key string) {
+	if auth, ok := d.byDevice[key]; ok {
+		delete(d.byUser, auth.userCode)
+		delete(d.byDevice, key)
+		if d.byClient[auth.client]--; d.byClient[auth.client] <= 0 {
+			delete(d.byClient, auth.client)
+		}
+	}
+}
+
+func (d *DeviceAuthorizations) pruneLocked() {
+	now := d.now()
+	for key, auth := range d.byDevice {
+		if now.After(auth.expiresAt) {
+			d.removeLocked(key)
+		}
+	}
+	for client, last := range d.lastStart {
+		if now.Sub(last) >= d.startInterval {
+			delete(d.lastStart, client)
+		}
+	}
+}
+
+func deviceKey(deviceCode string) string {
+	h := sha256.Sum256([]byte(deviceCode))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// newUserCode returns eight characters drawn uniformly from
+// userCodeAlphabet.
+func newUserCode() (string, error) {
+	// Bytes at or above this bound are dropped to avoid modulo bias.
+	bound := 256 - 256%len(userCodeAlphabet)
+	code := make([]byte, 0, 8)
+	buf := make([]byte, 16)
+	for len(code) < cap(code) {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, v := range buf {
+			if int(v) < bound && len(code) < cap(code) {
+				code = append(code, userCodeAlphabet[int(v)%len(userCodeAlphabet)])
+			}
+		}
+	}
+	return string(code), nil
+}
+
+// formatUserCode splits a user code for display, e.g. BCDF-GHJK.
+func formatUserCode(code string) string {
+	return code[:4] + "-" + code[4:]
+}
+
+// normalizeUserCode accepts a user code in any case, with or without the
+// separator.
+func normalizeUserCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,153 @@
+package admin
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceAuthorizations(t *testing.T) {
+	newStore := func() (*DeviceAuthorizations, *time.Time) {
+		now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		d := NewDeviceAuthorizations(10*time.Minute, 5*time.Second)
+		d.now = func() time.Time { return now }
+		return d, &now
+	}
+
+	t.Run("issues a readable user code", func(t *testing.T) {
+		d, _ := newStore()
+
+		deviceCode, userCode, err := d.Start("laptop", "10.0.0.1")
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, deviceCode)
+		assert.Regexp(t, regexp.MustCompile(`^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`), userCode)
+	})
+
+	t.Run("reports pending until approved, then returns the approver once", func(t *testing.T) {
+		d, now := newStore()
+		deviceCode, userCode, err := d.Start("laptop", "10.0.0.1")
+		require.NoError(t, err)
+
+		_, _, err = d.Poll(deviceCode)
+		assert.ErrorIs(t, err, errAuthorizationPending)
+
+		require.NoError(t, d.Approve(userCode, "acct-1"))
+		*now = now.Add(5 * time.Second)
+		accountID, label, err := d.Poll(deviceCode)
+		require.NoError(t, err)
+		assert.Equal(t, "acct-1", accountID)
+		assert.Equal(t, "laptop", label)
+
+		_, _, err = d.Poll(deviceCode)
+		assert.ErrorIs(t, err, errExpiredToken)
+	})
+
+	t.Run("accepts the user code in any case without the separator", func(t *testing.T) {
+		d, _ := newStore()
+		_, userCode, err := d.Start("laptop", "10.0.0.1")
+		require.NoError(t, err)
+
+		_, _, err = d.Lookup(normalizeUserCode(userCode)[:4] + " " + normalizeUserCode(userCode)[4:])
+		require.NoError(t, err)
+		require.NoError(t, d.Approve(userCode[:4]+userCode[5:], "acct-1"))
+	})
+
+	t.Run("slows down a client polling too fast", func(t *testing.T) {
+		d, now := newStore()
+		deviceCode, _, err := d.Start("laptop", "10.0.0.1")
+		require.NoError(t, err)
+
+		_, _, err = d.Poll(deviceCode)
+		assert.ErrorIs(t, err, errAuthorizationPending)
+		*now = now.Add(time.Second)
+		_, _, err = d.Poll(deviceCode)
+		assert.ErrorIs(t, err, errSlowDown)
+	})
+
+	t.Run("denied logins return access_denied", func(t *testing.T) {
+		d, _ := newStore()
+		deviceCode, userCode, err := d.Start("laptop", "10.0.0.1")
+		require.NoError(t, err)
+
+		require.NoError(t, d.Deny(userCode))
+
+		_, _, err = d.Poll(deviceCode)
+		assert.ErrorIs(t, err, errAccessDenied)
+		assert.ErrorIs(t, d.Approve(userCode, "acct-1"), errUserCodeNotFound)
+	})
+
+	t.Run("expires logins after the TTL", func(t *testing.T) {
+		d, now := newStore()
+		deviceCode, userCode, err := d.Start("laptop", "10.0.0.1")
+		require.NoError(t, err)
+
+		*now = now.Add(11 * time.Minute)
+
+		assert.ErrorIs(t, d.Approve(userCode, "acct-1"), errUserCodeNotFound)
+		_, _, err = d.Poll(deviceCode)
+		assert.ErrorIs(t, err, errExpiredToken)
+	})
+
+	t.Run("throttles logins started from one client", func(t *testing.T) {
+		d, now := newStore()
+		_, _, err := d.Start("laptop", "10.0.0.1")
+		require.NoError(t, err)
+
+		_, _, err = d.Start("laptop", "10.0.0.1")
+		assert.ErrorIs(t, err, errDeviceLoginThrottled)
+		_, _, err = d.Start("laptop", "10.0.0.2")
+		require.NoError(t, err)
+
+		*now = now.Add(deviceStartInterval)
+		_, _, err = d.Start("laptop", "10.0.0.1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("caps pending logins per client", func(t *testing.T) {
+		d, now := newStore()
+		for i := 0; i < maxPendingDevicesPerClient; i++ {
+			_, _, err := d.Start("laptop", "10.0.0.1")
+			require.NoError(t, err)
+			*now = now.Add(deviceStartInterval)
+		}
+
+		_, _, err := d.Start("laptop", "10.0.0.1")
+		assert.ErrorIs(t, err, errTooManyDeviceLogins)
+		_, _, err = d.Start("laptop", "10.0.0.2")
+		assert.NoError(t, err)
+	})
+
+	t.Run("frees a client's slot when its login completes", func(t *testing.T) {
+		d, now := newStore()
+		d.maxPerClient = 1
+		deviceCode, userCode, err := d.Start("laptop", "10.0.0.1")
+		require.NoError(t, err)
+		require.NoError(t, d.Deny(userCode))
+		_, _, err = d.Poll(deviceCode)
+		require.ErrorIs(t, err, errAccessDenied)
+
+		*now = now.Add(deviceStartInterval)
+		_, _, err = d.Start("laptop", "10.0.0.1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("caps pending logins across clients until they expire", func(t *testing.T) {
+		d, now := newStore()
+		d.maxPending = 2
+		for _, client := range []string{"10.0.0.1", "10.0.0.2"} {
+			_, _, err := d.Start("laptop", client)
+			require.NoError(t, err)
+		}
+
+		_, _, err := d.Start("laptop", "10.0.0.3")
+		assert.ErrorIs(t, err, errTooManyDeviceLogins)
+
+		*now = now.Add(11 * time.Minute)
+		_, _, err = d.Start("laptop", "10.0.0.3")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,274 @@
+package admin
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+)
+
+// GroupMember is a member of a group in the groups list.
+type GroupMember struct {
+	AccountID string `json:"account_id"`
+	Username  string `json:"username,omitempty"`
+}
+
+// GroupListEntry is the JSON response for a group in the list.
+type GroupListEntry struct {
+	GroupID   string            `json:"group_id"`
+	Name      string            `json:"name"`
+	Members   []GroupMember     `json:"members"`
+	Roles     map[string]string `json:"roles"`
+	CreatedAt string            `json:"created_at"`
+}
+
+// CreateGroupRequest is the request body for POST /create-group.
+type CreateGroupRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateGroupResponse is the response for POST /create-group.
+type CreateGroupResponse struct {
+	GroupID string `json:"group_id"`
+}
+
+// DeleteGroupRequest is the request body for POST /delete-group.
+type DeleteGroupRequest struct {
+	GroupID string `json:"group_id"`
+}
+
+// GroupMemberRequest is the request body for POST /add-group-member and
+// POST /remove-group-member.
+type GroupMemberRequest struct {
+	GroupID   string `json:"group_id"`
+	AccountID string `json:"account_id"`
+}
+
+// GroupRoleRequest is the request body for POST /assign-group-role and
+// POST /revoke-group-role.
+type GroupRoleRequest struct {
+	GroupID string `json:"group_id"`
+	RealmID string `json:"realm_id"`
+	Role    string `json:"role,omitempty"`
+}
+
+// RegisterGroupsAPIRoutes registers the group management API. All group
+// routes require a system admin.
+func RegisterGroupsAPIRoutes(mux *http.ServeMux, cfg *RouteConfig) {
+	authMiddleware := AuthMiddleware(cfg.AuthConfig, cfg.ProjectionStore)
+	requireAdmin := RequireAdminMiddleware()
+
+	mux.Handle("GET /api/groups", authMiddleware(requireAdmin(http.HandlerFunc(handleGetGroups(cfg)))))
+	mux.Handle("POST /api/create-group", authMiddleware(requireAdmin(http.HandlerFunc(handleCreateGroup(cfg)))))
+	mux.Handle("POST /api/delete-group", authMiddleware(requireAdmin(http.HandlerFunc(handleDeleteGroup(cfg)))))
+	mux.Handle("POST /api/add-group-member", authMiddleware(requireAdmin(http.HandlerFunc(handleAddGroupMember(cfg)))))
+	mux.Handle("POST /api/remove-group-member", authMiddleware(requireAdmin(http.HandlerFunc(handleRemoveGroupMember(cfg)))))
+	mux.Handle("POST /api/assign-group-role", authMiddleware(requireAdmin(http.HandlerFunc(handleAssignGroupRole(cfg)))))
+	mux.Handle("POST /api/revoke-group-role", authMiddleware(requireAdmin(http.HandlerFunc(handleRevokeGroupRole(cfg)))))
+}
+
+func handleGetGroups(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groups := []GroupListEntry{}
+		if cfg.ProjectionStore != nil {
+			raws, err := cfg.ProjectionStore.List(r.Context(), domain.AdminRealmID, "group_directory")
+			if err != nil {
+				log.Printf("handleGetGroups: failed to list groups: %v", err)
+				writeError(w, http.StatusInternalServerError, "failed to list groups")
+				return
+			}
+			for _, raw := range raws {
+				var group projectors.GroupDirectoryEntry
+				if err := json.Unmarshal(raw, &group); err != nil {
+					continue
+				}
+				members := make([]GroupMember, 0, len(group.Members))
+				for _, accountID := range group.Members {
+					member := GroupMember{AccountID: accountID}
+					var account projectors.AccountDirectoryEntry
+					if err := cfg.ProjectionStore.Get(r.Context(), domain.AdminRealmID, "account_directory", accountID, &account); err == nil {
+						member.Username = account.Username
+					}
+					members = append(members, member)
+				}
+				roles := group.Roles
+				if roles == nil {
+					roles = map[string]string{}
+				}
+				groups = append(groups, GroupListEntry{
+					GroupID:   group.GroupID,
+					Name:      group.Name,
+					Members:   members,
+					Roles:     roles,
+					CreatedAt: group.CreatedAt.Format("2006-01-02T15:04:05.000Z"),
+				})
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(groups); err != nil {
+			log.Printf("handleGetGroups: failed to encode response: %v", err)
+		}
+	}
+}
+
+func handleCreateGroup(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateGroupRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		if strings.TrimSpace(req.Name) == "" {
+			writeError(w, http.StatusBadRequest, "name is required")
+			return
+		}
+
+		result, err := domain.HandleCreateGroup(r.Context(), domain.CreateGroup{Name: req.Name}, cfg.EventStore, cfg.ProjectionStore)
+		if err != nil {
+			if strings.Contains(err.Error(), "already exists") {
+				writeError(w, http.StatusConflict, err.Error())
+				return
+			}
+			log.Printf("handleCreateGroup: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(CreateGroupResponse{GroupID: result.GroupID}); err != nil {
+			log.Printf("handleCreateGroup: failed to encode response: %v", err)
+		}
+	}
+}
+
+func handleDeleteGroup(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DeleteGroupRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		if req.GroupID == "" {
+			writeError(w, http.StatusBadRequest, "group_id is required")
+			return
+		}
+
+		if err := domain.HandleDeleteGroup(r.Context(), domain.DeleteGroup{GroupID: req.GroupID}, cfg.EventStore); err != nil {
+			log.Printf("handleDeleteGroup: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleAddGroupMember(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GroupMemberRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		if req.GroupID == "" || req.AccountID == "" {
+			writeError(w, http.StatusBadRequest, "group_id and account_id are required")
+			return
+		}
+
+		err := domain.HandleAddGroupMember(r.Context(), domain.AddGroupMember{
+			GroupID:   req.GroupID,
+			AccountID: req.AccountID,
+		}, cfg.EventStore)
+		if err != nil {
+			log.Printf("handleAddGroupMember: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleRemoveGroupMember(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GroupMemberRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		if req.GroupID == "" || req.AccountID == "" {
+			writeError(w, http.StatusBadRequest, "group_id and account_id are required")
+			return
+		}
+
+		err := domain.HandleRemoveGroupMember(r.Context(), domain.RemoveGroupMember{
+			GroupID:   req.GroupID,
+			AccountID: req.AccountID,
+		}, cfg.EventStore)
+		if err != nil {
+			log.Printf("handleRemoveGroupMember: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleAssignGroupRole(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GroupRoleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		if req.GroupID == "" || req.RealmID == "" || req.Role == "" {
+			writeError(w, http.StatusBadRequest, "group_id, realm_id, and role are required")
+			return
+		}
+
+		err := domain.HandleAssignGroupRole(r.Context(), domain.AssignGroupRole{
+			GroupID: req.GroupID,
+			RealmID: req.RealmID,
+			Role:    req.Role,
+		}, cfg.EventStore, cfg.ProjectionStore)
+		if err != nil {
+			log.Printf("handleAssignGroupRole: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleRevokeGroupRole(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GroupRoleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON")
+			return
+		}
+		if req.GroupID == "" || req.RealmID == "" {
+			writeError(w, http.StatusBadRequest, "group_id and realm_id are required")
+			return
+		}
+
+		err := domain.HandleRevokeGroupRole(r.Context(), domain.RevokeGroupRole{
+			GroupID: req.GroupID,
+			RealmID: req.RealmID,
+		}, cfg.EventStore)
+		if err != nil {
+			log.Printf("handleRevokeGroupRole: failed: %v", err)
+			handleDomainError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupsAPI(t *testing.T) {
+	adminRequest := func(path, body string) *http.Request {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), accountIDKey, "acct-admin")
+		ctx = context.WithValue(ctx, rolesKey, map[string]string{"_admin": "admin"})
+		return req.WithContext(ctx)
+	}
+
+	seedGroup := func(t *testing.T, events *mockEventStore) {
+		t.Helper()
+		_, err := events.Append(context.Background(), domain.AdminRealmID, "group-grp-1", 0, []core.EventData{
+			{EventType: domain.EventGroupCreated, Data: domain.GroupCreated{GroupID: "grp-1", Name: "squad"}},
+		})
+		require.NoError(t, err)
+		_, err = events.Append(context.Background(), domain.AdminRealmID, "account-acct-1", 0, []core.EventData{
+			{EventType: domain.EventAccountCreated, Data: domain.AccountCreated{AccountID: "acct-1", Username: "alice"}},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("create-group returns the new group ID", func(t *testing.T) {
+		cfg := &RouteConfig{EventStore: newMockEventStore(), ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleCreateGroup(cfg).ServeHTTP(rec, adminRequest("/api/create-group", `{"name":"squad"}`))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var resp CreateGroupResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.True(t, strings.HasPrefix(resp.GroupID, "grp-"))
+	})
+
+	t.Run("create-group returns 409 when the name is taken", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.listData["group_directory"] = []json.RawMessage{json.RawMessage(`{"group_id":"grp-1","name":"squad"}`)}
+		cfg := &RouteConfig{EventStore: newMockEventStore(), ProjectionStore: store}
+
+		rec := httptest.NewRecorder()
+		handleCreateGroup(cfg).ServeHTTP(rec, adminRequest("/api/create-group", `{"name":"Squad"}`))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("add-group-member adds the account", func(t *testing.T) {
+		events := newMockEventStore()
+		seedGroup(t, events)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleAddGroupMember(cfg).ServeHTTP(rec, adminRequest("/api/add-group-member", `{"group_id":"grp-1","account_id":"acct-1"}`))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		stream := events.streams[domain.AdminRealmID+"|group-grp-1"]
+		assert.Equal(t, domain.EventGroupMemberAdded, stream[len(stream)-1].EventType)
+	})
+
+	t.Run("add-group-member returns 404 for an unknown group", func(t *testing.T) {
+		cfg := &RouteConfig{EventStore: newMockEventStore(), ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleAddGroupMember(cfg).ServeHTTP(rec, adminRequest("/api/add-group-member", `{"group_id":"grp-missing","account_id":"acct-1"}`))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("assign-group-role returns 422 for an invalid role", func(t *testing.T) {
+		events := newMockEventStore()
+		seedGroup(t, events)
+		cfg := &RouteConfig{EventStore: events, ProjectionStore: newMockProjectionStore()}
+
+		rec := httptest.NewRecorder()
+		handleAssignGroupRole(cfg).ServeHTTP(rec, adminRequest("/api/assign-group-role", `{"group_id":"grp-1","realm_id":"realm-1","role":"root"}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("groups lists members with their usernames", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.listData["group_directory"] = []json.RawMessage{
+			json.RawMessage(`{"group_id":"grp-1","name":"squad","members":["acct-1"],"roles":{"realm-1":"member"},"created_at":"2026-01-15T10:00:00Z"}`),
+		}
+		store.data[compositeKey("_admin", "account_directory", "acct-1")] = projectors.AccountDirectoryEntry{AccountID: "acct-1", Username: "alice"}
+		cfg := &RouteConfig{ProjectionStore: store}
+
+		rec := httptest.NewRecorder()
+		handleGetGroups(cfg).ServeHTTP(rec, httptest.NewRequest("GET", "/api/groups", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var groups []GroupListEntry
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &groups))
+		require.Len(t, groups, 1)
+		assert.Equal(t, []GroupMember{{AccountID: "acct-1", Username: "alice"}}, groups[0].Members)
+		assert.Equal(t, map[string]string{"realm-1": "member"}, groups[0].Roles)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
 	"github.com/devzeebo/bifrost/domain/projectors"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -25,10 +26,12 @@ const (
 	patIDKey     adminContextKey = "admin_pat_id"
 	usernameKey  adminContextKey = "admin_username"
 	rolesKey     adminContextKey = "admin_roles"
+	scopesKey    adminContextKey = "admin_pat_scopes"
 )
 
 // AdminClaims represents the JWT claims for admin UI authentication.
 type AdminClaims struct {
+	Type      string `json:"typ"`
 	AccountID string `json:"sub"`
 	PATID     string `json:"pat"`
 	jwt.RegisteredClaims
@@ -41,6 +44,15 @@ type AuthConfig struct {
 	CookieName     string
 	CookieSecure   bool
 	CookieSameSite http.SameSite
+	// Usage records PAT usage on login and Bearer authentication; nil disables it.
+	Usage *PATUsageRecorder
+	// AccessTokenExpiry is the lifetime of access tokens from POST /api/token.
+	AccessTokenExpiry time.Duration
+	// Revocations rejects access tokens of revoked PATs and suspended accounts;
+	// nil falls back to looking up the PAT on every request.
+	Revocations *RevocationList
+	// SecurityLog records logins and authentication failures; nil disables it.
+	SecurityLog *SecurityLog
 }
 
 // DefaultAuthConfig returns the default authentication configuration.
@@ -51,10 +63,11 @@ type AuthConfig struct {
 // to maintain session validity. Use GenerateSigningKey() to create a new random key.
 func DefaultAuthConfig() *AuthConfig {
 	return &AuthConfig{
-		TokenExpiry:    12 * time.Hour, // SOC 2 CC6.1 requires max 12-hour absolute session lifetime
-		CookieName:     "admin_token",
-		CookieSecure:   true,
-		CookieSameSite: http.SameSiteStrictMode,
+		TokenExpiry:       12 * time.Hour, // SOC 2 CC6.1 requires max 12-hour absolute session lifetime
+		CookieName:        "admin_token",
+		CookieSecure:      true,
+		CookieSameSite:    http.SameSiteStrictMode,
+		AccessTokenExpiry: DefaultAccessTokenExpiry,
 	}
 }
 
@@ -91,6 +104,43 @@ func RolesFromContext(ctx context.Context) (map[string]string, bool) {
 	return roles, ok
 }
 
+// PATScopesFromContext extracts the scopes of the authenticating PAT from the
+// request context. It reports false when the PAT is unscoped.
+func PATScopesFromContext(ctx context.Context) (*domain.PATScopes, bool) {
+	scopes, ok := ctx.Value(scopesKey).(*domain.PATScopes)
+	return scopes, ok && scopes != nil
+}
+
+// EndpointName returns the API endpoint name matched against PAT command
+// scopes: the request path without its "/api/" prefix.
+func EndpointName(path string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(path, "/api"), "/")
+}
+
+// ScopeAccountEntry returns a copy of entry narrowed to what a PAT with the
+// given scopes may act as: realms outside the scope are dropped and roles are
+// capped at the scope's maximum role.
+func ScopeAccountEntry(entry projectors.AccountAuthEntry, scopes *domain.PATScopes) *projectors.AccountAuthEntry {
+	if scopes.IsEmpty() {
+		return &entry
+	}
+	roles := make(map[string]string, len(entry.Roles))
+	for realmID, role := range entry.Roles {
+		if scopes.AllowsRealm(realmID) {
+			roles[realmID] = scopes.CapRole(role)
+		}
+	}
+	entry.Roles = roles
+	var realms []string
+	for _, realmID := range entry.Realms {
+		if scopes.AllowsRealm(realmID) {
+			realms = append(realms, realmID)
+		}
+	}
+	entry.Realms = realms
+	return &entry
+}
+
 // GenerateJWT creates a signed JWT token for the given account and PAT.
 // Returns an error if SigningKey is not configured.
 func GenerateJWT(cfg *AuthConfig, accountID, patID string) (string, error) {
@@ -103,6 +153,7 @@ func GenerateJWTWithExpiry(cfg *AuthConfig, accountID, patID string, tokenExpiry
 	}
 
 	claims := AdminClaims{
+		Type:      sessionTokenType,
 		AccountID: accountID,
 		PATID:     patID,
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -115,7 +166,7 @@ func GenerateJWTWithExpiry(cfg *AuthConfig, accountID, patID string, tokenExpiry
 	return token.SignedString(cfg.SigningKey)
 }
 
-// ValidateJWT parses and validates a JWT token string.
+// ValidateJWT parses and validates a session JWT.
 func ValidateJWT(cfg *AuthConfig, tokenString string) (*AdminClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &AdminClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -131,21 +182,30 @@ func ValidateJWT(cfg *AuthConfig, tokenString string) (*AdminClaims, error) {
 	if !ok {
 		return nil, fmt.Errorf("invalid token claims type: expected *AdminClaims, got %T", token.Claims)
 	}
+	// Access tokens are signed with the same key but are not sessions.
+	if claims.Type != sessionTokenType {
+		return nil, fmt.Errorf("parse JWT: token type %q is not a session", claims.Type)
+	}
 
 	return claims, nil
 }
 
 // CheckPATStatus verifies that a PAT is still active by looking it up in the projection store.
-// It uses the same lookup mechanism as the API's PAT authentication.
-func CheckPATStatus(ctx context.Context, projectionStore core.ProjectionStore, patID string) (*projectors.AccountAuthEntry, error) {
+// It uses the same lookup mechanism as the API's PAT authentication. The returned
+// entry is narrowed to the PAT's scopes, which are returned with it.
+func CheckPATStatus(ctx context.Context, projectionStore core.ProjectionStore, patID string) (*projectors.AccountAuthEntry, *domain.PATScopes, error) {
 	// Look up the PAT entry from PAT ID reverse lookup
 	var patEntry projectors.PATIDEntry
 	if err := projectionStore.Get(ctx, "_admin", "pat_by_id", patID, &patEntry); err != nil {
 		var nfe *core.NotFoundError
 		if errors.As(err, &nfe) {
-			return nil, ErrPATRevoked
+			return nil, nil, ErrPATRevoked
 		}
-		return nil, fmt.Errorf("checking PAT status for %s: lookup PAT entry: %w", patID, err)
+		return nil, nil, fmt.Errorf("checking PAT status for %s: lookup PAT entry: %w", patID, err)
+	}
+
+	if domain.IsExpired(patEntry.ExpiresAt, time.Now()) {
+		return nil, nil, ErrPATExpired
 	}
 
 	// Look up the account auth entry by account ID
@@ -153,16 +213,16 @@ func CheckPATStatus(ctx context.Context, projectionStore core.ProjectionStore, p
 	if err := projectionStore.Get(ctx, "_admin", "account_auth", patEntry.AccountID, &entry); err != nil {
 		var nfe *core.NotFoundError
 		if errors.As(err, &nfe) {
-			return nil, ErrPATRevoked
+			return nil, nil, ErrPATRevoked
 		}
-		return nil, fmt.Errorf("checking PAT status for %s: lookup account entry: %w", patID, err)
+		return nil, nil, fmt.Errorf("checking PAT status for %s: lookup account entry: %w", patID, err)
 	}
 
 	if entry.Status == "suspended" {
-		return nil, ErrAccountSuspended
+		return nil, nil, ErrAccountSuspended
 	}
 
-	return &entry, nil
+	return ScopeAccountEntry(entry, patEntry.Scopes), patEntry.Scopes, nil
 }
 
 // Authentication errors
@@ -171,7 +231,10 @@ var (
 	ErrInvalidToken     = errors.New("invalid authentication token")
 	ErrTokenExpired     = errors.New("authentication token expired")
 	ErrPATRevoked       = errors.New("PAT has been revoked")
+	ErrPATExpired       = errors.New("PAT has expired")
 	ErrAccountSuspended = errors.New("account is suspended")
+	ErrPATOutOfScope    = errors.New("PAT is not scoped for this endpoint")
+	ErrGrantsChanged    = errors.New("access token predates a change to the account's roles")
 )
 
 // AuthMiddleware returns HTTP middleware that authenticates admin requests.
@@ -186,13 +249,15 @@ func AuthMiddleware(cfg *AuthConfig, projectionStore core.ProjectionStore) func(
 				claims, err := ValidateJWT(cfg, cookie.Value)
 				if err == nil {
 					// Check that the PAT is still active
-					entry, err := CheckPATStatus(r.Context(), projectionStore, claims.PATID)
-					if err == nil {
+					entry, scopes, err := CheckPATStatus(r.Context(), projectionStore, claims.PATID)
+					if err == nil && scopes.AllowsCommand(EndpointName(r.URL.Path)) {
 						ctx := r.Context()
 						ctx = context.WithValue(ctx, accountIDKey, claims.AccountID)
 						ctx = context.WithValue(ctx, patIDKey, claims.PATID)
 						ctx = context.WithValue(ctx, usernameKey, entry.Username)
 						ctx = context.WithValue(ctx, rolesKey, entry.Roles)
+						ctx = context.WithValue(ctx, scopesKey, scopes)
+						ctx = core.WithActor(ctx, claims.AccountID)
 						next.ServeHTTP(w, r.WithContext(ctx))
 						return
 					}
@@ -204,17 +269,30 @@ func AuthMiddleware(cfg *AuthConfig, projectionStore core.ProjectionStore) func(
 			if authHeader != "" && strings.HasPrefix(authHeader, "Bearer ") {
 				token := strings.TrimPrefix(authHeader, "Bearer ")
 				if token != "" {
-					entry, patID, err := ValidatePAT(r.Context(), projectionStore, token)
+					entry, patEntry, err := authenticateBearer(r.Context(), cfg, projectionStore, token)
+					if err == nil && !patEntry.Scopes.AllowsCommand(EndpointName(r.URL.Path)) {
+						cfg.SecurityLog.RecordAuthFailure(r, entry.AccountID, http.StatusForbidden, ErrPATOutOfScope.Error())
+						writeError(w, http.StatusForbidden, ErrPATOutOfScope.Error())
+						return
+					}
 					if err == nil {
+						// Access tokens were counted when they were exchanged
+						if !IsAccessToken(token) {
+							cfg.Usage.Record(patEntry.PATID, ClientIP(r), time.Now())
+							w.Header().Set(TokenExchangeHeader, "/api/token")
+						}
 						ctx := r.Context()
 						ctx = context.WithValue(ctx, accountIDKey, entry.AccountID)
-						ctx = context.WithValue(ctx, patIDKey, patID)
+						ctx = context.WithValue(ctx, patIDKey, patEntry.PATID)
 						ctx = context.WithValue(ctx, usernameKey, entry.Username)
 						ctx = context.WithValue(ctx, rolesKey, entry.Roles)
+						ctx = context.WithValue(ctx, scopesKey, patEntry.Scopes)
+						ctx = core.WithActor(ctx, entry.AccountID)
 						next.ServeHTTP(w, r.WithContext(ctx))
 						return
 					}
 					// Bearer token was provided but invalid - return 401 JSON
+					cfg.SecurityLog.RecordAuthFailure(r, "", http.StatusUnauthorized, err.Error())
 					writeUnauthorized(w, err)
 					return
 				}
@@ -299,12 +377,34 @@ func ClearAuthCookie(w http.ResponseWriter, cfg *AuthConfig) {
 }
 
 // ValidatePAT validates a PAT string and returns the associated account entry and PAT ID.
-// This is used during login to validate the PAT before generating a JWT.
+// This is used during login to validate the PAT before generating a JWT. The
+// returned entry is narrowed to the PAT's scopes.
 func ValidatePAT(ctx context.Context, projectionStore core.ProjectionStore, token string) (*projectors.AccountAuthEntry, string, error) {
+	entry, patEntry, err := validatePAT(ctx, projectionStore, token)
+	if err != nil {
+		return nil, "", err
+	}
+	return entry, patEntry.PATID, nil
+}
+
+// authenticateBearer authenticates a Bearer token that is either a PAT or an
+// access token exchanged from one.
+func authenticateBearer(ctx context.Context, cfg *AuthConfig, projectionStore core.ProjectionStore, token string) (*projectors.AccountAuthEntry, *projectors.PATIDEntry, error) {
+	if !IsAccessToken(token) {
+		return validatePAT(ctx, projectionStore, token)
+	}
+	claims, err := AuthenticateAccessToken(ctx, cfg, projectionStore, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return claims.AccountEntry(), &projectors.PATIDEntry{PATID: claims.PATID, AccountID: claims.AccountID, Scopes: claims.Scopes}, nil
+}
+
+func validatePAT(ctx context.Context, projectionStore core.ProjectionStore, token string) (*projectors.AccountAuthEntry, *projectors.PATIDEntry, error) {
 	// Decode the raw key from base64url
 	rawBytes, err := base64.RawURLEncoding.DecodeString(token)
 	if err != nil {
-		return nil, "", ErrInvalidToken
+		return nil, nil, ErrInvalidToken
 	}
 
 	// SHA-256 hash the raw bytes and encode as base64url
@@ -316,9 +416,9 @@ func ValidatePAT(ctx context.Context, projectionStore core.ProjectionStore, toke
 	if err := projectionStore.Get(ctx, "_admin", "pat_by_keyhash", keyHash, &patID); err != nil {
 		var nfe *core.NotFoundError
 		if errors.As(err, &nfe) {
-			return nil, "", ErrInvalidToken
+			return nil, nil, ErrInvalidToken
 		}
-		return nil, "", fmt.Errorf("validate PAT: lookup PAT ID: %w", err)
+		return nil, nil, fmt.Errorf("validate PAT: lookup PAT ID: %w", err)
 	}
 
 	// Look up PAT entry to get account ID
@@ -326,9 +426,13 @@ func ValidatePAT(ctx context.Context, projectionStore core.ProjectionStore, toke
 	if err := projectionStore.Get(ctx, "_admin", "pat_by_id", patID, &patEntry); err != nil {
 		var nfe *core.NotFoundError
 		if errors.As(err, &nfe) {
-			return nil, "", ErrInvalidToken
+			return nil, nil, ErrInvalidToken
 		}
-		return nil, "", fmt.Errorf("validate PAT: lookup PAT entry: %w", err)
+		return nil, nil, fmt.Errorf("validate PAT: lookup PAT entry: %w", err)
+	}
+
+	if domain.IsExpired(patEntry.ExpiresAt, time.Now()) {
+		return nil, nil, ErrPATExpired
 	}
 
 	// Look up account auth entry
@@ -336,16 +440,17 @@ func ValidatePAT(ctx context.Context, projectionStore core.ProjectionStore, toke
 	if err := projectionStore.Get(ctx, "_admin", "account_auth", patEntry.AccountID, &entry); err != nil {
 		var nfe *core.NotFoundError
 		if errors.As(err, &nfe) {
-			return nil, "", ErrInvalidToken
+			return nil, nil, ErrInvalidToken
 		}
-		return nil, "", fmt.Errorf("validate PAT: lookup account entry: %w", err)
+		return nil, nil, fmt.Errorf("validate PAT: lookup account entry: %w", err)
 	}
 
 	if entry.Status == "suspended" {
-		return nil, "", ErrAccountSuspended
+		return nil, nil, ErrAccountSuspended
 	}
 
-	return &entry, patID, nil
+	patEntry.PATID = patID
+	return ScopeAccountEntry(entry, patEntry.Scopes), &patEntry, nil
 }
 
 const realmCookieName = "admin_realm"
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
 	"github.com/devzeebo/bifrost/domain/projectors"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
@@ -102,6 +103,36 @@ func TestValidateJWT(t *testing.T) {
 		assert.Error(t, err)
 		assert.ErrorContains(t, err, "unexpected signing method")
 	})
+
+	t.Run("valid token is typed as a session", func(t *testing.T) {
+		token, err := GenerateJWT(cfg, "account-123", "pat-456")
+		require.NoError(t, err)
+
+		claims, err := ValidateJWT(cfg, token)
+		require.NoError(t, err)
+		assert.Equal(t, "session", claims.Type)
+	})
+
+	t.Run("rejects an access token", func(t *testing.T) {
+		token, _, err := GenerateAccessToken(cfg, &projectors.AccountAuthEntry{AccountID: "account-123"}, "pat-456", nil, nil)
+		require.NoError(t, err)
+
+		_, err = ValidateJWT(cfg, token)
+		assert.ErrorContains(t, err, "not a session")
+	})
+
+	t.Run("rejects an untyped token", func(t *testing.T) {
+		claims := AdminClaims{
+			AccountID:        "account-123",
+			PATID:            "pat-456",
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		}
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(cfg.SigningKey)
+		require.NoError(t, err)
+
+		_, err = ValidateJWT(cfg, tokenString)
+		assert.Error(t, err)
+	})
 }
 
 func TestValidateJWT_Expiry(t *testing.T) {
@@ -140,7 +171,7 @@ func TestCheckPATStatus(t *testing.T) {
 			Roles:     map[string]string{"realm-1": "member"},
 		}
 
-		entry, err := CheckPATStatus(ctx, store, "pat-123")
+		entry, _, err := CheckPATStatus(ctx, store, "pat-123")
 		require.NoError(t, err)
 		assert.Equal(t, "account-456", entry.AccountID)
 		assert.Equal(t, "testuser", entry.Username)
@@ -150,7 +181,7 @@ func TestCheckPATStatus(t *testing.T) {
 	t.Run("revoked PAT - not found in lookup", func(t *testing.T) {
 		store := newMockProjectionStore()
 
-		_, err := CheckPATStatus(ctx, store, "pat-123")
+		_, _, err := CheckPATStatus(ctx, store, "pat-123")
 		assert.ErrorIs(t, err, ErrPATRevoked)
 	})
 
@@ -158,7 +189,7 @@ func TestCheckPATStatus(t *testing.T) {
 		store := newMockProjectionStore()
 		// PAT entry doesn't exist (deleted on revocation)
 
-		_, err := CheckPATStatus(ctx, store, "pat-123")
+		_, _, err := CheckPATStatus(ctx, store, "pat-123")
 		assert.ErrorIs(t, err, ErrPATRevoked)
 	})
 
@@ -175,15 +206,52 @@ func TestCheckPATStatus(t *testing.T) {
 			Status:    "suspended",
 		}
 
-		_, err := CheckPATStatus(ctx, store, "pat-123")
+		_, _, err := CheckPATStatus(ctx, store, "pat-123")
 		assert.ErrorIs(t, err, ErrAccountSuspended)
 	})
 
+	t.Run("expired PAT", func(t *testing.T) {
+		store := newMockProjectionStore()
+		expiresAt := time.Now().Add(-time.Minute)
+		store.data[compositeKey("_admin", "pat_by_id", "pat-123")] = projectors.PATIDEntry{
+			PATID:     "pat-123",
+			KeyHash:   "keyhash-abc",
+			AccountID: "account-456",
+			ExpiresAt: &expiresAt,
+		}
+
+		_, _, err := CheckPATStatus(ctx, store, "pat-123")
+		assert.ErrorIs(t, err, ErrPATExpired)
+	})
+
+	t.Run("scoped PAT narrows the account's roles", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.data[compositeKey("_admin", "pat_by_id", "pat-123")] = projectors.PATIDEntry{
+			PATID:     "pat-123",
+			KeyHash:   "keyhash-abc",
+			AccountID: "account-456",
+			Scopes:    &domain.PATScopes{Realms: []string{"realm-1"}, MaxRole: domain.RoleViewer},
+		}
+		store.data[compositeKey("_admin", "account_auth", "account-456")] = projectors.AccountAuthEntry{
+			AccountID: "account-456",
+			Username:  "testuser",
+			Status:    "active",
+			Roles:     map[string]string{"_admin": "owner", "realm-1": "admin", "realm-2": "member"},
+			Realms:    []string{"realm-1", "realm-2"},
+		}
+
+		entry, scopes, err := CheckPATStatus(ctx, store, "pat-123")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"realm-1": "viewer"}, entry.Roles)
+		assert.Equal(t, []string{"realm-1"}, entry.Realms)
+		assert.Equal(t, &domain.PATScopes{Realms: []string{"realm-1"}, MaxRole: domain.RoleViewer}, scopes)
+	})
+
 	t.Run("projection store error", func(t *testing.T) {
 		store := newMockProjectionStore()
 		store.getError = errors.New("db error")
 
-		_, err := CheckPATStatus(ctx, store, "pat-123")
+		_, _, err := CheckPATStatus(ctx, store, "pat-123")
 		assert.Error(t, err)
 		assert.NotErrorIs(t, err, ErrPATRevoked)
 	})
@@ -259,6 +327,44 @@ func TestValidatePAT(t *testing.T) {
 		assert.ErrorIs(t, err, ErrAccountSuspended)
 	})
 
+	t.Run("expired PAT", func(t *testing.T) {
+		store := newMockProjectionStore()
+		token, keyHash := createPATToken(t)
+		expiresAt := time.Now().Add(-time.Minute)
+		store.data[compositeKey("_admin", "pat_by_keyhash", keyHash)] = "pat-789"
+		store.data[compositeKey("_admin", "pat_by_id", "pat-789")] = projectors.PATIDEntry{
+			PATID:     "pat-789",
+			KeyHash:   keyHash,
+			AccountID: "account-456",
+			ExpiresAt: &expiresAt,
+		}
+
+		_, _, err := ValidatePAT(ctx, store, token)
+		assert.ErrorIs(t, err, ErrPATExpired)
+	})
+
+	t.Run("scoped PAT narrows the account's roles", func(t *testing.T) {
+		store := newMockProjectionStore()
+		token, keyHash := createPATToken(t)
+		store.data[compositeKey("_admin", "pat_by_keyhash", keyHash)] = "pat-789"
+		store.data[compositeKey("_admin", "pat_by_id", "pat-789")] = projectors.PATIDEntry{
+			PATID:     "pat-789",
+			KeyHash:   keyHash,
+			AccountID: "account-456",
+			Scopes:    &domain.PATScopes{MaxRole: domain.RoleMember},
+		}
+		store.data[compositeKey("_admin", "account_auth", "account-456")] = projectors.AccountAuthEntry{
+			AccountID: "account-456",
+			Username:  "testuser",
+			Status:    "active",
+			Roles:     map[string]string{"_admin": "admin", "realm-1": "viewer"},
+		}
+
+		entry, _, err := ValidatePAT(ctx, store, token)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"_admin": "member", "realm-1": "viewer"}, entry.Roles)
+	})
+
 	t.Run("PAT ID reverse lookup missing", func(t *testing.T) {
 		store := newMockProjectionStore()
 		token, keyHash := createPATToken(t)
@@ -323,6 +429,40 @@ func TestAuthMiddleware(t *testing.T) {
 		assert.Contains(t, rec.Body.String(), "account-456")
 	})
 
+	t.Run("bearer PAT outside its command scope is forbidden", func(t *testing.T) {
+		store := newMockProjectionStore()
+		rawKey := []byte("test-key-bytes-that-are-32-bytes!")
+		h := sha256.Sum256(rawKey)
+		keyHash := base64.RawURLEncoding.EncodeToString(h[:])
+		store.data[compositeKey("_admin", "pat_by_keyhash", keyHash)] = "pat-789"
+		store.data[compositeKey("_admin", "pat_by_id", "pat-789")] = projectors.PATIDEntry{
+			PATID:     "pat-789",
+			KeyHash:   keyHash,
+			AccountID: "account-456",
+			Scopes:    &domain.PATScopes{Commands: []string{"pats"}},
+		}
+		store.data[compositeKey("_admin", "account_auth", "account-456")] = projectors.AccountAuthEntry{
+			AccountID: "account-456",
+			Username:  "testuser",
+			Status:    "active",
+			Roles:     map[string]string{"_admin": "admin"},
+		}
+		middleware := AuthMiddleware(cfg, store)
+
+		allowed := httptest.NewRequest("GET", "/api/pats", nil)
+		allowed.Header.Set("Authorization", "Bearer "+base64.RawURLEncoding.EncodeToString(rawKey))
+		allowedRec := httptest.NewRecorder()
+		middleware(protectedHandler).ServeHTTP(allowedRec, allowed)
+		assert.Equal(t, http.StatusOK, allowedRec.Code)
+
+		denied := httptest.NewRequest("GET", "/api/accounts", nil)
+		denied.Header.Set("Authorization", "Bearer "+base64.RawURLEncoding.EncodeToString(rawKey))
+		deniedRec := httptest.NewRecorder()
+		middleware(protectedHandler).ServeHTTP(deniedRec, denied)
+		assert.Equal(t, http.StatusForbidden, deniedRec.Code)
+		assert.Contains(t, deniedRec.Body.String(), "not scoped")
+	})
+
 	t.Run("missing cookie redirects to login", func(t *testing.T) {
 		store := newMockProjectionStore()
 
@@ -717,7 +857,12 @@ func (m *mockProjectionStore) Get(ctx context.Context, realm, projection, key st
 		}
 		*d = e
 	default:
-		return fmt.Errorf("mockProjectionStore.Get: unhandled dest type %T", dest)
+		// Fall back to a JSON round trip for projection documents declared inside handlers.
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Errorf("mockProjectionStore.Get: marshal %s: %w", ckey, err)
+		}
+		return json.Unmarshal(b, dest)
 	}
 	return nil
 }
@@ -0,0 +1,282 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	oidcLoginCookie = "oidc_login"
+	oidcCookiePath  = "/api/ui/oidc"
+	oidcLoginTTL    = 10 * time.Minute
+)
+
+// oidcLoginClaims carry a login in progress from /oidc/login to
+// /oidc/callback in a signed cookie.
+type oidcLoginClaims struct {
+	State       string `json:"state"`
+	Nonce       string `json:"nonce"`
+	Verifier    string `json:"verifier"`
+	CLICallback string `json:"cli_callback,omitempty"`
+	RememberMe  bool   `json:"remember_me,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// RegisterOIDCRoutes registers the single sign-on routes when an OIDC
+// provider is configured.
+func RegisterOIDCRoutes(mux *http.ServeMux, cfg *RouteConfig) {
+	if cfg.OIDC == nil {
+		return
+	}
+	mux.HandleFunc("GET /api/ui/oidc/login", handleOIDCLogin(cfg))
+	mux.HandleFunc("GET /api/ui/oidc/callback", handleOIDCCallback(cfg))
+}
+
+// handleOIDCLogin redirects the browser to the provider. A cli_callback
+// query parameter makes the callback hand a PAT to that loopback URL instead
+// of starting a UI session.
+func handleOIDCLogin(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCallback := r.URL.Query().Get("cli_callback")
+		if cliCallback != "" && !isLoopbackCallback(cliCallback) {
+			writeError(w, http.StatusBadRequest, "cli_callback must be an http URL on the loopback interface")
+			return
+		}
+
+		var login oidcLoginClaims
+		for _, field := range []*string{&login.State, &login.Nonce, &login.Verifier} {
+			token, err := randomToken()
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "failed to start sign-in")
+				return
+			}
+			*field = token
+		}
+		login.CLICallback = cliCallback
+		login.RememberMe = r.URL.Query().Get("remember_me") == "true"
+		login.ExpiresAt = jwt.NewNumericDate(time.Now().Add(oidcLoginTTL))
+
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, login).SignedString(cfg.AuthConfig.SigningKey)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to start sign-in")
+			return
+		}
+
+		// Lax, not Strict: the provider's redirect back is a cross-site navigation.
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcLoginCookie,
+			Value:    signed,
+			Path:     oidcCookiePath,
+			MaxAge:   int(oidcLoginTTL.Seconds()),
+			HttpOnly: true,
+			Secure:   cfg.AuthConfig.CookieSecure,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, cfg.OIDC.authCodeURL(login.State, login.Nonce, login.Verifier), http.StatusFound)
+	}
+}
+
+func handleOIDCCallback(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if providerErr := q.Get("error"); providerErr != "" {
+			writeError(w, http.StatusUnauthorized, "sign-in failed: "+providerErr)
+			return
+		}
+
+		login, err := readOIDCLogin(r, cfg.AuthConfig)
+		if err != nil || q.Get("state") == "" || q.Get("state") != login.State {
+			writeError(w, http.StatusBadRequest, "invalid or expired sign-in state")
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcLoginCookie,
+			Value:    "",
+			Path:     oidcCookiePath,
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   cfg.AuthConfig.CookieSecure,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		rawIDToken, err := cfg.OIDC.exchange(r.Context(), q.Get("code"), login.Verifier)
+		if err != nil {
+			log.Printf("handleOIDCCallback: code exchange failed: %v", err)
+			writeError(w, http.StatusUnauthorized, "sign-in failed")
+			return
+		}
+		claims, err := cfg.OIDC.verifyIDToken(r.Context(), rawIDToken, login.Nonce)
+		if err != nil {
+			log.Printf("handleOIDCCallback: ID token rejected: %v", err)
+			writeError(w, http.StatusUnauthorized, "invalid ID token")
+			return
+		}
+		issuer, subject := identityFromClaims(claims)
+		if issuer == "" || subject == "" {
+			writeError(w, http.StatusUnauthorized, "invalid ID token")
+			return
+		}
+
+		username := cfg.OIDC.verifiedUsername(claims)
+
+		accountID, err := resolveSSOAccount(r.Context(), cfg, issuer, subject, username)
+		if err != nil {
+			recordLoginFailure(r, cfg, "", "sso", fmt.Sprintf("%s: %v", subject, err))
+			var nfErr *core.NotFoundError
+			var unverified *errUnverifiedUsername
+			switch {
+			case errors.As(err, &unverified):
+				writeError(w, http.StatusForbidden, fmt.Sprintf("ID token has no verified %s claim", cfg.OIDC.cfg.UsernameClaim))
+			case errors.As(err, &nfErr):
+				writeError(w, http.StatusForbidden, fmt.Sprintf("no account for username %q", username))
+			case errors.Is(err, ErrAccountSuspended):
+				writeError(w, http.StatusUnauthorized, "account suspended")
+			case errors.Is(err, errServiceAccountSignIn):
+				writeError(w, http.StatusForbidden, "service accounts cannot sign in")
+			default:
+				handleDomainError(w, err)
+			}
+			return
+		}
+
+		ttl := getSessionTTL(cfg.AuthConfig, login.RememberMe)
+		label := "sso"
+		if login.CLICallback != "" {
+			ttl = cfg.OIDC.cfg.CLITokenTTL
+			label = "sso-cli"
+		}
+		expiresAt := time.Now().Add(ttl)
+		pat, err := domain.HandleCreatePAT(core.WithActor(r.Context(), accountID), domain.CreatePAT{
+			AccountID: accountID,
+			Label:     label,
+			ExpiresAt: &expiresAt,
+		}, cfg.EventStore)
+		if err != nil {
+			handleDomainError(w, err)
+			return
+		}
+		// The session is checked against pat_by_id on the next request.
+		if cfg.ProjectionEngine != nil {
+			cfg.ProjectionEngine.RunCatchUpOnce(r.Context())
+		}
+
+		recordLoginSuccess(r, cfg, accountID, pat.PATID, "sso")
+
+		if login.CLICallback != "" {
+			target, _ := url.Parse(login.CLICallback)
+			values := target.Query()
+			values.Set("token", pat.RawToken)
+			target.RawQuery = values.Encode()
+			http.Redirect(w, r, target.String(), http.StatusFound)
+			return
+		}
+
+		cfg.AuthConfig.Usage.Record(pat.PATID, ClientIP(r), time.Now())
+		token, err := GenerateJWTWithExpiry(cfg.AuthConfig, accountID, pat.PATID, ttl)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to create session")
+			return
+		}
+		setUIAuthCookie(w, cfg.AuthConfig, token, ttl)
+		http.Redirect(w, r, UIPrefix+"/dashboard", http.StatusFound)
+	}
+}
+
+func readOIDCLogin(r *http.Request, cfg *AuthConfig) (*oidcLoginClaims, error) {
+	cookie, err := r.Cookie(oidcLoginCookie)
+	if err != nil {
+		return nil, err
+	}
+	login := &oidcLoginClaims{}
+	_, err = jwt.ParseWithClaims(cookie.Value, login, func(token *jwt.Token) (interface{}, error) {
+		return cfg.SigningKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	return login, nil
+}
+
+var errServiceAccountSignIn = errors.New("service accounts cannot sign in")
+
+// errUnverifiedUsername reports an unlinked identity whose ID token has no
+// verified username to link it by.
+type errUnverifiedUsername struct{}
+
+func (*errUnverifiedUsername) Error() string { return "no verified username claim" }
+
+// resolveSSOAccount maps a provider identity to a Bifrost account. An
+// identity signs in as the account it was linked to, whatever its username
+// claim says now. An unlinked identity is linked by its verified username,
+// creating the account when auto-provisioning is on. Provisioned accounts
+// hold no roles, and their initial PAT is revoked since nobody ever sees it.
+func resolveSSOAccount(ctx context.Context, cfg *RouteConfig, issuer, subject, username string) (string, error) {
+	var linked projectors.SSOIdentityEntry
+	err := cfg.ProjectionStore.Get(ctx, domain.AdminRealmID, "sso_identity", domain.SSOIdentityKey(issuer, subject), &linked)
+	if err == nil {
+		if err := checkSSOAccount(ctx, cfg, linked.AccountID); err != nil {
+			return "", err
+		}
+		return linked.AccountID, nil
+	}
+	var nfErr *core.NotFoundError
+	if !errors.As(err, &nfErr) {
+		return "", err
+	}
+	if username == "" {
+		return "", &errUnverifiedUsername{}
+	}
+
+	var accountID string
+	var lookup projectors.UsernameLookupEntry
+	err = cfg.ProjectionStore.Get(ctx, domain.AdminRealmID, "username_lookup", username, &lookup)
+	switch {
+	case err == nil:
+		if err := checkSSOAccount(ctx, cfg, lookup.AccountID); err != nil {
+			return "", err
+		}
+		accountID = lookup.AccountID
+	case errors.As(err, &nfErr) && cfg.OIDC.cfg.AutoProvision:
+		created, err := domain.HandleCreateAccount(ctx, domain.CreateAccount{Username: username}, cfg.EventStore, cfg.ProjectionStore)
+		if err != nil {
+			return "", err
+		}
+		if err := domain.HandleRevokePAT(ctx, domain.RevokePAT{AccountID: created.AccountID, PATID: created.PATID}, cfg.EventStore); err != nil {
+			return "", err
+		}
+		accountID = created.AccountID
+	default:
+		return "", err
+	}
+
+	link := domain.LinkSSOIdentity{AccountID: accountID, Issuer: issuer, Subject: subject}
+	if err := domain.HandleLinkSSOIdentity(ctx, link, cfg.EventStore, cfg.ProjectionStore); err != nil {
+		return "", err
+	}
+	return accountID, nil
+}
+
+// checkSSOAccount rejects accounts that may not sign in through SSO.
+func checkSSOAccount(ctx context.Context, cfg *RouteConfig, accountID string) error {
+	var entry projectors.AccountAuthEntry
+	if err := cfg.ProjectionStore.Get(ctx, domain.AdminRealmID, "account_auth", accountID, &entry); err != nil {
+		return err
+	}
+	if entry.Status == "suspended" {
+		return ErrAccountSuspended
+	}
+	if entry.Kind == domain.AccountKindService {
+		return errServiceAccountSignIn
+	}
+	return nil
+}
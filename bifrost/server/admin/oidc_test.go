@@ -0,0 +1,523 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverOIDC(t *testing.T) {
+	t.Run("reads the discovery document", func(t *testing.T) {
+		idp := newStubIdP(t)
+
+		provider, err := DiscoverOIDC(context.Background(), idp.config())
+
+		require.NoError(t, err)
+		assert.Equal(t, idp.server.URL+"/token", provider.metadata.TokenEndpoint)
+	})
+
+	t.Run("rejects a document for another issuer", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.issuer = "https://elsewhere.example.com"
+
+		_, err := DiscoverOIDC(context.Background(), idp.config())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match")
+	})
+
+	t.Run("requires a client ID", func(t *testing.T) {
+		idp := newStubIdP(t)
+		cfg := idp.config()
+		cfg.ClientID = ""
+
+		_, err := DiscoverOIDC(context.Background(), cfg)
+
+		require.Error(t, err)
+	})
+}
+
+func TestOIDCLogin(t *testing.T) {
+	t.Run("redirects to the provider with a PKCE challenge", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.get("/api/ui/oidc/login", nil)
+
+		require.Equal(t, http.StatusFound, rec.Code)
+		location, err := url.Parse(rec.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, idp.server.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+		q := location.Query()
+		assert.Equal(t, "code", q.Get("response_type"))
+		assert.Equal(t, "bifrost", q.Get("client_id"))
+		assert.Equal(t, "S256", q.Get("code_challenge_method"))
+		assert.NotEmpty(t, q.Get("code_challenge"))
+		assert.NotEmpty(t, q.Get("state"))
+		assert.NotEmpty(t, q.Get("nonce"))
+
+		cookie := findCookie(rec, oidcLoginCookie)
+		require.NotNil(t, cookie)
+		assert.True(t, cookie.HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+	})
+
+	t.Run("rejects a CLI callback off the loopback interface", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.get("/api/ui/oidc/login?cli_callback="+url.QueryEscape("http://attacker.example.com/cb"), nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("routes are not registered without a provider", func(t *testing.T) {
+		mux := http.NewServeMux()
+		_, err := RegisterRoutes(mux, &RouteConfig{AuthConfig: DefaultAuthConfig(), ProjectionStore: newMockProjectionStore()})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest("GET", "/api/ui/oidc/login", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestOIDCCallback(t *testing.T) {
+	t.Run("signs an existing account in with a session cookie", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.complete_login("", "testuser")
+
+		require.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+		assert.Equal(t, UIPrefix+"/dashboard", rec.Header().Get("Location"))
+		session := findCookie(rec, env.cfg.AuthConfig.CookieName)
+		require.NotNil(t, session)
+		claims, err := ValidateJWT(env.cfg.AuthConfig, session.Value)
+		require.NoError(t, err)
+		assert.Equal(t, "account-test-123", claims.AccountID)
+
+		pat := env.created_pat("account-test-123")
+		assert.Equal(t, claims.PATID, pat.PATID)
+		assert.Equal(t, "sso", pat.Label)
+		require.NotNil(t, pat.ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(defaultSessionTTL), *pat.ExpiresAt, time.Minute)
+	})
+
+	t.Run("hands a PAT to the CLI loopback callback", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.complete_login("http://127.0.0.1:5555/callback", "testuser")
+
+		require.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+		location, err := url.Parse(rec.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:5555", location.Host)
+		assert.NotEmpty(t, location.Query().Get("token"))
+		assert.Nil(t, findCookie(rec, env.cfg.AuthConfig.CookieName))
+		assert.Equal(t, "sso-cli", env.created_pat("account-test-123").Label)
+	})
+
+	t.Run("rejects a state that does not match the login", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+		login := env.get("/api/ui/oidc/login", nil)
+
+		rec := env.get("/api/ui/oidc/callback?code=code-1&state=forged", findCookie(login, oidcLoginCookie))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("rejects an ID token with the wrong nonce", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.nonceOverride = "other-nonce"
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.complete_login("", "testuser")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid ID token")
+	})
+
+	t.Run("rejects an ID token for another client", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.audience = "someone-else"
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.complete_login("", "testuser")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("fails the exchange when the verifier does not match", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.forgeChallenge = true
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.complete_login("", "testuser")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), "sign-in failed")
+	})
+
+	t.Run("rejects an unknown username without auto-provisioning", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.complete_login("", "stranger")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), `no account for username \"stranger\"`)
+	})
+
+	t.Run("auto-provisions an unknown username", func(t *testing.T) {
+		idp := newStubIdP(t)
+		cfg := idp.config()
+		cfg.AutoProvision = true
+		env := newOIDCTestEnv(t, idp, cfg)
+
+		rec := env.complete_login("", "stranger")
+
+		require.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+		var provisioned []string
+		var revoked int
+		for _, events := range env.events.streams {
+			for _, evt := range events {
+				switch evt.EventType {
+				case domain.EventAccountCreated:
+					var created domain.AccountCreated
+					require.NoError(t, json.Unmarshal(evt.Data, &created))
+					provisioned = append(provisioned, created.Username)
+				case domain.EventPATRevoked:
+					revoked++
+				}
+			}
+		}
+		assert.Contains(t, provisioned, "stranger")
+		assert.Equal(t, 1, revoked, "the unused initial PAT is revoked")
+	})
+
+	t.Run("links the identity on first sign-in", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.complete_login("", "testuser")
+
+		require.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+		linked := env.linked_identity("account-test-123")
+		assert.Equal(t, idp.issuer, linked.Issuer)
+		assert.Equal(t, "sub-testuser", linked.Subject)
+	})
+
+	t.Run("signs a linked identity in after its username changes", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+		env.identity_is_linked("sub-renamed", "account-test-123")
+
+		rec := env.complete_login("", "renamed")
+
+		require.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+		assert.Equal(t, "sso", env.created_pat("account-test-123").Label)
+	})
+
+	t.Run("signs a linked identity in without a verified username", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.unverified = true
+		env := newOIDCTestEnv(t, idp, idp.config())
+		env.identity_is_linked("sub-testuser", "account-test-123")
+
+		rec := env.complete_login("", "testuser")
+
+		assert.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+	})
+
+	t.Run("does not link by an unverified username", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.unverified = true
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.complete_login("", "testuser")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), "no verified email claim")
+		for _, evt := range env.events.streams[domain.AdminRealmID+"|account-account-test-123"] {
+			assert.NotEqual(t, domain.EventSSOIdentityLinked, evt.EventType)
+		}
+	})
+
+	t.Run("links by an unverified username configured as trusted", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.unverified = true
+		cfg := idp.config()
+		cfg.UsernameClaimVerified = true
+		env := newOIDCTestEnv(t, idp, cfg)
+
+		rec := env.complete_login("", "testuser")
+
+		require.Equal(t, http.StatusFound, rec.Code, rec.Body.String())
+		assert.Equal(t, "sub-testuser", env.linked_identity("account-test-123").Subject)
+	})
+
+	t.Run("does not auto-provision an unverified username", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.unverified = true
+		cfg := idp.config()
+		cfg.AutoProvision = true
+		env := newOIDCTestEnv(t, idp, cfg)
+
+		rec := env.complete_login("", "stranger")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Len(t, env.events.streams, 1, "only the existing account's stream")
+	})
+
+	t.Run("rejects a suspended account", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+		entry := env.store.data[compositeKey("_admin", "account_auth", "account-test-123")].(projectors.AccountAuthEntry)
+		entry.Status = "suspended"
+		env.store.data[compositeKey("_admin", "account_auth", "account-test-123")] = entry
+
+		rec := env.complete_login("", "testuser")
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("reports a provider error", func(t *testing.T) {
+		idp := newStubIdP(t)
+		env := newOIDCTestEnv(t, idp, idp.config())
+
+		rec := env.get("/api/ui/oidc/callback?error=access_denied", nil)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), "access_denied")
+	})
+}
+
+// --- Stub identity provider ---
+
+type stubGrant struct {
+	challenge string
+	nonce     string
+	username  string
+}
+
+type stubIdP struct {
+	t              *testing.T
+	server         *httptest.Server
+	key            *rsa.PrivateKey
+	issuer         string
+	audience       string
+	nonceOverride  string
+	forgeChallenge bool
+	unverified     bool
+	grants         map[string]stubGrant
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{t: t, key: key, audience: "bifrost", grants: map[string]stubGrant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.issuer,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", idp.handleToken)
+	idp.server = httptest.NewServer(mux)
+	idp.issuer = idp.server.URL
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *stubIdP) config() OIDCConfig {
+	return OIDCConfig{
+		IssuerURL:    idp.server.URL,
+		ClientID:     "bifrost",
+		ClientSecret: "secret",
+		RedirectURL:  "http://bifrost.test/api/ui/oidc/callback",
+	}
+}
+
+// authorize stands in for the user signing in at the provider: it records a
+// grant for the login's challenge and nonce and returns its code.
+func (idp *stubIdP) authorize(authURL, username string) (code, state string) {
+	idp.t.Helper()
+	u, err := url.Parse(authURL)
+	require.NoError(idp.t, err)
+	q := u.Query()
+	code = "code-" + username
+	challenge := q.Get("code_challenge")
+	if idp.forgeChallenge {
+		challenge = "forged"
+	}
+	idp.grants[code] = stubGrant{challenge: challenge, nonce: q.Get("nonce"), username: username}
+	return code, q.Get("state")
+}
+
+func (idp *stubIdP) handleToken(w http.ResponseWriter, r *http.Request) {
+	require.NoError(idp.t, r.ParseForm())
+	grant, ok := idp.grants[r.Form.Get("code")]
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge || r.Form.Get("client_secret") != "secret" {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	nonce := grant.nonce
+	if idp.nonceOverride != "" {
+		nonce = idp.nonceOverride
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            idp.issuer,
+		"aud":            idp.audience,
+		"sub":            "sub-" + grant.username,
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"nonce":          nonce,
+		"email":          grant.username,
+		"email_verified": !idp.unverified,
+	})
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(idp.key)
+	require.NoError(idp.t, err)
+	_ = json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "token_type": "Bearer"})
+}
+
+// --- Test environment ---
+
+type oidcTestEnv struct {
+	t      *testing.T
+	idp    *stubIdP
+	cfg    *RouteConfig
+	store  *mockProjectionStore
+	events *mockEventStore
+	mux    *http.ServeMux
+}
+
+func newOIDCTestEnv(t *testing.T, idp *stubIdP, oidcCfg OIDCConfig) *oidcTestEnv {
+	t.Helper()
+	provider, err := DiscoverOIDC(context.Background(), oidcCfg)
+	require.NoError(t, err)
+
+	store := newMockProjectionStoreWithAccount()
+	store.data[compositeKey("_admin", "username_lookup", "testuser")] = projectors.UsernameLookupEntry{Username: "testuser", AccountID: "account-test-123"}
+	events := newMockEventStore()
+	_, err = events.Append(context.Background(), domain.AdminRealmID, "account-account-test-123", 0, []core.EventData{
+		{EventType: domain.EventAccountCreated, Data: domain.AccountCreated{AccountID: "account-test-123", Username: "testuser"}},
+	})
+	require.NoError(t, err)
+
+	authCfg := DefaultAuthConfig()
+	authCfg.SigningKey = make([]byte, 32)
+	_, err = rand.Read(authCfg.SigningKey)
+	require.NoError(t, err)
+
+	cfg := &RouteConfig{AuthConfig: authCfg, ProjectionStore: store, EventStore: events, OIDC: provider}
+	mux := http.NewServeMux()
+	_, err = RegisterRoutes(mux, cfg)
+	require.NoError(t, err)
+	return &oidcTestEnv{t: t, idp: idp, cfg: cfg, store: store, events: events, mux: mux}
+}
+
+func (env *oidcTestEnv) get(target string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	env.t.Helper()
+	req := httptest.NewRequest("GET", target, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	env.mux.ServeHTTP(rec, req)
+	return rec
+}
+
+// complete_login runs a login through the stub provider and returns the
+// callback response.
+func (env *oidcTestEnv) complete_login(cliCallback, username string) *httptest.ResponseRecorder {
+	env.t.Helper()
+	target := "/api/ui/oidc/login"
+	if cliCallback != "" {
+		target += "?cli_callback=" + url.QueryEscape(cliCallback)
+	}
+	login := env.get(target, nil)
+	require.Equal(env.t, http.StatusFound, login.Code, login.Body.String())
+
+	code, state := env.idp.authorize(login.Header().Get("Location"), username)
+	callback := "/api/ui/oidc/callback?" + url.Values{"code": {code}, "state": {state}}.Encode()
+	return env.get(callback, findCookie(login, oidcLoginCookie))
+}
+
+func (env *oidcTestEnv) created_pat(accountID string) domain.PATCreated {
+	env.t.Helper()
+	var pat domain.PATCreated
+	for _, evt := range env.events.streams[domain.AdminRealmID+"|account-"+accountID] {
+		if evt.EventType == domain.EventPATCreated {
+			require.NoError(env.t, json.Unmarshal(evt.Data, &pat))
+		}
+	}
+	require.NotEmpty(env.t, pat.PATID, "expected a PATCreated event")
+	return pat
+}
+
+// identity_is_linked records the stub provider's subject as linked to the account.
+func (env *oidcTestEnv) identity_is_linked(subject, accountID string) {
+	env.t.Helper()
+	key := domain.SSOIdentityKey(env.idp.issuer, subject)
+	env.store.data[compositeKey("_admin", "sso_identity", key)] = projectors.SSOIdentityEntry{
+		Issuer: env.idp.issuer, Subject: subject, AccountID: accountID,
+	}
+}
+
+func (env *oidcTestEnv) linked_identity(accountID string) domain.SSOIdentityLinked {
+	env.t.Helper()
+	var linked domain.SSOIdentityLinked
+	for _, evt := range env.events.streams[domain.AdminRealmID+"|account-"+accountID] {
+		if evt.EventType == domain.EventSSOIdentityLinked {
+			require.NoError(env.t, json.Unmarshal(evt.Data, &linked))
+		}
+	}
+	require.NotEmpty(env.t, linked.Subject, "expected an SSOIdentityLinked event")
+	return linked
+}
+
+func findCookie(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == name && c.MaxAge >= 0 {
+			return c
+		}
+	}
+	return nil
+}
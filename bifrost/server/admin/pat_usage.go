@@ -0,0 +1,166 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+)
+
+// PATUsageTable holds per-PAT usage. It is written directly rather than
+// projected from events, so it survives projection rebuilds and recording a
+// request never appends to the event store.
+const PATUsageTable = "pat_usage"
+
+// DefaultPATUsageFlushInterval is how often recorded usage is written out.
+const DefaultPATUsageFlushInterval = 30 * time.Second
+
+// PATUsage is the pat_usage document for a PAT.
+// Key: PAT ID, stored in the _admin realm.
+type PATUsage struct {
+	PATID        string    `json:"pat_id"`
+	LastUsedAt   time.Time `json:"last_used_at"`
+	LastIP       string    `json:"last_ip,omitempty"`
+	RequestCount int64     `json:"request_count"`
+}
+
+func (u PATUsage) merge(pending PATUsage) PATUsage {
+	u.PATID = pending.PATID
+	if pending.LastUsedAt.After(u.LastUsedAt) {
+		u.LastUsedAt = pending.LastUsedAt
+		u.LastIP = pending.LastIP
+	}
+	u.RequestCount += pending.RequestCount
+	return u
+}
+
+// PATUsageRecorder coalesces PAT usage in memory and writes it to the
+// pat_usage table at most once per flush interval per PAT. A nil recorder
+// records nothing.
+type PATUsageRecorder struct {
+	store    core.ProjectionStore
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[string]PATUsage
+}
+
+// NewPATUsageRecorder creates a recorder that flushes to store every interval.
+func NewPATUsageRecorder(store core.ProjectionStore, interval time.Duration) *PATUsageRecorder {
+	if interval <= 0 {
+		interval = DefaultPATUsageFlushInterval
+	}
+	return &PATUsageRecorder{
+		store:    store,
+		interval: interval,
+		pending:  make(map[string]PATUsage),
+	}
+}
+
+// Record notes one request authenticated by patID from ip at the given time.
+func (r *PATUsageRecorder) Record(patID, ip string, at time.Time) {
+	if r == nil || patID == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending[patID] = r.pending[patID].merge(PATUsage{
+		PATID:        patID,
+		LastUsedAt:   at.UTC(),
+		LastIP:       ip,
+		RequestCount: 1,
+	})
+}
+
+// Flush writes all pending usage to the store. Usage that fails to write is
+// kept for the next flush.
+func (r *PATUsageRecorder) Flush(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string]PATUsage)
+	r.mu.Unlock()
+
+	var errs []error
+	for patID, usage := range pending {
+		stored, err := readPATUsage(ctx, r.store, patID)
+		if err == nil {
+			err = r.store.Put(ctx, domain.AdminRealmID, PATUsageTable, patID, stored.merge(usage))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("flush usage for PAT %s: %w", patID, err))
+			r.mu.Lock()
+			r.pending[patID] = r.pending[patID].merge(usage)
+			r.mu.Unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run flushes pending usage every interval until ctx is cancelled, then
+// flushes once more.
+func (r *PATUsageRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				log.Printf("pat usage: %v", err)
+			}
+		case <-ctx.Done():
+			if err := r.Flush(context.Background()); err != nil {
+				log.Printf("pat usage: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// Usage returns the usage for patID, including usage not yet flushed. It
+// reports false when the PAT has never been used.
+func (r *PATUsageRecorder) Usage(ctx context.Context, patID string) (PATUsage, bool, error) {
+	if r == nil {
+		return PATUsage{}, false, nil
+	}
+	usage, err := readPATUsage(ctx, r.store, patID)
+	if err != nil {
+		return PATUsage{}, false, err
+	}
+	r.mu.Lock()
+	if pending, ok := r.pending[patID]; ok {
+		usage = usage.merge(pending)
+	}
+	r.mu.Unlock()
+	return usage, usage.RequestCount > 0, nil
+}
+
+func readPATUsage(ctx context.Context, store core.ProjectionStore, patID string) (PATUsage, error) {
+	var usage PATUsage
+	if err := store.Get(ctx, domain.AdminRealmID, PATUsageTable, patID, &usage); err != nil {
+		var nfe *core.NotFoundError
+		if errors.As(err, &nfe) {
+			return PATUsage{PATID: patID}, nil
+		}
+		return PATUsage{}, err
+	}
+	return usage, nil
+}
+
+// ClientIP returns the address of the client that sent r.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
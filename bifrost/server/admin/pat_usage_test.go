@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPATUsageRecorder(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("coalesces requests until flushed", func(t *testing.T) {
+		store := newMockProjectionStore()
+		recorder := NewPATUsageRecorder(store, time.Minute)
+
+		recorder.Record("pat-1", "10.0.0.1", t0)
+		recorder.Record("pat-1", "10.0.0.2", t0.Add(time.Second))
+
+		assert.Empty(t, store.data)
+		require.NoError(t, recorder.Flush(ctx))
+
+		usage, used, err := recorder.Usage(ctx, "pat-1")
+		require.NoError(t, err)
+		assert.True(t, used)
+		assert.Equal(t, int64(2), usage.RequestCount)
+		assert.Equal(t, "10.0.0.2", usage.LastIP)
+		assert.True(t, t0.Add(time.Second).Equal(usage.LastUsedAt))
+	})
+
+	t.Run("adds flushed requests to stored usage", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.data[compositeKey("_admin", PATUsageTable, "pat-1")] = PATUsage{
+			PATID: "pat-1", LastUsedAt: t0, LastIP: "10.0.0.1", RequestCount: 5,
+		}
+		recorder := NewPATUsageRecorder(store, time.Minute)
+
+		recorder.Record("pat-1", "10.0.0.9", t0.Add(time.Hour))
+		require.NoError(t, recorder.Flush(ctx))
+
+		stored := store.data[compositeKey("_admin", PATUsageTable, "pat-1")].(PATUsage)
+		assert.Equal(t, int64(6), stored.RequestCount)
+		assert.Equal(t, "10.0.0.9", stored.LastIP)
+	})
+
+	t.Run("reports unflushed usage", func(t *testing.T) {
+		store := newMockProjectionStore()
+		recorder := NewPATUsageRecorder(store, time.Minute)
+
+		recorder.Record("pat-1", "10.0.0.1", t0)
+
+		usage, used, err := recorder.Usage(ctx, "pat-1")
+		require.NoError(t, err)
+		assert.True(t, used)
+		assert.Equal(t, int64(1), usage.RequestCount)
+	})
+
+	t.Run("reports an unused PAT", func(t *testing.T) {
+		recorder := NewPATUsageRecorder(newMockProjectionStore(), time.Minute)
+
+		_, used, err := recorder.Usage(ctx, "pat-1")
+		require.NoError(t, err)
+		assert.False(t, used)
+	})
+
+	t.Run("keeps usage that failed to flush", func(t *testing.T) {
+		store := newMockProjectionStore()
+		store.getError = errors.New("db error")
+		recorder := NewPATUsageRecorder(store, time.Minute)
+
+		recorder.Record("pat-1", "10.0.0.1", t0)
+		assert.Error(t, recorder.Flush(ctx))
+
+		store.getError = nil
+		require.NoError(t, recorder.Flush(ctx))
+		stored := store.data[compositeKey("_admin", PATUsageTable, "pat-1")].(PATUsage)
+		assert.Equal(t, int64(1), stored.RequestCount)
+	})
+
+	t.Run("nil recorder records nothing", func(t *testing.T) {
+		var recorder *PATUsageRecorder
+
+		recorder.Record("pat-1", "10.0.0.1", t0)
+		assert.NoError(t, recorder.Flush(ctx))
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/pats", nil)
+	req.RemoteAddr = "192.0.2.7:51234"
+
+	assert.Equal(t, "192.0.2.7", ClientIP(req))
+}
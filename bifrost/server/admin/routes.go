@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/fs"
 	"net/http"
+	"time"
 
 	"github.com/devzeebo/bifrost/core"
 )
@@ -19,6 +20,12 @@ type RouteConfig struct {
 	ProjectionStore   core.ProjectionStore
 	EventStore        core.EventStore
 	ProjectionEngine  ProjectionEngine
+	OIDC              *OIDCProvider // Optional: enables single sign-on
+	Devices           *DeviceAuthorizations // Optional: pending bf login --web logins. Defaults to an in-memory store.
+	DeviceTokenTTL    time.Duration         // Lifetime of PATs issued to bf login --web. Defaults to 30 days.
+	// RateLimit wraps the API routes before authentication, e.g. to limit
+	// sign-in attempts per client IP. Optional.
+	RateLimit func(http.Handler) http.Handler
 	ViteDevServerURL  string // URL of Vite dev server (development mode, e.g., "http://localhost:3000")
 	UIFS              fs.FS  // Optional: custom filesystem for UI (for testing). Defaults to embedded UIFiles.
 }
@@ -31,11 +38,40 @@ type RegisterRoutesResult struct {
 // RegisterRoutes registers API routes and UI proxy routes.
 // This is a simplified version without the old template-based admin UI.
 func RegisterRoutes(mux *http.ServeMux, cfg *RouteConfig) (*RegisterRoutesResult, error) {
+	// API routes are served from their own mux, mounted on /api/, so
+	// RateLimit runs before they authenticate. Routes registered on mux
+	// with a full path, such as the realm API, take precedence over it.
+	api := http.NewServeMux()
+
 	// Register session API routes for Vike/React UI
-	RegisterSessionAPIRoutes(mux, cfg)
+	RegisterSessionAPIRoutes(api, cfg)
+
+	// Register single sign-on routes when OIDC is configured
+	RegisterOIDCRoutes(api, cfg)
+
+	// Register device authorization routes for bf login --web
+	if cfg.Devices == nil {
+		cfg.Devices = NewDeviceAuthorizations(DefaultDeviceCodeTTL, DefaultDevicePollInterval)
+	}
+	RegisterDeviceAPIRoutes(api, cfg)
+
+	// Register PAT to access token exchange
+	RegisterTokenAPIRoutes(api, cfg)
 
 	// Register accounts JSON API routes for Vike/React UI
-	RegisterAccountsAPIRoutes(mux, cfg)
+	RegisterAccountsAPIRoutes(api, cfg)
+
+	// Register group management API routes
+	RegisterGroupsAPIRoutes(api, cfg)
+
+	// Register audit log query route
+	RegisterAuditAPIRoutes(api, cfg)
+
+	var apiHandler http.Handler = api
+	if cfg.RateLimit != nil {
+		apiHandler = cfg.RateLimit(api)
+	}
+	mux.Handle("/api/", apiHandler)
 
 	// Register new /ui/ routes (development or production)
 	registerUIRoutes(mux, cfg)
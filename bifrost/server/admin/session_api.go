@@ -62,6 +62,7 @@ type OnboardingCheckResponse struct {
 	NeedsSysAdmin bool `json:"needs_sysadmin"` // true if no sysadmin exists
 	NeedsRealm    bool `json:"needs_realm"`    // true if no realms exist (excluding _admin)
 	NeedsOnboarding bool `json:"needs_onboarding"` // true if either is needed
+	SSOEnabled      bool `json:"sso_enabled"`      // true if OIDC single sign-on is configured
 }
 
 // CreateAdminRequest is the request body for POST /ui/onboarding/create-admin.
@@ -106,12 +107,17 @@ func handleUILogin(cfg *RouteConfig) http.HandlerFunc {
 		}
 
 		// Validate PAT
-		entry, patID, err := ValidatePAT(r.Context(), cfg.ProjectionStore, pat)
+		entry, patEntry, err := validatePAT(r.Context(), cfg.ProjectionStore, pat)
 		if err != nil {
+			recordLoginFailure(r, cfg, "", "pat", err.Error())
 			if errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrPATRevoked) {
 				writeError(w, http.StatusUnauthorized, "invalid or revoked PAT")
 				return
 			}
+			if errors.Is(err, ErrPATExpired) {
+				writeError(w, http.StatusUnauthorized, "PAT expired")
+				return
+			}
 			if errors.Is(err, ErrAccountSuspended) {
 				writeError(w, http.StatusUnauthorized, "account suspended")
 				return
@@ -120,10 +126,26 @@ func handleUILogin(cfg *RouteConfig) http.HandlerFunc {
 			return
 		}
 
+		if entry.Kind == domain.AccountKindService {
+			recordLoginFailure(r, cfg, entry.AccountID, "pat", "service accounts cannot sign in")
+			writeError(w, http.StatusForbidden, "service accounts cannot sign in")
+			return
+		}
+
+		// A command allowlist is meant for API clients; the UI calls endpoints it would not list.
+		if patEntry.Scopes != nil && len(patEntry.Scopes.Commands) > 0 {
+			recordLoginFailure(r, cfg, entry.AccountID, "pat", "PAT is limited to specific commands")
+			writeError(w, http.StatusForbidden, "PAT is limited to specific commands and cannot sign in")
+			return
+		}
+
+		cfg.AuthConfig.Usage.Record(patEntry.PATID, ClientIP(r), time.Now())
+		recordLoginSuccess(r, cfg, entry.AccountID, patEntry.PATID, "pat")
+
 		sessionTTL := getSessionTTL(cfg.AuthConfig, req.RememberMe)
 
 		// Generate JWT
-		token, err := GenerateJWTWithExpiry(cfg.AuthConfig, entry.AccountID, patID, sessionTTL)
+		token, err := GenerateJWTWithExpiry(cfg.AuthConfig, entry.AccountID, patEntry.PATID, sessionTTL)
 		if err != nil {
 			writeError(w, http.StatusInternalServerError, "failed to create session")
 			return
@@ -202,7 +224,7 @@ func handleUISession(cfg *RouteConfig) http.HandlerFunc {
 		}
 
 		// Check PAT status
-		entry, err := CheckPATStatus(r.Context(), cfg.ProjectionStore, claims.PATID)
+		entry, _, err := CheckPATStatus(r.Context(), cfg.ProjectionStore, claims.PATID)
 		if err != nil {
 			writeError(w, http.StatusUnauthorized, "unauthorized")
 			return
@@ -254,6 +276,7 @@ func handleCheckOnboarding(cfg *RouteConfig) http.HandlerFunc {
 			NeedsSysAdmin:  needsSysAdmin,
 			NeedsRealm:     needsRealm,
 			NeedsOnboarding: needsSysAdmin || needsRealm,
+			SSOEnabled:      cfg.OIDC != nil,
 		}
 
 		w.Header().Set("Content-Type", "application/json")
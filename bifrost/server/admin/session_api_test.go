@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
 	"github.com/devzeebo/bifrost/domain/projectors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -115,6 +116,28 @@ func TestUISessionAPI_Login(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	})
 
+	t.Run("login with a service account PAT returns 403", func(t *testing.T) {
+		serviceStore := newMockProjectionStoreWithAccount()
+		entry := serviceStore.data[compositeKey("_admin", "account_auth", "account-test-123")].(projectors.AccountAuthEntry)
+		entry.Kind = domain.AccountKindService
+		serviceStore.data[compositeKey("_admin", "account_auth", "account-test-123")] = entry
+		serviceMux := http.NewServeMux()
+		_, err := RegisterRoutes(serviceMux, &RouteConfig{AuthConfig: cfg.AuthConfig, ProjectionStore: serviceStore})
+		require.NoError(t, err)
+
+		body, err := json.Marshal(LoginRequest{PAT: serviceStore.validToken})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/api/ui/login", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		serviceMux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), "service accounts cannot sign in")
+	})
+
 	t.Run("login with empty PAT returns 400", func(t *testing.T) {
 		loginReq := LoginRequest{PAT: ""}
 		body, err := json.Marshal(loginReq)
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TokenExchangeHeader is set on responses to requests authenticated by a
+// PAT, telling clients they can exchange it at POST /api/token.
+const TokenExchangeHeader = "X-Bifrost-Token-Exchange"
+
+// TokenExchangeResponse is the response for POST /api/token.
+type TokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// RegisterTokenAPIRoutes registers the token exchange route. A client trades
+// its PAT for a short-lived access token and sends that instead, so the
+// server can authorize its requests without looking up the PAT each time.
+func RegisterTokenAPIRoutes(mux *http.ServeMux, cfg *RouteConfig) {
+	mux.HandleFunc("POST /api/token", handleTokenExchange(cfg))
+}
+
+func handleTokenExchange(cfg *RouteConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pat := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if pat == "" || pat == r.Header.Get("Authorization") {
+			writeUnauthorized(w, ErrNoToken)
+			return
+		}
+		// Only a PAT can be exchanged, so an access token cannot renew itself.
+		if IsAccessToken(pat) {
+			writeUnauthorized(w, ErrInvalidToken)
+			return
+		}
+
+		entry, patEntry, err := validatePAT(r.Context(), cfg.ProjectionStore, pat)
+		if err != nil {
+			writeUnauthorized(w, err)
+			return
+		}
+		cfg.AuthConfig.Usage.Record(patEntry.PATID, ClientIP(r), time.Now())
+
+		token, expiresAt, err := GenerateAccessToken(cfg.AuthConfig, entry, patEntry.PATID, patEntry.Scopes, patEntry.ExpiresAt)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to issue access token")
+			return
+		}
+
+		resp := TokenExchangeResponse{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			ExpiresIn:   int(time.Until(expiresAt).Seconds()),
+			ExpiresAt:   expiresAt.UTC().Format(time.RFC3339),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("handleTokenExchange: failed to encode response: %v", err)
+		}
+	}
+}
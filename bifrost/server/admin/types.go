@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/devzeebo/bifrost/core"
 )
@@ -71,9 +72,33 @@ func handleDomainError(w http.ResponseWriter, err error) {
 		return
 	}
 
+	if isValidationError(err.Error()) {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
 	writeError(w, http.StatusInternalServerError, err.Error())
 }
 
+// isValidationError reports whether a domain error message describes a
+// rejected command rather than a server failure.
+func isValidationError(msg string) bool {
+	prefixes := []string{
+		"account ",
+		"realm ",
+		"PAT ",
+		"service account ",
+		"group ",
+		"invalid ",
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(msg, p) {
+			return true
+		}
+	}
+	return false
+}
+
 // RequireMemberMiddleware returns middleware that checks if the user has member+ role in a specific realm.
 func RequireMemberMiddleware(realmID string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
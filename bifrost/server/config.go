@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -17,6 +18,24 @@ type Config struct {
 	CatchUpInterval  time.Duration `yaml:"catchup_interval"`
 	ViteDevServerURL string        `yaml:"vite_dev_server_url"`
 	JWTSigningKey    string       `yaml:"jwt_signing_key"`
+	DeviceTokenTTL   time.Duration `yaml:"device_token_ttl"`
+	OIDC             OIDCConfig    `yaml:"oidc"`
+	RateLimit        RateLimitConfig `yaml:"rate_limit"`
+	// WebhookAllowedHosts lists the non-public hostnames, addresses and
+	// CIDR networks webhooks may be sent to.
+	WebhookAllowedHosts []string `yaml:"webhook_allowed_hosts"`
+}
+
+// OIDCConfig configures single sign-on. SSO is off while IssuerURL is empty.
+type OIDCConfig struct {
+	IssuerURL             string   `yaml:"issuer_url"`
+	ClientID              string   `yaml:"client_id"`
+	ClientSecret          string   `yaml:"client_secret"`
+	RedirectURL           string   `yaml:"redirect_url"`
+	Scopes                []string `yaml:"scopes"`
+	UsernameClaim         string   `yaml:"username_claim"`
+	UsernameClaimVerified bool     `yaml:"username_claim_verified"`
+	AutoProvision         bool     `yaml:"auto_provision"`
 }
 
 type configFile struct {
@@ -25,6 +44,23 @@ type configFile struct {
 	Port            int    `yaml:"port"`
 	CatchUpInterval string `yaml:"catchup_interval"`
 	JWTSigningKey   string `yaml:"jwt_signing_key"`
+	DeviceTokenTTL  string `yaml:"device_token_ttl"`
+	OIDC            OIDCConfig `yaml:"oidc"`
+	RateLimit       rateLimitFile `yaml:"rate_limit"`
+	WebhookAllowedHosts []string `yaml:"webhook_allowed_hosts"`
+}
+
+// rateLimitFile distinguishes an unset rate limit value, which keeps the
+// default, from an explicit zero, which disables the budget.
+type rateLimitFile struct {
+	ReadRate        *float64 `yaml:"read_rate"`
+	ReadBurst       *int     `yaml:"read_burst"`
+	WriteRate       *float64 `yaml:"write_rate"`
+	WriteBurst      *int     `yaml:"write_burst"`
+	RealmReadRate   *float64 `yaml:"realm_read_rate"`
+	RealmReadBurst  *int     `yaml:"realm_read_burst"`
+	RealmWriteRate  *float64 `yaml:"realm_write_rate"`
+	RealmWriteBurst *int     `yaml:"realm_write_burst"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -41,6 +77,7 @@ func LoadConfigWithPaths(configPaths []string) (*Config, error) {
 		DBPath:          "./bifrost.db",
 		Port:            8080,
 		CatchUpInterval: 1 * time.Second,
+		RateLimit:       DefaultRateLimitConfig(),
 	}
 
 	// Load from config file first
@@ -98,6 +135,41 @@ func loadConfigFile(cfg *Config, configPaths []string) error {
 	if cf.JWTSigningKey != "" {
 		cfg.JWTSigningKey = cf.JWTSigningKey
 	}
+	if cf.DeviceTokenTTL != "" {
+		d, err := time.ParseDuration(cf.DeviceTokenTTL)
+		if err != nil {
+			return fmt.Errorf("parse device_token_ttl: %w", err)
+		}
+		cfg.DeviceTokenTTL = d
+	}
+	cfg.OIDC = cf.OIDC
+	if cf.RateLimit.ReadRate != nil {
+		cfg.RateLimit.ReadRate = *cf.RateLimit.ReadRate
+	}
+	if cf.RateLimit.ReadBurst != nil {
+		cfg.RateLimit.ReadBurst = *cf.RateLimit.ReadBurst
+	}
+	if cf.RateLimit.WriteRate != nil {
+		cfg.RateLimit.WriteRate = *cf.RateLimit.WriteRate
+	}
+	if cf.RateLimit.WriteBurst != nil {
+		cfg.RateLimit.WriteBurst = *cf.RateLimit.WriteBurst
+	}
+	if cf.RateLimit.RealmReadRate != nil {
+		cfg.RateLimit.RealmReadRate = *cf.RateLimit.RealmReadRate
+	}
+	if cf.RateLimit.RealmReadBurst != nil {
+		cfg.RateLimit.RealmReadBurst = *cf.RateLimit.RealmReadBurst
+	}
+	if cf.RateLimit.RealmWriteRate != nil {
+		cfg.RateLimit.RealmWriteRate = *cf.RateLimit.RealmWriteRate
+	}
+	if cf.RateLimit.RealmWriteBurst != nil {
+		cfg.RateLimit.RealmWriteBurst = *cf.RateLimit.RealmWriteBurst
+	}
+	if len(cf.WebhookAllowedHosts) > 0 {
+		cfg.WebhookAllowedHosts = cf.WebhookAllowedHosts
+	}
 
 	return nil
 }
@@ -140,10 +212,35 @@ func applyEnvOverrides(cfg *Config) error {
 		cfg.CatchUpInterval = d
 	}
 
+	if ttlStr := os.Getenv("BIFROST_DEVICE_TOKEN_TTL"); ttlStr != "" {
+		d, err := time.ParseDuration(ttlStr)
+		if err != nil {
+			return fmt.Errorf("BIFROST_DEVICE_TOKEN_TTL must be a valid duration: %w", err)
+		}
+		cfg.DeviceTokenTTL = d
+	}
+
 	if url := os.Getenv("BIFROST_VITE_DEV_SERVER_URL"); url != "" {
 		cfg.ViteDevServerURL = url
 	}
 
+	if err := applyOIDCEnvOverrides(&cfg.OIDC); err != nil {
+		return err
+	}
+
+	if err := applyRateLimitEnvOverrides(&cfg.RateLimit); err != nil {
+		return err
+	}
+
+	if v := os.Getenv("BIFROST_WEBHOOK_ALLOWED_HOSTS"); v != "" {
+		cfg.WebhookAllowedHosts = nil
+		for _, host := range strings.Split(v, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				cfg.WebhookAllowedHosts = append(cfg.WebhookAllowedHosts, host)
+			}
+		}
+	}
+
 	// Set default DB path based on driver if still at default
 	if cfg.DBPath == "./bifrost.db" && cfg.DBDriver == "postgres" {
 		cfg.DBPath = "postgres://localhost/bifrost?sslmode=disable"
@@ -151,3 +248,78 @@ func applyEnvOverrides(cfg *Config) error {
 
 	return nil
 }
+
+func applyOIDCEnvOverrides(oidc *OIDCConfig) error {
+	if v := os.Getenv("BIFROST_OIDC_ISSUER_URL"); v != "" {
+		oidc.IssuerURL = v
+	}
+	if v := os.Getenv("BIFROST_OIDC_CLIENT_ID"); v != "" {
+		oidc.ClientID = v
+	}
+	if v := os.Getenv("BIFROST_OIDC_CLIENT_SECRET"); v != "" {
+		oidc.ClientSecret = v
+	}
+	if v := os.Getenv("BIFROST_OIDC_REDIRECT_URL"); v != "" {
+		oidc.RedirectURL = v
+	}
+	if v := os.Getenv("BIFROST_OIDC_USERNAME_CLAIM"); v != "" {
+		oidc.UsernameClaim = v
+	}
+	if v := os.Getenv("BIFROST_OIDC_USERNAME_CLAIM_VERIFIED"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("BIFROST_OIDC_USERNAME_CLAIM_VERIFIED must be true or false: %w", err)
+		}
+		oidc.UsernameClaimVerified = b
+	}
+	if v := os.Getenv("BIFROST_OIDC_AUTO_PROVISION"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("BIFROST_OIDC_AUTO_PROVISION must be true or false: %w", err)
+		}
+		oidc.AutoProvision = b
+	}
+
+	if oidc.IssuerURL != "" && (oidc.ClientID == "" || oidc.RedirectURL == "") {
+		return fmt.Errorf("OIDC issuer_url requires client_id and redirect_url")
+	}
+	return nil
+}
+
+func applyRateLimitEnvOverrides(rl *RateLimitConfig) error {
+	for _, rate := range []struct {
+		env  string
+		dest *float64
+	}{
+		{"BIFROST_RATE_LIMIT_READ_RATE", &rl.ReadRate},
+		{"BIFROST_RATE_LIMIT_WRITE_RATE", &rl.WriteRate},
+		{"BIFROST_RATE_LIMIT_REALM_READ_RATE", &rl.RealmReadRate},
+		{"BIFROST_RATE_LIMIT_REALM_WRITE_RATE", &rl.RealmWriteRate},
+	} {
+		if v := os.Getenv(rate.env); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil || f < 0 {
+				return fmt.Errorf("%s must be a non-negative number", rate.env)
+			}
+			*rate.dest = f
+		}
+	}
+	for _, burst := range []struct {
+		env  string
+		dest *int
+	}{
+		{"BIFROST_RATE_LIMIT_READ_BURST", &rl.ReadBurst},
+		{"BIFROST_RATE_LIMIT_WRITE_BURST", &rl.WriteBurst},
+		{"BIFROST_RATE_LIMIT_REALM_READ_BURST", &rl.RealmReadBurst},
+		{"BIFROST_RATE_LIMIT_REALM_WRITE_BURST", &rl.RealmWriteBurst},
+	} {
+		if v := os.Getenv(burst.env); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%s must be a non-negative integer", burst.env)
+			}
+			*burst.dest = n
+		}
+	}
+	return nil
+}
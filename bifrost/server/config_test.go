@@ -1,6 +1,8 @@
 package server
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -111,6 +113,146 @@ func TestLoadConfig(t *testing.T) {
 		tc.config_has_no_error()
 		tc.catchup_interval_is(2 * time.Second)
 	})
+
+	t.Run("reads OIDC settings from env vars", func(t *testing.T) {
+		tc := newConfigTestContext(t)
+
+		// Given
+		tc.env_var("BIFROST_OIDC_ISSUER_URL", "https://idp.example.com")
+		tc.env_var("BIFROST_OIDC_CLIENT_ID", "bifrost")
+		tc.env_var("BIFROST_OIDC_REDIRECT_URL", "https://bifrost.example.com/api/ui/oidc/callback")
+		tc.env_var("BIFROST_OIDC_USERNAME_CLAIM_VERIFIED", "true")
+		tc.env_var("BIFROST_OIDC_AUTO_PROVISION", "true")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_no_error()
+		assert.Equal(t, OIDCConfig{
+			IssuerURL:             "https://idp.example.com",
+			ClientID:              "bifrost",
+			RedirectURL:           "https://bifrost.example.com/api/ui/oidc/callback",
+			UsernameClaimVerified: true,
+			AutoProvision:         true,
+		}, tc.cfg.OIDC)
+	})
+
+	t.Run("returns error when OIDC issuer has no client ID", func(t *testing.T) {
+		tc := newConfigTestContext(t)
+
+		// Given
+		tc.env_var("BIFROST_OIDC_ISSUER_URL", "https://idp.example.com")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_error_containing("client_id")
+	})
+
+	t.Run("applies default rate limits", func(t *testing.T) {
+		tc := newConfigTestContext(t)
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_no_error()
+		assert.Equal(t, DefaultRateLimitConfig(), tc.cfg.RateLimit)
+	})
+
+	t.Run("reads rate limits from env vars", func(t *testing.T) {
+		tc := newConfigTestContext(t)
+
+		// Given
+		tc.env_var("BIFROST_RATE_LIMIT_READ_RATE", "2.5")
+		tc.env_var("BIFROST_RATE_LIMIT_WRITE_BURST", "3")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_no_error()
+		assert.Equal(t, 2.5, tc.cfg.RateLimit.ReadRate)
+		assert.Equal(t, 3, tc.cfg.RateLimit.WriteBurst)
+	})
+
+	t.Run("returns error when a rate limit is negative", func(t *testing.T) {
+		tc := newConfigTestContext(t)
+
+		// Given
+		tc.env_var("BIFROST_RATE_LIMIT_WRITE_RATE", "-1")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_error_containing("BIFROST_RATE_LIMIT_WRITE_RATE")
+	})
+
+	t.Run("a zero rate in the config file disables that budget", func(t *testing.T) {
+		tc := newConfigTestContext(t)
+
+		// Given
+		tc.config_file("rate_limit:\n  write_rate: 0\n")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_no_error()
+		assert.Zero(t, tc.cfg.RateLimit.WriteRate)
+		assert.Equal(t, DefaultRateLimitConfig().ReadRate, tc.cfg.RateLimit.ReadRate)
+	})
+
+	t.Run("reads the device token TTL from the config file and env vars", func(t *testing.T) {
+		tc := newConfigTestContext(t)
+
+		// Given
+		tc.config_file("device_token_ttl: 48h\n")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_no_error()
+		assert.Equal(t, 48*time.Hour, tc.cfg.DeviceTokenTTL)
+
+		// Given
+		tc.env_var("BIFROST_DEVICE_TOKEN_TTL", "12h")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_no_error()
+		assert.Equal(t, 12*time.Hour, tc.cfg.DeviceTokenTTL)
+	})
+
+	t.Run("reads webhook allowed hosts from the config file and env vars", func(t *testing.T) {
+		tc := newConfigTestContext(t)
+
+		// Given
+		tc.config_file("webhook_allowed_hosts: [ci.internal, 10.0.0.0/8]\n")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_no_error()
+		assert.Equal(t, []string{"ci.internal", "10.0.0.0/8"}, tc.cfg.WebhookAllowedHosts)
+
+		// Given
+		tc.env_var("BIFROST_WEBHOOK_ALLOWED_HOSTS", "localhost, 192.168.0.0/16")
+
+		// When
+		tc.load_config()
+
+		// Then
+		tc.config_has_no_error()
+		assert.Equal(t, []string{"localhost", "192.168.0.0/16"}, tc.cfg.WebhookAllowedHosts)
+	})
 }
 
 // --- Test Context ---
@@ -118,6 +260,7 @@ func TestLoadConfig(t *testing.T) {
 type configTestContext struct {
 	t      *testing.T
 	envSet map[string]string
+	paths  []string
 
 	cfg *Config
 	err error
@@ -139,11 +282,18 @@ func (tc *configTestContext) env_var(key, value string) {
 	tc.t.Setenv(key, value)
 }
 
+func (tc *configTestContext) config_file(contents string) {
+	tc.t.Helper()
+	path := filepath.Join(tc.t.TempDir(), "server.yaml")
+	require.NoError(tc.t, os.WriteFile(path, []byte(contents), 0o600))
+	tc.paths = append(tc.paths, path)
+}
+
 // --- When ---
 
 func (tc *configTestContext) load_config() {
 	tc.t.Helper()
-	tc.cfg, tc.err = LoadConfigWithPaths([]string{}) // Empty array = no config files
+	tc.cfg, tc.err = LoadConfigWithPaths(tc.paths) // No paths = no config files
 }
 
 // --- Then ---
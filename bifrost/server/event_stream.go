@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+)
+
+// eventStreamHeartbeat is how often an idle stream sends a comment so
+// proxies do not close the connection.
+const eventStreamHeartbeat = 15 * time.Second
+
+// StreamedEvent is the data of one Server-Sent Event on GET /api/events/stream.
+// The event's id is its global position, which clients resume from.
+type StreamedEvent struct {
+	GlobalPosition int64           `json:"global_position"`
+	EventType      string          `json:"event_type"`
+	RealmID        string          `json:"realm_id"`
+	StreamID       string          `json:"stream_id"`
+	Version        int             `json:"version"`
+	RuneID         string          `json:"rune_id,omitempty"`
+	ActorID        string          `json:"actor_id,omitempty"`
+	Timestamp      time.Time       `json:"timestamp"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// eventStreamFilter selects the events a stream sends. Empty fields match
+// every event.
+type eventStreamFilter struct {
+	eventTypes []string
+	runeID     string
+	tags       []string
+}
+
+// EventStreamer serves realm events as Server-Sent Events. Each stream polls
+// the event store from the last position it sent, like the projection
+// engine's catch-up, so it needs no coordination with the writers.
+type EventStreamer struct {
+	eventStore      core.EventStore
+	projectionStore core.ProjectionStore
+	interval        time.Duration
+	heartbeat       time.Duration
+
+	// done is closed by Close to end every open stream.
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewEventStreamer creates a streamer whose streams poll for new events every interval.
+func NewEventStreamer(eventStore core.EventStore, projectionStore core.ProjectionStore, interval time.Duration) *EventStreamer {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &EventStreamer{
+		eventStore:      eventStore,
+		projectionStore: projectionStore,
+		interval:        interval,
+		heartbeat:       eventStreamHeartbeat,
+		done:            make(chan struct{}),
+	}
+}
+
+// Close ends every open stream. Handlers.Close calls it when the server
+// shuts down.
+func (s *EventStreamer) Close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+// Stream sends the realm's events after from that match filter until the
+// client disconnects, the streamer is closed, or the caller loses access.
+func (s *EventStreamer) Stream(w http.ResponseWriter, r *http.Request, realmID string, from int64, filter eventStreamFilter) {
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Stop nginx and similar proxies from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	poll := time.NewTicker(s.interval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(s.heartbeat)
+	defer heartbeat.Stop()
+
+	position := from
+	for {
+		if !s.authorized(ctx) {
+			fmt.Fprint(w, "event: error\ndata: {\"error\":\"access revoked\"}\n\n")
+			_ = rc.Flush()
+			return
+		}
+		next, err := s.send(ctx, w, realmID, position, filter)
+		if err != nil {
+			return
+		}
+		if next != position {
+			position = next
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+
+		select {
+		case <-poll.C:
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-s.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// send writes the events after position that match filter and returns the
+// position of the last event read. Only write errors are returned; a failed
+// read is retried on the next poll.
+func (s *EventStreamer) send(ctx context.Context, w http.ResponseWriter, realmID string, position int64, filter eventStreamFilter) (int64, error) {
+	events, err := s.eventStore.ReadAll(ctx, realmID, position)
+	if err != nil {
+		log.Printf("event stream: error reading events for realm %s: %v", realmID, err)
+		return position, nil
+	}
+	for _, event := range events {
+		position = event.GlobalPosition
+		runeID, _ := strings.CutPrefix(event.StreamID, "rune-")
+		if !s.matches(ctx, filter, realmID, runeID, event) {
+			continue
+		}
+		data, err := json.Marshal(StreamedEvent{
+			GlobalPosition: event.GlobalPosition,
+			EventType:      event.EventType,
+			RealmID:        realmID,
+			StreamID:       event.StreamID,
+			Version:        event.Version,
+			RuneID:         runeID,
+			ActorID:        core.ParseEventMetadata(event.Metadata).ActorID,
+			Timestamp:      event.Timestamp.UTC(),
+			Data:           json.RawMessage(event.Data),
+		})
+		if err != nil {
+			return position, err
+		}
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.GlobalPosition, event.EventType, data); err != nil {
+			return position, err
+		}
+	}
+	return position, nil
+}
+
+func (s *EventStreamer) matches(ctx context.Context, filter eventStreamFilter, realmID, runeID string, event core.Event) bool {
+	if len(filter.eventTypes) > 0 && !slices.Contains(filter.eventTypes, event.EventType) {
+		return false
+	}
+	if filter.runeID != "" && runeID != filter.runeID {
+		return false
+	}
+	if len(filter.tags) > 0 {
+		tags := runeTags(ctx, s.projectionStore, realmID, runeID, event)
+		return slices.ContainsFunc(filter.tags, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+	}
+	return true
+}
+
+// authorized reports whether the caller may still view the realm's runes.
+// A stream outlives the authentication of the request that opened it, so
+// revoking the token, suspending the account or changing its role ends it.
+func (s *EventStreamer) authorized(ctx context.Context) bool {
+	accountID, ok := AccountIDFromContext(ctx)
+	if !ok {
+		return true
+	}
+	if patID, _ := ctx.Value(patIDKey).(string); patID != "" {
+		var pat projectors.PATIDEntry
+		if err := s.projectionStore.Get(ctx, domain.AdminRealmID, "pat_by_id", patID, &pat); err != nil {
+			return false
+		}
+		if domain.IsExpired(pat.ExpiresAt, time.Now()) {
+			return false
+		}
+	}
+	var entry projectors.AccountAuthEntry
+	if err := s.projectionStore.Get(ctx, domain.AdminRealmID, "account_auth", accountID, &entry); err != nil {
+		return false
+	}
+	if entry.Status == "suspended" {
+		return false
+	}
+
+	realmID, _ := RealmIDFromContext(ctx)
+	scopes, _ := ctx.Value(patScopesKey).(*domain.PATScopes)
+	role := accountRealmRole(&entry, realmID)
+	if role == "" || !scopes.AllowsRealm(realmID) {
+		return false
+	}
+	perms, err := domain.RolePermissions(ctx, realmID, scopes.CapRole(role), s.projectionStore)
+	return err == nil && slices.Contains(perms, domain.PermViewRunes) && scopes.AllowsPermission(domain.PermViewRunes)
+}
+
+// lastEventID returns the position a reconnecting client resumes after: the
+// Last-Event-ID header an EventSource sends, else the last_event_id query
+// parameter for the first connection.
+func lastEventID(r *http.Request) (int64, bool, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, false, nil
+	}
+	position, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || position < 0 {
+		return 0, false, fmt.Errorf("invalid Last-Event-ID %q", value)
+	}
+	return position, true, nil
+}
+
+// head returns the global position of the realm's latest event, where a
+// stream that is not resuming starts. Stores that cannot look it up directly
+// have the realm's events read instead.
+func (s *EventStreamer) head(ctx context.Context, realmID string) (int64, error) {
+	if reader, ok := s.eventStore.(core.PositionReader); ok {
+		return reader.LatestPosition(ctx, realmID)
+	}
+	events, err := s.eventStore.ReadAll(ctx, realmID, 0)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	return events[len(events)-1].GlobalPosition, nil
+}
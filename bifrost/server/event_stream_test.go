@@ -0,0 +1,301 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+)
+
+// --- Tests ---
+
+func TestEventStream(t *testing.T) {
+	t.Run("streams events appended after the stream opens", func(t *testing.T) {
+		tc := newEventStreamTestContext(t)
+
+		// Given
+		tc.rune_event(domain.EventRuneCreated, "bf-a1b2")
+		tc.stream_is_opened("/events/stream", "")
+
+		// When
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+
+		// Then
+		frame := tc.next_event()
+		assert.Equal(t, "2", frame.id)
+		assert.Equal(t, domain.EventRuneClaimed, frame.event)
+		assert.Equal(t, "bf-a1b2", frame.data.RuneID)
+		assert.Equal(t, "realm-1", frame.data.RealmID)
+	})
+
+	t.Run("opens at the realm's own latest position", func(t *testing.T) {
+		tc := newEventStreamTestContext(t)
+
+		// Given
+		tc.rune_event(domain.EventRuneCreated, "bf-a1b2")
+		tc.events.append(core.Event{RealmID: "realm-2", StreamID: "rune-bf-c3d4", EventType: domain.EventRuneCreated, Data: []byte(`{}`)})
+
+		// When
+		head, err := tc.streamer.head(context.Background(), "realm-1")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), head)
+	})
+
+	t.Run("resumes after Last-Event-ID", func(t *testing.T) {
+		tc := newEventStreamTestContext(t)
+
+		// Given
+		tc.rune_event(domain.EventRuneCreated, "bf-a1b2")
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+		tc.rune_event(domain.EventRuneFulfilled, "bf-a1b2")
+
+		// When
+		tc.stream_is_opened("/events/stream", "1")
+
+		// Then
+		assert.Equal(t, "2", tc.next_event().id)
+		assert.Equal(t, "3", tc.next_event().id)
+	})
+
+	t.Run("filters by event type and rune", func(t *testing.T) {
+		tc := newEventStreamTestContext(t)
+
+		// Given
+		tc.rune_event(domain.EventRuneClaimed, "bf-other")
+		tc.rune_event(domain.EventRuneCreated, "bf-a1b2")
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+
+		// When
+		tc.stream_is_opened("/events/stream?type=RuneClaimed,RuneFulfilled&rune_id=bf-a1b2&last_event_id=0", "")
+
+		// Then
+		frame := tc.next_event()
+		assert.Equal(t, "3", frame.id)
+		assert.Equal(t, domain.EventRuneClaimed, frame.event)
+	})
+
+	t.Run("filters by tag", func(t *testing.T) {
+		tc := newEventStreamTestContext(t)
+
+		// Given
+		tc.rune_has_tags("bf-a1b2", "backend")
+		tc.rune_has_tags("bf-c3d4", "release")
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+		tc.rune_event(domain.EventRuneClaimed, "bf-c3d4")
+
+		// When
+		tc.stream_is_opened("/events/stream?tag=release&last_event_id=0", "")
+
+		// Then
+		assert.Equal(t, "bf-c3d4", tc.next_event().data.RuneID)
+	})
+
+	t.Run("rejects an invalid Last-Event-ID", func(t *testing.T) {
+		tc := newEventStreamTestContext(t)
+
+		// When
+		tc.stream_is_opened("/events/stream", "abc")
+
+		// Then
+		tc.status_is(http.StatusBadRequest)
+	})
+
+	t.Run("ends the stream once the caller has no role in the realm", func(t *testing.T) {
+		tc := newEventStreamTestContext(t)
+
+		// Given
+		tc.caller_has_role("")
+		tc.rune_event(domain.EventRuneCreated, "bf-a1b2")
+
+		// When
+		tc.stream_is_opened("/events/stream?last_event_id=0", "")
+
+		// Then
+		frame := tc.next_event()
+		assert.Equal(t, "error", frame.event)
+		tc.stream_ends()
+	})
+
+	t.Run("close ends open streams", func(t *testing.T) {
+		tc := newEventStreamTestContext(t)
+
+		// Given
+		tc.stream_is_opened("/events/stream", "")
+
+		// When
+		tc.streamer.Close()
+
+		// Then
+		tc.stream_ends()
+	})
+
+	t.Run("is unavailable without a streamer", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.get("/events/stream")
+
+		// Then
+		tc.status_is(http.StatusServiceUnavailable)
+	})
+
+	t.Run("registers the stream route", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.routes_are_registered()
+
+		// Then
+		tc.route_exists("GET", "/api/events/stream")
+	})
+}
+
+// --- Test Context ---
+
+type eventStreamTestContext struct {
+	t *testing.T
+
+	events      *webhookEventStore
+	projections *mockProjectionStore
+	streamer    *EventStreamer
+	server      *httptest.Server
+
+	response *http.Response
+	reader   *bufio.Reader
+}
+
+type eventStreamFrame struct {
+	id    string
+	event string
+	data  StreamedEvent
+}
+
+func newEventStreamTestContext(t *testing.T) *eventStreamTestContext {
+	t.Helper()
+	tc := &eventStreamTestContext{
+		t:           t,
+		events:      &webhookEventStore{},
+		projections: newMockProjectionStore(),
+	}
+	tc.streamer = NewEventStreamer(tc.events, tc.projections, 10*time.Millisecond)
+	t.Cleanup(tc.streamer.Close)
+
+	handlers := NewHandlers(tc.events, tc.projections, &mockProjectionEngine{store: tc.projections})
+	handlers.SetEventStreamer(tc.streamer)
+	tc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), realmIDKey, "realm-1")
+		ctx = context.WithValue(ctx, accountIDKey, "acct-1")
+		handlers.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	t.Cleanup(tc.server.Close)
+
+	tc.caller_has_role("viewer")
+	return tc
+}
+
+// --- Given ---
+
+func (tc *eventStreamTestContext) caller_has_role(role string) {
+	tc.t.Helper()
+	roles := map[string]string{}
+	if role != "" {
+		roles["realm-1"] = role
+	}
+	tc.projections.put(domain.AdminRealmID, "account_auth", "acct-1", projectors.AccountAuthEntry{
+		AccountID: "acct-1", Status: "active", Roles: roles,
+	})
+}
+
+func (tc *eventStreamTestContext) rune_has_tags(runeID string, tags ...string) {
+	tc.t.Helper()
+	tc.projections.put("realm-1", projectors.RuneSummaryTable.Name, runeID, projectors.RuneSummary{ID: runeID, Tags: tags})
+}
+
+func (tc *eventStreamTestContext) rune_event(eventType, runeID string) {
+	tc.t.Helper()
+	data, err := json.Marshal(map[string]string{"id": runeID})
+	require.NoError(tc.t, err)
+	tc.events.append(core.Event{
+		RealmID:   "realm-1",
+		StreamID:  "rune-" + runeID,
+		EventType: eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	})
+}
+
+// --- When ---
+
+func (tc *eventStreamTestContext) stream_is_opened(path, lastEventID string) {
+	tc.t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	tc.t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tc.server.URL+path, nil)
+	require.NoError(tc.t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	tc.response, err = http.DefaultClient.Do(req)
+	require.NoError(tc.t, err)
+	tc.t.Cleanup(func() { tc.response.Body.Close() })
+	tc.reader = bufio.NewReader(tc.response.Body)
+}
+
+// --- Then ---
+
+func (tc *eventStreamTestContext) status_is(expected int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.response.StatusCode)
+}
+
+// next_event reads frames until one carrying data, skipping comments.
+func (tc *eventStreamTestContext) next_event() eventStreamFrame {
+	tc.t.Helper()
+	require.Equal(tc.t, "text/event-stream", tc.response.Header.Get("Content-Type"))
+	var frame eventStreamFrame
+	var data string
+	for {
+		line, err := tc.reader.ReadString('\n')
+		require.NoError(tc.t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && data != "":
+			if frame.event != "error" {
+				require.NoError(tc.t, json.Unmarshal([]byte(data), &frame.data))
+			}
+			return frame
+		case strings.HasPrefix(line, "id: "):
+			frame.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			frame.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func (tc *eventStreamTestContext) stream_ends() {
+	tc.t.Helper()
+	rest, err := io.ReadAll(tc.reader)
+	require.NoError(tc.t, err)
+	assert.NotContains(tc.t, string(rest), "data: ")
+}
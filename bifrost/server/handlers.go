@@ -7,14 +7,17 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/devzeebo/bifrost/core"
 	"github.com/devzeebo/bifrost/domain"
 	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/devzeebo/bifrost/server/admin"
 )
 
 // ProjectionEngine is the interface for running sync projections.
@@ -29,7 +32,21 @@ type Handlers struct {
 	eventStore      core.EventStore
 	projectionStore core.ProjectionStore
 	engine          ProjectionEngine
+	realmPurgers    []core.RealmPurger
+	securityLog     *admin.SecurityLog
+	rateLimiter     *RateLimiter
+	webhooks        *WebhookDispatcher
+	events          *EventStreamer
 	mux             *http.ServeMux
+
+	// claimLocks maps each realm ID to a *sync.Mutex that serializes POST
+	// /claim-next in that realm, so callers on this server take turns rather
+	// than collide on the same rune. claimPoll is how often a long-polling
+	// claim-next looks for work; closing ends those polls.
+	claimLocks sync.Map
+	claimPoll  time.Duration
+	closing   chan struct{}
+	closeOnce sync.Once
 }
 
 // NewHandlers creates a new Handlers instance with the given dependencies.
@@ -39,11 +56,14 @@ func NewHandlers(eventStore core.EventStore, projectionStore core.ProjectionStor
 		projectionStore: projectionStore,
 		engine:          engine,
 		mux:             http.NewServeMux(),
+		claimPoll:       claimNextPollInterval,
+		closing:         make(chan struct{}),
 	}
 	h.mux.HandleFunc("GET /health", h.Health)
 	h.mux.HandleFunc("POST /create-rune", h.CreateRune)
 	h.mux.HandleFunc("POST /update-rune", h.UpdateRune)
 	h.mux.HandleFunc("POST /claim-rune", h.ClaimRune)
+	h.mux.HandleFunc("POST /claim-next", h.ClaimNext)
 	h.mux.HandleFunc("POST /unclaim-rune", h.UnclaimRune)
 	h.mux.HandleFunc("POST /fulfill-rune", h.FulfillRune)
 	h.mux.HandleFunc("POST /seal-rune", h.SealRune)
@@ -58,87 +78,171 @@ func NewHandlers(eventStore core.EventStore, projectionStore core.ProjectionStor
 	h.mux.HandleFunc("POST /add-ac", h.AddAC)
 	h.mux.HandleFunc("POST /update-ac", h.UpdateAC)
 	h.mux.HandleFunc("POST /remove-ac", h.RemoveAC)
+	h.mux.HandleFunc("POST /verify-ac", h.VerifyAC)
 	h.mux.HandleFunc("POST /update-rune-state", h.UpdateRuneState)
 	h.mux.HandleFunc("POST /clear-rune-state", h.ClearRuneState)
 	h.mux.HandleFunc("POST /shatter-rune", h.ShatterRune)
+	h.mux.HandleFunc("POST /move-rune", h.MoveRune)
 	h.mux.HandleFunc("POST /sweep-runes", h.SweepRunes)
 	h.mux.HandleFunc("GET /runes", h.ListRunes)
 	h.mux.HandleFunc("GET /ready", h.Ready)
+	h.mux.HandleFunc("GET /search", h.Search)
 	h.mux.HandleFunc("GET /rune", h.GetRune)
 	h.mux.HandleFunc("POST /create-realm", h.CreateRealm)
 	h.mux.HandleFunc("POST /suspend-realm", h.SuspendRealm)
+	h.mux.HandleFunc("POST /rename-realm", h.RenameRealm)
+	h.mux.HandleFunc("POST /reactivate-realm", h.ReactivateRealm)
+	h.mux.HandleFunc("POST /archive-realm", h.ArchiveRealm)
+	h.mux.HandleFunc("POST /delete-realm", h.DeleteRealm)
 	h.mux.HandleFunc("GET /realms", h.ListRealms)
 	h.mux.HandleFunc("GET /realm", h.GetRealm)
+	h.mux.HandleFunc("GET /realm-settings", h.GetRealmSettings)
+	h.mux.HandleFunc("POST /realm-settings", h.UpdateRealmSettings)
+	h.mux.HandleFunc("GET /policies", h.ListPolicies)
+	h.mux.HandleFunc("GET /wip", h.GetWIPUsage)
+	h.mux.HandleFunc("POST /set-policy", h.SetPolicy)
+	h.mux.HandleFunc("POST /remove-policy", h.RemovePolicy)
+	h.mux.HandleFunc("GET /webhooks", h.ListWebhooks)
+	h.mux.HandleFunc("GET /webhook-deliveries", h.ListWebhookDeliveries)
+	h.mux.HandleFunc("POST /add-webhook", h.AddWebhook)
+	h.mux.HandleFunc("POST /remove-webhook", h.RemoveWebhook)
+	h.mux.HandleFunc("POST /test-webhook", h.TestWebhook)
+	h.mux.HandleFunc("GET /events/stream", h.StreamEvents)
+	h.mux.HandleFunc("GET /roles", h.ListRoles)
+	h.mux.HandleFunc("POST /define-role", h.DefineRole)
+	h.mux.HandleFunc("POST /delete-role", h.DeleteRole)
 	h.mux.HandleFunc("POST /assign-role", h.AssignRole)
 	h.mux.HandleFunc("POST /revoke-role", h.RevokeRole)
 	return h
 }
 
+// SetRealmPurgers sets the stores that DeleteRealm purges a realm's data from.
+func (h *Handlers) SetRealmPurgers(purgers ...core.RealmPurger) {
+	h.realmPurgers = purgers
+}
+
+// SetSecurityLog sets the log that records projection rebuilds.
+func (h *Handlers) SetSecurityLog(securityLog *admin.SecurityLog) {
+	h.securityLog = securityLog
+}
+
+// SetRateLimiter sets the limiter applied to authenticated /api routes.
+func (h *Handlers) SetRateLimiter(limiter *RateLimiter) {
+	h.rateLimiter = limiter
+}
+
+// SetWebhookDispatcher sets the dispatcher that sends test deliveries.
+func (h *Handlers) SetWebhookDispatcher(dispatcher *WebhookDispatcher) {
+	h.webhooks = dispatcher
+}
+
+// SetEventStreamer sets the streamer that serves GET /api/events/stream.
+func (h *Handlers) SetEventStreamer(streamer *EventStreamer) {
+	h.events = streamer
+}
+
+// Close ends long-polling claim-next requests and open event streams.
+// http.Server.Shutdown waits for handlers to return, so Close is registered
+// with RegisterOnShutdown.
+func (h *Handlers) Close() {
+	h.closeOnce.Do(func() { close(h.closing) })
+	if h.events != nil {
+		h.events.Close()
+	}
+}
+
 // ServeHTTP delegates to the internal mux.
 func (h *Handlers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.mux.ServeHTTP(w, r)
 }
 
 // RegisterRoutes registers all handler routes on the given mux with middleware.
+// Each /api route requires the permission named after its command.
 func (h *Handlers) RegisterRoutes(mux *http.ServeMux, realmMiddleware, adminMiddleware func(http.Handler) http.Handler) {
-	// Compose role-based middleware chains for realm endpoints (non-_admin realms)
-	viewerAuth := func(next http.Handler) http.Handler {
-		return realmMiddleware(RequireRole("viewer")(next))
+	can := func(perm string) func(http.Handler) http.Handler {
+		return RequirePermission(h.projectionStore, perm)
 	}
-	memberAuth := func(next http.Handler) http.Handler {
-		return realmMiddleware(RequireRole("member")(next))
+	// Requests are rate limited once authentication has identified the token and realm
+	limit := h.rateLimiter.Middleware
+	// Realm endpoints (non-_admin realms); writes are rejected while a realm is archived
+	writable := RequireWritableRealm(h.projectionStore)
+	realmRead := func(perm string, next http.HandlerFunc) http.Handler {
+		return realmMiddleware(limit(can(perm)(next)))
 	}
-	adminRealmAuth := func(next http.Handler) http.Handler {
-		return realmMiddleware(RequireRole("admin")(next))
+	realmWrite := func(perm string, next http.HandlerFunc) http.Handler {
+		return realmMiddleware(limit(can(perm)(writable(next))))
 	}
 
-	// Admin endpoints use adminMiddleware (allows _admin realm) with role check
-	adminAuth := func(next http.Handler) http.Handler {
-		return adminMiddleware(RequireRole("admin")(next))
+	// Admin endpoints use adminMiddleware (allows _admin realm) with a permission check
+	adminAuth := func(perm string, next http.HandlerFunc) http.Handler {
+		return adminMiddleware(limit(can(perm)(next)))
 	}
 
 	// Health check — no auth
 	mux.HandleFunc("GET /health", h.Health)
 
-	// Rune commands (member role minimum)
-	mux.Handle("POST /api/create-rune", memberAuth(http.HandlerFunc(h.CreateRune)))
-	mux.Handle("POST /api/update-rune", memberAuth(http.HandlerFunc(h.UpdateRune)))
-	mux.Handle("POST /api/claim-rune", memberAuth(http.HandlerFunc(h.ClaimRune)))
-	mux.Handle("POST /api/unclaim-rune", memberAuth(http.HandlerFunc(h.UnclaimRune)))
-	mux.Handle("POST /api/fulfill-rune", memberAuth(http.HandlerFunc(h.FulfillRune)))
-	mux.Handle("POST /api/seal-rune", memberAuth(http.HandlerFunc(h.SealRune)))
-	mux.Handle("POST /api/fail-rune", memberAuth(http.HandlerFunc(h.FailRune)))
-	mux.Handle("POST /api/reopen-rune", memberAuth(http.HandlerFunc(h.ReopenRune)))
-	mux.Handle("POST /api/forge-rune", memberAuth(http.HandlerFunc(h.ForgeRune)))
-	mux.Handle("POST /api/add-dependency", memberAuth(http.HandlerFunc(h.AddDependency)))
-	mux.Handle("POST /api/remove-dependency", memberAuth(http.HandlerFunc(h.RemoveDependency)))
-	mux.Handle("POST /api/add-note", memberAuth(http.HandlerFunc(h.AddNote)))
-	mux.Handle("POST /api/add-retro", memberAuth(http.HandlerFunc(h.AddRetro)))
-	mux.Handle("POST /api/add-ac", memberAuth(http.HandlerFunc(h.AddAC)))
-	mux.Handle("POST /api/update-ac", memberAuth(http.HandlerFunc(h.UpdateAC)))
-	mux.Handle("POST /api/remove-ac", memberAuth(http.HandlerFunc(h.RemoveAC)))
-	mux.Handle("POST /api/update-rune-state", memberAuth(http.HandlerFunc(h.UpdateRuneState)))
-	mux.Handle("POST /api/clear-rune-state", memberAuth(http.HandlerFunc(h.ClearRuneState)))
-	mux.Handle("POST /api/shatter-rune", memberAuth(http.HandlerFunc(h.ShatterRune)))
-	mux.Handle("POST /api/sweep-runes", memberAuth(http.HandlerFunc(h.SweepRunes)))
-
-	// Rune queries (viewer role minimum)
-	mux.Handle("GET /api/runes", viewerAuth(http.HandlerFunc(h.ListRunes)))
-	mux.Handle("GET /api/rune", viewerAuth(http.HandlerFunc(h.GetRune)))
-	mux.Handle("GET /api/ready", viewerAuth(http.HandlerFunc(h.Ready)))
-	mux.Handle("GET /api/retro", viewerAuth(http.HandlerFunc(h.GetRetro)))
-
-	// Role management (admin role minimum, realm auth)
-	mux.Handle("POST /api/assign-role", adminRealmAuth(http.HandlerFunc(h.AssignRole)))
-	mux.Handle("POST /api/revoke-role", adminRealmAuth(http.HandlerFunc(h.RevokeRole)))
-
-	// Admin commands (admin auth — allows _admin realm with role check)
-	mux.Handle("POST /api/create-realm", adminAuth(http.HandlerFunc(h.CreateRealm)))
-	mux.Handle("POST /api/suspend-realm", adminMiddleware(http.HandlerFunc(h.SuspendRealm)))
-	mux.Handle("GET /api/realms", adminAuth(http.HandlerFunc(h.ListRealms)))
-	mux.Handle("GET /api/realm", viewerAuth(http.HandlerFunc(h.GetRealm)))
-	mux.Handle("POST /api/rebuild-projections", adminAuth(http.HandlerFunc(h.RebuildProjections)))
-	mux.Handle("GET /api/resolve-username", adminAuth(http.HandlerFunc(h.ResolveUsername)))
+	// Rune commands (member bundle)
+	mux.Handle("POST /api/create-rune", realmWrite(domain.PermCreateRune, h.CreateRune))
+	mux.Handle("POST /api/update-rune", realmWrite(domain.PermUpdateRune, h.UpdateRune))
+	mux.Handle("POST /api/claim-rune", realmWrite(domain.PermClaimRune, h.ClaimRune))
+	mux.Handle("POST /api/claim-next", realmWrite(domain.PermClaimRune, h.ClaimNext))
+	mux.Handle("POST /api/unclaim-rune", realmWrite(domain.PermUnclaimRune, h.UnclaimRune))
+	mux.Handle("POST /api/fulfill-rune", realmWrite(domain.PermFulfillRune, h.FulfillRune))
+	mux.Handle("POST /api/seal-rune", realmWrite(domain.PermSealRune, h.SealRune))
+	mux.Handle("POST /api/fail-rune", realmWrite(domain.PermFailRune, h.FailRune))
+	mux.Handle("POST /api/reopen-rune", realmWrite(domain.PermReopenRune, h.ReopenRune))
+	mux.Handle("POST /api/forge-rune", realmWrite(domain.PermForgeRune, h.ForgeRune))
+	mux.Handle("POST /api/add-dependency", realmWrite(domain.PermAddDependency, h.AddDependency))
+	mux.Handle("POST /api/remove-dependency", realmWrite(domain.PermRemoveDependency, h.RemoveDependency))
+	mux.Handle("POST /api/add-note", realmWrite(domain.PermAddNote, h.AddNote))
+	mux.Handle("POST /api/add-retro", realmWrite(domain.PermAddRetro, h.AddRetro))
+	mux.Handle("POST /api/add-ac", realmWrite(domain.PermAddAC, h.AddAC))
+	mux.Handle("POST /api/update-ac", realmWrite(domain.PermUpdateAC, h.UpdateAC))
+	mux.Handle("POST /api/remove-ac", realmWrite(domain.PermRemoveAC, h.RemoveAC))
+	mux.Handle("POST /api/verify-ac", realmWrite(domain.PermVerifyAC, h.VerifyAC))
+	mux.Handle("POST /api/update-rune-state", realmWrite(domain.PermUpdateRuneState, h.UpdateRuneState))
+	mux.Handle("POST /api/clear-rune-state", realmWrite(domain.PermClearRuneState, h.ClearRuneState))
+	mux.Handle("POST /api/shatter-rune", realmWrite(domain.PermShatterRune, h.ShatterRune))
+	mux.Handle("POST /api/move-rune", realmWrite(domain.PermMoveRune, h.MoveRune))
+	mux.Handle("POST /api/sweep-runes", realmWrite(domain.PermSweepRunes, h.SweepRunes))
+
+	// Rune and realm queries (viewer bundle)
+	mux.Handle("GET /api/runes", realmRead(domain.PermViewRunes, h.ListRunes))
+	mux.Handle("GET /api/rune", realmRead(domain.PermViewRunes, h.GetRune))
+	mux.Handle("GET /api/ready", realmRead(domain.PermViewRunes, h.Ready))
+	mux.Handle("GET /api/search", realmRead(domain.PermViewRunes, h.Search))
+	mux.Handle("GET /api/retro", realmRead(domain.PermViewRunes, h.GetRetro))
+	mux.Handle("GET /api/wip", realmRead(domain.PermViewRunes, h.GetWIPUsage))
+	mux.Handle("GET /api/events/stream", realmRead(domain.PermViewRunes, h.StreamEvents))
+	mux.Handle("GET /api/realm-settings", realmRead(domain.PermViewRealm, h.GetRealmSettings))
+	mux.Handle("GET /api/policies", realmRead(domain.PermViewRealm, h.ListPolicies))
+	mux.Handle("GET /api/roles", realmRead(domain.PermViewRealm, h.ListRoles))
+	mux.Handle("GET /api/webhooks", realmRead(domain.PermViewRealm, h.ListWebhooks))
+	mux.Handle("GET /api/webhook-deliveries", realmRead(domain.PermViewRealm, h.ListWebhookDeliveries))
+	mux.Handle("GET /api/realm", realmRead(domain.PermViewRealm, h.GetRealm))
+
+	// Realm administration (admin bundle)
+	mux.Handle("POST /api/assign-role", realmWrite(domain.PermAssignRole, h.AssignRole))
+	mux.Handle("POST /api/revoke-role", realmWrite(domain.PermRevokeRole, h.RevokeRole))
+	mux.Handle("POST /api/realm-settings", realmWrite(domain.PermUpdateRealmSettings, h.UpdateRealmSettings))
+	mux.Handle("POST /api/set-policy", realmWrite(domain.PermSetPolicy, h.SetPolicy))
+	mux.Handle("POST /api/remove-policy", realmWrite(domain.PermRemovePolicy, h.RemovePolicy))
+	mux.Handle("POST /api/define-role", realmWrite(domain.PermDefineRole, h.DefineRole))
+	mux.Handle("POST /api/delete-role", realmWrite(domain.PermDeleteRole, h.DeleteRole))
+	mux.Handle("POST /api/add-webhook", realmWrite(domain.PermAddWebhook, h.AddWebhook))
+	mux.Handle("POST /api/remove-webhook", realmWrite(domain.PermRemoveWebhook, h.RemoveWebhook))
+	mux.Handle("POST /api/test-webhook", realmWrite(domain.PermTestWebhook, h.TestWebhook))
+
+	// System commands (admin auth — allows _admin realm with permission check)
+	mux.Handle("POST /api/create-realm", adminAuth(domain.PermCreateRealm, h.CreateRealm))
+	mux.Handle("POST /api/suspend-realm", adminAuth(domain.PermSuspendRealm, h.SuspendRealm))
+	mux.Handle("POST /api/rename-realm", adminAuth(domain.PermRenameRealm, h.RenameRealm))
+	mux.Handle("POST /api/reactivate-realm", adminAuth(domain.PermReactivateRealm, h.ReactivateRealm))
+	mux.Handle("POST /api/archive-realm", adminAuth(domain.PermArchiveRealm, h.ArchiveRealm))
+	mux.Handle("POST /api/delete-realm", adminAuth(domain.PermDeleteRealm, h.DeleteRealm))
+	mux.Handle("GET /api/realms", adminAuth(domain.PermListRealms, h.ListRealms))
+	mux.Handle("POST /api/rebuild-projections", adminAuth(domain.PermRebuildProjections, h.RebuildProjections))
+	mux.Handle("GET /api/resolve-username", adminAuth(domain.PermResolveUsername, h.ResolveUsername))
 }
 
 // --- Command Handlers ---
@@ -158,6 +262,7 @@ func (h *Handlers) CreateRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	cmd.ParentID = h.resolveRuneID(r.Context(), realmID, cmd.ParentID)
 	result, err := domain.HandleCreateRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore)
 	if err != nil {
 		handleDomainError(w, err)
@@ -173,12 +278,27 @@ func (h *Handlers) UpdateRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusForbidden, "realm ID required")
 		return
 	}
-	var cmd domain.UpdateRune
-	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+	var req struct {
+		domain.UpdateRune
+		cascadeOptions
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleUpdateRune(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	if (req.Cascade || req.DryRun) && updatesNonPriorityFields(req.UpdateRune) {
+		writeError(w, http.StatusBadRequest, "cascade only supports priority updates")
+		return
+	}
+	req.ID = h.resolveRuneID(r.Context(), realmID, req.ID)
+	cmd := req.UpdateRune
+	if req.Cascade || req.DryRun {
+		h.cascade(w, r, realmID, req.cascadeOptions, domain.CascadeRune{
+			ID: cmd.ID, Operation: domain.CascadeReprioritize, Priority: cmd.Priority,
+		})
+		return
+	}
+	if err := domain.HandleUpdateRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -186,6 +306,14 @@ func (h *Handlers) UpdateRune(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// updatesNonPriorityFields reports whether cmd changes anything besides the
+// priority, which is the only field a cascading update propagates.
+func updatesNonPriorityFields(cmd domain.UpdateRune) bool {
+	return cmd.Title != nil || cmd.Description != nil || cmd.Branch != nil ||
+		cmd.Tags != nil || len(cmd.AddTags) > 0 || len(cmd.RemoveTags) > 0 ||
+		cmd.DueAt != nil || cmd.DeferUntil != nil || cmd.ClearDueAt || cmd.ClearDeferUntil
+}
+
 func (h *Handlers) ClaimRune(w http.ResponseWriter, r *http.Request) {
 	realmID, ok := RealmIDFromContext(r.Context())
 	if !ok {
@@ -197,7 +325,10 @@ func (h *Handlers) ClaimRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleClaimRune(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.ID = h.resolveRuneID(r.Context(), realmID, cmd.ID)
+	cmd.AccountID, _ = AccountIDFromContext(r.Context())
+	cmd.Agent = IsServiceAccountFromContext(r.Context())
+	if err := domain.HandleClaimRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -205,6 +336,129 @@ func (h *Handlers) ClaimRune(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+const (
+	// claimNextMaxWait caps how long POST /claim-next long-polls for work.
+	claimNextMaxWait      = 60 * time.Second
+	claimNextPollInterval = time.Second
+)
+
+// claimNextRequest is the POST /claim-next body. Empty filters match every
+// rune; a rune matches tags when it carries any of them.
+type claimNextRequest struct {
+	Claimant    string   `json:"claimant"`
+	Tags        []string `json:"tags,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Branch      string   `json:"branch,omitempty"`
+	ParentID    string   `json:"parent_id,omitempty"`
+	WaitSeconds int      `json:"wait_seconds,omitempty"`
+}
+
+// ClaimNext claims the highest-priority ready rune matching the request's
+// filters and returns its detail. With wait_seconds it long-polls until a
+// rune is claimed or the wait elapses, then responds 204.
+func (h *Handlers) ClaimNext(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var req claimNextRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Claimant == "" {
+		writeError(w, http.StatusBadRequest, "claimant is required")
+		return
+	}
+	if req.WaitSeconds < 0 {
+		writeError(w, http.StatusBadRequest, "wait_seconds must not be negative")
+		return
+	}
+	filter := readyFilter{
+		parentID: h.resolveRuneID(r.Context(), realmID, req.ParentID),
+		branch:   req.Branch,
+		runeType: req.Type,
+		tags:     normalizeTagList(req.Tags),
+	}
+	deadline := time.Now().Add(min(time.Duration(req.WaitSeconds)*time.Second, claimNextMaxWait))
+
+	for {
+		runeID, err := h.claimNext(r, realmID, req.Claimant, filter)
+		if err != nil {
+			handleDomainError(w, err)
+			return
+		}
+		if runeID != "" {
+			resp, err := h.runeDetail(r.Context(), realmID, runeID)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "failed to get rune")
+				return
+			}
+			writeJSON(w, http.StatusOK, resp)
+			return
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		timer := time.NewTimer(min(h.claimPoll, remaining))
+		select {
+		case <-timer.C:
+		case <-h.closing:
+			timer.Stop()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// claimNext claims the first ready rune matching filter and returns its ID,
+// or "" when there is none. Candidates come from the projections as they
+// stand; a claim appends at the stream version it read, so a rune already
+// claimed here or by another server fails and is skipped. Projections are
+// only caught up after a successful claim, before the realm's next caller
+// looks. If policies or WIP limits rejected every candidate, the first
+// rejection is returned instead.
+func (h *Handlers) claimNext(r *http.Request, realmID, claimant string, filter readyFilter) (string, error) {
+	lock, _ := h.claimLocks.LoadOrStore(realmID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	ctx := r.Context()
+	candidates, err := h.readyRunes(ctx, realmID, filter)
+	if err != nil {
+		return "", err
+	}
+
+	accountID, _ := AccountIDFromContext(ctx)
+	var rejection error
+	for _, candidate := range candidates {
+		cmd := domain.ClaimRune{
+			ID:        fmt.Sprintf("%v", candidate["id"]),
+			Claimant:  claimant,
+			AccountID: accountID,
+			Agent:     IsServiceAccountFromContext(ctx),
+		}
+		err := domain.HandleClaimRune(ctx, realmID, cmd, h.eventStore, h.projectionStore)
+		if err == nil {
+			h.engine.RunCatchUpOnce(ctx)
+			return cmd.ID, nil
+		}
+		var policyErr *domain.PolicyViolationError
+		var wipErr *domain.WIPLimitError
+		if rejection == nil && (errors.As(err, &policyErr) || errors.As(err, &wipErr)) {
+			rejection = err
+		}
+	}
+	return "", rejection
+}
+
 func (h *Handlers) UnclaimRune(w http.ResponseWriter, r *http.Request) {
 	realmID, ok := RealmIDFromContext(r.Context())
 	if !ok {
@@ -216,7 +470,8 @@ func (h *Handlers) UnclaimRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleUnclaimRune(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.ID = h.resolveRuneID(r.Context(), realmID, cmd.ID)
+	if err := domain.HandleUnclaimRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -235,7 +490,8 @@ func (h *Handlers) FulfillRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleFulfillRune(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.ID = h.resolveRuneID(r.Context(), realmID, cmd.ID)
+	if err := domain.HandleFulfillRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -249,12 +505,23 @@ func (h *Handlers) SealRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusForbidden, "realm ID required")
 		return
 	}
-	var cmd domain.SealRune
-	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+	var req struct {
+		domain.SealRune
+		cascadeOptions
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleSealRune(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	req.ID = h.resolveRuneID(r.Context(), realmID, req.ID)
+	cmd := req.SealRune
+	if req.Cascade || req.DryRun {
+		h.cascade(w, r, realmID, req.cascadeOptions, domain.CascadeRune{
+			ID: cmd.ID, Operation: domain.CascadeSeal, Reason: cmd.Reason,
+		})
+		return
+	}
+	if err := domain.HandleSealRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -268,12 +535,23 @@ func (h *Handlers) FailRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusForbidden, "realm ID required")
 		return
 	}
-	var cmd domain.FailRune
-	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+	var req struct {
+		domain.FailRune
+		cascadeOptions
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleFailRune(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	req.ID = h.resolveRuneID(r.Context(), realmID, req.ID)
+	cmd := req.FailRune
+	if req.Cascade || req.DryRun {
+		h.cascade(w, r, realmID, req.cascadeOptions, domain.CascadeRune{
+			ID: cmd.ID, Operation: domain.CascadeFail, Reason: cmd.Reason,
+		})
+		return
+	}
+	if err := domain.HandleFailRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -292,6 +570,7 @@ func (h *Handlers) ForgeRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	cmd.ID = h.resolveRuneID(r.Context(), realmID, cmd.ID)
 	if err := domain.HandleForgeRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
@@ -311,6 +590,8 @@ func (h *Handlers) AddDependency(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	cmd.TargetID = h.resolveRuneID(r.Context(), realmID, cmd.TargetID)
 	if err := domain.HandleAddDependency(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
@@ -330,6 +611,8 @@ func (h *Handlers) RemoveDependency(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	cmd.TargetID = h.resolveRuneID(r.Context(), realmID, cmd.TargetID)
 	if err := domain.HandleRemoveDependency(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
@@ -364,12 +647,11 @@ func (h *Handlers) AssignRole(w http.ResponseWriter, r *http.Request) {
 	// Role assignment rules:
 	// - System admins can assign any role
 	// - Realm owners can assign any role in their realm
-	// - Realm admins can only assign member/viewer roles
-	// - Members/viewers cannot assign roles at all
+	// - Other roles with the assign-role permission can assign any role but owner/admin
+	// - Roles without it cannot assign roles at all
 	if !isSysAdmin {
-		// Must have at least admin role to assign roles
-		if callerRealmRole != domain.RoleAdmin && callerRealmRole != domain.RoleOwner {
-			writeError(w, http.StatusForbidden, "admin or owner role required to assign roles")
+		if !h.callerHasPermission(r, domain.PermAssignRole) {
+			writeError(w, http.StatusForbidden, "assign-role permission required to assign roles")
 			return
 		}
 		if cmd.Role == domain.RoleOwner && callerRealmRole != domain.RoleOwner {
@@ -423,12 +705,11 @@ func (h *Handlers) RevokeRole(w http.ResponseWriter, r *http.Request) {
 	// Role revocation rules:
 	// - System admins can revoke any role
 	// - Realm owners can revoke any role in their realm
-	// - Realm admins can only revoke member/viewer roles
-	// - Members/viewers cannot revoke roles at all
+	// - Other roles with the revoke-role permission can revoke any role but owner/admin
+	// - Roles without it cannot revoke roles at all
 	if !isSysAdmin {
-		// Must have at least admin role to revoke roles
-		if callerRealmRole != domain.RoleAdmin && callerRealmRole != domain.RoleOwner {
-			writeError(w, http.StatusForbidden, "admin or owner role required to revoke roles")
+		if !h.callerHasPermission(r, domain.PermRevokeRole) {
+			writeError(w, http.StatusForbidden, "revoke-role permission required to revoke roles")
 			return
 		}
 		if targetRole == domain.RoleOwner && callerRealmRole != domain.RoleOwner {
@@ -468,12 +749,23 @@ func (h *Handlers) ReopenRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusForbidden, "realm ID required")
 		return
 	}
-	var cmd domain.ReopenRune
-	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+	var req struct {
+		domain.ReopenRune
+		cascadeOptions
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleReopenRune(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	req.ID = h.resolveRuneID(r.Context(), realmID, req.ID)
+	cmd := req.ReopenRune
+	if req.Cascade || req.DryRun {
+		h.cascade(w, r, realmID, req.cascadeOptions, domain.CascadeRune{
+			ID: cmd.ID, Operation: domain.CascadeReopen, AsClaimed: cmd.AsClaimed,
+		})
+		return
+	}
+	if err := domain.HandleReopenRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -492,7 +784,8 @@ func (h *Handlers) ShatterRune(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleShatterRune(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.ID = h.resolveRuneID(r.Context(), realmID, cmd.ID)
+	if err := domain.HandleShatterRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -500,6 +793,41 @@ func (h *Handlers) ShatterRune(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handlers) MoveRune(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.MoveRune
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.ID = h.resolveRuneID(r.Context(), realmID, cmd.ID)
+	cmd.ParentID = h.resolveRuneID(r.Context(), realmID, cmd.ParentID)
+	result, err := domain.HandleMoveRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// resolveRuneID maps the alias of a moved rune to the rune's original ID.
+// Anything that is not a known alias is returned unchanged.
+func (h *Handlers) resolveRuneID(ctx context.Context, realmID, id string) string {
+	if id == "" {
+		return id
+	}
+	var entry projectors.RuneAliasEntry
+	if err := h.projectionStore.Get(ctx, realmID, "rune_alias", id, &entry); err != nil {
+		return id
+	}
+	return entry.RuneID
+}
+
 func (h *Handlers) SweepRunes(w http.ResponseWriter, r *http.Request) {
 	realmID, ok := RealmIDFromContext(r.Context())
 	if !ok {
@@ -515,6 +843,30 @@ func (h *Handlers) SweepRunes(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string][]string{"shattered": shattered})
 }
 
+// cascadeOptions are the extra body fields accepted by the seal, fail, reopen
+// and update endpoints to apply the change to a whole subtree.
+type cascadeOptions struct {
+	Cascade bool `json:"cascade,omitempty"`
+	DryRun  bool `json:"dry_run,omitempty"`
+}
+
+func (h *Handlers) cascade(w http.ResponseWriter, r *http.Request, realmID string, opts cascadeOptions, cmd domain.CascadeRune) {
+	if !opts.Cascade {
+		writeError(w, http.StatusBadRequest, "dry_run requires cascade")
+		return
+	}
+	cmd.DryRun = opts.DryRun
+	results, err := domain.HandleCascadeRune(r.Context(), realmID, cmd, h.eventStore, h.projectionStore)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	if !cmd.DryRun {
+		h.runSyncQuietly(r)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"dry_run": cmd.DryRun, "results": results})
+}
+
 func (h *Handlers) AddNote(w http.ResponseWriter, r *http.Request) {
 	realmID, ok := RealmIDFromContext(r.Context())
 	if !ok {
@@ -526,7 +878,8 @@ func (h *Handlers) AddNote(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleAddNote(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	if err := domain.HandleAddNote(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -545,7 +898,8 @@ func (h *Handlers) AddRetro(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleAddRetro(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	if err := domain.HandleAddRetro(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -564,7 +918,8 @@ func (h *Handlers) AddAC(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleAddACItem(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	if err := domain.HandleAddACItem(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -583,7 +938,8 @@ func (h *Handlers) UpdateAC(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleUpdateACItem(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	if err := domain.HandleUpdateACItem(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -602,7 +958,28 @@ func (h *Handlers) RemoveAC(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleRemoveACItem(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	if err := domain.HandleRemoveACItem(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) VerifyAC(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.VerifyACItem
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	if err := domain.HandleVerifyACItem(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -621,7 +998,8 @@ func (h *Handlers) UpdateRuneState(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleUpdateRuneState(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	if err := domain.HandleUpdateRuneState(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -640,7 +1018,8 @@ func (h *Handlers) ClearRuneState(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if err := domain.HandleClearRuneState(r.Context(), realmID, cmd, h.eventStore); err != nil {
+	cmd.RuneID = h.resolveRuneID(r.Context(), realmID, cmd.RuneID)
+	if err := domain.HandleClearRuneState(r.Context(), realmID, cmd, h.eventStore, h.projectionStore); err != nil {
 		handleDomainError(w, err)
 		return
 	}
@@ -659,6 +1038,7 @@ func (h *Handlers) GetRetro(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "id query parameter is required")
 		return
 	}
+	id = h.resolveRuneID(r.Context(), realmID, id)
 
 	// Determine if id refers to a saga by checking rune_child_count.
 	var childCount projectors.RuneChildCountEntry
@@ -754,11 +1134,88 @@ func (h *Handlers) SuspendRealm(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handlers) RenameRealm(w http.ResponseWriter, r *http.Request) {
+	var cmd domain.RenameRealm
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if cmd.RealmID == "" {
+		writeError(w, http.StatusBadRequest, "realm_id is required")
+		return
+	}
+
+	if err := domain.HandleRenameRealm(r.Context(), cmd, h.eventStore, h.projectionStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) ReactivateRealm(w http.ResponseWriter, r *http.Request) {
+	var cmd domain.ReactivateRealm
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if cmd.RealmID == "" {
+		writeError(w, http.StatusBadRequest, "realm_id is required")
+		return
+	}
+
+	if err := domain.HandleReactivateRealm(r.Context(), cmd, h.eventStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) ArchiveRealm(w http.ResponseWriter, r *http.Request) {
+	var cmd domain.ArchiveRealm
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if cmd.RealmID == "" {
+		writeError(w, http.StatusBadRequest, "realm_id is required")
+		return
+	}
+
+	if err := domain.HandleArchiveRealm(r.Context(), cmd, h.eventStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) DeleteRealm(w http.ResponseWriter, r *http.Request) {
+	var cmd domain.DeleteRealm
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if cmd.RealmID == "" {
+		writeError(w, http.StatusBadRequest, "realm_id is required")
+		return
+	}
+
+	if err := domain.HandleDeleteRealm(r.Context(), cmd, h.eventStore, h.projectionStore, h.realmPurgers...); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *Handlers) RebuildProjections(w http.ResponseWriter, r *http.Request) {
 	if err := h.engine.RebuildProjections(r.Context()); err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	h.securityLog.RecordRequest(r, admin.ActionProjectionsRebuilt, projectors.AuditLogEntry{})
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
@@ -801,10 +1258,21 @@ func (h *Handlers) ListRunes(w http.ResponseWriter, r *http.Request) {
 	priorityFilter := r.URL.Query().Get("priority")
 	assigneeFilter := r.URL.Query().Get("assignee")
 	branchFilter := r.URL.Query().Get("branch")
-	parentFilter := r.URL.Query().Get("parent_id")
+	parentFilter := h.resolveRuneID(r.Context(), realmID, r.URL.Query().Get("parent_id"))
 	tagFilters := parseTagFilters(r)
+	overdueFilter, err := parseOptionalBool(r.URL.Query().Get("overdue"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid overdue filter")
+		return
+	}
+	deferredFilter, err := parseOptionalBool(r.URL.Query().Get("deferred"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid deferred filter")
+		return
+	}
+	now := time.Now()
 
-	if statusFilter != "" || priorityFilter != "" || assigneeFilter != "" || branchFilter != "" || parentFilter != "" || len(tagFilters) > 0 {
+	if statusFilter != "" || priorityFilter != "" || assigneeFilter != "" || branchFilter != "" || parentFilter != "" || len(tagFilters) > 0 || overdueFilter != nil || deferredFilter != nil {
 		var filtered []json.RawMessage
 		for _, raw := range runes {
 			var item map[string]any
@@ -839,6 +1307,12 @@ func (h *Handlers) ListRunes(w http.ResponseWriter, r *http.Request) {
 			if len(tagFilters) > 0 && !itemHasAnyTag(item, tagFilters) {
 				continue
 			}
+			if overdueFilter != nil && isRuneOverdue(item, now) != *overdueFilter {
+				continue
+			}
+			if deferredFilter != nil && isRuneDeferred(item, now) != *deferredFilter {
+				continue
+			}
 			filtered = append(filtered, raw)
 		}
 		runes = filtered
@@ -932,6 +1406,7 @@ func (h *Handlers) ListRunes(w http.ResponseWriter, r *http.Request) {
 
 		item["dependencies_count"] = depCount
 		item["dependents_count"] = dependentCount
+		item["overdue"] = isRuneOverdue(item, now)
 		claimant, _ := item["claimant"].(string)
 		if claimant != "" {
 			var accountEntry projectors.AccountAuthEntry
@@ -958,13 +1433,33 @@ func (h *Handlers) Ready(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusForbidden, "realm ID required")
 		return
 	}
-	runes, err := h.projectionStore.List(r.Context(), realmID, "rune_summary")
+	ready, err := h.readyRunes(r.Context(), realmID, readyFilter{
+		parentID: h.resolveRuneID(r.Context(), realmID, r.URL.Query().Get("parent_id")),
+	})
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to list runes")
 		return
 	}
+	writeJSON(w, http.StatusOK, ready)
+}
+
+// readyFilter narrows the ready runes. Empty fields match every rune; a rune
+// matches tags when it carries any of them.
+type readyFilter struct {
+	parentID string
+	branch   string
+	runeType string
+	tags     []string
+}
 
-	parentFilter := r.URL.Query().Get("parent_id")
+// readyRunes returns the open, undeferred and unblocked runes matching
+// filter, highest priority first.
+func (h *Handlers) readyRunes(ctx context.Context, realmID string, filter readyFilter) ([]map[string]any, error) {
+	runes, err := h.projectionStore.List(ctx, realmID, "rune_summary")
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
 
 	var ready []map[string]any
 	for _, raw := range runes {
@@ -978,14 +1473,28 @@ func (h *Handlers) Ready(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		if parentFilter != "" && fmt.Sprintf("%v", item["parent_id"]) != parentFilter {
+		if filter.parentID != "" && fmt.Sprintf("%v", item["parent_id"]) != filter.parentID {
+			continue
+		}
+		if filter.branch != "" && fmt.Sprintf("%v", item["branch"]) != filter.branch {
+			continue
+		}
+		if filter.runeType != "" && fmt.Sprintf("%v", item["type"]) != filter.runeType {
+			continue
+		}
+		if len(filter.tags) > 0 && !itemHasAnyTag(item, filter.tags) {
+			continue
+		}
+
+		// Deferred runes stay hidden until their defer_until time passes
+		if isRuneDeferred(item, now) {
 			continue
 		}
 
 		// Filter to blocked=false
 		runeID := fmt.Sprintf("%v", item["id"])
 		var detail projectors.RuneDetail
-		err = h.projectionStore.Get(r.Context(), realmID, "rune_detail", runeID, &detail)
+		err = h.projectionStore.Get(ctx, realmID, "rune_detail", runeID, &detail)
 		if err != nil {
 			// If we can't get details, assume unblocked
 			ready = append(ready, item)
@@ -995,7 +1504,7 @@ func (h *Handlers) Ready(w http.ResponseWriter, r *http.Request) {
 		for _, dep := range detail.Dependencies {
 			if dep.Relationship == domain.RelBlockedBy {
 				var summary projectors.RuneSummary
-				err := h.projectionStore.Get(r.Context(), realmID, "rune_summary", dep.TargetID, &summary)
+				err := h.projectionStore.Get(ctx, realmID, "rune_summary", dep.TargetID, &summary)
 				if err != nil || summary.Status != "fulfilled" {
 					isBlocked = true
 					break
@@ -1026,43 +1535,534 @@ func (h *Handlers) Ready(w http.ResponseWriter, r *http.Request) {
 		return riNum < rjNum
 	})
 
-	writeJSON(w, http.StatusOK, ready)
+	return ready, nil
 }
 
-func (h *Handlers) GetRune(w http.ResponseWriter, r *http.Request) {
+// GET /api/search returns at most searchMaxLimit results, searchDefaultLimit
+// unless limit is given.
+const (
+	searchDefaultLimit = 20
+	searchMaxLimit     = 100
+)
+
+// SearchResult is one rune matched by GET /api/search. Snippet is the best
+// matching text, with the query terms wrapped in ** markers.
+type SearchResult struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Status   string   `json:"status"`
+	Priority int      `json:"priority"`
+	Type     string   `json:"type,omitempty"`
+	Tags     []string `json:"tags"`
+	Rank     float64  `json:"rank"`
+	Snippet  string   `json:"snippet"`
+}
+
+// Search full-text searches the titles, descriptions, acceptance criteria,
+// notes and retro items of the realm's runes, best match first.
+func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
 	realmID, ok := RealmIDFromContext(r.Context())
 	if !ok {
 		writeError(w, http.StatusForbidden, "realm ID required")
 		return
 	}
-	runeID := r.URL.Query().Get("id")
-	if runeID == "" {
-		writeError(w, http.StatusBadRequest, "id query parameter is required")
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, "q is required")
 		return
 	}
-	var detail projectors.RuneDetail
-	err := h.projectionStore.Get(r.Context(), realmID, "rune_detail", runeID, &detail)
-	if err != nil {
-		if isNotFound(err) {
-			writeError(w, http.StatusNotFound, "rune not found")
+	limit := searchDefaultLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "failed to get rune")
+		limit = min(n, searchMaxLimit)
+	}
+	searcher, ok := h.projectionStore.(core.TextSearcher)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "search is not supported by this database")
 		return
 	}
-	writeJSON(w, http.StatusOK, detail)
-}
 
-func (h *Handlers) ListRealms(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	statusFilter := r.URL.Query().Get("status")
+	tagFilters := parseTagFilters(r)
+	// Filtered searches read every hit, since the filters drop some of them
+	storeLimit := limit
+	if statusFilter != "" || len(tagFilters) > 0 {
+		storeLimit = 0
+	}
+	hits, err := searcher.Search(r.Context(), realmID, projectors.RuneSearchTable.Name, query, storeLimit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to search runes")
+		return
+	}
 
-	// Determine which realm IDs this user can see
-	var realmIDs []string
-	accountID, hasAccountID := AccountIDFromContext(ctx)
-	if hasAccountID && accountID != "" {
-		var accountEntry projectors.AccountAuthEntry
-		if err := h.projectionStore.Get(ctx, "_admin", "account_auth", accountID, &accountEntry); err == nil {
-			isSysAdmin := accountEntry.Roles["_admin"] == "admin" || accountEntry.Roles["_admin"] == "owner"
+	results := make([]SearchResult, 0, min(len(hits), limit))
+	for _, hit := range hits {
+		summary, err := core.GetRef(r.Context(), h.projectionStore, realmID, projectors.RuneSummaryTable, hit.Key)
+		if err != nil {
+			continue
+		}
+		if statusFilter != "" && summary.Status != statusFilter {
+			continue
+		}
+		if len(tagFilters) > 0 && !slices.ContainsFunc(tagFilters, func(tag string) bool {
+			return slices.Contains(normalizeTagList(summary.Tags), tag)
+		}) {
+			continue
+		}
+		results = append(results, SearchResult{
+			ID:       summary.ID,
+			Title:    summary.Title,
+			Status:   summary.Status,
+			Priority: summary.Priority,
+			Type:     summary.Type,
+			Tags:     summary.Tags,
+			Rank:     hit.Rank,
+			Snippet:  hit.Snippet,
+		})
+		if len(results) == limit {
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (h *Handlers) GetRune(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	runeID := r.URL.Query().Get("id")
+	if runeID == "" {
+		writeError(w, http.StatusBadRequest, "id query parameter is required")
+		return
+	}
+	runeID = h.resolveRuneID(r.Context(), realmID, runeID)
+	resp, err := h.runeDetail(r.Context(), realmID, runeID)
+	if err != nil {
+		if isNotFound(err) {
+			writeError(w, http.StatusNotFound, "rune not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get rune")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// runeDetail reads a rune's detail along with its saga rollup.
+func (h *Handlers) runeDetail(ctx context.Context, realmID, runeID string) (runeDetailResponse, error) {
+	var detail projectors.RuneDetail
+	if err := h.projectionStore.Get(ctx, realmID, "rune_detail", runeID, &detail); err != nil {
+		return runeDetailResponse{}, err
+	}
+	resp := runeDetailResponse{RuneDetail: detail}
+	var progress projectors.SagaProgress
+	if err := h.projectionStore.Get(ctx, realmID, "saga_progress", runeID, &progress); err == nil && progress.Total > 0 {
+		progress.Blocked = h.countBlockedChildren(ctx, realmID, progress)
+		resp.SagaProgress = &progress
+	}
+	return resp, nil
+}
+
+// runeDetailResponse is the GET /rune payload: the projected detail plus the
+// saga rollup when the rune has children.
+type runeDetailResponse struct {
+	projectors.RuneDetail
+	SagaProgress *projectors.SagaProgress `json:"saga_progress,omitempty"`
+}
+
+// countBlockedChildren counts unfinished children that still have an
+// unfulfilled blocked_by dependency.
+func (h *Handlers) countBlockedChildren(ctx context.Context, realmID string, progress projectors.SagaProgress) int {
+	blocked := 0
+	for childID, status := range progress.Children {
+		switch status {
+		case "fulfilled", "sealed", "failed":
+			continue
+		}
+		var child projectors.RuneDetail
+		if err := h.projectionStore.Get(ctx, realmID, "rune_detail", childID, &child); err != nil {
+			continue
+		}
+		if h.isBlocked(ctx, realmID, child.Dependencies) {
+			blocked++
+		}
+	}
+	return blocked
+}
+
+func (h *Handlers) isBlocked(ctx context.Context, realmID string, deps []projectors.DependencyRef) bool {
+	for _, dep := range deps {
+		if dep.Relationship != domain.RelBlockedBy {
+			continue
+		}
+		var blocker projectors.RuneSummary
+		if err := h.projectionStore.Get(ctx, realmID, "rune_summary", dep.TargetID, &blocker); err != nil {
+			continue
+		}
+		if blocker.Status != "fulfilled" {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handlers) GetRealmSettings(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	settings, err := domain.ReadRealmSettings(r.Context(), realmID, h.projectionStore)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get realm settings")
+		return
+	}
+	writeJSON(w, http.StatusOK, settings)
+}
+
+func (h *Handlers) GetWIPUsage(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	usage, err := domain.ReadWIPUsage(r.Context(), realmID, h.projectionStore)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get WIP usage")
+		return
+	}
+	writeJSON(w, http.StatusOK, usage)
+}
+
+// StreamEvents streams the realm's events as Server-Sent Events, optionally
+// filtered by event type, rune and tag. Without Last-Event-ID only events
+// appended after the stream opens are sent.
+func (h *Handlers) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	if h.events == nil {
+		writeError(w, http.StatusServiceUnavailable, "event streaming is not enabled")
+		return
+	}
+	from, resuming, err := lastEventID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !resuming {
+		if from, err = h.events.head(r.Context(), realmID); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to read events")
+			return
+		}
+	}
+
+	var eventTypes []string
+	for _, value := range r.URL.Query()["type"] {
+		for _, eventType := range strings.Split(value, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				eventTypes = append(eventTypes, eventType)
+			}
+		}
+	}
+	h.events.Stream(w, r, realmID, from, eventStreamFilter{
+		eventTypes: eventTypes,
+		runeID:     h.resolveRuneID(r.Context(), realmID, r.URL.Query().Get("rune_id")),
+		tags:       parseTagFilters(r),
+	})
+}
+
+func (h *Handlers) UpdateRealmSettings(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.UpdateRealmSettings
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.RealmID = realmID
+	if err := domain.HandleUpdateRealmSettings(r.Context(), cmd, h.eventStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	policies, err := domain.ReadRealmPolicies(r.Context(), realmID, h.projectionStore)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, policies.Policies)
+}
+
+func (h *Handlers) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.SetPolicy
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.RealmID = realmID
+	if err := domain.HandleSetPolicy(r.Context(), cmd, h.eventStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) RemovePolicy(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.RemovePolicy
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.RealmID = realmID
+	if err := domain.HandleRemovePolicy(r.Context(), cmd, h.eventStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// webhookView is a webhook as listed by the API, without its secret.
+type webhookView struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (h *Handlers) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	webhooks, err := domain.ReadRealmWebhooks(r.Context(), realmID, h.projectionStore)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	views := make([]webhookView, 0, len(webhooks.Webhooks))
+	for _, webhook := range webhooks.Webhooks {
+		views = append(views, webhookView{
+			ID:         webhook.ID,
+			URL:        webhook.URL,
+			EventTypes: webhook.EventTypes,
+			Tags:       webhook.Tags,
+			CreatedAt:  webhook.CreatedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (h *Handlers) AddWebhook(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.AddWebhook
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.RealmID = realmID
+	if h.webhooks != nil {
+		cmd.AllowedHosts = h.webhooks.AllowedHosts()
+	}
+	result, err := domain.HandleAddWebhook(r.Context(), cmd, h.eventStore)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	writeJSON(w, http.StatusCreated, result)
+}
+
+func (h *Handlers) RemoveWebhook(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.RemoveWebhook
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.RealmID = realmID
+	if err := domain.HandleRemoveWebhook(r.Context(), cmd, h.eventStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestWebhook sends a WebhookTest delivery and returns it, whether or not
+// the receiver accepted it.
+func (h *Handlers) TestWebhook(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	if h.webhooks == nil {
+		writeError(w, http.StatusServiceUnavailable, "webhook delivery is not enabled")
+		return
+	}
+	var body struct {
+		WebhookID string `json:"webhook_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.WebhookID == "" {
+		writeError(w, http.StatusBadRequest, "webhook_id is required")
+		return
+	}
+	delivery, err := h.webhooks.Test(r.Context(), realmID, body.WebhookID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, delivery)
+}
+
+// ListWebhookDeliveries returns the realm's delivery log, newest first,
+// optionally for one webhook. limit defaults to 50 and is capped at 500.
+func (h *Handlers) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	limit := 50
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = min(n, 500)
+	}
+	deliveries, err := readWebhookDeliveries(r.Context(), h.projectionStore, realmID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	webhookID := r.URL.Query().Get("webhook_id")
+	result := make([]WebhookDelivery, 0, min(len(deliveries), limit))
+	for _, delivery := range deliveries {
+		if webhookID != "" && delivery.WebhookID != webhookID {
+			continue
+		}
+		result = append(result, delivery)
+		if len(result) == limit {
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handlers) ListRoles(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	roles, err := domain.ReadRealmRoles(r.Context(), realmID, h.projectionStore)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	list := make([]domain.CustomRole, 0, len(domain.ValidRoles)+len(roles.Roles))
+	for _, role := range domain.ValidRoles {
+		list = append(list, domain.CustomRole{Name: role, Permissions: domain.BuiltinRolePermissions(role)})
+	}
+	list = append(list, roles.Roles...)
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (h *Handlers) DefineRole(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.DefineCustomRole
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.RealmID = realmID
+	if err := domain.HandleDefineCustomRole(r.Context(), cmd, h.eventStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	realmID, ok := RealmIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusForbidden, "realm ID required")
+		return
+	}
+	var cmd domain.DeleteCustomRole
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cmd.RealmID = realmID
+	if err := domain.HandleDeleteCustomRole(r.Context(), cmd, h.eventStore); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	h.runSyncQuietly(r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) ListRealms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Determine which realm IDs this user can see
+	var realmIDs []string
+	accountID, hasAccountID := AccountIDFromContext(ctx)
+	if hasAccountID && accountID != "" {
+		var accountEntry projectors.AccountAuthEntry
+		if err := h.projectionStore.Get(ctx, "_admin", "account_auth", accountID, &accountEntry); err == nil {
+			isSysAdmin := accountEntry.Roles["_admin"] == "admin" || accountEntry.Roles["_admin"] == "owner"
 			if isSysAdmin {
 				// System admins see all realms — read from projection, not event store
 				entries, err := h.projectionStore.List(ctx, "_admin", "realm_directory")
@@ -1205,6 +2205,14 @@ func writeError(w http.ResponseWriter, statusCode int, message string) {
 	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
 
+// callerHasPermission reports whether the caller's role in the request realm grants perm.
+func (h *Handlers) callerHasPermission(r *http.Request, perm string) bool {
+	role, _ := RoleFromContext(r.Context())
+	realmID, _ := RealmIDFromContext(r.Context())
+	perms, err := domain.RolePermissions(r.Context(), realmID, role, h.projectionStore)
+	return err == nil && slices.Contains(perms, perm)
+}
+
 func handleDomainError(w http.ResponseWriter, err error) {
 	var concErr *core.ConcurrencyError
 	if errors.As(err, &concErr) {
@@ -1218,6 +2226,24 @@ func handleDomainError(w http.ResponseWriter, err error) {
 		return
 	}
 
+	var wipErr *domain.WIPLimitError
+	if errors.As(err, &wipErr) {
+		writeJSON(w, http.StatusConflict, map[string]string{
+			"error": err.Error(),
+			"limit": wipErr.Limit,
+		})
+		return
+	}
+
+	var policyErr *domain.PolicyViolationError
+	if errors.As(err, &policyErr) {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{
+			"error":  err.Error(),
+			"policy": policyErr.Policy,
+		})
+		return
+	}
+
 	var badReqErr *core.BadRequestError
 	if errors.As(err, &badReqErr) {
 		writeError(w, http.StatusBadRequest, err.Error())
@@ -1315,6 +2341,52 @@ func itemHasAnyTag(item map[string]any, wanted []string) bool {
 	return false
 }
 
+// itemTime parses an RFC 3339 timestamp field from a projected rune item.
+func itemTime(item map[string]any, field string) (time.Time, bool) {
+	raw, ok := item[field].(string)
+	if !ok || raw == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// isRuneDeferred reports whether the rune has a defer_until time in the future.
+func isRuneDeferred(item map[string]any, now time.Time) bool {
+	deferUntil, ok := itemTime(item, "defer_until")
+	return ok && now.Before(deferUntil)
+}
+
+// isRuneOverdue reports whether the rune is past its due_at time and has not
+// reached a terminal status.
+func isRuneOverdue(item map[string]any, now time.Time) bool {
+	dueAt, ok := itemTime(item, "due_at")
+	if !ok || !now.After(dueAt) {
+		return false
+	}
+	switch fmt.Sprintf("%v", item["status"]) {
+	case "fulfilled", "sealed", "failed", "shattered":
+		return false
+	}
+	return true
+}
+
+// parseOptionalBool parses a boolean query parameter, returning nil when it
+// is absent.
+func parseOptionalBool(raw string) (*bool, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
 func parseRuneIDSuffix(id string) int {
 	re := regexp.MustCompile(`\.(\d+)$`)
 	matches := re.FindStringSubmatch(id)
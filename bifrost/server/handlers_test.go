@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/devzeebo/bifrost/core"
 	"github.com/devzeebo/bifrost/domain"
@@ -158,7 +160,7 @@ func TestCreateRuneHandler(t *testing.T) {
 		// When
 		tc.post("/create-rune", domain.CreateRune{
 			Title:    "Fix bug",
-			Priority: 1,
+			Priority: intPtr(1),
 			Branch:   strPtr("main"),
 		})
 
@@ -224,76 +226,63 @@ func TestUpdateRuneHandler(t *testing.T) {
 		tc.status_is(http.StatusNotFound)
 		tc.response_body_has_error_field()
 	})
-}
-
-// --- Tests: ClaimRune ---
 
-func TestClaimRuneHandler(t *testing.T) {
-	t.Run("claims rune and returns 204", func(t *testing.T) {
+	t.Run("cascades a priority change to descendants", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
 		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001.1")
+		tc.rune_has_children("realm-1", "bf-0001", "bf-0001.1")
 
 		// When
-		tc.post("/claim-rune", domain.ClaimRune{
-			ID:       "bf-0001",
-			Claimant: "alice",
-		})
+		tc.post("/update-rune", map[string]any{"id": "bf-0001", "priority": 2, "cascade": true})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusOK)
+		tc.event_was_appended("realm-1", "rune-bf-0001.1", domain.EventRuneUpdated)
 	})
-}
 
-// --- Tests: UnclaimRune ---
-
-func TestUnclaimRuneHandler(t *testing.T) {
-	t.Run("unclaims rune and returns 204", func(t *testing.T) {
+	t.Run("returns 400 when cascade is combined with non-priority fields", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.rune_is_claimed_in_event_store("realm-1", "bf-0001", "alice")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001.1")
+		tc.rune_has_children("realm-1", "bf-0001", "bf-0001.1")
 
 		// When
-		tc.post("/unclaim-rune", domain.UnclaimRune{
-			ID: "bf-0001",
-		})
+		tc.post("/update-rune", map[string]any{"id": "bf-0001", "priority": 2, "title": "Renamed", "cascade": true})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusBadRequest)
+		tc.event_was_appended("realm-1", "rune-bf-0001.1", domain.EventRuneForged)
 	})
-}
 
-// --- Tests: FulfillRune ---
-
-func TestFulfillRuneHandler(t *testing.T) {
-	t.Run("fulfills rune and returns 204", func(t *testing.T) {
+	t.Run("returns 400 when dry run is combined with non-priority fields", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.rune_is_claimed_in_event_store("realm-1", "bf-0001", "alice")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
 
 		// When
-		tc.post("/fulfill-rune", domain.FulfillRune{
-			ID: "bf-0001",
-		})
+		tc.post("/update-rune", map[string]any{"id": "bf-0001", "add_tags": []string{"x"}, "cascade": true, "dry_run": true})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusBadRequest)
 	})
 }
 
-// --- Tests: SealRune ---
+// --- Tests: ClaimRune ---
 
-func TestSealRuneHandler(t *testing.T) {
-	t.Run("seals rune and returns 204", func(t *testing.T) {
+func TestClaimRuneHandler(t *testing.T) {
+	t.Run("claims rune and returns 204", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
@@ -302,9 +291,9 @@ func TestSealRuneHandler(t *testing.T) {
 		tc.rune_exists_in_event_store("realm-1", "bf-0001")
 
 		// When
-		tc.post("/seal-rune", domain.SealRune{
-			ID:     "bf-0001",
-			Reason: "done",
+		tc.post("/claim-rune", domain.ClaimRune{
+			ID:       "bf-0001",
+			Claimant: "alice",
 		})
 
 		// Then
@@ -312,957 +301,2146 @@ func TestSealRuneHandler(t *testing.T) {
 	})
 }
 
-// --- Tests: ForgeRune ---
+// --- Tests: ClaimNext ---
 
-func TestForgeRuneHandler(t *testing.T) {
-	t.Run("forges rune and returns 204", func(t *testing.T) {
+func TestClaimNextHandler(t *testing.T) {
+	t.Run("claims the highest-priority ready rune and returns its detail", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.rune_exists_as_draft_in_event_store("realm-1", "bf-0001")
+		tc.request_has_account_id("acct-1")
+		tc.ready_rune("realm-1", "bf-0001", 2, "")
+		tc.ready_rune("realm-1", "bf-0002", 1, "")
 
 		// When
-		tc.post("/forge-rune", domain.ForgeRune{
-			ID: "bf-0001",
-		})
+		tc.post("/claim-next", map[string]any{"claimant": "alice"})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusOK)
+		tc.response_rune_id_is("bf-0002")
+		tc.event_was_appended("realm-1", "rune-bf-0002", domain.EventRuneClaimed)
+		tc.appended_event_data_has("realm-1", "rune-bf-0002", "account_id", "acct-1")
+		tc.no_event_was_appended("realm-1", "rune-bf-0001", domain.EventRuneClaimed)
 	})
 
-	t.Run("returns 400 for invalid JSON body", func(t *testing.T) {
+	t.Run("skips a rune another caller has already claimed", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
+		tc.ready_rune("realm-1", "bf-0001", 1, "")
+		tc.ready_rune("realm-1", "bf-0002", 2, "")
+		tc.eventStore.appendToStream("realm-1", "rune-bf-0001", domain.EventRuneClaimed, domain.RuneClaimed{ID: "bf-0001", Claimant: "bob"})
 
 		// When
-		tc.post_raw("/forge-rune", []byte(`{invalid`))
+		tc.post("/claim-next", map[string]any{"claimant": "alice"})
 
 		// Then
-		tc.status_is(http.StatusBadRequest)
-		tc.response_body_has_error_field()
+		tc.status_is(http.StatusOK)
+		tc.response_rune_id_is("bf-0002")
 	})
-}
-
-// --- Tests: AddDependency ---
 
-func TestAddDependencyHandler(t *testing.T) {
-	t.Run("adds dependency and returns 204", func(t *testing.T) {
+	t.Run("matches tag and type filters", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.rune_exists_in_event_store("realm-1", "bf-0001")
-		tc.rune_exists_in_event_store("realm-1", "bf-0002")
+		tc.ready_rune("realm-1", "bf-0001", 0, "bug", "backend")
+		tc.ready_rune("realm-1", "bf-0002", 1, "task", "backend")
+		tc.ready_rune("realm-1", "bf-0003", 2, "bug", "release")
 
 		// When
-		tc.post("/add-dependency", domain.AddDependency{
-			RuneID:       "bf-0001",
-			TargetID:     "bf-0002",
-			Relationship: "relates_to",
-		})
+		tc.post("/claim-next", map[string]any{"claimant": "alice", "type": "bug", "tags": []string{"Release"}})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusOK)
+		tc.response_rune_id_is("bf-0003")
 	})
-}
 
-// --- Tests: RemoveDependency ---
-
-func TestRemoveDependencyHandler(t *testing.T) {
-	t.Run("removes dependency and returns 204", func(t *testing.T) {
+	t.Run("returns 204 when no rune is ready", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.rune_with_dependency("realm-1", "bf-0001", "bf-0002", "relates_to")
 
 		// When
-		tc.post("/remove-dependency", domain.RemoveDependency{
-			RuneID:       "bf-0001",
-			TargetID:     "bf-0002",
-			Relationship: "relates_to",
-		})
+		tc.post("/claim-next", map[string]any{"claimant": "alice"})
 
 		// Then
 		tc.status_is(http.StatusNoContent)
 	})
-}
-
-// --- Tests: AddNote ---
 
-func TestAddNoteHandler(t *testing.T) {
-	t.Run("adds note and returns 204", func(t *testing.T) {
+	t.Run("returns the policy rejection when every candidate is rejected", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.ready_rune("realm-1", "bf-0001", 1, "")
+		tc.realm_has_policy("realm-1", "no-claims", "false", "claim")
 
 		// When
-		tc.post("/add-note", domain.AddNote{
-			RuneID: "bf-0001",
-			Text:   "some note",
-		})
+		tc.post("/claim-next", map[string]any{"claimant": "alice"})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusUnprocessableEntity)
+		tc.response_body_contains("no-claims")
 	})
-}
-
-// --- Tests: CreateRealm ---
 
-func TestCreateRealmHandler(t *testing.T) {
-	t.Run("creates realm and returns 201", func(t *testing.T) {
+	t.Run("stops long-polling when the handlers close", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
-		tc.event_store_appends_successfully()
+		tc.request_has_realm_id("realm-1")
+		tc.handlers.Close()
 
 		// When
-		tc.post("/create-realm", domain.CreateRealm{
-			Name: "My Realm",
-		})
+		tc.post("/claim-next", map[string]any{"claimant": "alice", "wait_seconds": 30})
 
 		// Then
-		tc.status_is(http.StatusCreated)
-		tc.content_type_is_json()
-		tc.response_body_has_field("realm_id")
+		tc.status_is(http.StatusNoContent)
 	})
 
-	t.Run("auto-assigns creator as owner", func(t *testing.T) {
+	t.Run("catches up projections once after a claim", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
-		tc.request_has_account_id("acct-creator")
-		tc.account_exists_in_event_store("acct-creator")
+		tc.request_has_realm_id("realm-1")
+		tc.ready_rune("realm-1", "bf-0001", 1, "")
 
 		// When
-		tc.post("/create-realm", domain.CreateRealm{
-			Name: "My Realm",
-		})
+		tc.post("/claim-next", map[string]any{"claimant": "alice"})
 
 		// Then
-		tc.status_is(http.StatusCreated)
-
-		stream := tc.eventStore.streams["_admin:account-acct-creator"]
-		require.NotEmpty(t, stream)
-
-		lastEvent := stream[len(stream)-1]
-		assert.Equal(t, domain.EventRoleAssigned, lastEvent.EventType)
-
-		var roleAssigned domain.RoleAssigned
-		err := json.Unmarshal(lastEvent.Data, &roleAssigned)
-		require.NoError(t, err)
-		assert.Equal(t, "acct-creator", roleAssigned.AccountID)
-		assert.Equal(t, domain.RoleOwner, roleAssigned.Role)
-		assert.NotEmpty(t, roleAssigned.RealmID)
+		tc.status_is(http.StatusOK)
+		tc.catch_up_ran_times(1)
 	})
-}
-
-// --- Tests: ListRealms ---
 
-func TestListRealmsHandler(t *testing.T) {
-	t.Run("returns list of realms", func(t *testing.T) {
+	t.Run("does not catch up projections while polling for work", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
-		tc.request_has_account_id("acct-admin")
-		tc.account_is_sys_admin("acct-admin")
-		tc.has_realm_list()
+		tc.handlers.claimPoll = 10 * time.Millisecond
+		tc.request_has_realm_id("realm-1")
 
 		// When
-		tc.get("/realms")
+		tc.post("/claim-next", map[string]any{"claimant": "alice", "wait_seconds": 1})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_is_non_empty_json_array()
+		tc.status_is(http.StatusNoContent)
+		tc.catch_up_ran_times(0)
 	})
 
-	t.Run("returns empty array when no realms exist", func(t *testing.T) {
+	t.Run("locks claims per realm", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
-		tc.request_has_account_id("acct-admin")
-		tc.account_is_sys_admin("acct-admin")
 
 		// When
-		tc.get("/realms")
+		tc.realm_claims_are_locked("realm-1")
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_is_empty_json_array()
+		tc.realm_claims_are_unlocked("realm-2")
 	})
 
-	t.Run("excludes suspended realms by default", func(t *testing.T) {
+	t.Run("requires a claimant", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
-		tc.request_has_account_id("acct-admin")
-		tc.account_is_sys_admin("acct-admin")
-		tc.has_realm_list()
-		tc.has_suspended_realm("realm-suspended", "Suspended Realm")
+		tc.request_has_realm_id("realm-1")
 
 		// When
-		tc.get("/realms")
+		tc.post("/claim-next", map[string]any{})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.response_array_has_length(1)
+		tc.status_is(http.StatusBadRequest)
 	})
+}
 
-	t.Run("includes suspended realms with include_suspended=true", func(t *testing.T) {
+func TestSearchHandler(t *testing.T) {
+	t.Run("returns matching runes in rank order with snippets", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
-		tc.handlers_configured()
-		tc.request_has_account_id("acct-admin")
-		tc.account_is_sys_admin("acct-admin")
-		tc.has_realm_list()
-		tc.has_suspended_realm("realm-suspended", "Suspended Realm")
+		tc.ready_rune("realm-1", "bf-0001", 2, "")
+		tc.ready_rune("realm-1", "bf-0002", 1, "")
+		tc.search_returns(
+			core.SearchHit{Key: "bf-0002", Rank: 2, Snippet: "**login** redirect"},
+			core.SearchHit{Key: "bf-0001", Rank: 1, Snippet: "the **login** page"},
+		)
+		tc.request_has_realm_id("realm-1")
 
 		// When
-		tc.get("/realms?include_suspended=true")
+		tc.get("/search?q=login")
 
 		// Then
 		tc.status_is(http.StatusOK)
-		tc.response_array_has_length(2)
+		tc.search_was_for("realm-1", "login", searchDefaultLimit)
+		results := tc.search_results()
+		require.Len(t, results, 2)
+		assert.Equal(t, "bf-0002", results[0].ID)
+		assert.Equal(t, "**login** redirect", results[0].Snippet)
+		assert.Equal(t, "open", results[0].Status)
 	})
-}
 
-// --- Tests: ListRunes ---
-
-func TestListRunesHandler(t *testing.T) {
-	t.Run("returns list of runes for realm", func(t *testing.T) {
+	t.Run("filters by status and tag and applies the limit", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
-		tc.handlers_configured()
+		tc.ready_rune("realm-1", "bf-0001", 1, "", "backend")
+		tc.ready_rune("realm-1", "bf-0002", 1, "", "frontend")
+		tc.ready_rune("realm-1", "bf-0003", 1, "", "backend")
+		tc.search_returns(
+			core.SearchHit{Key: "bf-0001", Rank: 3},
+			core.SearchHit{Key: "bf-0002", Rank: 2},
+			core.SearchHit{Key: "bf-0003", Rank: 1},
+		)
 		tc.request_has_realm_id("realm-1")
-		tc.has_rune_list("realm-1")
 
 		// When
-		tc.get("/runes")
+		tc.get("/search?q=login&status=open&tag=backend&limit=1")
 
 		// Then
 		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_is_non_empty_json_array()
+		tc.search_was_for("realm-1", "login", 0)
+		results := tc.search_results()
+		require.Len(t, results, 1)
+		assert.Equal(t, "bf-0001", results[0].ID)
 	})
 
-	t.Run("returns empty array when no runes exist", func(t *testing.T) {
+	t.Run("skips hits without a rune summary", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
-		tc.handlers_configured()
+		tc.search_returns(core.SearchHit{Key: "bf-gone", Rank: 1})
 		tc.request_has_realm_id("realm-1")
 
 		// When
-		tc.get("/runes")
+		tc.get("/search?q=login")
 
 		// Then
 		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
 		tc.response_is_empty_json_array()
 	})
 
-	t.Run("filters runes by status query parameter", func(t *testing.T) {
+	t.Run("rejects a missing query or bad limit", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
-		tc.handlers_configured()
+		tc.search_returns()
 		tc.request_has_realm_id("realm-1")
-		tc.has_mixed_runes("realm-1")
 
 		// When
-		tc.get("/runes?status=open")
+		tc.get("/search?q=+")
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_array_has_length(1)
-		tc.response_array_all_have_field_value("status", "open")
+		tc.status_is(http.StatusBadRequest)
+
+		// When
+		tc.recorder = httptest.NewRecorder()
+		tc.get("/search?q=login&limit=0")
+
+		// Then
+		tc.status_is(http.StatusBadRequest)
 	})
 
-	t.Run("filters runes by priority query parameter", func(t *testing.T) {
+	t.Run("is not implemented without a searchable store", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_mixed_runes("realm-1")
 
 		// When
-		tc.get("/runes?priority=1")
+		tc.get("/search?q=login")
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_array_has_length(1)
-		tc.response_array_all_have_field_value("id", "bf-0002")
+		tc.status_is(http.StatusNotImplemented)
 	})
+}
 
-	t.Run("filters runes by assignee query parameter", func(t *testing.T) {
+// --- Tests: UnclaimRune ---
+
+func TestUnclaimRuneHandler(t *testing.T) {
+	t.Run("unclaims rune and returns 204", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_mixed_runes("realm-1")
+		tc.rune_is_claimed_in_event_store("realm-1", "bf-0001", "alice")
 
 		// When
-		tc.get("/runes?assignee=alice")
+		tc.post("/unclaim-rune", domain.UnclaimRune{
+			ID: "bf-0001",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_array_has_length(1)
-		tc.response_array_all_have_field_value("assignee", "alice")
+		tc.status_is(http.StatusNoContent)
 	})
+}
 
-	t.Run("returns empty array when no runes match filter", func(t *testing.T) {
+// --- Tests: FulfillRune ---
+
+func TestFulfillRuneHandler(t *testing.T) {
+	t.Run("fulfills rune and returns 204", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_mixed_runes("realm-1")
+		tc.rune_is_claimed_in_event_store("realm-1", "bf-0001", "alice")
 
 		// When
-		tc.get("/runes?status=fulfilled")
+		tc.post("/fulfill-rune", domain.FulfillRune{
+			ID: "bf-0001",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_is_empty_json_array()
+		tc.status_is(http.StatusNoContent)
 	})
+}
 
-	t.Run("filters runes by multiple query parameters", func(t *testing.T) {
+// --- Tests: SealRune ---
+
+func TestSealRuneHandler(t *testing.T) {
+	t.Run("seals rune and returns 204", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_mixed_runes("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
 
 		// When
-		tc.get("/runes?status=open&priority=0")
+		tc.post("/seal-rune", domain.SealRune{
+			ID:     "bf-0001",
+			Reason: "done",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_array_has_length(1)
-		tc.response_array_all_have_field_value("id", "bf-0001")
+		tc.status_is(http.StatusNoContent)
 	})
 
-	t.Run("filters runes by branch query parameter", func(t *testing.T) {
+	t.Run("cascades to descendants and returns per-rune results", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_runes_with_branches("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001.1")
+		tc.rune_has_children("realm-1", "bf-0001", "bf-0001.1")
 
 		// When
-		tc.get("/runes?branch=main")
+		tc.post("/seal-rune", map[string]any{"id": "bf-0001", "reason": "cancelled", "cascade": true})
 
 		// Then
 		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_array_has_length(2)
-		tc.response_array_all_have_field_value("branch", "main")
+		tc.response_body_equals(`{"dry_run":false,"results":[{"rune_id":"bf-0001","result":"applied"},{"rune_id":"bf-0001.1","result":"applied"}]}`)
+		tc.event_was_appended("realm-1", "rune-bf-0001.1", domain.EventRuneSealed)
 	})
 
-	t.Run("returns all runes when no filters are provided", func(t *testing.T) {
+	t.Run("dry run lists changes without sealing", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_mixed_runes("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001.1")
+		tc.rune_has_children("realm-1", "bf-0001", "bf-0001.1")
 
 		// When
-		tc.get("/runes")
+		tc.post("/seal-rune", map[string]any{"id": "bf-0001", "cascade": true, "dry_run": true})
 
 		// Then
 		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_array_has_length(3)
+		tc.response_body_contains(`"result":"would_apply"`)
+		tc.event_was_appended("realm-1", "rune-bf-0001.1", domain.EventRuneForged)
 	})
 
-	t.Run("filters runes by tag query parameter", func(t *testing.T) {
+	t.Run("returns 400 for dry_run without cascade", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_tagged_runes("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
 
 		// When
-		tc.get("/runes?tag=backend")
+		tc.post("/seal-rune", map[string]any{"id": "bf-0001", "dry_run": true})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.response_array_has_length(1)
-		tc.response_array_contains_rune_id("bf-0001")
+		tc.status_is(http.StatusBadRequest)
+		tc.event_was_appended("realm-1", "rune-bf-0001", domain.EventRuneForged)
 	})
+}
 
-	t.Run("excludes runes with open blockers when blocked=false", func(t *testing.T) {
+// --- Tests: ForgeRune ---
+
+func TestForgeRuneHandler(t *testing.T) {
+	t.Run("forges rune and returns 204", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_rune_summary("realm-1", "bf-0001", "open")
-		tc.has_rune_summary("realm-1", "bf-0002", "open")
-		tc.has_rune_summary("realm-1", "bf-0003", "open")
-		tc.has_rune_detail_with_dependencies("realm-1", "bf-0001", []projectors.DependencyRef{
-			{TargetID: "bf-0003", Relationship: "blocked_by"},
+		tc.rune_exists_as_draft_in_event_store("realm-1", "bf-0001")
+
+		// When
+		tc.post("/forge-rune", domain.ForgeRune{
+			ID: "bf-0001",
 		})
 
+		// Then
+		tc.status_is(http.StatusNoContent)
+	})
+
+	t.Run("returns 400 for invalid JSON body", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
 		// When
-		tc.get("/runes?status=open&blocked=false")
+		tc.post_raw("/forge-rune", []byte(`{invalid`))
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.response_array_has_length(2)
-		tc.response_array_contains_rune_id("bf-0002")
-		tc.response_array_contains_rune_id("bf-0003")
-		tc.response_array_does_not_contain_rune_id("bf-0001")
+		tc.status_is(http.StatusBadRequest)
+		tc.response_body_has_error_field()
 	})
+}
 
-	t.Run("excludes runes with sealed blockers when blocked=false", func(t *testing.T) {
+// --- Tests: AddDependency ---
+
+func TestAddDependencyHandler(t *testing.T) {
+	t.Run("adds dependency and returns 204", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_rune_summary("realm-1", "bf-0001", "open")
-		tc.has_rune_summary("realm-1", "bf-0002", "open")
-		tc.has_rune_summary("realm-1", "bf-0003", "sealed")
-		tc.has_rune_detail_with_dependencies("realm-1", "bf-0001", []projectors.DependencyRef{
-			{TargetID: "bf-0003", Relationship: "blocked_by"},
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.rune_exists_in_event_store("realm-1", "bf-0002")
+
+		// When
+		tc.post("/add-dependency", domain.AddDependency{
+			RuneID:       "bf-0001",
+			TargetID:     "bf-0002",
+			Relationship: "relates_to",
 		})
 
+		// Then
+		tc.status_is(http.StatusNoContent)
+	})
+}
+
+// --- Tests: RemoveDependency ---
+
+func TestRemoveDependencyHandler(t *testing.T) {
+	t.Run("removes dependency and returns 204", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.rune_with_dependency("realm-1", "bf-0001", "bf-0002", "relates_to")
+
 		// When
-		tc.get("/runes?status=open&blocked=false")
+		tc.post("/remove-dependency", domain.RemoveDependency{
+			RuneID:       "bf-0001",
+			TargetID:     "bf-0002",
+			Relationship: "relates_to",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.response_array_has_length(1)
-		tc.response_array_contains_rune_id("bf-0002")
+		tc.status_is(http.StatusNoContent)
 	})
+}
 
-	t.Run("includes runes whose blockers are all fulfilled when blocked=false", func(t *testing.T) {
+// --- Tests: AddNote ---
+
+func TestAddNoteHandler(t *testing.T) {
+	t.Run("adds note and returns 204", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_rune_summary("realm-1", "bf-0001", "open")
-		tc.has_rune_summary("realm-1", "bf-0002", "open")
-		tc.has_rune_summary("realm-1", "bf-0003", "fulfilled")
-		tc.has_rune_detail_with_dependencies("realm-1", "bf-0001", []projectors.DependencyRef{
-			{TargetID: "bf-0003", Relationship: "blocked_by"},
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+
+		// When
+		tc.post("/add-note", domain.AddNote{
+			RuneID: "bf-0001",
+			Text:   "some note",
+		})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+	})
+}
+
+// --- Tests: CreateRealm ---
+
+func TestCreateRealmHandler(t *testing.T) {
+	t.Run("creates realm and returns 201", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.event_store_appends_successfully()
+
+		// When
+		tc.post("/create-realm", domain.CreateRealm{
+			Name: "My Realm",
 		})
 
+		// Then
+		tc.status_is(http.StatusCreated)
+		tc.content_type_is_json()
+		tc.response_body_has_field("realm_id")
+	})
+
+	t.Run("auto-assigns creator as owner", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_account_id("acct-creator")
+		tc.account_exists_in_event_store("acct-creator")
+
 		// When
-		tc.get("/runes?status=open&blocked=false")
+		tc.post("/create-realm", domain.CreateRealm{
+			Name: "My Realm",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.response_array_has_length(2)
-		tc.response_array_contains_rune_id("bf-0001")
-		tc.response_array_contains_rune_id("bf-0002")
+		tc.status_is(http.StatusCreated)
+
+		stream := tc.eventStore.streams["_admin:account-acct-creator"]
+		require.NotEmpty(t, stream)
+
+		lastEvent := stream[len(stream)-1]
+		assert.Equal(t, domain.EventRoleAssigned, lastEvent.EventType)
+
+		var roleAssigned domain.RoleAssigned
+		err := json.Unmarshal(lastEvent.Data, &roleAssigned)
+		require.NoError(t, err)
+		assert.Equal(t, "acct-creator", roleAssigned.AccountID)
+		assert.Equal(t, domain.RoleOwner, roleAssigned.Role)
+		assert.NotEmpty(t, roleAssigned.RealmID)
 	})
+}
 
-	t.Run("returns all matching runes when blocked=false and no runes are blocked", func(t *testing.T) {
+func TestRealmLifecycleHandlers(t *testing.T) {
+	t.Run("renames a realm and returns 204", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/rename-realm", domain.RenameRealm{RealmID: "realm-1", Name: "Asgard"})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+		tc.appended_event_data_has("_admin", "realm-realm-1", "name", "Asgard")
+	})
+
+	t.Run("archives and reactivates a realm", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/archive-realm", domain.ArchiveRealm{RealmID: "realm-1"})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+
+		// When
+		tc.post("/reactivate-realm", domain.ReactivateRealm{RealmID: "realm-1"})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+	})
+
+	t.Run("returns 422 when deleting an active realm", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/delete-realm", domain.DeleteRealm{RealmID: "realm-1", Confirm: "realm-1"})
+
+		// Then
+		tc.status_is(http.StatusUnprocessableEntity)
+		tc.response_body_contains("suspend or archive it first")
+	})
+
+	t.Run("deletes an archived realm and purges its data", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.realm_exists_in_event_store("realm-1")
+		tc.eventStore.appendToStream("_admin", "realm-realm-1", domain.EventRealmArchived, domain.RealmArchived{RealmID: "realm-1"})
+		purger := &recordingRealmPurger{}
+		tc.handlers.SetRealmPurgers(purger)
+
+		// When
+		tc.post("/delete-realm", domain.DeleteRealm{RealmID: "realm-1", Confirm: "realm-1"})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+		assert.Equal(t, []string{"realm-1"}, purger.purged)
+	})
+
+	t.Run("rejects writes to an archived realm", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
+		tc.realm_has_status_in_directory("realm-1", "archived")
 		tc.request_has_realm_id("realm-1")
-		tc.has_rune_summary("realm-1", "bf-0001", "open")
-		tc.has_rune_summary("realm-1", "bf-0002", "open")
+		tc.request_has_role("member")
+		tc.routes_are_registered()
+
+		// When
+		tc.post_to_mux("/api/create-rune", domain.CreateRune{Title: "Test", Branch: strPtr("main")})
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+		tc.response_body_contains("archived")
+	})
+
+	t.Run("allows reads from an archived realm", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.realm_has_status_in_directory("realm-1", "archived")
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("viewer")
+		tc.routes_are_registered()
+
+		// When
+		tc.get_from_mux("/api/runes")
+
+		// Then
+		tc.status_is(http.StatusOK)
+	})
+}
+
+type recordingRealmPurger struct {
+	purged []string
+}
+
+func (p *recordingRealmPurger) PurgeRealm(_ context.Context, realmID string) error {
+	p.purged = append(p.purged, realmID)
+	return nil
+}
+
+// --- Tests: ListRealms ---
+
+func TestListRealmsHandler(t *testing.T) {
+	t.Run("returns list of realms", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_account_id("acct-admin")
+		tc.account_is_sys_admin("acct-admin")
+		tc.has_realm_list()
+
+		// When
+		tc.get("/realms")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_is_non_empty_json_array()
+	})
+
+	t.Run("returns empty array when no realms exist", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_account_id("acct-admin")
+		tc.account_is_sys_admin("acct-admin")
+
+		// When
+		tc.get("/realms")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_is_empty_json_array()
+	})
+
+	t.Run("excludes suspended realms by default", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_account_id("acct-admin")
+		tc.account_is_sys_admin("acct-admin")
+		tc.has_realm_list()
+		tc.has_suspended_realm("realm-suspended", "Suspended Realm")
+
+		// When
+		tc.get("/realms")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(1)
+	})
+
+	t.Run("includes suspended realms with include_suspended=true", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_account_id("acct-admin")
+		tc.account_is_sys_admin("acct-admin")
+		tc.has_realm_list()
+		tc.has_suspended_realm("realm-suspended", "Suspended Realm")
+
+		// When
+		tc.get("/realms?include_suspended=true")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(2)
+	})
+}
+
+// --- Tests: ListRunes ---
+
+func TestListRunesHandler(t *testing.T) {
+	t.Run("returns list of runes for realm", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_list("realm-1")
+
+		// When
+		tc.get("/runes")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_is_non_empty_json_array()
+	})
+
+	t.Run("returns empty array when no runes exist", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.get("/runes")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_is_empty_json_array()
+	})
+
+	t.Run("filters runes by overdue query parameter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_scheduled_runes("realm-1")
+
+		// When
+		tc.get("/runes?overdue=true")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(1)
+		tc.response_array_contains_rune_id("bf-late")
+		tc.response_array_all_have_field_value("overdue", "true")
+	})
+
+	t.Run("filters runes by deferred query parameter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_scheduled_runes("realm-1")
+
+		// When
+		tc.get("/runes?deferred=false")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(4)
+		tc.response_array_does_not_contain_rune_id("bf-snoozed")
+	})
+
+	t.Run("accepts any boolean spelling for the overdue filter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_scheduled_runes("realm-1")
+
+		// When
+		tc.get("/runes?overdue=1")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(1)
+		tc.response_array_contains_rune_id("bf-late")
+	})
+
+	t.Run("returns 400 for an invalid overdue filter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.get("/runes?overdue=yes")
+
+		// Then
+		tc.status_is(http.StatusBadRequest)
+	})
+
+	t.Run("returns 400 for an invalid deferred filter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.get("/runes?deferred=maybe")
+
+		// Then
+		tc.status_is(http.StatusBadRequest)
+	})
+
+	t.Run("filters runes by status query parameter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_mixed_runes("realm-1")
+
+		// When
+		tc.get("/runes?status=open")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_array_has_length(1)
+		tc.response_array_all_have_field_value("status", "open")
+	})
+
+	t.Run("filters runes by priority query parameter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_mixed_runes("realm-1")
+
+		// When
+		tc.get("/runes?priority=1")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_array_has_length(1)
+		tc.response_array_all_have_field_value("id", "bf-0002")
+	})
+
+	t.Run("filters runes by assignee query parameter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_mixed_runes("realm-1")
+
+		// When
+		tc.get("/runes?assignee=alice")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_array_has_length(1)
+		tc.response_array_all_have_field_value("assignee", "alice")
+	})
+
+	t.Run("returns empty array when no runes match filter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_mixed_runes("realm-1")
+
+		// When
+		tc.get("/runes?status=fulfilled")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_is_empty_json_array()
+	})
+
+	t.Run("filters runes by multiple query parameters", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_mixed_runes("realm-1")
+
+		// When
+		tc.get("/runes?status=open&priority=0")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_array_has_length(1)
+		tc.response_array_all_have_field_value("id", "bf-0001")
+	})
+
+	t.Run("filters runes by branch query parameter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_runes_with_branches("realm-1")
+
+		// When
+		tc.get("/runes?branch=main")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_array_has_length(2)
+		tc.response_array_all_have_field_value("branch", "main")
+	})
+
+	t.Run("returns all runes when no filters are provided", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_mixed_runes("realm-1")
+
+		// When
+		tc.get("/runes")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_array_has_length(3)
+	})
+
+	t.Run("filters runes by tag query parameter", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_tagged_runes("realm-1")
+
+		// When
+		tc.get("/runes?tag=backend")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(1)
+		tc.response_array_contains_rune_id("bf-0001")
+	})
+
+	t.Run("excludes runes with open blockers when blocked=false", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_summary("realm-1", "bf-0001", "open")
+		tc.has_rune_summary("realm-1", "bf-0002", "open")
+		tc.has_rune_summary("realm-1", "bf-0003", "open")
+		tc.has_rune_detail_with_dependencies("realm-1", "bf-0001", []projectors.DependencyRef{
+			{TargetID: "bf-0003", Relationship: "blocked_by"},
+		})
+
+		// When
+		tc.get("/runes?status=open&blocked=false")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(2)
+		tc.response_array_contains_rune_id("bf-0002")
+		tc.response_array_contains_rune_id("bf-0003")
+		tc.response_array_does_not_contain_rune_id("bf-0001")
+	})
+
+	t.Run("excludes runes with sealed blockers when blocked=false", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_summary("realm-1", "bf-0001", "open")
+		tc.has_rune_summary("realm-1", "bf-0002", "open")
+		tc.has_rune_summary("realm-1", "bf-0003", "sealed")
+		tc.has_rune_detail_with_dependencies("realm-1", "bf-0001", []projectors.DependencyRef{
+			{TargetID: "bf-0003", Relationship: "blocked_by"},
+		})
+
+		// When
+		tc.get("/runes?status=open&blocked=false")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(1)
+		tc.response_array_contains_rune_id("bf-0002")
+	})
+
+	t.Run("includes runes whose blockers are all fulfilled when blocked=false", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_summary("realm-1", "bf-0001", "open")
+		tc.has_rune_summary("realm-1", "bf-0002", "open")
+		tc.has_rune_summary("realm-1", "bf-0003", "fulfilled")
+		tc.has_rune_detail_with_dependencies("realm-1", "bf-0001", []projectors.DependencyRef{
+			{TargetID: "bf-0003", Relationship: "blocked_by"},
+		})
+
+		// When
+		tc.get("/runes?status=open&blocked=false")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(2)
+		tc.response_array_contains_rune_id("bf-0001")
+		tc.response_array_contains_rune_id("bf-0002")
+	})
+
+	t.Run("returns all matching runes when blocked=false and no runes are blocked", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_summary("realm-1", "bf-0001", "open")
+		tc.has_rune_summary("realm-1", "bf-0002", "open")
+
+		// When
+		tc.get("/runes?status=open&blocked=false")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(2)
+	})
+
+	t.Run("blocked filter is ignored when not provided", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_summary("realm-1", "bf-0001", "open")
+		tc.has_rune_summary("realm-1", "bf-0002", "open")
+		tc.has_rune_summary("realm-1", "bf-0003", "open")
+		tc.has_rune_detail_with_dependencies("realm-1", "bf-0001", []projectors.DependencyRef{
+			{TargetID: "bf-0003", Relationship: "blocked_by"},
+		})
+
+		// When
+		tc.get("/runes?status=open")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_has_length(3)
+	})
+}
+
+// --- Tests: Ready ---
+
+func TestReadyHandler(t *testing.T) {
+	t.Run("returns ready runes sorted by priority", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_ready_runes("realm-1")
+
+		// When
+		tc.get("/ready")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_array_has_length(2)
+		tc.response_array_sorted_by_priority()
+	})
+
+	t.Run("filters out blocked runes", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_blocked_and_unblocked_runes("realm-1")
+
+		// When
+		tc.get("/ready")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_array_has_length(2)
+		tc.response_array_does_not_contain_rune_id("bf-blocked")
+	})
+
+	t.Run("hides runes deferred until a future time", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_scheduled_runes("realm-1")
+
+		// When
+		tc.get("/ready")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_array_does_not_contain_rune_id("bf-snoozed")
+		tc.response_array_contains_rune_id("bf-woken")
+		tc.response_array_contains_rune_id("bf-late")
+	})
+
+	t.Run("returns empty array when no ready runes", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.get("/ready")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_is_empty_json_array()
+	})
+}
+
+// --- Tests: GetRune ---
+
+func TestGetRuneHandler(t *testing.T) {
+	t.Run("returns rune detail", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_detail("realm-1", "bf-0001")
+
+		// When
+		tc.get("/rune?id=bf-0001")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_body_has_field("id")
+	})
+
+	t.Run("returns 400 when id query param is missing", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.get("/rune")
+
+		// Then
+		tc.status_is(http.StatusBadRequest)
+		tc.response_body_has_error_field()
+	})
+
+	t.Run("returns 404 when rune not found in projection", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.get("/rune?id=bf-9999")
+
+		// Then
+		tc.status_is(http.StatusNotFound)
+		tc.response_body_has_error_field()
+	})
+
+	t.Run("includes saga progress with blocked child count", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_detail("realm-1", "bf-0001")
+		tc.has_saga_with_blocked_child("realm-1", "bf-0001")
+
+		// When
+		tc.get("/rune?id=bf-0001")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_body_has_field("id")
+		tc.response_saga_progress_is(3, 33, 1)
+	})
+
+	t.Run("resolves an alias to the moved rune", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_detail("realm-1", "bf-0001.2")
+		tc.rune_has_alias("realm-1", "bf-0002.1", "bf-0001.2")
+
+		// When
+		tc.get("/rune?id=bf-0002.1")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_body_contains(`"id":"bf-0001.2"`)
+	})
+
+	t.Run("omits saga progress for runes without children", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.has_rune_detail("realm-1", "bf-0001")
+
+		// When
+		tc.get("/rune?id=bf-0001")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_body_lacks_field("saga_progress")
+	})
+}
+
+// --- Tests: RealmSettings ---
+
+func TestRealmSettingsHandlers(t *testing.T) {
+	t.Run("GET returns defaults for realm without settings", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.get("/realm-settings")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_body_equals(`{"realm_id":"realm-1","saga_auto_complete":"off","require_ac_verification":false,"require_branch":true,"default_priority":0,"default_rune_type":"rune","max_state_size":65536,"wip_limits":{}}`)
+	})
+
+	t.Run("POST updates settings for the request realm", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/realm-settings", map[string]string{"realm_id": "other-realm", "saga_auto_complete": "fulfill"})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+		tc.event_was_appended("_admin", "realm-realm-1", domain.EventRealmSettingsUpdated)
+	})
+
+	t.Run("POST returns 422 for invalid mode", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/realm-settings", map[string]string{"saga_auto_complete": "always"})
+
+		// Then
+		tc.status_is(http.StatusUnprocessableEntity)
+		tc.response_body_has_error_field()
+	})
+
+	t.Run("POST returns 422 for non-positive max_state_size", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/realm-settings", map[string]any{"max_state_size": -1})
+
+		// Then
+		tc.status_is(http.StatusUnprocessableEntity)
+		tc.response_body_contains("invalid max_state_size")
+	})
+}
+
+func TestPolicyHandlers(t *testing.T) {
+	t.Run("POST /set-policy appends PolicySet for the request realm", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/set-policy", map[string]any{
+			"name":       "claim-needs-ready",
+			"actions":    []string{"claim"},
+			"expression": `"ready" in rune.tags`,
+		})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+		tc.event_was_appended("_admin", "realm-realm-1", domain.EventPolicySet)
+	})
+
+	t.Run("POST /set-policy returns 422 for an invalid expression", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/set-policy", map[string]any{"name": "broken", "expression": "rune.status =="})
+
+		// Then
+		tc.status_is(http.StatusUnprocessableEntity)
+		tc.response_body_has_error_field()
+	})
+
+	t.Run("GET /policies lists the realm's policies", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_has_policy("realm-1", "claim-needs-ready", `"ready" in rune.tags`, "claim")
+
+		// When
+		tc.get("/policies")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_body_contains(`"name":"claim-needs-ready"`)
+	})
+
+	t.Run("rune command rejected by a policy returns 422 with the policy name", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-a1b2")
+		tc.realm_has_policy("realm-1", "claim-needs-ready", `"ready" in rune.tags`, "claim")
+
+		// When
+		tc.post("/claim-rune", map[string]string{"id": "bf-a1b2", "claimant": "alice"})
+
+		// Then
+		tc.status_is(http.StatusUnprocessableEntity)
+		tc.response_body_contains(`"policy":"claim-needs-ready"`)
+		tc.no_event_was_appended("realm-1", "rune-bf-a1b2", domain.EventRuneClaimed)
+	})
+
+	t.Run("policy sees the caller's role", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("admin")
+		tc.rune_exists_in_event_store("realm-1", "bf-a1b2")
+		tc.realm_has_policy("realm-1", "admins-claim", `role == "admin"`, "claim")
+
+		// When
+		tc.post("/claim-rune", map[string]string{"id": "bf-a1b2", "claimant": "alice"})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+	})
+}
+
+func TestWIPHandlers(t *testing.T) {
+	t.Run("claim over a WIP limit returns 409 naming the limit", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-a1b2")
+		tc.realm_has_wip_limits("realm-1", domain.WIPLimits{PerClaimant: 1})
+		tc.realm_has_active_claims("realm-1", domain.ActiveClaim{RuneID: "bf-0001", Claimant: "alice"})
+
+		// When
+		tc.post("/claim-rune", map[string]string{"id": "bf-a1b2", "claimant": "alice"})
+
+		// Then
+		tc.status_is(http.StatusConflict)
+		tc.response_body_contains(`"limit":"claimant"`)
+		tc.response_body_contains(`WIP limit reached: claimant \"alice\" has 1 of 1 claims`)
+		tc.no_event_was_appended("realm-1", "rune-bf-a1b2", domain.EventRuneClaimed)
+	})
+
+	t.Run("claim records the caller's account", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_account_id("acct-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-a1b2")
+
+		// When
+		tc.post("/claim-rune", map[string]string{"id": "bf-a1b2", "claimant": "alice", "account_id": "someone-else"})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+		tc.appended_event_data_has("realm-1", "rune-bf-a1b2", "account_id", "acct-1")
+	})
+
+	t.Run("claim by a service account is labelled as agent work", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_account_id("acct-bot")
+		tc.request_is_from_service_account()
+		tc.rune_exists_in_event_store("realm-1", "bf-a1b2")
+
+		// When
+		tc.post("/claim-rune", map[string]string{"id": "bf-a1b2", "claimant": "ci-bot"})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+		tc.appended_event_data_has("realm-1", "rune-bf-a1b2", "agent", true)
+	})
+
+	t.Run("claim by a human cannot be labelled as agent work", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_account_id("acct-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-a1b2")
+
+		// When
+		tc.post("/claim-rune", map[string]any{"id": "bf-a1b2", "claimant": "alice", "agent": true})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+		tc.appended_event_data_has("realm-1", "rune-bf-a1b2", "agent", nil)
+	})
+
+	t.Run("GET /wip reports usage and limits", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_has_wip_limits("realm-1", domain.WIPLimits{PerRealm: 5})
+		tc.realm_has_active_claims("realm-1",
+			domain.ActiveClaim{RuneID: "bf-0001", Claimant: "alice", Tags: []string{"deploy"}},
+			domain.ActiveClaim{RuneID: "bf-0002", Claimant: "alice"},
+		)
+
+		// When
+		tc.get("/wip")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_body_equals(`{"limits":{"per_realm":5},"realm":2,"claimants":{"alice":2},"accounts":{},"tags":{"deploy":1}}`)
+	})
+}
+
+// --- Tests: AssignRole ---
+
+func TestAssignRoleHandler(t *testing.T) {
+	t.Run("assigns role and returns 204 with admin caller", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("admin")
+		tc.account_exists_in_event_store("acct-target")
+		tc.realm_exists_in_directory("realm-1", "Test Realm")
+
+		// When
+		tc.post("/assign-role", domain.AssignRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+			Role:      "member",
+		})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+	})
+
+	t.Run("returns 403 when caller is member", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("member")
+		tc.routes_are_registered()
+
+		// When
+		tc.post_to_mux("/api/assign-role", domain.AssignRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+			Role:      "member",
+		})
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+	})
+
+	t.Run("returns 403 when non-owner assigns owner role", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("admin")
+		tc.account_exists_in_event_store("acct-target")
+
+		// When
+		tc.post("/assign-role", domain.AssignRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+			Role:      "owner",
+		})
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+	})
+
+	t.Run("owner can assign owner role", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("owner")
+		tc.account_exists_in_event_store("acct-target")
+		tc.realm_exists_in_directory("realm-1", "Test Realm")
+
+		// When
+		tc.post("/assign-role", domain.AssignRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+			Role:      "owner",
+		})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+	})
+
+	t.Run("returns 400 for invalid JSON body", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("admin")
+
+		// When
+		tc.post_raw("/assign-role", []byte(`{invalid`))
+
+		// Then
+		tc.status_is(http.StatusBadRequest)
+		tc.response_body_has_error_field()
+	})
+}
+
+// --- Tests: RevokeRole ---
+
+func TestRevokeRoleHandler(t *testing.T) {
+	t.Run("revokes role and returns 204 with admin caller", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("admin")
+		tc.account_has_role_in_event_store("acct-target", "realm-1", "member")
+
+		// When
+		tc.post("/revoke-role", domain.RevokeRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+		})
+
+		// Then
+		tc.status_is(http.StatusNoContent)
+	})
+
+	t.Run("returns 403 when non-owner revokes owner role", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("admin")
+		tc.account_has_role_in_event_store("acct-target", "realm-1", "owner")
 
 		// When
-		tc.get("/runes?status=open&blocked=false")
+		tc.post("/revoke-role", domain.RevokeRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.response_array_has_length(2)
+		tc.status_is(http.StatusForbidden)
 	})
 
-	t.Run("blocked filter is ignored when not provided", func(t *testing.T) {
+	t.Run("owner can revoke owner role", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_rune_summary("realm-1", "bf-0001", "open")
-		tc.has_rune_summary("realm-1", "bf-0002", "open")
-		tc.has_rune_summary("realm-1", "bf-0003", "open")
-		tc.has_rune_detail_with_dependencies("realm-1", "bf-0001", []projectors.DependencyRef{
-			{TargetID: "bf-0003", Relationship: "blocked_by"},
-		})
+		tc.request_has_role("owner")
+		tc.account_has_role_in_event_store("acct-target", "realm-1", "owner")
 
 		// When
-		tc.get("/runes?status=open")
+		tc.post("/revoke-role", domain.RevokeRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.response_array_has_length(3)
+		tc.status_is(http.StatusNoContent)
 	})
 }
 
-// --- Tests: Ready ---
+// --- Tests: ShatterRune ---
 
-func TestReadyHandler(t *testing.T) {
-	t.Run("returns ready runes sorted by priority", func(t *testing.T) {
+func TestShatterRuneHandler(t *testing.T) {
+	t.Run("shatters sealed rune and returns 204", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_ready_runes("realm-1")
+		tc.rune_is_sealed_in_event_store("realm-1", "bf-0001")
 
 		// When
-		tc.get("/ready")
+		tc.post("/shatter-rune", domain.ShatterRune{
+			ID: "bf-0001",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_array_has_length(2)
-		tc.response_array_sorted_by_priority()
+		tc.status_is(http.StatusNoContent)
 	})
 
-	t.Run("filters out blocked runes", func(t *testing.T) {
+	t.Run("returns 404 for non-existent rune", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_blocked_and_unblocked_runes("realm-1")
 
 		// When
-		tc.get("/ready")
+		tc.post("/shatter-rune", domain.ShatterRune{
+			ID: "bf-9999",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_array_has_length(2)
-		tc.response_array_does_not_contain_rune_id("bf-blocked")
+		tc.status_is(http.StatusNotFound)
+		tc.response_body_has_error_field()
 	})
 
-	t.Run("returns empty array when no ready runes", func(t *testing.T) {
+	t.Run("returns 422 for open rune", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
 
 		// When
-		tc.get("/ready")
+		tc.post("/shatter-rune", domain.ShatterRune{
+			ID: "bf-0001",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_is_empty_json_array()
+		tc.status_is(http.StatusUnprocessableEntity)
+		tc.response_body_has_error_field()
 	})
 }
 
-// --- Tests: GetRune ---
+// --- Tests: SweepRunes ---
 
-func TestGetRuneHandler(t *testing.T) {
-	t.Run("returns rune detail", func(t *testing.T) {
+func TestMoveRuneHandler(t *testing.T) {
+	t.Run("moves rune under a new parent and returns the alias", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.has_rune_detail("realm-1", "bf-0001")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.rune_exists_in_event_store("realm-1", "bf-0002")
 
 		// When
-		tc.get("/rune?id=bf-0001")
+		tc.post("/move-rune", domain.MoveRune{
+			ID:       "bf-0001",
+			ParentID: "bf-0002",
+		})
 
 		// Then
 		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_body_has_field("id")
+		tc.response_body_contains(`"alias":"bf-0002.1"`)
+		tc.event_was_appended("realm-1", "rune-bf-0001", domain.EventRuneMoved)
+		tc.event_was_appended("realm-1", "rune-bf-0002.1", domain.EventRuneAliasReserved)
 	})
 
-	t.Run("returns 400 when id query param is missing", func(t *testing.T) {
+	t.Run("resolves aliases in the request", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.rune_exists_in_event_store("realm-1", "bf-0002")
+		tc.rune_has_alias("realm-1", "bf-0003.1", "bf-0001")
 
 		// When
-		tc.get("/rune")
+		tc.post("/move-rune", domain.MoveRune{
+			ID:       "bf-0003.1",
+			ParentID: "bf-0002",
+		})
 
 		// Then
-		tc.status_is(http.StatusBadRequest)
-		tc.response_body_has_error_field()
+		tc.status_is(http.StatusOK)
+		tc.event_was_appended("realm-1", "rune-bf-0001", domain.EventRuneMoved)
 	})
 
-	t.Run("returns 404 when rune not found in projection", func(t *testing.T) {
+	t.Run("returns 422 when moving under a descendant", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
+		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.child_rune_exists_in_event_store("realm-1", "bf-0001.1", "bf-0001")
 
 		// When
-		tc.get("/rune?id=bf-9999")
+		tc.post("/move-rune", domain.MoveRune{
+			ID:       "bf-0001",
+			ParentID: "bf-0001.1",
+		})
 
 		// Then
-		tc.status_is(http.StatusNotFound)
+		tc.status_is(http.StatusUnprocessableEntity)
 		tc.response_body_has_error_field()
 	})
-}
 
-// --- Tests: AssignRole ---
-
-func TestAssignRoleHandler(t *testing.T) {
-	t.Run("assigns role and returns 204 with admin caller", func(t *testing.T) {
+	t.Run("returns 404 for non-existent rune", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("admin")
-		tc.account_exists_in_event_store("acct-target")
-		tc.realm_exists_in_directory("realm-1", "Test Realm")
 
 		// When
-		tc.post("/assign-role", domain.AssignRole{
-			AccountID: "acct-target",
-			RealmID:   "realm-1",
-			Role:      "member",
+		tc.post("/move-rune", domain.MoveRune{
+			ID: "bf-9999",
 		})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusNotFound)
 	})
+}
 
-	t.Run("returns 403 when caller is member", func(t *testing.T) {
+func TestSweepRunesHandler(t *testing.T) {
+	t.Run("returns 200 with shattered rune IDs", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("member")
-		tc.routes_are_registered()
+		tc.rune_is_sealed_in_event_store("realm-1", "bf-0001")
+		tc.has_rune_summary("realm-1", "bf-0001", "sealed")
 
 		// When
-		tc.post_to_mux("/api/assign-role", domain.AssignRole{
-			AccountID: "acct-target",
-			RealmID:   "realm-1",
-			Role:      "member",
-		})
+		tc.post("/sweep-runes", nil)
 
 		// Then
-		tc.status_is(http.StatusForbidden)
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_body_has_field("shattered")
+		tc.response_shattered_contains("bf-0001")
 	})
 
-	t.Run("returns 403 when non-owner assigns owner role", func(t *testing.T) {
+	t.Run("returns 200 with empty shattered list when no candidates", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("admin")
-		tc.account_exists_in_event_store("acct-target")
 
 		// When
-		tc.post("/assign-role", domain.AssignRole{
-			AccountID: "acct-target",
-			RealmID:   "realm-1",
-			Role:      "owner",
-		})
+		tc.post("/sweep-runes", nil)
 
 		// Then
-		tc.status_is(http.StatusForbidden)
+		tc.status_is(http.StatusOK)
+		tc.content_type_is_json()
+		tc.response_body_equals(`{"shattered":[]}`)
 	})
+}
 
-	t.Run("owner can assign owner role", func(t *testing.T) {
+// --- Tests: RegisterRoutes ---
+
+func TestRegisterRoutes(t *testing.T) {
+	t.Run("registers all expected routes on mux", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
-		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("owner")
-		tc.account_exists_in_event_store("acct-target")
-		tc.realm_exists_in_directory("realm-1", "Test Realm")
 
 		// When
-		tc.post("/assign-role", domain.AssignRole{
-			AccountID: "acct-target",
-			RealmID:   "realm-1",
-			Role:      "owner",
-		})
+		tc.routes_are_registered()
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.route_exists("GET", "/health")
+		tc.route_exists("POST", "/api/create-rune")
+		tc.route_exists("POST", "/api/update-rune")
+		tc.route_exists("POST", "/api/claim-rune")
+		tc.route_exists("POST", "/api/claim-next")
+		tc.route_exists("GET", "/api/search")
+		tc.route_exists("POST", "/api/fulfill-rune")
+		tc.route_exists("POST", "/api/forge-rune")
+		tc.route_exists("POST", "/api/seal-rune")
+		tc.route_exists("POST", "/api/shatter-rune")
+		tc.route_exists("POST", "/api/move-rune")
+		tc.route_exists("POST", "/api/sweep-runes")
+		tc.route_exists("POST", "/api/add-dependency")
+		tc.route_exists("POST", "/api/remove-dependency")
+		tc.route_exists("POST", "/api/add-note")
+		tc.route_exists("POST", "/api/add-ac")
+		tc.route_exists("POST", "/api/update-ac")
+		tc.route_exists("POST", "/api/remove-ac")
+		tc.route_exists("POST", "/api/verify-ac")
+		tc.route_exists("GET", "/api/policies")
+		tc.route_exists("GET", "/api/wip")
+		tc.route_exists("POST", "/api/set-policy")
+		tc.route_exists("POST", "/api/remove-policy")
+		tc.route_exists("GET", "/api/roles")
+		tc.route_exists("POST", "/api/define-role")
+		tc.route_exists("POST", "/api/delete-role")
+		tc.route_exists("GET", "/api/runes")
+		tc.route_exists("GET", "/api/rune")
+		tc.route_exists("POST", "/api/create-realm")
+		tc.route_exists("POST", "/api/suspend-realm")
+		tc.route_exists("POST", "/api/rename-realm")
+		tc.route_exists("POST", "/api/reactivate-realm")
+		tc.route_exists("POST", "/api/archive-realm")
+		tc.route_exists("POST", "/api/delete-realm")
+		tc.route_exists("GET", "/api/realms")
+		tc.route_exists("POST", "/api/assign-role")
+		tc.route_exists("POST", "/api/revoke-role")
 	})
+}
 
-	t.Run("returns 400 for invalid JSON body", func(t *testing.T) {
+// --- Tests: Role-based routing ---
+
+func TestRoleBasedRouting(t *testing.T) {
+	t.Run("viewer can GET /runes", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("admin")
+		tc.request_has_role("viewer")
+		tc.routes_are_registered()
 
 		// When
-		tc.post_raw("/assign-role", []byte(`{invalid`))
+		tc.get_from_mux("/api/runes")
 
 		// Then
-		tc.status_is(http.StatusBadRequest)
-		tc.response_body_has_error_field()
+		tc.status_is(http.StatusOK)
 	})
-}
-
-// --- Tests: RevokeRole ---
 
-func TestRevokeRoleHandler(t *testing.T) {
-	t.Run("revokes role and returns 204 with admin caller", func(t *testing.T) {
+	t.Run("viewer cannot POST /create-rune", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("admin")
-		tc.account_has_role_in_event_store("acct-target", "realm-1", "member")
+		tc.request_has_role("viewer")
+		tc.routes_are_registered()
 
 		// When
-		tc.post("/revoke-role", domain.RevokeRole{
-			AccountID: "acct-target",
-			RealmID:   "realm-1",
+		tc.post_to_mux("/api/create-rune", domain.CreateRune{
+			Title:    "Test",
+			Priority: intPtr(1),
+			Branch:   strPtr("main"),
 		})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusForbidden)
 	})
 
-	t.Run("returns 403 when non-owner revokes owner role", func(t *testing.T) {
+	t.Run("member can POST /create-rune", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("admin")
-		tc.account_has_role_in_event_store("acct-target", "realm-1", "owner")
+		tc.request_has_role("member")
+		tc.event_store_appends_successfully()
+		tc.routes_are_registered()
 
 		// When
-		tc.post("/revoke-role", domain.RevokeRole{
-			AccountID: "acct-target",
-			RealmID:   "realm-1",
+		tc.post_to_mux("/api/create-rune", domain.CreateRune{
+			Title:    "Test",
+			Priority: intPtr(1),
+			Branch:   strPtr("main"),
 		})
 
 		// Then
-		tc.status_is(http.StatusForbidden)
+		tc.status_is(http.StatusCreated)
 	})
 
-	t.Run("owner can revoke owner role", func(t *testing.T) {
+	t.Run("member cannot POST /assign-role", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("owner")
-		tc.account_has_role_in_event_store("acct-target", "realm-1", "owner")
+		tc.request_has_role("member")
+		tc.routes_are_registered()
 
 		// When
-		tc.post("/revoke-role", domain.RevokeRole{
+		tc.post_to_mux("/api/assign-role", domain.AssignRole{
 			AccountID: "acct-target",
 			RealmID:   "realm-1",
+			Role:      "member",
 		})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusForbidden)
 	})
-}
-
-// --- Tests: ShatterRune ---
 
-func TestShatterRuneHandler(t *testing.T) {
-	t.Run("shatters sealed rune and returns 204", func(t *testing.T) {
+	t.Run("viewer of _admin cannot POST /suspend-realm", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
-		tc.request_has_realm_id("realm-1")
-		tc.rune_is_sealed_in_event_store("realm-1", "bf-0001")
+		tc.request_has_realm_id(domain.AdminRealmID)
+		tc.request_has_role("viewer")
+		tc.routes_are_registered()
 
 		// When
-		tc.post("/shatter-rune", domain.ShatterRune{
-			ID: "bf-0001",
-		})
+		tc.post_to_mux("/api/suspend-realm", domain.SuspendRealm{RealmID: "realm-1"})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusForbidden)
 	})
 
-	t.Run("returns 404 for non-existent rune", func(t *testing.T) {
+	t.Run("viewer cannot POST /forge-rune", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("viewer")
+		tc.routes_are_registered()
 
 		// When
-		tc.post("/shatter-rune", domain.ShatterRune{
-			ID: "bf-9999",
+		tc.post_to_mux("/api/forge-rune", domain.ForgeRune{
+			ID: "bf-0001",
 		})
 
 		// Then
-		tc.status_is(http.StatusNotFound)
-		tc.response_body_has_error_field()
+		tc.status_is(http.StatusForbidden)
 	})
 
-	t.Run("returns 422 for open rune", func(t *testing.T) {
+	t.Run("member can POST /forge-rune", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.rune_exists_in_event_store("realm-1", "bf-0001")
+		tc.request_has_role("member")
+		tc.rune_exists_as_draft_in_event_store("realm-1", "bf-0001")
+		tc.routes_are_registered()
 
 		// When
-		tc.post("/shatter-rune", domain.ShatterRune{
+		tc.post_to_mux("/api/forge-rune", domain.ForgeRune{
 			ID: "bf-0001",
 		})
 
 		// Then
-		tc.status_is(http.StatusUnprocessableEntity)
-		tc.response_body_has_error_field()
+		tc.status_is(http.StatusNoContent)
 	})
-}
-
-// --- Tests: SweepRunes ---
 
-func TestSweepRunesHandler(t *testing.T) {
-	t.Run("returns 200 with shattered rune IDs", func(t *testing.T) {
+	t.Run("admin can POST /assign-role", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.rune_is_sealed_in_event_store("realm-1", "bf-0001")
-		tc.has_rune_summary("realm-1", "bf-0001", "sealed")
+		tc.request_has_role("admin")
+		tc.account_exists_in_event_store("acct-target")
+		tc.realm_exists_in_directory("realm-1", "Test Realm")
+		tc.routes_are_registered()
 
 		// When
-		tc.post("/sweep-runes", nil)
+		tc.post_to_mux("/api/assign-role", domain.AssignRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+			Role:      "member",
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_body_has_field("shattered")
-		tc.response_shattered_contains("bf-0001")
+		tc.status_is(http.StatusNoContent)
 	})
 
-	t.Run("returns 200 with empty shattered list when no candidates", func(t *testing.T) {
+	t.Run("custom role can use the commands it lists", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
+		tc.realm_has_custom_role("realm-1", "triager", domain.PermViewRunes, domain.PermCreateRune)
 		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("triager")
+		tc.routes_are_registered()
 
 		// When
-		tc.post("/sweep-runes", nil)
+		tc.post_to_mux("/api/create-rune", domain.CreateRune{
+			Title:    "Test",
+			Priority: intPtr(1),
+			Branch:   strPtr("main"),
+		})
 
 		// Then
-		tc.status_is(http.StatusOK)
-		tc.content_type_is_json()
-		tc.response_body_equals(`{"shattered":[]}`)
+		tc.status_is(http.StatusCreated)
 	})
-}
-
-// --- Tests: RegisterRoutes ---
 
-func TestRegisterRoutes(t *testing.T) {
-	t.Run("registers all expected routes on mux", func(t *testing.T) {
+	t.Run("custom role cannot use commands it does not list", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
+		tc.realm_has_custom_role("realm-1", "triager", domain.PermViewRunes, domain.PermCreateRune)
+		tc.request_has_realm_id("realm-1")
+		tc.request_has_role("triager")
+		tc.routes_are_registered()
 
 		// When
-		tc.routes_are_registered()
+		tc.post_to_mux("/api/shatter-rune", map[string]string{"id": "bf-0001"})
 
 		// Then
-		tc.route_exists("GET", "/health")
-		tc.route_exists("POST", "/api/create-rune")
-		tc.route_exists("POST", "/api/update-rune")
-		tc.route_exists("POST", "/api/claim-rune")
-		tc.route_exists("POST", "/api/fulfill-rune")
-		tc.route_exists("POST", "/api/forge-rune")
-		tc.route_exists("POST", "/api/seal-rune")
-		tc.route_exists("POST", "/api/shatter-rune")
-		tc.route_exists("POST", "/api/sweep-runes")
-		tc.route_exists("POST", "/api/add-dependency")
-		tc.route_exists("POST", "/api/remove-dependency")
-		tc.route_exists("POST", "/api/add-note")
-		tc.route_exists("POST", "/api/add-ac")
-		tc.route_exists("POST", "/api/update-ac")
-		tc.route_exists("POST", "/api/remove-ac")
-		tc.route_exists("GET", "/api/runes")
-		tc.route_exists("GET", "/api/rune")
-		tc.route_exists("POST", "/api/create-realm")
-		tc.route_exists("GET", "/api/realms")
-		tc.route_exists("POST", "/api/assign-role")
-		tc.route_exists("POST", "/api/revoke-role")
+		tc.status_is(http.StatusForbidden)
 	})
-}
-
-// --- Tests: Role-based routing ---
 
-func TestRoleBasedRouting(t *testing.T) {
-	t.Run("viewer can GET /runes", func(t *testing.T) {
+	t.Run("custom role from another realm grants nothing", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
+		tc.realm_has_custom_role("realm-2", "triager", domain.PermViewRunes)
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("viewer")
+		tc.request_has_role("triager")
 		tc.routes_are_registered()
 
 		// When
 		tc.get_from_mux("/api/runes")
 
 		// Then
-		tc.status_is(http.StatusOK)
+		tc.status_is(http.StatusForbidden)
 	})
 
-	t.Run("viewer cannot POST /create-rune", func(t *testing.T) {
+	t.Run("read-only PAT cannot write even with a custom role", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
+		tc.realm_has_custom_role("realm-1", "triager", domain.PermViewRunes, domain.PermCreateRune)
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("viewer")
+		tc.request_has_role("triager")
+		tc.request_has_pat_scopes(&domain.PATScopes{MaxRole: domain.RoleViewer})
 		tc.routes_are_registered()
 
 		// When
 		tc.post_to_mux("/api/create-rune", domain.CreateRune{
 			Title:    "Test",
-			Priority: 1,
+			Priority: intPtr(1),
 			Branch:   strPtr("main"),
 		})
 
@@ -1270,104 +2448,104 @@ func TestRoleBasedRouting(t *testing.T) {
 		tc.status_is(http.StatusForbidden)
 	})
 
-	t.Run("member can POST /create-rune", func(t *testing.T) {
+	t.Run("custom role with assign-role can assign member", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
+		tc.realm_has_custom_role("realm-1", "lead", domain.PermAssignRole)
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("member")
-		tc.event_store_appends_successfully()
+		tc.request_has_role("lead")
+		tc.account_exists_in_event_store("acct-target")
+		tc.realm_exists_in_directory("realm-1", "Test Realm")
 		tc.routes_are_registered()
 
 		// When
-		tc.post_to_mux("/api/create-rune", domain.CreateRune{
-			Title:    "Test",
-			Priority: 1,
-			Branch:   strPtr("main"),
+		tc.post_to_mux("/api/assign-role", domain.AssignRole{
+			AccountID: "acct-target",
+			RealmID:   "realm-1",
+			Role:      "member",
 		})
 
 		// Then
-		tc.status_is(http.StatusCreated)
+		tc.status_is(http.StatusNoContent)
 	})
 
-	t.Run("member cannot POST /assign-role", func(t *testing.T) {
+	t.Run("custom role with assign-role cannot assign admin", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
+		tc.realm_has_custom_role("realm-1", "lead", domain.PermAssignRole)
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("member")
+		tc.request_has_role("lead")
 		tc.routes_are_registered()
 
 		// When
 		tc.post_to_mux("/api/assign-role", domain.AssignRole{
 			AccountID: "acct-target",
 			RealmID:   "realm-1",
-			Role:      "member",
+			Role:      "admin",
 		})
 
 		// Then
 		tc.status_is(http.StatusForbidden)
 	})
+}
 
-	t.Run("viewer cannot POST /forge-rune", func(t *testing.T) {
+func TestCustomRoleHandlers(t *testing.T) {
+	t.Run("GET /roles lists built-in and custom roles", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
+		tc.realm_has_custom_role("realm-1", "triager", domain.PermViewRunes)
 		tc.request_has_realm_id("realm-1")
 		tc.request_has_role("viewer")
-		tc.routes_are_registered()
 
 		// When
-		tc.post_to_mux("/api/forge-rune", domain.ForgeRune{
-			ID: "bf-0001",
-		})
+		tc.get("/roles")
 
 		// Then
-		tc.status_is(http.StatusForbidden)
+		tc.status_is(http.StatusOK)
+		var roles []domain.CustomRole
+		require.NoError(t, json.Unmarshal(tc.recorder.Body.Bytes(), &roles))
+		require.Len(t, roles, 5)
+		assert.Equal(t, domain.RoleOwner, roles[0].Name)
+		assert.Equal(t, domain.CustomRole{Name: "triager", Permissions: []string{domain.PermViewRunes}}, roles[4])
 	})
 
-	t.Run("member can POST /forge-rune", func(t *testing.T) {
+	t.Run("POST /define-role appends CustomRoleDefined to the realm stream", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
-		tc.request_has_role("member")
-		tc.rune_exists_as_draft_in_event_store("realm-1", "bf-0001")
-		tc.routes_are_registered()
+		tc.request_has_role("admin")
+		tc.realm_exists_in_event_store("realm-1")
 
 		// When
-		tc.post_to_mux("/api/forge-rune", domain.ForgeRune{
-			ID: "bf-0001",
-		})
+		tc.post("/define-role", map[string]any{"name": "triager", "permissions": []string{"view-runes", "add-note"}})
 
 		// Then
 		tc.status_is(http.StatusNoContent)
+		tc.event_was_appended("_admin", "realm-realm-1", domain.EventCustomRoleDefined)
 	})
 
-	t.Run("admin can POST /assign-role", func(t *testing.T) {
+	t.Run("POST /define-role rejects unknown permissions with 422", func(t *testing.T) {
 		tc := newHandlerTestContext(t)
 
 		// Given
 		tc.handlers_configured()
 		tc.request_has_realm_id("realm-1")
 		tc.request_has_role("admin")
-		tc.account_exists_in_event_store("acct-target")
-		tc.realm_exists_in_directory("realm-1", "Test Realm")
-		tc.routes_are_registered()
+		tc.realm_exists_in_event_store("realm-1")
 
 		// When
-		tc.post_to_mux("/api/assign-role", domain.AssignRole{
-			AccountID: "acct-target",
-			RealmID:   "realm-1",
-			Role:      "member",
-		})
+		tc.post("/define-role", map[string]any{"name": "triager", "permissions": []string{"teleport"}})
 
 		// Then
-		tc.status_is(http.StatusNoContent)
+		tc.status_is(http.StatusUnprocessableEntity)
 	})
 }
 
@@ -1381,12 +2559,15 @@ type handlerTestContext struct {
 	projectionStore *mockProjectionStore
 	engine          *mockProjectionEngine
 	handlers        *Handlers
+	searcher        *searchingProjectionStore
 
 	// HTTP
-	recorder  *httptest.ResponseRecorder
-	realmID   string
-	accountID string
-	role      string
+	recorder    *httptest.ResponseRecorder
+	realmID     string
+	accountID   string
+	role        string
+	accountKind string
+	patScopes   *domain.PATScopes
 
 	// Error for handleDomainError tests
 	domainErr error
@@ -1415,6 +2596,13 @@ func (tc *handlerTestContext) handlers_configured() {
 	tc.handlers = NewHandlers(tc.eventStore, tc.projectionStore, tc.engine)
 }
 
+func (tc *handlerTestContext) realm_claims_are_locked(realmID string) {
+	tc.t.Helper()
+	lock, _ := tc.handlers.claimLocks.LoadOrStore(realmID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	tc.t.Cleanup(lock.(*sync.Mutex).Unlock)
+}
+
 func (tc *handlerTestContext) request_has_realm_id(realmID string) {
 	tc.t.Helper()
 	tc.realmID = realmID
@@ -1425,11 +2613,29 @@ func (tc *handlerTestContext) request_has_account_id(accountID string) {
 	tc.accountID = accountID
 }
 
+func (tc *handlerTestContext) request_is_from_service_account() {
+	tc.t.Helper()
+	tc.accountKind = domain.AccountKindService
+}
+
 func (tc *handlerTestContext) request_has_role(role string) {
 	tc.t.Helper()
 	tc.role = role
 }
 
+func (tc *handlerTestContext) request_has_pat_scopes(scopes *domain.PATScopes) {
+	tc.t.Helper()
+	tc.patScopes = scopes
+}
+
+func (tc *handlerTestContext) realm_has_custom_role(realmID, name string, permissions ...string) {
+	tc.t.Helper()
+	_ = tc.projectionStore.Put(context.Background(), "_admin", "realm_roles", realmID, domain.RealmRoles{
+		RealmID: realmID,
+		Roles:   []domain.CustomRole{{Name: name, Permissions: permissions}},
+	})
+}
+
 func (tc *handlerTestContext) domain_error_is(err error) {
 	tc.t.Helper()
 	tc.domainErr = err
@@ -1452,6 +2658,35 @@ func (tc *handlerTestContext) rune_exists_in_event_store(realmID, runeID string)
 	tc.eventStore.appendToStream(realmID, "rune-"+runeID, domain.EventRuneForged, forged)
 }
 
+func (tc *handlerTestContext) rune_has_children(realmID, parentID string, childIDs ...string) {
+	tc.t.Helper()
+	children := make(map[string]string, len(childIDs))
+	for _, id := range childIDs {
+		children[id] = "open"
+	}
+	_ = tc.projectionStore.Put(context.Background(), realmID, "saga_progress", parentID, projectors.SagaProgress{
+		RuneID: parentID, Total: len(childIDs), Children: children,
+	})
+}
+
+func (tc *handlerTestContext) child_rune_exists_in_event_store(realmID, runeID, parentID string) {
+	tc.t.Helper()
+	created := domain.RuneCreated{
+		ID:       runeID,
+		Title:    "Child Rune",
+		Priority: 1,
+		ParentID: parentID,
+	}
+	tc.eventStore.appendToStream(realmID, "rune-"+runeID, domain.EventRuneCreated, created)
+}
+
+func (tc *handlerTestContext) rune_has_alias(realmID, alias, runeID string) {
+	tc.t.Helper()
+	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_alias", alias, projectors.RuneAliasEntry{
+		Alias: alias, RuneID: runeID,
+	})
+}
+
 func (tc *handlerTestContext) rune_exists_as_draft_in_event_store(realmID, runeID string) {
 	tc.t.Helper()
 	created := domain.RuneCreated{
@@ -1528,6 +2763,23 @@ func (tc *handlerTestContext) has_rune_summary(realmID, runeID, status string) {
 	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", runeID, summary)
 }
 
+// ready_rune adds an open rune to the event store and its projections.
+func (tc *handlerTestContext) ready_rune(realmID, runeID string, priority int, runeType string, tags ...string) {
+	tc.t.Helper()
+	tc.rune_exists_in_event_store(realmID, runeID)
+	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", runeID, projectors.RuneSummary{
+		ID: runeID, Status: "open", Priority: priority, Type: runeType, Tags: tags,
+	})
+	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_detail", runeID, projectors.RuneDetail{ID: runeID})
+}
+
+// search_returns configures handlers over a store whose searches return hits.
+func (tc *handlerTestContext) search_returns(hits ...core.SearchHit) {
+	tc.t.Helper()
+	tc.searcher = &searchingProjectionStore{mockProjectionStore: tc.projectionStore, hits: hits}
+	tc.handlers = NewHandlers(tc.eventStore, tc.searcher, tc.engine)
+}
+
 func (tc *handlerTestContext) has_rune_detail_with_dependencies(realmID, runeID string, deps []projectors.DependencyRef) {
 	tc.t.Helper()
 	detail := projectors.RuneDetail{ID: runeID, Dependencies: deps}
@@ -1557,6 +2809,13 @@ func (tc *handlerTestContext) realm_exists_in_directory(realmID, name string) {
 	})
 }
 
+func (tc *handlerTestContext) realm_has_status_in_directory(realmID, status string) {
+	tc.t.Helper()
+	_ = tc.projectionStore.Put(context.Background(), "_admin", "realm_directory", realmID, map[string]string{
+		"realm_id": realmID, "name": "Test Realm", "status": status,
+	})
+}
+
 func (tc *handlerTestContext) has_rune_list(realmID string) {
 	tc.t.Helper()
 	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", "bf-0001", map[string]string{
@@ -1609,6 +2868,54 @@ func (tc *handlerTestContext) has_rune_detail(realmID, runeID string) {
 	})
 }
 
+func (tc *handlerTestContext) has_saga_with_blocked_child(realmID, parentID string) {
+	tc.t.Helper()
+	_ = tc.projectionStore.Put(context.Background(), realmID, "saga_progress", parentID, projectors.SagaProgress{
+		RuneID:          parentID,
+		Total:           3,
+		Counts:          map[string]int{"fulfilled": 1, "open": 2},
+		PercentComplete: 33,
+		Children:        map[string]string{parentID + ".1": "fulfilled", parentID + ".2": "open", parentID + ".3": "open"},
+	})
+	tc.has_rune_summary(realmID, parentID+".1", "fulfilled")
+	tc.has_rune_detail_with_dependencies(realmID, parentID+".2", []projectors.DependencyRef{
+		{Relationship: domain.RelBlockedBy, TargetID: parentID + ".3"},
+	})
+	tc.has_rune_summary(realmID, parentID+".3", "open")
+	tc.has_rune_detail_with_dependencies(realmID, parentID+".3", []projectors.DependencyRef{
+		{Relationship: domain.RelBlockedBy, TargetID: parentID + ".1"},
+	})
+}
+
+func (tc *handlerTestContext) realm_has_policy(realmID, name, expression string, actions ...string) {
+	tc.t.Helper()
+	_ = tc.projectionStore.Put(context.Background(), "_admin", "realm_policies", realmID, domain.RealmPolicies{
+		RealmID:  realmID,
+		Policies: []domain.Policy{{Name: name, Actions: actions, Expression: expression}},
+	})
+}
+
+func (tc *handlerTestContext) realm_has_wip_limits(realmID string, limits domain.WIPLimits) {
+	tc.t.Helper()
+	settings := domain.DefaultRealmSettings(realmID)
+	settings.WIPLimits = limits
+	_ = tc.projectionStore.Put(context.Background(), "_admin", "realm_settings", realmID, settings)
+}
+
+func (tc *handlerTestContext) realm_has_active_claims(realmID string, claims ...domain.ActiveClaim) {
+	tc.t.Helper()
+	doc := domain.ActiveClaims{Claims: map[string]domain.ActiveClaim{}}
+	for _, claim := range claims {
+		doc.Claims[claim.RuneID] = claim
+	}
+	_ = tc.projectionStore.Put(context.Background(), realmID, "wip_claims", "claims", doc)
+}
+
+func (tc *handlerTestContext) realm_exists_in_event_store(realmID string) {
+	tc.t.Helper()
+	tc.eventStore.appendToStream("_admin", "realm-"+realmID, domain.EventRealmCreated, domain.RealmCreated{RealmID: realmID, Name: "Test Realm"})
+}
+
 func (tc *handlerTestContext) has_ready_runes(realmID string) {
 	tc.t.Helper()
 	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", "bf-0001", map[string]any{
@@ -1649,6 +2956,27 @@ func (tc *handlerTestContext) has_blocked_and_unblocked_runes(realmID string) {
 	})
 }
 
+func (tc *handlerTestContext) has_scheduled_runes(realmID string) {
+	tc.t.Helper()
+	past := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", "bf-late", map[string]any{
+		"id": "bf-late", "title": "Late", "status": "open", "priority": 1.0, "due_at": past,
+	})
+	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", "bf-done", map[string]any{
+		"id": "bf-done", "title": "Done late", "status": "fulfilled", "priority": 1.0, "due_at": past,
+	})
+	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", "bf-failed", map[string]any{
+		"id": "bf-failed", "title": "Failed late", "status": "failed", "priority": 1.0, "due_at": past,
+	})
+	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", "bf-snoozed", map[string]any{
+		"id": "bf-snoozed", "title": "Snoozed", "status": "open", "priority": 1.0, "defer_until": future,
+	})
+	_ = tc.projectionStore.Put(context.Background(), realmID, "rune_summary", "bf-woken", map[string]any{
+		"id": "bf-woken", "title": "Woken", "status": "open", "priority": 1.0, "defer_until": past, "due_at": future,
+	})
+}
+
 // --- When ---
 
 func (tc *handlerTestContext) write_json(status int, data any) {
@@ -1676,6 +3004,13 @@ func (tc *handlerTestContext) build_context(ctx context.Context) context.Context
 	}
 	if tc.role != "" {
 		ctx = context.WithValue(ctx, roleKey, tc.role)
+		ctx = domain.WithPolicyRole(ctx, tc.role)
+	}
+	if tc.accountKind != "" {
+		ctx = context.WithValue(ctx, accountKindKey, tc.accountKind)
+	}
+	if tc.patScopes != nil {
+		ctx = context.WithValue(ctx, patScopesKey, tc.patScopes)
 	}
 	return ctx
 }
@@ -1731,6 +3066,18 @@ func (tc *handlerTestContext) routes_are_registered() {
 
 // --- Then ---
 
+func (tc *handlerTestContext) catch_up_ran_times(expected int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.engine.catchUps)
+}
+
+func (tc *handlerTestContext) realm_claims_are_unlocked(realmID string) {
+	tc.t.Helper()
+	lock, _ := tc.handlers.claimLocks.LoadOrStore(realmID, &sync.Mutex{})
+	require.True(tc.t, lock.(*sync.Mutex).TryLock(), "claims in %s are locked", realmID)
+	lock.(*sync.Mutex).Unlock()
+}
+
 func (tc *handlerTestContext) status_is(code int) {
 	tc.t.Helper()
 	assert.Equal(tc.t, code, tc.recorder.Code)
@@ -1760,6 +3107,13 @@ func (tc *handlerTestContext) response_body_has_error_field() {
 	assert.Contains(tc.t, resp, "error")
 }
 
+func (tc *handlerTestContext) response_rune_id_is(expected string) {
+	tc.t.Helper()
+	var resp map[string]any
+	require.NoError(tc.t, json.Unmarshal(tc.recorder.Body.Bytes(), &resp))
+	assert.Equal(tc.t, expected, resp["id"])
+}
+
 func (tc *handlerTestContext) response_body_has_field(field string) {
 	tc.t.Helper()
 	var resp map[string]any
@@ -1768,6 +3122,49 @@ func (tc *handlerTestContext) response_body_has_field(field string) {
 	assert.Contains(tc.t, resp, field)
 }
 
+func (tc *handlerTestContext) event_was_appended(realmID, streamID, eventType string) {
+	tc.t.Helper()
+	events := tc.eventStore.streams[tc.eventStore.streamKey(realmID, streamID)]
+	require.NotEmpty(tc.t, events, "expected events in stream %q", streamID)
+	assert.Equal(tc.t, eventType, events[len(events)-1].EventType)
+}
+
+func (tc *handlerTestContext) appended_event_data_has(realmID, streamID, field string, expected any) {
+	tc.t.Helper()
+	events := tc.eventStore.streams[tc.eventStore.streamKey(realmID, streamID)]
+	require.NotEmpty(tc.t, events, "expected events in stream %q", streamID)
+	var data map[string]any
+	require.NoError(tc.t, json.Unmarshal(events[len(events)-1].Data, &data))
+	assert.Equal(tc.t, expected, data[field])
+}
+
+func (tc *handlerTestContext) no_event_was_appended(realmID, streamID, eventType string) {
+	tc.t.Helper()
+	for _, evt := range tc.eventStore.streams[tc.eventStore.streamKey(realmID, streamID)] {
+		assert.NotEqual(tc.t, eventType, evt.EventType, "unexpected %s in stream %q", eventType, streamID)
+	}
+}
+
+func (tc *handlerTestContext) response_body_lacks_field(field string) {
+	tc.t.Helper()
+	var resp map[string]any
+	err := json.Unmarshal(tc.recorder.Body.Bytes(), &resp)
+	require.NoError(tc.t, err, "response body should be valid JSON")
+	assert.NotContains(tc.t, resp, field)
+}
+
+func (tc *handlerTestContext) response_saga_progress_is(total, percent, blocked int) {
+	tc.t.Helper()
+	var resp struct {
+		SagaProgress *projectors.SagaProgress `json:"saga_progress"`
+	}
+	require.NoError(tc.t, json.Unmarshal(tc.recorder.Body.Bytes(), &resp))
+	require.NotNil(tc.t, resp.SagaProgress, "expected saga_progress in response")
+	assert.Equal(tc.t, total, resp.SagaProgress.Total)
+	assert.Equal(tc.t, percent, resp.SagaProgress.PercentComplete)
+	assert.Equal(tc.t, blocked, resp.SagaProgress.Blocked)
+}
+
 func (tc *handlerTestContext) response_is_non_empty_json_array() {
 	tc.t.Helper()
 	var resp []any
@@ -1829,6 +3226,22 @@ func (tc *handlerTestContext) response_array_all_have_field_value(field, expecte
 	}
 }
 
+func (tc *handlerTestContext) search_was_for(realmID, query string, limit int) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.searcher)
+	assert.Equal(tc.t, realmID, tc.searcher.realmID)
+	assert.Equal(tc.t, projectors.RuneSearchTable.Name, tc.searcher.table)
+	assert.Equal(tc.t, query, tc.searcher.query)
+	assert.Equal(tc.t, limit, tc.searcher.limit)
+}
+
+func (tc *handlerTestContext) search_results() []SearchResult {
+	tc.t.Helper()
+	var results []SearchResult
+	require.NoError(tc.t, json.Unmarshal(tc.recorder.Body.Bytes(), &results))
+	return results
+}
+
 func (tc *handlerTestContext) route_exists(method, path string) {
 	tc.t.Helper()
 	req := httptest.NewRequest(method, path, nil)
@@ -1946,6 +3359,7 @@ func (m *mockEventStore) ListRealmIDs(_ context.Context) ([]string, error) {
 
 type mockProjectionEngine struct {
 	runSyncCalled bool
+	catchUps      int
 	store         *mockProjectionStore
 	eventStore    *mockEventStore
 }
@@ -1973,6 +3387,7 @@ func (m *mockProjectionEngine) RunSync(ctx context.Context, events []core.Event)
 
 func (m *mockProjectionEngine) RunCatchUpOnce(ctx context.Context) {
 	m.runSyncCalled = true
+	m.catchUps++
 	// Simulate processing all RealmCreated events from event store into projection
 	if m.store != nil && m.eventStore != nil {
 		for _, events := range m.eventStore.streams {
@@ -1995,4 +3410,28 @@ func (m *mockProjectionEngine) Stop() error { return nil }
 
 func (m *mockProjectionEngine) RebuildProjections(ctx context.Context) error { return nil }
 
+// --- Searching Projection Store ---
+
+// searchingProjectionStore is a mockProjectionStore that can search,
+// returning fixed hits and recording the last search.
+type searchingProjectionStore struct {
+	*mockProjectionStore
+	hits []core.SearchHit
+
+	realmID string
+	table   string
+	query   string
+	limit   int
+}
+
+func (m *searchingProjectionStore) EnableSearch(_ context.Context, _ string, _ ...string) error {
+	return nil
+}
+
+func (m *searchingProjectionStore) Search(_ context.Context, realmID string, table string, query string, limit int) ([]core.SearchHit, error) {
+	m.realmID, m.table, m.query, m.limit = realmID, table, query, limit
+	return m.hits, nil
+}
+
 func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
@@ -318,9 +318,11 @@ func (tc *e2eTestContext) server_is_running() {
 		eventStore:      es,
 		projectionStore: ps,
 		projectors: []core.Projector{
+			projectors.NewGroupDirectoryProjector(),
 			projectors.NewAccountAuthProjector(),
 			projectors.NewAccountDirectoryProjector(),
 			projectors.NewUsernameLookupProjector(),
+			projectors.NewSSOIdentityProjector(),
 			projectors.NewPATIDProjector(),
 			projectors.NewPATKeyhashProjector(),
 			projectors.NewSystemStatusProjector(),
@@ -23,6 +23,17 @@ import (
 	"github.com/devzeebo/bifrost/server/admin"
 )
 
+// realmPurgers returns the stores that can purge a deleted realm's data.
+func realmPurgers(stores ...any) []core.RealmPurger {
+	var purgers []core.RealmPurger
+	for _, store := range stores {
+		if purger, ok := store.(core.RealmPurger); ok {
+			purgers = append(purgers, purger)
+		}
+	}
+	return purgers
+}
+
 // registerProjectors registers all projectors with the engine
 func registerProjectors(engine core.ProjectionEngine) error {
 	// Account projections (realm: _admin)
@@ -35,12 +46,26 @@ func registerProjectors(engine core.ProjectionEngine) error {
 	if err := engine.Register(projectors.NewUsernameLookupProjector()); err != nil {
 		return err
 	}
+	if err := engine.Register(projectors.NewSSOIdentityProjector()); err != nil {
+		return err
+	}
 	if err := engine.Register(projectors.NewPATIDProjector()); err != nil {
 		return err
 	}
 	if err := engine.Register(projectors.NewPATKeyhashProjector()); err != nil {
 		return err
 	}
+	if err := engine.Register(projectors.NewTokenRevocationProjector()); err != nil {
+		return err
+	}
+	if err := engine.Register(projectors.NewAuditLogProjector()); err != nil {
+		return err
+	}
+
+	// Group projections (realm: _admin)
+	if err := engine.Register(projectors.NewGroupDirectoryProjector()); err != nil {
+		return err
+	}
 
 	// System projections (realm: _admin)
 	if err := engine.Register(projectors.NewSystemStatusProjector()); err != nil {
@@ -54,6 +79,18 @@ func registerProjectors(engine core.ProjectionEngine) error {
 	if err := engine.Register(projectors.NewRealmNameLookupProjector()); err != nil {
 		return err
 	}
+	if err := engine.Register(projectors.NewRealmSettingsProjector()); err != nil {
+		return err
+	}
+	if err := engine.Register(projectors.NewRealmPoliciesProjector()); err != nil {
+		return err
+	}
+	if err := engine.Register(projectors.NewRealmRolesProjector()); err != nil {
+		return err
+	}
+	if err := engine.Register(projectors.NewRealmWebhooksProjector()); err != nil {
+		return err
+	}
 
 	// Rune projections (realm: per-realm)
 	if err := engine.Register(projectors.NewRuneSummaryProjector()); err != nil {
@@ -74,12 +111,24 @@ func registerProjectors(engine core.ProjectionEngine) error {
 	if err := engine.Register(projectors.NewRuneChildCountProjector()); err != nil {
 		return err
 	}
+	if err := engine.Register(projectors.NewSagaProgressProjector()); err != nil {
+		return err
+	}
+	if err := engine.Register(projectors.NewRuneAliasProjector()); err != nil {
+		return err
+	}
 	if err := engine.Register(projectors.NewRuneRetroProjector()); err != nil {
 		return err
 	}
 	if err := engine.Register(projectors.NewRuneACCounterProjector()); err != nil {
 		return err
 	}
+	if err := engine.Register(projectors.NewRuneSearchProjector()); err != nil {
+		return err
+	}
+	if err := engine.Register(projectors.NewWIPClaimsProjector()); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -138,6 +187,10 @@ func Run(ctx context.Context, cfg *Config) error {
 		}
 	}
 
+	// Events appended by authenticated requests record the acting account
+	rawEventStore := eventStore
+	eventStore = core.NewActorEventStore(eventStore)
+
 	// 3. Create projection engine and register projectors
 	engine := core.NewProjectionEngine(
 		eventStore,
@@ -151,6 +204,13 @@ func Run(ctx context.Context, cfg *Config) error {
 		log.Fatalf("failed to register projectors: %v", err)
 	}
 
+	// Rune text is indexed for GET /api/search by stores that support it
+	if searcher, ok := projectionStore.(core.TextSearcher); ok {
+		if err := searcher.EnableSearch(ctx, projectors.RuneSearchTable.Name, projectors.RuneSearchFields...); err != nil {
+			return fmt.Errorf("enable rune search: %w", err)
+		}
+	}
+
 	// 4. Start catch-up in background
 	if err := engine.StartCatchUp(ctx); err != nil {
 		return fmt.Errorf("start catch-up: %w", err)
@@ -185,6 +245,81 @@ func Run(ctx context.Context, cfg *Config) error {
 	// Disable secure cookies for local development
 	adminAuthConfig.CookieSecure = false
 
+	// PAT usage is written to its own table, outside the projection engine
+	if err := projectionStore.CreateTable(ctx, admin.PATUsageTable); err != nil {
+		return fmt.Errorf("create %s table: %w", admin.PATUsageTable, err)
+	}
+	usageCtx, stopUsage := context.WithCancel(context.Background())
+	usageDone := make(chan struct{})
+	adminAuthConfig.Usage = admin.NewPATUsageRecorder(projectionStore, admin.DefaultPATUsageFlushInterval)
+	go func() {
+		defer close(usageDone)
+		adminAuthConfig.Usage.Run(usageCtx)
+	}()
+	// Flush usage recorded by the last requests before the database closes
+	defer func() {
+		stopUsage()
+		<-usageDone
+	}()
+
+	// Logins, auth failures and rebuilds are written to their own table too
+	if err := projectionStore.CreateTable(ctx, admin.SecurityLogTable); err != nil {
+		return fmt.Errorf("create %s table: %w", admin.SecurityLogTable, err)
+	}
+	securityCtx, stopSecurityLog := context.WithCancel(context.Background())
+	securityLogDone := make(chan struct{})
+	adminAuthConfig.SecurityLog = admin.NewSecurityLog(projectionStore, admin.DefaultSecurityLogFlushInterval)
+	go func() {
+		defer close(securityLogDone)
+		adminAuthConfig.SecurityLog.Run(securityCtx)
+	}()
+	defer func() {
+		stopSecurityLog()
+		<-securityLogDone
+	}()
+
+	// Webhook deliveries are logged to their own table and sent in the background
+	if err := projectionStore.CreateTable(ctx, WebhookDeliveriesTable); err != nil {
+		return fmt.Errorf("create %s table: %w", WebhookDeliveriesTable, err)
+	}
+	webhookDispatcher := NewWebhookDispatcher(rawEventStore, projectionStore, checkpointStore, cfg.CatchUpInterval)
+	webhookDispatcher.SetAllowedHosts(cfg.WebhookAllowedHosts)
+	webhookCtx, stopWebhooks := context.WithCancel(context.Background())
+	webhooksDone := make(chan struct{})
+	go func() {
+		defer close(webhooksDone)
+		webhookDispatcher.Run(webhookCtx)
+	}()
+	defer func() {
+		stopWebhooks()
+		<-webhooksDone
+	}()
+
+	// Access tokens are checked against an in-memory copy of token_revocations
+	adminAuthConfig.Revocations = admin.NewRevocationList(projectionStore, admin.DefaultRevocationRefreshInterval)
+	if err := adminAuthConfig.Revocations.Refresh(ctx); err != nil {
+		return err
+	}
+	go adminAuthConfig.Revocations.Run(ctx)
+
+	// Discover the single sign-on provider, if configured
+	var oidcProvider *admin.OIDCProvider
+	if cfg.OIDC.IssuerURL != "" {
+		oidcProvider, err = admin.DiscoverOIDC(ctx, admin.OIDCConfig{
+			IssuerURL:             cfg.OIDC.IssuerURL,
+			ClientID:              cfg.OIDC.ClientID,
+			ClientSecret:          cfg.OIDC.ClientSecret,
+			RedirectURL:           cfg.OIDC.RedirectURL,
+			Scopes:                cfg.OIDC.Scopes,
+			UsernameClaim:         cfg.OIDC.UsernameClaim,
+			UsernameClaimVerified: cfg.OIDC.UsernameClaimVerified,
+			AutoProvision:         cfg.OIDC.AutoProvision,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	// 6. Set up HTTP routes with auth middleware
 	mux := http.NewServeMux()
 	auth := AuthMiddleware(projectionStore, &AuthConfig{AdminAuthConfig: adminAuthConfig})
@@ -192,6 +327,13 @@ func Run(ctx context.Context, cfg *Config) error {
 	adminAuth := func(h http.Handler) http.Handler { return auth(RequireAdmin(h)) }
 
 	handlers := NewHandlers(eventStore, projectionStore, engine)
+	handlers.SetRealmPurgers(realmPurgers(rawEventStore, projectionStore, checkpointStore)...)
+	handlers.SetSecurityLog(adminAuthConfig.SecurityLog)
+	rateLimiter := NewRateLimiter(cfg.RateLimit)
+	handlers.SetRateLimiter(rateLimiter)
+	handlers.SetWebhookDispatcher(webhookDispatcher)
+	eventStreamer := NewEventStreamer(rawEventStore, projectionStore, cfg.CatchUpInterval)
+	handlers.SetEventStreamer(eventStreamer)
 	handlers.RegisterRoutes(mux, realmAuth, adminAuth)
 
 	// Register admin UI routes
@@ -200,6 +342,9 @@ func Run(ctx context.Context, cfg *Config) error {
 		ProjectionStore:  projectionStore,
 		EventStore:       eventStore,
 		ProjectionEngine: engine,
+		OIDC:             oidcProvider,
+		DeviceTokenTTL:   cfg.DeviceTokenTTL,
+		RateLimit:        rateLimiter.ClientIPMiddleware,
 		ViteDevServerURL: cfg.ViteDevServerURL,
 	})
 	if err != nil {
@@ -217,6 +362,9 @@ func Run(ctx context.Context, cfg *Config) error {
 			return ctx
 		},
 	}
+	// Shutdown waits for handlers to return, so open event streams and
+	// long-polling claims are ended first
+	srv.RegisterOnShutdown(handlers.Close)
 
 	// 7. Listen for shutdown signals
 	notifyCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
@@ -5,8 +5,11 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/devzeebo/bifrost/core"
 	"github.com/devzeebo/bifrost/domain"
@@ -19,6 +22,10 @@ type contextKey string
 const realmIDKey contextKey = "realm_id"
 const accountIDKey contextKey = "account_id"
 const roleKey contextKey = "role"
+const patScopesKey contextKey = "pat_scopes"
+const patIDKey contextKey = "pat_id"
+const accountKindKey contextKey = "account_kind"
+const securityLogKey contextKey = "security_log"
 
 // RealmIDFromContext extracts the realm ID from the request context.
 func RealmIDFromContext(ctx context.Context) (string, bool) {
@@ -32,6 +39,12 @@ func AccountIDFromContext(ctx context.Context) (string, bool) {
 	return id, ok
 }
 
+// IsServiceAccountFromContext reports whether the request was authenticated as a service account.
+func IsServiceAccountFromContext(ctx context.Context) bool {
+	kind, _ := ctx.Value(accountKindKey).(string)
+	return kind == domain.AccountKindService
+}
+
 // RoleFromContext extracts the role from the request context.
 func RoleFromContext(ctx context.Context) (string, bool) {
 	role, ok := ctx.Value(roleKey).(string)
@@ -44,7 +57,37 @@ func RequireRole(minRole string) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			role, ok := RoleFromContext(r.Context())
 			if !ok || domain.RoleLevel(role) < domain.RoleLevel(minRole) {
-				http.Error(w, "Forbidden", http.StatusForbidden)
+				denyRequest(w, r, fmt.Sprintf("role %q is below %q", role, minRole))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequirePermission returns HTTP middleware that requires the caller's role in
+// the request realm to grant perm. Built-in roles grant their fixed bundles;
+// custom roles grant the permissions defined for them in the realm.
+func RequirePermission(projectionStore core.ProjectionStore, perm string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := RoleFromContext(r.Context())
+			if !ok {
+				denyRequest(w, r, "no role in realm")
+				return
+			}
+			realmID, _ := RealmIDFromContext(r.Context())
+			perms, err := domain.RolePermissions(r.Context(), realmID, role, projectionStore)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "failed to resolve role permissions")
+				return
+			}
+			if !slices.Contains(perms, perm) {
+				denyRequest(w, r, fmt.Sprintf("role %q lacks permission %q", role, perm))
+				return
+			}
+			if scopes, _ := r.Context().Value(patScopesKey).(*domain.PATScopes); !scopes.AllowsPermission(perm) {
+				denyRequest(w, r, fmt.Sprintf("token not scoped for permission %q", perm))
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -57,7 +100,7 @@ func RequireRealm(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		realmID, ok := RealmIDFromContext(r.Context())
 		if !ok || realmID == "_admin" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+			denyRequest(w, r, "endpoint requires a realm other than _admin")
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -69,24 +112,59 @@ func RequireAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		realmID, ok := RealmIDFromContext(r.Context())
 		if !ok || realmID != "_admin" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+			denyRequest(w, r, "endpoint requires the _admin realm")
 			return
 		}
 		next.ServeHTTP(w, r)
 	})
 }
 
+// denyRequest rejects an authenticated request with 403 and records the
+// denial in the security log AuthMiddleware placed in its context.
+func denyRequest(w http.ResponseWriter, r *http.Request, reason string) {
+	accountID, _ := AccountIDFromContext(r.Context())
+	securityLog, _ := r.Context().Value(securityLogKey).(*admin.SecurityLog)
+	securityLog.RecordAuthFailure(r, accountID, http.StatusForbidden, reason)
+	http.Error(w, "Forbidden", http.StatusForbidden)
+}
+
+// RequireWritableRealm returns HTTP middleware that rejects changes to an archived realm.
+func RequireWritableRealm(projectionStore core.ProjectionStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			realmID, _ := RealmIDFromContext(r.Context())
+			var entry projectors.RealmDirectoryEntry
+			if err := projectionStore.Get(r.Context(), "_admin", "realm_directory", realmID, &entry); err == nil && entry.Status == "archived" {
+				writeError(w, http.StatusForbidden, "realm is archived and read-only")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // AuthConfig holds configuration for combined authentication (Bearer token + JWT cookie).
 type AuthConfig struct {
 	AdminAuthConfig *admin.AuthConfig
 }
 
+// securityLog returns the log that records authentication failures, or nil.
+func (c *AuthConfig) securityLog() *admin.SecurityLog {
+	if c == nil || c.AdminAuthConfig == nil {
+		return nil
+	}
+	return c.AdminAuthConfig.SecurityLog
+}
+
 // AuthMiddleware returns HTTP middleware that authenticates via:
 // 1. JWT cookie (for UI sessions), OR
 // 2. Bearer token + X-Bifrost-Realm header (for API clients)
 func AuthMiddleware(projectionStore core.ProjectionStore, authConfig *AuthConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Authorization middleware after this one records denials here
+			r = r.WithContext(context.WithValue(r.Context(), securityLogKey, authConfig.securityLog()))
+
 			// Try JWT cookie auth first (for UI sessions)
 			if authConfig != nil && authConfig.AdminAuthConfig != nil {
 				if cookie, err := r.Cookie(authConfig.AdminAuthConfig.CookieName); err == nil {
@@ -122,16 +200,37 @@ func AuthMiddleware(projectionStore core.ProjectionStore, authConfig *AuthConfig
 				return
 			}
 
-			ctx, err := authenticateViaBearerToken(r.Context(), token, realmID, projectionStore)
+			var ctx context.Context
+			var err error
+			if admin.IsAccessToken(token) && authConfig != nil && authConfig.AdminAuthConfig != nil {
+				ctx, err = authenticateViaAccessToken(r.Context(), token, realmID, authConfig.AdminAuthConfig, projectionStore)
+			} else {
+				ctx, err = authenticateViaBearerToken(r.Context(), token, realmID, projectionStore)
+			}
 			if err != nil {
+				status, message := http.StatusUnauthorized, "Unauthorized"
 				if authErr, ok := err.(*AuthError); ok {
-					http.Error(w, authErr.Message, authErr.Status)
-				} else {
-					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					status, message = authErr.Status, authErr.Message
 				}
+				authConfig.securityLog().RecordAuthFailure(r, "", status, message)
+				http.Error(w, message, status)
 				return
 			}
 
+			if scopes, _ := ctx.Value(patScopesKey).(*domain.PATScopes); !scopes.AllowsCommand(admin.EndpointName(r.URL.Path)) {
+				accountID, _ := AccountIDFromContext(ctx)
+				authConfig.securityLog().RecordAuthFailure(r, accountID, http.StatusForbidden, "Token not scoped for endpoint")
+				http.Error(w, "Token not scoped for endpoint", http.StatusForbidden)
+				return
+			}
+
+			// Access tokens were counted when they were exchanged
+			if authConfig != nil && authConfig.AdminAuthConfig != nil && !admin.IsAccessToken(token) {
+				patID, _ := ctx.Value(patIDKey).(string)
+				authConfig.AdminAuthConfig.Usage.Record(patID, admin.ClientIP(r), time.Now())
+				w.Header().Set(admin.TokenExchangeHeader, "/api/token")
+			}
+
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -146,10 +245,13 @@ func authenticateViaJWT(ctx context.Context, token string, cfg *admin.AuthConfig
 	}
 
 	// Check that the PAT is still active
-	entry, err := admin.CheckPATStatus(r.Context(), projectionStore, claims.PATID)
+	entry, scopes, err := admin.CheckPATStatus(r.Context(), projectionStore, claims.PATID)
 	if err != nil {
 		return nil, ErrUnauthorized("Unauthorized")
 	}
+	if !scopes.AllowsCommand(admin.EndpointName(r.URL.Path)) {
+		return nil, ErrForbidden("Token not scoped for endpoint")
+	}
 
 	// Get realm from header or cookie, fallback to first available
 	realmID := r.Header.Get("X-Bifrost-Realm")
@@ -185,6 +287,11 @@ func authenticateViaJWT(ctx context.Context, token string, cfg *admin.AuthConfig
 	ctx = context.WithValue(ctx, accountIDKey, claims.AccountID)
 	ctx = context.WithValue(ctx, realmIDKey, realmID)
 	ctx = context.WithValue(ctx, roleKey, role)
+	ctx = domain.WithPolicyRole(ctx, role)
+	ctx = context.WithValue(ctx, patScopesKey, scopes)
+	ctx = context.WithValue(ctx, patIDKey, claims.PATID)
+	ctx = context.WithValue(ctx, accountKindKey, entry.Kind)
+	ctx = core.WithActor(ctx, claims.AccountID)
 	return ctx, nil
 }
 
@@ -193,6 +300,10 @@ func isAdminEndpoint(path string) bool {
 	adminEndpoints := []string{
 		"/api/create-realm",
 		"/api/suspend-realm", 
+		"/api/rename-realm",
+		"/api/reactivate-realm",
+		"/api/archive-realm",
+		"/api/delete-realm",
 		"/api/realms",
 		"/api/rebuild-projections",
 		"/api/resolve-username",
@@ -265,6 +376,10 @@ func authenticateViaBearerToken(ctx context.Context, token string, realmID strin
 		return nil, ErrInternal("Internal server error")
 	}
 
+	if domain.IsExpired(patEntry.ExpiresAt, time.Now()) {
+		return nil, ErrUnauthorized("Token expired")
+	}
+
 	// Look up account auth entry
 	var entry projectors.AccountAuthEntry
 	err = projectionStore.Get(ctx, "_admin", "account_auth", patEntry.AccountID, &entry)
@@ -282,37 +397,71 @@ func authenticateViaBearerToken(ctx context.Context, token string, realmID strin
 		return nil, ErrForbidden("Account suspended")
 	}
 
+	return authorizeRealm(ctx, &entry, patID, patEntry.Scopes, realmID, projectionStore)
+}
+
+// authenticateViaAccessToken validates an access token exchanged from a PAT
+// and returns the context with auth info. It needs no projection lookups
+// unless the realm is given by a name the token does not know.
+func authenticateViaAccessToken(ctx context.Context, token string, realmID string, cfg *admin.AuthConfig, projectionStore core.ProjectionStore) (context.Context, error) {
+	claims, err := admin.AuthenticateAccessToken(ctx, cfg, projectionStore, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrTokenExpired), errors.Is(err, admin.ErrPATExpired), errors.Is(err, admin.ErrGrantsChanged):
+			return nil, ErrUnauthorized("Token expired")
+		case errors.Is(err, admin.ErrAccountSuspended):
+			return nil, ErrForbidden("Account suspended")
+		case errors.Is(err, admin.ErrInvalidToken), errors.Is(err, admin.ErrPATRevoked):
+			return nil, ErrUnauthorized("Unauthorized")
+		}
+		return nil, ErrInternal("Internal server error")
+	}
+	return authorizeRealm(ctx, claims.AccountEntry(), claims.PATID, claims.Scopes, realmID, projectionStore)
+}
+
+// authorizeRealm resolves the requested realm for an authenticated account
+// and returns the context with the account's role in it, capped by scopes.
+func authorizeRealm(ctx context.Context, entry *projectors.AccountAuthEntry, patID string, scopes *domain.PATScopes, realmID string, projectionStore core.ProjectionStore) (context.Context, error) {
 	// Resolve realm ID (handles both realm IDs and realm names)
 	resolvedRealmID, err := resolveRealmID(ctx, realmID, entry.Roles, entry.Realms, entry.RealmNames, projectionStore)
 	if err != nil {
 		return nil, err
 	}
 
-	// Extract role for the requested realm
-	var role string
-	if entry.Roles != nil {
-		role = entry.Roles[resolvedRealmID]
-	}
+	role := accountRealmRole(entry, resolvedRealmID)
 	if role == "" {
-		// Fallback to Realms slice for legacy data
-		for _, realm := range entry.Realms {
-			if realm == resolvedRealmID {
-				role = "member"
-				break
-			}
-		}
+		return nil, ErrForbidden("No access to realm")
 	}
 
-	if role == "" {
-		return nil, ErrForbidden("No access to realm")
+	// Scopes only narrow what the account itself has been granted
+	if !scopes.AllowsRealm(resolvedRealmID) {
+		return nil, ErrForbidden("Token not scoped for realm")
 	}
+	role = scopes.CapRole(role)
 
 	ctx = context.WithValue(ctx, accountIDKey, entry.AccountID)
 	ctx = context.WithValue(ctx, realmIDKey, resolvedRealmID)
 	ctx = context.WithValue(ctx, roleKey, role)
+	ctx = domain.WithPolicyRole(ctx, role)
+	ctx = context.WithValue(ctx, patScopesKey, scopes)
+	ctx = context.WithValue(ctx, patIDKey, patID)
+	ctx = context.WithValue(ctx, accountKindKey, entry.Kind)
+	ctx = core.WithActor(ctx, entry.AccountID)
 	return ctx, nil
 }
 
+// accountRealmRole returns the account's role in a realm, or "" if it has none.
+func accountRealmRole(entry *projectors.AccountAuthEntry, realmID string) string {
+	if role := entry.Roles[realmID]; role != "" {
+		return role
+	}
+	// Fallback to Realms slice for legacy data
+	if slices.Contains(entry.Realms, realmID) {
+		return "member"
+	}
+	return ""
+}
+
 // resolveRealmID resolves a realm identifier (ID or name) to a realm ID.
 // Returns an AuthError if the realm cannot be found or accessed.
 func resolveRealmID(ctx context.Context, realmIdent string, roles map[string]string, realms []string, realmNames map[string]string, projectionStore core.ProjectionStore) (string, error) {
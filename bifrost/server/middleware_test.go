@@ -9,8 +9,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/devzeebo/bifrost/server/admin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -124,6 +128,198 @@ func TestAuthMiddleware(t *testing.T) {
 		tc.next_handler_was_not_called()
 	})
 
+	t.Run("returns 401 when the PAT has expired", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-1")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member"})
+		tc.pat_expires_at(time.Now().Add(-time.Minute))
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusUnauthorized)
+		tc.next_handler_was_not_called()
+	})
+
+	t.Run("accepts a PAT that has not yet expired", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-1")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member"})
+		tc.pat_expires_at(time.Now().Add(time.Hour))
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.next_handler_was_called()
+	})
+
+	t.Run("records usage for an authenticated PAT", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-1")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member"})
+		tc.pat_usage_is_recorded()
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.next_handler_was_called()
+		tc.pat_usage_count_is(1)
+	})
+
+	t.Run("does not record usage for a rejected PAT", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-2")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member"})
+		tc.pat_usage_is_recorded()
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.next_handler_was_not_called()
+		tc.pat_usage_count_is(0)
+	})
+
+	t.Run("returns 403 when the PAT is not scoped for the realm", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-2")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member", "realm-2": "member"})
+		tc.pat_has_scopes(domain.PATScopes{Realms: []string{"realm-1"}})
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+		tc.response_body_contains("Token not scoped for realm")
+		tc.next_handler_was_not_called()
+	})
+
+	t.Run("caps the role at the PAT's max role", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-1")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "admin"})
+		tc.pat_has_scopes(domain.PATScopes{MaxRole: domain.RoleViewer})
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.next_handler_was_called()
+		tc.context_has_role("viewer")
+	})
+
+	t.Run("does not raise the role above the account's grant", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-1")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "viewer"})
+		tc.pat_has_scopes(domain.PATScopes{MaxRole: domain.RoleAdmin})
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.next_handler_was_called()
+		tc.context_has_role("viewer")
+	})
+
+	t.Run("accepts an endpoint in the PAT's command scope", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_path_is("/claim-rune")
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-1")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member"})
+		tc.pat_has_scopes(domain.PATScopes{Commands: []string{"claim-rune", "runes"}})
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.next_handler_was_called()
+	})
+
+	t.Run("returns 403 for an endpoint outside the PAT's command scope", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.request_path_is("/create-rune")
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-1")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member"})
+		tc.pat_has_scopes(domain.PATScopes{Commands: []string{"claim-rune", "runes"}})
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+		tc.response_body_contains("Token not scoped for endpoint")
+		tc.next_handler_was_not_called()
+	})
+
+	t.Run("carries the PAT's scopes into a session cookie request", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.session_cookies_are_enabled()
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "admin"})
+		tc.pat_has_scopes(domain.PATScopes{MaxRole: domain.RoleViewer})
+		tc.request_with_session_cookie("acct-1")
+		tc.request_has_realm_header("realm-1")
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.next_handler_was_called()
+		tc.context_has_role("viewer")
+		tc.context_has_pat_scopes(&domain.PATScopes{MaxRole: domain.RoleViewer})
+	})
+
+	t.Run("rejects a session cookie for an endpoint outside the PAT's command scope", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.session_cookies_are_enabled()
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member"})
+		tc.pat_has_scopes(domain.PATScopes{Commands: []string{"claim-rune", "runes"}})
+		tc.request_path_is("/create-rune")
+		tc.request_with_session_cookie("acct-1")
+		tc.request_has_realm_header("realm-1")
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.next_handler_was_not_called()
+	})
+
 	t.Run("returns 403 when account has no role for requested realm", func(t *testing.T) {
 		tc := newTestContext(t)
 
@@ -317,6 +513,146 @@ func TestAuthMiddleware(t *testing.T) {
 		tc.next_handler_was_called()
 		tc.context_has_realm_id("realm-1")
 	})
+
+	t.Run("advertises token exchange on responses to PAT requests", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.pat_usage_is_recorded()
+		tc.request_with_bearer_token(tc.rawKey)
+		tc.request_has_realm_header("realm-1")
+		tc.store_has_account_with_roles("acct-1", "alice", "active", map[string]string{"realm-1": "member"})
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_header_is(admin.TokenExchangeHeader, "/api/token")
+	})
+
+	t.Run("authenticates an access token without projection lookups", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.access_tokens_are_enabled()
+		tc.request_with_access_token("acct-1", map[string]string{"realm-1": "admin"}, nil)
+		tc.request_has_realm_header("realm-1")
+		tc.store_returns_error()
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.next_handler_was_called()
+		tc.context_has_account_id("acct-1")
+		tc.context_has_realm_id("realm-1")
+		tc.context_has_role("admin")
+		tc.response_header_is(admin.TokenExchangeHeader, "")
+	})
+
+	t.Run("caps an access token's role at its PAT's max role", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.access_tokens_are_enabled()
+		tc.request_with_access_token("acct-1", map[string]string{"realm-1": "admin"}, &domain.PATScopes{MaxRole: "viewer"})
+		tc.request_has_realm_header("realm-1")
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.context_has_role("viewer")
+	})
+
+	t.Run("records the authenticated account as the actor", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.access_tokens_are_enabled()
+		tc.request_with_access_token("acct-1", map[string]string{"realm-1": "admin"}, nil)
+		tc.request_has_realm_header("realm-1")
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.context_has_actor("acct-1")
+	})
+
+	t.Run("records rejected tokens in the security log", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.access_tokens_are_enabled()
+		tc.security_log_is_enabled()
+		tc.request_with_access_token("acct-1", map[string]string{"realm-1": "admin"}, nil)
+		tc.request_has_realm_header("realm-2")
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+		tc.security_log_has(admin.ActionAuthorizationDenied)
+	})
+
+	t.Run("collapses a burst of rejected tokens into one security log entry", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.security_log_is_enabled()
+		tc.request_with_bearer_token("junk")
+		tc.request_has_realm_header("realm-1")
+
+		// When
+		for range 100 {
+			tc.middleware_is_invoked()
+		}
+
+		// Then
+		tc.status_is(http.StatusUnauthorized)
+		tc.security_log_has(admin.ActionAuthenticationFailed)
+		tc.security_log_entry_count_is(100)
+	})
+
+	t.Run("returns 403 for an access token of a suspended account", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.store_has_token_revocation("account", "acct-1")
+		tc.access_tokens_are_enabled()
+		tc.request_with_access_token("acct-1", map[string]string{"realm-1": "admin"}, nil)
+		tc.request_has_realm_header("realm-1")
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+		tc.next_handler_was_not_called()
+	})
+
+	t.Run("returns 401 for an access token of a revoked PAT", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.store_has_token_revocation("pat", "pat-test-123")
+		tc.access_tokens_are_enabled()
+		tc.request_with_access_token("acct-1", map[string]string{"realm-1": "admin"}, nil)
+		tc.request_has_realm_header("realm-1")
+
+		// When
+		tc.middleware_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusUnauthorized)
+		tc.next_handler_was_not_called()
+	})
 }
 
 func TestRequireRealm(t *testing.T) {
@@ -349,6 +685,86 @@ func TestRequireRealm(t *testing.T) {
 	})
 }
 
+func TestAuthorizationDenials(t *testing.T) {
+	t.Run("RequireRealm records the denial in the security log", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.security_log_is_enabled()
+		tc.context_with_realm_id("_admin")
+		tc.request_carries_security_log()
+
+		// When
+		tc.require_realm_is_invoked()
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+		tc.security_log_has(admin.ActionAuthorizationDenied)
+	})
+
+	t.Run("RequireRole records the denial in the security log", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.security_log_is_enabled()
+		tc.context_with_role("viewer")
+		tc.request_carries_security_log()
+
+		// When
+		tc.require_role_is_invoked("member")
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+		tc.security_log_has(admin.ActionAuthorizationDenied)
+	})
+
+	t.Run("RequirePermission records the denial in the security log", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.security_log_is_enabled()
+		tc.context_with_role("viewer")
+		tc.request_carries_security_log()
+
+		// When
+		tc.require_permission_is_invoked(domain.PermCreateRune)
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+		tc.next_handler_was_not_called()
+		tc.security_log_has(admin.ActionAuthorizationDenied)
+	})
+
+	t.Run("RequirePermission lets a granted permission through without logging", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.security_log_is_enabled()
+		tc.context_with_role("member")
+		tc.request_carries_security_log()
+
+		// When
+		tc.require_permission_is_invoked(domain.PermCreateRune)
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.security_log_is_empty()
+	})
+
+	t.Run("denials without a security log still return 403", func(t *testing.T) {
+		tc := newTestContext(t)
+
+		// Given
+		tc.context_with_role("viewer")
+
+		// When
+		tc.require_permission_is_invoked(domain.PermCreateRune)
+
+		// Then
+		tc.status_is(http.StatusForbidden)
+	})
+}
+
 func TestRoleFromContext(t *testing.T) {
 	t.Run("returns role when present in context", func(t *testing.T) {
 		ctx := context.WithValue(context.Background(), roleKey, "admin")
@@ -644,7 +1060,8 @@ type testContext struct {
 	keyHash string
 
 	// Dependencies
-	store *mockProjectionStore
+	store      *mockProjectionStore
+	authConfig *AuthConfig
 
 	// HTTP
 	request  *http.Request
@@ -765,6 +1182,40 @@ func (tc *testContext) store_has_account_with_roles(accountID, username, status
 	tc.store.put("_admin", "account_auth", accountID, entry)
 }
 
+func (tc *testContext) pat_expires_at(expiresAt time.Time) {
+	tc.t.Helper()
+	patEntry := map[string]any{
+		"pat_id":     "pat-test-123",
+		"key_hash":   tc.keyHash,
+		"account_id": "acct-1",
+		"expires_at": expiresAt,
+	}
+	tc.store.put("_admin", "pat_by_id", "pat-test-123", patEntry)
+}
+
+func (tc *testContext) pat_has_scopes(scopes domain.PATScopes) {
+	tc.t.Helper()
+	patEntry := map[string]any{
+		"pat_id":     "pat-test-123",
+		"key_hash":   tc.keyHash,
+		"account_id": "acct-1",
+		"scopes":     scopes,
+	}
+	tc.store.put("_admin", "pat_by_id", "pat-test-123", patEntry)
+}
+
+func (tc *testContext) request_path_is(path string) {
+	tc.t.Helper()
+	tc.request = httptest.NewRequest(http.MethodPost, path, nil)
+}
+
+func (tc *testContext) pat_usage_is_recorded() {
+	tc.t.Helper()
+	tc.authConfig = &AuthConfig{AdminAuthConfig: &admin.AuthConfig{
+		Usage: admin.NewPATUsageRecorder(tc.store, time.Minute),
+	}}
+}
+
 func (tc *testContext) store_returns_error() {
 	tc.t.Helper()
 	tc.store.forceError = true
@@ -805,6 +1256,64 @@ func (tc *testContext) context_with_role(role string) {
 	tc.request = tc.request.WithContext(ctx)
 }
 
+func (tc *testContext) access_tokens_are_enabled() {
+	tc.t.Helper()
+	revocations := admin.NewRevocationList(tc.store, time.Minute)
+	require.NoError(tc.t, revocations.Refresh(context.Background()))
+	tc.authConfig = &AuthConfig{AdminAuthConfig: &admin.AuthConfig{
+		SigningKey:  []byte("test-signing-key-that-is-32-byte"),
+		Revocations: revocations,
+	}}
+}
+
+func (tc *testContext) session_cookies_are_enabled() {
+	tc.t.Helper()
+	tc.authConfig = &AuthConfig{AdminAuthConfig: &admin.AuthConfig{
+		SigningKey:  []byte("test-signing-key-that-is-32-byte"),
+		CookieName:  "bifrost_session",
+		TokenExpiry: time.Hour,
+	}}
+}
+
+func (tc *testContext) request_with_session_cookie(accountID string) {
+	tc.t.Helper()
+	token, err := admin.GenerateJWT(tc.authConfig.AdminAuthConfig, accountID, "pat-test-123")
+	require.NoError(tc.t, err)
+	if tc.request == nil {
+		tc.request = httptest.NewRequest(http.MethodGet, "/test", nil)
+	}
+	tc.request.AddCookie(&http.Cookie{Name: tc.authConfig.AdminAuthConfig.CookieName, Value: token})
+}
+
+func (tc *testContext) request_with_access_token(accountID string, roles map[string]string, scopes *domain.PATScopes) {
+	tc.t.Helper()
+	entry := &projectors.AccountAuthEntry{AccountID: accountID, Username: "alice", Status: "active", Roles: roles}
+	token, _, err := admin.GenerateAccessToken(tc.authConfig.AdminAuthConfig, entry, "pat-test-123", scopes, nil)
+	require.NoError(tc.t, err)
+	tc.request_with_bearer_token(token)
+}
+
+func (tc *testContext) store_has_token_revocation(kind, id string) {
+	tc.t.Helper()
+	tc.store.put("_admin", projectors.TokenRevocationsTable.Name, projectors.TokenRevocationKey(kind, id), projectors.TokenRevocationEntry{Kind: kind, ID: id})
+}
+
+func (tc *testContext) security_log_is_enabled() {
+	tc.t.Helper()
+	if tc.authConfig == nil {
+		tc.authConfig = &AuthConfig{AdminAuthConfig: &admin.AuthConfig{}}
+	}
+	tc.authConfig.AdminAuthConfig.SecurityLog = admin.NewSecurityLog(tc.store, time.Minute)
+}
+
+// request_carries_security_log puts the security log in the request context,
+// as AuthMiddleware does.
+func (tc *testContext) request_carries_security_log() {
+	tc.t.Helper()
+	ctx := context.WithValue(tc.request.Context(), securityLogKey, tc.authConfig.securityLog())
+	tc.request = tc.request.WithContext(ctx)
+}
+
 // --- When ---
 
 func (tc *testContext) middleware_is_invoked() {
@@ -817,7 +1326,7 @@ func (tc *testContext) middleware_is_invoked() {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	middleware := AuthMiddleware(tc.store, nil)
+	middleware := AuthMiddleware(tc.store, tc.authConfig)
 	handler := middleware(next)
 	handler.ServeHTTP(tc.recorder, tc.request)
 }
@@ -836,6 +1345,20 @@ func (tc *testContext) require_realm_is_invoked() {
 	middleware.ServeHTTP(tc.recorder, tc.request)
 }
 
+func (tc *testContext) require_permission_is_invoked(perm string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.request, "request must be set before invoking middleware")
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.nextCalled = true
+		tc.capturedCtx = r.Context()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	middleware := RequirePermission(tc.store, perm)(next)
+	middleware.ServeHTTP(tc.recorder, tc.request)
+}
+
 func (tc *testContext) require_role_is_invoked(minRole string) {
 	tc.t.Helper()
 	require.NotNil(tc.t, tc.request, "request must be set before invoking middleware")
@@ -883,6 +1406,37 @@ func (tc *testContext) context_has_account_id(expected string) {
 	assert.Equal(tc.t, expected, id)
 }
 
+func (tc *testContext) context_has_actor(expected string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.capturedCtx, "next handler was not called, no context captured")
+	actorID, ok := core.ActorFromContext(tc.capturedCtx)
+	assert.True(tc.t, ok, "expected actor in context")
+	assert.Equal(tc.t, expected, actorID)
+}
+
+func (tc *testContext) security_log_has(action string) {
+	tc.t.Helper()
+	entries, err := tc.authConfig.AdminAuthConfig.SecurityLog.Entries(context.Background())
+	require.NoError(tc.t, err)
+	require.Len(tc.t, entries, 1)
+	assert.Equal(tc.t, action, entries[0].Action)
+}
+
+func (tc *testContext) security_log_entry_count_is(expected int) {
+	tc.t.Helper()
+	entries, err := tc.authConfig.AdminAuthConfig.SecurityLog.Entries(context.Background())
+	require.NoError(tc.t, err)
+	require.Len(tc.t, entries, 1)
+	assert.Equal(tc.t, expected, entries[0].Count)
+}
+
+func (tc *testContext) security_log_is_empty() {
+	tc.t.Helper()
+	entries, err := tc.authConfig.AdminAuthConfig.SecurityLog.Entries(context.Background())
+	require.NoError(tc.t, err)
+	assert.Empty(tc.t, entries)
+}
+
 func (tc *testContext) context_has_role(expected string) {
 	tc.t.Helper()
 	require.NotNil(tc.t, tc.capturedCtx, "next handler was not called, no context captured")
@@ -891,6 +1445,25 @@ func (tc *testContext) context_has_role(expected string) {
 	assert.Equal(tc.t, expected, role)
 }
 
+func (tc *testContext) context_has_pat_scopes(expected *domain.PATScopes) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.capturedCtx, "next handler was not called, no context captured")
+	scopes, _ := tc.capturedCtx.Value(patScopesKey).(*domain.PATScopes)
+	assert.Equal(tc.t, expected, scopes)
+}
+
+func (tc *testContext) pat_usage_count_is(expected int64) {
+	tc.t.Helper()
+	usage, _, err := tc.authConfig.AdminAuthConfig.Usage.Usage(context.Background(), "pat-test-123")
+	require.NoError(tc.t, err)
+	assert.Equal(tc.t, expected, usage.RequestCount)
+}
+
+func (tc *testContext) response_header_is(key, expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.recorder.Header().Get(key))
+}
+
 func (tc *testContext) response_body_contains(substring string) {
 	tc.t.Helper()
 	assert.Contains(tc.t, tc.recorder.Body.String(), substring)
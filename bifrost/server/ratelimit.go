@@ -0,0 +1,201 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/devzeebo/bifrost/server/admin"
+)
+
+// RateLimitConfig sets the token-bucket budgets for API requests. Every PAT
+// (or, for UI sessions, account) has its own read and write bucket in each
+// realm, and all callers in a realm share the realm's read and write bucket.
+// Requests to the admin routes, which may come before authentication, use
+// the per-token budgets keyed by client IP. Rates are requests per second and
+// bursts are bucket sizes; a zero rate disables that budget.
+type RateLimitConfig struct {
+	ReadRate        float64 `yaml:"read_rate"`
+	ReadBurst       int     `yaml:"read_burst"`
+	WriteRate       float64 `yaml:"write_rate"`
+	WriteBurst      int     `yaml:"write_burst"`
+	RealmReadRate   float64 `yaml:"realm_read_rate"`
+	RealmReadBurst  int     `yaml:"realm_read_burst"`
+	RealmWriteRate  float64 `yaml:"realm_write_rate"`
+	RealmWriteBurst int     `yaml:"realm_write_burst"`
+}
+
+// DefaultRateLimitConfig returns budgets that leave interactive use and
+// bf orchestrate polling untouched while stopping runaway loops. A realm
+// admits about five busy clients at their full rate.
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		ReadRate:        20,
+		ReadBurst:       100,
+		WriteRate:       5,
+		WriteBurst:      25,
+		RealmReadRate:   100,
+		RealmReadBurst:  500,
+		RealmWriteRate:  25,
+		RealmWriteBurst: 125,
+	}
+}
+
+// rateLimitIdleTTL is how long an unused bucket is kept. A bucket idle this
+// long has refilled at any practical rate, so dropping it loses nothing.
+const rateLimitIdleTTL = 10 * time.Minute
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// bucketLimit names a bucket and the budget it refills at.
+type bucketLimit struct {
+	key   string
+	rate  float64
+	burst int
+}
+
+// RateLimiter enforces RateLimitConfig. A nil limiter allows every request.
+type RateLimiter struct {
+	cfg RateLimitConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a rate limiter, or returns nil when cfg disables
+// every budget.
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	if cfg.ReadRate <= 0 && cfg.WriteRate <= 0 && cfg.RealmReadRate <= 0 && cfg.RealmWriteRate <= 0 {
+		return nil
+	}
+	return &RateLimiter{
+		cfg:     cfg,
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Middleware returns HTTP middleware that rejects requests over budget with
+// 429 and a Retry-After header. It must run after authentication, which
+// supplies the token and realm the budget is keyed by. GET and HEAD requests
+// draw on the read budgets; everything else on the write budgets. A request
+// must fit both its token's budget and the realm's.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		read := r.Method == http.MethodGet || r.Method == http.MethodHead
+		class, rate, burst, realmRate, realmBurst := "write", l.cfg.WriteRate, l.cfg.WriteBurst, l.cfg.RealmWriteRate, l.cfg.RealmWriteBurst
+		if read {
+			class, rate, burst, realmRate, realmBurst = "read", l.cfg.ReadRate, l.cfg.ReadBurst, l.cfg.RealmReadRate, l.cfg.RealmReadBurst
+		}
+
+		realmID, _ := RealmIDFromContext(r.Context())
+		var limits []bucketLimit
+		if rate > 0 {
+			limits = append(limits, bucketLimit{key: rateLimitSubject(r) + "|" + realmID + "|" + class, rate: rate, burst: burst})
+		}
+		if realmRate > 0 {
+			limits = append(limits, bucketLimit{key: "realm:" + realmID + "|" + class, rate: realmRate, burst: realmBurst})
+		}
+		l.serve(w, r, next, limits)
+	})
+}
+
+// ClientIPMiddleware returns HTTP middleware that applies the per-token
+// budgets to each client IP. It runs before authentication, for routes such
+// as sign-in and token exchange that must be limited before a token is known.
+func (l *RateLimiter) ClientIPMiddleware(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class, rate, burst := "write", l.cfg.WriteRate, l.cfg.WriteBurst
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			class, rate, burst = "read", l.cfg.ReadRate, l.cfg.ReadBurst
+		}
+		var limits []bucketLimit
+		if rate > 0 {
+			limits = append(limits, bucketLimit{key: "ip:" + admin.ClientIP(r) + "|" + class, rate: rate, burst: burst})
+		}
+		l.serve(w, r, next, limits)
+	})
+}
+
+// serve passes the request to next if every bucket in limits has a token,
+// and otherwise rejects it with 429.
+func (l *RateLimiter) serve(w http.ResponseWriter, r *http.Request, next http.Handler, limits []bucketLimit) {
+	if len(limits) > 0 {
+		if wait, ok := l.take(limits...); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+	}
+	next.ServeHTTP(w, r)
+}
+
+// rateLimitSubject names who a request is charged to: its PAT, or its
+// account for sessions that carry none.
+func rateLimitSubject(r *http.Request) string {
+	if patID, _ := r.Context().Value(patIDKey).(string); patID != "" {
+		return "pat:" + patID
+	}
+	accountID, _ := AccountIDFromContext(r.Context())
+	return "account:" + accountID
+}
+
+// take removes a token from each bucket in limits, or from none of them
+// when any is empty. It then reports how long until every bucket has a token.
+func (l *RateLimiter) take(limits ...bucketLimit) (time.Duration, bool) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	buckets := make([]*tokenBucket, len(limits))
+	var wait time.Duration
+	for i, limit := range limits {
+		burst := max(limit.burst, 1)
+		b, ok := l.buckets[limit.key]
+		if !ok {
+			b = &tokenBucket{tokens: float64(burst), last: now}
+			l.buckets[limit.key] = b
+		}
+		b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*limit.rate)
+		b.last = now
+		if b.tokens < 1 {
+			wait = max(wait, time.Duration((1-b.tokens)/limit.rate*float64(time.Second)))
+		}
+		buckets[i] = b
+	}
+	if wait > 0 {
+		return wait, false
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return 0, true
+}
+
+// sweep drops idle buckets at most once per idle TTL. Callers hold l.mu.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitIdleTTL {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= rateLimitIdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
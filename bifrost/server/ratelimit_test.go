@@ -0,0 +1,299 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/devzeebo/bifrost/server/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("allows requests within the burst", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{ReadRate: 1, ReadBurst: 3})
+
+		// When
+		tc.requests_are_made(http.MethodGet, "pat-1", "realm-1", 3)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusOK, http.StatusOK)
+	})
+
+	t.Run("returns 429 with Retry-After once the bucket is empty", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 0.5, WriteBurst: 1})
+
+		// When
+		tc.requests_are_made(http.MethodPost, "pat-1", "realm-1", 2)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusTooManyRequests)
+		tc.last_retry_after_is("2")
+	})
+
+	t.Run("refills the bucket over time", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 1, WriteBurst: 1})
+		tc.requests_are_made(http.MethodPost, "pat-1", "realm-1", 1)
+
+		// When
+		tc.time_passes(time.Second)
+		tc.requests_are_made(http.MethodPost, "pat-1", "realm-1", 1)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusOK)
+	})
+
+	t.Run("keeps separate budgets for reads and writes", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{ReadRate: 1, ReadBurst: 1, WriteRate: 1, WriteBurst: 1})
+
+		// When
+		tc.requests_are_made(http.MethodPost, "pat-1", "realm-1", 1)
+		tc.requests_are_made(http.MethodGet, "pat-1", "realm-1", 1)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusOK)
+	})
+
+	t.Run("keeps separate budgets per token and realm", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 1, WriteBurst: 1})
+
+		// When
+		tc.requests_are_made(http.MethodPost, "pat-1", "realm-1", 1)
+		tc.requests_are_made(http.MethodPost, "pat-2", "realm-1", 1)
+		tc.requests_are_made(http.MethodPost, "pat-1", "realm-2", 1)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusOK, http.StatusOK)
+	})
+
+	t.Run("a zero rate leaves that budget unlimited", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 1, WriteBurst: 1})
+
+		// When
+		tc.requests_are_made(http.MethodGet, "pat-1", "realm-1", 5)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK)
+	})
+
+	t.Run("is disabled when both rates are zero", func(t *testing.T) {
+		assert.Nil(t, NewRateLimiter(RateLimitConfig{}))
+	})
+
+	t.Run("shares the realm budget between tokens", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 10, WriteBurst: 10, RealmWriteRate: 1, RealmWriteBurst: 1})
+
+		// When
+		tc.requests_are_made(http.MethodPost, "pat-1", "realm-1", 1)
+		tc.requests_are_made(http.MethodPost, "pat-2", "realm-1", 1)
+		tc.requests_are_made(http.MethodPost, "pat-2", "realm-2", 1)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusTooManyRequests, http.StatusOK)
+	})
+
+	t.Run("does not charge the token when the realm budget is spent", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 0.001, WriteBurst: 1, RealmWriteRate: 1, RealmWriteBurst: 1})
+		tc.requests_are_made(http.MethodPost, "pat-1", "realm-1", 1)
+		tc.requests_are_made(http.MethodPost, "pat-2", "realm-1", 1)
+
+		// When
+		tc.time_passes(time.Second)
+		tc.requests_are_made(http.MethodPost, "pat-2", "realm-1", 1)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusTooManyRequests, http.StatusOK)
+	})
+
+	t.Run("enforces the realm budget without a token budget", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{RealmReadRate: 1, RealmReadBurst: 2})
+
+		// When
+		tc.requests_are_made(http.MethodGet, "pat-1", "realm-1", 1)
+		tc.requests_are_made(http.MethodGet, "pat-2", "realm-1", 2)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusOK, http.StatusTooManyRequests)
+	})
+}
+
+func TestRateLimiterClientIP(t *testing.T) {
+	t.Run("keeps a budget per client IP", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 1, WriteBurst: 1})
+
+		// When
+		tc.requests_from_ip_are_made(http.MethodPost, "/api/ui/login", "10.0.0.1", 2)
+		tc.requests_from_ip_are_made(http.MethodPost, "/api/ui/login", "10.0.0.2", 1)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusTooManyRequests, http.StatusOK)
+	})
+
+	t.Run("limits admin routes before they authenticate", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 1, WriteBurst: 1})
+		tc.admin_routes_are_registered()
+
+		// When
+		tc.requests_from_ip_are_made(http.MethodPost, "/api/token", "10.0.0.1", 2)
+
+		// Then
+		tc.statuses_are(http.StatusUnauthorized, http.StatusTooManyRequests)
+	})
+
+	t.Run("leaves routes registered on the server mux to their own limits", func(t *testing.T) {
+		tc := newRateLimitTestContext(t)
+
+		// Given
+		tc.rate_limiter(RateLimitConfig{WriteRate: 1, WriteBurst: 1})
+		tc.admin_routes_are_registered()
+
+		// When
+		tc.requests_from_ip_are_made(http.MethodPost, "/api/create-rune", "10.0.0.1", 2)
+
+		// Then
+		tc.statuses_are(http.StatusOK, http.StatusOK)
+	})
+}
+
+// --- Test Context ---
+
+type rateLimitTestContext struct {
+	t *testing.T
+
+	limiter  *RateLimiter
+	mux      *http.ServeMux
+	now      time.Time
+	statuses []int
+	last     *httptest.ResponseRecorder
+}
+
+func newRateLimitTestContext(t *testing.T) *rateLimitTestContext {
+	t.Helper()
+	return &rateLimitTestContext{
+		t:   t,
+		now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// --- Given ---
+
+func (tc *rateLimitTestContext) rate_limiter(cfg RateLimitConfig) {
+	tc.t.Helper()
+	tc.limiter = NewRateLimiter(cfg)
+	require.NotNil(tc.t, tc.limiter)
+	tc.limiter.now = func() time.Time { return tc.now }
+}
+
+// admin_routes_are_registered mounts the admin routes behind the client IP
+// limiter, next to a server route, as main does.
+func (tc *rateLimitTestContext) admin_routes_are_registered() {
+	tc.t.Helper()
+	tc.mux = http.NewServeMux()
+	tc.mux.HandleFunc("POST /api/create-rune", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	authCfg := admin.DefaultAuthConfig()
+	authCfg.SigningKey = []byte("test-signing-key-that-is-32-byte")
+	_, err := admin.RegisterRoutes(tc.mux, &admin.RouteConfig{
+		AuthConfig:      authCfg,
+		ProjectionStore: newMockProjectionStore(),
+		RateLimit:       tc.limiter.ClientIPMiddleware,
+		UIFS:            fstest.MapFS{},
+	})
+	require.NoError(tc.t, err)
+}
+
+func (tc *rateLimitTestContext) time_passes(d time.Duration) {
+	tc.t.Helper()
+	tc.now = tc.now.Add(d)
+}
+
+// --- When ---
+
+func (tc *rateLimitTestContext) requests_are_made(method, patID, realmID string, count int) {
+	tc.t.Helper()
+	handler := tc.limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	for range count {
+		req := httptest.NewRequest(method, "/api/test", nil)
+		ctx := context.WithValue(req.Context(), patIDKey, patID)
+		ctx = context.WithValue(ctx, realmIDKey, realmID)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(ctx))
+		tc.statuses = append(tc.statuses, rec.Code)
+		tc.last = rec
+	}
+}
+
+// requests_from_ip_are_made sends unauthenticated requests from ip, through
+// the registered routes if there are any and the client IP limiter if not.
+func (tc *rateLimitTestContext) requests_from_ip_are_made(method, path, ip string, count int) {
+	tc.t.Helper()
+	var handler http.Handler = tc.mux
+	if tc.mux == nil {
+		handler = tc.limiter.ClientIPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+	for range count {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":40000"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		tc.statuses = append(tc.statuses, rec.Code)
+		tc.last = rec
+	}
+}
+
+// --- Then ---
+
+func (tc *rateLimitTestContext) statuses_are(expected ...int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.statuses)
+}
+
+func (tc *rateLimitTestContext) last_retry_after_is(expected string) {
+	tc.t.Helper()
+	require.NotNil(tc.t, tc.last)
+	assert.Equal(tc.t, expected, tc.last.Header().Get("Retry-After"))
+}
@@ -0,0 +1,556 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devzeebo/bifrost/core"
+	"github.com/devzeebo/bifrost/domain"
+	"github.com/devzeebo/bifrost/domain/projectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tests ---
+
+func TestWebhookDispatcher(t *testing.T) {
+	t.Run("delivers a subscribed event with a valid signature", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusOK)
+		tc.a_webhook("wh-1", []string{domain.EventRuneFulfilled}, nil)
+		tc.rune_event(domain.EventRuneFulfilled, "bf-a1b2")
+
+		// When
+		tc.dispatch()
+
+		// Then
+		tc.received_count_is(1)
+		tc.received_event_type_is(0, domain.EventRuneFulfilled)
+		tc.received_signature_is_valid(0)
+		tc.received_payload_has_rune(0, "bf-a1b2")
+		tc.delivery_status_is("wh-1", domain.EventRuneFulfilled, WebhookDeliveryDelivered)
+	})
+
+	t.Run("skips event types the webhook did not subscribe to", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusOK)
+		tc.a_webhook("wh-1", []string{domain.EventRuneFulfilled}, nil)
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+
+		// When
+		tc.dispatch()
+
+		// Then
+		tc.received_count_is(0)
+	})
+
+	t.Run("filters by the rune's tags", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusOK)
+		tc.a_webhook("wh-1", nil, []string{"release"})
+		tc.rune_has_tags("bf-a1b2", "release")
+		tc.rune_has_tags("bf-c3d4", "backend")
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+		tc.rune_event(domain.EventRuneClaimed, "bf-c3d4")
+
+		// When
+		tc.dispatch()
+
+		// Then
+		tc.received_count_is(1)
+		tc.received_payload_has_rune(0, "bf-a1b2")
+	})
+
+	t.Run("does not deliver events from before the webhook was added", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusOK)
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+		tc.a_webhook("wh-1", nil, nil)
+
+		// When
+		tc.dispatch()
+
+		// Then
+		tc.received_count_is(0)
+	})
+
+	t.Run("delivers each event once", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusOK)
+		tc.a_webhook("wh-1", nil, nil)
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+		tc.dispatch()
+
+		// When
+		tc.dispatch()
+
+		// Then
+		tc.received_count_is(1)
+	})
+
+	t.Run("retries a failed delivery with exponential backoff", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK)
+		tc.a_webhook("wh-1", nil, nil)
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+		tc.dispatch()
+
+		// When
+		tc.time_passes(5 * time.Second)
+		tc.dispatch()
+		tc.time_passes(5 * time.Second)
+		tc.dispatch()
+		tc.time_passes(20 * time.Second)
+		tc.dispatch()
+
+		// Then
+		tc.received_count_is(3)
+		tc.delivery_status_is("wh-1", domain.EventRuneClaimed, WebhookDeliveryDelivered)
+		tc.delivery_attempts_are("wh-1", domain.EventRuneClaimed, 3)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusInternalServerError)
+		tc.a_webhook("wh-1", nil, nil)
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+
+		// When
+		for range webhookMaxAttempts + 2 {
+			tc.dispatch()
+			tc.time_passes(time.Hour)
+		}
+
+		// Then
+		tc.received_count_is(webhookMaxAttempts)
+		tc.delivery_status_is("wh-1", domain.EventRuneClaimed, WebhookDeliveryFailed)
+	})
+
+	t.Run("refuses to connect to a non-public address", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusOK)
+		tc.a_webhook("wh-1", nil, nil)
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+		tc.dispatcher.SetAllowedHosts(nil)
+
+		// When
+		tc.dispatch()
+
+		// Then
+		tc.received_count_is(0)
+		tc.delivery_status_is("wh-1", domain.EventRuneClaimed, WebhookDeliveryPending)
+		tc.delivery_error_contains("wh-1", domain.EventRuneClaimed, "not a public address")
+	})
+
+	t.Run("sends to several webhooks in one cycle", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusOK)
+		for i := range webhookWorkers + 2 {
+			tc.a_webhook(fmt.Sprintf("wh-%d", i), nil, nil)
+		}
+		tc.rune_event(domain.EventRuneClaimed, "bf-a1b2")
+
+		// When
+		tc.dispatch()
+
+		// Then
+		tc.received_count_is(webhookWorkers + 2)
+		tc.delivery_status_is("wh-0", domain.EventRuneClaimed, WebhookDeliveryDelivered)
+		tc.delivery_status_is("wh-9", domain.EventRuneClaimed, WebhookDeliveryDelivered)
+	})
+
+	t.Run("sends test deliveries without retrying", func(t *testing.T) {
+		tc := newWebhookTestContext(t)
+
+		// Given
+		tc.a_receiver(http.StatusOK)
+		tc.a_webhook("wh-1", nil, nil)
+
+		// When
+		delivery, err := tc.dispatcher.Test(context.Background(), "realm-1", "wh-1")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, WebhookDeliveryDelivered, delivery.Status)
+		tc.received_count_is(1)
+		tc.received_event_type_is(0, WebhookTestEvent)
+		tc.received_signature_is_valid(0)
+	})
+}
+
+func TestWebhookHandlers(t *testing.T) {
+	t.Run("lists webhooks without their secrets", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_has_webhook("realm-1", "wh-1")
+
+		// When
+		tc.get("/webhooks")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_body_contains(`"id":"wh-1"`)
+		assert.NotContains(t, tc.recorder.Body.String(), "secret")
+	})
+
+	t.Run("adds a webhook and returns its secret", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/add-webhook", map[string]any{"url": "https://ci.example.com/hook", "event_types": []string{"RuneFulfilled"}})
+
+		// Then
+		tc.status_is(http.StatusCreated)
+		tc.response_body_has_field("webhook_id")
+		tc.response_body_has_field("secret")
+		tc.event_was_appended("_admin", "realm-realm-1", domain.EventWebhookAdded)
+	})
+
+	t.Run("rejects a webhook for a private address", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_exists_in_event_store("realm-1")
+
+		// When
+		tc.post("/add-webhook", map[string]any{"url": "http://169.254.169.254/latest/meta-data"})
+
+		// Then
+		tc.status_is(http.StatusUnprocessableEntity)
+		tc.response_body_contains("public address")
+	})
+
+	t.Run("lists deliveries for one webhook, newest first", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+		tc.realm_has_delivery("realm-1", "whd-1", "wh-1", time.Hour)
+		tc.realm_has_delivery("realm-1", "whd-2", "wh-1", 2*time.Hour)
+		tc.realm_has_delivery("realm-1", "whd-3", "wh-2", 3*time.Hour)
+
+		// When
+		tc.get("/webhook-deliveries?webhook_id=wh-1")
+
+		// Then
+		tc.status_is(http.StatusOK)
+		tc.response_delivery_ids_are("whd-2", "whd-1")
+	})
+
+	t.Run("test-webhook is unavailable without a dispatcher", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.request_has_realm_id("realm-1")
+
+		// When
+		tc.post("/test-webhook", map[string]string{"webhook_id": "wh-1"})
+
+		// Then
+		tc.status_is(http.StatusServiceUnavailable)
+	})
+
+	t.Run("registers the webhook routes", func(t *testing.T) {
+		tc := newHandlerTestContext(t)
+
+		// Given
+		tc.handlers_configured()
+		tc.routes_are_registered()
+
+		// Then
+		tc.route_exists("GET", "/api/webhooks")
+		tc.route_exists("GET", "/api/webhook-deliveries")
+		tc.route_exists("POST", "/api/add-webhook")
+		tc.route_exists("POST", "/api/remove-webhook")
+		tc.route_exists("POST", "/api/test-webhook")
+	})
+}
+
+// --- Test Context ---
+
+type webhookTestContext struct {
+	t *testing.T
+
+	events      *webhookEventStore
+	projections *mockProjectionStore
+	checkpoints *webhookCheckpointStore
+	dispatcher  *WebhookDispatcher
+	now         time.Time
+
+	receiver *httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	received []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookTestContext(t *testing.T) *webhookTestContext {
+	t.Helper()
+	tc := &webhookTestContext{
+		t:           t,
+		events:      &webhookEventStore{},
+		projections: newMockProjectionStore(),
+		checkpoints: &webhookCheckpointStore{positions: map[string]int64{}},
+		now:         time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	tc.dispatcher = NewWebhookDispatcher(tc.events, tc.projections, tc.checkpoints, time.Second)
+	tc.dispatcher.now = func() time.Time { return tc.now }
+	// Test receivers listen on loopback
+	tc.dispatcher.SetAllowedHosts([]string{"127.0.0.1"})
+	return tc
+}
+
+// webhookEventStore is an append-only event log that supports ReadAll.
+type webhookEventStore struct {
+	mu     sync.Mutex
+	events []core.Event
+}
+
+func (s *webhookEventStore) append(event core.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	event.GlobalPosition = int64(len(s.events) + 1)
+	s.events = append(s.events, event)
+}
+
+func (s *webhookEventStore) Append(context.Context, string, string, int, []core.EventData) ([]core.Event, error) {
+	return nil, nil
+}
+
+func (s *webhookEventStore) ReadStream(context.Context, string, string, int) ([]core.Event, error) {
+	return nil, nil
+}
+
+func (s *webhookEventStore) ReadAll(_ context.Context, realmID string, from int64) ([]core.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []core.Event
+	for _, event := range s.events {
+		if event.RealmID == realmID && event.GlobalPosition > from {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *webhookEventStore) LatestPosition(_ context.Context, realmID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var position int64
+	for _, event := range s.events {
+		if event.RealmID == realmID {
+			position = event.GlobalPosition
+		}
+	}
+	return position, nil
+}
+
+func (s *webhookEventStore) ListRealmIDs(context.Context) ([]string, error) {
+	return []string{domain.AdminRealmID, "realm-1"}, nil
+}
+
+type webhookCheckpointStore struct {
+	positions map[string]int64
+}
+
+func (s *webhookCheckpointStore) GetCheckpoint(_ context.Context, realmID, name string) (int64, error) {
+	return s.positions[realmID+"/"+name], nil
+}
+
+func (s *webhookCheckpointStore) SetCheckpoint(_ context.Context, realmID, name string, position int64) error {
+	s.positions[realmID+"/"+name] = position
+	return nil
+}
+
+// --- Given ---
+
+// a_receiver starts a receiver answering with the given statuses in turn,
+// repeating the last one.
+func (tc *webhookTestContext) a_receiver(statuses ...int) {
+	tc.t.Helper()
+	tc.statuses = statuses
+	tc.receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		tc.mu.Lock()
+		status := tc.statuses[min(len(tc.received), len(tc.statuses)-1)]
+		tc.received = append(tc.received, r)
+		tc.bodies = append(tc.bodies, body)
+		tc.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	tc.t.Cleanup(tc.receiver.Close)
+}
+
+func (tc *webhookTestContext) a_webhook(id string, eventTypes, tags []string) {
+	tc.t.Helper()
+	webhooks, err := domain.ReadRealmWebhooks(context.Background(), "realm-1", tc.projections)
+	require.NoError(tc.t, err)
+	webhooks = domain.ApplyWebhookAdded(webhooks, domain.WebhookAdded{
+		RealmID: "realm-1", WebhookID: id, URL: tc.receiver.URL,
+		EventTypes: eventTypes, Tags: tags, Secret: "s3cret", CreatedAt: tc.now,
+	})
+	tc.projections.put(domain.AdminRealmID, projectors.RealmWebhooksTable.Name, "realm-1", webhooks)
+	tc.time_passes(time.Millisecond)
+}
+
+func (tc *webhookTestContext) rune_has_tags(runeID string, tags ...string) {
+	tc.t.Helper()
+	tc.projections.put("realm-1", projectors.RuneSummaryTable.Name, runeID, projectors.RuneSummary{ID: runeID, Tags: tags})
+}
+
+func (tc *webhookTestContext) rune_event(eventType, runeID string) {
+	tc.t.Helper()
+	data, err := json.Marshal(map[string]string{"id": runeID})
+	require.NoError(tc.t, err)
+	tc.events.append(core.Event{
+		RealmID:   "realm-1",
+		StreamID:  "rune-" + runeID,
+		EventType: eventType,
+		Data:      data,
+		Timestamp: tc.now,
+	})
+	tc.time_passes(time.Millisecond)
+}
+
+func (tc *webhookTestContext) time_passes(d time.Duration) {
+	tc.t.Helper()
+	tc.now = tc.now.Add(d)
+}
+
+// --- When ---
+
+func (tc *webhookTestContext) dispatch() {
+	tc.t.Helper()
+	tc.dispatcher.DispatchOnce(context.Background())
+}
+
+// --- Then ---
+
+func (tc *webhookTestContext) received_count_is(expected int) {
+	tc.t.Helper()
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	assert.Len(tc.t, tc.received, expected)
+}
+
+func (tc *webhookTestContext) received_event_type_is(i int, expected string) {
+	tc.t.Helper()
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	require.Greater(tc.t, len(tc.received), i)
+	assert.Equal(tc.t, expected, tc.received[i].Header.Get("X-Bifrost-Event"))
+}
+
+func (tc *webhookTestContext) received_signature_is_valid(i int) {
+	tc.t.Helper()
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	require.Greater(tc.t, len(tc.received), i)
+	assert.Equal(tc.t, SignWebhookPayload("s3cret", tc.bodies[i]), tc.received[i].Header.Get(webhookSignatureHeader))
+}
+
+func (tc *webhookTestContext) received_payload_has_rune(i int, runeID string) {
+	tc.t.Helper()
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	require.Greater(tc.t, len(tc.bodies), i)
+	var payload webhookPayload
+	require.NoError(tc.t, json.Unmarshal(tc.bodies[i], &payload))
+	assert.Equal(tc.t, runeID, payload.RuneID)
+	assert.Equal(tc.t, "realm-1", payload.RealmID)
+}
+
+func (tc *webhookTestContext) delivery(webhookID, eventType string) WebhookDelivery {
+	tc.t.Helper()
+	deliveries, err := readWebhookDeliveries(context.Background(), tc.projections, "realm-1")
+	require.NoError(tc.t, err)
+	for _, delivery := range deliveries {
+		if delivery.WebhookID == webhookID && delivery.EventType == eventType {
+			return delivery
+		}
+	}
+	tc.t.Fatalf("no %s delivery for %s", eventType, webhookID)
+	return WebhookDelivery{}
+}
+
+func (tc *webhookTestContext) delivery_status_is(webhookID, eventType, expected string) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.delivery(webhookID, eventType).Status)
+}
+
+func (tc *webhookTestContext) delivery_error_contains(webhookID, eventType, expected string) {
+	tc.t.Helper()
+	assert.Contains(tc.t, tc.delivery(webhookID, eventType).Error, expected)
+}
+
+func (tc *webhookTestContext) delivery_attempts_are(webhookID, eventType string, expected int) {
+	tc.t.Helper()
+	assert.Equal(tc.t, expected, tc.delivery(webhookID, eventType).Attempts)
+}
+
+// --- Handler helpers ---
+
+func (tc *handlerTestContext) realm_has_webhook(realmID, webhookID string) {
+	tc.t.Helper()
+	tc.projectionStore.put(domain.AdminRealmID, "realm_webhooks", realmID, domain.RealmWebhooks{
+		RealmID:  realmID,
+		Webhooks: []domain.Webhook{{ID: webhookID, URL: "https://ci.example.com/hook", Secret: "s3cret"}},
+	})
+}
+
+func (tc *handlerTestContext) realm_has_delivery(realmID, deliveryID, webhookID string, age time.Duration) {
+	tc.t.Helper()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tc.projectionStore.put(realmID, WebhookDeliveriesTable, deliveryID, WebhookDelivery{
+		ID: deliveryID, WebhookID: webhookID, RealmID: realmID, EventType: domain.EventRuneClaimed,
+		Status: WebhookDeliveryDelivered, CreatedAt: base.Add(age),
+	})
+}
+
+func (tc *handlerTestContext) response_delivery_ids_are(expected ...string) {
+	tc.t.Helper()
+	var deliveries []WebhookDelivery
+	require.NoError(tc.t, json.Unmarshal(tc.recorder.Body.Bytes(), &deliveries))
+	ids := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.ID)
+	}
+	assert.Equal(tc.t, expected, ids)
+}
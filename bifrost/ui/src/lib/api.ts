@@ -1,6 +1,7 @@
 import type {
   CreateAdminRequest,
   CreateAdminResponse,
+  DeviceLoginInfo,
   LoginRequest,
   OnboardingCheckResponse,
   SessionInfo,
@@ -178,6 +179,28 @@ export class ApiClient {
     });
   }
 
+  // Device login (bf login --web)
+  public async getDeviceLogin(userCode: string): Promise<DeviceLoginInfo> {
+    return this.request<DeviceLoginInfo>(
+      `/ui/device?user_code=${encodeURIComponent(userCode)}`,
+      { method: "GET" },
+    );
+  }
+
+  public async approveDeviceLogin(userCode: string): Promise<void> {
+    return this.request("/ui/device/approve", {
+      method: "POST",
+      body: JSON.stringify({ user_code: userCode }),
+    });
+  }
+
+  public async denyDeviceLogin(userCode: string): Promise<void> {
+    return this.request("/ui/device/deny", {
+      method: "POST",
+      body: JSON.stringify({ user_code: userCode }),
+    });
+  }
+
   // Onboarding
   public async createAdmin(request: CreateAdminRequest): Promise<CreateAdminResponse> {
     return this.request<CreateAdminResponse>("/ui/onboarding/create-admin?sync=true", {
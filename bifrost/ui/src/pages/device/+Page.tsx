@@ -0,0 +1,156 @@
+"use client";
+
+import { useEffect, useState } from "react";
+import { Button } from "@base-ui/react/button";
+import { Input } from "@base-ui/react/input";
+import { navigate } from "@/lib/router";
+import { useAuth } from "../../lib/auth";
+import { useToast } from "../../lib/toast";
+import { api } from "../../lib/api";
+import type { DeviceLoginInfo } from "../../types/session";
+
+type Outcome = "approved" | "denied" | null;
+
+const Page = () => {
+  const [userCode, setUserCode] = useState("");
+  const [login, setLogin] = useState<DeviceLoginInfo | null>(null);
+  const [outcome, setOutcome] = useState<Outcome>(null);
+  const [isBusy, setIsBusy] = useState(false);
+  const { isAuthenticated, loading: authLoading, username } = useAuth();
+  const { showToast } = useToast();
+
+  useEffect(() => {
+    if (authLoading) {
+      return;
+    }
+    if (!isAuthenticated) {
+      navigate("/login");
+      return;
+    }
+    const code = new URLSearchParams(window.location.search).get("code");
+    if (code) {
+      setUserCode(code);
+    }
+  }, [authLoading, isAuthenticated]);
+
+  const handleLookup = async (event: React.FormEvent) => {
+    event.preventDefault();
+    setIsBusy(true);
+    try {
+      setLogin(await api.getDeviceLogin(userCode.trim()));
+    } catch {
+      showToast("Error", "Code not found or expired", "error");
+    } finally {
+      setIsBusy(false);
+    }
+  };
+
+  const handleDecision = async (approve: boolean) => {
+    if (!login) {
+      return;
+    }
+    setIsBusy(true);
+    try {
+      if (approve) {
+        await api.approveDeviceLogin(login.user_code);
+      } else {
+        await api.denyDeviceLogin(login.user_code);
+      }
+      setOutcome(approve ? "approved" : "denied");
+    } catch {
+      showToast("Error", "Code not found or expired", "error");
+    } finally {
+      setIsBusy(false);
+    }
+  };
+
+  const buttonStyle = (color: string) => ({
+    backgroundColor: color,
+    border: "2px solid var(--color-border)",
+    color: "white",
+    boxShadow: "var(--shadow-soft)",
+    width: "100%",
+  });
+
+  const renderBody = () => {
+    if (outcome === "approved") {
+      return <p>Approved. Return to your terminal to finish signing in.</p>;
+    }
+    if (outcome === "denied") {
+      return <p>Denied. The terminal will not be signed in.</p>;
+    }
+    if (login) {
+      return (
+        <>
+          <p className="mb-2">
+            <strong>{login.label}</strong> wants to sign in as <strong>{username}</strong>.
+          </p>
+          <p className="mb-6">Only approve if you started this login and see the code {login.user_code} there.</p>
+          <div className="flex gap-4">
+            <Button
+              type="button"
+              disabled={isBusy}
+              onClick={() => handleDecision(true)}
+              style={buttonStyle("var(--color-blue)")}
+            >
+              Approve
+            </Button>
+            <Button
+              type="button"
+              disabled={isBusy}
+              onClick={() => handleDecision(false)}
+              style={buttonStyle("var(--color-red)")}
+            >
+              Deny
+            </Button>
+          </div>
+        </>
+      );
+    }
+    return (
+      <form onSubmit={handleLookup}>
+        <div className="mb-4">
+          <Input
+            value={userCode}
+            onChange={(event) => setUserCode(event.target.value)}
+            placeholder="XXXX-XXXX"
+            style={{
+              backgroundColor: "var(--color-bg)",
+              border: "2px solid var(--color-border)",
+              color: "var(--color-text)",
+            }}
+          />
+        </div>
+        <Button
+          type="submit"
+          disabled={isBusy || !userCode.trim()}
+          style={buttonStyle("var(--color-blue)")}
+        >
+          Continue
+        </Button>
+      </form>
+    );
+  };
+
+  if (authLoading) {
+    return null;
+  }
+
+  return (
+    <div className="min-h-[calc(100vh-56px)] flex items-center justify-center p-6">
+      <div
+        className="p-8 max-w-md w-full"
+        style={{
+          backgroundColor: "var(--color-bg)",
+          border: "2px solid var(--color-border)",
+          boxShadow: "var(--shadow-soft)",
+        }}
+      >
+        <h1 className="text-2xl font-bold mb-6 text-center">Approve CLI Login</h1>
+        {renderBody()}
+      </div>
+    </div>
+  );
+};
+
+export { Page };
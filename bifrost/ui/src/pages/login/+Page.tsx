@@ -13,6 +13,7 @@ const Page = () => {
   const [rememberMe, setRememberMe] = useState(false);
   const [isLoading, setIsLoading] = useState(false);
   const [isCheckingOnboarding, setIsCheckingOnboarding] = useState(true);
+  const [ssoEnabled, setSsoEnabled] = useState(false);
   const { login } = useAuth();
   const { showToast } = useToast();
 
@@ -23,6 +24,7 @@ const Page = () => {
         if (response.needs_onboarding) {
           navigate("/onboarding");
         } else {
+          setSsoEnabled(response.sso_enabled === true);
           setIsCheckingOnboarding(false);
         }
       } catch (error) {
@@ -150,6 +152,15 @@ const Page = () => {
             })()}
           </Button>
         </form>
+        {ssoEnabled && (
+          <a
+            href={`/api/ui/oidc/login${rememberMe ? "?remember_me=true" : ""}`}
+            className="block mt-4 text-center underline"
+            style={{ color: "var(--color-text)" }}
+          >
+            Sign in with SSO
+          </a>
+        )}
       </div>
     </div>
   );
@@ -14,6 +14,7 @@ export type LoginRequest = {
 
 export type OnboardingCheckResponse = {
   needs_onboarding: boolean;
+  sso_enabled?: boolean;
 };
 
 export type CreateAdminRequest = {
@@ -28,3 +29,9 @@ export type CreateAdminResponse = {
   pat: string;
   realm_id: string;
 };
+
+export type DeviceLoginInfo = {
+  user_code: string;
+  label: string;
+  expires_at: string;
+};